@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/theirongolddev/cburn/internal/cli"
+	"github.com/theirongolddev/cburn/internal/pipeline"
+
+	"github.com/spf13/cobra"
+)
+
+var analyzeCmd = &cobra.Command{
+	Use:   "analyze",
+	Short: "Find sessions with unusually high tokens per prompt or low cache hit rate",
+	RunE:  runAnalyze,
+}
+
+func init() {
+	rootCmd.AddCommand(analyzeCmd)
+}
+
+func runAnalyze(_ *cobra.Command, _ []string) error {
+	result, err := loadData()
+	if err != nil {
+		return err
+	}
+	if len(result.Sessions) == 0 {
+		fmt.Println("\n  No sessions found.")
+		return nil
+	}
+
+	filtered, since, until := applyFilters(result.Sessions)
+	base, outliers := pipeline.FindInefficientSessions(filtered, since, until)
+
+	fmt.Println()
+	fmt.Println(cli.RenderTitle("INEFFICIENT SESSIONS  " + periodLabel()))
+	fmt.Println()
+
+	if base.TokensPerPrompt == 0 {
+		fmt.Printf("  Not enough sessions to build a baseline (%d eligible).\n\n", base.Sessions)
+		return nil
+	}
+	fmt.Printf("  Baseline (median of %d sessions): %s tokens/prompt, %s cache hit rate\n\n",
+		base.Sessions, cli.FormatTokens(int64(base.TokensPerPrompt)), cli.FormatPercent(base.CacheHitRate))
+
+	if len(outliers) == 0 {
+		fmt.Println("  No outliers. Nice.")
+		fmt.Println()
+		return nil
+	}
+
+	rows := make([][]string, 0, len(outliers))
+	for _, e := range outliers {
+		var why []string
+		if e.HighTokens {
+			why = append(why, fmt.Sprintf("%.1fx tokens", e.TokensPerPrompt/base.TokensPerPrompt))
+		}
+		if e.LowCache {
+			why = append(why, "low cache")
+		}
+		rows = append(rows, []string{
+			e.Session.StartTime.Local().Format("Jan 02 15:04"),
+			truncate(e.Session.Project, 18),
+			cli.FormatNumber(int64(e.Session.UserMessages)),
+			cli.FormatTokens(int64(e.TokensPerPrompt)),
+			cli.FormatPercent(e.Session.CacheHitRate),
+			cli.FormatCost(e.Session.EstimatedCost),
+			strings.Join(why, ", "),
+		})
+	}
+
+	fmt.Print(cli.RenderTable(cli.Table{
+		Headers:  []string{"Started", "Project", "Prompts", "Tok/Prompt", "Cache", "Cost", "Why"},
+		Optional: []int{2, 4, 3},
+		Flex:     1,
+		Rows:     rows,
+	}))
+	return nil
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/browsercookie"
+	"github.com/theirongolddev/cburn/internal/claudeai"
+	"github.com/theirongolddev/cburn/internal/config"
+
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/x/term"
+	"github.com/spf13/cobra"
+)
+
+// sessionExpiryWarning is how far ahead of an imported key's expiry
+// `cburn status` starts suggesting a re-import.
+const sessionExpiryWarning = 3 * 24 * time.Hour
+
+var (
+	flagAuthBrowser string
+	flagAuthYes     bool
+)
+
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Manage the claude.ai session key",
+}
+
+var authImportBrowserCmd = &cobra.Command{
+	Use:   "import-browser",
+	Short: "Import the claude.ai session key from a local browser",
+	Long: "Reads the claude.ai sessionKey cookie from Chrome, Chromium, Brave, Edge, or Firefox\n" +
+		"cookie stores on this machine and saves it to the config file. You are asked before\n" +
+		"any cookie store is read; Chromium browsers may also ask for keyring access.\n" +
+		"Log in to claude.ai in the browser first.",
+	Args: cobra.NoArgs,
+	RunE: runAuthImportBrowser,
+}
+
+func init() {
+	authImportBrowserCmd.Flags().StringVar(&flagAuthBrowser, "browser", "", "Only read this browser (chrome, chromium, brave, edge, firefox)")
+	authImportBrowserCmd.Flags().BoolVarP(&flagAuthYes, "yes", "y", false, "Skip the confirmation prompt")
+	authCmd.AddCommand(authImportBrowserCmd)
+	rootCmd.AddCommand(authCmd)
+}
+
+func runAuthImportBrowser(_ *cobra.Command, _ []string) error {
+	stores := browsercookie.Discover()
+	if flagAuthBrowser != "" {
+		var filtered []browsercookie.Store
+		for _, s := range stores {
+			if strings.EqualFold(s.Browser, flagAuthBrowser) {
+				filtered = append(filtered, s)
+			}
+		}
+		stores = filtered
+	}
+	if len(stores) == 0 {
+		return errors.New("no supported browser cookie stores found")
+	}
+
+	fmt.Println()
+	fmt.Println("  cburn will read only the claude.ai sessionKey cookie from:")
+	for _, s := range stores {
+		fmt.Printf("    %-9s %s\n", s.Browser, s.Profile)
+	}
+	fmt.Println()
+	if !flagAuthYes {
+		if !term.IsTerminal(os.Stdin.Fd()) {
+			return errors.New("not a terminal; pass --yes to confirm reading browser cookies")
+		}
+		ok := false
+		if err := huh.NewConfirm().Title("Read these cookie stores?").Value(&ok).Run(); err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println("  Cancelled.")
+			return nil
+		}
+	}
+
+	now := time.Now()
+	var best browsercookie.Cookie
+	var problems []string
+	for _, s := range stores {
+		c, err := s.Read()
+		switch {
+		case errors.Is(err, browsercookie.ErrNotFound):
+			continue
+		case err != nil:
+			problems = append(problems, fmt.Sprintf("%s (%s): %v", s.Browser, s.Profile, err))
+			continue
+		case c.Expired(now) || claudeai.NewClient(c.Value) == nil:
+			continue
+		}
+		if best.Value == "" || c.Expires.After(best.Expires) {
+			best = c
+		}
+	}
+
+	if best.Value == "" {
+		for _, p := range problems {
+			fmt.Fprintf(os.Stderr, "  %s\n", p)
+		}
+		return errors.New("no valid claude.ai session cookie found; log in to claude.ai in your browser and retry")
+	}
+
+	// Make sure claude.ai still accepts it before replacing a working key.
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	if _, err := claudeai.NewClient(best.Value).FetchOrganizations(ctx); errors.Is(err, claudeai.ErrUnauthorized) {
+		return fmt.Errorf("claude.ai rejected the cookie from %s (%s); log in again in that browser", best.Store.Browser, best.Store.Profile)
+	}
+
+	cfg, _ := config.Load()
+	cfg.ClaudeAI.SessionKey = best.Value
+	cfg.ClaudeAI.SessionExpires = best.Expires
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("saving config: %w", err)
+	}
+
+	fmt.Printf("  Imported session key %s from %s (%s)\n", maskAPIKey(best.Value), best.Store.Browser, best.Store.Profile)
+	if !best.Expires.IsZero() {
+		fmt.Printf("  Expires %s\n", best.Expires.Local().Format("2006-01-02"))
+	}
+	if os.Getenv("CLAUDE_SESSION_KEY") != "" {
+		fmt.Println("  Note: CLAUDE_SESSION_KEY is set and takes precedence over the config file.")
+	}
+	fmt.Println()
+	return nil
+}
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/cli"
+	"github.com/theirongolddev/cburn/internal/model"
+	"github.com/theirongolddev/cburn/internal/pipeline"
+	"github.com/theirongolddev/cburn/internal/source"
+	"github.com/theirongolddev/cburn/internal/store"
+
+	"github.com/spf13/cobra"
+)
+
+var flagBenchRuns int
+
+var benchCmd = &cobra.Command{
+	Use:    "bench",
+	Short:  "Time each stage of loading your data dir",
+	Long:   "Times discovery, a full parse, a cold and a warm cache load (into a scratch\ncache, so yours is untouched), and aggregation, keeping the fastest of --runs.",
+	Hidden: true,
+	Args:   cobra.NoArgs,
+	RunE:   runBench,
+}
+
+func init() {
+	benchCmd.Flags().IntVar(&flagBenchRuns, "runs", 3, "Repeat each stage this many times and keep the fastest")
+	rootCmd.AddCommand(benchCmd)
+}
+
+// benchStage is one timed step of the load pipeline.
+type benchStage struct {
+	name   string
+	best   time.Duration
+	detail string
+}
+
+func (s *benchStage) record(d time.Duration, detail string) {
+	if s.best == 0 || d < s.best {
+		s.best = d
+	}
+	s.detail = detail
+}
+
+func runBench(_ *cobra.Command, _ []string) error {
+	if flagBenchRuns < 1 {
+		return errors.New("--runs must be at least 1")
+	}
+	roots := scanRoots()
+	scratch, err := os.MkdirTemp("", "cburn-bench-")
+	if err != nil {
+		return fmt.Errorf("creating scratch dir: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(scratch) }()
+
+	stages := []*benchStage{
+		{name: "Discover"},
+		{name: "Parse (no cache)"},
+		{name: "Cache build (cold)"},
+		{name: "Cache load (warm)"},
+		{name: "Aggregate"},
+	}
+	var sessions []model.SessionStats
+
+	for run := 0; run < flagBenchRuns; run++ {
+		if !flagQuiet {
+			fmt.Fprintf(os.Stderr, "\r  Run %d/%d...", run+1, flagBenchRuns)
+		}
+
+		start := time.Now()
+		files, err := source.ScanRoots(roots)
+		if err != nil {
+			return err
+		}
+		var bytes int64
+		for _, f := range files {
+			bytes += f.Size
+		}
+		stages[0].record(time.Since(start), fmt.Sprintf("%s files, %s", cli.FormatNumber(int64(len(files))), cli.FormatBytes(bytes)))
+
+		var parsed atomic.Int64
+		start = time.Now()
+		_, err = pipeline.LoadStream(roots, !flagNoSubagents, nil, func(model.SessionStats) { parsed.Add(1) })
+		if err != nil {
+			return err
+		}
+		d := time.Since(start)
+		stages[1].record(d, fmt.Sprintf("%s sessions, %s/s on %d workers",
+			cli.FormatNumber(parsed.Load()), cli.FormatBytes(int64(float64(bytes)/d.Seconds())), runtime.GOMAXPROCS(0)))
+
+		cache, err := store.Open(filepath.Join(scratch, fmt.Sprintf("run%d.db", run)))
+		if err != nil {
+			return fmt.Errorf("opening scratch cache: %w", err)
+		}
+		start = time.Now()
+		cr, err := pipeline.LoadWithCache(roots, !flagNoSubagents, cache, nil)
+		if err != nil {
+			_ = cache.Close()
+			return err
+		}
+		stages[2].record(time.Since(start), fmt.Sprintf("%s files parsed and saved", cli.FormatNumber(int64(cr.Reparsed))))
+
+		start = time.Now()
+		cr, err = pipeline.LoadWithCache(roots, !flagNoSubagents, cache, nil)
+		_ = cache.Close()
+		if err != nil {
+			return err
+		}
+		stages[3].record(time.Since(start), fmt.Sprintf("%s cache hits, %s reparsed",
+			cli.FormatNumber(int64(cr.CacheHits)), cli.FormatNumber(int64(cr.Reparsed))))
+		sessions = cr.Sessions
+
+		filtered, since, until := applyFilters(sessions)
+		start = time.Now()
+		pipeline.Aggregate(filtered, since, until)
+		pipeline.AggregateDays(filtered, since, until)
+		pipeline.AggregateModels(filtered, since, until)
+		pipeline.AggregateProjects(filtered, since, until)
+		stages[4].record(time.Since(start), fmt.Sprintf("%s sessions, %s", cli.FormatNumber(int64(len(filtered))), periodLabel()))
+	}
+	if !flagQuiet {
+		fmt.Fprintf(os.Stderr, "\r%*s\r", 20, "")
+	}
+
+	rows := make([][]string, 0, len(stages))
+	for _, s := range stages {
+		rows = append(rows, []string{s.name, formatBenchDuration(s.best), s.detail})
+	}
+
+	fmt.Println()
+	fmt.Println(cli.RenderTitle(fmt.Sprintf("LOAD BENCHMARK  best of %d", flagBenchRuns)))
+	fmt.Println()
+	fmt.Print(cli.RenderTable(cli.Table{
+		Headers: []string{"Stage", "Time", "Detail"},
+		Rows:    rows,
+		Flex:    2,
+	}))
+	fmt.Println()
+	return nil
+}
+
+// formatBenchDuration shows sub-second stage times in milliseconds.
+func formatBenchDuration(d time.Duration) string {
+	if d < time.Second {
+		return fmt.Sprintf("%.1fms", float64(d.Microseconds())/1000)
+	}
+	return fmt.Sprintf("%.2fs", d.Seconds())
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/theirongolddev/cburn/internal/cli"
+	"github.com/theirongolddev/cburn/internal/pipeline"
+
+	"github.com/spf13/cobra"
+)
+
+var branchesCmd = &cobra.Command{
+	Use:   "branches",
+	Short: "Cost by git repository and branch",
+	RunE:  runBranches,
+}
+
+func init() {
+	rootCmd.AddCommand(branchesCmd)
+}
+
+func runBranches(_ *cobra.Command, _ []string) error {
+	result, err := loadData()
+	if err != nil {
+		return err
+	}
+	if len(result.Sessions) == 0 {
+		fmt.Println("\n  No sessions found.")
+		return nil
+	}
+
+	filtered, since, until := applyFilters(result.Sessions)
+	branches := pipeline.AggregateBranches(filtered, since, until)
+
+	if len(branches) == 0 {
+		fmt.Println("\n  No branch data in the selected time range.")
+		return nil
+	}
+
+	fmt.Println()
+	fmt.Println(cli.RenderTitle("BRANCHES  " + periodLabel()))
+	fmt.Println()
+
+	rows := make([][]string, 0, len(branches))
+	for _, bs := range branches {
+		branch := bs.Branch
+		if branch == "" {
+			branch = "(unknown)"
+		}
+		rows = append(rows, []string{
+			truncate(bs.Repo, 18),
+			truncate(branch, 28),
+			cli.FormatNumber(int64(bs.Sessions)),
+			cli.FormatNumber(int64(bs.Prompts)),
+			cli.FormatTokens(bs.TotalTokens),
+			cli.FormatCost(bs.EstimatedCost),
+		})
+	}
+
+	fmt.Print(cli.RenderTable(cli.Table{
+		Headers:  []string{"Repo", "Branch", "Sessions", "Prompts", "Tokens", "Cost"},
+		Optional: []int{3, 2, 4},
+		Flex:     1,
+		Rows:     rows,
+	}))
+
+	return nil
+}
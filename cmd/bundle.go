@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/bundle"
+	"github.com/theirongolddev/cburn/internal/model"
+	"github.com/theirongolddev/cburn/internal/pipeline"
+	"github.com/theirongolddev/cburn/internal/receipts"
+	"github.com/theirongolddev/cburn/internal/store"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagBundleLabel string
+	flagBundleAll   bool
+)
+
+var bundleCmd = &cobra.Command{
+	Use:   "bundle",
+	Short: "Export or import session aggregates without raw JSONL",
+	Long: "A bundle is a .tar.gz of per-session token and cost aggregates (no prompt content).\n" +
+		"Export on each machine, then import the bundles on one machine to see combined usage\n" +
+		"with --source filtering.",
+}
+
+var bundleExportCmd = &cobra.Command{
+	Use:   "export [file]",
+	Short: "Write this machine's sessions to a bundle",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runBundleExport,
+}
+
+var bundleImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Merge a bundle into the cache under a source label",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runBundleImport,
+}
+
+func init() {
+	bundleExportCmd.Flags().StringVarP(&flagBundleLabel, "label", "l", "", "Source label recorded in the bundle (default: user@host)")
+	bundleExportCmd.Flags().BoolVar(&flagBundleAll, "all", false, "Export all history instead of the --days window")
+	bundleImportCmd.Flags().StringVarP(&flagBundleLabel, "label", "l", "", "Source label (default: the label recorded in the bundle)")
+	bundleCmd.AddCommand(bundleExportCmd)
+	bundleCmd.AddCommand(bundleImportCmd)
+	rootCmd.AddCommand(bundleCmd)
+}
+
+func runBundleExport(_ *cobra.Command, args []string) error {
+	result, err := loadData()
+	if err != nil {
+		return err
+	}
+
+	sessions := bundleSessions(result.Sessions)
+
+	label := flagBundleLabel
+	if label == "" {
+		label = receipts.Author()
+	}
+	if err := pipeline.ValidateSourceLabel(label); err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("cburn-%s-%s.tar.gz", label, time.Now().Format("20060102"))
+	if len(args) > 0 {
+		path = args[0]
+	}
+
+	m := bundle.Manifest{Label: label, Author: receipts.Author(), CreatedAt: time.Now().UTC()}
+	if err := bundle.WriteFile(path, m, sessions); err != nil {
+		return fmt.Errorf("writing bundle: %w", err)
+	}
+	fmt.Printf("  Exported %s sessions to %s\n", formatNumber(int64(len(sessions))), path)
+	return nil
+}
+
+// bundleSessions picks the sessions a bundle exports: only this machine's,
+// since re-exporting imports would double count, with the usual filters.
+// Privacy mode masks names on screen only; a bundle keeps the real ones so
+// whoever imports it can tell projects apart.
+func bundleSessions(all []model.SessionStats) []model.SessionStats {
+	sessions := pipeline.FilterBySource(all, pipeline.LocalSource)
+	sessions, since, until := filterSessions(sessions)
+	if !flagBundleAll {
+		sessions = pipeline.FilterByTime(sessions, since, until)
+	}
+	return sessions
+}
+
+func runBundleImport(_ *cobra.Command, args []string) error {
+	cache, err := store.Open(pipeline.CachePath())
+	if err != nil {
+		return fmt.Errorf("opening cache: %w", err)
+	}
+	defer func() { _ = cache.Close() }()
+
+	return importBundle(cache, args[0], flagBundleLabel)
+}
+
+// importBundle stores a bundle's sessions under label, or under the label
+// recorded in the bundle when label is empty.
+func importBundle(cache *store.Cache, path, label string) error {
+	m, sessions, err := bundle.Read(path)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+	if label == "" {
+		label = m.Label
+	}
+	if err := pipeline.StoreSource(cache, label, sessions); err != nil {
+		return err
+	}
+
+	if !flagQuiet && m.Author != "" {
+		fmt.Fprintf(os.Stderr, "  Bundle from %s, created %s\n", m.Author, m.CreatedAt.Local().Format("2006-01-02 15:04"))
+	}
+	fmt.Printf("  Imported %s sessions under source %q\n", formatNumber(int64(len(sessions))), label)
+	return nil
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/bundle"
+	"github.com/theirongolddev/cburn/internal/model"
+	"github.com/theirongolddev/cburn/internal/redact"
+)
+
+func TestBundleExportIgnoresPrivacy(t *testing.T) {
+	redact.SetEnabled(true)
+	t.Cleanup(func() { redact.SetEnabled(false) })
+	flagBundleAll = true
+	t.Cleanup(func() { flagBundleAll = false })
+
+	start := time.Now().Add(-time.Hour)
+	sessions := bundleSessions([]model.SessionStats{
+		{SessionID: "a", Project: "acme-billing", ProjectPath: "/home/alice/acme-billing", Repo: "acme",
+			GitBranch: "feature/invoices", StartTime: start, EndTime: start.Add(time.Minute), EstimatedCost: 1},
+		{SessionID: "b", Project: "imported", Source: "laptop", StartTime: start},
+	})
+
+	path := filepath.Join(t.TempDir(), "out.tar.gz")
+	if err := bundle.WriteFile(path, bundle.Manifest{Label: "test"}, sessions); err != nil {
+		t.Fatal(err)
+	}
+	_, got, err := bundle.Read(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("bundle holds %d sessions, want the one local session", len(got))
+	}
+	s := got[0]
+	if s.Project != "acme-billing" || s.ProjectPath != "/home/alice/acme-billing" || s.Repo != "acme" || s.GitBranch != "feature/invoices" {
+		t.Errorf("bundle session = %q %q %q %q, want the real names", s.Project, s.ProjectPath, s.Repo, s.GitBranch)
+	}
+}
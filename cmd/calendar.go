@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/calendar"
+	"github.com/theirongolddev/cburn/internal/config"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagCalendarOutput  string
+	flagCalendarMinCost float64
+)
+
+var calendarCmd = &cobra.Command{
+	Use:   "calendar",
+	Short: "Export heavy-usage sessions as an iCalendar (.ics) file",
+	Long: "Write an event for each session in the window that cost more than --min-cost\n" +
+		"(default [calendar] min_cost_usd, then $5), subagents included, spanning its\n" +
+		"first to last activity. Import the file into a calendar to overlay Claude\n" +
+		"working sessions for time tracking; re-importing updates events in place.\n" +
+		"The daemon serves the same feed at /v1/calendar.ics for subscriptions.",
+	Example: "  cburn calendar -n 90 -o claude.ics\n" +
+		"  cburn calendar --min-cost 20 -p api > api-heavy.ics",
+	Args: cobra.NoArgs,
+	RunE: runCalendar,
+}
+
+func init() {
+	calendarCmd.Flags().StringVarP(&flagCalendarOutput, "output", "o", "", "File to write (default: stdout)")
+	calendarCmd.Flags().Float64Var(&flagCalendarMinCost, "min-cost", 0, "Session cost in USD to exceed for an event (default: [calendar] min_cost_usd, then 5)")
+	rootCmd.AddCommand(calendarCmd)
+}
+
+func runCalendar(cmd *cobra.Command, _ []string) error {
+	if flagCalendarMinCost < 0 {
+		return errors.New("--min-cost must not be negative")
+	}
+	minCost := flagCalendarMinCost
+	if !cmd.Flags().Changed("min-cost") {
+		cfg, _ := config.Load()
+		minCost = cfg.Calendar.MinCost()
+	}
+
+	result, err := loadData()
+	if err != nil {
+		return err
+	}
+	filtered, since, until := applyFilters(result.Sessions)
+	blocks := calendar.Blocks(filtered, minCost, since, until)
+
+	if flagCalendarOutput == "" {
+		return calendar.Write(os.Stdout, blocks, time.Now())
+	}
+	f, err := os.OpenFile(flagCalendarOutput, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	err = calendar.Write(f, blocks, time.Now())
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "  Wrote %d events over $%.2f to %s\n", len(blocks), minCost, flagCalendarOutput)
+	return nil
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/claudeai"
+	"github.com/theirongolddev/cburn/internal/cli"
+	"github.com/theirongolddev/cburn/internal/config"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+// checkExitLimited is the exit code when a rate-limit window is above the
+// hint threshold, so scripts can gate heavy runs: `cburn check && run-agents`.
+const checkExitLimited = 2
+
+var (
+	flagCheckThreshold int
+	flagCheckWait      bool
+)
+
+var checkCmd = &cobra.Command{
+	Use:     "check",
+	Aliases: []string{"guard"},
+	Short:   "Check rate-limit headroom before starting an expensive run",
+	Long: "Exits 0 when every claude.ai rate-limit window is below the hint threshold\n" +
+		"([rate_limits] hint_threshold_pct, default 80). Otherwise prints when the binding\n" +
+		"window resets and exits 2, or with --wait, counts down until the reset and exits 0.",
+	RunE: runCheck,
+}
+
+func init() {
+	checkCmd.Flags().IntVar(&flagCheckThreshold, "threshold", 0, "Utilization percent that triggers a hint (default from config)")
+	checkCmd.Flags().BoolVar(&flagCheckWait, "wait", false, "Wait with a countdown until the window resets")
+	rootCmd.AddCommand(checkCmd)
+}
+
+func runCheck(_ *cobra.Command, _ []string) error {
+	cfg, _ := config.Load()
+	sessionKey := config.GetSessionKey(cfg)
+	if sessionKey == "" {
+		return errors.New("no session key configured (see `cburn status`)")
+	}
+
+	threshold := cfg.RateLimits.HintThreshold()
+	if flagCheckThreshold > 0 {
+		threshold = config.RateLimitsConfig{HintThresholdPct: flagCheckThreshold}.HintThreshold()
+	}
+
+	data, err := fetchSubscription(sessionKey, cfg.ClaudeAI.OrgID)
+	if err != nil {
+		return err
+	}
+	if data.Usage == nil {
+		return fmt.Errorf("no rate-limit data available: %w", data.Error)
+	}
+	if data.Stale {
+		fmt.Fprintf(os.Stderr, "  claude.ai unreachable; using rate limits as of %s\n", data.FetchedAt.Local().Format("3:04 PM"))
+	}
+
+	now := time.Now()
+	for _, nw := range data.Usage.Windows() {
+		fmt.Printf("  %-14s %s %4.0f%%\n", nw.Label, renderMiniBar(nw.Window.Pct, 20), nw.Window.Pct*100)
+	}
+	fmt.Println()
+
+	hint := claudeai.SchedulingHint(data.Usage, threshold, now)
+	if hint == nil {
+		okStyle := lipgloss.NewStyle().Foreground(cli.ColorGreen)
+		fmt.Printf("  %s\n", okStyle.Render(fmt.Sprintf("All windows below %.0f%% — good time for heavy runs.", threshold*100)))
+		return nil
+	}
+
+	warnStyle := lipgloss.NewStyle().Foreground(cli.ColorOrange)
+	fmt.Printf("  %s\n", warnStyle.Render(formatScheduleHint(*hint, now)))
+
+	wait := hint.Wait(now)
+	if !flagCheckWait || wait == 0 {
+		os.Exit(checkExitLimited)
+	}
+
+	waitForReset(*hint)
+	fmt.Printf("  %s window reset — go.\n", hint.Label)
+	return nil
+}
+
+// formatScheduleHint describes the binding window and when it resets.
+func formatScheduleHint(h claudeai.ScheduleHint, now time.Time) string {
+	msg := fmt.Sprintf("%s window at %.0f%%", h.Label, h.Pct*100)
+	wait := h.Wait(now)
+	if wait == 0 {
+		return msg + " (reset time unknown)"
+	}
+	at := h.ResetsAt.Local().Format("3:04 PM")
+	if wait > 24*time.Hour {
+		at = h.ResetsAt.Local().Format("Mon 3:04 PM")
+	}
+	return fmt.Sprintf("%s — resets in %s (%s); start heavy runs after that.", msg, formatCountdown(wait), at)
+}
+
+// waitForReset blocks until the hint's reset time, redrawing a countdown.
+func waitForReset(h claudeai.ScheduleHint) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		left := h.Wait(time.Now())
+		if left == 0 {
+			fmt.Fprint(os.Stderr, "\r\033[K")
+			return
+		}
+		fmt.Fprintf(os.Stderr, "\r  Waiting for %s reset: %s   ", h.Label, left.Truncate(time.Second))
+		<-ticker.C
+	}
+}
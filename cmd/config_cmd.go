@@ -3,8 +3,11 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/theirongolddev/cburn/internal/cli"
 	"github.com/theirongolddev/cburn/internal/config"
+	"github.com/theirongolddev/cburn/internal/source"
 
 	"github.com/spf13/cobra"
 )
@@ -36,9 +39,22 @@ func runConfig(_ *cobra.Command, _ []string) error {
 	fmt.Println("  [General]")
 	fmt.Printf("    Default days:      %d\n", cfg.General.DefaultDays)
 	fmt.Printf("    Include subagents: %v\n", cfg.General.IncludeSubagents)
+	if cfg.General.Timezone != "" {
+		fmt.Printf("    Time zone:         %s\n", cfg.General.Timezone)
+	}
 	if cfg.General.ClaudeDir != "" {
 		fmt.Printf("    Claude directory:  %s\n", cfg.General.ClaudeDir)
 	}
+	for _, r := range cfg.General.ScanRoots {
+		profile := r.Profile
+		if profile == "" {
+			profile = source.ProfileClaudeCode
+		}
+		fmt.Printf("    Scan root:         %s (%s)\n", r.Path, profile)
+	}
+	if len(cfg.General.ScanExclude) > 0 {
+		fmt.Printf("    Scan exclude:      %s\n", strings.Join(cfg.General.ScanExclude, ", "))
+	}
 	fmt.Println()
 
 	fmt.Println("  [Claude.ai]")
@@ -62,8 +78,16 @@ func runConfig(_ *cobra.Command, _ []string) error {
 	}
 	fmt.Println()
 
+	if cfg.UsesKeychain() {
+		fmt.Println("  Keys are stored in the OS keychain (`cburn secrets` for details).")
+		fmt.Println()
+	}
+
 	fmt.Println("  [Appearance]")
 	fmt.Printf("    Theme: %s\n", cfg.Appearance.Theme)
+	if cfg.Currency.Code != "" {
+		fmt.Printf("    Currency: %s (%s)\n", strings.ToUpper(cfg.Currency.Code), cli.FormatCostFixed(1, 2)+" per $1")
+	}
 	fmt.Println()
 
 	fmt.Println("  [Budget]")
@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"github.com/theirongolddev/cburn/internal/cli"
+	"github.com/theirongolddev/cburn/internal/config"
+	"github.com/theirongolddev/cburn/internal/model"
 	"github.com/theirongolddev/cburn/internal/pipeline"
 
 	"github.com/spf13/cobra"
@@ -15,11 +17,20 @@ var costsCmd = &cobra.Command{
 	RunE:  runCosts,
 }
 
+var flagCostsBy string
+
 func init() {
+	costsCmd.Flags().StringVar(&flagCostsBy, "by", "", "Group costs by: tag (cost allocation tags from [projects] rules) or origin (interactive vs automation)")
 	rootCmd.AddCommand(costsCmd)
 }
 
 func runCosts(_ *cobra.Command, _ []string) error {
+	switch flagCostsBy {
+	case "", "tag", "origin":
+	default:
+		return fmt.Errorf("unknown --by value %q (supported: tag, origin)", flagCostsBy)
+	}
+
 	result, err := loadData()
 	if err != nil {
 		return err
@@ -43,8 +54,17 @@ func runCosts(_ *cobra.Command, _ []string) error {
 	prevSince := since.Add(-prevDuration)
 	prevStats := pipeline.Aggregate(filtered, prevSince, since)
 
+	if flagCostsBy == "tag" {
+		renderCostsByTag(pipeline.AggregateTags(filtered, since, until), stats.EstimatedCost)
+		return nil
+	}
+	if flagCostsBy == "origin" {
+		renderCostsByOrigin(pipeline.AggregateOrigins(filtered, since, until), stats.EstimatedCost)
+		return nil
+	}
+
 	fmt.Println()
-	fmt.Println(cli.RenderTitle(fmt.Sprintf("COST BREAKDOWN  Last %dd", flagDays)))
+	fmt.Println(cli.RenderTitle("COST BREAKDOWN  " + periodLabel()))
 	fmt.Println()
 
 	// Cost by token type
@@ -81,6 +101,12 @@ func runCosts(_ *cobra.Command, _ []string) error {
 		Rows:    typeRows,
 	}))
 
+	// Run rate against the configured subscription
+	cfg, _ := config.Load()
+	if name, usd, ok := cfg.Budget.Subscription(); ok {
+		fmt.Printf("  Plan equivalent: %s\n\n", cli.FormatPlanEquivalent(stats.CostPerDay, usd, name))
+	}
+
 	// Period comparison
 	if prevStats.EstimatedCost > 0 {
 		fmt.Printf("  Period Comparison\n")
@@ -88,12 +114,12 @@ func runCosts(_ *cobra.Command, _ []string) error {
 		if prevStats.EstimatedCost > maxCost {
 			maxCost = prevStats.EstimatedCost
 		}
-		fmt.Printf("  This %dd  %s  %s\n",
-			flagDays,
+		fmt.Printf("  This %s  %s  %s\n",
+			periodShort(),
 			cli.RenderHorizontalBar("", stats.EstimatedCost, maxCost, 30),
 			cli.FormatCost(stats.EstimatedCost))
-		fmt.Printf("  Prev %dd  %s  %s\n\n",
-			flagDays,
+		fmt.Printf("  Prev %s  %s  %s\n\n",
+			periodShort(),
 			cli.RenderHorizontalBar("", prevStats.EstimatedCost, maxCost, 30),
 			cli.FormatCost(prevStats.EstimatedCost))
 	}
@@ -119,17 +145,112 @@ func runCosts(_ *cobra.Command, _ []string) error {
 	})
 
 	fmt.Print(cli.RenderTable(cli.Table{
-		Title:   "By Model",
-		Headers: []string{"Model", "Input", "Output", "Cache", "Total"},
-		Rows:    modelRows,
+		Title:    "By Model",
+		Headers:  []string{"Model", "Input", "Output", "Cache", "Total"},
+		Optional: []int{3, 1, 2},
+		Rows:     modelRows,
 	}))
 
+	// Cost by service tier, when anything ran off the standard tier
+	if tiers := pipeline.AggregateTiers(filtered, since, until); len(tiers) > 1 ||
+		(len(tiers) == 1 && tiers[0].Tier != config.TierStandard) {
+		tierRows := make([][]string, 0, len(tiers))
+		for _, ts := range tiers {
+			saved := ""
+			if d := ts.ListCost - ts.EstimatedCost; d > 0.005 {
+				saved = cli.FormatCost(d)
+			}
+			tierRows = append(tierRows, []string{
+				ts.Tier,
+				cli.FormatNumber(int64(ts.APICalls)),
+				cli.FormatCost(ts.EstimatedCost),
+				saved,
+				fmt.Sprintf("%.1f%%", ts.SharePercent),
+			})
+		}
+		fmt.Print(cli.RenderTable(cli.Table{
+			Title:    "By Service Tier",
+			Headers:  []string{"Tier", "Calls", "Cost", "Saved", "Share"},
+			Optional: []int{4, 1},
+			Rows:     tierRows,
+		}))
+	}
+
 	fmt.Printf("  Cache Savings: %s saved this period\n\n",
 		cli.FormatCost(stats.CacheSavings))
 
 	return nil
 }
 
+func renderCostsByTag(tags []model.TagStats, totalCost float64) {
+	fmt.Println()
+	fmt.Println(cli.RenderTitle("COSTS BY TAG  " + periodLabel()))
+	fmt.Println()
+
+	rows := make([][]string, 0, len(tags)+2)
+	for _, ts := range tags {
+		tag := ts.Tag
+		if tag == "" {
+			tag = "(untagged)"
+		}
+		rows = append(rows, []string{
+			tag,
+			cli.FormatNumber(int64(ts.Projects)),
+			cli.FormatNumber(int64(ts.Sessions)),
+			cli.FormatTokens(ts.TotalTokens),
+			cli.FormatCost(ts.EstimatedCost),
+			fmt.Sprintf("%.1f%%", ts.SharePercent),
+		})
+	}
+	rows = append(rows, []string{"---"})
+	rows = append(rows, []string{"TOTAL", "", "", "", cli.FormatCost(totalCost), ""})
+
+	fmt.Print(cli.RenderTable(cli.Table{
+		Headers:  []string{"Tag", "Projects", "Sessions", "Tokens", "Cost", "Share"},
+		Optional: []int{1, 2, 3},
+		Rows:     rows,
+	}))
+
+	if len(tags) == 1 && tags[0].Tag == "" {
+		fmt.Println("  No sessions matched a [projects] rule. Add rules to your config, e.g.:")
+		fmt.Println()
+		fmt.Println("    [[projects.rules]]")
+		fmt.Println("    match = \"~/work/acme\"")
+		fmt.Println("    tag = \"acme\"")
+		fmt.Println()
+	}
+}
+
+func renderCostsByOrigin(origins []model.OriginStats, totalCost float64) {
+	fmt.Println()
+	fmt.Println(cli.RenderTitle("COSTS BY ORIGIN  " + periodLabel()))
+	fmt.Println()
+
+	rows := make([][]string, 0, len(origins)+2)
+	for _, o := range origins {
+		rows = append(rows, []string{
+			o.Origin,
+			cli.FormatNumber(int64(o.Projects)),
+			cli.FormatNumber(int64(o.Sessions)),
+			cli.FormatNumber(int64(o.Prompts)),
+			cli.FormatTokens(o.TotalTokens),
+			cli.FormatCost(o.EstimatedCost),
+			fmt.Sprintf("%.1f%%", o.SharePercent),
+		})
+	}
+	rows = append(rows, []string{"---"})
+	rows = append(rows, []string{"TOTAL", "", "", "", "", cli.FormatCost(totalCost), ""})
+
+	fmt.Print(cli.RenderTable(cli.Table{
+		Headers:  []string{"Origin", "Projects", "Sessions", "Prompts", "Tokens", "Cost", "Share"},
+		Optional: []int{1, 2, 3, 4},
+		Rows:     rows,
+	}))
+	fmt.Println("  Automation: SDK and `claude -p` runs, CI checkouts, sessions without user")
+	fmt.Println("  prompts, and projects matching [projects] automation patterns.")
+	fmt.Println()
+}
+
 func shortModel(name string) string {
 	// "claude-opus-4-6" -> "opus-4-6"
 	if len(name) > 7 && name[:7] == "claude-" {
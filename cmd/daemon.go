@@ -5,7 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"net/http"
+	"log"
 	"os"
 	"os/exec"
 	"os/signal"
@@ -15,8 +15,12 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/theirongolddev/cburn/internal/cli"
+	"github.com/theirongolddev/cburn/internal/config"
 	"github.com/theirongolddev/cburn/internal/daemon"
+	"github.com/theirongolddev/cburn/internal/notify"
 	"github.com/theirongolddev/cburn/internal/pipeline"
+	"github.com/theirongolddev/cburn/pkg/client"
 
 	"github.com/spf13/cobra"
 )
@@ -35,6 +39,7 @@ var (
 	flagDaemonPIDFile      string
 	flagDaemonLogFile      string
 	flagDaemonEventsBuffer int
+	flagDaemonHeartbeat    time.Duration
 	flagDaemonChild        bool
 )
 
@@ -56,6 +61,20 @@ var daemonStopCmd = &cobra.Command{
 	RunE:  runDaemonStop,
 }
 
+var daemonOpenAPICmd = &cobra.Command{
+	Use:   "openapi",
+	Short: "Print the OpenAPI description of the daemon's HTTP API",
+	Args:  cobra.NoArgs,
+	RunE:  runDaemonOpenAPI,
+}
+
+var daemonTestWebhookCmd = &cobra.Command{
+	Use:   "test-webhook [url]",
+	Short: "Send a test notification to configured webhooks (or the given URL)",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runDaemonTestWebhook,
+}
+
 func init() {
 	defaultPID := filepath.Join(pipeline.CacheDir(), "cburnd.pid")
 	defaultLog := filepath.Join(pipeline.CacheDir(), "cburnd.log")
@@ -65,6 +84,7 @@ func init() {
 	daemonCmd.PersistentFlags().StringVar(&flagDaemonPIDFile, "pid-file", defaultPID, "PID file path")
 	daemonCmd.PersistentFlags().StringVar(&flagDaemonLogFile, "log-file", defaultLog, "Log file path for detached mode")
 	daemonCmd.PersistentFlags().IntVar(&flagDaemonEventsBuffer, "events-buffer", 200, "Max in-memory events retained")
+	daemonCmd.PersistentFlags().DurationVar(&flagDaemonHeartbeat, "heartbeat", 15*time.Second, "SSE keep-alive interval for /v1/stream")
 
 	daemonCmd.Flags().BoolVar(&flagDaemonDetach, "detach", false, "Run daemon as a background process")
 	daemonCmd.Flags().BoolVar(&flagDaemonChild, "child", false, "Internal: mark detached child process")
@@ -72,10 +92,12 @@ func init() {
 
 	daemonCmd.AddCommand(daemonStatusCmd)
 	daemonCmd.AddCommand(daemonStopCmd)
+	daemonCmd.AddCommand(daemonTestWebhookCmd)
+	daemonCmd.AddCommand(daemonOpenAPICmd)
 	rootCmd.AddCommand(daemonCmd)
 }
 
-func runDaemon(_ *cobra.Command, _ []string) error {
+func runDaemon(cmd *cobra.Command, _ []string) error {
 	if flagDaemonDetach && flagDaemonChild {
 		return errors.New("invalid daemon launch mode")
 	}
@@ -84,7 +106,7 @@ func runDaemon(_ *cobra.Command, _ []string) error {
 		return startDaemonDetached()
 	}
 
-	return runDaemonForeground()
+	return runDaemonForeground(cmd)
 }
 
 func startDaemonDetached() error {
@@ -131,7 +153,7 @@ func startDaemonDetached() error {
 	return nil
 }
 
-func runDaemonForeground() error {
+func runDaemonForeground(cmd *cobra.Command) error {
 	if err := ensureDaemonNotRunning(flagDaemonPIDFile); err != nil {
 		return err
 	}
@@ -155,25 +177,20 @@ func runDaemonForeground() error {
 	_ = writeState(statePath(flagDaemonPIDFile), state)
 	defer func() { _ = os.Remove(statePath(flagDaemonPIDFile)) }()
 
-	cfg := daemon.Config{
-		DataDir:          flagDataDir,
-		Days:             flagDays,
-		ProjectFilter:    flagProject,
-		ModelFilter:      flagModel,
-		IncludeSubagents: !flagNoSubagents,
-		UseCache:         !flagNoCache,
-		Interval:         flagDaemonInterval,
-		Addr:             flagDaemonAddr,
-		EventsBuffer:     flagDaemonEventsBuffer,
+	appCfg, _ := config.Load()
+	cfg, err := daemonConfig(cmd, appCfg)
+	if err != nil {
+		return err
 	}
 	svc := daemon.New(cfg)
 
 	fmt.Printf("  cburn daemon listening on http://%s\n", flagDaemonAddr)
-	fmt.Printf("  Polling every %s from %s\n", flagDaemonInterval, flagDataDir)
+	fmt.Printf("  Polling every %s from %s\n", cfg.Interval, cfg.DataDir)
 	fmt.Printf("  Stop with: cburn daemon stop --pid-file %s\n", flagDaemonPIDFile)
 
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer cancel()
+	go watchDaemonConfig(ctx, cmd, svc)
 
 	if err := svc.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
 		return err
@@ -181,6 +198,138 @@ func runDaemonForeground() error {
 	return nil
 }
 
+// daemonConfig builds the daemon's settings from flags and config. Flags
+// given on the command line win; otherwise the --view, then [daemon] in
+// config, then the flag defaults decide. It runs again on every reload.
+func daemonConfig(cmd *cobra.Command, appCfg config.Config) (daemon.Config, error) {
+	flags := cmd.Flags()
+	dc := appCfg.Daemon
+	days, project, model := dc.Days, dc.Project, dc.Model
+	if flagView != "" {
+		v, err := appCfg.FindView(flagView)
+		if err != nil {
+			return daemon.Config{}, err
+		}
+		if v.Days > 0 {
+			days = v.Days
+		}
+		if v.Project != "" {
+			project = v.Project
+		}
+		if v.Model != "" {
+			model = v.Model
+		}
+	}
+	if flags.Changed("days") || days <= 0 {
+		days = flagDays
+	}
+	if flags.Changed("project") || project == "" {
+		project = flagProject
+	}
+	if flags.Changed("model") || model == "" {
+		model = flagModel
+	}
+	dataDir := flagDataDir
+	if !flags.Changed("data-dir") && dc.DataDir != "" {
+		dataDir = dc.DataDirPath()
+	}
+	interval := flagDaemonInterval
+	if !flags.Changed("interval") && dc.IntervalSec > 0 {
+		interval = time.Duration(dc.IntervalSec) * time.Second
+	}
+
+	cfg := daemon.Config{
+		DataDir:          dataDir,
+		ExtraRoots:       appCfg.General.ScanRoots,
+		ScanExclude:      appCfg.General.ScanExclude,
+		Days:             days,
+		ProjectFilter:    project,
+		ModelFilter:      model,
+		IncludeSubagents: !flagNoSubagents,
+		UseCache:         !flagNoCache,
+		Interval:         interval,
+		Addr:             flagDaemonAddr,
+		EventsBuffer:     flagDaemonEventsBuffer,
+		Heartbeat:        flagDaemonHeartbeat,
+		WriteReceipts:    appCfg.Receipts.Enabled,
+
+		Webhooks:           appCfg.Notify.Webhooks,
+		Desktop:            appCfg.Notify.Desktop,
+		UsageDeltaUSD:      appCfg.Notify.UsageDeltaUSD,
+		RunawayAlerts:      appCfg.Notify.Runaway,
+		SessionKey:         config.GetSessionKey(appCfg),
+		OrgID:              appCfg.ClaudeAI.OrgID,
+		RateLimitThreshold: appCfg.RateLimits.HintThreshold(),
+		SlackSigningSecret: dc.SlackSigningSecret,
+		CalendarMinCostUSD: appCfg.Calendar.MinCost(),
+	}
+	if appCfg.Digest.To != "" {
+		mail := digestSMTP(appCfg.Digest)
+		cfg.Digest, cfg.DigestHour = &mail, appCfg.Digest.SendHour()
+	}
+	if appCfg.Budget.MonthlyUSD != nil {
+		cfg.MonthlyBudgetUSD = *appCfg.Budget.MonthlyUSD
+		cfg.BillingDay = appCfg.Budget.BillingDay
+	}
+	sinks, err := daemon.OpenSinks(dc.Sinks)
+	if err != nil {
+		return daemon.Config{}, err
+	}
+	cfg.Sinks = sinks
+	return cfg, nil
+}
+
+// configCheckInterval is how often the daemon looks for config changes.
+const configCheckInterval = 2 * time.Second
+
+// watchDaemonConfig reloads the daemon on SIGHUP and whenever the config
+// file changes, until ctx is done. A config that fails to load or apply is
+// reported and the daemon keeps its current settings.
+func watchDaemonConfig(ctx context.Context, cmd *cobra.Command, svc *daemon.Service) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	modTime := func() time.Time {
+		info, err := os.Stat(config.Path())
+		if err != nil {
+			return time.Time{}
+		}
+		return info.ModTime()
+	}
+	lastMod := modTime()
+	ticker := time.NewTicker(configCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		var why string
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			why = "SIGHUP"
+		case <-ticker.C:
+			m := modTime()
+			if m.Equal(lastMod) {
+				continue
+			}
+			lastMod = m
+			why = "config change"
+		}
+
+		appCfg, err := config.Load()
+		if err == nil {
+			var cfg daemon.Config
+			if cfg, err = daemonConfig(cmd, appCfg); err == nil {
+				svc.Reload(cfg)
+				log.Printf("cburn daemon reloaded (%s): polling every %s from %s", why, cfg.Interval, cfg.DataDir)
+				continue
+			}
+		}
+		log.Printf("cburn daemon reload (%s) failed, keeping current settings: %v", why, err)
+	}
+}
+
 func runDaemonStatus(_ *cobra.Command, _ []string) error {
 	pid, err := readPID(flagDaemonPIDFile)
 	if err != nil {
@@ -202,22 +351,16 @@ func runDaemonStatus(_ *cobra.Command, _ []string) error {
 	fmt.Printf("  Daemon PID: %d\n", pid)
 	fmt.Printf("  Address: http://%s\n", addr)
 
-	client := &http.Client{Timeout: 2 * time.Second}
-	resp, err := client.Get("http://" + addr + "/v1/status") //nolint:noctx // short status probe
-	if err != nil {
-		fmt.Printf("  API status: unreachable (%v)\n", err)
-		return nil
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusOK {
-		fmt.Printf("  API status: HTTP %d\n", resp.StatusCode)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	st, err := client.New(addr, nil).Status(ctx)
+	var httpErr *client.HTTPError
+	switch {
+	case errors.As(err, &httpErr):
+		fmt.Printf("  API status: HTTP %d\n", httpErr.StatusCode)
 		return nil
-	}
-
-	var st daemon.Status
-	if err := json.NewDecoder(resp.Body).Decode(&st); err != nil {
-		fmt.Printf("  API status: malformed response (%v)\n", err)
+	case err != nil:
+		fmt.Printf("  API status: unreachable (%v)\n", err)
 		return nil
 	}
 
@@ -229,7 +372,7 @@ func runDaemonStatus(_ *cobra.Command, _ []string) error {
 	fmt.Printf("  Poll count: %d\n", st.PollCount)
 	fmt.Printf("  Sessions: %d\n", st.Summary.Sessions)
 	fmt.Printf("  Tokens: %d\n", st.Summary.Tokens)
-	fmt.Printf("  Cost: $%.2f\n", st.Summary.EstimatedCostUSD)
+	fmt.Printf("  Cost: %s\n", cli.FormatCostFixed(st.Summary.EstimatedCostUSD, 2))
 	if st.LastError != "" {
 		fmt.Printf("  Last error: %s\n", st.LastError)
 	}
@@ -264,6 +407,48 @@ func runDaemonStop(_ *cobra.Command, _ []string) error {
 	return fmt.Errorf("daemon (pid %d) did not exit in time", pid)
 }
 
+func runDaemonOpenAPI(_ *cobra.Command, _ []string) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(daemon.OpenAPISpec())
+}
+
+func runDaemonTestWebhook(_ *cobra.Command, args []string) error {
+	appCfg, _ := config.Load()
+	hooks := appCfg.Notify.Webhooks
+	if len(args) > 0 {
+		hooks = []config.Webhook{{URL: args[0]}}
+	}
+	if len(hooks) == 0 {
+		return errors.New("no webhooks configured; add [[notify.webhooks]] to config.toml or pass a URL")
+	}
+
+	n := notify.Notification{
+		Kind:  notify.KindTest,
+		Title: "cburn: test notification",
+		Text:  "Webhook delivery from the cburn daemon is working.",
+		At:    time.Now(),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	sender := notify.NewSender()
+	failed := 0
+	for _, wh := range hooks {
+		if err := sender.Send(ctx, wh, n); err != nil {
+			fmt.Printf("  FAIL  %s\n", err)
+			failed++
+			continue
+		}
+		fmt.Printf("  OK    %s webhook\n", notify.FormatFor(wh))
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d webhooks failed", failed, len(hooks))
+	}
+	return nil
+}
+
 func filterDetachArg(args []string) []string {
 	out := make([]string, 0, len(args))
 	for _, a := range args {
@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/theirongolddev/cburn/internal/daemon"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	flagServiceSystemd bool
+	flagServiceLaunchd bool
+	flagServicePrint   bool
+)
+
+var daemonInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Start the daemon at login as a systemd or launchd service",
+	Long: "Writes a systemd user unit (Linux) or launchd agent (macOS) that runs this\n" +
+		"cburn binary as `cburn daemon` with the flags given here, then enables and\n" +
+		"starts it. The service manager follows the OS unless --systemd or --launchd\n" +
+		"is given; --print shows the file without installing it.",
+	Example: "  cburn daemon install --interval 30s --addr 127.0.0.1:9000",
+	Args:    cobra.NoArgs,
+	RunE:    runDaemonInstall,
+}
+
+var daemonUninstallCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: "Stop and remove the service installed by daemon install",
+	Args:  cobra.NoArgs,
+	RunE:  runDaemonUninstall,
+}
+
+func init() {
+	for _, c := range []*cobra.Command{daemonInstallCmd, daemonUninstallCmd} {
+		c.Flags().BoolVar(&flagServiceSystemd, "systemd", false, "Use a systemd user unit")
+		c.Flags().BoolVar(&flagServiceLaunchd, "launchd", false, "Use a launchd agent")
+		c.MarkFlagsMutuallyExclusive("systemd", "launchd")
+	}
+	daemonInstallCmd.Flags().BoolVar(&flagServicePrint, "print", false, "Print the unit or plist instead of installing it")
+
+	daemonCmd.AddCommand(daemonInstallCmd, daemonUninstallCmd)
+}
+
+// serviceManager picks systemd or launchd from the flags, or from the OS.
+func serviceManager() (string, error) {
+	switch {
+	case flagServiceSystemd:
+		return "systemd", nil
+	case flagServiceLaunchd:
+		return "launchd", nil
+	case runtime.GOOS == "linux":
+		return "systemd", nil
+	case runtime.GOOS == "darwin":
+		return "launchd", nil
+	}
+	return "", fmt.Errorf("no service manager support on %s; run `cburn daemon --detach` instead", runtime.GOOS)
+}
+
+// servicePath is where the unit or plist for manager lives.
+func servicePath(manager string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	if manager == "launchd" {
+		return filepath.Join(home, "Library", "LaunchAgents", daemon.LaunchdLabel+".plist"), nil
+	}
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "systemd", "user", daemon.SystemdUnitName), nil
+}
+
+// daemonServiceArgs is the command line the service runs: `daemon` plus
+// every daemon and global flag set on this invocation.
+func daemonServiceArgs(cmd *cobra.Command) []string {
+	args := []string{"daemon"}
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		switch f.Name {
+		case "systemd", "launchd", "print", "detach", "child":
+			return
+		}
+		args = append(args, "--"+f.Name+"="+f.Value.String())
+	})
+	return args
+}
+
+func runDaemonInstall(cmd *cobra.Command, _ []string) error {
+	manager, err := serviceManager()
+	if err != nil {
+		return err
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("resolve executable: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+
+	args := daemonServiceArgs(cmd)
+	var content string
+	if manager == "launchd" {
+		content = daemon.LaunchdPlist(exe, args, flagDaemonLogFile)
+	} else {
+		content = daemon.SystemdUnit(exe, args)
+	}
+	if flagServicePrint {
+		fmt.Print(content)
+		return nil
+	}
+
+	// The service would fail to start alongside a daemon run by hand.
+	if pid, err := readPID(flagDaemonPIDFile); err == nil && processAlive(pid) {
+		return fmt.Errorf("daemon already running (pid %d); stop it with `cburn daemon stop` first", pid)
+	}
+
+	path, err := servicePath(manager)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("create service directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		return fmt.Errorf("write service file: %w", err)
+	}
+
+	if manager == "launchd" {
+		// Reload a previous install so the new plist takes effect
+		_ = runServiceCommand("launchctl", "bootout", launchdTarget())
+		err = runServiceCommand("launchctl", "bootstrap", launchdDomain(), path)
+	} else {
+		err = runServiceCommand("systemctl", "--user", "daemon-reload")
+		if err == nil {
+			err = runServiceCommand("systemctl", "--user", "enable", "--now", daemon.SystemdUnitName)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("wrote %s but could not start it: %w", path, err)
+	}
+
+	fmt.Printf("  Installed %s service: %s\n", manager, path)
+	fmt.Printf("  API: http://%s/v1/status\n", flagDaemonAddr)
+	if manager == "launchd" {
+		fmt.Printf("  Log: %s\n", flagDaemonLogFile)
+	} else {
+		fmt.Printf("  Log: journalctl --user -u %s\n", daemon.SystemdUnitName)
+	}
+	return nil
+}
+
+func runDaemonUninstall(_ *cobra.Command, _ []string) error {
+	manager, err := serviceManager()
+	if err != nil {
+		return err
+	}
+	path, err := servicePath(manager)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("no %s service installed at %s", manager, path)
+	}
+
+	// Stopping fails when the service isn't loaded, which is fine here
+	if manager == "launchd" {
+		_ = runServiceCommand("launchctl", "bootout", launchdTarget())
+	} else {
+		_ = runServiceCommand("systemctl", "--user", "disable", "--now", daemon.SystemdUnitName)
+	}
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("remove service file: %w", err)
+	}
+	if manager == "systemd" {
+		_ = runServiceCommand("systemctl", "--user", "daemon-reload")
+	}
+
+	fmt.Printf("  Removed %s service: %s\n", manager, path)
+	return nil
+}
+
+// runServiceCommand runs a service manager command, folding its output
+// into the error when it fails.
+func runServiceCommand(name string, args ...string) error {
+	out, err := exec.Command(name, args...).CombinedOutput() //nolint:gosec // fixed service manager commands
+	if err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("%s %s: %s", name, strings.Join(args, " "), msg)
+		}
+		return fmt.Errorf("%s %s: %w", name, strings.Join(args, " "), err)
+	}
+	return nil
+}
+
+// launchdDomain is the launchd domain of the logged-in user's agents.
+func launchdDomain() string {
+	return "gui/" + strconv.Itoa(os.Getuid())
+}
+
+func launchdTarget() string {
+	return launchdDomain() + "/" + daemon.LaunchdLabel
+}
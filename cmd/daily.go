@@ -38,7 +38,7 @@ func runDaily(_ *cobra.Command, _ []string) error {
 	}
 
 	fmt.Println()
-	fmt.Println(cli.RenderTitle(fmt.Sprintf("DAILY USAGE  Last %dd", flagDays)))
+	fmt.Println(cli.RenderTitle("DAILY USAGE  " + periodLabel()))
 	fmt.Println()
 
 	rows := make([][]string, 0, len(days))
@@ -50,13 +50,24 @@ func runDaily(_ *cobra.Command, _ []string) error {
 			cli.FormatNumber(int64(d.Prompts)),
 			cli.FormatTokens(d.InputTokens + d.OutputTokens + d.CacheCreation5m + d.CacheCreation1h),
 			cli.FormatCost(d.EstimatedCost),
+			formatOutPerMin(d.OutputTokens, d.DurationSecs),
 		})
 	}
 
 	fmt.Print(cli.RenderTable(cli.Table{
-		Headers: []string{"Date", "Day", "Sessions", "Prompts", "Tokens", "Cost"},
-		Rows:    rows,
+		Headers:  []string{"Date", "Day", "Sessions", "Prompts", "Tokens", "Cost", "Out/min"},
+		Optional: []int{6, 1, 3, 2},
+		Rows:     rows,
 	}))
 
 	return nil
 }
+
+// formatOutPerMin formats output tokens per active minute, or "-" for a day
+// without recorded turn time.
+func formatOutPerMin(outputTokens, durationSecs int64) string {
+	if perMin := pipeline.OutputPerMinute(outputTokens, durationSecs); perMin > 0 {
+		return cli.FormatTokens(int64(perMin))
+	}
+	return "-"
+}
@@ -0,0 +1,323 @@
+package cmd
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/config"
+	"github.com/theirongolddev/cburn/internal/pipeline"
+	"github.com/theirongolddev/cburn/internal/redact"
+	"github.com/theirongolddev/cburn/internal/source"
+	"github.com/theirongolddev/cburn/internal/store"
+
+	"github.com/BurntSushi/toml"
+	"github.com/spf13/cobra"
+)
+
+// Limits on the parse error samples in a debug bundle.
+const (
+	debugSampleFiles = 10
+	debugSampleLines = 3
+	debugSampleBytes = 2000
+)
+
+var debugBundleCmd = &cobra.Command{
+	Use:   "debug-bundle [file]",
+	Short: "Collect anonymized diagnostics to attach to a bug report",
+	Long: "Writes a .tar.gz with cburn's version, your config with secrets and webhook URLs\n" +
+		"removed, cache statistics, load timings, and samples of lines that failed to\n" +
+		"parse with their content replaced by placeholders. Session files, project names,\n" +
+		"and file paths are not included as-is. Review the files before sharing.",
+	Args: cobra.MaximumNArgs(1),
+	RunE: runDebugBundle,
+}
+
+func init() {
+	rootCmd.AddCommand(debugBundleCmd)
+}
+
+// debugFile is one file in the debug bundle.
+type debugFile struct {
+	name string
+	data []byte
+}
+
+func runDebugBundle(_ *cobra.Command, args []string) error {
+	now := time.Now()
+	path := fmt.Sprintf("cburn-debug-%s.tar.gz", now.Format("20060102-150405"))
+	if len(args) == 1 {
+		path = args[0]
+	}
+	// Paths in the report go through redact, whatever --privacy says.
+	wasEnabled := redact.Enabled()
+	redact.SetEnabled(true)
+	defer redact.SetEnabled(wasEnabled)
+
+	var files []debugFile
+	add := func(name string, v any) error {
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return fmt.Errorf("encoding %s: %w", name, err)
+		}
+		files = append(files, debugFile{name, append(data, '\n')})
+		return nil
+	}
+
+	if err := add("version.json", debugVersion()); err != nil {
+		return err
+	}
+
+	cfg, cfgErr := config.Load()
+	var buf bytes.Buffer
+	if cfgErr != nil {
+		fmt.Fprintf(&buf, "# config failed to load: %s\n", cfgErr)
+	}
+	if err := toml.NewEncoder(&buf).Encode(anonymizeConfig(cfg.Redacted())); err != nil {
+		return fmt.Errorf("encoding config: %w", err)
+	}
+	files = append(files, debugFile{"config.toml", buf.Bytes()})
+
+	cache, err := store.Open(pipeline.CachePath())
+	if err != nil {
+		return fmt.Errorf("opening cache: %w", err)
+	}
+	defer func() { _ = cache.Close() }()
+
+	start := time.Now()
+	cr, err := pipeline.LoadWithCache(scanRoots(), !flagNoSubagents, cache, nil)
+	if err != nil {
+		return fmt.Errorf("loading sessions: %w", err)
+	}
+	days, _ := cache.ScanHistory(now.AddDate(0, 0, -6))
+	history := make([]map[string]any, 0, len(days))
+	for _, d := range days {
+		history = append(history, map[string]any{
+			"day": d.Day, "scans": d.Scans, "files_parsed": d.FilesParsed, "duration_ms": d.Duration.Milliseconds(),
+		})
+	}
+	if err := add("timing.json", map[string]any{
+		"load_ms":           time.Since(start).Milliseconds(),
+		"total_files":       cr.TotalFiles,
+		"cache_hits":        cr.CacheHits,
+		"reparsed":          cr.Reparsed,
+		"sessions":          len(cr.Sessions),
+		"projects":          cr.ProjectCount,
+		"file_errors":       cr.FileErrors,
+		"parse_errors":      cr.ParseErrors,
+		"skewed_timestamps": cr.SkewedTimestamps,
+		"duplicates":        len(cr.Duplicates),
+		"scans_last_7_days": history,
+		"gomaxprocs":        runtime.GOMAXPROCS(0),
+	}); err != nil {
+		return err
+	}
+
+	cacheStats, err := debugCacheStats(cache)
+	if err != nil {
+		return err
+	}
+	if err := add("cache.json", cacheStats); err != nil {
+		return err
+	}
+
+	issues, err := cache.ParseIssues()
+	if err != nil {
+		return fmt.Errorf("reading parse issues: %w", err)
+	}
+	if err := add("parse_issues.json", debugParseIssues(issues)); err != nil {
+		return err
+	}
+
+	if err := writeDebugBundle(path, files, now); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	fmt.Printf("  Wrote %s (%d files: ", path, len(files))
+	names := make([]string, len(files))
+	for i, f := range files {
+		names[i] = f.name
+	}
+	fmt.Printf("%s)\n", strings.Join(names, ", "))
+	fmt.Println("  Review it, then attach it to your GitHub issue.")
+	return nil
+}
+
+func debugVersion() map[string]string {
+	v := map[string]string{
+		"go":   runtime.Version(),
+		"os":   runtime.GOOS,
+		"arch": runtime.GOARCH,
+		"cpus": fmt.Sprint(runtime.NumCPU()),
+	}
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		v["version"] = bi.Main.Version
+		for _, s := range bi.Settings {
+			switch s.Key {
+			case "vcs.revision", "vcs.time", "vcs.modified":
+				v[s.Key] = s.Value
+			}
+		}
+	}
+	return v
+}
+
+// anonymizeConfig masks the project names and paths a config mentions.
+// Slices are copied so cfg's own are untouched.
+func anonymizeConfig(cfg config.Config) config.Config {
+	cfg.General.ClaudeDir = redact.Name("path", cfg.General.ClaudeDir)
+	cfg.General.ScanRoots = slices.Clone(cfg.General.ScanRoots)
+	for i := range cfg.General.ScanRoots {
+		r := &cfg.General.ScanRoots[i]
+		r.Path = redact.Name("path", r.Path)
+		r.Project = redact.Name("project", r.Project)
+	}
+	cfg.Projects.Rules = slices.Clone(cfg.Projects.Rules)
+	for i := range cfg.Projects.Rules {
+		r := &cfg.Projects.Rules[i]
+		r.Match = redact.Name("match", r.Match)
+		r.Tag = redact.Name("tag", r.Tag)
+	}
+	cfg.Projects.Aliases = slices.Clone(cfg.Projects.Aliases)
+	for i := range cfg.Projects.Aliases {
+		a := &cfg.Projects.Aliases[i]
+		a.Match = redact.Name("match", a.Match)
+		a.Project = redact.Name("project", a.Project)
+	}
+	cfg.Projects.Automation = slices.Clone(cfg.Projects.Automation)
+	for i, pattern := range cfg.Projects.Automation {
+		cfg.Projects.Automation[i] = redact.Name("match", pattern)
+	}
+	cfg.Views = slices.Clone(cfg.Views)
+	for i := range cfg.Views {
+		cfg.Views[i].Project = redact.Name("project", cfg.Views[i].Project)
+	}
+	cfg.Daemon.Project = redact.Name("project", cfg.Daemon.Project)
+	cfg.Daemon.DataDir = redact.Name("path", cfg.Daemon.DataDir)
+	cfg.Daemon.Sinks = slices.Clone(cfg.Daemon.Sinks)
+	for i := range cfg.Daemon.Sinks {
+		cfg.Daemon.Sinks[i].Path = redact.Name("path", cfg.Daemon.Sinks[i].Path)
+	}
+	return cfg
+}
+
+func debugCacheStats(cache *store.Cache) (map[string]any, error) {
+	sessions, err := cache.SessionCount()
+	if err != nil {
+		return nil, fmt.Errorf("counting sessions: %w", err)
+	}
+	tracked, err := cache.GetTrackedFiles()
+	if err != nil {
+		return nil, fmt.Errorf("reading tracked files: %w", err)
+	}
+	quarantined, err := cache.QuarantinedFiles()
+	if err != nil {
+		return nil, fmt.Errorf("reading quarantined files: %w", err)
+	}
+	dups, err := cache.DuplicateFiles()
+	if err != nil {
+		return nil, fmt.Errorf("reading duplicate files: %w", err)
+	}
+	sources, err := cache.ListSources()
+	if err != nil {
+		return nil, fmt.Errorf("listing sources: %w", err)
+	}
+	var compressed int
+	for p := range tracked {
+		if source.IsCompressed(p) {
+			compressed++
+		}
+	}
+	return map[string]any{
+		"disk_bytes":         store.DiskUsage(pipeline.CachePath()),
+		"sessions":           sessions,
+		"tracked_files":      len(tracked),
+		"compressed_files":   compressed,
+		"quarantined_files":  len(quarantined),
+		"duplicate_files":    len(dups),
+		"imported_sources":   len(sources),
+		"subagents_included": !flagNoSubagents,
+	}, nil
+}
+
+// debugIssue is a parse issue with its path masked and content-free
+// samples of the offending lines.
+type debugIssue struct {
+	File             string        `json:"file"`
+	ParseErrors      int           `json:"parse_errors"`
+	SkewedTimestamps int           `json:"skewed_timestamps"`
+	Err              string        `json:"error,omitempty"`
+	Quarantined      bool          `json:"quarantined,omitempty"`
+	SeenAt           time.Time     `json:"seen_at"`
+	Samples          []debugSample `json:"samples,omitempty"`
+}
+
+type debugSample struct {
+	Line  int    `json:"line"`
+	Bytes int    `json:"bytes"`
+	Shape string `json:"shape"`
+}
+
+func debugParseIssues(issues []store.ParseIssue) []debugIssue {
+	out := make([]debugIssue, 0, len(issues))
+	sampled := 0
+	for _, pi := range issues {
+		di := debugIssue{
+			File:             redact.Path(pi.FilePath),
+			ParseErrors:      pi.ParseErrors,
+			SkewedTimestamps: pi.SkewedTimestamps,
+			Err:              strings.ReplaceAll(pi.Err, pi.FilePath, redact.Path(pi.FilePath)),
+			Quarantined:      pi.Quarantined,
+			SeenAt:           pi.SeenAt,
+		}
+		if pi.ParseErrors > 0 && sampled < debugSampleFiles {
+			sampled++
+			bad, _ := source.MalformedLines(pi.FilePath, debugSampleLines)
+			for _, b := range bad {
+				di.Samples = append(di.Samples, debugSample{
+					Line:  b.Num,
+					Bytes: len(b.Line),
+					Shape: redact.Line(b.Line, debugSampleBytes),
+				})
+			}
+		}
+		out = append(out, di)
+	}
+	return out
+}
+
+func writeDebugBundle(path string, files []debugFile, at time.Time) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600) //nolint:gosec // user-chosen output path
+	if err != nil {
+		return err
+	}
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for _, df := range files {
+		hdr := &tar.Header{Name: df.name, Mode: 0o600, Size: int64(len(df.data)), ModTime: at}
+		if err := tw.WriteHeader(hdr); err != nil {
+			_ = f.Close()
+			return err
+		}
+		if _, err := tw.Write(df.data); err != nil {
+			_ = f.Close()
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/theirongolddev/cburn/internal/config"
+
+	"github.com/BurntSushi/toml"
+)
+
+func TestAnonymizeConfig(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.General.ClaudeDir = "/home/alice/.claude"
+	cfg.Projects.Rules = []config.ProjectRule{{Match: "~/work/acme-rules", Tag: "acme-tag"}}
+	cfg.Projects.Aliases = []config.ProjectAlias{{Match: "/home/alice/src/acme-api", Project: "acme-billing"}}
+	cfg.Projects.Automation = []string{"~/ci/acme-agent"}
+	cfg.Daemon.DataDir = "/home/alice/claude-data"
+	cfg.Daemon.Sinks = []config.Sink{{Type: "file", Path: "/home/alice/events.jsonl"}}
+
+	var buf strings.Builder
+	if err := toml.NewEncoder(&buf).Encode(anonymizeConfig(cfg.Redacted())); err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{"alice", "acme"} {
+		if strings.Contains(buf.String(), s) {
+			t.Errorf("anonymized config contains %q:\n%s", s, buf.String())
+		}
+	}
+	if cfg.Projects.Aliases[0].Project != "acme-billing" {
+		t.Error("anonymizeConfig modified the original aliases")
+	}
+	if cfg.Projects.Automation[0] != "~/ci/acme-agent" {
+		t.Error("anonymizeConfig modified the original automation patterns")
+	}
+	if cfg.Daemon.Sinks[0].Path != "/home/alice/events.jsonl" {
+		t.Error("anonymizeConfig modified the original sinks")
+	}
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/theirongolddev/cburn/internal/cli"
+	"github.com/theirongolddev/cburn/internal/fixtures"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	fixturesOut       string
+	fixturesSessions  int
+	fixturesProjects  int
+	fixturesDays      int
+	fixturesTurns     int
+	fixturesModels    string
+	fixturesCache     string
+	fixturesSubagents float64
+	fixturesSeed      uint64
+)
+
+var devtoolsCmd = &cobra.Command{
+	Use:    "devtools",
+	Short:  "Tools for developing and debugging cburn",
+	Hidden: true,
+}
+
+var genFixturesCmd = &cobra.Command{
+	Use:   "gen-fixtures",
+	Short: "Write synthetic Claude Code session files",
+	Long: "Synthesizes realistic JSONL sessions (no real prompts or code) in the ~/.claude\n" +
+		"layout, for reproducing bugs without sharing your data. Point any command at\n" +
+		"the output with --data-dir.",
+	Args: cobra.NoArgs,
+	RunE: runGenFixtures,
+}
+
+func init() {
+	f := genFixturesCmd.Flags()
+	f.StringVarP(&fixturesOut, "out", "o", "", "Output directory (default: a new temp dir)")
+	f.IntVar(&fixturesSessions, "sessions", 20, "Number of top-level sessions")
+	f.IntVar(&fixturesProjects, "projects", 3, "Number of projects to spread sessions across")
+	f.IntVar(&fixturesDays, "days", 14, "Spread session starts over this many days before now")
+	f.IntVar(&fixturesTurns, "max-turns", 8, "Most prompts in one session")
+	f.StringVar(&fixturesModels, "models", strings.Join(fixtures.DefaultModels, ","), "Comma-separated model IDs sessions pick from")
+	f.StringVar(&fixturesCache, "cache", fixtures.CacheMixed, "Cache pattern: mixed, warm, cold, or none")
+	f.Float64Var(&fixturesSubagents, "subagents", 0.25, "Fraction of sessions that spawn a subagent")
+	f.Uint64Var(&fixturesSeed, "seed", 1, "Random seed; the same seed writes the same files")
+	devtoolsCmd.AddCommand(genFixturesCmd)
+	rootCmd.AddCommand(devtoolsCmd)
+}
+
+func runGenFixtures(cmd *cobra.Command, _ []string) error {
+	dir := fixturesOut
+	if dir == "" {
+		var err error
+		if dir, err = os.MkdirTemp("", "cburn-fixtures-"); err != nil {
+			return fmt.Errorf("creating output dir: %w", err)
+		}
+	}
+	subagents := fixturesSubagents
+	if subagents == 0 {
+		subagents = -1 // Options treats 0 as the default rate
+	}
+	var models []string
+	for _, m := range strings.Split(fixturesModels, ",") {
+		if m = strings.TrimSpace(m); m != "" {
+			models = append(models, m)
+		}
+	}
+
+	res, err := fixtures.Generate(dir, fixtures.Options{
+		Sessions:  fixturesSessions,
+		Projects:  fixturesProjects,
+		Days:      fixturesDays,
+		MaxTurns:  fixturesTurns,
+		Models:    models,
+		Cache:     fixturesCache,
+		Subagents: subagents,
+		Seed:      fixturesSeed,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "  Wrote %s sessions (%s subagents, %s API calls) to %s\n",
+		cli.FormatNumber(int64(res.Sessions)), cli.FormatNumber(int64(res.Subagents)),
+		cli.FormatNumber(int64(res.APICalls)), res.Dir)
+	fmt.Fprintf(cmd.OutOrStdout(), "  Try: cburn --data-dir %s --no-cache summary\n", res.Dir)
+	return nil
+}
@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/cli"
+	"github.com/theirongolddev/cburn/internal/pipeline"
+	"github.com/theirongolddev/cburn/internal/redact"
+	"github.com/theirongolddev/cburn/internal/store"
+
+	"github.com/spf13/cobra"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "List session files that failed to parse or were skipped as duplicates",
+	Long: "Scans your sessions, then lists files that could not be read, had malformed\n" +
+		"lines, or had timestamps before 2023 or in the future (those are ignored so they\n" +
+		"don't distort daily charts). Files with read errors are retried on every load;\n" +
+		"quarantine one to skip it until you release it.\n\n" +
+		"It also lists copies of a session found under more than one path, as when\n" +
+		"~/.claude is synced between machines. Only the most complete copy is counted.",
+	Args: cobra.NoArgs,
+	RunE: runDoctor,
+}
+
+var doctorQuarantineCmd = &cobra.Command{
+	Use:   "quarantine <file>",
+	Short: "Stop loading a session file",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runDoctorQuarantine,
+}
+
+var doctorReleaseCmd = &cobra.Command{
+	Use:   "release <file>",
+	Short: "Load a quarantined session file again",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runDoctorRelease,
+}
+
+func init() {
+	doctorCmd.AddCommand(doctorQuarantineCmd, doctorReleaseCmd)
+	rootCmd.AddCommand(doctorCmd)
+}
+
+// errDoctorNoCache is returned when --no-cache leaves nothing to inspect.
+var errDoctorNoCache = errors.New("parse diagnostics are kept in the cache; drop --no-cache")
+
+// openDoctorCache opens the session cache, which holds the parse issue list.
+func openDoctorCache() (*store.Cache, error) {
+	if flagNoCache {
+		return nil, errDoctorNoCache
+	}
+	cache, err := store.Open(pipeline.CachePath())
+	if err != nil {
+		return nil, fmt.Errorf("opening cache: %w", err)
+	}
+	return cache, nil
+}
+
+func runDoctor(_ *cobra.Command, _ []string) error {
+	if flagNoCache {
+		return errDoctorNoCache
+	}
+	// Load first so the list reflects the files as they are now.
+	if _, err := loadSessions(); err != nil {
+		return err
+	}
+	cache, err := openDoctorCache()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = cache.Close() }()
+
+	issues, err := cache.ParseIssues()
+	if err != nil {
+		return fmt.Errorf("reading parse issues: %w", err)
+	}
+	dups, err := cache.DuplicateFiles()
+	if err != nil {
+		return fmt.Errorf("reading duplicate files: %w", err)
+	}
+
+	home, _ := os.UserHomeDir()
+	printParseIssues(issues, home)
+	if len(dups) > 0 {
+		printDuplicates(dups, home)
+	}
+	return nil
+}
+
+// printParseIssues lists the files that had problems parsing.
+func printParseIssues(issues []store.ParseIssue, home string) {
+	fmt.Println()
+	fmt.Println(cli.RenderTitle("PARSE DIAGNOSTICS"))
+	fmt.Println()
+	if len(issues) == 0 {
+		fmt.Println("  Every session file parsed cleanly.")
+		fmt.Println()
+		return
+	}
+
+	rows := make([][]string, 0, len(issues))
+	for _, pi := range issues {
+		status := "skipped"
+		switch {
+		case pi.Quarantined:
+			status = "quarantined"
+		case pi.Err != "":
+			status = "retried each load"
+		}
+		problem := pi.Err
+		switch {
+		case problem != "":
+		case pi.ParseErrors > 0 && pi.SkewedTimestamps > 0:
+			problem = "malformed JSON, implausible timestamps"
+		case pi.ParseErrors > 0:
+			problem = "malformed JSON"
+		case pi.SkewedTimestamps > 0:
+			problem = "implausible timestamps"
+		}
+		rows = append(rows, []string{
+			shortenHome(pi.FilePath, home),
+			cli.FormatNumber(int64(pi.ParseErrors)),
+			cli.FormatNumber(int64(pi.SkewedTimestamps)),
+			problem,
+			formatSeen(pi.SeenAt),
+			status,
+		})
+	}
+
+	fmt.Print(cli.RenderTable(cli.Table{
+		Headers:  []string{"File", "Bad Lines", "Bad Times", "Problem", "Last Seen", "Status"},
+		Optional: []int{4, 3},
+		Rows:     rows,
+	}))
+
+	fmt.Println()
+	fmt.Println("  Quarantine a file with `cburn doctor quarantine <file>`;")
+	fmt.Println("  load it again with `cburn doctor release <file>`.")
+	fmt.Println()
+}
+
+// printDuplicates lists session files skipped as copies of another file.
+func printDuplicates(dups []store.DuplicateFile, home string) {
+	fmt.Println(cli.RenderTitle("DUPLICATE SESSIONS"))
+	fmt.Println()
+
+	rows := make([][]string, 0, len(dups))
+	for _, d := range dups {
+		rows = append(rows, []string{
+			shortenHome(redact.Path(d.FilePath), home),
+			shortenHome(redact.Path(d.KeptPath), home),
+			redact.ID(d.SessionID),
+			formatSeen(d.SeenAt),
+		})
+	}
+	fmt.Print(cli.RenderTable(cli.Table{
+		Headers:  []string{"Skipped Copy", "Counted Copy", "Session", "Last Seen"},
+		Optional: []int{3, 2},
+		Rows:     rows,
+	}))
+
+	fmt.Println()
+	fmt.Println("  Only the most complete copy of a session is counted.")
+	fmt.Println()
+}
+
+func runDoctorQuarantine(_ *cobra.Command, args []string) error {
+	path, err := doctorPath(args[0])
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); err != nil {
+		return err
+	}
+	cache, err := openDoctorCache()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = cache.Close() }()
+
+	if err := cache.Quarantine(path, time.Now()); err != nil {
+		return fmt.Errorf("quarantining %s: %w", path, err)
+	}
+	fmt.Printf("  Quarantined %s; it is skipped until released.\n", path)
+	return nil
+}
+
+func runDoctorRelease(_ *cobra.Command, args []string) error {
+	path, err := doctorPath(args[0])
+	if err != nil {
+		return err
+	}
+	cache, err := openDoctorCache()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = cache.Close() }()
+
+	released, err := cache.Release(path)
+	if err != nil {
+		return fmt.Errorf("releasing %s: %w", path, err)
+	}
+	if !released {
+		return fmt.Errorf("%s is not quarantined", path)
+	}
+	fmt.Printf("  Released %s; it is parsed again on the next load.\n", path)
+	return nil
+}
+
+// doctorPath resolves a file argument to the absolute path the cache uses.
+func doctorPath(arg string) (string, error) {
+	if home, err := os.UserHomeDir(); err == nil && strings.HasPrefix(arg, "~/") {
+		arg = filepath.Join(home, arg[2:])
+	}
+	path, err := filepath.Abs(arg)
+	if err != nil {
+		return "", fmt.Errorf("resolving %s: %w", arg, err)
+	}
+	return path, nil
+}
+
+// shortenHome abbreviates a path under home with "~".
+func shortenHome(path, home string) string {
+	if home != "" && strings.HasPrefix(path, home+string(filepath.Separator)) {
+		return "~" + path[len(home):]
+	}
+	return path
+}
@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/cli"
+	"github.com/theirongolddev/cburn/internal/pipeline"
+
+	"github.com/spf13/cobra"
+)
+
+// gateExitExceeded is the exit code when spend is over --max-cost, kept
+// apart from 1 (cburn itself failed) so a pipeline can tell the two apart.
+const gateExitExceeded = 2
+
+// errGateExceeded is returned by runGate when spend is over --max-cost;
+// Execute exits with gateExitExceeded for it.
+var errGateExceeded = errors.New("estimated spend exceeds --max-cost")
+
+// gateWarnFraction is the share of --max-cost at which the github format
+// raises a warning instead of a notice.
+const gateWarnFraction = 0.8
+
+var (
+	flagGateMaxCost float64
+	flagGateFormat  string
+)
+
+var gateCmd = &cobra.Command{
+	Use:   "gate",
+	Short: "Fail when spend in the window exceeds a limit, for CI pipelines",
+	Long: "Exits 0 while estimated spend in the window (--days, --from/--to, and the usual\n" +
+		"filters) is at most --max-cost, and 2 when it is over. --format json prints the\n" +
+		"result as JSON; --format github prints a GitHub Actions annotation and, under\n" +
+		"Actions, writes cost, max_cost, and passed to $GITHUB_OUTPUT.",
+	Example: "  cburn gate --max-cost 50 --days 1\n" +
+		"  cburn gate --max-cost 200 -n 7 -p ci-agent --format github",
+	Args: cobra.NoArgs,
+	RunE: runGate,
+}
+
+func init() {
+	gateCmd.Flags().Float64Var(&flagGateMaxCost, "max-cost", 0, "Largest estimated spend in USD that passes")
+	gateCmd.Flags().StringVar(&flagGateFormat, "format", "text", "Output: text, json, or github (Actions annotations)")
+	_ = gateCmd.MarkFlagRequired("max-cost")
+	rootCmd.AddCommand(gateCmd)
+}
+
+// gateResult is what `cburn gate --format json` prints.
+type gateResult struct {
+	Passed     bool      `json:"passed"`
+	CostUSD    float64   `json:"cost_usd"`
+	MaxCostUSD float64   `json:"max_cost_usd"`
+	Sessions   int       `json:"sessions"`
+	APICalls   int       `json:"api_calls"`
+	Tokens     int64     `json:"tokens"`
+	Since      time.Time `json:"since"`
+	Until      time.Time `json:"until"`
+	Project    string    `json:"project,omitempty"`
+	Model      string    `json:"model,omitempty"`
+}
+
+func runGate(cmd *cobra.Command, _ []string) error {
+	if flagGateMaxCost <= 0 {
+		return errors.New("--max-cost must be greater than 0")
+	}
+	switch flagGateFormat {
+	case "text", "json", "github":
+	default:
+		return fmt.Errorf("unknown --format %q (want text, json, or github)", flagGateFormat)
+	}
+
+	result, err := loadData()
+	if err != nil {
+		return err
+	}
+	filtered, since, until := applyFilters(result.Sessions)
+	stats := pipeline.Aggregate(filtered, since, until)
+	res := gateResult{
+		Passed:     stats.EstimatedCost <= flagGateMaxCost,
+		CostUSD:    stats.EstimatedCost,
+		MaxCostUSD: flagGateMaxCost,
+		Sessions:   stats.TotalSessions,
+		APICalls:   stats.TotalAPICalls,
+		Tokens:     stats.TotalBilledTokens,
+		Since:      since,
+		Until:      until,
+		Project:    flagProject,
+		Model:      flagModel,
+	}
+
+	switch flagGateFormat {
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(res); err != nil {
+			return err
+		}
+	case "github":
+		fmt.Println(gateAnnotation(res))
+		if err := writeGitHubOutput(res); err != nil {
+			return err
+		}
+	default:
+		verdict := "PASS"
+		if !res.Passed {
+			verdict = "FAIL"
+		}
+		fmt.Printf("  %s  %s\n", verdict, gateSummary(res))
+	}
+
+	if !res.Passed {
+		// The verdict is already printed; only the exit code is left.
+		cmd.SilenceErrors, cmd.SilenceUsage = true, true
+		return errGateExceeded
+	}
+	return nil
+}
+
+// gateSummary is the one-line description of a gate result. Costs stay in
+// USD, the currency of --max-cost, whatever [currency] says.
+func gateSummary(r gateResult) string {
+	return fmt.Sprintf("$%.2f spent of a $%.2f limit (%s): %d sessions, %s API calls, %s tokens",
+		r.CostUSD, r.MaxCostUSD, periodLabel(),
+		r.Sessions, cli.FormatNumber(int64(r.APICalls)), cli.FormatTokens(r.Tokens))
+}
+
+// gateAnnotation is the GitHub Actions workflow command for r: an error
+// when over the limit, a warning from gateWarnFraction of it, else a notice.
+func gateAnnotation(r gateResult) string {
+	level := "notice"
+	switch {
+	case !r.Passed:
+		level = "error"
+	case r.CostUSD >= gateWarnFraction*r.MaxCostUSD:
+		level = "warning"
+	}
+	return fmt.Sprintf("::%s title=%s::%s", level, ghEscapeProperty("Claude usage gate"), ghEscapeData(gateSummary(r)))
+}
+
+// writeGitHubOutput appends the result to $GITHUB_OUTPUT for later steps;
+// outside Actions it does nothing.
+func writeGitHubOutput(r gateResult) error {
+	path := os.Getenv("GITHUB_OUTPUT")
+	if path == "" {
+		return nil
+	}
+	//nolint:gosec // path is set by the GitHub Actions runner
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(f, "cost=%.4f\nmax_cost=%.4f\npassed=%t\n", r.CostUSD, r.MaxCostUSD, r.Passed)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// ghEscapeData escapes a workflow command message.
+func ghEscapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// ghEscapeProperty escapes a workflow command property value.
+func ghEscapeProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
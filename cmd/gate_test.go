@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGateAnnotation(t *testing.T) {
+	tests := []struct {
+		name string
+		res  gateResult
+		want string
+	}{
+		{"notice", gateResult{Passed: true, CostUSD: 10, MaxCostUSD: 50}, "::notice title=Claude usage gate::$10.00 spent of a $50.00 limit"},
+		{"warning", gateResult{Passed: true, CostUSD: 40, MaxCostUSD: 50}, "::warning title=Claude usage gate::$40.00 spent"},
+		{"error", gateResult{Passed: false, CostUSD: 60, MaxCostUSD: 50}, "::error title=Claude usage gate::$60.00 spent"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := gateAnnotation(tt.res); !strings.HasPrefix(got, tt.want) {
+				t.Errorf("gateAnnotation = %q, want prefix %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGHEscape(t *testing.T) {
+	tests := []struct {
+		in, data, property string
+	}{
+		{"plain", "plain", "plain"},
+		{"50%", "50%25", "50%25"},
+		{"a\r\nb", "a%0D%0Ab", "a%0D%0Ab"},
+		{"key: a, b", "key: a, b", "key%3A a%2C b"},
+		{"%0A", "%250A", "%250A"},
+	}
+	for _, tt := range tests {
+		if got := ghEscapeData(tt.in); got != tt.data {
+			t.Errorf("ghEscapeData(%q) = %q, want %q", tt.in, got, tt.data)
+		}
+		if got := ghEscapeProperty(tt.in); got != tt.property {
+			t.Errorf("ghEscapeProperty(%q) = %q, want %q", tt.in, got, tt.property)
+		}
+	}
+}
+
+func TestWriteGitHubOutput(t *testing.T) {
+	t.Setenv("GITHUB_OUTPUT", "")
+	if err := writeGitHubOutput(gateResult{}); err != nil {
+		t.Errorf("outside Actions: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "output")
+	if err := os.WriteFile(path, []byte("earlier=1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GITHUB_OUTPUT", path)
+	if err := writeGitHubOutput(gateResult{Passed: false, CostUSD: 60.125, MaxCostUSD: 50}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "earlier=1\ncost=60.1250\nmax_cost=50.0000\npassed=false\n"; string(got) != want {
+		t.Errorf("$GITHUB_OUTPUT = %q, want %q", got, want)
+	}
+}
@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/theirongolddev/cburn/internal/cli"
 	"github.com/theirongolddev/cburn/internal/pipeline"
@@ -34,7 +33,7 @@ func runHourly(_ *cobra.Command, _ []string) error {
 	hours := pipeline.AggregateHourly(filtered, since, until)
 
 	fmt.Println()
-	fmt.Println(cli.RenderTitle(fmt.Sprintf("ACTIVITY BY HOUR  Last %dd (local time)", flagDays)))
+	fmt.Println(cli.RenderTitle("ACTIVITY BY HOUR  " + periodLabel() + " (local time)"))
 	fmt.Println()
 
 	// Find max for bar scaling
@@ -51,10 +50,11 @@ func runHourly(_ *cobra.Command, _ []string) error {
 		if maxPrompts > 0 {
 			barLen = h.Prompts * maxBarWidth / maxPrompts
 		}
-		bar := strings.Repeat("█", barLen)
+		bar, _ := cli.BarCells(barLen, 0)
 
 		promptStr := cli.FormatNumber(int64(h.Prompts))
-		fmt.Printf("  %02d:00 │ %6s │ %s\n", h.Hour, promptStr, bar)
+		sep := cli.VerticalRule()
+		fmt.Printf("  %02d:00 %s %6s %s %s\n", h.Hour, sep, promptStr, sep, bar)
 	}
 
 	// Find peak hour
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/theirongolddev/cburn/internal/bundle"
+	"github.com/theirongolddev/cburn/internal/cli"
+	"github.com/theirongolddev/cburn/internal/pipeline"
+	"github.com/theirongolddev/cburn/internal/store"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagImportLabel  string
+	flagImportList   bool
+	flagImportRemove string
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import <dir-or-archive>",
+	Short: "Import another machine's Claude sessions under a source label",
+	Long: "Parses another machine's ~/.claude directory (a directory, .tar.gz, or .zip) or a bundle from\n" +
+		"`cburn bundle export`, and stores its sessions in the cache under --label.\n" +
+		"Re-importing the same label replaces the old data.\n" +
+		"Filter any command with --source <label> (or --source local for this machine).",
+	Args: cobra.MaximumNArgs(1),
+	RunE: runImport,
+}
+
+func init() {
+	importCmd.Flags().StringVarP(&flagImportLabel, "label", "l", "", "Source label for the imported sessions (default: directory or archive name)")
+	importCmd.Flags().BoolVar(&flagImportList, "list", false, "List imported sources")
+	importCmd.Flags().StringVar(&flagImportRemove, "remove", "", "Remove all sessions imported under this label")
+	rootCmd.AddCommand(importCmd)
+}
+
+func runImport(_ *cobra.Command, args []string) error {
+	cache, err := store.Open(pipeline.CachePath())
+	if err != nil {
+		return fmt.Errorf("opening cache: %w", err)
+	}
+	defer func() { _ = cache.Close() }()
+
+	switch {
+	case flagImportList:
+		return renderSources(cache)
+	case flagImportRemove != "":
+		n, err := cache.DeleteSource(flagImportRemove)
+		if err != nil {
+			return fmt.Errorf("removing source: %w", err)
+		}
+		if n == 0 {
+			return fmt.Errorf("no imported source named %q", flagImportRemove)
+		}
+		fmt.Printf("  Removed %d sessions from source %q\n", n, flagImportRemove)
+		return nil
+	}
+
+	if len(args) == 0 {
+		return fmt.Errorf("import needs a directory or archive (or --list / --remove)")
+	}
+	src := args[0]
+	if _, err := os.Stat(src); err != nil {
+		return fmt.Errorf("import source: %w", err)
+	}
+
+	// Bundles from `cburn bundle export` carry their own label.
+	if pipeline.IsArchive(src) {
+		err := importBundle(cache, src, flagImportLabel)
+		if !errors.Is(err, bundle.ErrNotBundle) {
+			return err
+		}
+	}
+
+	label := flagImportLabel
+	if label == "" {
+		label = defaultImportLabel(src)
+	}
+	if err := pipeline.ValidateSourceLabel(label); err != nil {
+		return err
+	}
+
+	root := src
+	if pipeline.IsArchive(src) {
+		tmp, err := os.MkdirTemp("", "cburn-import-")
+		if err != nil {
+			return err
+		}
+		defer func() { _ = os.RemoveAll(tmp) }()
+		if err := pipeline.ExtractArchive(src, tmp); err != nil {
+			return fmt.Errorf("extracting %s: %w", src, err)
+		}
+		root = tmp
+	}
+
+	claudeDir, err := pipeline.FindClaudeDir(root)
+	if err != nil {
+		return err
+	}
+
+	if !flagQuiet {
+		fmt.Fprintf(os.Stderr, "  Importing %s as %q...\n", src, label)
+	}
+	n, err := pipeline.ImportSource(cache, label, claudeDir, nil)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("  Imported %s sessions under source %q\n", formatNumber(int64(n)), label)
+	return nil
+}
+
+// defaultImportLabel derives a label from the import path's base name,
+// e.g. "alice-laptop.tar.gz" -> "alice-laptop".
+func defaultImportLabel(src string) string {
+	base := filepath.Base(filepath.Clean(src))
+	for _, ext := range []string{".tar.gz", ".tgz", ".zip"} {
+		if strings.HasSuffix(strings.ToLower(base), ext) {
+			base = base[:len(base)-len(ext)]
+			break
+		}
+	}
+	return strings.TrimPrefix(base, ".")
+}
+
+func renderSources(cache *store.Cache) error {
+	sources, err := cache.ListSources()
+	if err != nil {
+		return fmt.Errorf("listing sources: %w", err)
+	}
+	if len(sources) == 0 {
+		fmt.Println("\n  No imported sources. Add one with: cburn import <dir-or-archive> --label <name>")
+		return nil
+	}
+
+	rows := make([][]string, 0, len(sources))
+	for _, si := range sources {
+		last := "-"
+		if !si.LastActivity.IsZero() {
+			last = si.LastActivity.Local().Format("2006-01-02")
+		}
+		rows = append(rows, []string{
+			si.Source,
+			formatNumber(int64(si.Sessions)),
+			cli.FormatCost(si.EstimatedCost),
+			last,
+		})
+	}
+
+	fmt.Println()
+	fmt.Println(cli.RenderTitle("IMPORTED SOURCES"))
+	fmt.Println()
+
+	fmt.Print(cli.RenderTable(cli.Table{
+		Headers:  []string{"Source", "Sessions", "Cost", "Last Active"},
+		Optional: []int{3},
+		Rows:     rows,
+	}))
+	return nil
+}
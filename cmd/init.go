@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/config"
+	"github.com/theirongolddev/cburn/internal/tui/theme"
+
+	"github.com/spf13/cobra"
+)
+
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Write the config file non-interactively",
+	Long: "Writes the config file from flags, for scripted setup of a new machine.\n" +
+		"Settings not given keep their current value (or the default). --days sets\n" +
+		"the default time range. Keys may also come from CLAUDE_SESSION_KEY and\n" +
+		"ANTHROPIC_ADMIN_KEY at run time instead of being stored.",
+	Example: "  cburn init --session-key sk-ant-sid01-... --days 30 --theme tokyo-night --json",
+	Args:    cobra.NoArgs,
+	RunE:    runInit,
+}
+
+var (
+	initSessionKey string
+	initAdminKey   string
+	initTheme      string
+	initJSON       bool
+)
+
+func init() {
+	initCmd.Flags().StringVar(&initSessionKey, "session-key", "", "Claude.ai session key for subscription data")
+	initCmd.Flags().StringVar(&initAdminKey, "admin-key", "", "Anthropic Admin API key for billing data")
+	initCmd.Flags().StringVar(&initTheme, "theme", "", "Color theme, e.g. tokyo-night")
+	initCmd.Flags().BoolVar(&initJSON, "json", false, "Print the resulting config as JSON, secrets redacted")
+	rootCmd.AddCommand(initCmd)
+}
+
+func runInit(cmd *cobra.Command, _ []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	if key := strings.TrimSpace(initSessionKey); key != "" {
+		cfg.ClaudeAI.SessionKey = key
+		cfg.ClaudeAI.SessionExpires = time.Time{} // hand-entered keys have no known expiry
+	}
+	if key := strings.TrimSpace(initAdminKey); key != "" {
+		cfg.AdminAPI.APIKey = key
+	}
+	if cmd.Flags().Changed("days") {
+		if flagDays <= 0 {
+			return fmt.Errorf("--days must be positive, got %d", flagDays)
+		}
+		cfg.General.DefaultDays = flagDays
+	}
+	if initTheme != "" {
+		if !knownTheme(initTheme) {
+			return fmt.Errorf("unknown theme %q (available: %s)", initTheme, strings.Join(themeNames(), ", "))
+		}
+		cfg.Appearance.Theme = initTheme
+	}
+
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("saving config: %w", err)
+	}
+
+	if !initJSON {
+		fmt.Printf("  Saved to %s\n", config.Path())
+		return nil
+	}
+
+	// Secrets and webhook URLs stay out of the printed copy, which may end
+	// up in logs.
+	m, err := config.AsMap(cfg.Redacted())
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(map[string]any{"path": config.Path(), "config": m})
+}
+
+func knownTheme(name string) bool {
+	for _, t := range theme.All {
+		if t.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func themeNames() []string {
+	names := make([]string, len(theme.All))
+	for i, t := range theme.All {
+		names[i] = t.Name
+	}
+	return names
+}
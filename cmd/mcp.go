@@ -0,0 +1,263 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/claudeai"
+	"github.com/theirongolddev/cburn/internal/config"
+	"github.com/theirongolddev/cburn/internal/mcp"
+	"github.com/theirongolddev/cburn/internal/model"
+	"github.com/theirongolddev/cburn/internal/pipeline"
+
+	"github.com/spf13/cobra"
+)
+
+var mcpCmd = &cobra.Command{
+	Use:   "mcp",
+	Short: "Run a Model Context Protocol server exposing usage data over stdio",
+	Long: "Lets Claude Code agents query their own spend. Register it with:\n" +
+		"  claude mcp add cburn -- cburn mcp\n\n" +
+		"Tools: get_usage_summary, get_session_cost, get_rate_limits.",
+	RunE: runMCP,
+}
+
+func init() {
+	rootCmd.AddCommand(mcpCmd)
+}
+
+func runMCP(_ *cobra.Command, _ []string) error {
+	// stdout carries the protocol; keep loaders silent.
+	flagQuiet = true
+
+	srv := mcp.NewServer("cburn", "1")
+	srv.AddTool(mcp.Tool{
+		Name:        "get_usage_summary",
+		Description: "Claude Code usage totals (sessions, tokens, estimated cost, cache hit rate, top models) over the last N days.",
+		InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"days":    map[string]any{"type": "integer", "description": "Time window in days (default 30)"},
+				"project": map[string]any{"type": "string", "description": "Project name substring filter"},
+			},
+		},
+		Handler: mcpUsageSummary,
+	})
+	srv.AddTool(mcp.Tool{
+		Name: "get_session_cost",
+		Description: "Cost and tokens of one Claude Code session, including its subagents. " +
+			"Defaults to the most recent session in the server's working directory.",
+		InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"session_id": map[string]any{"type": "string", "description": "Session ID or unique prefix (optional)"},
+			},
+		},
+		Handler: mcpSessionCost,
+	})
+	srv.AddTool(mcp.Tool{
+		Name:        "get_rate_limits",
+		Description: "claude.ai subscription rate-limit windows (utilization and reset times) with a scheduling hint. Requires a configured session key.",
+		Handler:     mcpRateLimits,
+	})
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+	return srv.Serve(ctx, os.Stdin, os.Stdout)
+}
+
+type mcpModelUsage struct {
+	Model   string  `json:"model"`
+	CostUSD float64 `json:"cost_usd"`
+	Calls   int     `json:"api_calls"`
+}
+
+func mcpUsageSummary(_ context.Context, raw json.RawMessage) (any, error) {
+	var args struct {
+		Days    int    `json:"days"`
+		Project string `json:"project"`
+	}
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+	if args.Days <= 0 {
+		args.Days = flagDays
+	}
+
+	result, err := loadData()
+	if err != nil {
+		return nil, err
+	}
+	sessions := pipeline.FilterByProject(result.Sessions, args.Project)
+	now := time.Now()
+	since := now.AddDate(0, 0, -args.Days)
+	stats := pipeline.Aggregate(sessions, since, now)
+
+	var top []mcpModelUsage
+	for i, ms := range pipeline.AggregateModels(sessions, since, now) {
+		if i == 3 {
+			break
+		}
+		top = append(top, mcpModelUsage{Model: ms.Model, CostUSD: round2(ms.EstimatedCost), Calls: ms.APICalls})
+	}
+
+	return map[string]any{
+		"days":               args.Days,
+		"project_filter":     args.Project,
+		"sessions":           stats.TotalSessions,
+		"prompts":            stats.TotalPrompts,
+		"api_calls":          stats.TotalAPICalls,
+		"total_tokens":       stats.TotalBilledTokens,
+		"estimated_cost_usd": round2(stats.EstimatedCost),
+		"cost_per_day_usd":   round2(stats.CostPerDay),
+		"cache_hit_rate":     round2(stats.CacheHitRate),
+		"top_models":         top,
+	}, nil
+}
+
+func mcpSessionCost(_ context.Context, raw json.RawMessage) (any, error) {
+	var args struct {
+		SessionID string `json:"session_id"`
+	}
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	result, err := loadData()
+	if err != nil {
+		return nil, err
+	}
+
+	sess, err := findSession(result.Sessions, args.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var subCost float64
+	subCount := 0
+	for _, s := range result.Sessions {
+		if s.IsSubagent && s.ParentSession == sess.SessionID {
+			subCost += s.EstimatedCost
+			subCount++
+		}
+	}
+
+	models := make([]mcpModelUsage, 0, len(sess.Models))
+	for name, mu := range sess.Models {
+		models = append(models, mcpModelUsage{Model: name, CostUSD: round2(mu.EstimatedCost), Calls: mu.APICalls})
+	}
+
+	return map[string]any{
+		"session_id":         sess.SessionID,
+		"project":            sess.Project,
+		"start_time":         sess.StartTime,
+		"last_activity":      sess.EndTime,
+		"prompts":            sess.UserMessages,
+		"api_calls":          sess.APICalls,
+		"input_tokens":       sess.InputTokens,
+		"output_tokens":      sess.OutputTokens,
+		"cache_read_tokens":  sess.CacheReadTokens,
+		"estimated_cost_usd": round2(sess.EstimatedCost),
+		"subagents":          subCount,
+		"subagent_cost_usd":  round2(subCost),
+		"total_cost_usd":     round2(sess.EstimatedCost + subCost),
+		"models":             models,
+	}, nil
+}
+
+// findSession resolves an ID or unique prefix, or with an empty ID, the most
+// recently active top-level session in the working directory (falling back
+// to the most recent session anywhere).
+func findSession(sessions []model.SessionStats, id string) (model.SessionStats, error) {
+	if id != "" {
+		var match []model.SessionStats
+		for _, s := range sessions {
+			if s.SessionID == id {
+				return s, nil
+			}
+			if strings.HasPrefix(s.SessionID, id) {
+				match = append(match, s)
+			}
+		}
+		switch len(match) {
+		case 0:
+			return model.SessionStats{}, fmt.Errorf("no session matching %q", id)
+		case 1:
+			return match[0], nil
+		default:
+			return model.SessionStats{}, fmt.Errorf("session prefix %q is ambiguous (%d matches)", id, len(match))
+		}
+	}
+
+	cwd, _ := os.Getwd()
+	var newest, newestHere model.SessionStats
+	for _, s := range sessions {
+		if s.IsSubagent || s.Source != "" {
+			continue
+		}
+		if s.EndTime.After(newest.EndTime) {
+			newest = s
+		}
+		if cwd != "" && s.ProjectPath == cwd && s.EndTime.After(newestHere.EndTime) {
+			newestHere = s
+		}
+	}
+	if newestHere.SessionID != "" {
+		return newestHere, nil
+	}
+	if newest.SessionID != "" {
+		return newest, nil
+	}
+	return model.SessionStats{}, errors.New("no sessions found")
+}
+
+func mcpRateLimits(_ context.Context, _ json.RawMessage) (any, error) {
+	cfg, _ := config.Load()
+	sessionKey := config.GetSessionKey(cfg)
+	if sessionKey == "" {
+		return nil, errors.New("no claude.ai session key configured (run `cburn setup`)")
+	}
+	data, err := fetchSubscription(sessionKey, cfg.ClaudeAI.OrgID)
+	if err != nil {
+		return nil, err
+	}
+	if data.Usage == nil {
+		if data.Error != nil {
+			return nil, fmt.Errorf("fetching rate limits: %w", data.Error)
+		}
+		return nil, errors.New("no rate-limit data available")
+	}
+
+	now := time.Now()
+	windows := make([]map[string]any, 0, 4)
+	for _, nw := range data.Usage.Windows() {
+		w := map[string]any{
+			"window":      nw.Label,
+			"utilization": round2(nw.Window.Pct),
+		}
+		if !nw.Window.ResetsAt.IsZero() {
+			w["resets_at"] = nw.Window.ResetsAt
+			w["resets_in_minutes"] = int(nw.Window.ResetsAt.Sub(now).Minutes())
+		}
+		windows = append(windows, w)
+	}
+
+	out := map[string]any{"windows": windows}
+	if data.Stale {
+		out["as_of"] = data.FetchedAt
+	}
+	if hint := claudeai.SchedulingHint(data.Usage, cfg.RateLimits.HintThreshold(), now); hint != nil {
+		out["hint"] = formatScheduleHint(*hint, now)
+	}
+	return out, nil
+}
+
+func round2(f float64) float64 {
+	return float64(int64(f*100+0.5)) / 100
+}
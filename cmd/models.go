@@ -2,8 +2,11 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/theirongolddev/cburn/internal/cli"
+	"github.com/theirongolddev/cburn/internal/model"
 	"github.com/theirongolddev/cburn/internal/pipeline"
 
 	"github.com/spf13/cobra"
@@ -15,7 +18,20 @@ var modelsCmd = &cobra.Command{
 	RunE:  runModels,
 }
 
+var modelsCoverageCmd = &cobra.Command{
+	Use:   "coverage",
+	Short: "List every model ID seen and whether it has pricing",
+	Long: "Lists each model in your data with the raw IDs that normalize to it, whether\n" +
+		"cburn has pricing for it, first and last use, and total spend. Unpriced models\n" +
+		"are listed first; they are counted at $0. Covers all history unless --days is set.",
+	RunE: runModelsCoverage,
+}
+
+var modelsLatency bool
+
 func init() {
+	modelsCmd.Flags().BoolVar(&modelsLatency, "latency", false, "Show turn latency per model (mean and percentiles) instead of usage")
+	modelsCmd.AddCommand(modelsCoverageCmd)
 	rootCmd.AddCommand(modelsCmd)
 }
 
@@ -30,6 +46,9 @@ func runModels(_ *cobra.Command, _ []string) error {
 	}
 
 	filtered, since, until := applyFilters(result.Sessions)
+	if modelsLatency {
+		return renderModelLatency(pipeline.AggregateLatency(filtered, since, until))
+	}
 	models := pipeline.AggregateModels(filtered, since, until)
 
 	if len(models) == 0 {
@@ -38,7 +57,7 @@ func runModels(_ *cobra.Command, _ []string) error {
 	}
 
 	fmt.Println()
-	fmt.Println(cli.RenderTitle(fmt.Sprintf("MODEL USAGE  Last %dd", flagDays)))
+	fmt.Println(cli.RenderTitle("MODEL USAGE  " + periodLabel()))
 	fmt.Println()
 
 	rows := make([][]string, 0, len(models))
@@ -50,13 +69,109 @@ func runModels(_ *cobra.Command, _ []string) error {
 			cli.FormatTokens(ms.OutputTokens),
 			cli.FormatCost(ms.EstimatedCost),
 			fmt.Sprintf("%.1f%%", ms.SharePercent),
+			cli.FormatPercent(ms.CacheReadShare),
 		})
 	}
 
 	fmt.Print(cli.RenderTable(cli.Table{
-		Headers: []string{"Model", "Calls", "Input", "Output", "Cost", "Share"},
-		Rows:    rows,
+		Headers:  []string{"Model", "Calls", "Input", "Output", "Cost", "Share", "Cache Read"},
+		Optional: []int{6, 2, 3, 1},
+		Rows:     rows,
 	}))
 
 	return nil
 }
+
+func renderModelLatency(latency []model.ModelLatency) error {
+	if len(latency) == 0 {
+		fmt.Println("\n  No turn durations in the selected time range.")
+		return nil
+	}
+
+	fmt.Println()
+	fmt.Println(cli.RenderTitle("MODEL LATENCY  " + periodLabel()))
+	fmt.Println()
+
+	rows := make([][]string, 0, len(latency))
+	for _, ml := range latency {
+		rows = append(rows, []string{
+			shortModel(ml.Model),
+			cli.FormatNumber(int64(ml.Turns)),
+			cli.FormatLatency(ml.Mean),
+			cli.FormatLatency(ml.P50),
+			cli.FormatLatency(ml.P90),
+			cli.FormatLatency(ml.P99),
+		})
+	}
+
+	fmt.Print(cli.RenderTable(cli.Table{
+		Headers:  []string{"Model", "Turns", "Mean", "p50", "p90", "p99"},
+		Optional: []int{5, 2},
+		Rows:     rows,
+	}))
+	fmt.Println("  Turn time is wall time from prompt to final answer, tool runs included.")
+	return nil
+}
+
+func runModelsCoverage(cmd *cobra.Command, _ []string) error {
+	result, err := loadData()
+	if err != nil {
+		return err
+	}
+
+	filtered, since, until := applyFilters(result.Sessions)
+	title := "MODEL COVERAGE  " + periodLabel()
+	if !cmd.Flags().Changed("days") && rangeSince.IsZero() {
+		since, until = time.Time{}, time.Time{}
+		title = "MODEL COVERAGE  All time"
+	}
+	coverage := pipeline.AggregateModelCoverage(filtered, since, until)
+
+	if len(coverage) == 0 {
+		fmt.Println("\n  No model data found.")
+		return nil
+	}
+
+	fmt.Println()
+	fmt.Println(cli.RenderTitle(title))
+	fmt.Println()
+
+	unpriced := 0
+	rows := make([][]string, 0, len(coverage))
+	for _, mc := range coverage {
+		priced, cost := "yes", cli.FormatCost(mc.EstimatedCost)
+		if !mc.Priced {
+			unpriced++
+			priced, cost = "NO", "-"
+		}
+		rows = append(rows, []string{
+			mc.Model,
+			strings.Join(mc.RawNames, ", "),
+			priced,
+			formatSeen(mc.FirstSeen),
+			formatSeen(mc.LastSeen),
+			cli.FormatNumber(int64(mc.APICalls)),
+			cost,
+		})
+	}
+
+	fmt.Print(cli.RenderTable(cli.Table{
+		Headers:  []string{"Model", "Seen As", "Priced", "First Seen", "Last Seen", "Calls", "Spend"},
+		Optional: []int{3, 5, 1},
+		Flex:     1,
+		Rows:     rows,
+	}))
+
+	if unpriced > 0 {
+		fmt.Printf("\n  %d model(s) have no pricing and are counted at $0.\n", unpriced)
+		fmt.Println("  Add them under [pricing.overrides] in the config file.")
+	}
+	return nil
+}
+
+func formatSeen(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02")
+}
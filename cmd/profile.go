@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/config"
+
+	"github.com/spf13/cobra"
+)
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Export or import portable settings (config without secrets)",
+	Long: "A profile bundles budgets, theme, TUI preferences, project tag rules, and pricing\n" +
+		"overrides into one TOML file. API keys, the claude.ai session, webhook URLs, and the data\n" +
+		"directory are never exported, and are kept as-is on import.",
+}
+
+var profileExportCmd = &cobra.Command{
+	Use:   "export [file]",
+	Short: "Write the current settings to a profile file (stdout if omitted)",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runProfileExport,
+}
+
+var profileImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Replace local settings with a profile, keeping local secrets",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runProfileImport,
+}
+
+func init() {
+	profileCmd.AddCommand(profileExportCmd)
+	profileCmd.AddCommand(profileImportCmd)
+	rootCmd.AddCommand(profileCmd)
+}
+
+func runProfileExport(_ *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	p := config.NewProfile(cfg, time.Now())
+
+	if len(args) == 0 || args[0] == "-" {
+		return config.WriteProfile(os.Stdout, p)
+	}
+
+	f, err := os.OpenFile(args[0], os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600) //nolint:gosec // user-chosen output path
+	if err != nil {
+		return fmt.Errorf("creating profile: %w", err)
+	}
+	if err := config.WriteProfile(f, p); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Printf("  Exported profile to %s\n", args[0])
+	return nil
+}
+
+func runProfileImport(_ *cobra.Command, args []string) error {
+	var r io.Reader = os.Stdin
+	if args[0] != "-" {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return fmt.Errorf("opening profile: %w", err)
+		}
+		defer func() { _ = f.Close() }()
+		r = f
+	}
+
+	p, err := config.ReadProfile(r)
+	if err != nil {
+		return err
+	}
+
+	local, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if err := config.Save(config.ApplyProfile(local, p)); err != nil {
+		return err
+	}
+
+	fmt.Printf("  Imported profile (exported %s) into %s\n",
+		p.ExportedAt.Local().Format("2006-01-02 15:04"), config.Path())
+	return nil
+}
@@ -38,7 +38,7 @@ func runProjects(_ *cobra.Command, _ []string) error {
 	}
 
 	fmt.Println()
-	fmt.Println(cli.RenderTitle(fmt.Sprintf("PROJECTS  Last %dd", flagDays)))
+	fmt.Println(cli.RenderTitle("PROJECTS  " + periodLabel()))
 	fmt.Println()
 
 	rows := make([][]string, 0, len(projects))
@@ -53,8 +53,9 @@ func runProjects(_ *cobra.Command, _ []string) error {
 	}
 
 	fmt.Print(cli.RenderTable(cli.Table{
-		Headers: []string{"Project", "Sessions", "Prompts", "Tokens", "Cost"},
-		Rows:    rows,
+		Headers:  []string{"Project", "Sessions", "Prompts", "Tokens", "Cost"},
+		Optional: []int{2, 1},
+		Rows:     rows,
 	}))
 
 	return nil
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/cli"
+	"github.com/theirongolddev/cburn/internal/query"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagQueryJSON bool
+	flagQueryCSV  bool
+)
+
+var queryCmd = &cobra.Command{
+	Use:   "query <expression>",
+	Short: "Answer ad-hoc questions with a small aggregate query language",
+	Long: "Aggregate the cached sessions with an expression of the form\n\n" +
+		"  agg[, agg...] [by field[, field...]] [where cond [and cond...]] [limit n]\n\n" +
+		"Aggregates: sum, avg, min, max of cost, tokens, input, output, cache_read,\n" +
+		"cache_write, calls, prompts, or duration, and count() for sessions. They\n" +
+		"are per session: avg, min, and max compare each session's total in the group.\n" +
+		"Group by: project, model, day, week, month, hour, branch, repo, tag, origin,\n" +
+		"source, or session.\n" +
+		"Conditions: days=N, since=YYYY-MM-DD, until=YYYY-MM-DD; project, model, branch,\n" +
+		"repo, tag, origin, source, and session with = != ~ !~ (~ is a case-insensitive\n" +
+		"regex); cost, tokens, calls, prompts, and duration (minutes) with = != > >= < <=.\n\n" +
+		"Without days/since/until the usual --days, --from/--to, and filters apply.",
+	Example: "  cburn query 'sum(cost) by project where days=7 and model~\"opus\"'\n" +
+		"  cburn query 'sum(cost), count() by week where origin=automation'\n" +
+		"  cburn query 'avg(duration), max(cost) by branch where repo~api limit 10' --json",
+	Args: cobra.ExactArgs(1),
+	RunE: runQuery,
+}
+
+func init() {
+	queryCmd.Flags().BoolVar(&flagQueryJSON, "json", false, "Print rows as JSON")
+	queryCmd.Flags().BoolVar(&flagQueryCSV, "csv", false, "Print rows as CSV")
+	rootCmd.AddCommand(queryCmd)
+}
+
+func runQuery(_ *cobra.Command, args []string) error {
+	if flagQueryJSON && flagQueryCSV {
+		return errors.New("--json and --csv cannot be combined")
+	}
+	q, err := query.Parse(args[0])
+	if err != nil {
+		return fmt.Errorf("query: %w", err)
+	}
+
+	result, err := loadData()
+	if err != nil {
+		return err
+	}
+	filtered, since, until := applyFilters(result.Sessions)
+	since, until = q.Window(since, until, time.Now())
+	res := q.Run(filtered, since, until)
+
+	switch {
+	case flagQueryJSON:
+		return writeQueryJSON(res)
+	case flagQueryCSV:
+		return writeQueryCSV(res)
+	}
+
+	fmt.Println()
+	fmt.Println(cli.RenderTitle("QUERY  " + args[0]))
+	fmt.Println()
+	if len(res.Rows) == 0 {
+		fmt.Println("  No matching sessions.")
+		return nil
+	}
+
+	table := make([][]string, 0, len(res.Rows))
+	for _, r := range res.Rows {
+		row := make([]string, 0, len(res.Columns))
+		for k, key := range r.Keys {
+			switch {
+			case key == "":
+				key = "(none)"
+			case q.By[k] == "model":
+				key = shortModel(key)
+			}
+			row = append(row, truncate(key, 40))
+		}
+		for a, v := range r.Values {
+			row = append(row, formatQueryValue(q.Aggs[a], v))
+		}
+		table = append(table, row)
+	}
+	fmt.Print(cli.RenderTable(cli.Table{Headers: res.Columns, Rows: table}))
+	return nil
+}
+
+// formatQueryValue renders an aggregate in the unit of its metric.
+func formatQueryValue(a query.Agg, v float64) string {
+	switch a.Metric {
+	case "":
+		return cli.FormatNumber(int64(v))
+	case "cost":
+		return cli.FormatCost(v)
+	case "duration":
+		return cli.FormatDuration(int64(math.Round(v)))
+	case "calls", "prompts":
+		if a.Func == "avg" {
+			return strconv.FormatFloat(v, 'f', 1, 64)
+		}
+		return cli.FormatNumber(int64(v))
+	}
+	return cli.FormatTokens(int64(math.Round(v)))
+}
+
+func writeQueryJSON(res query.Result) error {
+	out := make([]map[string]any, 0, len(res.Rows))
+	for _, r := range res.Rows {
+		m := make(map[string]any, len(res.Columns))
+		for k, key := range r.Keys {
+			m[res.Columns[k]] = key
+		}
+		for a, v := range r.Values {
+			m[res.Columns[len(r.Keys)+a]] = v
+		}
+		out = append(out, m)
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+func writeQueryCSV(res query.Result) error {
+	w := csv.NewWriter(os.Stdout)
+	if err := w.Write(res.Columns); err != nil {
+		return err
+	}
+	for _, r := range res.Rows {
+		rec := append([]string{}, r.Keys...)
+		for _, v := range r.Values {
+			rec = append(rec, strconv.FormatFloat(v, 'f', -1, 64))
+		}
+		if err := w.Write(rec); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/cli"
+	"github.com/theirongolddev/cburn/internal/config"
+	"github.com/theirongolddev/cburn/internal/receipts"
+
+	"github.com/spf13/cobra"
+)
+
+var flagReceiptsWrite bool
+
+var receiptsCmd = &cobra.Command{
+	Use:   "receipts [project-dir]",
+	Short: "Show merged session cost receipts for a project directory",
+	Long: "Reads every .cburn/receipts*.jsonl in the project directory (default: current directory),\n" +
+		"merging receipts from teammates, and totals cost by author.",
+	Args: cobra.MaximumNArgs(1),
+	RunE: runReceipts,
+}
+
+func init() {
+	receiptsCmd.Flags().BoolVar(&flagReceiptsWrite, "write", false, "Write receipts for finished sessions now (even if [receipts] is disabled)")
+	rootCmd.AddCommand(receiptsCmd)
+}
+
+func runReceipts(_ *cobra.Command, args []string) error {
+	if flagReceiptsWrite {
+		result, err := loadData()
+		if err != nil {
+			return err
+		}
+		cfg, _ := config.Load()
+		if !cfg.Receipts.Enabled {
+			// loadData only writes when enabled; do it explicitly here.
+			n, err := receipts.WriteCompleted(result.Sessions, time.Now())
+			if err != nil {
+				return err
+			}
+			fmt.Printf("  Wrote %d receipts\n", n)
+		}
+	}
+
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	if _, err := os.Stat(dir); err != nil {
+		return fmt.Errorf("project dir: %w", err)
+	}
+
+	all, err := receipts.Load(dir)
+	if err != nil {
+		return err
+	}
+	if len(all) == 0 {
+		fmt.Println("\n  No receipts found in", dir+"/"+receipts.DirName)
+		fmt.Println("  Enable with `[receipts] enabled = true` in", config.Path())
+		return nil
+	}
+
+	type authorTotal struct {
+		author   string
+		sessions int
+		tokens   int64
+		cost     float64
+		last     time.Time
+	}
+	totals := make(map[string]*authorTotal)
+	var grand authorTotal
+	for _, r := range all {
+		at, ok := totals[r.Author]
+		if !ok {
+			at = &authorTotal{author: r.Author}
+			totals[r.Author] = at
+		}
+		tokens := r.InputTokens + r.OutputTokens + r.CacheWriteTokens
+		at.sessions++
+		at.tokens += tokens
+		at.cost += r.CostUSD
+		if r.EndTime.After(at.last) {
+			at.last = r.EndTime
+		}
+		grand.sessions++
+		grand.tokens += tokens
+		grand.cost += r.CostUSD
+	}
+
+	authors := make([]*authorTotal, 0, len(totals))
+	for _, at := range totals {
+		authors = append(authors, at)
+	}
+	sort.Slice(authors, func(i, j int) bool { return authors[i].cost > authors[j].cost })
+
+	fmt.Println()
+	fmt.Println(cli.RenderTitle(fmt.Sprintf("RECEIPTS  %s", all[0].Project)))
+	fmt.Println()
+
+	rows := make([][]string, 0, len(authors)+2)
+	for _, at := range authors {
+		rows = append(rows, []string{
+			at.author,
+			cli.FormatNumber(int64(at.sessions)),
+			cli.FormatTokens(at.tokens),
+			cli.FormatCost(at.cost),
+			at.last.Local().Format("2006-01-02"),
+		})
+	}
+	rows = append(rows, []string{"---"})
+	rows = append(rows, []string{"TOTAL", cli.FormatNumber(int64(grand.sessions)), cli.FormatTokens(grand.tokens), cli.FormatCost(grand.cost), ""})
+
+	fmt.Print(cli.RenderTable(cli.Table{
+		Headers:  []string{"Author", "Sessions", "Tokens", "Cost", "Last Session"},
+		Optional: []int{4, 2},
+		Rows:     rows,
+	}))
+
+	return nil
+}
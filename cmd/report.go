@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/claudeai"
+	"github.com/theirongolddev/cburn/internal/cli"
+	"github.com/theirongolddev/cburn/internal/config"
+	"github.com/theirongolddev/cburn/internal/digest"
+	"github.com/theirongolddev/cburn/internal/model"
+	"github.com/theirongolddev/cburn/internal/pipeline"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagReportDaily  bool
+	flagReportOutput string
+	flagReportSend   bool
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Period report: spend, today against the week before, and cost anomalies",
+	Long: "Summarize spend for the period and list cost anomalies: days whose spend\n" +
+		fmt.Sprintf("reached %.0fx the median of the 7 days before, as a runaway agent loop makes it.\n\n", pipeline.AnomalyFactor) +
+		"With --daily, write yesterday's digest as an HTML page instead: its cost,\n" +
+		"top sessions, and claude.ai rate-limit status. --send mails it with the\n" +
+		"[digest] settings the daemon uses for its morning email.",
+	RunE: runReport,
+}
+
+func init() {
+	reportCmd.Flags().BoolVar(&flagReportDaily, "daily", false, "Write yesterday's HTML digest")
+	reportCmd.Flags().StringVarP(&flagReportOutput, "output", "o", "", "With --daily, the file to write (default: stdout)")
+	reportCmd.Flags().BoolVar(&flagReportSend, "send", false, "With --daily, email the digest using [digest] in config")
+	rootCmd.AddCommand(reportCmd)
+}
+
+func runReport(_ *cobra.Command, _ []string) error {
+	result, err := loadData()
+	if err != nil {
+		return err
+	}
+	filtered, since, until := applyFilters(result.Sessions)
+	if flagReportDaily {
+		return runDailyDigest(filtered)
+	}
+	if len(result.Sessions) == 0 {
+		fmt.Println("\n  No sessions found.")
+		return nil
+	}
+
+	stats := pipeline.Aggregate(filtered, since, until)
+	anomalies := pipeline.FindCostAnomalies(filtered, since, until)
+
+	fmt.Println()
+	fmt.Println(cli.RenderTitle("USAGE REPORT  " + periodLabel()))
+	fmt.Println()
+	fmt.Printf("  Spent %s over %s sessions (%s/day)\n",
+		cli.FormatCost(stats.EstimatedCost), cli.FormatNumber(int64(stats.TotalSessions)), cli.FormatCost(stats.CostPerDay))
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	if !until.Before(today) {
+		if a, ok := pipeline.TodayAnomaly(filtered, now); ok {
+			fmt.Printf("  Today: %s, %.1fx the last week's median of %s/day. Runaway agent loop?\n",
+				cli.FormatCost(a.Cost), a.Ratio(), cli.FormatCost(a.Median))
+		} else {
+			fmt.Printf("  Today: %s, no anomaly\n", cli.FormatCost(pipeline.Aggregate(filtered, today, now).EstimatedCost))
+		}
+	}
+	fmt.Println()
+
+	if len(anomalies) == 0 {
+		fmt.Printf("  No cost anomalies (days over %.0fx the trailing 7-day median).\n\n", pipeline.AnomalyFactor)
+		return nil
+	}
+
+	rows := make([][]string, 0, len(anomalies))
+	for _, a := range anomalies {
+		rows = append(rows, []string{
+			a.Date.Format("2006-01-02"),
+			cli.FormatDayOfWeek(int(a.Date.Weekday())),
+			cli.FormatCost(a.Cost),
+			cli.FormatCost(a.Median),
+			fmt.Sprintf("%.1fx", a.Ratio()),
+		})
+	}
+	fmt.Println(cli.RenderTitle("COST ANOMALIES"))
+	fmt.Println()
+	fmt.Print(cli.RenderTable(cli.Table{
+		Headers:  []string{"Date", "Day", "Cost", "7d Median", "Ratio"},
+		Optional: []int{1},
+		Rows:     rows,
+	}))
+	return nil
+}
+
+// runDailyDigest writes yesterday's digest to --output or stdout, or mails
+// it with --send.
+func runDailyDigest(sessions []model.SessionStats) error {
+	cfg, _ := config.Load()
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	d := digest.Build(sessions, today.AddDate(0, 0, -1), now)
+	if key := config.GetSessionKey(cfg); key != "" {
+		var usage *claudeai.ParsedUsage
+		data, err := fetchSubscription(key, cfg.ClaudeAI.OrgID)
+		if data != nil {
+			usage = data.Usage
+		}
+		d.SetRateLimits(usage, err)
+	}
+
+	if flagReportSend {
+		if err := digestSMTP(cfg.Digest).Send(d); err != nil {
+			return fmt.Errorf("sending digest: %w", err)
+		}
+		fmt.Printf("  Sent %q to %s\n", d.Subject(), cfg.Digest.To)
+		return nil
+	}
+
+	if flagReportOutput == "" {
+		return digest.WriteHTML(os.Stdout, d)
+	}
+	f, err := os.OpenFile(flagReportOutput, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	err = digest.WriteHTML(f, d)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "  Wrote %s\n", flagReportOutput)
+	return nil
+}
+
+// digestSMTP is the mail setup in [digest].
+func digestSMTP(c config.DigestConfig) digest.SMTP {
+	return digest.SMTP{
+		Host:     c.SMTPHost,
+		Port:     c.SMTPPort,
+		Username: c.SMTPUser,
+		Password: c.SMTPPassword,
+		From:     c.From,
+		To:       c.To,
+	}
+}
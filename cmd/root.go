@@ -1,15 +1,24 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/theirongolddev/cburn/internal/cli"
+	"github.com/theirongolddev/cburn/internal/config"
+	"github.com/theirongolddev/cburn/internal/fx"
 	"github.com/theirongolddev/cburn/internal/model"
 	"github.com/theirongolddev/cburn/internal/pipeline"
+	"github.com/theirongolddev/cburn/internal/receipts"
+	"github.com/theirongolddev/cburn/internal/redact"
+	"github.com/theirongolddev/cburn/internal/source"
 	"github.com/theirongolddev/cburn/internal/store"
+	"github.com/theirongolddev/cburn/internal/tui/theme"
 
 	"github.com/spf13/cobra"
 )
@@ -22,18 +31,69 @@ var (
 	flagDataDir     string
 	flagQuiet       bool
 	flagNoSubagents bool
+	flagSource      string
+	flagTZ          string
+	flagFrom        string
+	flagTo          string
+	flagBilling     bool
+	flagView        string
+	flagNoColor     bool
+	flagPlain       bool
+	flagPrivacy     bool
+	flagGapAware    bool
+	flagIdleGap     int
 )
 
+// keepExcluded makes loadSessions keep sessions hidden from totals, for
+// commands that list them.
+var keepExcluded bool
+
+// rangeSince and rangeUntil hold the --from/--to range; both are zero when
+// the window is the last --days days.
+var rangeSince, rangeUntil time.Time
+
 var rootCmd = &cobra.Command{
 	Use:   "cburn",
 	Short: "Claude Usage Metrics CLI",
 	Long:  "Analyze your Claude Code usage: tokens, costs, sessions, and more.",
 	RunE:  runSummary,
+
+	PersistentPreRunE: preRun,
+}
+
+// applyCurrency shows costs in the configured currency. When no rate is
+// configured and the live lookup fails, costs stay in USD with a warning
+// rather than failing the command.
+func applyCurrency(c config.CurrencyConfig) {
+	if c == (config.CurrencyConfig{}) {
+		return
+	}
+	loc := cli.Locale{
+		Symbol:      c.CurrencySymbol(),
+		SymbolAfter: c.SymbolAfter,
+		Rate:        c.Rate,
+		Decimal:     c.Decimal,
+		Thousands:   c.Thousands,
+	}
+	if loc.Rate == 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		rate, err := fx.Rate(ctx, c.Code, pipeline.CacheDir())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "  %v; showing costs in USD\n", err)
+			loc.Symbol, loc.SymbolAfter = "$", false
+		}
+		loc.Rate = rate
+	}
+	cli.SetLocale(loc)
 }
 
 // Execute is the main entry point called from main.go.
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
+		if errors.Is(err, errGateExceeded) {
+			os.Exit(gateExitExceeded)
+		}
 		os.Exit(1)
 	}
 }
@@ -49,22 +109,147 @@ func init() {
 	rootCmd.PersistentFlags().StringVarP(&flagDataDir, "data-dir", "d", defaultDataDir, "Claude data directory")
 	rootCmd.PersistentFlags().BoolVarP(&flagQuiet, "quiet", "q", false, "Suppress progress output")
 	rootCmd.PersistentFlags().BoolVar(&flagNoSubagents, "no-subagents", false, "Exclude subagent sessions")
+	rootCmd.PersistentFlags().StringVar(&flagSource, "source", "", "Filter to an imported source label (\"local\" for this machine)")
+	rootCmd.PersistentFlags().StringVar(&flagFrom, "from", "", "Start date, inclusive (YYYY-MM-DD); overrides --days")
+	rootCmd.PersistentFlags().StringVar(&flagTo, "to", "", "End date, inclusive (YYYY-MM-DD); with --days alone, ends the window there")
+	rootCmd.PersistentFlags().BoolVar(&flagBilling, "billing", false, "Current billing period (from budget.billing_day, default the 1st) instead of --days")
+	rootCmd.PersistentFlags().StringVar(&flagView, "view", "", "Apply a saved view's filters from [[views]] in config; explicit flags win")
+	rootCmd.PersistentFlags().BoolVar(&flagNoColor, "no-color", false, "Disable colors and mark severity with symbols (also set by NO_COLOR)")
+	rootCmd.PersistentFlags().BoolVar(&flagPlain, "plain", false, "Plain aligned text without box drawing or color, for screen readers and CI logs")
+	rootCmd.PersistentFlags().BoolVar(&flagPrivacy, "privacy", false, "Mask project names, session IDs, and org names (also appearance.privacy)")
+	rootCmd.PersistentFlags().BoolVar(&flagGapAware, "gap-aware", false, "Exclude idle gaps from session durations (also general.gap_aware_durations)")
+	rootCmd.PersistentFlags().IntVar(&flagIdleGap, "idle-gap", 0, "Minutes of inactivity counted as idle (default: general.idle_gap_minutes, then 5)")
+	rootCmd.PersistentFlags().StringVar(&flagTZ, "tz", "", "Time zone for day/hour bucketing, e.g. UTC (default: general.timezone, then the system zone)")
+}
+
+// preRun applies the global flags and their config settings before any
+// command runs: time zone, date range and saved view, plain and no-color
+// output, currency, privacy, idle gaps and gap-aware durations, and usage
+// recording.
+func preRun(cmd *cobra.Command, args []string) error {
+	if flagPlain {
+		cli.SetPlain()
+	}
+	if flagPlain || flagNoColor || os.Getenv("NO_COLOR") != "" {
+		cli.DisableColor()
+		theme.NoColor = true
+	}
+	cfg, _ := config.Load()
+	applyCurrency(cfg.Currency)
+	redact.SetEnabled(flagPrivacy || cfg.Appearance.Privacy)
+	idle := cfg.General.IdleGapMinutes
+	if flagIdleGap > 0 {
+		idle = flagIdleGap
+	}
+	pipeline.SetIdleGap(time.Duration(idle) * time.Minute)
+	pipeline.SetGapAware(flagGapAware || cfg.General.GapAwareDurations)
+	tz := flagTZ
+	if tz == "" {
+		tz = cfg.General.Timezone
+	}
+	if err := config.SetTimezone(tz); err != nil {
+		return err
+	}
+	if flagView != "" {
+		if err := applyView(cmd, cfg); err != nil {
+			return err
+		}
+	}
+	// Dates are read in the bucketing zone, so parse them after setting it.
+	if flagBilling {
+		if flagFrom != "" || flagTo != "" {
+			return errors.New("--billing cannot be combined with --from/--to")
+		}
+		now := time.Now()
+		rangeSince, _ = pipeline.BillingPeriod(cfg.Budget.BillingDay, now)
+		rangeUntil = now
+	} else if flagFrom != "" || flagTo != "" {
+		since, until, err := pipeline.ParseDateRange(flagFrom, flagTo, flagDays, time.Now())
+		if err != nil {
+			return err
+		}
+		rangeSince, rangeUntil = since, until
+	}
+	recordCommandUsage(cmd, args)
+	return nil
+}
+
+// applyView fills --days, --project, and --model from the --view named in
+// cfg, leaving any the user set explicitly.
+func applyView(cmd *cobra.Command, cfg config.Config) error {
+	v, err := cfg.FindView(flagView)
+	if err != nil {
+		return err
+	}
+	flags := cmd.Flags()
+	if v.Days > 0 && !flags.Changed("days") {
+		flagDays = v.Days
+	}
+	if v.Project != "" && !flags.Changed("project") {
+		flagProject = v.Project
+	}
+	if v.Model != "" && !flags.Changed("model") {
+		flagModel = v.Model
+	}
+	return nil
 }
 
 // loadData is the shared data loading path used by all commands.
 // Uses SQLite cache when available for fast subsequent runs.
+// Cost allocation tags and automation rules from the [projects] config are
+// applied to the result.
 func loadData() (*pipeline.LoadResult, error) {
+	result, err := loadSessions()
+	if err != nil {
+		return nil, err
+	}
+	if cfg, err := config.Load(); err == nil {
+		pipeline.ApplyProjectIdentity(result.Sessions, cfg.Projects)
+		pipeline.ApplyTags(result.Sessions, cfg.Projects)
+		pipeline.ApplyAutomation(result.Sessions, cfg.Projects)
+		if cfg.Receipts.Enabled {
+			if _, err := receipts.WriteCompleted(result.Sessions, time.Now()); err != nil && !flagQuiet {
+				fmt.Fprintf(os.Stderr, "  Warning: writing receipts: %v\n", err)
+			}
+		}
+	}
+	return result, nil
+}
+
+// scanRoots returns the data directory plus any extra scan roots from config.
+func scanRoots() []source.Root {
+	cfg, _ := config.Load()
+	return pipeline.ScanRoots(flagDataDir, cfg.General.ScanRoots, cfg.General.ScanExclude)
+}
+
+// loadSessions loads sessions from the cache or by parsing, with progress output.
+func loadSessions() (*pipeline.LoadResult, error) {
 	if !flagQuiet {
 		fmt.Fprintf(os.Stderr, "  Scanning sessions...\n")
 	}
 
-	progressFn := func(current, total int) {
+	// Workers report concurrently; throttle redraws but always show the last file.
+	var progressMu sync.Mutex
+	var lastDraw time.Time
+	progressFn := func(p pipeline.Progress) {
 		if flagQuiet {
 			return
 		}
-		if current%100 == 0 || current == total {
-			fmt.Fprintf(os.Stderr, "\r  Parsing [%d/%d]", current, total)
+		progressMu.Lock()
+		defer progressMu.Unlock()
+		done := p.Files == p.TotalFiles
+		if !done && time.Since(lastDraw) < 100*time.Millisecond {
+			return
 		}
+		lastDraw = time.Now()
+		line := fmt.Sprintf("\r  Parsing [%d/%d]", p.Files, p.TotalFiles)
+		if p.TotalBytes > 0 {
+			line += fmt.Sprintf("  %s / %s", cli.FormatBytes(p.Bytes), cli.FormatBytes(p.TotalBytes))
+		}
+		if eta := p.ETA(); eta > 0 && !done {
+			line += "  ETA " + cli.FormatDuration(int64(eta.Seconds()+1))
+		}
+		fmt.Fprintf(os.Stderr, "%s    ", line)
 	}
 
 	// Try cached load unless --no-cache
@@ -78,7 +263,7 @@ func loadData() (*pipeline.LoadResult, error) {
 		} else {
 			defer func() { _ = cache.Close() }()
 
-			cr, err := pipeline.LoadWithCache(flagDataDir, !flagNoSubagents, cache, progressFn)
+			cr, err := pipeline.LoadWithCache(scanRoots(), !flagNoSubagents, cache, progressFn)
 			if err != nil {
 				// Cache-assisted load failed — fall back
 				if !flagQuiet {
@@ -98,6 +283,11 @@ func loadData() (*pipeline.LoadResult, error) {
 							cr.ProjectCount,
 						)
 					}
+					noteSkewedTimestamps(cr.SkewedTimestamps)
+					noteDuplicates(len(cr.Duplicates))
+				}
+				if !keepExcluded {
+					cr.Sessions = pipeline.WithoutExcluded(cr.Sessions)
 				}
 				return &cr.LoadResult, nil
 			}
@@ -105,7 +295,7 @@ func loadData() (*pipeline.LoadResult, error) {
 	}
 
 	// Uncached path
-	result, err := pipeline.Load(flagDataDir, !flagNoSubagents, progressFn)
+	result, err := pipeline.Load(scanRoots(), !flagNoSubagents, progressFn)
 	if err != nil {
 		return nil, err
 	}
@@ -115,28 +305,81 @@ func loadData() (*pipeline.LoadResult, error) {
 			formatNumber(int64(result.ParsedFiles)),
 			result.ProjectCount,
 		)
+		noteSkewedTimestamps(result.SkewedTimestamps)
+		noteDuplicates(len(result.Duplicates))
 	}
 
 	return result, nil
 }
 
-// applyFilters returns filtered sessions and the computed time range.
+// noteSkewedTimestamps mentions timestamps the parser dropped as implausible.
+func noteSkewedTimestamps(n int) {
+	if n > 0 {
+		fmt.Fprintf(os.Stderr, "  Ignored %s timestamps before 2023 or in the future (see cburn doctor)\n", formatNumber(int64(n)))
+	}
+}
+
+// noteDuplicates mentions session files skipped as copies of another.
+func noteDuplicates(n int) {
+	if n > 0 {
+		fmt.Fprintf(os.Stderr, "  Skipped %s duplicate session copies (see cburn doctor)\n", formatNumber(int64(n)))
+	}
+}
+
+// applyFilters returns filtered sessions, masked for display in privacy
+// mode, and the computed time range.
 func applyFilters(sessions []model.SessionStats) ([]model.SessionStats, time.Time, time.Time) {
-	now := time.Now()
-	since := now.AddDate(0, 0, -flagDays)
-	until := now
+	filtered, since, until := filterSessions(sessions)
+	return redact.Sessions(filtered), since, until
+}
+
+// filterSessions is applyFilters without privacy masking, for data written
+// out for others to read, like bundles.
+func filterSessions(sessions []model.SessionStats) ([]model.SessionStats, time.Time, time.Time) {
+	since, until := timeWindow()
 
 	filtered := sessions
+	if flagSource != "" {
+		filtered = pipeline.FilterBySource(filtered, flagSource)
+	}
 	if flagProject != "" {
 		filtered = pipeline.FilterByProject(filtered, flagProject)
 	}
 	if flagModel != "" {
 		filtered = pipeline.FilterByModel(filtered, flagModel)
 	}
-
 	return filtered, since, until
 }
 
+// timeWindow returns the [since, until) range selected by --days, --from /
+// --to, or --billing.
+func timeWindow() (since, until time.Time) {
+	if !rangeSince.IsZero() {
+		return rangeSince, rangeUntil
+	}
+	now := time.Now()
+	return now.AddDate(0, 0, -flagDays), now
+}
+
+// periodLabel describes the selected time window for report titles.
+func periodLabel() string {
+	switch {
+	case flagBilling:
+		return "Billing period since " + rangeSince.Format("Jan 2")
+	case !rangeSince.IsZero():
+		return pipeline.DateRangeLabel(rangeSince, rangeUntil, time.Now())
+	}
+	return fmt.Sprintf("Last %dd", flagDays)
+}
+
+// periodShort is periodLabel for inline comparisons ("vs prev 30d").
+func periodShort() string {
+	if !rangeSince.IsZero() {
+		return fmt.Sprintf("%dd", int(rangeUntil.Sub(rangeSince).Round(24*time.Hour).Hours()/24))
+	}
+	return fmt.Sprintf("%dd", flagDays)
+}
+
 func formatNumber(n int64) string {
 	return cli.FormatNumber(n)
 }
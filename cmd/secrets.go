@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/theirongolddev/cburn/internal/config"
+	"github.com/theirongolddev/cburn/internal/secrets"
+
+	"github.com/spf13/cobra"
+)
+
+var secretsCmd = &cobra.Command{
+	Use:   "secrets",
+	Short: "Show or change where API keys are stored",
+	Long: "API keys live in the config file by default. With secrets_backend = \"keychain\"\n" +
+		"under [general] they go to the OS credential store instead: the macOS Keychain,\n" +
+		"the Secret Service on Linux (secret-tool), or the Windows Credential Manager.\n" +
+		"A key the keychain refuses stays in the config file rather than being lost.",
+	Args: cobra.NoArgs,
+	RunE: runSecretsStatus,
+}
+
+var secretsMigrateCmd = &cobra.Command{
+	Use:       "migrate [keychain|plaintext]",
+	Short:     "Move API keys into the OS keychain (default) or back into the config file",
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: []string{config.SecretsKeychain, config.SecretsPlaintext},
+	RunE:      runSecretsMigrate,
+}
+
+func init() {
+	secretsCmd.AddCommand(secretsMigrateCmd)
+	rootCmd.AddCommand(secretsCmd)
+}
+
+func runSecretsStatus(_ *cobra.Command, _ []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	backend := config.SecretsPlaintext
+	if cfg.UsesKeychain() {
+		backend = config.SecretsKeychain
+	}
+	fmt.Printf("  Backend:  %s\n", backend)
+	if err := secrets.Available(); err != nil {
+		fmt.Println("  Keychain: not available on this system")
+	} else {
+		fmt.Println("  Keychain: available")
+	}
+
+	plain, err := config.PlaintextSecrets()
+	if err != nil {
+		return err
+	}
+	if len(plain) > 0 {
+		fmt.Printf("  In config file (plaintext): %s\n", strings.Join(plain, ", "))
+		if !cfg.UsesKeychain() {
+			fmt.Println("\n  Run `cburn secrets migrate` to move them to the keychain.")
+		}
+	}
+	return nil
+}
+
+func runSecretsMigrate(_ *cobra.Command, args []string) error {
+	backend := config.SecretsKeychain
+	if len(args) > 0 {
+		backend = args[0]
+	}
+	left, err := config.SetSecretsBackend(backend)
+	if err != nil {
+		return err
+	}
+	if len(left) > 0 {
+		fmt.Printf("  The keychain refused %s; kept in the config file.\n", strings.Join(left, ", "))
+		return nil
+	}
+	if backend == config.SecretsKeychain {
+		fmt.Println("  API keys are now stored in the OS keychain.")
+	} else {
+		fmt.Printf("  API keys are now stored in %s.\n", config.Path())
+	}
+	return nil
+}
@@ -3,8 +3,12 @@ package cmd
 import (
 	"fmt"
 	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/theirongolddev/cburn/internal/cli"
+	"github.com/theirongolddev/cburn/internal/model"
 	"github.com/theirongolddev/cburn/internal/pipeline"
 
 	"github.com/spf13/cobra"
@@ -16,23 +20,38 @@ var sessionsCmd = &cobra.Command{
 	RunE:  runSessions,
 }
 
-var sessionsLimit int
+var (
+	sessionsLimit  int
+	sessionsHidden bool
+)
 
 func init() {
 	sessionsCmd.Flags().IntVarP(&sessionsLimit, "limit", "l", 20, "Number of sessions to show")
+	sessionsCmd.Flags().BoolVar(&sessionsHidden, "hidden", false, "List only sessions hidden from totals (toggle with X in the TUI Sessions tab)")
 	rootCmd.AddCommand(sessionsCmd)
 }
 
 func runSessions(_ *cobra.Command, _ []string) error {
+	keepExcluded = sessionsHidden
 	result, err := loadData()
 	if err != nil {
 		return err
 	}
+	if sessionsHidden {
+		var hidden []model.SessionStats
+		for _, s := range result.Sessions {
+			if s.Excluded {
+				hidden = append(hidden, s)
+			}
+		}
+		result.Sessions = hidden
+	}
 	if len(result.Sessions) == 0 {
 		fmt.Println("\n  No sessions found.")
 		return nil
 	}
 
+	pipeline.ScoreEfficiency(result.Sessions)
 	filtered, since, until := applyFilters(result.Sessions)
 	sessions := pipeline.FilterByTime(filtered, since, until)
 
@@ -46,21 +65,40 @@ func runSessions(_ *cobra.Command, _ []string) error {
 		return sessions[i].StartTime.After(sessions[j].StartTime)
 	})
 
+	compactions := pipeline.Compactions(sessions)
+
 	// Limit
 	if sessionsLimit > 0 && len(sessions) > sessionsLimit {
 		sessions = sessions[:sessionsLimit]
 	}
 
 	fmt.Println()
-	fmt.Println(cli.RenderTitle(fmt.Sprintf("SESSIONS  Last %dd (showing %d)", flagDays, len(sessions))))
+	title := "SESSIONS"
+	if sessionsHidden {
+		title = "HIDDEN SESSIONS"
+	}
+	fmt.Println(cli.RenderTitle(fmt.Sprintf("%s  %s (showing %d)", title, periodLabel(), len(sessions))))
 	fmt.Println()
 
+	// Notes and tags get a column only when a listed session has one.
+	hasNotes := false
+	for _, s := range sessions {
+		if s.Note != "" || len(s.UserTags) > 0 {
+			hasNotes = true
+			break
+		}
+	}
+
+	now := time.Now()
 	rows := make([][]string, 0, len(sessions))
 	for _, s := range sessions {
 		startStr := ""
 		if !s.StartTime.IsZero() {
 			startStr = s.StartTime.Local().Format("Jan 02 15:04")
 		}
+		if pipeline.IsActive(s, now) {
+			startStr += " ● active"
+		}
 
 		totalTokens := s.InputTokens + s.OutputTokens +
 			s.CacheCreation5mTokens + s.CacheCreation1hTokens
@@ -70,19 +108,46 @@ func runSessions(_ *cobra.Command, _ []string) error {
 			project += " (sub)"
 		}
 
-		rows = append(rows, []string{
+		score := "-"
+		if s.EfficiencyScore > 0 {
+			score = strconv.Itoa(s.EfficiencyScore)
+		}
+
+		row := []string{
 			startStr,
 			truncate(project, 14),
 			cli.FormatDuration(s.DurationSecs),
 			cli.FormatTokens(totalTokens),
 			cli.FormatCost(s.EstimatedCost),
-		})
+			score,
+		}
+		if hasNotes {
+			note := s.Note
+			for _, tag := range s.UserTags {
+				note += " #" + tag
+			}
+			row = append(row, truncate(strings.TrimSpace(note), 40))
+		}
+		rows = append(rows, row)
 	}
 
-	fmt.Print(cli.RenderTable(cli.Table{
-		Headers: []string{"Start", "Project", "Duration", "Tokens", "Cost"},
-		Rows:    rows,
-	}))
+	tbl := cli.Table{
+		Headers:  []string{"Start", "Project", "Duration", "Tokens", "Cost", "Score"},
+		Optional: []int{5, 2, 3},
+		Flex:     1,
+		Rows:     rows,
+	}
+	if hasNotes {
+		tbl.Headers = append(tbl.Headers, "Note")
+		tbl.Optional = []int{5, 6, 2, 3}
+	}
+	fmt.Print(cli.RenderTable(tbl))
+
+	if c := compactions; c.Compactions > 0 {
+		fmt.Printf("  Compacted: %d of %d sessions (%s), %d compactions; avg %s vs %s uncompacted\n\n",
+			c.Compacted, c.Sessions, cli.FormatPercent(c.Rate()), c.Compactions,
+			cli.FormatCost(c.CompactedCost), cli.FormatCost(c.OtherCost))
+	}
 
 	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/theirongolddev/cburn/internal/config"
 	"github.com/theirongolddev/cburn/internal/source"
@@ -110,6 +111,7 @@ func runSetup(_ *cobra.Command, _ []string) error {
 	sessionKey = strings.TrimSpace(sessionKey)
 	if sessionKey != "" {
 		cfg.ClaudeAI.SessionKey = sessionKey
+		cfg.ClaudeAI.SessionExpires = time.Time{} // hand-entered keys have no known expiry
 	}
 	adminKey = strings.TrimSpace(adminKey)
 	if adminKey != "" {
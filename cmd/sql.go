@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/theirongolddev/cburn/internal/cli"
+	"github.com/theirongolddev/cburn/internal/pipeline"
+	"github.com/theirongolddev/cburn/internal/store"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagSQLJSON bool
+	flagSQLCSV  bool
+)
+
+var sqlCmd = &cobra.Command{
+	Use:   "sql <query>",
+	Short: "Run a read-only SQL query against the session cache",
+	Long: "Bring the SQLite cache up to date, then run one query against it over a\n" +
+		"read-only connection; statements that would write fail.\n\n" +
+		"The daily_costs (per day) and model_costs (per day and model) views hold\n" +
+		"the same totals as cburn daily and cburn models, without hidden sessions,\n" +
+		"over UTC days (day_utc) whatever --tz or general.timezone say. Their\n" +
+		"columns stay stable across releases. The tables behind them are\n" +
+		"described in the README and may gain columns. --privacy does not apply.\n\n" +
+		"With --no-cache the cache is queried as it stands, without scanning.",
+	Example: "  cburn sql \"SELECT day_utc, estimated_cost FROM daily_costs ORDER BY day_utc DESC LIMIT 7\"\n" +
+		"  cburn sql \"SELECT model, SUM(estimated_cost) FROM model_costs WHERE day_utc >= '2025-12-01' GROUP BY model\"\n" +
+		"  cburn sql \"SELECT project, COUNT(*) FROM sessions GROUP BY project\" --csv",
+	Args: cobra.ExactArgs(1),
+	RunE: runSQL,
+}
+
+func init() {
+	sqlCmd.Flags().BoolVar(&flagSQLJSON, "json", false, "Print rows as JSON")
+	sqlCmd.Flags().BoolVar(&flagSQLCSV, "csv", false, "Print rows as CSV")
+	rootCmd.AddCommand(sqlCmd)
+}
+
+func runSQL(_ *cobra.Command, args []string) error {
+	if flagSQLJSON && flagSQLCSV {
+		return errors.New("--json and --csv cannot be combined")
+	}
+
+	if flagNoCache {
+		// Still open it once, so an older cache gets the current views.
+		cache, err := store.Open(pipeline.CachePath())
+		if err != nil {
+			return err
+		}
+		_ = cache.Close()
+	} else if _, err := loadSessions(); err != nil {
+		return err
+	}
+
+	res, err := store.Query(pipeline.CachePath(), args[0])
+	if err != nil {
+		return fmt.Errorf("sql: %w", err)
+	}
+
+	switch {
+	case flagSQLJSON:
+		return writeSQLJSON(res)
+	case flagSQLCSV:
+		return writeSQLCSV(res)
+	}
+
+	if len(res.Rows) == 0 {
+		fmt.Println("\n  No rows.")
+		return nil
+	}
+	table := make([][]string, 0, len(res.Rows))
+	for _, r := range res.Rows {
+		row := make([]string, len(r))
+		for i, v := range r {
+			if v == nil {
+				row[i] = "NULL"
+			} else {
+				row[i] = sqlText(v)
+			}
+		}
+		table = append(table, row)
+	}
+	fmt.Println()
+	fmt.Print(cli.RenderTable(cli.Table{Headers: res.Columns, Rows: table}))
+	noun := "rows"
+	if len(res.Rows) == 1 {
+		noun = "row"
+	}
+	fmt.Printf("\n  %s %s\n", cli.FormatNumber(int64(len(res.Rows))), noun)
+	return nil
+}
+
+// sqlText renders a non-NULL SQLite value for a table or CSV cell.
+func sqlText(v any) string {
+	switch v := v.(type) {
+	case []byte:
+		return string(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprint(v)
+}
+
+func writeSQLJSON(res *store.QueryResult) error {
+	out := make([]map[string]any, 0, len(res.Rows))
+	for _, r := range res.Rows {
+		m := make(map[string]any, len(res.Columns))
+		for i, v := range r {
+			if b, ok := v.([]byte); ok {
+				v = string(b)
+			}
+			m[res.Columns[i]] = v
+		}
+		out = append(out, m)
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+func writeSQLCSV(res *store.QueryResult) error {
+	w := csv.NewWriter(os.Stdout)
+	if err := w.Write(res.Columns); err != nil {
+		return err
+	}
+	for _, r := range res.Rows {
+		rec := make([]string, len(r))
+		for i, v := range r {
+			if v != nil {
+				rec[i] = sqlText(v)
+			}
+		}
+		if err := w.Write(rec); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -11,11 +11,21 @@ import (
 	"github.com/theirongolddev/cburn/internal/claudeai"
 	"github.com/theirongolddev/cburn/internal/cli"
 	"github.com/theirongolddev/cburn/internal/config"
+	"github.com/theirongolddev/cburn/internal/pipeline"
+	"github.com/theirongolddev/cburn/internal/redact"
 
+	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/term"
 	"github.com/spf13/cobra"
 )
 
+var flagStatusOrg string
+
+// errSessionExpired is returned by fetchSubscription when claude.ai rejects
+// the session key.
+var errSessionExpired = errors.New("session key expired or invalid — run `cburn auth import-browser` or grab a fresh one from claude.ai cookies")
+
 var statusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Show claude.ai subscription status and rate limits",
@@ -23,6 +33,7 @@ var statusCmd = &cobra.Command{
 }
 
 func init() {
+	statusCmd.Flags().StringVar(&flagStatusOrg, "org", "", "Organization UUID or name (default: the saved org_id, else the first)")
 	rootCmd.AddCommand(statusCmd)
 }
 
@@ -39,36 +50,31 @@ func runStatus(_ *cobra.Command, _ []string) error {
 		fmt.Println("    3. Copy the 'sessionKey' value (starts with sk-ant-sid...)")
 		fmt.Println()
 		fmt.Println("  Then configure it:")
+		fmt.Println("    cburn auth import-browser                       (read it from your browser)")
 		fmt.Println("    cburn setup                                     (interactive)")
 		fmt.Println("    CLAUDE_SESSION_KEY=sk-ant-sid... cburn status    (one-shot)")
 		fmt.Println()
 		return nil
 	}
 
-	client := claudeai.NewClient(sessionKey)
-	if client == nil {
-		return errors.New("invalid session key format (expected sk-ant-sid... prefix)")
-	}
-
-	if !flagQuiet {
-		fmt.Fprintf(os.Stderr, "  Fetching subscription data...\n")
+	orgID := cfg.ClaudeAI.OrgID
+	if flagStatusOrg != "" {
+		orgID = flagStatusOrg
 	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	data := client.FetchAll(ctx)
-
-	if data.Error != nil {
-		if errors.Is(data.Error, claudeai.ErrUnauthorized) {
-			return errors.New("session key expired or invalid — grab a fresh one from claude.ai cookies")
+	data, err := fetchSubscription(sessionKey, orgID)
+	if errors.Is(err, errSessionExpired) && offerReimport() {
+		if err := runAuthImportBrowser(nil, nil); err != nil {
+			return err
 		}
-		if errors.Is(data.Error, claudeai.ErrRateLimited) {
-			return errors.New("rate limited by claude.ai — try again in a minute")
-		}
-		// Partial data may still be available, continue rendering
-		if data.Usage == nil && data.Overage == nil {
-			return fmt.Errorf("fetch failed: %w", data.Error)
+		cfg, _ = config.Load()
+		data, err = fetchSubscription(config.GetSessionKey(cfg), orgID)
+	}
+	if err != nil {
+		return err
+	}
+	if flagStatusOrg != "" {
+		if _, ok := claudeai.SelectOrg(data.Orgs, flagStatusOrg); !ok {
+			return fmt.Errorf("no organization matches %q (have %s)", flagStatusOrg, orgNames(data.Orgs))
 		}
 	}
 
@@ -78,10 +84,23 @@ func runStatus(_ *cobra.Command, _ []string) error {
 
 	// Organization info
 	if data.Org.UUID != "" {
-		fmt.Printf("  Organization: %s\n", data.Org.Name)
+		fmt.Printf("  Organization: %s\n", redact.Org(data.Org.Name))
 		if len(data.Org.Capabilities) > 0 {
 			fmt.Printf("  Capabilities: %s\n", strings.Join(data.Org.Capabilities, ", "))
 		}
+		if len(data.Orgs) > 1 {
+			mutedStyle := lipgloss.NewStyle().Foreground(cli.ColorTextMuted)
+			fmt.Println()
+			fmt.Println("  Organizations:")
+			for _, o := range data.Orgs {
+				marker := "  "
+				if o.UUID == data.Org.UUID {
+					marker = "▸ "
+				}
+				fmt.Printf("    %s%-30s %s\n", marker, redact.Org(o.Name), mutedStyle.Render(redact.ID(o.UUID)))
+			}
+			fmt.Println(mutedStyle.Render("  Switch with --org <uuid|name>, or pick one in the TUI Settings tab."))
+		}
 		fmt.Println()
 	}
 
@@ -104,9 +123,10 @@ func runStatus(_ *cobra.Command, _ []string) error {
 
 		if len(rows) > 0 {
 			fmt.Print(cli.RenderTable(cli.Table{
-				Title:   "Rate Limits",
-				Headers: []string{"Window", "Used", "Bar", "Resets"},
-				Rows:    rows,
+				Title:    "Rate Limits",
+				Headers:  []string{"Window", "Used", "Bar", "Resets"},
+				Optional: []int{2},
+				Rows:     rows,
 			}))
 		}
 	}
@@ -138,16 +158,90 @@ func runStatus(_ *cobra.Command, _ []string) error {
 	}
 
 	// Partial error warning
-	if data.Error != nil {
+	if data.Stale {
+		warnStyle := lipgloss.NewStyle().Foreground(cli.ColorOrange)
+		fmt.Printf("  %s\n\n", warnStyle.Render(fmt.Sprintf("claude.ai unreachable, showing cached data — %s", data.Error)))
+	} else if data.Error != nil {
 		warnStyle := lipgloss.NewStyle().Foreground(cli.ColorOrange)
 		fmt.Printf("  %s\n\n", warnStyle.Render(fmt.Sprintf("Partial data — %s", data.Error)))
 	}
 
-	fmt.Printf("  Fetched at %s\n\n", data.FetchedAt.Format("3:04:05 PM"))
+	if cfg.ClaudeAI.SessionExpiresWithin(sessionExpiryWarning, time.Now()) {
+		warnStyle := lipgloss.NewStyle().Foreground(cli.ColorOrange)
+		fmt.Printf("  %s\n\n", warnStyle.Render(fmt.Sprintf(
+			"Session key expires %s — refresh it with `cburn auth import-browser`",
+			cfg.ClaudeAI.SessionExpires.Local().Format("Jan 02 15:04"))))
+	}
+
+	if data.Stale {
+		fmt.Printf("  As of %s\n\n", data.FetchedAt.Local().Format("Jan 02 3:04:05 PM"))
+	} else {
+		fmt.Printf("  Fetched at %s\n\n", data.FetchedAt.Format("3:04:05 PM"))
+	}
 
 	return nil
 }
 
+// offerReimport asks whether to re-import an expired session key from the
+// browser. It only asks on an interactive terminal.
+func offerReimport() bool {
+	if !term.IsTerminal(os.Stdin.Fd()) || !term.IsTerminal(os.Stdout.Fd()) {
+		return false
+	}
+	fmt.Fprintf(os.Stderr, "  %s\n", errSessionExpired)
+	ok := false
+	if err := huh.NewConfirm().Title("Re-import the session key from your browser?").Value(&ok).Run(); err != nil {
+		return false
+	}
+	return ok
+}
+
+// fetchSubscription fetches claude.ai subscription data for the preferred
+// organization (see claudeai.Client.FetchAll), mapping auth and rate-limit
+// failures to actionable errors. Partial or stale data is returned with
+// data.Error set.
+func fetchSubscription(sessionKey, orgID string) (*claudeai.SubscriptionData, error) {
+	client := claudeai.NewClient(sessionKey)
+	if client == nil {
+		return nil, errors.New("invalid session key format (expected sk-ant-sid... prefix)")
+	}
+
+	if !flagQuiet {
+		fmt.Fprintf(os.Stderr, "  Fetching subscription data...\n")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	data := client.WithCache(pipeline.CacheDir()).FetchAll(ctx, orgID)
+
+	if data.Error != nil {
+		if errors.Is(data.Error, claudeai.ErrUnauthorized) {
+			return nil, errSessionExpired
+		}
+		if data.Stale {
+			return data, nil
+		}
+		if errors.Is(data.Error, claudeai.ErrRateLimited) {
+			return nil, errors.New("rate limited by claude.ai — try again in a minute")
+		}
+		// Partial data may still be available, continue rendering
+		if data.Usage == nil && data.Overage == nil {
+			return nil, fmt.Errorf("fetch failed: %w", data.Error)
+		}
+	}
+	return data, nil
+}
+
+// orgNames lists organizations as "Name (uuid)" for error messages.
+func orgNames(orgs []claudeai.Organization) string {
+	names := make([]string, 0, len(orgs))
+	for _, o := range orgs {
+		names = append(names, fmt.Sprintf("%s (%s)", o.Name, o.UUID))
+	}
+	return strings.Join(names, ", ")
+}
+
 func rateLimitRow(label string, w *claudeai.ParsedWindow) []string {
 	pctStr := fmt.Sprintf("%.0f%%", w.Pct*100)
 	bar := renderMiniBar(w.Pct, 20)
@@ -184,8 +278,12 @@ func renderMiniBar(pct float64, width int) string {
 	barStyle := lipgloss.NewStyle().Foreground(color)
 	dimStyle := lipgloss.NewStyle().Foreground(cli.ColorTextDim)
 
-	return barStyle.Render(strings.Repeat("█", filled)) +
-		dimStyle.Render(strings.Repeat("░", empty))
+	full, rest := cli.BarCells(filled, empty)
+	bar := barStyle.Render(full) + dimStyle.Render(rest)
+	if mark := cli.SeverityMark(pct, 0.5, 0.8); mark != "" {
+		bar += " " + mark
+	}
+	return bar
 }
 
 func formatCountdown(d time.Duration) string {
@@ -5,7 +5,10 @@ import (
 	"os"
 
 	"github.com/theirongolddev/cburn/internal/cli"
+	"github.com/theirongolddev/cburn/internal/config"
+	"github.com/theirongolddev/cburn/internal/model"
 	"github.com/theirongolddev/cburn/internal/pipeline"
+	"github.com/theirongolddev/cburn/internal/store"
 
 	"github.com/spf13/cobra"
 )
@@ -21,6 +24,14 @@ func init() {
 }
 
 func runSummary(_ *cobra.Command, _ []string) error {
+	// Custom metrics need every session, which the quick path never loads.
+	if !pipeline.HasMetrics() {
+		if stats, prevStats, ok := quickSummary(); ok {
+			renderSummary(stats, prevStats, nil)
+			return nil
+		}
+	}
+
 	result, err := loadData()
 	if err != nil {
 		return err
@@ -45,9 +56,67 @@ func runSummary(_ *cobra.Command, _ []string) error {
 	prevSince := since.Add(-prevDuration)
 	prevStats := pipeline.Aggregate(filtered, prevSince, since)
 
+	// Budget progress for the billing period
+	var budget *pipeline.BudgetProgress
+	if cfg, _ := config.Load(); flagBilling && cfg.Budget.MonthlyUSD != nil {
+		bp := pipeline.AggregateBudget(filtered, *cfg.Budget.MonthlyUSD, cfg.Budget.BillingDay, until)
+		budget = &bp
+	}
+
+	renderSummary(stats, prevStats, budget)
+	renderMetricCards(pipeline.ComputeMetrics(filtered, since, until))
+
+	// Print warnings
+	if result.FileErrors > 0 {
+		fmt.Fprintf(os.Stderr, "\n  %d files could not be parsed\n", result.FileErrors)
+	}
+
+	return nil
+}
+
+// quickSummary answers from the cache's pre-aggregated daily totals when no
+// session file changed since the last load, skipping the full session load.
+// ok is false whenever it can't, and runSummary loads everything instead.
+func quickSummary() (stats, prevStats model.SummaryStats, ok bool) {
+	// --model needs per-session model lists, --billing the sessions for
+	// budget progress, and receipts are written during a full load. The
+	// cached totals hold raw durations at the default idle gap.
+	if flagNoCache || flagModel != "" || flagBilling {
+		return stats, prevStats, false
+	}
+	if pipeline.GapAware() || pipeline.IdleGap() != pipeline.DefaultIdleGap {
+		return stats, prevStats, false
+	}
+	cfg, _ := config.Load()
+	if cfg.Receipts.Enabled {
+		return stats, prevStats, false
+	}
+	// Cached totals are filed under each session's own project name, before
+	// renamed and moved projects are merged.
+	if flagProject != "" && (cfg.Projects.MergeByRepo || len(cfg.Projects.Aliases) > 0) {
+		return stats, prevStats, false
+	}
+	cache, err := store.Open(pipeline.CachePath())
+	if err != nil {
+		return stats, prevStats, false
+	}
+	defer func() { _ = cache.Close() }()
+
+	since, until := timeWindow()
+	prevSince := since.Add(-until.Sub(since))
+	res, ok, err := pipeline.SummaryFromCache(scanRoots(), !flagNoSubagents, cache,
+		pipeline.SummaryQuery{Since: since, Until: until, Source: flagSource, Project: flagProject},
+		pipeline.SummaryQuery{Since: prevSince, Until: since, Source: flagSource, Project: flagProject})
+	if err != nil || !ok || res[0].TotalSessions == 0 {
+		return stats, prevStats, false
+	}
+	return res[0], res[1], true
+}
+
+func renderSummary(stats, prevStats model.SummaryStats, budget *pipeline.BudgetProgress) {
 	// Render output
 	fmt.Println()
-	fmt.Println(cli.RenderTitle(fmt.Sprintf("CLAUDE USAGE  Last %dd", flagDays)))
+	fmt.Println(cli.RenderTitle("CLAUDE USAGE  " + periodLabel()))
 	fmt.Println()
 
 	// Build the summary table
@@ -55,6 +124,14 @@ func runSummary(_ *cobra.Command, _ []string) error {
 		{"Sessions", cli.FormatNumber(int64(stats.TotalSessions))},
 		{"Prompts", cli.FormatNumber(int64(stats.TotalPrompts))},
 		{"Total Time", cli.FormatDuration(stats.TotalDurationSecs)},
+	}
+	if stats.ActiveSecs > 0 {
+		rows = append(rows, []string{"Active Time", cli.FormatDuration(stats.ActiveSecs) + "  (idle gaps excluded)"})
+	}
+	if stats.ClaudeSecs > 0 {
+		rows = append(rows, []string{"Claude Time", cli.FormatDuration(stats.ClaudeSecs)})
+	}
+	rows = append(rows, [][]string{
 		{"---"},
 		{"Input Tokens", cli.FormatTokens(stats.InputTokens)},
 		{"Output Tokens", cli.FormatTokens(stats.OutputTokens)},
@@ -67,29 +144,61 @@ func runSummary(_ *cobra.Command, _ []string) error {
 		{"Cache Savings", cli.FormatCost(stats.CacheSavings)},
 		{"Cache Hit Rate", cli.FormatPercent(stats.CacheHitRate)},
 		{"---"},
-	}
+	}...)
 
 	// Cost per day with delta
 	costDayStr := cli.FormatCost(stats.CostPerDay) + "/day"
 	if prevStats.CostPerDay > 0 {
-		costDayStr += fmt.Sprintf("  (%s vs prev %dd)",
-			cli.FormatDelta(stats.CostPerDay, prevStats.CostPerDay), flagDays)
+		costDayStr += fmt.Sprintf("  (%s vs prev %s)",
+			cli.FormatDelta(stats.CostPerDay, prevStats.CostPerDay), periodShort())
 	}
 	rows = append(rows, []string{"Cost/day", costDayStr})
+	cfg, _ := config.Load()
+	if name, usd, ok := cfg.Budget.Subscription(); ok {
+		rows = append(rows, []string{"Plan Equivalent", cli.FormatPlanEquivalent(stats.CostPerDay, usd, name)})
+	}
 	rows = append(rows, []string{"Tokens/day", cli.FormatTokens(stats.TokensPerDay)})
+	if perMin := pipeline.OutputPerMinute(stats.OutputTokens, stats.TotalDurationSecs); perMin > 0 {
+		throughput := cli.FormatTokens(int64(perMin)) + " out/min"
+		if prevMin := pipeline.OutputPerMinute(prevStats.OutputTokens, prevStats.TotalDurationSecs); prevMin > 0 {
+			throughput += fmt.Sprintf("  (%+.0f%% vs prev %s)", (perMin/prevMin-1)*100, periodShort())
+		}
+		rows = append(rows, []string{"Throughput", throughput})
+	}
+	if mpd := pipeline.MinutesPerDollar(stats.ActiveSecs, stats.EstimatedCost); mpd > 0 {
+		rows = append(rows, []string{"Minutes per $", fmt.Sprintf("%.1f active min", mpd)})
+	}
 	rows = append(rows, []string{"Sessions/day", fmt.Sprintf("%.1f", stats.SessionsPerDay)})
 
+	if budget != nil {
+		rows = append(rows, []string{"---"})
+		rows = append(rows, []string{"Budget", fmt.Sprintf("%s of %s (%s)",
+			cli.FormatCost(budget.Spent), cli.FormatCost(budget.Budget), cli.FormatPercent(budget.Pct()))})
+		if budget.Projected > 0 {
+			rows = append(rows, []string{"Projected", fmt.Sprintf("%s by %s",
+				cli.FormatCost(budget.Projected), budget.End.Format("Jan 2"))})
+		}
+	}
+
 	table := cli.Table{
 		Headers: []string{"Metric", "Value"},
 		Rows:    rows,
 	}
 
 	fmt.Print(cli.RenderTable(table))
+}
 
-	// Print warnings
-	if result.FileErrors > 0 {
-		fmt.Fprintf(os.Stderr, "\n  %d files could not be parsed\n", result.FileErrors)
+// renderMetricCards prints the cards of custom metrics (see pkg/plugin).
+func renderMetricCards(cards []model.MetricCard) {
+	for _, c := range cards {
+		rows := make([][]string, 0, len(c.Rows))
+		for _, r := range c.Rows {
+			rows = append(rows, []string{r.Label, r.Value})
+		}
+		fmt.Print(cli.RenderTable(cli.Table{
+			Title:   c.Title,
+			Headers: []string{"Metric", "Value"},
+			Rows:    rows,
+		}))
 	}
-
-	return nil
 }
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/cli"
+	"github.com/theirongolddev/cburn/internal/redact"
+	"github.com/theirongolddev/cburn/internal/source"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var flagTailInterval time.Duration
+
+var tailCmd = &cobra.Command{
+	Use:   "tail",
+	Short: "Follow the newest session and print each API call as it happens",
+	RunE:  runTail,
+}
+
+func init() {
+	tailCmd.Flags().DurationVar(&flagTailInterval, "interval", time.Second, "Poll interval")
+	rootCmd.AddCommand(tailCmd)
+}
+
+func runTail(_ *cobra.Command, _ []string) error {
+	if flagTailInterval < 100*time.Millisecond {
+		flagTailInterval = 100 * time.Millisecond
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	timeStyle := lipgloss.NewStyle().Foreground(cli.ColorTextMuted)
+	modelStyle := lipgloss.NewStyle().Foreground(cli.ColorBlue)
+	costStyle := lipgloss.NewStyle().Foreground(cli.ColorGreen).Bold(true)
+	totalStyle := lipgloss.NewStyle().Foreground(cli.ColorTextDim)
+	headStyle := lipgloss.NewStyle().Foreground(cli.ColorAccent).Bold(true)
+
+	follower := source.NewFollower(flagDataDir, !flagNoSubagents, 5*time.Second)
+	var sessionCost float64
+
+	ticker := time.NewTicker(flagTailInterval)
+	defer ticker.Stop()
+
+	fmt.Println(headStyle.Render("  Waiting for session activity... (Ctrl+C to stop)"))
+
+	for {
+		upd, err := follower.Poll()
+		if err != nil && !flagQuiet {
+			fmt.Fprintf(os.Stderr, "  tail: %v\n", err)
+		}
+		if upd.Switched {
+			sessionCost = 0
+			label := redact.Project(upd.File.Project) + "  " + redact.ID(upd.File.SessionID)
+			if upd.File.IsSubagent {
+				label += "  (subagent)"
+			}
+			fmt.Println()
+			fmt.Println(headStyle.Render("  ▸ " + label))
+		}
+		for _, c := range upd.Calls {
+			sessionCost += c.EstimatedCost
+			fmt.Printf("  %s  %s in %7s  out %7s  cache r/w %7s/%-7s  %s  %s\n",
+				timeStyle.Render(c.Timestamp.Local().Format("15:04:05")),
+				modelStyle.Render(fmt.Sprintf("%-18s", truncate(shortModel(c.Model), 18))),
+				cli.FormatTokens(c.InputTokens),
+				cli.FormatTokens(c.OutputTokens),
+				cli.FormatTokens(c.CacheReadTokens),
+				cli.FormatTokens(c.CacheCreation5mTokens+c.CacheCreation1hTokens),
+				costStyle.Render(fmt.Sprintf("%8s", cli.FormatCost(c.EstimatedCost))),
+				totalStyle.Render("Σ "+cli.FormatCost(sessionCost)),
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/adminapi"
+	"github.com/theirongolddev/cburn/internal/cli"
+	"github.com/theirongolddev/cburn/internal/config"
+	"github.com/theirongolddev/cburn/internal/redact"
+
+	"github.com/spf13/cobra"
+)
+
+var teamLimit int
+
+var teamCmd = &cobra.Command{
+	Use:   "team",
+	Short: "Org-wide Claude Code usage per user (Admin API)",
+	Long: "Fetch the organization's Claude Code usage report from the Anthropic Admin API\n" +
+		"and rank users and API keys by estimated cost. Requires an Admin API key\n" +
+		"(admin_api.api_key or ANTHROPIC_ADMIN_KEY).",
+	RunE: runTeam,
+}
+
+func init() {
+	teamCmd.Flags().IntVarP(&teamLimit, "limit", "l", 0, "Show at most this many users (0 = all)")
+	rootCmd.AddCommand(teamCmd)
+}
+
+func runTeam(_ *cobra.Command, _ []string) error {
+	cfg, _ := config.Load()
+	client, err := adminClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	since, until := timeWindow()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	records, err := client.FetchClaudeCodeUsage(ctx, since, until)
+	if err != nil {
+		return err
+	}
+	users := adminapi.TeamUsage(records)
+	if len(users) == 0 {
+		fmt.Println("\n  No Claude Code usage reported for the organization in the selected time range.")
+		return nil
+	}
+
+	var totalCost float64
+	var totalSessions int
+	for _, u := range users {
+		totalCost += u.CostUSD
+		totalSessions += u.Sessions
+	}
+
+	fmt.Println()
+	fmt.Println(cli.RenderTitle("TEAM  " + periodLabel()))
+	fmt.Println()
+
+	shown := users
+	if teamLimit > 0 && len(shown) > teamLimit {
+		shown = shown[:teamLimit]
+	}
+	rows := make([][]string, 0, len(shown))
+	for i, u := range shown {
+		name := redact.User(u.Actor)
+		if u.IsAPIKey {
+			name += " (key)"
+		}
+		share := "-"
+		if totalCost > 0 {
+			share = fmt.Sprintf("%.0f%%", u.CostUSD/totalCost*100)
+		}
+		rows = append(rows, []string{
+			fmt.Sprintf("%d", i+1),
+			truncate(name, 32),
+			cli.FormatNumber(int64(u.Sessions)),
+			cli.FormatNumber(int64(u.ActiveDays)),
+			cli.FormatTokens(u.TotalTokens()),
+			cli.FormatTokens(u.OutputTokens),
+			fmt.Sprintf("+%s/-%s", cli.FormatNumber(int64(u.LinesAdded)), cli.FormatNumber(int64(u.LinesRemoved))),
+			cli.FormatNumber(int64(u.Commits)),
+			cli.FormatCost(u.CostUSD),
+			share,
+		})
+	}
+
+	fmt.Print(cli.RenderTable(cli.Table{
+		Headers:  []string{"#", "User", "Sessions", "Days", "Tokens", "Output", "Lines", "Commits", "Cost", "Share"},
+		Optional: []int{6, 7, 5, 3},
+		Flex:     1,
+		Rows:     rows,
+	}))
+	fmt.Printf("\n  %d users · %s sessions · %s estimated\n",
+		len(users), cli.FormatNumber(int64(totalSessions)), cli.FormatCost(totalCost))
+	return nil
+}
+
+// adminClient returns an Admin API client for the configured key.
+func adminClient(cfg config.Config) (*adminapi.Client, error) {
+	key := config.GetAdminAPIKey(cfg)
+	if key == "" {
+		return nil, errors.New("no Admin API key configured (set ANTHROPIC_ADMIN_KEY, or run cburn setup)")
+	}
+	client := adminapi.NewClient(key)
+	if client == nil {
+		return nil, errors.New("admin API key must start with sk-ant-admin")
+	}
+	return client, nil
+}
@@ -0,0 +1,223 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/adminapi"
+	"github.com/theirongolddev/cburn/internal/cli"
+	"github.com/theirongolddev/cburn/internal/config"
+	"github.com/theirongolddev/cburn/internal/redact"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	teamKeysBy        string
+	teamKeysWorkspace string
+	teamKeysKey       string
+	teamKeysJSON      bool
+	teamKeysCSV       bool
+)
+
+var teamKeysCmd = &cobra.Command{
+	Use:   "keys",
+	Short: "API usage per API key or workspace (Admin API)",
+	Long: "Break down the organization's Messages API usage by API key or workspace,\n" +
+		"to see which service or teammate drives spend. Costs are estimated from\n" +
+		"token counts with cburn's pricing table.",
+	RunE: runTeamKeys,
+}
+
+func init() {
+	teamKeysCmd.Flags().StringVar(&teamKeysBy, "by", "key", "Group by \"key\" or \"workspace\"")
+	teamKeysCmd.Flags().StringVar(&teamKeysWorkspace, "workspace", "", "Only workspaces whose name or ID contains this")
+	teamKeysCmd.Flags().StringVar(&teamKeysKey, "key", "", "Only API keys whose name or ID contains this")
+	teamKeysCmd.Flags().BoolVar(&teamKeysJSON, "json", false, "Print rows as JSON")
+	teamKeysCmd.Flags().BoolVar(&teamKeysCSV, "csv", false, "Print rows as CSV")
+	teamCmd.AddCommand(teamKeysCmd)
+}
+
+func runTeamKeys(_ *cobra.Command, _ []string) error {
+	if teamKeysBy != "key" && teamKeysBy != "workspace" {
+		return fmt.Errorf("--by must be \"key\" or \"workspace\", not %q", teamKeysBy)
+	}
+	if teamKeysJSON && teamKeysCSV {
+		return errors.New("--json and --csv cannot be combined")
+	}
+	byWorkspace := teamKeysBy == "workspace"
+	if byWorkspace && teamKeysKey != "" {
+		return errors.New("--key filters keys; it cannot be combined with --by workspace")
+	}
+
+	cfg, _ := config.Load()
+	client, err := adminClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	since, until := timeWindow()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	buckets, err := client.FetchMessagesUsage(ctx, since, until)
+	if err != nil {
+		return err
+	}
+	// Names are a nicety; without them rows fall back to IDs.
+	keys, _ := client.FetchAPIKeys(ctx)
+	workspaces, _ := client.FetchWorkspaces(ctx)
+
+	rows := adminapi.KeyBreakdown(buckets, keys, workspaces, config.CalculateCost, byWorkspace)
+	rows = filterKeyUsage(rows, teamKeysWorkspace, teamKeysKey)
+	if redact.Enabled() {
+		for i := range rows {
+			if r := &rows[i]; r.KeyID != "" {
+				r.Key, r.KeyID = redact.User(r.Key), redact.ID(r.KeyID)
+			}
+			if r := &rows[i]; r.WorkspaceID != "" {
+				r.Workspace, r.WorkspaceID = redact.Org(r.Workspace), redact.ID(r.WorkspaceID)
+			}
+		}
+	}
+
+	switch {
+	case teamKeysJSON:
+		return writeKeyUsageJSON(rows, byWorkspace)
+	case teamKeysCSV:
+		return writeKeyUsageCSV(rows, byWorkspace)
+	}
+
+	if len(rows) == 0 {
+		fmt.Println("\n  No API usage in the selected time range.")
+		return nil
+	}
+
+	var total float64
+	for _, r := range rows {
+		total += r.EstimatedCost
+	}
+
+	title, noun := "API KEYS  ", "keys"
+	headers := []string{"Key", "Workspace"}
+	if byWorkspace {
+		title, noun = "WORKSPACES  ", "workspaces"
+		headers = []string{"Workspace"}
+	}
+	headers = append(headers, "Days", "Input", "Output", "Cache R/W", "Cost", "Share")
+
+	fmt.Println()
+	fmt.Println(cli.RenderTitle(title + periodLabel()))
+	fmt.Println()
+
+	table := make([][]string, 0, len(rows))
+	for _, r := range rows {
+		var row []string
+		if byWorkspace {
+			row = []string{truncate(r.Workspace, 30)}
+		} else {
+			row = []string{truncate(r.Key, 28), truncate(r.Workspace, 20)}
+		}
+		share := "-"
+		if total > 0 {
+			share = fmt.Sprintf("%.0f%%", r.EstimatedCost/total*100)
+		}
+		table = append(table, append(row,
+			cli.FormatNumber(int64(r.ActiveDays)),
+			cli.FormatTokens(r.InputTokens),
+			cli.FormatTokens(r.OutputTokens),
+			cli.FormatTokens(r.CacheRead)+"/"+cli.FormatTokens(r.Cache5m+r.Cache1h),
+			cli.FormatCost(r.EstimatedCost),
+			share,
+		))
+	}
+
+	n := len(headers)
+	fmt.Print(cli.RenderTable(cli.Table{
+		Headers:  headers,
+		Optional: []int{n - 5, n - 6, n - 1},
+		Rows:     table,
+	}))
+	fmt.Printf("\n  %s estimated across %d %s\n", cli.FormatCost(total), len(rows), noun)
+	return nil
+}
+
+// filterKeyUsage keeps rows whose workspace and key name or ID contain the
+// given case-insensitive substrings.
+func filterKeyUsage(rows []adminapi.KeyUsage, workspace, key string) []adminapi.KeyUsage {
+	contains := func(name, id, sub string) bool {
+		sub = strings.ToLower(sub)
+		return sub == "" || strings.Contains(strings.ToLower(name), sub) || strings.Contains(strings.ToLower(id), sub)
+	}
+	out := rows[:0:0]
+	for _, r := range rows {
+		if contains(r.Workspace, r.WorkspaceID, workspace) && contains(r.Key, r.KeyID, key) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+func writeKeyUsageJSON(rows []adminapi.KeyUsage, byWorkspace bool) error {
+	out := make([]map[string]any, 0, len(rows))
+	for _, r := range rows {
+		m := map[string]any{
+			"workspace_id":       r.WorkspaceID,
+			"workspace":          r.Workspace,
+			"active_days":        r.ActiveDays,
+			"input_tokens":       r.InputTokens,
+			"output_tokens":      r.OutputTokens,
+			"cache_5m_tokens":    r.Cache5m,
+			"cache_1h_tokens":    r.Cache1h,
+			"cache_read_tokens":  r.CacheRead,
+			"estimated_cost_usd": r.EstimatedCost,
+		}
+		if !byWorkspace {
+			m["api_key_id"] = r.KeyID
+			m["api_key"] = r.Key
+		}
+		out = append(out, m)
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+func writeKeyUsageCSV(rows []adminapi.KeyUsage, byWorkspace bool) error {
+	w := csv.NewWriter(os.Stdout)
+	header := []string{"workspace_id", "workspace"}
+	if !byWorkspace {
+		header = append([]string{"api_key_id", "api_key"}, header...)
+	}
+	header = append(header, "active_days", "input_tokens", "output_tokens",
+		"cache_5m_tokens", "cache_1h_tokens", "cache_read_tokens", "estimated_cost_usd")
+	if err := w.Write(header); err != nil {
+		return err
+	}
+	for _, r := range rows {
+		rec := []string{r.WorkspaceID, r.Workspace}
+		if !byWorkspace {
+			rec = append([]string{r.KeyID, r.Key}, rec...)
+		}
+		rec = append(rec,
+			strconv.Itoa(r.ActiveDays),
+			strconv.FormatInt(r.InputTokens, 10),
+			strconv.FormatInt(r.OutputTokens, 10),
+			strconv.FormatInt(r.Cache5m, 10),
+			strconv.FormatInt(r.Cache1h, 10),
+			strconv.FormatInt(r.CacheRead, 10),
+			strconv.FormatFloat(r.EstimatedCost, 'f', 4, 64),
+		)
+		if err := w.Write(rec); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/claudeai"
+	"github.com/theirongolddev/cburn/internal/cli"
+	"github.com/theirongolddev/cburn/internal/config"
+	"github.com/theirongolddev/cburn/internal/model"
+	"github.com/theirongolddev/cburn/internal/pipeline"
+	"github.com/theirongolddev/cburn/internal/redact"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+// topRateLimitEvery is how often `cburn top` refreshes claude.ai rate limits.
+const topRateLimitEvery = time.Minute
+
+var (
+	flagTopInterval time.Duration
+	flagTopSessions int
+)
+
+var topCmd = &cobra.Command{
+	Use:   "top",
+	Short: "Live, compact view of today's cost, rate limits, and recent sessions",
+	Long: "A continuously refreshing screen for a small terminal or tmux pane, without the\n" +
+		"full dashboard. Rate limits are shown when a claude.ai session key is configured.",
+	RunE: runTop,
+}
+
+func init() {
+	topCmd.Flags().DurationVar(&flagTopInterval, "interval", 10*time.Second, "Refresh interval")
+	topCmd.Flags().IntVar(&flagTopSessions, "sessions", 6, "Number of recent sessions to show")
+	rootCmd.AddCommand(topCmd)
+}
+
+type topRateLimits struct {
+	usage *claudeai.ParsedUsage
+	err   error
+}
+
+func runTop(_ *cobra.Command, _ []string) error {
+	if flagTopInterval < time.Second {
+		flagTopInterval = time.Second
+	}
+	// Progress output would scroll the screen.
+	flagQuiet = true
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	cfg, _ := config.Load()
+	sessionKey := config.GetSessionKey(cfg)
+
+	// Rate limits are fetched in the background so a slow claude.ai response
+	// never stalls the screen.
+	rlCh := make(chan topRateLimits, 1)
+	var rl *topRateLimits
+	var lastRLFetch time.Time
+	rlInFlight := false
+	fetchRateLimits := func() {
+		rlInFlight = true
+		lastRLFetch = time.Now()
+		go func() {
+			data, err := fetchSubscription(sessionKey, cfg.ClaudeAI.OrgID)
+			res := topRateLimits{err: err}
+			if data != nil {
+				res.usage = data.Usage
+			}
+			rlCh <- res
+		}()
+	}
+
+	ticker := time.NewTicker(flagTopInterval)
+	defer ticker.Stop()
+
+	// Hide the cursor while running; restore it on exit.
+	fmt.Print("\x1b[?25l")
+	defer fmt.Print("\x1b[?25h\n")
+
+	var sessions []model.SessionStats
+	var loadErr error
+	reload := true
+	for {
+		if sessionKey != "" && !rlInFlight && time.Since(lastRLFetch) >= topRateLimitEvery {
+			fetchRateLimits()
+		}
+		if reload {
+			result, err := loadSessions()
+			loadErr = err
+			if err == nil {
+				sessions = result.Sessions
+				if flagSource != "" {
+					sessions = pipeline.FilterBySource(sessions, flagSource)
+				}
+			}
+			reload = false
+		}
+
+		frame := renderTopFrame(sessions, loadErr, rl, sessionKey != "", cfg.Budget, time.Now())
+		// Home the cursor and clear the screen, then draw the frame.
+		fmt.Print("\x1b[H\x1b[2J" + frame)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			reload = true
+		case res := <-rlCh:
+			rlInFlight = false
+			rl = &res
+		}
+	}
+}
+
+func renderTopFrame(sessions []model.SessionStats, loadErr error, rl *topRateLimits, hasKey bool, budget config.BudgetConfig, now time.Time) string {
+	headStyle := lipgloss.NewStyle().Foreground(cli.ColorAccent).Bold(true)
+	dimStyle := lipgloss.NewStyle().Foreground(cli.ColorTextDim)
+	mutedStyle := lipgloss.NewStyle().Foreground(cli.ColorTextMuted)
+	costStyle := lipgloss.NewStyle().Foreground(cli.ColorGreen).Bold(true)
+	activeStyle := lipgloss.NewStyle().Foreground(cli.ColorGreen)
+	warnStyle := lipgloss.NewStyle().Foreground(cli.ColorOrange)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, " %s  %s\n\n",
+		headStyle.Render("cburn top"),
+		dimStyle.Render(fmt.Sprintf("%s  every %s  Ctrl+C to quit", now.Format("15:04:05"), flagTopInterval)))
+
+	if loadErr != nil {
+		fmt.Fprintf(&b, " %s\n\n", warnStyle.Render("load failed: "+loadErr.Error()))
+	}
+
+	// Today and billing-period-to-date
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	today := pipeline.Aggregate(sessions, dayStart, now)
+	var limit float64
+	if budget.MonthlyUSD != nil {
+		limit = *budget.MonthlyUSD
+	}
+	period := pipeline.AggregateBudget(sessions, limit, budget.BillingDay, now)
+	fmt.Fprintf(&b, " %s %s  %s  %s  %s\n",
+		mutedStyle.Render("Today "),
+		costStyle.Render(fmt.Sprintf("%9s", cli.FormatCost(today.EstimatedCost))),
+		cli.FormatTokens(today.TotalBilledTokens)+" tokens",
+		cli.FormatNumber(int64(today.TotalPrompts))+" prompts",
+		cli.FormatNumber(int64(today.TotalSessions))+" sessions")
+	periodLine := fmt.Sprintf("%9s", cli.FormatCost(period.Spent))
+	if period.Budget > 0 {
+		style := dimStyle
+		if period.Pct() >= 1 {
+			style = warnStyle
+		}
+		periodLine += "  " + style.Render(fmt.Sprintf("of %s (%.0f%%)", cli.FormatCost(period.Budget), period.Pct()*100))
+	}
+	fmt.Fprintf(&b, " %s %s  %s\n\n",
+		mutedStyle.Render("Period"),
+		periodLine,
+		dimStyle.Render("since "+period.Start.Format("Jan 2")))
+
+	// Rate limits
+	if hasKey {
+		b.WriteString(" " + headStyle.Render("Rate limits") + "\n")
+		switch {
+		case rl == nil:
+			b.WriteString("   " + dimStyle.Render("fetching...") + "\n")
+		case rl.usage == nil:
+			msg := "unavailable"
+			if rl.err != nil {
+				msg = rl.err.Error()
+			}
+			b.WriteString("   " + warnStyle.Render(msg) + "\n")
+		default:
+			for _, nw := range rl.usage.Windows() {
+				resets := ""
+				if !nw.Window.ResetsAt.IsZero() {
+					if d := nw.Window.ResetsAt.Sub(now); d > 0 {
+						resets = "resets in " + formatCountdown(d)
+					} else {
+						resets = "resetting"
+					}
+				}
+				fmt.Fprintf(&b, "   %-14s %s %4.0f%%  %s\n",
+					nw.Label, renderMiniBar(nw.Window.Pct, 16), nw.Window.Pct*100, dimStyle.Render(resets))
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	// Recent sessions, most recently active first
+	recent := make([]model.SessionStats, 0, len(sessions))
+	for _, s := range sessions {
+		if !s.IsSubagent && !s.EndTime.IsZero() {
+			recent = append(recent, s)
+		}
+	}
+	sort.Slice(recent, func(i, j int) bool { return recent[i].EndTime.After(recent[j].EndTime) })
+	if len(recent) > flagTopSessions {
+		recent = recent[:flagTopSessions]
+	}
+
+	b.WriteString(" " + headStyle.Render("Recent sessions") + "\n")
+	if len(recent) == 0 {
+		b.WriteString("   " + dimStyle.Render("none") + "\n")
+	}
+	for _, s := range recent {
+		marker := dimStyle.Render("○")
+		if pipeline.IsActive(s, now) {
+			marker = activeStyle.Render("●")
+		}
+		fmt.Fprintf(&b, "   %s %-18s %s  %6s  %s\n",
+			marker,
+			truncate(redact.Project(s.Project), 18),
+			mutedStyle.Render(s.EndTime.Local().Format("15:04")),
+			cli.FormatDuration(s.DurationSecs),
+			costStyle.Render(fmt.Sprintf("%8s", cli.FormatCost(s.EstimatedCost))))
+	}
+
+	return b.String()
+}
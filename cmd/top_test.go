@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/config"
+	"github.com/theirongolddev/cburn/internal/model"
+	"github.com/theirongolddev/cburn/internal/redact"
+)
+
+func TestRenderTopFramePrivacy(t *testing.T) {
+	now := time.Date(2025, 6, 2, 12, 0, 0, 0, time.Local)
+	sessions := []model.SessionStats{{
+		SessionID: "a", Project: "acme-billing", StartTime: now.Add(-time.Hour),
+		EndTime: now.Add(-30 * time.Minute), EstimatedCost: 2,
+	}}
+
+	frame := renderTopFrame(sessions, nil, nil, false, config.BudgetConfig{}, now)
+	if !strings.Contains(frame, "acme-billing") {
+		t.Fatalf("project missing with privacy off:\n%s", frame)
+	}
+
+	redact.SetEnabled(true)
+	t.Cleanup(func() { redact.SetEnabled(false) })
+	frame = renderTopFrame(sessions, nil, nil, false, config.BudgetConfig{}, now)
+	if strings.Contains(frame, "acme-billing") {
+		t.Errorf("project shown with privacy on:\n%s", frame)
+	}
+	if !strings.Contains(frame, redact.Name("project", "acme-billing")) {
+		t.Errorf("pseudonym missing with privacy on:\n%s", frame)
+	}
+}
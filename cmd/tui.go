@@ -32,7 +32,17 @@ func runTUI(_ *cobra.Command, _ []string) error {
 	// Without this, lipgloss may default to Ascii profile (no colors)
 	lipgloss.SetColorProfile(termenv.TrueColor)
 
-	app := tui.NewApp(flagDataDir, flagDays, flagProject, flagModel, !flagNoSubagents)
+	app := tui.NewApp(flagDataDir, flagDays, flagProject, flagModel, flagSource, !flagNoSubagents).
+		WithDateRange(rangeSince, rangeUntil)
+	if flagView != "" {
+		v, err := cfg.FindView(flagView)
+		if err != nil {
+			return err
+		}
+		if app, err = app.WithView(v); err != nil {
+			return fmt.Errorf("view %s: %w", v.Name, err)
+		}
+	}
 	p := tea.NewProgram(app, tea.WithAltScreen())
 
 	if _, err := p.Run(); err != nil {
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/cli"
+	"github.com/theirongolddev/cburn/internal/config"
+	"github.com/theirongolddev/cburn/internal/usagelog"
+
+	"github.com/spf13/cobra"
+)
+
+var flagUsageClear bool
+
+var usageOfCburnCmd = &cobra.Command{
+	Use:   "usage-of-cburn",
+	Short: "Show which cburn commands and TUI tabs you use (local, opt-in)",
+	RunE:  runUsageOfCburn,
+}
+
+func init() {
+	usageOfCburnCmd.Flags().BoolVar(&flagUsageClear, "clear", false, "Delete the local usage log")
+	rootCmd.AddCommand(usageOfCburnCmd)
+}
+
+// recordCommandUsage appends the invoked command to the local usage log
+// when analytics are enabled. Failures are ignored; this must never block a command.
+func recordCommandUsage(cmd *cobra.Command, _ []string) {
+	cfg, err := config.Load()
+	if err != nil || !cfg.Analytics.Enabled {
+		return
+	}
+	name := cmd.CommandPath()
+	if !cmd.HasParent() {
+		name = "cburn summary"
+	}
+	_ = usagelog.Record(usagelog.KindCommand, name)
+}
+
+func runUsageOfCburn(_ *cobra.Command, _ []string) error {
+	if flagUsageClear {
+		if err := usagelog.Clear(); err != nil {
+			return err
+		}
+		fmt.Println("  Usage log cleared.")
+		return nil
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	events, err := usagelog.Load()
+	if err != nil {
+		return err
+	}
+
+	if !cfg.Analytics.Enabled {
+		fmt.Println()
+		fmt.Println("  Usage logging is off. To enable it, add to", config.Path()+":")
+		fmt.Println()
+		fmt.Println("    [analytics]")
+		fmt.Println("    enabled = true")
+		fmt.Println()
+		fmt.Println("  The log stays on this machine and is never transmitted.")
+		if len(events) == 0 {
+			return nil
+		}
+	}
+
+	since := time.Now().AddDate(0, 0, -flagDays)
+	counts := usagelog.Summarize(events, since)
+	if len(counts) == 0 {
+		fmt.Println("\n  No usage recorded in the selected time range.")
+		return nil
+	}
+
+	fmt.Println()
+	fmt.Println(cli.RenderTitle(fmt.Sprintf("CBURN USAGE  Last %dd", flagDays)))
+	fmt.Println()
+
+	rows := make([][]string, 0, len(counts))
+	for _, c := range counts {
+		rows = append(rows, []string{
+			c.Name,
+			c.Kind,
+			cli.FormatNumber(int64(c.Uses)),
+			c.LastUsed.Local().Format("2006-01-02 15:04"),
+		})
+	}
+
+	fmt.Print(cli.RenderTable(cli.Table{
+		Headers: []string{"Name", "Kind", "Uses", "Last Used"},
+		Rows:    rows,
+	}))
+	fmt.Printf("\n  Log: %s\n", usagelog.Path())
+
+	return nil
+}
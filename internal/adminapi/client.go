@@ -0,0 +1,307 @@
+// Package adminapi provides a client for the Anthropic Admin API's
+// organization usage reports, for admins who want team-wide numbers rather
+// than one machine's local logs.
+package adminapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	baseURL        = "https://api.anthropic.com"
+	apiVersion     = "2023-06-01"
+	requestTimeout = 30 * time.Second
+	maxBodySize    = 8 << 20 // 8 MB
+	maxPages       = 100
+	keyPrefix      = "sk-ant-admin"
+)
+
+var (
+	// ErrUnauthorized indicates the Admin API key is invalid or lacks access.
+	ErrUnauthorized = errors.New("adminapi: unauthorized (admin key invalid or revoked)")
+	// ErrRateLimited indicates the API rate limit was hit.
+	ErrRateLimited = errors.New("adminapi: rate limited")
+)
+
+// Client fetches organization usage from the Anthropic Admin API.
+type Client struct {
+	apiKey  string
+	http    *http.Client
+	baseURL string
+}
+
+// NewClient creates a client for the given Admin API key.
+// Returns nil if the key is empty or has the wrong prefix.
+func NewClient(apiKey string) *Client {
+	apiKey = strings.TrimSpace(apiKey)
+	if !strings.HasPrefix(apiKey, keyPrefix) {
+		return nil
+	}
+	return &Client{
+		apiKey:  apiKey,
+		http:    &http.Client{},
+		baseURL: baseURL,
+	}
+}
+
+// FetchClaudeCodeUsage returns the daily per-actor Claude Code records for
+// the UTC days from since up to until, following pagination.
+func (c *Client) FetchClaudeCodeUsage(ctx context.Context, since, until time.Time) ([]ClaudeCodeRecord, error) {
+	q := url.Values{}
+	q.Set("starting_at", since.UTC().Format(time.DateOnly))
+	q.Set("ending_at", until.UTC().AddDate(0, 0, 1).Format(time.DateOnly))
+	q.Set("limit", "1000")
+	return fetchPages[ClaudeCodeRecord](ctx, c, "/v1/organizations/usage_report/claude_code", q)
+}
+
+// TeamUsage sums records per actor, highest estimated cost first.
+func TeamUsage(records []ClaudeCodeRecord) []UserUsage {
+	byActor := make(map[string]*UserUsage)
+	days := make(map[string]map[string]bool)
+	for _, r := range records {
+		name := r.Actor.Name()
+		u := byActor[name]
+		if u == nil {
+			u = &UserUsage{Actor: name, IsAPIKey: r.Actor.Type == "api_actor"}
+			byActor[name] = u
+			days[name] = make(map[string]bool)
+		}
+		days[name][r.Date] = true
+		u.Sessions += r.CoreMetrics.NumSessions
+		u.LinesAdded += r.CoreMetrics.LinesOfCode.Added
+		u.LinesRemoved += r.CoreMetrics.LinesOfCode.Removed
+		u.Commits += r.CoreMetrics.Commits
+		u.PullRequests += r.CoreMetrics.PullRequests
+		for _, m := range r.ModelBreakdown {
+			u.InputTokens += m.Tokens.Input
+			u.OutputTokens += m.Tokens.Output
+			u.CacheRead += m.Tokens.CacheRead
+			u.CacheCreation += m.Tokens.CacheCreation
+			u.CostUSD += m.EstimatedCost.Amount / 100
+		}
+	}
+
+	out := make([]UserUsage, 0, len(byActor))
+	for name, u := range byActor {
+		u.ActiveDays = len(days[name])
+		out = append(out, *u)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].CostUSD != out[j].CostUSD {
+			return out[i].CostUSD > out[j].CostUSD
+		}
+		return out[i].Actor < out[j].Actor
+	})
+	return out
+}
+
+// FetchMessagesUsage returns daily Messages API usage from since to until,
+// grouped by API key, workspace, and model.
+func (c *Client) FetchMessagesUsage(ctx context.Context, since, until time.Time) ([]UsageBucket, error) {
+	q := url.Values{}
+	q.Set("starting_at", since.UTC().Format(time.RFC3339))
+	q.Set("ending_at", until.UTC().Format(time.RFC3339))
+	q.Set("bucket_width", "1d")
+	q.Set("limit", "31")
+	for _, g := range []string{"api_key_id", "workspace_id", "model"} {
+		q.Add("group_by[]", g)
+	}
+	return fetchPages[UsageBucket](ctx, c, "/v1/organizations/usage_report/messages", q)
+}
+
+// FetchAPIKeys returns every API key in the organization.
+func (c *Client) FetchAPIKeys(ctx context.Context) ([]APIKey, error) {
+	return fetchList[APIKey](ctx, c, "/v1/organizations/api_keys")
+}
+
+// FetchWorkspaces returns every workspace in the organization.
+func (c *Client) FetchWorkspaces(ctx context.Context) ([]Workspace, error) {
+	return fetchList[Workspace](ctx, c, "/v1/organizations/workspaces")
+}
+
+// CostFunc prices tokens for a model; see config.CalculateCost.
+type CostFunc func(model string, input, output, cache5m, cache1h, cacheRead int64) float64
+
+// KeyBreakdown sums usage per API key, naming keys and workspaces from
+// keys and workspaces, highest estimated cost first. With byWorkspace,
+// usage is summed per workspace instead and Key is left empty.
+func KeyBreakdown(buckets []UsageBucket, keys []APIKey, workspaces []Workspace, cost CostFunc, byWorkspace bool) []KeyUsage {
+	keyNames := make(map[string]string, len(keys))
+	for _, k := range keys {
+		keyNames[k.ID] = k.Name
+	}
+	wsNames := make(map[string]string, len(workspaces))
+	for _, w := range workspaces {
+		wsNames[w.ID] = w.Name
+	}
+
+	type group struct {
+		usage *KeyUsage
+		days  map[string]bool
+	}
+	groups := make(map[string]*group)
+	for _, b := range buckets {
+		for _, r := range b.Results {
+			keyID, wsID, model := deref(r.APIKeyID), deref(r.WorkspaceID), deref(r.Model)
+			id := keyID
+			if byWorkspace {
+				id = wsID
+			}
+			g := groups[id]
+			if g == nil {
+				u := &KeyUsage{WorkspaceID: wsID, Workspace: workspaceName(wsNames, wsID)}
+				if !byWorkspace {
+					u.KeyID, u.Key = keyID, keyName(keyNames, keyID)
+				}
+				g = &group{usage: u, days: make(map[string]bool)}
+				groups[id] = g
+			}
+			u := g.usage
+			u.InputTokens += r.UncachedInputTokens
+			u.OutputTokens += r.OutputTokens
+			u.Cache5m += r.CacheCreation.Ephemeral5m
+			u.Cache1h += r.CacheCreation.Ephemeral1h
+			u.CacheRead += r.CacheReadInputTokens
+			if cost != nil {
+				u.EstimatedCost += cost(model, r.UncachedInputTokens, r.OutputTokens,
+					r.CacheCreation.Ephemeral5m, r.CacheCreation.Ephemeral1h, r.CacheReadInputTokens)
+			}
+			g.days[b.StartingAt] = true
+		}
+	}
+
+	out := make([]KeyUsage, 0, len(groups))
+	for _, g := range groups {
+		g.usage.ActiveDays = len(g.days)
+		out = append(out, *g.usage)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].EstimatedCost != out[j].EstimatedCost {
+			return out[i].EstimatedCost > out[j].EstimatedCost
+		}
+		if out[i].Workspace != out[j].Workspace {
+			return out[i].Workspace < out[j].Workspace
+		}
+		return out[i].Key < out[j].Key
+	})
+	return out
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func keyName(names map[string]string, id string) string {
+	switch {
+	case id == "":
+		return "(no key)"
+	case names[id] != "":
+		return names[id]
+	}
+	return id
+}
+
+func workspaceName(names map[string]string, id string) string {
+	switch {
+	case id == "":
+		return "Default"
+	case names[id] != "":
+		return names[id]
+	}
+	return id
+}
+
+// fetchList GETs every page of an object list endpoint.
+func fetchList[T any](ctx context.Context, c *Client, path string) ([]T, error) {
+	q := url.Values{}
+	q.Set("limit", "100")
+	var all []T
+	for range maxPages {
+		body, err := c.get(ctx, path+"?"+q.Encode())
+		if err != nil {
+			return nil, err
+		}
+		var l list[T]
+		if err := json.Unmarshal(body, &l); err != nil {
+			return nil, fmt.Errorf("adminapi: parsing %s: %w", path, err)
+		}
+		all = append(all, l.Data...)
+		if !l.HasMore || l.LastID == "" {
+			return all, nil
+		}
+		q.Set("after_id", l.LastID)
+	}
+	return all, nil
+}
+
+// fetchPages GETs path with query q and every following page, returning
+// the concatenated data.
+func fetchPages[T any](ctx context.Context, c *Client, path string, q url.Values) ([]T, error) {
+	var all []T
+	for range maxPages {
+		body, err := c.get(ctx, path+"?"+q.Encode())
+		if err != nil {
+			return nil, err
+		}
+		var p page[T]
+		if err := json.Unmarshal(body, &p); err != nil {
+			return nil, fmt.Errorf("adminapi: parsing %s: %w", path, err)
+		}
+		all = append(all, p.Data...)
+		if !p.HasMore || p.NextPage == "" {
+			return all, nil
+		}
+		q.Set("page", p.NextPage)
+	}
+	return all, nil
+}
+
+// get performs an authenticated GET request and returns the response body.
+func (c *Client) get(ctx context.Context, path string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("adminapi: creating request: %w", err)
+	}
+
+	req.Header.Set("x-api-key", c.apiKey)
+	req.Header.Set("anthropic-version", apiVersion)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "github.com/theirongolddev/cburn/1.0")
+
+	//nolint:gosec // URL is built from the Anthropic API base URL
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("adminapi: request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return nil, ErrUnauthorized
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return nil, ErrRateLimited
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return nil, fmt.Errorf("adminapi: unexpected status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		return nil, fmt.Errorf("adminapi: reading response: %w", err)
+	}
+	return body, nil
+}
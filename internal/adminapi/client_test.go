@@ -0,0 +1,147 @@
+package adminapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestFetchClaudeCodeUsage_PaginatesAndRanks(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("x-api-key") != "sk-ant-admin01-test" || r.Header.Get("anthropic-version") == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/v1/organizations/usage_report/claude_code" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.URL.Query().Get("page") {
+		case "":
+			if got := r.URL.Query().Get("starting_at"); got != "2026-03-01" {
+				t.Errorf("starting_at = %q", got)
+			}
+			_, _ = w.Write([]byte(`{"data":[
+				{"date":"2026-03-01","actor":{"type":"user_actor","email_address":"ana@example.com"},
+				 "core_metrics":{"num_sessions":2,"lines_of_code":{"added":40,"removed":5},"commits_by_claude_code":1},
+				 "model_breakdown":[{"model":"claude-sonnet-4","tokens":{"input":100,"output":50},"estimated_cost":{"currency":"USD","amount":150}}]},
+				{"date":"2026-03-01","actor":{"type":"api_actor","api_key_name":"ci-bot"},
+				 "core_metrics":{"num_sessions":9},
+				 "model_breakdown":[{"model":"claude-opus-4","tokens":{"input":10,"output":5},"estimated_cost":{"currency":"USD","amount":900}}]}
+			],"has_more":true,"next_page":"p2"}`))
+		case "p2":
+			_, _ = w.Write([]byte(`{"data":[
+				{"date":"2026-03-02","actor":{"type":"user_actor","email_address":"ana@example.com"},
+				 "core_metrics":{"num_sessions":1},
+				 "model_breakdown":[{"model":"claude-sonnet-4","tokens":{"input":20,"cache_read":80},"estimated_cost":{"currency":"USD","amount":50}}]}
+			],"has_more":false}`))
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient("sk-ant-admin01-test")
+	c.http = srv.Client()
+	c.baseURL = srv.URL
+
+	since := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	records, err := c.FetchClaudeCodeUsage(context.Background(), since, since.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("got %d records, want 3", len(records))
+	}
+
+	users := TeamUsage(records)
+	if len(users) != 2 || users[0].Actor != "ci-bot" || !users[0].IsAPIKey {
+		t.Fatalf("ranking = %+v, want ci-bot first", users)
+	}
+	ana := users[1]
+	if ana.Sessions != 3 || ana.ActiveDays != 2 || ana.CostUSD != 2 || ana.TotalTokens() != 250 || ana.LinesAdded != 40 {
+		t.Errorf("ana = %+v", ana)
+	}
+}
+
+func TestNewClientRejectsNonAdminKeys(t *testing.T) {
+	for _, key := range []string{"", "sk-ant-api03-x", "sk-ant-sid01-x"} {
+		if NewClient(key) != nil {
+			t.Errorf("NewClient(%q) != nil", key)
+		}
+	}
+}
+
+func TestKeyBreakdown(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v1/organizations/usage_report/messages":
+			if g := r.URL.Query()["group_by[]"]; len(g) != 3 {
+				t.Errorf("group_by = %v", g)
+			}
+			_, _ = w.Write([]byte(`{"data":[
+				{"starting_at":"2026-03-01T00:00:00Z","results":[
+					{"uncached_input_tokens":1000,"output_tokens":100,"api_key_id":"k1","workspace_id":"w1","model":"claude-sonnet-4"},
+					{"uncached_input_tokens":10,"output_tokens":1,"api_key_id":null,"workspace_id":null,"model":"claude-sonnet-4"}]},
+				{"starting_at":"2026-03-02T00:00:00Z","results":[
+					{"uncached_input_tokens":500,"cache_read_input_tokens":200,"api_key_id":"k1","workspace_id":"w1","model":"claude-sonnet-4"},
+					{"uncached_input_tokens":3000,"output_tokens":300,"api_key_id":"k2","workspace_id":"w1","model":"claude-sonnet-4"}]}
+			],"has_more":false}`))
+		case r.URL.Path == "/v1/organizations/api_keys" && r.URL.Query().Get("after_id") == "":
+			_, _ = w.Write([]byte(`{"data":[{"id":"k1","name":"billing-service"}],"has_more":true,"last_id":"k1"}`))
+		case r.URL.Path == "/v1/organizations/api_keys":
+			_, _ = w.Write([]byte(`{"data":[{"id":"k2","name":"search-indexer"}],"has_more":false}`))
+		case r.URL.Path == "/v1/organizations/workspaces":
+			_, _ = w.Write([]byte(`{"data":[{"id":"w1","name":"Production"}],"has_more":false}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient("sk-ant-admin01-test")
+	c.http = srv.Client()
+	c.baseURL = srv.URL
+	ctx := context.Background()
+
+	since := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	buckets, err := c.FetchMessagesUsage(ctx, since, since.AddDate(0, 0, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys, err := c.FetchAPIKeys(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	workspaces, err := c.FetchWorkspaces(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 2 {
+		t.Fatalf("got %d keys, want 2 across pages", len(keys))
+	}
+
+	// One dollar per thousand tokens keeps the ranking easy to follow.
+	cost := func(_ string, in, out, c5m, c1h, read int64) float64 {
+		return float64(in+out+c5m+c1h+read) / 1000
+	}
+
+	byKey := KeyBreakdown(buckets, keys, workspaces, cost, false)
+	if len(byKey) != 3 {
+		t.Fatalf("got %d key rows, want 3: %+v", len(byKey), byKey)
+	}
+	if byKey[0].Key != "search-indexer" || byKey[1].Key != "billing-service" || byKey[2].Key != "(no key)" {
+		t.Errorf("ranking = %q, %q, %q", byKey[0].Key, byKey[1].Key, byKey[2].Key)
+	}
+	if k := byKey[1]; k.ActiveDays != 2 || k.TotalTokens() != 1800 || k.Workspace != "Production" {
+		t.Errorf("billing-service = %+v", k)
+	}
+	if byKey[2].Workspace != "Default" {
+		t.Errorf("keyless usage workspace = %q, want Default", byKey[2].Workspace)
+	}
+
+	byWS := KeyBreakdown(buckets, keys, workspaces, cost, true)
+	if len(byWS) != 2 || byWS[0].Workspace != "Production" || byWS[0].TotalTokens() != 5100 || byWS[0].Key != "" {
+		t.Errorf("by workspace = %+v", byWS)
+	}
+}
@@ -0,0 +1,147 @@
+package adminapi
+
+// page is the envelope shared by the paginated Admin API report endpoints.
+type page[T any] struct {
+	Data     []T    `json:"data"`
+	HasMore  bool   `json:"has_more"`
+	NextPage string `json:"next_page"`
+}
+
+// ClaudeCodeRecord is one actor's Claude Code activity for one day, as
+// returned by the Claude Code usage report.
+type ClaudeCodeRecord struct {
+	Date           string          `json:"date"`
+	Actor          Actor           `json:"actor"`
+	CustomerType   string          `json:"customer_type"`
+	TerminalType   string          `json:"terminal_type"`
+	CoreMetrics    CoreMetrics     `json:"core_metrics"`
+	ModelBreakdown []ModelActivity `json:"model_breakdown"`
+}
+
+// Actor identifies who generated usage: a user by email, or an API key by
+// name.
+type Actor struct {
+	Type         string `json:"type"` // "user_actor" or "api_actor"
+	EmailAddress string `json:"email_address,omitempty"`
+	APIKeyName   string `json:"api_key_name,omitempty"`
+}
+
+// Name returns the actor's email or API key name.
+func (a Actor) Name() string {
+	if a.EmailAddress != "" {
+		return a.EmailAddress
+	}
+	return a.APIKeyName
+}
+
+// CoreMetrics are the per-day productivity counters of a Claude Code record.
+type CoreMetrics struct {
+	NumSessions int `json:"num_sessions"`
+	LinesOfCode struct {
+		Added   int `json:"added"`
+		Removed int `json:"removed"`
+	} `json:"lines_of_code"`
+	Commits      int `json:"commits_by_claude_code"`
+	PullRequests int `json:"pull_requests_by_claude_code"`
+}
+
+// ModelActivity is one model's tokens and estimated cost within a record.
+type ModelActivity struct {
+	Model  string `json:"model"`
+	Tokens struct {
+		Input         int64 `json:"input"`
+		Output        int64 `json:"output"`
+		CacheRead     int64 `json:"cache_read"`
+		CacheCreation int64 `json:"cache_creation"`
+	} `json:"tokens"`
+	EstimatedCost struct {
+		Currency string  `json:"currency"`
+		Amount   float64 `json:"amount"` // cents
+	} `json:"estimated_cost"`
+}
+
+// UserUsage is one actor's Claude Code usage summed over a report window.
+type UserUsage struct {
+	Actor         string // email, or API key name for api_actor records
+	IsAPIKey      bool
+	ActiveDays    int
+	Sessions      int
+	InputTokens   int64
+	OutputTokens  int64
+	CacheRead     int64
+	CacheCreation int64
+	CostUSD       float64
+	LinesAdded    int
+	LinesRemoved  int
+	Commits       int
+	PullRequests  int
+}
+
+// TotalTokens returns all tokens the actor sent and received.
+func (u UserUsage) TotalTokens() int64 {
+	return u.InputTokens + u.OutputTokens + u.CacheRead + u.CacheCreation
+}
+
+// list is the envelope of the Admin API's object list endpoints.
+type list[T any] struct {
+	Data    []T    `json:"data"`
+	HasMore bool   `json:"has_more"`
+	LastID  string `json:"last_id"`
+}
+
+// UsageBucket is one time bucket of the Messages usage report.
+type UsageBucket struct {
+	StartingAt string        `json:"starting_at"`
+	EndingAt   string        `json:"ending_at"`
+	Results    []UsageResult `json:"results"`
+}
+
+// UsageResult is one group's token counts within a usage bucket. The group
+// fields are nil when usage has no key (e.g. the Console workbench) or
+// belongs to the default workspace.
+type UsageResult struct {
+	UncachedInputTokens int64 `json:"uncached_input_tokens"`
+	CacheCreation       struct {
+		Ephemeral1h int64 `json:"ephemeral_1h_input_tokens"`
+		Ephemeral5m int64 `json:"ephemeral_5m_input_tokens"`
+	} `json:"cache_creation"`
+	CacheReadInputTokens int64   `json:"cache_read_input_tokens"`
+	OutputTokens         int64   `json:"output_tokens"`
+	APIKeyID             *string `json:"api_key_id"`
+	WorkspaceID          *string `json:"workspace_id"`
+	Model                *string `json:"model"`
+}
+
+// APIKey is an organization API key.
+type APIKey struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	Status      string  `json:"status"`
+	WorkspaceID *string `json:"workspace_id"`
+}
+
+// Workspace is an organization workspace.
+type Workspace struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// KeyUsage is usage summed per API key (or per workspace, with Key empty).
+type KeyUsage struct {
+	KeyID         string // "" for usage without a key
+	Key           string // key name, or KeyID when the key is unknown
+	WorkspaceID   string // "" for the default workspace
+	Workspace     string
+	ActiveDays    int // days with any usage
+	InputTokens   int64
+	OutputTokens  int64
+	Cache5m       int64
+	Cache1h       int64
+	CacheRead     int64
+	EstimatedCost float64
+}
+
+// TotalTokens returns all tokens sent and received.
+func (k KeyUsage) TotalTokens() int64 {
+	return k.InputTokens + k.OutputTokens + k.Cache5m + k.Cache1h + k.CacheRead
+}
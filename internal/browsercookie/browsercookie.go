@@ -0,0 +1,265 @@
+// Package browsercookie reads the claude.ai sessionKey cookie from local
+// browser cookie stores, so the session key can be refreshed without
+// copying it out of DevTools.
+package browsercookie
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite" // register sqlite driver
+)
+
+const (
+	cookieName = "sessionKey"
+	cookieHost = "claude.ai"
+	keyPrefix  = "sk-ant-sid"
+)
+
+// ErrNotFound indicates the store has no claude.ai session cookie.
+var ErrNotFound = errors.New("no claude.ai session cookie")
+
+// Store is one browser profile's cookie database.
+type Store struct {
+	Browser string // e.g. "Chrome", "Firefox"
+	Profile string // profile directory name
+	Path    string // cookie database file
+
+	kind    storeKind
+	keyring string // OS keyring entry holding the Chromium cookie password
+}
+
+type storeKind int
+
+const (
+	kindChromium storeKind = iota
+	kindFirefox
+)
+
+// Cookie is a claude.ai session cookie read from a browser.
+type Cookie struct {
+	Value   string
+	Expires time.Time // zero for session cookies
+	Store   Store
+}
+
+// chromium describes a Chromium-based browser's data directory (relative to
+// the OS config dir) and keyring entry.
+type chromium struct {
+	name    string
+	linux   string
+	darwin  string
+	keyring string // macOS Keychain service / Linux secret-tool application
+}
+
+var chromiumBrowsers = []chromium{
+	{"Chrome", "google-chrome", "Google/Chrome", "Chrome"},
+	{"Chromium", "chromium", "Chromium", "Chromium"},
+	{"Brave", "BraveSoftware/Brave-Browser", "BraveSoftware/Brave-Browser", "Brave"},
+	{"Edge", "microsoft-edge", "Microsoft Edge", "Microsoft Edge"},
+}
+
+// Discover returns the cookie stores found for the current user, Chromium
+// browsers first. Browsers whose cookies cannot be decrypted on this OS
+// (Chromium on Windows) are not returned.
+func Discover() []Store {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+
+	var stores []Store
+	for _, b := range chromiumBrowsers {
+		var base string
+		switch runtime.GOOS {
+		case "linux":
+			base = filepath.Join(home, ".config", b.linux)
+		case "darwin":
+			base = filepath.Join(home, "Library", "Application Support", b.darwin)
+		default:
+			continue
+		}
+		for _, pattern := range []string{"*/Cookies", "*/Network/Cookies"} {
+			matches, _ := filepath.Glob(filepath.Join(base, pattern))
+			for _, m := range matches {
+				rel, _ := filepath.Rel(base, m)
+				stores = append(stores, Store{
+					Browser: b.name,
+					Profile: strings.Split(filepath.ToSlash(rel), "/")[0],
+					Path:    m,
+					kind:    kindChromium,
+					keyring: b.keyring,
+				})
+			}
+		}
+	}
+
+	var firefoxDirs []string
+	switch runtime.GOOS {
+	case "linux":
+		firefoxDirs = []string{
+			filepath.Join(home, ".mozilla", "firefox"),
+			filepath.Join(home, "snap", "firefox", "common", ".mozilla", "firefox"),
+		}
+	case "darwin":
+		firefoxDirs = []string{filepath.Join(home, "Library", "Application Support", "Firefox", "Profiles")}
+	case "windows":
+		firefoxDirs = []string{filepath.Join(os.Getenv("APPDATA"), "Mozilla", "Firefox", "Profiles")}
+	}
+	for _, dir := range firefoxDirs {
+		matches, _ := filepath.Glob(filepath.Join(dir, "*", "cookies.sqlite"))
+		sort.Strings(matches)
+		for _, m := range matches {
+			stores = append(stores, Store{
+				Browser: "Firefox",
+				Profile: filepath.Base(filepath.Dir(m)),
+				Path:    m,
+				kind:    kindFirefox,
+			})
+		}
+	}
+	return stores
+}
+
+// Read returns the claude.ai session cookie from the store, or ErrNotFound.
+// Chromium stores may prompt for OS keyring access on first use.
+func (s Store) Read() (Cookie, error) {
+	var c Cookie
+	var err error
+	switch s.kind {
+	case kindFirefox:
+		c, err = readFirefox(s.Path)
+	default:
+		c, err = readChromium(s.Path, chromiumPasswords(s.keyring))
+	}
+	c.Store = s
+	return c, err
+}
+
+// Expired reports whether the cookie's expiry has passed.
+func (c Cookie) Expired(now time.Time) bool {
+	return !c.Expires.IsZero() && !now.Before(c.Expires)
+}
+
+func readFirefox(path string) (Cookie, error) {
+	db, cleanup, err := openCopy(path)
+	if err != nil {
+		return Cookie{}, err
+	}
+	defer cleanup()
+
+	var value string
+	var expiry int64
+	err = db.QueryRow(`SELECT value, expiry FROM moz_cookies
+		WHERE name = ? AND (host = ? OR host LIKE ?)
+		ORDER BY expiry DESC LIMIT 1`, cookieName, cookieHost, "%."+cookieHost).Scan(&value, &expiry)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Cookie{}, ErrNotFound
+	}
+	if err != nil {
+		return Cookie{}, fmt.Errorf("reading firefox cookies: %w", err)
+	}
+
+	c := Cookie{Value: value}
+	if expiry > 0 {
+		// Older Firefox stores seconds, newer milliseconds.
+		if expiry > 1e11 {
+			c.Expires = time.UnixMilli(expiry)
+		} else {
+			c.Expires = time.Unix(expiry, 0)
+		}
+	}
+	return c, nil
+}
+
+// chromeEpochOffset is the number of seconds from Chromium's expires_utc
+// origin (1601-01-01, counted in microseconds) to the Unix epoch.
+const chromeEpochOffset = 11644473600
+
+func readChromium(path string, passwords func() [][]byte) (Cookie, error) {
+	db, cleanup, err := openCopy(path)
+	if err != nil {
+		return Cookie{}, err
+	}
+	defer cleanup()
+
+	// Since schema version 24 the plaintext is prefixed with a SHA-256 of
+	// the host.
+	var version int
+	_ = db.QueryRow(`SELECT value FROM meta WHERE key = 'version'`).Scan(&version)
+
+	var value string
+	var encrypted []byte
+	var expires int64
+	err = db.QueryRow(`SELECT value, encrypted_value, expires_utc FROM cookies
+		WHERE name = ? AND (host_key = ? OR host_key LIKE ?)
+		ORDER BY expires_utc DESC LIMIT 1`, cookieName, cookieHost, "%."+cookieHost).Scan(&value, &encrypted, &expires)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Cookie{}, ErrNotFound
+	}
+	if err != nil {
+		return Cookie{}, fmt.Errorf("reading chromium cookies: %w", err)
+	}
+
+	if value == "" && len(encrypted) > 0 {
+		value, err = decryptChromium(encrypted, version >= 24, passwords)
+		if err != nil {
+			return Cookie{}, err
+		}
+	}
+
+	c := Cookie{Value: value}
+	if expires > 0 {
+		c.Expires = time.UnixMicro(expires - chromeEpochOffset*1e6)
+	}
+	return c, nil
+}
+
+// openCopy opens a snapshot of a cookie database. Browsers keep their
+// database locked while running, so it (and its WAL) is copied first.
+func openCopy(path string) (*sql.DB, func(), error) {
+	dir, err := os.MkdirTemp("", "cburn-cookies-")
+	if err != nil {
+		return nil, nil, err
+	}
+	cleanup := func() { _ = os.RemoveAll(dir) }
+
+	dst := filepath.Join(dir, "cookies.db")
+	if err := copyFile(path, dst); err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	_ = copyFile(path+"-wal", dst+"-wal") // absent unless the browser is running
+
+	db, err := sql.Open("sqlite", dst)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	return db, func() { _ = db.Close(); cleanup() }, nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src) //nolint:gosec // cookie store path from Discover or the user
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600) //nolint:gosec // path inside our temp dir
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
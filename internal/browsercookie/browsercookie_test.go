@@ -0,0 +1,106 @@
+package browsercookie
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/sha1" //nolint:gosec // matches Chromium's key derivation
+	"crypto/sha256"
+	"database/sql"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+const testKey = "sk-ant-sid01-test"
+
+func encryptV10(t *testing.T, plain []byte) []byte {
+	t.Helper()
+	iterations := 1
+	if runtime.GOOS == "darwin" {
+		iterations = 1003
+	}
+	key, err := pbkdf2.Key(sha1.New, "peanuts", []byte("saltysalt"), iterations, 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pad := aes.BlockSize - len(plain)%aes.BlockSize
+	plain = append(plain, bytes.Repeat([]byte{byte(pad)}, pad)...)
+	out := make([]byte, len(plain))
+	cipher.NewCBCEncrypter(block, bytes.Repeat([]byte(" "), aes.BlockSize)).CryptBlocks(out, plain)
+	return append([]byte("v10"), out...)
+}
+
+func createDB(t *testing.T, stmts ...string) (string, *sql.DB) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "cookies.db")
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	for _, s := range stmts {
+		if _, err := db.Exec(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return path, db
+}
+
+func TestReadChromium(t *testing.T) {
+	expires := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	expiresUTC := expires.UnixMicro() + chromeEpochOffset*1e6
+	hostHash := sha256.Sum256([]byte(cookieHost))
+
+	path, db := createDB(t,
+		`CREATE TABLE meta (key TEXT, value TEXT)`,
+		`INSERT INTO meta VALUES ('version', '24')`,
+		`CREATE TABLE cookies (host_key TEXT, name TEXT, value TEXT, encrypted_value BLOB, expires_utc INTEGER)`,
+	)
+	if _, err := db.Exec(`INSERT INTO cookies VALUES ('.claude.ai', 'sessionKey', '', ?, ?)`,
+		encryptV10(t, append(hostHash[:], testKey...)), expiresUTC); err != nil {
+		t.Fatal(err)
+	}
+
+	peanuts := func() [][]byte { return [][]byte{[]byte("wrong"), []byte("peanuts")} }
+	c, err := readChromium(path, peanuts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Value != testKey || !c.Expires.Equal(expires) {
+		t.Errorf("got %q expiring %v, want %q expiring %v", c.Value, c.Expires, testKey, expires)
+	}
+
+	if _, err := readChromium(path, func() [][]byte { return nil }); err == nil {
+		t.Error("no usable password should fail")
+	}
+}
+
+func TestReadFirefox(t *testing.T) {
+	path, _ := createDB(t,
+		`CREATE TABLE moz_cookies (host TEXT, name TEXT, value TEXT, expiry INTEGER)`,
+		`INSERT INTO moz_cookies VALUES ('example.com', 'sessionKey', 'nope', 1)`,
+		`INSERT INTO moz_cookies VALUES ('claude.ai', 'sessionKey', 'sk-ant-sid01-test', 1790000000)`,
+	)
+	c, err := readFirefox(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Value != testKey || c.Expires.Unix() != 1790000000 {
+		t.Errorf("got %q expiring %v", c.Value, c.Expires)
+	}
+	if c.Expired(time.Unix(1790000000, 0)) != true || c.Expired(time.Unix(1700000000, 0)) {
+		t.Error("Expired disagrees with the cookie expiry")
+	}
+
+	empty, _ := createDB(t, `CREATE TABLE moz_cookies (host TEXT, name TEXT, value TEXT, expiry INTEGER)`)
+	if _, err := readFirefox(empty); err != ErrNotFound {
+		t.Errorf("err = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,294 @@
+// Package bundle reads and writes portable cburn data bundles.
+//
+// A bundle is a gzipped tar holding manifest.json and sessions.jsonl: one
+// line per session with token counts, costs, and per-model usage. Bundles
+// never contain prompt or response content, or raw JSONL, so they are safe to
+// hand to a team lead who merges them with `cburn bundle import`.
+package bundle
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+const (
+	// Format identifies cburn bundles in the manifest.
+	Format = "cburn-bundle"
+	// Version is the current bundle format version.
+	Version = 1
+
+	manifestName = "manifest.json"
+	sessionsName = "sessions.jsonl"
+)
+
+// Manifest describes a bundle's origin and contents.
+type Manifest struct {
+	Format    string    `json:"format"`
+	Version   int       `json:"version"`
+	Label     string    `json:"label"`
+	Author    string    `json:"author,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	Sessions  int       `json:"sessions"`
+}
+
+// Session is the bundle representation of model.SessionStats.
+// Local file paths are deliberately omitted.
+type Session struct {
+	SessionID     string                 `json:"session_id"`
+	Project       string                 `json:"project"`
+	ProjectPath   string                 `json:"project_path,omitempty"`
+	Repo          string                 `json:"repo,omitempty"`
+	GitBranch     string                 `json:"git_branch,omitempty"`
+	Entrypoint    string                 `json:"entrypoint,omitempty"`
+	IsSubagent    bool                   `json:"is_subagent,omitempty"`
+	ParentSession string                 `json:"parent_session,omitempty"`
+	StartTime     time.Time              `json:"start_time"`
+	EndTime       time.Time              `json:"end_time"`
+	DurationSecs  int64                  `json:"duration_secs"`
+	UserMessages  int                    `json:"user_messages"`
+	APICalls      int                    `json:"api_calls"`
+	InputTokens   int64                  `json:"input_tokens"`
+	OutputTokens  int64                  `json:"output_tokens"`
+	CacheWrite5m  int64                  `json:"cache_write_5m_tokens"`
+	CacheWrite1h  int64                  `json:"cache_write_1h_tokens"`
+	CacheRead     int64                  `json:"cache_read_tokens"`
+	EstimatedCost float64                `json:"cost_usd"`
+	CacheHitRate  float64                `json:"cache_hit_rate"`
+	Models        map[string]ModelTokens `json:"models,omitempty"`
+	Note          string                 `json:"note,omitempty"`
+	Tags          []string               `json:"tags,omitempty"`
+}
+
+// ModelTokens is per-model usage within a bundled session.
+type ModelTokens struct {
+	APICalls      int     `json:"api_calls"`
+	InputTokens   int64   `json:"input_tokens"`
+	OutputTokens  int64   `json:"output_tokens"`
+	CacheWrite5m  int64   `json:"cache_write_5m_tokens"`
+	CacheWrite1h  int64   `json:"cache_write_1h_tokens"`
+	CacheRead     int64   `json:"cache_read_tokens"`
+	EstimatedCost float64 `json:"cost_usd"`
+}
+
+// Write encodes sessions as a bundle. m.Format, m.Version, and m.Sessions
+// are filled in automatically.
+func Write(w io.Writer, m Manifest, sessions []model.SessionStats) error {
+	m.Format = Format
+	m.Version = Version
+	m.Sessions = len(sessions)
+
+	manifest, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	var body []byte
+	for _, s := range sessions {
+		line, err := json.Marshal(fromStats(s))
+		if err != nil {
+			return err
+		}
+		body = append(body, line...)
+		body = append(body, '\n')
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	for _, f := range []struct {
+		name string
+		data []byte
+	}{{manifestName, manifest}, {sessionsName, body}} {
+		hdr := &tar.Header{
+			Name:    f.name,
+			Mode:    0o600,
+			Size:    int64(len(f.data)),
+			ModTime: m.CreatedAt,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := tw.Write(f.data); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// WriteFile writes a bundle to path.
+func WriteFile(path string, m Manifest, sessions []model.SessionStats) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600) //nolint:gosec // user-chosen output path
+	if err != nil {
+		return err
+	}
+	if err := Write(f, m, sessions); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// ErrNotBundle is returned by Read when the archive has no cburn manifest.
+var ErrNotBundle = errors.New("not a cburn bundle")
+
+// Read decodes a bundle file into its manifest and sessions.
+func Read(path string) (Manifest, []model.SessionStats, error) {
+	var m Manifest
+
+	f, err := os.Open(path) //nolint:gosec // user-supplied bundle path
+	if err != nil {
+		return m, nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return m, nil, ErrNotBundle
+	}
+	defer func() { _ = gz.Close() }()
+
+	var sessions []model.SessionStats
+	haveManifest := false
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return m, nil, fmt.Errorf("reading bundle: %w", err)
+		}
+		switch hdr.Name {
+		case manifestName:
+			if err := json.NewDecoder(tr).Decode(&m); err != nil {
+				return m, nil, fmt.Errorf("reading manifest: %w", err)
+			}
+			if m.Format != Format {
+				return m, nil, ErrNotBundle
+			}
+			if m.Version > Version {
+				return m, nil, fmt.Errorf("bundle version %d is newer than supported (%d); upgrade cburn", m.Version, Version)
+			}
+			haveManifest = true
+		case sessionsName:
+			sessions, err = readSessions(tr)
+			if err != nil {
+				return m, nil, err
+			}
+		}
+	}
+	if !haveManifest {
+		return m, nil, ErrNotBundle
+	}
+	return m, sessions, nil
+}
+
+func readSessions(r io.Reader) ([]model.SessionStats, error) {
+	var out []model.SessionStats
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for sc.Scan() {
+		if len(sc.Bytes()) == 0 {
+			continue
+		}
+		var s Session
+		if err := json.Unmarshal(sc.Bytes(), &s); err != nil {
+			return nil, fmt.Errorf("reading sessions: %w", err)
+		}
+		out = append(out, s.toStats())
+	}
+	return out, sc.Err()
+}
+
+func fromStats(s model.SessionStats) Session {
+	b := Session{
+		SessionID:     s.SessionID,
+		Project:       s.Project,
+		ProjectPath:   s.ProjectPath,
+		Repo:          s.Repo,
+		GitBranch:     s.GitBranch,
+		Entrypoint:    s.Entrypoint,
+		IsSubagent:    s.IsSubagent,
+		ParentSession: s.ParentSession,
+		StartTime:     s.StartTime,
+		EndTime:       s.EndTime,
+		DurationSecs:  s.DurationSecs,
+		UserMessages:  s.UserMessages,
+		APICalls:      s.APICalls,
+		InputTokens:   s.InputTokens,
+		OutputTokens:  s.OutputTokens,
+		CacheWrite5m:  s.CacheCreation5mTokens,
+		CacheWrite1h:  s.CacheCreation1hTokens,
+		CacheRead:     s.CacheReadTokens,
+		EstimatedCost: s.EstimatedCost,
+		CacheHitRate:  s.CacheHitRate,
+		Note:          s.Note,
+		Tags:          s.UserTags,
+	}
+	if len(s.Models) > 0 {
+		b.Models = make(map[string]ModelTokens, len(s.Models))
+		for name, mu := range s.Models {
+			b.Models[name] = ModelTokens{
+				APICalls:      mu.APICalls,
+				InputTokens:   mu.InputTokens,
+				OutputTokens:  mu.OutputTokens,
+				CacheWrite5m:  mu.CacheCreation5mTokens,
+				CacheWrite1h:  mu.CacheCreation1hTokens,
+				CacheRead:     mu.CacheReadTokens,
+				EstimatedCost: mu.EstimatedCost,
+			}
+		}
+	}
+	return b
+}
+
+func (b Session) toStats() model.SessionStats {
+	s := model.SessionStats{
+		SessionID:             b.SessionID,
+		Project:               b.Project,
+		ProjectPath:           b.ProjectPath,
+		Repo:                  b.Repo,
+		GitBranch:             b.GitBranch,
+		Entrypoint:            b.Entrypoint,
+		IsSubagent:            b.IsSubagent,
+		ParentSession:         b.ParentSession,
+		StartTime:             b.StartTime,
+		EndTime:               b.EndTime,
+		DurationSecs:          b.DurationSecs,
+		UserMessages:          b.UserMessages,
+		APICalls:              b.APICalls,
+		InputTokens:           b.InputTokens,
+		OutputTokens:          b.OutputTokens,
+		CacheCreation5mTokens: b.CacheWrite5m,
+		CacheCreation1hTokens: b.CacheWrite1h,
+		CacheReadTokens:       b.CacheRead,
+		EstimatedCost:         b.EstimatedCost,
+		CacheHitRate:          b.CacheHitRate,
+		Note:                  b.Note,
+		UserTags:              b.Tags,
+		Models:                make(map[string]*model.ModelUsage, len(b.Models)),
+	}
+	for name, mt := range b.Models {
+		s.Models[name] = &model.ModelUsage{
+			APICalls:              mt.APICalls,
+			InputTokens:           mt.InputTokens,
+			OutputTokens:          mt.OutputTokens,
+			CacheCreation5mTokens: mt.CacheWrite5m,
+			CacheCreation1hTokens: mt.CacheWrite1h,
+			CacheReadTokens:       mt.CacheRead,
+			EstimatedCost:         mt.EstimatedCost,
+		}
+	}
+	return s
+}
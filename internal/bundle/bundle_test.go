@@ -0,0 +1,63 @@
+package bundle
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+func TestWriteRead_RoundTrip(t *testing.T) {
+	start := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	sessions := []model.SessionStats{{
+		SessionID:             "s1",
+		Project:               "api",
+		ProjectPath:           "/work/api",
+		FilePath:              "/home/me/.claude/projects/-work-api/s1.jsonl",
+		GitBranch:             "main",
+		StartTime:             start,
+		EndTime:               start.Add(time.Hour),
+		APICalls:              3,
+		InputTokens:           100,
+		CacheCreation1hTokens: 50,
+		EstimatedCost:         1.25,
+		Note:                  "big refactor",
+		UserTags:              []string{"billable"},
+		Models: map[string]*model.ModelUsage{
+			"claude-opus-4-6": {APICalls: 3, InputTokens: 100, EstimatedCost: 1.25},
+		},
+	}}
+
+	path := filepath.Join(t.TempDir(), "b.tar.gz")
+	if err := WriteFile(path, Manifest{Label: "alice", CreatedAt: start}, sessions); err != nil {
+		t.Fatal(err)
+	}
+
+	m, got, err := Read(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Label != "alice" || m.Sessions != 1 || m.Format != Format {
+		t.Errorf("manifest = %+v", m)
+	}
+	if len(got) != 1 {
+		t.Fatalf("got %d sessions, want 1", len(got))
+	}
+	s := got[0]
+	if s.SessionID != "s1" || s.GitBranch != "main" || s.CacheCreation1hTokens != 50 || s.EstimatedCost != 1.25 {
+		t.Errorf("session = %+v", s)
+	}
+	if s.Note != "big refactor" || len(s.UserTags) != 1 || s.UserTags[0] != "billable" {
+		t.Errorf("annotation = %q %v", s.Note, s.UserTags)
+	}
+	if s.FilePath != "" {
+		t.Errorf("FilePath leaked into bundle: %q", s.FilePath)
+	}
+	if mu := s.Models["claude-opus-4-6"]; mu == nil || mu.APICalls != 3 {
+		t.Errorf("models = %+v", s.Models)
+	}
+	if !s.StartTime.Equal(start) {
+		t.Errorf("StartTime = %v, want %v", s.StartTime, start)
+	}
+}
@@ -0,0 +1,169 @@
+// Package calendar exports heavy-usage sessions as iCalendar (RFC 5545)
+// events, so Claude working sessions can be overlaid on a calendar for
+// time tracking.
+package calendar
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+// Block is one heavy-usage session, its subagents folded in.
+type Block struct {
+	SessionID string
+	Project   string
+	Branch    string
+	Source    string // import label; "" for this machine
+	Start     time.Time
+	End       time.Time
+	Prompts   int
+	APICalls  int
+	Subagents int
+	Tokens    int64 // input, output, and cache writes; cache reads excluded
+	CostUSD   float64
+	Models    []string
+}
+
+// Blocks returns the top-level sessions starting in [since, until) that
+// cost more than minCost including their subagents, oldest first.
+func Blocks(sessions []model.SessionStats, minCost float64, since, until time.Time) []Block {
+	children := make(map[string][]model.SessionStats)
+	for _, s := range sessions {
+		if s.IsSubagent && s.ParentSession != "" {
+			children[s.ParentSession] = append(children[s.ParentSession], s)
+		}
+	}
+
+	var blocks []Block
+	for _, s := range sessions {
+		if s.IsSubagent || s.StartTime.IsZero() || s.StartTime.Before(since) || !s.StartTime.Before(until) {
+			continue
+		}
+		b := newBlock(s, children[s.SessionID])
+		if b.CostUSD > minCost {
+			blocks = append(blocks, b)
+		}
+	}
+	sort.Slice(blocks, func(i, j int) bool {
+		return blocks[i].Start.Before(blocks[j].Start)
+	})
+	return blocks
+}
+
+func newBlock(s model.SessionStats, subagents []model.SessionStats) Block {
+	b := Block{
+		SessionID: s.SessionID,
+		Project:   s.Project,
+		Branch:    s.GitBranch,
+		Source:    s.Source,
+		Start:     s.StartTime,
+		End:       s.EndTime,
+		Prompts:   s.UserMessages,
+		Subagents: len(subagents),
+	}
+	models := make(map[string]struct{})
+	for _, part := range append([]model.SessionStats{s}, subagents...) {
+		b.APICalls += part.APICalls
+		b.Tokens += part.InputTokens + part.OutputTokens + part.CacheCreation5mTokens + part.CacheCreation1hTokens
+		b.CostUSD += part.EstimatedCost
+		for m := range part.Models {
+			models[m] = struct{}{}
+		}
+		if part.EndTime.After(b.End) {
+			b.End = part.EndTime
+		}
+	}
+	for m := range models {
+		b.Models = append(b.Models, m)
+	}
+	sort.Strings(b.Models)
+	return b
+}
+
+// Write writes blocks as a VCALENDAR with one VEVENT each. Event UIDs are
+// derived from session IDs, so a calendar that re-imports the file or
+// polls the feed updates events instead of duplicating them.
+func Write(w io.Writer, blocks []Block, now time.Time) error {
+	bw := bufio.NewWriter(w)
+	line := func(name, value string) {
+		writeFolded(bw, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//cburn//Claude usage//EN")
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	line("X-WR-CALNAME", "Claude usage")
+	for _, b := range blocks {
+		end := b.End
+		if !end.After(b.Start) {
+			end = b.Start.Add(time.Minute) // calendars drop zero-length events
+		}
+		line("BEGIN", "VEVENT")
+		line("UID", escape(b.SessionID)+"@cburn")
+		line("DTSTAMP", stamp(now))
+		line("DTSTART", stamp(b.Start))
+		line("DTEND", stamp(end))
+		line("SUMMARY", escape(fmt.Sprintf("Claude: %s ($%.2f)", b.Project, b.CostUSD)))
+		line("DESCRIPTION", escape(description(b)))
+		line("CATEGORIES", "Claude")
+		line("TRANSP", "TRANSPARENT") // usage, not a meeting: don't show as busy
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+	return bw.Flush()
+}
+
+func description(b Block) string {
+	var d strings.Builder
+	fmt.Fprintf(&d, "Estimated cost: $%.2f\n", b.CostUSD)
+	fmt.Fprintf(&d, "Prompts: %d, API calls: %d, tokens: %d\n", b.Prompts, b.APICalls, b.Tokens)
+	if b.Subagents > 0 {
+		fmt.Fprintf(&d, "Subagents: %d\n", b.Subagents)
+	}
+	if len(b.Models) > 0 {
+		fmt.Fprintf(&d, "Models: %s\n", strings.Join(b.Models, ", "))
+	}
+	if b.Branch != "" {
+		fmt.Fprintf(&d, "Branch: %s\n", b.Branch)
+	}
+	if b.Source != "" {
+		fmt.Fprintf(&d, "Source: %s\n", b.Source)
+	}
+	fmt.Fprintf(&d, "Session: %s", b.SessionID)
+	return d.String()
+}
+
+func stamp(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// escape escapes a TEXT value: backslashes, semicolons, commas, and
+// newlines.
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(s)
+}
+
+// writeFolded writes a content line, folding it onto continuation lines
+// (CRLF and a space) so none exceeds 75 octets, without splitting a UTF-8
+// character.
+func writeFolded(w *bufio.Writer, s string) {
+	limit := 75
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && s[cut]&0xC0 == 0x80 { // continuation byte
+			cut--
+		}
+		_, _ = w.WriteString(s[:cut] + "\r\n ")
+		s = s[cut:]
+		limit = 74 // the leading space counts
+	}
+	_, _ = w.WriteString(s + "\r\n")
+}
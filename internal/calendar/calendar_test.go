@@ -0,0 +1,100 @@
+package calendar
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+func TestBlocks(t *testing.T) {
+	day := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+	sessions := []model.SessionStats{
+		{SessionID: "late", Project: "api", StartTime: day.Add(5 * time.Hour), EndTime: day.Add(6 * time.Hour), EstimatedCost: 8},
+		{SessionID: "main", Project: "app", StartTime: day, EndTime: day.Add(time.Hour), EstimatedCost: 3, APICalls: 10,
+			Models: map[string]*model.ModelUsage{"claude-opus-4-6": {}}},
+		// Pushes main over the threshold and past its own end
+		{SessionID: "agent", IsSubagent: true, ParentSession: "main", StartTime: day.Add(30 * time.Minute),
+			EndTime: day.Add(90 * time.Minute), EstimatedCost: 2.5, APICalls: 4,
+			Models: map[string]*model.ModelUsage{"claude-haiku-4-5": {}}},
+		{SessionID: "cheap", Project: "app", StartTime: day.Add(2 * time.Hour), EstimatedCost: 4.99},
+		// The threshold has to be exceeded, not just reached
+		{SessionID: "exact", Project: "app", StartTime: day.Add(3 * time.Hour), EstimatedCost: 5},
+		{SessionID: "free", Project: "app", StartTime: day.Add(4 * time.Hour)},
+		{SessionID: "old", Project: "app", StartTime: day.AddDate(0, 0, -10), EstimatedCost: 50},
+	}
+
+	blocks := Blocks(sessions, 5, day.AddDate(0, 0, -1), day.AddDate(0, 0, 1))
+	if len(blocks) != 2 || blocks[0].SessionID != "main" || blocks[1].SessionID != "late" {
+		t.Fatalf("blocks = %+v, want main then late", blocks)
+	}
+	b := blocks[0]
+	if b.CostUSD != 5.5 || b.APICalls != 14 || b.Subagents != 1 || !b.End.Equal(day.Add(90*time.Minute)) {
+		t.Errorf("main = %+v, want its subagent folded in", b)
+	}
+	if strings.Join(b.Models, ",") != "claude-haiku-4-5,claude-opus-4-6" {
+		t.Errorf("models = %v", b.Models)
+	}
+
+	// A zero threshold takes every session that cost anything.
+	if n := len(Blocks(sessions, 0, day.AddDate(0, 0, -1), day.AddDate(0, 0, 1))); n != 4 {
+		t.Errorf("blocks over $0 = %d, want 4", n)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	start := time.Date(2025, 6, 2, 9, 0, 0, 0, time.FixedZone("CEST", 2*3600))
+	blocks := []Block{{
+		SessionID: "abc-123",
+		Project:   "billing, api; v2",
+		Branch:    strings.Repeat("feature/ünïcode-", 6),
+		Start:     start,
+		End:       start.Add(90 * time.Minute),
+		CostUSD:   12.345,
+	}, {
+		SessionID: "zero",
+		Project:   "app",
+		Start:     start,
+		End:       start,
+		CostUSD:   6,
+	}}
+	var buf bytes.Buffer
+	if err := Write(&buf, blocks, time.Date(2025, 6, 3, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\nVERSION:2.0\r\n",
+		"UID:abc-123@cburn\r\n",
+		"DTSTAMP:20250603T000000Z\r\n",
+		"DTSTART:20250602T070000Z\r\nDTEND:20250602T083000Z\r\n",
+		`SUMMARY:Claude: billing\, api\; v2 ($12.35)`,
+		`DESCRIPTION:Estimated cost: $12.35\nPrompts: 0`,
+		// A zero-length session still gets a visible minute
+		"DTSTART:20250602T070000Z\r\nDTEND:20250602T070100Z\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output lacks %q:\n%s", want, out)
+		}
+	}
+	if strings.Count(out, "BEGIN:VEVENT") != 2 {
+		t.Errorf("want two events:\n%s", out)
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line of %d octets not folded: %q", len(line), line)
+		}
+		if strings.ToValidUTF8(line, "?") != line {
+			t.Errorf("fold split a UTF-8 character: %q", line)
+		}
+	}
+	unfolded := strings.ReplaceAll(out, "\r\n ", "")
+	if !strings.Contains(unfolded, `Branch: `+blocks[0].Branch+`\n`) {
+		t.Errorf("description doesn't unfold to the branch:\n%s", unfolded)
+	}
+}
@@ -0,0 +1,87 @@
+package claudeai
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	cacheFile = "claudeai.json"
+	// cacheFreshFor is how long a cached result is served without asking
+	// claude.ai again, so back-to-back CLI commands share one fetch.
+	cacheFreshFor = 30 * time.Second
+	// cacheStaleFor is the oldest cached result served when the API is
+	// unreachable; older rate-limit windows are more misleading than useful.
+	cacheStaleFor = 24 * time.Hour
+)
+
+// cacheEntry is the on-disk form of a successful FetchAll. It is keyed by a
+// hash of the session key and the org preference so switching accounts or
+// orgs never serves another one's data.
+type cacheEntry struct {
+	KeyHash   string         `json:"key_hash"`
+	OrgPref   string         `json:"org_pref"`
+	Org       Organization   `json:"org"`
+	Orgs      []Organization `json:"orgs"`
+	Usage     *ParsedUsage   `json:"usage"`
+	Overage   *OverageLimit  `json:"overage,omitempty"`
+	FetchedAt time.Time      `json:"fetched_at"`
+}
+
+func (c *Client) keyHash() string {
+	sum := sha256.Sum256([]byte(c.sessionKey))
+	return hex.EncodeToString(sum[:8])
+}
+
+// loadCache returns the cached result for orgID, or nil.
+func (c *Client) loadCache(orgID string) *SubscriptionData {
+	if c.cacheDir == "" {
+		return nil
+	}
+	raw, err := os.ReadFile(filepath.Join(c.cacheDir, cacheFile))
+	if err != nil {
+		return nil
+	}
+	var e cacheEntry
+	if err := json.Unmarshal(raw, &e); err != nil || e.KeyHash != c.keyHash() || e.OrgPref != orgID || e.Usage == nil {
+		return nil
+	}
+	return &SubscriptionData{
+		Org:       e.Org,
+		Orgs:      e.Orgs,
+		Usage:     e.Usage,
+		Overage:   e.Overage,
+		FetchedAt: e.FetchedAt,
+	}
+}
+
+// saveCache records a successful result (best-effort).
+func (c *Client) saveCache(orgID string, d *SubscriptionData) {
+	if c.cacheDir == "" {
+		return
+	}
+	raw, err := json.Marshal(cacheEntry{
+		KeyHash:   c.keyHash(),
+		OrgPref:   orgID,
+		Org:       d.Org,
+		Orgs:      d.Orgs,
+		Usage:     d.Usage,
+		Overage:   d.Overage,
+		FetchedAt: d.FetchedAt,
+	})
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(c.cacheDir, 0o750); err != nil {
+		return
+	}
+	tmp := filepath.Join(c.cacheDir, cacheFile+".tmp")
+	if err := os.WriteFile(tmp, raw, 0o600); err != nil {
+		return
+	}
+	_ = os.Rename(tmp, filepath.Join(c.cacheDir, cacheFile))
+}
@@ -31,6 +31,10 @@ var (
 type Client struct {
 	sessionKey string
 	http       *http.Client
+	baseURL    string
+	attempts   int           // total tries per request
+	backoff    time.Duration // doubled after each failed try
+	cacheDir   string        // "" disables the on-disk cache
 }
 
 // NewClient creates a client for the given session key.
@@ -46,12 +50,47 @@ func NewClient(sessionKey string) *Client {
 	return &Client{
 		sessionKey: sessionKey,
 		http:       &http.Client{},
+		baseURL:    baseURL,
+		attempts:   3,
+		backoff:    500 * time.Millisecond,
 	}
 }
 
-// FetchAll fetches orgs, usage, and overage for the first organization.
-// Partial data is returned even if some requests fail.
-func (c *Client) FetchAll(ctx context.Context) *SubscriptionData {
+// WithCache keeps the last successful FetchAll result in dir, so repeated
+// fetches within cacheFreshFor skip the network and an unreachable API
+// falls back to stale data.
+func (c *Client) WithCache(dir string) *Client {
+	c.cacheDir = dir
+	return c
+}
+
+// FetchAll fetches orgs, usage, and overage for the preferred organization:
+// the one whose UUID or name matches orgID, or the first one when orgID is
+// empty or no longer matches. Partial data is returned even if some requests
+// fail.
+//
+// With a cache (see WithCache), a recent result is returned as is, and when
+// the API fails for any reason other than an invalid key the last good
+// result is returned with Stale set and Error explaining why.
+func (c *Client) FetchAll(ctx context.Context, orgID string) *SubscriptionData {
+	cached := c.loadCache(orgID)
+	if cached != nil && time.Since(cached.FetchedAt) < cacheFreshFor {
+		return cached
+	}
+
+	result := c.fetchAll(ctx, orgID)
+	switch {
+	case result.Usage != nil:
+		c.saveCache(orgID, result)
+	case cached != nil && !errors.Is(result.Error, ErrUnauthorized) && time.Since(cached.FetchedAt) < cacheStaleFor:
+		cached.Stale = true
+		cached.Error = result.Error
+		return cached
+	}
+	return result
+}
+
+func (c *Client) fetchAll(ctx context.Context, orgID string) *SubscriptionData {
 	result := &SubscriptionData{FetchedAt: time.Now()}
 
 	orgs, err := c.FetchOrganizations(ctx)
@@ -64,8 +103,12 @@ func (c *Client) FetchAll(ctx context.Context) *SubscriptionData {
 		return result
 	}
 
+	result.Orgs = orgs
 	result.Org = orgs[0]
-	orgID := orgs[0].UUID
+	if org, ok := SelectOrg(orgs, orgID); ok {
+		result.Org = org
+	}
+	orgID = result.Org.UUID
 
 	// Fetch usage and overage independently — partial results are fine
 	usage, usageErr := c.FetchUsage(ctx, orgID)
@@ -102,6 +145,26 @@ func (c *Client) FetchOrganizations(ctx context.Context) ([]Organization, error)
 	return orgs, nil
 }
 
+// SelectOrg returns the organization whose UUID or (case-insensitive) name
+// matches pref.
+func SelectOrg(orgs []Organization, pref string) (Organization, bool) {
+	pref = strings.TrimSpace(pref)
+	if pref == "" {
+		return Organization{}, false
+	}
+	for _, o := range orgs {
+		if o.UUID == pref {
+			return o, true
+		}
+	}
+	for _, o := range orgs {
+		if strings.EqualFold(o.Name, pref) {
+			return o, true
+		}
+	}
+	return Organization{}, false
+}
+
 // FetchUsage returns parsed usage windows for the given organization.
 func (c *Client) FetchUsage(ctx context.Context, orgID string) (*ParsedUsage, error) {
 	body, err := c.get(ctx, fmt.Sprintf("/organizations/%s/usage", orgID))
@@ -136,43 +199,74 @@ func (c *Client) FetchOverageLimit(ctx context.Context, orgID string) (*OverageL
 	return &ol, nil
 }
 
-// get performs an authenticated GET request and returns the response body.
+// get performs an authenticated GET request and returns the response body,
+// retrying network errors, 429s, and 5xx responses with exponential backoff.
 func (c *Client) get(ctx context.Context, path string) ([]byte, error) {
+	attempts := c.attempts
+	if attempts < 1 {
+		attempts = 1
+	}
+	backoff := c.backoff
+
+	var lastErr error
+	for i := 0; i < attempts; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, lastErr
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		body, retry, err := c.getOnce(ctx, path)
+		if err == nil {
+			return body, nil
+		}
+		lastErr = err
+		if !retry {
+			break
+		}
+	}
+	return nil, lastErr
+}
+
+func (c *Client) getOnce(ctx context.Context, path string) (body []byte, retry bool, err error) {
 	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
 	defer cancel()
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+path, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("claudeai: creating request: %w", err)
+		return nil, false, fmt.Errorf("claudeai: creating request: %w", err)
 	}
 
 	req.Header.Set("Cookie", "sessionKey="+c.sessionKey)
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("User-Agent", "github.com/theirongolddev/cburn/1.0")
 
-	//nolint:gosec // URL is constructed from const baseURL
+	//nolint:gosec // URL is built from the claude.ai base URL
 	resp, err := c.http.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("claudeai: request failed: %w", err)
+		return nil, true, fmt.Errorf("claudeai: request failed: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	switch resp.StatusCode {
-	case http.StatusUnauthorized, http.StatusForbidden:
-		return nil, ErrUnauthorized
-	case http.StatusTooManyRequests:
-		return nil, ErrRateLimited
-	}
-
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("claudeai: unexpected status %d", resp.StatusCode)
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return nil, false, ErrUnauthorized
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return nil, true, ErrRateLimited
+	case resp.StatusCode >= 500:
+		return nil, true, fmt.Errorf("claudeai: unexpected status %d", resp.StatusCode)
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return nil, false, fmt.Errorf("claudeai: unexpected status %d", resp.StatusCode)
 	}
 
-	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	body, err = io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
 	if err != nil {
-		return nil, fmt.Errorf("claudeai: reading response: %w", err)
+		return nil, true, fmt.Errorf("claudeai: reading response: %w", err)
 	}
-	return body, nil
+	return body, false, nil
 }
 
 // parseWindow converts a raw UsageWindow into a normalized ParsedWindow.
@@ -0,0 +1,122 @@
+package claudeai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSelectOrg(t *testing.T) {
+	orgs := []Organization{
+		{UUID: "u-personal", Name: "Personal"},
+		{UUID: "u-work", Name: "Acme Corp"},
+	}
+	for _, tc := range []struct {
+		pref string
+		want string
+		ok   bool
+	}{
+		{"", "", false},
+		{"u-work", "u-work", true},
+		{"acme corp", "u-work", true},
+		{" Personal ", "u-personal", true},
+		{"u-gone", "", false},
+	} {
+		got, ok := SelectOrg(orgs, tc.pref)
+		if ok != tc.ok || got.UUID != tc.want {
+			t.Errorf("SelectOrg(%q) = %q, %v; want %q, %v", tc.pref, got.UUID, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func testClient(t *testing.T, srv *httptest.Server) *Client {
+	t.Helper()
+	c := NewClient("sk-ant-sid01-test")
+	c.http = srv.Client()
+	c.baseURL = srv.URL
+	c.backoff = time.Millisecond
+	return c.WithCache(t.TempDir())
+}
+
+func TestFetchAll_RetriesAndServesStale(t *testing.T) {
+	var usageCalls atomic.Int32
+	var down atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case down.Load():
+			w.WriteHeader(http.StatusBadGateway)
+		case r.URL.Path == "/organizations":
+			_, _ = w.Write([]byte(`[{"uuid":"u1","name":"Personal"}]`))
+		case r.URL.Path == "/organizations/u1/usage":
+			if usageCalls.Add(1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable) // transient; retried
+				return
+			}
+			_, _ = w.Write([]byte(`{"five_hour":{"utilization":42}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	c := testClient(t, srv)
+
+	data := c.FetchAll(context.Background(), "")
+	if data.Usage == nil || data.Usage.FiveHour.Pct != 0.42 || data.Stale {
+		t.Fatalf("first fetch = %+v (err %v), want fresh 42%% after a retry", data.Usage, data.Error)
+	}
+	if n := usageCalls.Load(); n != 2 {
+		t.Errorf("usage requests = %d, want 2 (one retry)", n)
+	}
+
+	// Within cacheFreshFor the cache answers without a request.
+	down.Store(true)
+	if again := c.FetchAll(context.Background(), ""); again.Stale || again.Error != nil || usageCalls.Load() != 2 {
+		t.Errorf("fresh cache hit = stale %v err %v after %d requests", again.Stale, again.Error, usageCalls.Load())
+	}
+
+	// Once it ages out, a failing API serves it as stale.
+	writeCacheAge(t, c, time.Hour)
+	stale := c.FetchAll(context.Background(), "")
+	if !stale.Stale || stale.Error == nil || stale.Usage == nil || stale.Usage.FiveHour.Pct != 0.42 {
+		t.Errorf("outage = stale %v err %v usage %+v, want stale cached data with the error", stale.Stale, stale.Error, stale.Usage)
+	}
+
+	// Another org preference never sees this org's cache.
+	if other := c.FetchAll(context.Background(), "u2"); other.Stale || other.Usage != nil {
+		t.Errorf("org u2 got cached data for u1")
+	}
+}
+
+func TestFetchAll_UnauthorizedIsNotMaskedByCache(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+	c := testClient(t, srv)
+	c.saveCache("", &SubscriptionData{Usage: &ParsedUsage{}, FetchedAt: time.Now().Add(-time.Hour)})
+
+	data := c.FetchAll(context.Background(), "")
+	if !errors.Is(data.Error, ErrUnauthorized) || data.Stale {
+		t.Errorf("got stale %v err %v, want ErrUnauthorized", data.Stale, data.Error)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("requests = %d, want 1 (401 is not retried)", n)
+	}
+}
+
+// writeCacheAge rewrites the cached entry as if fetched age ago.
+func writeCacheAge(t *testing.T, c *Client, age time.Duration) {
+	t.Helper()
+	d := c.loadCache("")
+	if d == nil {
+		t.Fatal("no cache entry")
+	}
+	d.FetchedAt = time.Now().Add(-age)
+	c.saveCache("", d)
+}
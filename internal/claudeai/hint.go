@@ -0,0 +1,64 @@
+package claudeai
+
+import "time"
+
+// NamedWindow pairs a rate-limit window with its display label.
+type NamedWindow struct {
+	Label  string
+	Window *ParsedWindow
+}
+
+// Windows returns the windows present in u, in display order.
+func (u *ParsedUsage) Windows() []NamedWindow {
+	if u == nil {
+		return nil
+	}
+	var out []NamedWindow
+	for _, nw := range []NamedWindow{
+		{"5-hour", u.FiveHour},
+		{"Weekly", u.SevenDay},
+		{"Weekly Opus", u.SevenDayOpus},
+		{"Weekly Sonnet", u.SevenDaySonnet},
+	} {
+		if nw.Window != nil {
+			out = append(out, nw)
+		}
+	}
+	return out
+}
+
+// ScheduleHint suggests when to start an expensive run: after the reset of
+// every window currently at or above the threshold.
+type ScheduleHint struct {
+	Label    string    // the window that resets last
+	Pct      float64   // its utilization, 0.0-1.0
+	ResetsAt time.Time // zero if the API didn't report a reset time
+}
+
+// Wait returns how long until the hint's reset, or 0 if it has passed or is unknown.
+func (h ScheduleHint) Wait(now time.Time) time.Duration {
+	if h.ResetsAt.IsZero() || !h.ResetsAt.After(now) {
+		return 0
+	}
+	return h.ResetsAt.Sub(now)
+}
+
+// SchedulingHint returns a hint when any window is at or above threshold
+// (0.0-1.0), or nil when there is headroom everywhere. Windows whose reset
+// time has already passed are ignored.
+func SchedulingHint(u *ParsedUsage, threshold float64, now time.Time) *ScheduleHint {
+	var hint *ScheduleHint
+	for _, nw := range u.Windows() {
+		w := nw.Window
+		if w.Pct < threshold {
+			continue
+		}
+		if !w.ResetsAt.IsZero() && !w.ResetsAt.After(now) {
+			continue
+		}
+		if hint == nil || w.ResetsAt.After(hint.ResetsAt) {
+			hint = &ScheduleHint{Label: nw.Label, Pct: w.Pct, ResetsAt: w.ResetsAt}
+		}
+	}
+	return hint
+}
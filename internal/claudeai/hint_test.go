@@ -0,0 +1,39 @@
+package claudeai
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSchedulingHint(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	u := &ParsedUsage{
+		FiveHour:     &ParsedWindow{Pct: 0.92, ResetsAt: now.Add(90 * time.Minute)},
+		SevenDay:     &ParsedWindow{Pct: 0.85, ResetsAt: now.Add(48 * time.Hour)},
+		SevenDayOpus: &ParsedWindow{Pct: 0.30, ResetsAt: now.Add(72 * time.Hour)},
+	}
+
+	h := SchedulingHint(u, 0.8, now)
+	if h == nil {
+		t.Fatal("expected a hint")
+	}
+	if h.Label != "Weekly" || h.Wait(now) != 48*time.Hour {
+		t.Errorf("hint = %+v, want Weekly in 48h", h)
+	}
+
+	if h := SchedulingHint(u, 0.9, now); h == nil || h.Label != "5-hour" {
+		t.Errorf("threshold 0.9: hint = %+v, want 5-hour", h)
+	}
+	if h := SchedulingHint(u, 0.95, now); h != nil {
+		t.Errorf("threshold 0.95: hint = %+v, want nil", h)
+	}
+
+	// A window whose reset already passed is stale and ignored.
+	stale := &ParsedUsage{FiveHour: &ParsedWindow{Pct: 1, ResetsAt: now.Add(-time.Minute)}}
+	if h := SchedulingHint(stale, 0.8, now); h != nil {
+		t.Errorf("stale window: hint = %+v, want nil", h)
+	}
+	if h := SchedulingHint(nil, 0.8, now); h != nil {
+		t.Errorf("nil usage: hint = %+v, want nil", h)
+	}
+}
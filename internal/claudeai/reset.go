@@ -0,0 +1,60 @@
+package claudeai
+
+import "time"
+
+// ExhaustedPct is the utilization at which a window counts as exhausted for
+// reset notifications.
+const ExhaustedPct = 0.99
+
+// WindowReset reports that a previously exhausted window has reset.
+type WindowReset struct {
+	Label   string
+	ResetAt time.Time // when the reset was noticed, or the reported reset time
+}
+
+// ResetTracker remembers exhausted windows and reports when each one resets:
+// once its reported reset time passes, or when a later fetch shows it below
+// ExhaustedPct, whichever comes first. Each exhaustion is reported once.
+type ResetTracker struct {
+	exhausted map[string]time.Time // label -> reported ResetsAt (zero if unknown)
+}
+
+// NewResetTracker returns an empty tracker.
+func NewResetTracker() *ResetTracker {
+	return &ResetTracker{exhausted: make(map[string]time.Time)}
+}
+
+// Observe records the windows in u and returns those that were exhausted
+// and are no longer.
+func (t *ResetTracker) Observe(u *ParsedUsage, now time.Time) []WindowReset {
+	var out []WindowReset
+	for _, nw := range u.Windows() {
+		if nw.Window.Pct >= ExhaustedPct {
+			t.exhausted[nw.Label] = nw.Window.ResetsAt
+			continue
+		}
+		if _, was := t.exhausted[nw.Label]; was {
+			delete(t.exhausted, nw.Label)
+			out = append(out, WindowReset{Label: nw.Label, ResetAt: now})
+		}
+	}
+	return out
+}
+
+// Due returns the exhausted windows whose reported reset time has passed,
+// so resets are noticed on time between fetches.
+func (t *ResetTracker) Due(now time.Time) []WindowReset {
+	var out []WindowReset
+	for label, at := range t.exhausted {
+		if !at.IsZero() && !now.Before(at) {
+			delete(t.exhausted, label)
+			out = append(out, WindowReset{Label: label, ResetAt: at})
+		}
+	}
+	return out
+}
+
+// Text describes the reset for a notification body.
+func (r WindowReset) Text() string {
+	return r.Label + " rate-limit window has reset — heavy usage can resume."
+}
@@ -0,0 +1,40 @@
+package claudeai
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResetTracker(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	tr := NewResetTracker()
+
+	full := &ParsedUsage{
+		FiveHour: &ParsedWindow{Pct: 1.0, ResetsAt: now.Add(time.Hour)},
+		SevenDay: &ParsedWindow{Pct: 1.0}, // no reset time reported
+	}
+	if rs := tr.Observe(full, now); len(rs) != 0 {
+		t.Fatalf("exhausting windows reported %v", rs)
+	}
+	if rs := tr.Due(now.Add(59 * time.Minute)); len(rs) != 0 {
+		t.Fatalf("reset reported early: %v", rs)
+	}
+	rs := tr.Due(now.Add(time.Hour))
+	if len(rs) != 1 || rs[0].Label != "5-hour" {
+		t.Fatalf("due = %v, want the 5-hour window", rs)
+	}
+	if rs := tr.Due(now.Add(2 * time.Hour)); len(rs) != 0 {
+		t.Fatalf("reset reported twice: %v", rs)
+	}
+
+	// The weekly window has no reset time; a lower reading reports it, and
+	// the 5-hour window already reported by Due stays quiet.
+	later := now.Add(2 * time.Hour)
+	rs = tr.Observe(&ParsedUsage{
+		FiveHour: &ParsedWindow{Pct: 0.1},
+		SevenDay: &ParsedWindow{Pct: 0.2},
+	}, later)
+	if len(rs) != 1 || rs[0].Label != "Weekly" || !rs[0].ResetAt.Equal(later) {
+		t.Fatalf("observe = %v, want Weekly reset at %v", rs, later)
+	}
+}
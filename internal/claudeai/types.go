@@ -37,11 +37,13 @@ type OverageLimit struct {
 
 // SubscriptionData is the parsed, TUI-ready aggregate of all claude.ai API data.
 type SubscriptionData struct {
-	Org       Organization
+	Org       Organization   // the organization usage was fetched for
+	Orgs      []Organization // every organization the session belongs to
 	Usage     *ParsedUsage
 	Overage   *OverageLimit
 	FetchedAt time.Time
 	Error     error
+	Stale     bool // served from the on-disk cache because the API failed; see Error
 }
 
 // ParsedUsage holds normalized usage windows.
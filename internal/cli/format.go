@@ -6,8 +6,70 @@ import (
 	"math"
 	"strconv"
 	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
+// Locale controls how costs and numbers are written. Costs are computed in
+// USD and converted at Rate for display.
+type Locale struct {
+	Symbol      string  // currency symbol, e.g. "$" or "€"
+	SymbolAfter bool    // "12,50 €" rather than "€12,50"
+	Rate        float64 // display currency units per USD
+	Decimal     string  // decimal separator
+	Thousands   string  // thousands separator
+}
+
+// USLocale is the default: US dollars with US separators.
+var USLocale = Locale{Symbol: "$", Rate: 1, Decimal: ".", Thousands: ","}
+
+var locale = USLocale
+
+// SetLocale changes how every formatter writes costs and numbers. Empty
+// fields keep their USLocale values.
+func SetLocale(l Locale) {
+	if l.Symbol == "" {
+		l.Symbol = USLocale.Symbol
+	}
+	if l.Rate <= 0 {
+		l.Rate = USLocale.Rate
+	}
+	if l.Decimal == "" {
+		l.Decimal = USLocale.Decimal
+	}
+	if l.Thousands == "" {
+		l.Thousands = USLocale.Thousands
+	}
+	locale = l
+}
+
+// ConvertCost converts a USD amount to the display currency.
+func ConvertCost(usd float64) float64 {
+	return usd * locale.Rate
+}
+
+// localizeDecimal swaps the decimal point of a formatted number for the
+// locale's separator.
+func localizeDecimal(s string) string {
+	if locale.Decimal == "." {
+		return s
+	}
+	return strings.Replace(s, ".", locale.Decimal, 1)
+}
+
+// withSymbol attaches the currency symbol to a formatted amount, spaced
+// off when it is a code like "CHF".
+func withSymbol(amount string) string {
+	if locale.SymbolAfter {
+		return amount + " " + locale.Symbol
+	}
+	if r, _ := utf8.DecodeLastRuneInString(locale.Symbol); unicode.IsLetter(r) {
+		return locale.Symbol + " " + amount
+	}
+	return locale.Symbol + amount
+}
+
 // FormatTokens formats a token count with human-readable suffixes.
 // e.g., 1234 -> "1.2K", 1234567 -> "1.2M", 1234567890 -> "1.2B"
 func FormatTokens(n int64) string {
@@ -18,28 +80,46 @@ func FormatTokens(n int64) string {
 
 	switch {
 	case abs >= 1_000_000_000:
-		return fmt.Sprintf("%.1fB", float64(n)/1_000_000_000)
+		return localizeDecimal(fmt.Sprintf("%.1fB", float64(n)/1_000_000_000))
 	case abs >= 1_000_000:
-		return fmt.Sprintf("%.1fM", float64(n)/1_000_000)
+		return localizeDecimal(fmt.Sprintf("%.1fM", float64(n)/1_000_000))
 	case abs >= 1_000:
-		return fmt.Sprintf("%.1fK", float64(n)/1_000)
+		return localizeDecimal(fmt.Sprintf("%.1fK", float64(n)/1_000))
 	default:
 		return strconv.FormatInt(n, 10)
 	}
 }
 
-// FormatCost formats a USD cost value.
+// FormatCost formats a USD cost in the display currency, with fewer
+// decimals as the amount grows.
 func FormatCost(cost float64) string {
-	if cost >= 1000 {
-		return "$" + FormatNumber(int64(math.Round(cost)))
+	v := ConvertCost(cost)
+	if v >= 1000 {
+		return withSymbol(FormatNumber(int64(math.Round(v))))
+	}
+	if v >= 100 {
+		return withSymbol(fmt.Sprintf("%.0f", v))
 	}
-	if cost >= 100 {
-		return fmt.Sprintf("$%.0f", cost)
+	if v >= 10 {
+		return withSymbol(localizeDecimal(fmt.Sprintf("%.1f", v)))
 	}
-	if cost >= 10 {
-		return fmt.Sprintf("$%.1f", cost)
+	return withSymbol(localizeDecimal(fmt.Sprintf("%.2f", v)))
+}
+
+// FormatCostFixed formats a USD cost in the display currency with exactly
+// decimals digits after the separator.
+func FormatCostFixed(cost float64, decimals int) string {
+	v := ConvertCost(cost)
+	whole, frac, _ := strings.Cut(strconv.FormatFloat(math.Abs(v), 'f', decimals, 64), ".")
+	n, _ := strconv.ParseInt(whole, 10, 64)
+	s := FormatNumber(n)
+	if frac != "" {
+		s += locale.Decimal + frac
+	}
+	if v < 0 {
+		s = "-" + s
 	}
-	return fmt.Sprintf("$%.2f", cost)
+	return withSymbol(s)
 }
 
 // FormatDuration formats seconds into a human-readable duration.
@@ -61,6 +141,19 @@ func FormatDuration(secs int64) string {
 	return fmt.Sprintf("%ds", secs)
 }
 
+// FormatLatency formats a turn duration with sub-minute precision.
+// e.g., 850ms -> "850ms", 12.34s -> "12.3s", 125s -> "2m 05s"
+func FormatLatency(d time.Duration) string {
+	switch {
+	case d < time.Second:
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	case d < time.Minute:
+		return localizeDecimal(fmt.Sprintf("%.1fs", d.Seconds()))
+	}
+	secs := int64(d.Seconds())
+	return fmt.Sprintf("%dm %02ds", secs/60, secs%60)
+}
+
 // FormatNumber adds comma separators to an integer.
 // e.g., 1234567 -> "1,234,567"
 func FormatNumber(n int64) string {
@@ -80,16 +173,31 @@ func FormatNumber(n int64) string {
 	}
 	for i := remainder; i < len(s); i += 3 {
 		if result.Len() > 0 {
-			result.WriteByte(',')
+			result.WriteString(locale.Thousands)
 		}
 		result.WriteString(s[i : i+3])
 	}
 	return result.String()
 }
 
+// FormatBytes formats a byte count with binary-unit suffixes.
+// e.g., 512 -> "512B", 1536 -> "1.5KB", 420000000 -> "400.5MB"
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit && exp < 3; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%cB", float64(n)/float64(div), "KMGT"[exp])
+}
+
 // FormatPercent formats a 0-1 float as a percentage string.
 func FormatPercent(f float64) string {
-	return fmt.Sprintf("%.1f%%", f*100)
+	return localizeDecimal(fmt.Sprintf("%.1f%%", f*100))
 }
 
 // FormatDelta formats a cost delta with sign and color hint.
@@ -102,6 +210,38 @@ func FormatDelta(current, previous float64) string {
 	return "-" + FormatCost(-delta)
 }
 
+// PlanMultiple projects a daily cost to a month and returns it as a
+// multiple of a monthly plan price.
+func PlanMultiple(costPerDay, planUSD float64) float64 {
+	if planUSD <= 0 {
+		return 0
+	}
+	return costPerDay * 365.25 / 12 / planUSD
+}
+
+// FormatPlanMultiple formats a PlanMultiple as "3.4×" from one plan up,
+// and as a share like "45%" below it.
+func FormatPlanMultiple(m float64) string {
+	if m >= 1 {
+		return localizeDecimal(fmt.Sprintf("%.1f×", m))
+	}
+	return fmt.Sprintf("%.0f%%", m*100)
+}
+
+// FormatPlanEquivalent describes a daily cost against a subscription,
+// e.g. "3.4× your $20/mo Pro plan" or "45% of your $200/mo Max 20x plan".
+func FormatPlanEquivalent(costPerDay, planUSD float64, planName string) string {
+	m := PlanMultiple(costPerDay, planUSD)
+	plan := FormatCostFixed(planUSD, 0) + "/mo"
+	if planName != "" {
+		plan += " " + planName
+	}
+	if m >= 1 {
+		return fmt.Sprintf("%s your %s plan", FormatPlanMultiple(m), plan)
+	}
+	return fmt.Sprintf("%s of your %s plan", FormatPlanMultiple(m), plan)
+}
+
 // FormatDayOfWeek returns a 3-letter day abbreviation from a weekday number.
 func FormatDayOfWeek(weekday int) string {
 	days := []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatCost_Locale(t *testing.T) {
+	defer SetLocale(USLocale)
+
+	if got := FormatCost(1234.5); got != "$1,235" {
+		t.Errorf("US: %q", got)
+	}
+
+	SetLocale(Locale{Symbol: "€", SymbolAfter: true, Rate: 0.5, Decimal: ",", Thousands: "."})
+	for usd, want := range map[float64]string{
+		3:     "1,50 €",
+		50:    "25,0 €",
+		10000: "5.000 €",
+	} {
+		if got := FormatCost(usd); got != want {
+			t.Errorf("FormatCost(%v) = %q, want %q", usd, got, want)
+		}
+	}
+	if got := FormatCostFixed(4690.5, 2); got != "2.345,25 €" {
+		t.Errorf("FormatCostFixed = %q", got)
+	}
+	if got := FormatTokens(1500); got != "1,5K" {
+		t.Errorf("FormatTokens = %q", got)
+	}
+
+	SetLocale(Locale{Symbol: "CHF"})
+	if got := FormatCost(2); got != "CHF 2.00" {
+		t.Errorf("code symbol: %q", got)
+	}
+}
+
+func TestFormatPlanEquivalent(t *testing.T) {
+	// Daily costs that run to $68 and $90 over a 30.4375-day month.
+	if got := FormatPlanEquivalent(68*12/365.25, 20, "Pro"); got != "3.4× your $20/mo Pro plan" {
+		t.Errorf("over plan: %q", got)
+	}
+	if got := FormatPlanEquivalent(90*12/365.25, 200, "Max 20x"); got != "45% of your $200/mo Max 20x plan" {
+		t.Errorf("under plan: %q", got)
+	}
+	if got := PlanMultiple(10, 0); got != 0 {
+		t.Errorf("zero plan price: %v", got)
+	}
+}
+
+func TestFormatLatency(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		850 * time.Millisecond:   "850ms",
+		12340 * time.Millisecond: "12.3s",
+		125 * time.Second:        "2m 05s",
+	} {
+		if got := FormatLatency(d); got != want {
+			t.Errorf("FormatLatency(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
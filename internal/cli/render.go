@@ -2,10 +2,15 @@ package cli
 
 import (
 	"fmt"
+	"os"
+	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/lipgloss/table"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/charmbracelet/x/term"
+	"github.com/muesli/termenv"
 )
 
 // Theme colors (Flexoki Dark)
@@ -25,6 +30,59 @@ var (
 	ColorYellow    = lipgloss.Color("#D0A215")
 )
 
+// NoColor is set by DisableColor. Renderers then mark severity with
+// symbols, since it can no longer be told by color.
+var NoColor bool
+
+// DisableColor turns off ANSI color for all lipgloss output, CLI and TUI
+// alike. lipgloss already honors NO_COLOR; this covers --no-color.
+func DisableColor() {
+	NoColor = true
+	lipgloss.SetColorProfile(termenv.Ascii)
+}
+
+// Plain is set by SetPlain. Titles, tables, and bars then render as
+// aligned ASCII text, for screen readers and CI logs.
+var Plain bool
+
+// SetPlain switches every renderer to plain output, which implies no color.
+func SetPlain() {
+	Plain = true
+	DisableColor()
+}
+
+// BarCells returns a text bar's filled and empty cells: block characters,
+// or # and . in plain mode.
+func BarCells(filled, empty int) (string, string) {
+	if Plain {
+		return strings.Repeat("#", filled), strings.Repeat(".", empty)
+	}
+	return strings.Repeat("█", filled), strings.Repeat("░", empty)
+}
+
+// VerticalRule returns the column separator for hand-laid-out rows.
+func VerticalRule() string {
+	if Plain {
+		return "|"
+	}
+	return "│"
+}
+
+// SeverityMark returns "!" for a level at or above warn and "!!" at or above
+// crit when color is off, and "" otherwise.
+func SeverityMark(level, warn, crit float64) string {
+	if !NoColor {
+		return ""
+	}
+	switch {
+	case level >= crit:
+		return "!!"
+	case level >= warn:
+		return "!"
+	}
+	return ""
+}
+
 // Styles
 var (
 	titleStyle = lipgloss.NewStyle().
@@ -44,15 +102,46 @@ var (
 )
 
 // Table represents a bordered text table for CLI output.
+//
+// Tables wider than the terminal are narrowed to fit: columns listed in
+// Optional are dropped first (in that order), then the Flex column is
+// truncated with an ellipsis.
 type Table struct {
 	Title   string
 	Headers []string
 	Rows    [][]string
+
+	Optional []int // column indices that may be hidden, least important first
+	Flex     int   // column index that may be truncated (default: the first)
+	MaxWidth int   // 0 = terminal width; unconstrained when not a terminal
+}
+
+// minFlexWidth is the narrowest a Flex column is truncated to.
+const minFlexWidth = 6
+
+// TerminalWidth returns the width of the terminal on stdout, or 0 when stdout
+// is not a terminal (e.g. piped), in which case output is not constrained.
+// $COLUMNS overrides detection.
+func TerminalWidth() int {
+	if c, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && c > 0 {
+		return c
+	}
+	w, _, err := term.GetSize(os.Stdout.Fd())
+	if err != nil {
+		return 0
+	}
+	return w
 }
 
 // RenderTitle renders a centered title bar in a bordered box.
 func RenderTitle(title string) string {
+	if Plain {
+		return "  " + title
+	}
 	width := 55
+	if tw := TerminalWidth(); tw > 0 && tw-2 < width {
+		width = max(tw-2, 20) // leave room for the border
+	}
 	border := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(ColorBorder).
@@ -78,12 +167,21 @@ func RenderTable(t Table) string {
 		rows = append(rows, row)
 	}
 
+	headers := t.Headers
+	width := t.MaxWidth
+	if width == 0 {
+		width = TerminalWidth()
+	}
+	if width > 0 {
+		headers, rows = fitTable(headers, rows, t.Optional, t.Flex, width)
+	}
+
 	tbl := table.New().
 		Border(lipgloss.RoundedBorder()).
 		BorderStyle(dimStyle).
 		BorderColumn(true).
 		BorderHeader(true).
-		Headers(t.Headers...).
+		Headers(headers...).
 		Rows(rows...).
 		StyleFunc(func(row, col int) lipgloss.Style {
 			s := lipgloss.NewStyle().Padding(0, 1)
@@ -103,12 +201,134 @@ func RenderTable(t Table) string {
 		b.WriteString(headerStyle.Render(t.Title))
 		b.WriteString("\n")
 	}
+	if Plain {
+		b.WriteString(renderPlainTable(headers, rows))
+		return b.String()
+	}
 	b.WriteString(tbl.Render())
 	b.WriteString("\n")
 
 	return b.String()
 }
 
+// renderPlainTable lays out a table as space-aligned columns under a dashed
+// header rule, first column left-aligned and the rest right-aligned.
+func renderPlainTable(headers []string, rows [][]string) string {
+	ncols := len(headers)
+	for _, r := range rows {
+		ncols = max(ncols, len(r))
+	}
+	colW := make([]int, ncols)
+	for _, r := range append([][]string{headers}, rows...) {
+		for i, c := range r {
+			colW[i] = max(colW[i], lipgloss.Width(c))
+		}
+	}
+
+	var b strings.Builder
+	line := func(cells []string) {
+		var l strings.Builder
+		l.WriteString("  ")
+		for i, c := range cells {
+			if i > 0 {
+				l.WriteString("  ")
+			}
+			pad := strings.Repeat(" ", colW[i]-lipgloss.Width(c))
+			if i == 0 {
+				l.WriteString(c + pad)
+			} else {
+				l.WriteString(pad + c)
+			}
+		}
+		b.WriteString(strings.TrimRight(l.String(), " "))
+		b.WriteString("\n")
+	}
+	if len(headers) > 0 {
+		line(headers)
+		rule := make([]string, len(headers))
+		for i := range headers {
+			rule[i] = strings.Repeat("-", colW[i])
+		}
+		line(rule)
+	}
+	for _, r := range rows {
+		line(r)
+	}
+	return b.String()
+}
+
+// fitTable drops optional columns and then truncates the flex column until
+// the bordered table fits in width. It returns new slices; inputs are not
+// modified.
+func fitTable(headers []string, rows [][]string, optional []int, flex, width int) ([]string, [][]string) {
+	ncols := len(headers)
+	for _, r := range rows {
+		ncols = max(ncols, len(r))
+	}
+	colW := make([]int, ncols)
+	measure := func(col int, cell string) {
+		colW[col] = max(colW[col], lipgloss.Width(cell))
+	}
+	for i, h := range headers {
+		measure(i, h)
+	}
+	for _, r := range rows {
+		for i, c := range r {
+			measure(i, c)
+		}
+	}
+
+	keep := make([]bool, ncols)
+	for i := range keep {
+		keep[i] = true
+	}
+	// Each kept column takes its content plus 1 cell of padding on each side
+	// and one border; there is one more border at the end.
+	total := func() int {
+		w := 1
+		for i, k := range keep {
+			if k {
+				w += colW[i] + 3
+			}
+		}
+		return w
+	}
+
+	for _, col := range optional {
+		if total() <= width {
+			break
+		}
+		if col >= 0 && col < ncols && col != flex {
+			keep[col] = false
+		}
+	}
+
+	truncTo := -1
+	if excess := total() - width; excess > 0 && flex >= 0 && flex < ncols {
+		truncTo = max(colW[flex]-excess, minFlexWidth)
+	}
+
+	project := func(r []string) []string {
+		out := make([]string, 0, len(r))
+		for i, c := range r {
+			if !keep[i] {
+				continue
+			}
+			if i == flex && truncTo >= 0 && lipgloss.Width(c) > truncTo {
+				c = ansi.Truncate(c, truncTo, "…")
+			}
+			out = append(out, c)
+		}
+		return out
+	}
+
+	newRows := make([][]string, len(rows))
+	for i, r := range rows {
+		newRows[i] = project(r)
+	}
+	return project(headers), newRows
+}
+
 // RenderProgressBar renders a simple text progress bar.
 func RenderProgressBar(current, total int, width int) string {
 	if total <= 0 {
@@ -125,9 +345,9 @@ func RenderProgressBar(current, total int, width int) string {
 		filled = width
 	}
 
-	bar := strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+	full, rest := BarCells(filled, width-filled)
 	return fmt.Sprintf("[%s] %s/%s",
-		mutedStyle.Render(bar),
+		mutedStyle.Render(full+rest),
 		FormatNumber(int64(current)),
 		FormatNumber(int64(total)),
 	)
@@ -175,6 +395,6 @@ func RenderHorizontalBar(label string, value, maxValue float64, maxWidth int) st
 	if barLen < 0 {
 		barLen = 0
 	}
-	bar := strings.Repeat("█", barLen)
+	bar, _ := BarCells(barLen, 0)
 	return "  " + bar
 }
@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFitTable(t *testing.T) {
+	headers := []string{"Project", "Sessions", "Prompts", "Cost"}
+	rows := [][]string{{"a-very-long-project-name", "12", "340", "$1.00"}}
+	// Natural width: (24+3) + (8+3) + (7+3) + (5+3) + 1 = 57
+
+	h, r := fitTable(headers, rows, []int{2, 1}, 0, 80)
+	if !reflect.DeepEqual(h, headers) || !reflect.DeepEqual(r, rows) {
+		t.Errorf("wide terminal changed the table: %v %v", h, r)
+	}
+
+	h, _ = fitTable(headers, rows, []int{2, 1}, 0, 50)
+	if want := []string{"Project", "Sessions", "Cost"}; !reflect.DeepEqual(h, want) {
+		t.Errorf("headers = %v, want %v", h, want)
+	}
+
+	// Both optional columns dropped (width 36), then truncate by 6.
+	h, r = fitTable(headers, rows, []int{2, 1}, 0, 30)
+	if want := []string{"Project", "Cost"}; !reflect.DeepEqual(h, want) {
+		t.Errorf("headers = %v, want %v", h, want)
+	}
+	if got := r[0][0]; got != "a-very-long-proje…" {
+		t.Errorf("flex cell = %q", got)
+	}
+	if rows[0][0] != "a-very-long-project-name" {
+		t.Error("fitTable modified its input")
+	}
+}
+
+func TestRenderPlainTable(t *testing.T) {
+	got := renderPlainTable([]string{"Project", "Cost"}, [][]string{{"cburn", "$1.50"}, {"a-longer-name", "$12.00"}})
+	want := "" +
+		"  Project          Cost\n" +
+		"  -------------  ------\n" +
+		"  cburn           $1.50\n" +
+		"  a-longer-name  $12.00\n"
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -2,9 +2,12 @@
 package config
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/BurntSushi/toml"
 )
@@ -16,7 +19,17 @@ type Config struct {
 	ClaudeAI   ClaudeAIConfig   `toml:"claude_ai"`
 	Budget     BudgetConfig     `toml:"budget"`
 	Appearance AppearanceConfig `toml:"appearance"`
+	Currency   CurrencyConfig   `toml:"currency"`
 	TUI        TUIConfig        `toml:"tui"`
+	Analytics  AnalyticsConfig  `toml:"analytics"`
+	Projects   ProjectsConfig   `toml:"projects"`
+	Receipts   ReceiptsConfig   `toml:"receipts"`
+	RateLimits RateLimitsConfig `toml:"rate_limits"`
+	Notify     NotifyConfig     `toml:"notify"`
+	Digest     DigestConfig     `toml:"digest"`
+	Calendar   CalendarConfig   `toml:"calendar"`
+	Daemon     DaemonConfig     `toml:"daemon"`
+	Views      []View           `toml:"views,omitempty"`
 	Pricing    PricingOverrides `toml:"pricing"`
 }
 
@@ -25,6 +38,36 @@ type GeneralConfig struct {
 	DefaultDays      int    `toml:"default_days"`
 	IncludeSubagents bool   `toml:"include_subagents"`
 	ClaudeDir        string `toml:"claude_dir,omitempty"`
+
+	// Timezone is the IANA zone (e.g. "UTC", "America/New_York") used for
+	// day and hour bucketing; empty uses the system zone.
+	Timezone string `toml:"timezone,omitempty"`
+
+	// ScanRoots are extra directories scanned alongside the Claude data
+	// directory, for JSONL written outside ~/.claude.
+	ScanRoots []ScanRoot `toml:"scan_roots,omitempty"`
+
+	// ScanExclude lists glob patterns for directories and files to skip in
+	// every scan root, such as a huge node_modules on a network mount.
+	// Patterns match an entry's name or its path relative to the directory
+	// walked (projects/ under the Claude data directory).
+	ScanExclude []string `toml:"scan_exclude,omitempty"`
+
+	// SecretsBackend is where API keys are kept: "plaintext" (the default)
+	// in this file, or "keychain" in the OS credential store.
+	SecretsBackend string `toml:"secrets_backend,omitempty"`
+
+	// GapAwareDurations excludes idle gaps longer than IdleGapMinutes
+	// (default 5) from session durations and the metrics built on them.
+	GapAwareDurations bool `toml:"gap_aware_durations,omitempty"`
+	IdleGapMinutes    int  `toml:"idle_gap_minutes,omitempty"`
+}
+
+// ScanRoot is an extra directory of session files.
+type ScanRoot struct {
+	Path    string `toml:"path"`
+	Profile string `toml:"profile,omitempty"` // claude-code (default) or jsonl
+	Project string `toml:"project,omitempty"` // fixed project name for every session under the root
 }
 
 // AdminAPIConfig holds Anthropic Admin API settings.
@@ -35,24 +78,204 @@ type AdminAPIConfig struct {
 
 // ClaudeAIConfig holds claude.ai session key settings for subscription data.
 type ClaudeAIConfig struct {
-	SessionKey string `toml:"session_key,omitempty"` //nolint:gosec // config field, not a secret
-	OrgID      string `toml:"org_id,omitempty"`      // auto-cached after first fetch
+	SessionKey     string    `toml:"session_key,omitempty"`     //nolint:gosec // config field, not a secret
+	OrgID          string    `toml:"org_id,omitempty"`          // auto-cached after first fetch
+	SessionExpires time.Time `toml:"session_expires,omitempty"` // cookie expiry, set by `cburn auth import-browser`
+}
+
+// SessionExpiresWithin reports whether the imported session key expires
+// within d of now (or already has). Keys entered by hand have no known
+// expiry and never report true.
+func (c ClaudeAIConfig) SessionExpiresWithin(d time.Duration, now time.Time) bool {
+	return !c.SessionExpires.IsZero() && c.SessionExpires.Sub(now) < d
 }
 
 // BudgetConfig holds budget tracking settings.
 type BudgetConfig struct {
 	MonthlyUSD *float64 `toml:"monthly_usd,omitempty"`
+	// BillingDay is the day of the month (1-28) a billing period starts;
+	// 0 means the 1st. Budgets and --billing use periods anchored on it.
+	BillingDay int `toml:"billing_day,omitempty"`
+
+	// Plan is the subscription ("pro", "max-5x", "max-20x") estimated costs
+	// are also shown against; PlanUSD overrides its monthly price.
+	Plan    string  `toml:"plan,omitempty"`
+	PlanUSD float64 `toml:"plan_usd,omitempty"`
 }
 
 // AppearanceConfig holds theme settings.
 type AppearanceConfig struct {
 	Theme string `toml:"theme"`
+	// Privacy masks project names, session IDs, and the organization name
+	// with stable pseudonyms, for screenshots and screen sharing.
+	Privacy bool `toml:"privacy,omitempty"`
+}
+
+// CurrencyConfig sets how costs are shown. Prices are in USD; other
+// currencies are converted at Rate, or at a live daily rate when Rate is 0.
+type CurrencyConfig struct {
+	Code        string  `toml:"code,omitempty"`         // ISO 4217 code, e.g. "EUR"; empty means USD
+	Symbol      string  `toml:"symbol,omitempty"`       // default: the code's usual symbol
+	Rate        float64 `toml:"rate,omitempty"`         // units of Code per USD; 0 looks it up
+	SymbolAfter bool    `toml:"symbol_after,omitempty"` // "12,50 €" rather than "€12,50"
+	Decimal     string  `toml:"decimal,omitempty"`      // decimal separator (default ".")
+	Thousands   string  `toml:"thousands,omitempty"`    // thousands separator (default ",")
+}
+
+// currencySymbols are the usual symbols of common currencies.
+var currencySymbols = map[string]string{
+	"USD": "$", "EUR": "€", "GBP": "£", "JPY": "¥", "CNY": "¥", "INR": "₹",
+	"KRW": "₩", "BRL": "R$", "CAD": "CA$", "AUD": "A$", "CHF": "CHF",
+}
+
+// CurrencySymbol returns the configured symbol, else the code's usual one,
+// else the code itself.
+func (c CurrencyConfig) CurrencySymbol() string {
+	if c.Symbol != "" {
+		return c.Symbol
+	}
+	code := strings.ToUpper(c.Code)
+	if sym, ok := currencySymbols[code]; ok {
+		return sym
+	}
+	if code == "" {
+		return "$"
+	}
+	return code
 }
 
 // TUIConfig holds TUI-specific settings.
 type TUIConfig struct {
 	AutoRefresh        bool `toml:"auto_refresh"`
 	RefreshIntervalSec int  `toml:"refresh_interval_sec"`
+
+	// Adaptive refresh: poll at RefreshMinSec while sessions are active and
+	// back off toward RefreshMaxSec when idle. Replaces the fixed interval.
+	// On by default, except in configs that set refresh_interval_sec but
+	// not adaptive_refresh.
+	AdaptiveRefresh bool `toml:"adaptive_refresh"`
+	RefreshMinSec   int  `toml:"refresh_min_sec"`
+	RefreshMaxSec   int  `toml:"refresh_max_sec"`
+}
+
+// AnalyticsConfig controls the opt-in, local-only usage log of cburn itself.
+type AnalyticsConfig struct {
+	Enabled bool `toml:"enabled"`
+}
+
+// ReceiptsConfig controls writing per-session cost receipts into
+// <project>/.cburn/receipts.jsonl.
+type ReceiptsConfig struct {
+	Enabled bool `toml:"enabled"`
+}
+
+// RateLimitsConfig controls scheduling hints for claude.ai rate-limit windows.
+type RateLimitsConfig struct {
+	// HintThresholdPct is the window utilization (0-100) at which `cburn check`
+	// and the TUI suggest waiting for the next reset.
+	HintThresholdPct int `toml:"hint_threshold_pct"`
+}
+
+// HintThreshold returns the hint threshold as a 0.0-1.0 fraction.
+func (r RateLimitsConfig) HintThreshold() float64 {
+	if r.HintThresholdPct <= 0 || r.HintThresholdPct > 100 {
+		return 0.8
+	}
+	return float64(r.HintThresholdPct) / 100
+}
+
+// NotifyConfig controls webhook and desktop notifications fired by the daemon.
+type NotifyConfig struct {
+	// UsageDeltaUSD fires a "usage" notification when one daemon poll adds at
+	// least this much estimated cost. 0 disables usage notifications.
+	UsageDeltaUSD float64   `toml:"usage_delta_usd,omitempty"`
+	Webhooks      []Webhook `toml:"webhooks,omitempty"`
+	// Desktop also shows notifications on this machine's desktop
+	// (notify-send or osascript), e.g. when an exhausted rate limit resets.
+	Desktop bool `toml:"desktop,omitempty"`
+	// Runaway fires a "runaway" notification when a session starts to look
+	// like a runaway agent (see pipeline.RunawayReason).
+	Runaway bool `toml:"runaway,omitempty"`
+}
+
+// Webhook is one notification target.
+type Webhook struct {
+	URL    string   `toml:"url"`
+	Format string   `toml:"format,omitempty"` // slack, discord, or json; detected from URL if empty
+	Events []string `toml:"events,omitempty"` // usage, budget, anomaly, runaway, rate_limit, rate_limit_reset; empty means all
+}
+
+// DigestConfig controls the daily digest email sent by the daemon (the
+// page `cburn report --daily` writes). Setting To turns it on.
+type DigestConfig struct {
+	To           string `toml:"to,omitempty"`
+	From         string `toml:"from,omitempty"` // defaults to To
+	Hour         int    `toml:"hour,omitempty"` // local hour the previous day's digest goes out; default 8
+	SMTPHost     string `toml:"smtp_host,omitempty"`
+	SMTPPort     int    `toml:"smtp_port,omitempty"` // default 587; 465 uses implicit TLS
+	SMTPUser     string `toml:"smtp_user,omitempty"`
+	SMTPPassword string `toml:"smtp_password,omitempty"` //nolint:gosec // config field, not a secret
+}
+
+// SendHour returns the hour the digest is sent at, 8 unless set.
+func (d DigestConfig) SendHour() int {
+	if d.Hour <= 0 || d.Hour > 23 {
+		return 8
+	}
+	return d.Hour
+}
+
+// CalendarConfig controls the iCalendar export of heavy-usage sessions
+// (`cburn calendar` and the daemon's /v1/calendar.ics).
+type CalendarConfig struct {
+	// MinCostUSD is the cost, subagents included, a session must exceed to
+	// get an event; default 5.
+	MinCostUSD float64 `toml:"min_cost_usd,omitempty"`
+}
+
+// MinCost returns the event threshold in USD, 5 unless set.
+func (c CalendarConfig) MinCost() float64 {
+	if c.MinCostUSD <= 0 {
+		return 5
+	}
+	return c.MinCostUSD
+}
+
+// DaemonConfig holds settings for `cburn daemon`.
+type DaemonConfig struct {
+	// Defaults for the flags of the same name; flags given on the command
+	// line win. The daemon rereads them on SIGHUP or when this file changes.
+	IntervalSec int    `toml:"interval_sec,omitempty"`
+	Days        int    `toml:"days,omitempty"`
+	Project     string `toml:"project,omitempty"`
+	Model       string `toml:"model,omitempty"`
+	DataDir     string `toml:"data_dir,omitempty"`
+
+	// Sinks receive every event the daemon publishes, e.g. for an
+	// append-only audit log.
+	Sinks []Sink `toml:"sinks,omitempty"`
+
+	// SlackSigningSecret turns on /v1/slack/command for a Slack app's slash
+	// command; requests must be signed with it.
+	SlackSigningSecret string `toml:"slack_signing_secret,omitempty"` //nolint:gosec // config field, not a secret
+}
+
+// DataDirPath is DataDir with a leading ~/ expanded.
+func (d DaemonConfig) DataDirPath() string {
+	return expandHome(d.DataDir)
+}
+
+// Sink is one destination for daemon events.
+type Sink struct {
+	Type   string   `toml:"type"`             // stdout, file, webhook, or sqlite
+	Path   string   `toml:"path,omitempty"`   // file and sqlite; a leading ~/ is expanded
+	URL    string   `toml:"url,omitempty"`    // webhook
+	Events []string `toml:"events,omitempty"` // event types to write; empty means all
+}
+
+// ExpandedPath is Path with a leading ~/ expanded.
+func (s Sink) ExpandedPath() string {
+	return expandHome(s.Path)
 }
 
 // PricingOverrides allows user-defined pricing for specific models.
@@ -82,6 +305,15 @@ func DefaultConfig() Config {
 		TUI: TUIConfig{
 			AutoRefresh:        true,
 			RefreshIntervalSec: 30,
+			AdaptiveRefresh:    true,
+			RefreshMinSec:      5,
+			RefreshMaxSec:      300,
+		},
+		RateLimits: RateLimitsConfig{
+			HintThresholdPct: 80,
+		},
+		Projects: ProjectsConfig{
+			MergeByRepo: true,
 		},
 	}
 }
@@ -112,15 +344,29 @@ func Load() (Config, error) {
 		return cfg, fmt.Errorf("reading config: %w", err)
 	}
 
-	if err := toml.Unmarshal(data, &cfg); err != nil {
+	md, err := toml.Decode(string(data), &cfg)
+	if err != nil {
 		return cfg, fmt.Errorf("parsing config: %w", err)
 	}
+	// A config from before adaptive refresh sets only the fixed interval;
+	// keep honoring it rather than switching to adaptive polling.
+	if md.IsDefined("tui", "refresh_interval_sec") && !md.IsDefined("tui", "adaptive_refresh") {
+		cfg.TUI.AdaptiveRefresh = false
+	}
+	if cfg.UsesKeychain() {
+		loadSecrets(&cfg)
+	}
 
 	return cfg, nil
 }
 
-// Save writes the config to disk.
+// Save writes the config to disk. With the keychain backend, secrets go to
+// the keychain instead, or stay in the file if it refuses them.
 func Save(cfg Config) error {
+	if cfg.UsesKeychain() {
+		storeSecrets(&cfg)
+	}
+
 	dir := Dir()
 	if err := os.MkdirAll(dir, 0o750); err != nil {
 		return fmt.Errorf("creating config dir: %w", err)
@@ -138,6 +384,20 @@ func Save(cfg Config) error {
 	return f.Close()
 }
 
+// AsMap returns cfg keyed by its TOML names, for writing it in other
+// formats such as JSON.
+func AsMap(cfg Config) (map[string]any, error) {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(cfg); err != nil {
+		return nil, err
+	}
+	m := make(map[string]any)
+	if _, err := toml.Decode(buf.String(), &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // GetAdminAPIKey returns the API key from env var or config, in that order.
 func GetAdminAPIKey(cfg Config) string {
 	if key := os.Getenv("ANTHROPIC_ADMIN_KEY"); key != "" {
@@ -159,3 +419,18 @@ func Exists() bool {
 	_, err := os.Stat(Path())
 	return err == nil
 }
+
+// SetTimezone makes the named IANA zone the one every day and hour bucket
+// uses by replacing time.Local, so the pipeline, tables, and TUI charts all
+// agree. An empty name or "local" keeps the system zone.
+func SetTimezone(name string) error {
+	if name == "" || strings.EqualFold(name, "local") {
+		return nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return fmt.Errorf("setting time zone: %w", err)
+	}
+	time.Local = loc
+	return nil
+}
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"os"
+	"testing"
+)
+
+func TestLoad_AdaptiveRefresh(t *testing.T) {
+	tests := []struct {
+		name string
+		toml string
+		want bool
+	}{
+		{"default", "[general]\ndefault_days = 7\n", true},
+		{"fixed interval only", "[tui]\nrefresh_interval_sec = 60\n", false},
+		{"both set", "[tui]\nrefresh_interval_sec = 60\nadaptive_refresh = true\n", true},
+		{"adaptive off", "[tui]\nadaptive_refresh = false\n", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+			if err := os.MkdirAll(Dir(), 0o750); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(Path(), []byte(tt.toml), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			cfg, err := Load()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cfg.TUI.AdaptiveRefresh != tt.want {
+				t.Errorf("AdaptiveRefresh = %v, want %v", cfg.TUI.AdaptiveRefresh, tt.want)
+			}
+		})
+	}
+}
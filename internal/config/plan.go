@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 // PlanInfo holds detected Claude subscription plan info.
@@ -38,3 +39,32 @@ func DetectPlan(claudeDir string) PlanInfo {
 
 	return info
 }
+
+// subscriptionPlans maps a [budget] plan key to its display name and
+// monthly price in USD.
+var subscriptionPlans = map[string]struct {
+	name string
+	usd  float64
+}{
+	"pro":     {"Pro", 20},
+	"max-5x":  {"Max 5x", 100},
+	"max-20x": {"Max 20x", 200},
+}
+
+// Subscription returns the configured plan's display name and monthly
+// price, or ok=false when no plan is set. An unknown plan key is shown
+// as written and needs plan_usd.
+func (b BudgetConfig) Subscription() (name string, usd float64, ok bool) {
+	if p, known := subscriptionPlans[strings.ToLower(b.Plan)]; known {
+		name, usd = p.name, p.usd
+	} else {
+		name = b.Plan
+	}
+	if b.PlanUSD > 0 {
+		usd = b.PlanUSD
+	}
+	if usd <= 0 {
+		return "", 0, false
+	}
+	return name, usd, true
+}
@@ -158,15 +158,47 @@ func LookupPricingAt(model string, at time.Time) (ModelPricing, bool) {
 	return selected, true
 }
 
-// CalculateCost computes the estimated cost in USD for a single API call.
+// Service tiers reported in API usage (usage.service_tier).
+const (
+	TierStandard = "standard"
+	TierPriority = "priority"
+	TierBatch    = "batch"
+)
+
+// tierMultipliers scale a call's list-price cost by its service tier.
+// Batch requests are billed at half price; tiers not listed pay list price.
+var tierMultipliers = map[string]float64{
+	TierBatch: 0.5,
+}
+
+// NormalizeTier maps an empty service tier to TierStandard.
+func NormalizeTier(tier string) string {
+	if tier == "" {
+		return TierStandard
+	}
+	return strings.ToLower(tier)
+}
+
+// TierMultiplier returns the cost multiplier for a service tier (1 for
+// standard, priority, and unknown tiers).
+func TierMultiplier(tier string) float64 {
+	if m, ok := tierMultipliers[NormalizeTier(tier)]; ok {
+		return m
+	}
+	return 1
+}
+
+// CalculateCost computes the estimated cost in USD for a single standard-tier API call.
 func CalculateCost(model string, inputTokens, outputTokens, cache5m, cache1h, cacheRead int64) float64 {
-	return CalculateCostAt(model, time.Now(), inputTokens, outputTokens, cache5m, cache1h, cacheRead)
+	return CalculateCostAt(model, time.Now(), TierStandard, inputTokens, outputTokens, cache5m, cache1h, cacheRead)
 }
 
-// CalculateCostAt computes the estimated cost in USD for a single API call at a point in time.
+// CalculateCostAt computes the estimated cost in USD for a single API call at
+// a point in time, scaled by its service tier (see TierMultiplier).
 func CalculateCostAt(
 	model string,
 	at time.Time,
+	tier string,
 	inputTokens,
 	outputTokens,
 	cache5m,
@@ -186,7 +218,7 @@ func CalculateCostAt(
 	cost += float64(cache1h) * pricing.CacheWrite1hPerMTok / 1_000_000
 	cost += float64(cacheRead) * pricing.CacheReadPerMTok / 1_000_000
 
-	return cost
+	return cost * TierMultiplier(tier)
 }
 
 // CalculateCacheSavings computes how much the cache reads saved vs full input pricing.
@@ -79,3 +79,17 @@ func TestLookupPricingAt_UsesLatestWhenTimeZero(t *testing.T) {
 		t.Fatalf("zero-time lookup InputPerMTok = %.2f, want 3.0", price.InputPerMTok)
 	}
 }
+
+func TestCalculateCostAt_AppliesTierMultiplier(t *testing.T) {
+	at := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	standard := CalculateCostAt("claude-sonnet-4-6", at, "", 1_000_000, 100_000, 0, 0, 0)
+	if standard == 0 {
+		t.Fatal("standard cost should be non-zero")
+	}
+	if got := CalculateCostAt("claude-sonnet-4-6", at, TierPriority, 1_000_000, 100_000, 0, 0, 0); got != standard {
+		t.Errorf("priority cost = %v, want list price %v", got, standard)
+	}
+	if got := CalculateCostAt("claude-sonnet-4-6", at, TierBatch, 1_000_000, 100_000, 0, 0, 0); got != standard/2 {
+		t.Errorf("batch cost = %v, want half of %v", got, standard)
+	}
+}
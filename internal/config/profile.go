@@ -0,0 +1,79 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// ProfileVersion is the current portable profile format version.
+const ProfileVersion = 1
+
+// Profile is a portable bundle of cburn preferences: everything in the config
+// except secrets and machine-specific paths. It lets a new machine be set up
+// with `cburn profile import`.
+type Profile struct {
+	Version    int       `toml:"version"`
+	ExportedAt time.Time `toml:"exported_at"`
+	Config     Config    `toml:"config"`
+}
+
+// NewProfile builds a profile from cfg with secrets and machine-specific
+// settings (API keys, claude.ai session/org, webhook URLs, digest mail
+// settings, Slack signing secret, daemon event sinks, data directories)
+// removed.
+func NewProfile(cfg Config, now time.Time) Profile {
+	cfg.AdminAPI.APIKey = ""
+	cfg.ClaudeAI = ClaudeAIConfig{}
+	cfg.Notify.Webhooks = nil
+	cfg.Digest = DigestConfig{}
+	cfg.Daemon.SlackSigningSecret = ""
+	cfg.Daemon.Sinks = nil
+	cfg.Daemon.DataDir = ""
+	cfg.General.ClaudeDir = ""
+	cfg.General.SecretsBackend = ""
+	return Profile{
+		Version:    ProfileVersion,
+		ExportedAt: now.UTC().Truncate(time.Second),
+		Config:     cfg,
+	}
+}
+
+// WriteProfile encodes p as TOML.
+func WriteProfile(w io.Writer, p Profile) error {
+	return toml.NewEncoder(w).Encode(p)
+}
+
+// ReadProfile decodes a profile. Settings missing from the file take their
+// default values.
+func ReadProfile(r io.Reader) (Profile, error) {
+	p := Profile{Config: DefaultConfig()}
+	if _, err := toml.NewDecoder(r).Decode(&p); err != nil {
+		return p, fmt.Errorf("parsing profile: %w", err)
+	}
+	if p.Version == 0 {
+		return p, fmt.Errorf("not a cburn profile (missing version)")
+	}
+	if p.Version > ProfileVersion {
+		return p, fmt.Errorf("profile version %d is newer than supported (%d); upgrade cburn", p.Version, ProfileVersion)
+	}
+	return p, nil
+}
+
+// ApplyProfile returns the profile's settings merged onto local, keeping
+// local's secrets and machine-specific settings.
+func ApplyProfile(local Config, p Profile) Config {
+	out := p.Config
+	out.AdminAPI.APIKey = local.AdminAPI.APIKey
+	out.ClaudeAI = local.ClaudeAI
+	out.Notify.Webhooks = local.Notify.Webhooks
+	out.Digest = local.Digest
+	out.Daemon.SlackSigningSecret = local.Daemon.SlackSigningSecret
+	out.Daemon.Sinks = local.Daemon.Sinks
+	out.Daemon.DataDir = local.Daemon.DataDir
+	out.General.ClaudeDir = local.General.ClaudeDir
+	out.General.SecretsBackend = local.General.SecretsBackend
+	return out
+}
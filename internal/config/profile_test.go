@@ -0,0 +1,88 @@
+package config
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProfile_RoundTripStripsSecrets(t *testing.T) {
+	budget := 250.0
+	src := DefaultConfig()
+	src.AdminAPI.APIKey = "sk-ant-admin-secret"
+	src.ClaudeAI = ClaudeAIConfig{SessionKey: "sk-ant-sid-secret", OrgID: "org-1"}
+	src.General.ClaudeDir = "/home/alice/.claude"
+	src.Daemon.DataDir = "/home/alice/claude-data"
+	src.Budget.MonthlyUSD = &budget
+	src.Appearance.Theme = "tokyo-night"
+	src.Projects.Rules = []ProjectRule{{Match: "~/work/acme", Tag: "acme"}}
+
+	var buf bytes.Buffer
+	if err := WriteProfile(&buf, NewProfile(src, time.Now())); err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"sk-ant-admin-secret", "sk-ant-sid-secret", "org-1", "/home/alice"} {
+		if strings.Contains(buf.String(), secret) {
+			t.Errorf("exported profile contains %q", secret)
+		}
+	}
+
+	p, err := ReadProfile(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	local := DefaultConfig()
+	local.ClaudeAI.SessionKey = "sk-ant-sid-local"
+	local.General.ClaudeDir = "/home/bob/.claude"
+	local.Daemon.DataDir = "/home/bob/claude-data"
+	got := ApplyProfile(local, p)
+
+	if got.Appearance.Theme != "tokyo-night" {
+		t.Errorf("theme = %q", got.Appearance.Theme)
+	}
+	if got.Budget.MonthlyUSD == nil || *got.Budget.MonthlyUSD != 250 {
+		t.Errorf("budget = %v", got.Budget.MonthlyUSD)
+	}
+	if len(got.Projects.Rules) != 1 || got.Projects.Rules[0].Tag != "acme" {
+		t.Errorf("rules = %+v", got.Projects.Rules)
+	}
+	if got.ClaudeAI.SessionKey != "sk-ant-sid-local" || got.General.ClaudeDir != "/home/bob/.claude" || got.Daemon.DataDir != "/home/bob/claude-data" {
+		t.Errorf("local machine settings not preserved: %+v %q %q", got.ClaudeAI, got.General.ClaudeDir, got.Daemon.DataDir)
+	}
+}
+
+func TestProfile_KeepsSinksLocal(t *testing.T) {
+	src := DefaultConfig()
+	src.Daemon.Sinks = []Sink{
+		{Type: "webhook", URL: "https://hooks.example.com/sink-secret"},
+		{Type: "file", Path: "/home/alice/events.jsonl"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteProfile(&buf, NewProfile(src, time.Now())); err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{"sink-secret", "/home/alice"} {
+		if strings.Contains(buf.String(), s) {
+			t.Errorf("exported profile contains %q:\n%s", s, buf.String())
+		}
+	}
+
+	p, err := ReadProfile(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	local := DefaultConfig()
+	local.Daemon.Sinks = []Sink{{Type: "sqlite", Path: "/home/bob/events.db"}}
+	if got := ApplyProfile(local, p).Daemon.Sinks; len(got) != 1 || got[0].Path != "/home/bob/events.db" {
+		t.Errorf("sinks = %+v, want the local sqlite sink", got)
+	}
+}
+
+func TestReadProfile_RejectsNonProfile(t *testing.T) {
+	if _, err := ReadProfile(strings.NewReader("[general]\ndefault_days = 7\n")); err == nil {
+		t.Error("expected error for config file without profile version")
+	}
+}
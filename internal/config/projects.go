@@ -0,0 +1,100 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ProjectsConfig maps projects to cost allocation tags (team, client, ...)
+// and merges projects that were renamed or moved.
+type ProjectsConfig struct {
+	Rules   []ProjectRule  `toml:"rules,omitempty"`
+	Aliases []ProjectAlias `toml:"aliases,omitempty"`
+
+	// MergeByRepo counts sessions in the same git repository, at the same
+	// place within it, as one project even after the repository moved.
+	MergeByRepo bool `toml:"merge_by_repo"`
+
+	// Automation lists patterns (as in ProjectRule.Match) for projects whose
+	// sessions are all automated runs, such as a CI checkout or a bot's
+	// working directory.
+	Automation []string `toml:"automation,omitempty"`
+}
+
+// IsAutomation reports whether the project matches an Automation pattern.
+func (pc ProjectsConfig) IsAutomation(project, path string) bool {
+	for _, pattern := range pc.Automation {
+		if pattern != "" && ruleMatches(expandHome(pattern), project, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// ProjectAlias reports every project matching Match (as in ProjectRule) under
+// the name Project. Aliases are evaluated in order; first match wins.
+type ProjectAlias struct {
+	Match   string `toml:"match"`
+	Project string `toml:"project"`
+}
+
+// AliasFor returns the name of the first alias matching the project, or "".
+func (pc ProjectsConfig) AliasFor(project, path string) string {
+	for _, a := range pc.Aliases {
+		if a.Match == "" || a.Project == "" {
+			continue
+		}
+		if ruleMatches(expandHome(a.Match), project, path) {
+			return a.Project
+		}
+	}
+	return ""
+}
+
+// ProjectRule assigns Tag to every project whose path or name matches Match.
+// Match is a glob (filepath.Match syntax) tested against the project path and
+// the project name, or a directory prefix of the project path. A leading "~/"
+// expands to the home directory. Rules are evaluated in order; first match wins.
+type ProjectRule struct {
+	Match string `toml:"match"`
+	Tag   string `toml:"tag"`
+}
+
+// TagFor returns the tag of the first rule matching the project, or "".
+func (pc ProjectsConfig) TagFor(project, path string) string {
+	for _, r := range pc.Rules {
+		if r.Match == "" || r.Tag == "" {
+			continue
+		}
+		if ruleMatches(expandHome(r.Match), project, path) {
+			return r.Tag
+		}
+	}
+	return ""
+}
+
+func ruleMatches(pattern, project, path string) bool {
+	if ok, _ := filepath.Match(pattern, project); ok {
+		return true
+	}
+	if path == "" {
+		return false
+	}
+	if ok, _ := filepath.Match(pattern, path); ok {
+		return true
+	}
+	prefix := strings.TrimSuffix(pattern, "/")
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
+
+func expandHome(p string) string {
+	if !strings.HasPrefix(p, "~/") {
+		return p
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return p
+	}
+	return filepath.Join(home, p[2:])
+}
@@ -0,0 +1,47 @@
+package config
+
+import "testing"
+
+func TestProjectsConfig_TagFor(t *testing.T) {
+	pc := ProjectsConfig{Rules: []ProjectRule{
+		{Match: "/work/acme", Tag: "acme"},
+		{Match: "/work/*/globex-*", Tag: "globex"},
+		{Match: "internal-*", Tag: "internal"},
+		{Match: "/work", Tag: "work"},
+	}}
+
+	tests := []struct {
+		project, path, want string
+	}{
+		{"api", "/work/acme/api", "acme"},
+		{"acme", "/work/acme", "acme"},
+		{"globex-web", "/work/clients/globex-web", "globex"},
+		{"internal-tools", "/home/me/internal-tools", "internal"},
+		{"other", "/work/other", "work"},
+		{"acmex", "/work2/acmex", ""},
+		{"nopath", "", ""},
+	}
+	for _, tt := range tests {
+		if got := pc.TagFor(tt.project, tt.path); got != tt.want {
+			t.Errorf("TagFor(%q, %q) = %q, want %q", tt.project, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestProjectsConfig_IsAutomation(t *testing.T) {
+	pc := ProjectsConfig{Automation: []string{"/srv/bots", "nightly-*"}}
+
+	tests := []struct {
+		project, path string
+		want          bool
+	}{
+		{"triage", "/srv/bots/triage", true},
+		{"nightly-evals", "/home/me/nightly-evals", true},
+		{"api", "/work/api", false},
+	}
+	for _, tt := range tests {
+		if got := pc.IsAutomation(tt.project, tt.path); got != tt.want {
+			t.Errorf("IsAutomation(%q, %q) = %v, want %v", tt.project, tt.path, got, tt.want)
+		}
+	}
+}
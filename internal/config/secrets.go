@@ -0,0 +1,193 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/theirongolddev/cburn/internal/secrets"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Values of [general] secrets_backend.
+const (
+	SecretsPlaintext = "plaintext" // in the config file (the default)
+	SecretsKeychain  = "keychain"  // in the OS credential store
+)
+
+// secretField is a config field holding a secret, under its keychain name.
+type secretField struct {
+	name string
+	ptr  func(*Config) *string
+}
+
+var secretFields = []secretField{
+	{"claude_ai.session_key", func(c *Config) *string { return &c.ClaudeAI.SessionKey }},
+	{"admin_api.api_key", func(c *Config) *string { return &c.AdminAPI.APIKey }},
+	{"daemon.slack_signing_secret", func(c *Config) *string { return &c.Daemon.SlackSigningSecret }},
+	{"digest.smtp_password", func(c *Config) *string { return &c.Digest.SMTPPassword }},
+}
+
+// Redacted returns a copy of c safe to share: secrets and webhook URLs,
+// which often embed a token, are replaced by "REDACTED" when set.
+func (c Config) Redacted() Config {
+	for _, f := range secretFields {
+		if p := f.ptr(&c); *p != "" {
+			*p = redactedValue
+		}
+	}
+	c.Notify.Webhooks = slices.Clone(c.Notify.Webhooks)
+	for i := range c.Notify.Webhooks {
+		c.Notify.Webhooks[i].URL = redactedValue
+	}
+	c.Daemon.Sinks = slices.Clone(c.Daemon.Sinks)
+	for i := range c.Daemon.Sinks {
+		if c.Daemon.Sinks[i].URL != "" {
+			c.Daemon.Sinks[i].URL = redactedValue
+		}
+	}
+	return c
+}
+
+const redactedValue = "REDACTED"
+
+// keychain caches what this process read from or wrote to the keychain, so
+// repeated Loads don't start a helper process each time. An empty value
+// records a secret known to be absent.
+var keychain = struct {
+	sync.Mutex
+	values map[string]string
+}{values: make(map[string]string)}
+
+// UsesKeychain reports whether secrets are kept in the OS credential store.
+func (c Config) UsesKeychain() bool {
+	return strings.EqualFold(c.General.SecretsBackend, SecretsKeychain)
+}
+
+// loadSecrets fills secret fields left empty in the file from the keychain.
+// A secret still written in the file, say after the keychain refused it,
+// wins.
+func loadSecrets(cfg *Config) {
+	keychain.Lock()
+	defer keychain.Unlock()
+	for _, f := range secretFields {
+		p := f.ptr(cfg)
+		if *p != "" {
+			continue
+		}
+		v, ok := keychain.values[f.name]
+		if !ok {
+			var err error
+			v, err = secrets.Get(f.name)
+			if err != nil && !errors.Is(err, secrets.ErrNotFound) {
+				continue // locked or unavailable: try again on the next Load
+			}
+			keychain.values[f.name] = v
+		}
+		*p = v
+	}
+}
+
+// storeSecrets moves cfg's secrets into the keychain and blanks them in cfg,
+// the copy about to be written to the file. A secret the keychain refuses
+// stays in cfg and so is kept in plaintext rather than lost. A secret
+// cleared since it was read from the keychain is removed from it.
+func storeSecrets(cfg *Config) {
+	keychain.Lock()
+	defer keychain.Unlock()
+	for _, f := range secretFields {
+		p := f.ptr(cfg)
+		stored := keychain.values[f.name]
+		switch {
+		case *p == "":
+			if stored != "" && secrets.Delete(f.name) == nil {
+				keychain.values[f.name] = ""
+			}
+		case *p == stored:
+			*p = ""
+		default:
+			if secrets.Set(f.name, *p) == nil {
+				keychain.values[f.name] = *p
+				*p = ""
+			}
+		}
+	}
+}
+
+// forgetKeychainSecrets removes the secrets read from the keychain, after
+// they have been written back to the config file. Any it could not read
+// are left alone.
+func forgetKeychainSecrets() error {
+	keychain.Lock()
+	defer keychain.Unlock()
+	var errs []error
+	for _, f := range secretFields {
+		if keychain.values[f.name] == "" {
+			continue
+		}
+		if err := secrets.Delete(f.name); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		keychain.values[f.name] = ""
+	}
+	return errors.Join(errs...)
+}
+
+// PlaintextSecrets returns the keychain names of the secrets written in the
+// config file itself.
+func PlaintextSecrets() ([]string, error) {
+	data, err := os.ReadFile(Path())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var cfg Config
+	if err := toml.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, f := range secretFields {
+		if *f.ptr(&cfg) != "" {
+			names = append(names, f.name)
+		}
+	}
+	return names, nil
+}
+
+// SetSecretsBackend switches where secrets are kept and moves the current
+// ones there. Moving to the keychain returns the names of any secrets it
+// refused, which stay in the config file.
+func SetSecretsBackend(backend string) ([]string, error) {
+	cfg, err := Load()
+	if err != nil {
+		return nil, err
+	}
+	switch backend {
+	case SecretsKeychain:
+		if err := secrets.Available(); err != nil {
+			return nil, err
+		}
+		cfg.General.SecretsBackend = SecretsKeychain
+		if err := Save(cfg); err != nil {
+			return nil, err
+		}
+		return PlaintextSecrets()
+	case SecretsPlaintext:
+		wasKeychain := cfg.UsesKeychain()
+		cfg.General.SecretsBackend = ""
+		if err := Save(cfg); err != nil {
+			return nil, err
+		}
+		if wasKeychain {
+			return nil, forgetKeychainSecrets()
+		}
+		return nil, nil
+	}
+	return nil, errors.New(`secrets backend must be "keychain" or "plaintext"`)
+}
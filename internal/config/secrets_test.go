@@ -0,0 +1,133 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// fakeSecretTool puts a secret-tool on PATH that files secrets under dir.
+func fakeSecretTool(t *testing.T) string {
+	t.Helper()
+	if runtime.GOOS != "linux" {
+		t.Skip("fakes the secret-tool backend")
+	}
+	bin, store := t.TempDir(), t.TempDir()
+	script := `#!/bin/sh
+store="` + store + `"
+eval "account=\${$#}"
+case "$1" in
+store) cat > "$store/$account" ;;
+lookup) [ -f "$store/$account" ] || exit 1; cat "$store/$account" ;;
+clear) rm -f "$store/$account" ;;
+esac
+`
+	if err := os.WriteFile(filepath.Join(bin, "secret-tool"), []byte(script), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return store
+}
+
+func TestKeychainBackend(t *testing.T) {
+	store := fakeSecretTool(t)
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	keychain.values = make(map[string]string)
+
+	cfg := DefaultConfig()
+	cfg.ClaudeAI.SessionKey = "sk-ant-sid01-secret"
+	if err := Save(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	moved, err := SetSecretsBackend(SecretsKeychain)
+	if err != nil || len(moved) != 0 {
+		t.Fatalf("SetSecretsBackend(keychain) = %v, %v", moved, err)
+	}
+	data, _ := os.ReadFile(Path())
+	if strings.Contains(string(data), "sk-ant-sid01-secret") {
+		t.Errorf("config file still holds the key:\n%s", data)
+	}
+	if b, _ := os.ReadFile(filepath.Join(store, "claude_ai.session_key")); string(b) != "sk-ant-sid01-secret" {
+		t.Errorf("keychain holds %q", b)
+	}
+
+	// A fresh process reads it back from the keychain.
+	keychain.values = make(map[string]string)
+	got, err := Load()
+	if err != nil || got.ClaudeAI.SessionKey != "sk-ant-sid01-secret" {
+		t.Fatalf("Load = %q, %v", got.ClaudeAI.SessionKey, err)
+	}
+
+	// Clearing the key in the config removes it from the keychain.
+	got.ClaudeAI.SessionKey = ""
+	if err := Save(got); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(store, "claude_ai.session_key")); !os.IsNotExist(err) {
+		t.Errorf("cleared key still in keychain: %v", err)
+	}
+
+	// Moving back writes secrets into the file again.
+	got.ClaudeAI.SessionKey = "sk-ant-sid01-other"
+	if err := Save(got); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := SetSecretsBackend(SecretsPlaintext); err != nil {
+		t.Fatal(err)
+	}
+	if names, _ := PlaintextSecrets(); !slices.Equal(names, []string{"claude_ai.session_key"}) {
+		t.Errorf("PlaintextSecrets = %v", names)
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.AdminAPI.APIKey = "sk-ant-admin-123"
+	cfg.Notify.Webhooks = []Webhook{{URL: "https://hooks.slack.com/services/T0/B0/secret"}}
+	cfg.Daemon.Sinks = []Sink{{Type: "file", Path: "/tmp/events.jsonl"}}
+
+	r := cfg.Redacted()
+	if r.AdminAPI.APIKey != redactedValue || r.ClaudeAI.SessionKey != "" {
+		t.Errorf("keys = %q, %q", r.AdminAPI.APIKey, r.ClaudeAI.SessionKey)
+	}
+	if r.Notify.Webhooks[0].URL != redactedValue || r.Daemon.Sinks[0].Path != "/tmp/events.jsonl" {
+		t.Errorf("webhook %q, sink %+v", r.Notify.Webhooks[0].URL, r.Daemon.Sinks[0])
+	}
+	if cfg.Notify.Webhooks[0].URL == redactedValue {
+		t.Error("Redacted modified the original webhooks")
+	}
+}
+
+// TestRedactedAsMap covers what cburn init --json prints: no secret field,
+// webhook URL, or sink URL may survive into the map.
+func TestRedactedAsMap(t *testing.T) {
+	cfg := DefaultConfig()
+	var secrets []string
+	for _, f := range secretFields {
+		v := "plaintext-" + f.name
+		*f.ptr(&cfg) = v
+		secrets = append(secrets, v)
+	}
+	cfg.Notify.Webhooks = []Webhook{{URL: "https://hooks.example.com/webhook-secret"}}
+	cfg.Daemon.Sinks = []Sink{{Type: "webhook", URL: "https://sink.example.com/sink-secret"}}
+	secrets = append(secrets, "webhook-secret", "sink-secret")
+
+	m, err := AsMap(cfg.Redacted())
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range secrets {
+		if strings.Contains(string(out), s) {
+			t.Errorf("output contains %q:\n%s", s, out)
+		}
+	}
+}
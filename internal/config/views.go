@@ -0,0 +1,44 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// View is a named combination of filters, picked with --view or the TUI's
+// view switcher. Zero fields leave that filter as it is.
+type View struct {
+	Name    string `toml:"name"`
+	Days    int    `toml:"days,omitempty"`
+	Project string `toml:"project,omitempty"`
+	Model   string `toml:"model,omitempty"`
+	Search  string `toml:"search,omitempty"` // TUI Sessions search, e.g. "cost>5 model:opus"
+}
+
+// FindView returns the view called name, ignoring case.
+func (c Config) FindView(name string) (View, error) {
+	for _, v := range c.Views {
+		if strings.EqualFold(v.Name, name) {
+			return v, nil
+		}
+	}
+	if len(c.Views) == 0 {
+		return View{}, fmt.Errorf("unknown view %q (none saved; add [[views]] to %s)", name, Path())
+	}
+	names := make([]string, len(c.Views))
+	for i, v := range c.Views {
+		names[i] = v.Name
+	}
+	return View{}, fmt.Errorf("unknown view %q (have: %s)", name, strings.Join(names, ", "))
+}
+
+// SaveView adds v, replacing any view with the same name.
+func (c *Config) SaveView(v View) {
+	for i := range c.Views {
+		if strings.EqualFold(c.Views[i].Name, v.Name) {
+			c.Views[i] = v
+			return
+		}
+	}
+	c.Views = append(c.Views, v)
+}
@@ -0,0 +1,39 @@
+package config
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+)
+
+func TestViews(t *testing.T) {
+	var cfg Config
+	cfg.SaveView(View{Name: "work", Days: 7, Project: "api"})
+	cfg.SaveView(View{Name: "opus", Model: "opus", Search: "cost>5"})
+	cfg.SaveView(View{Name: "Work", Days: 14})
+
+	if len(cfg.Views) != 2 {
+		t.Fatalf("got %d views, want 2 (same name replaces)", len(cfg.Views))
+	}
+	v, err := cfg.FindView("WORK")
+	if err != nil || v.Days != 14 || v.Project != "" {
+		t.Errorf("FindView(WORK) = %+v, %v", v, err)
+	}
+	if _, err := cfg.FindView("nope"); err == nil || !strings.Contains(err.Error(), "Work, opus") {
+		t.Errorf("FindView(nope) error = %v, want the known names", err)
+	}
+
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(cfg); err != nil {
+		t.Fatal(err)
+	}
+	var back Config
+	if _, err := toml.Decode(buf.String(), &back); err != nil {
+		t.Fatal(err)
+	}
+	if len(back.Views) != 2 || back.Views[1].Search != "cost>5" {
+		t.Errorf("round trip = %+v", back.Views)
+	}
+}
@@ -0,0 +1,264 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/claudeai"
+	"github.com/theirongolddev/cburn/internal/config"
+	"github.com/theirongolddev/cburn/internal/model"
+	"github.com/theirongolddev/cburn/internal/notify"
+	"github.com/theirongolddev/cburn/internal/pipeline"
+)
+
+// budgetSteps are the fractions of the monthly budget that trigger a
+// notification the first time they are crossed.
+var budgetSteps = []float64{0.5, 0.8, 1.0}
+
+// rateLimitCheckEvery is how often the daemon polls claude.ai for rate-limit
+// windows when notifications and a session key are configured.
+const rateLimitCheckEvery = 5 * time.Minute
+
+// alerter decides when usage, budget, and rate-limit notifications fire.
+// Budget and rate-limit state is seeded silently on first observation so a
+// daemon restart doesn't repeat alerts that were already sent.
+type alerter struct {
+	hooks         []config.Webhook
+	desktop       bool
+	usageDeltaUSD float64
+	budgetUSD     float64
+	billingDay    int
+	rlThreshold   float64
+	sender        *notify.Sender
+
+	budgetMonth string // start date of the last observed billing period; "" until seeded
+	budgetLevel int    // number of budgetSteps already reached this period
+
+	runawayAlerts bool
+	runawaySeen   map[string]bool // sessions already flagged; nil until seeded
+
+	rlSeeded    bool
+	rlAbove     map[string]bool
+	rlResets    *claudeai.ResetTracker
+	lastRLCheck time.Time
+}
+
+func newAlerter(cfg Config) *alerter {
+	if len(cfg.Webhooks) == 0 && !cfg.Desktop {
+		return nil
+	}
+	threshold := cfg.RateLimitThreshold
+	if threshold <= 0 {
+		threshold = 0.8
+	}
+	return &alerter{
+		hooks:         cfg.Webhooks,
+		desktop:       cfg.Desktop,
+		usageDeltaUSD: cfg.UsageDeltaUSD,
+		budgetUSD:     cfg.MonthlyBudgetUSD,
+		billingDay:    cfg.BillingDay,
+		runawayAlerts: cfg.RunawayAlerts,
+		rlThreshold:   threshold,
+		sender:        notify.NewSender(),
+		rlAbove:       make(map[string]bool),
+		rlResets:      claudeai.NewResetTracker(),
+	}
+}
+
+// usage returns a notification when one poll added at least usageDeltaUSD.
+func (a *alerter) usage(d Delta, snap Snapshot, now time.Time) *notify.Notification {
+	if a.usageDeltaUSD <= 0 || d.EstimatedCostUSD < a.usageDeltaUSD {
+		return nil
+	}
+	return &notify.Notification{
+		Kind:  notify.KindUsage,
+		Title: fmt.Sprintf("cburn: +$%.2f of Claude usage", d.EstimatedCostUSD),
+		Text: fmt.Sprintf("%d API calls and %d tokens since the last poll. Window total: $%.2f.",
+			d.APICalls, d.Tokens, snap.EstimatedCostUSD),
+		At: now,
+		Values: map[string]float64{
+			"delta_cost_usd": d.EstimatedCostUSD,
+			"total_cost_usd": snap.EstimatedCostUSD,
+		},
+	}
+}
+
+// budget returns a notification when billing-period-to-date cost crosses a
+// new step.
+func (a *alerter) budget(monthCost float64, now time.Time) *notify.Notification {
+	if a.budgetUSD <= 0 {
+		return nil
+	}
+	level := budgetLevel(monthCost, a.budgetUSD)
+	periodStart, _ := pipeline.BillingPeriod(a.billingDay, now)
+	if month := periodStart.Format("2006-01-02"); month != a.budgetMonth {
+		// First observation, or a new period reset the spend.
+		a.budgetMonth = month
+		a.budgetLevel = level
+		return nil
+	}
+	if level <= a.budgetLevel {
+		return nil
+	}
+	a.budgetLevel = level
+	pct := budgetSteps[level-1] * 100
+	return &notify.Notification{
+		Kind:  notify.KindBudget,
+		Title: fmt.Sprintf("cburn: %.0f%% of monthly budget used", pct),
+		Text:  fmt.Sprintf("Estimated cost since %s is $%.2f of your $%.2f budget.", periodStart.Format("Jan 2"), monthCost, a.budgetUSD),
+		At:    now,
+		Values: map[string]float64{
+			"month_cost_usd": monthCost,
+			"budget_usd":     a.budgetUSD,
+		},
+	}
+}
+
+// anomalyNotification reports a day whose spend spiked against the week before.
+func anomalyNotification(an *AnomalyEvent, now time.Time) notify.Notification {
+	return notify.Notification{
+		Kind:  notify.KindAnomaly,
+		Title: fmt.Sprintf("cburn: spend today is %.1fx normal", an.Ratio),
+		Text: fmt.Sprintf("Estimated cost today is $%.2f against a median of $%.2f/day over the last week. A runaway agent loop?",
+			an.CostUSD, an.MedianUSD),
+		At: now,
+		Values: map[string]float64{
+			"cost_usd":   an.CostUSD,
+			"median_usd": an.MedianUSD,
+		},
+	}
+}
+
+// runaways returns one notification per session that newly looks like a
+// runaway agent.
+func (a *alerter) runaways(sessions []model.SessionStats, now time.Time) []notify.Notification {
+	if !a.runawayAlerts {
+		return nil
+	}
+	seeded := a.runawaySeen != nil
+	if !seeded {
+		a.runawaySeen = make(map[string]bool)
+	}
+	var out []notify.Notification
+	for _, s := range sessions {
+		why := pipeline.RunawayReason(s)
+		if why == "" || a.runawaySeen[s.SessionID] {
+			continue
+		}
+		a.runawaySeen[s.SessionID] = true
+		if !seeded {
+			continue
+		}
+		out = append(out, notify.Notification{
+			Kind:  notify.KindRunaway,
+			Title: "cburn: possible runaway agent in " + s.Project,
+			Text:  fmt.Sprintf("Session %s: %s, $%.2f so far.", s.SessionID, why, s.EstimatedCost),
+			At:    now,
+			Values: map[string]float64{
+				"api_calls": float64(s.APICalls),
+				"cost_usd":  s.EstimatedCost,
+			},
+		})
+	}
+	return out
+}
+
+func budgetLevel(cost, budget float64) int {
+	level := 0
+	for _, step := range budgetSteps {
+		if cost >= step*budget {
+			level++
+		}
+	}
+	return level
+}
+
+// rateLimits returns one notification per window that rose above the
+// threshold, and one per exhausted window that has since reset.
+func (a *alerter) rateLimits(u *claudeai.ParsedUsage, now time.Time) []notify.Notification {
+	out := a.resetNotifications(a.rlResets.Observe(u, now), now)
+	for _, nw := range u.Windows() {
+		above := nw.Window.Pct >= a.rlThreshold
+		was := a.rlAbove[nw.Label]
+		a.rlAbove[nw.Label] = above
+		if !above || was || !a.rlSeeded {
+			continue
+		}
+		text := fmt.Sprintf("%s window is at %.0f%%.", nw.Label, nw.Window.Pct*100)
+		if !nw.Window.ResetsAt.IsZero() {
+			text += " Resets " + nw.Window.ResetsAt.Local().Format("Mon 3:04 PM") + "."
+		}
+		out = append(out, notify.Notification{
+			Kind:   notify.KindRateLimit,
+			Title:  "cburn: rate limit warning",
+			Text:   text,
+			At:     now,
+			Values: map[string]float64{"utilization": nw.Window.Pct},
+		})
+	}
+	a.rlSeeded = true
+	return out
+}
+
+func (a *alerter) resetNotifications(resets []claudeai.WindowReset, now time.Time) []notify.Notification {
+	out := make([]notify.Notification, 0, len(resets))
+	for _, r := range resets {
+		out = append(out, notify.Notification{
+			Kind:  notify.KindRLReset,
+			Title: "cburn: rate limit reset",
+			Text:  r.Text(),
+			At:    now,
+		})
+	}
+	return out
+}
+
+// checkRateLimits fetches claude.ai usage at most every rateLimitCheckEvery.
+// Resets of exhausted windows are reported on every call, at their reported
+// reset time, without waiting for the next fetch.
+func (a *alerter) checkRateLimits(sessionKey, orgID string, now time.Time) []notify.Notification {
+	due := a.resetNotifications(a.rlResets.Due(now), now)
+	if sessionKey == "" || now.Sub(a.lastRLCheck) < rateLimitCheckEvery {
+		return due
+	}
+	a.lastRLCheck = now
+
+	client := claudeai.NewClient(sessionKey)
+	if client == nil {
+		return due
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	data := client.WithCache(pipeline.CacheDir()).FetchAll(ctx, orgID)
+	if data.Usage == nil || data.Stale {
+		if data.Error != nil {
+			log.Printf("cburn daemon rate-limit fetch: %v", data.Error)
+		}
+		return due
+	}
+	return append(due, a.rateLimits(data.Usage, now)...)
+}
+
+// dispatch delivers notifications in the background so slow webhooks never
+// delay polling.
+func (a *alerter) dispatch(ns []notify.Notification) {
+	if len(ns) == 0 {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		for _, n := range ns {
+			for _, err := range a.sender.SendAll(ctx, a.hooks, n) {
+				log.Printf("cburn daemon %v", err)
+			}
+			if a.desktop {
+				if err := notify.Desktop(ctx, n); err != nil {
+					log.Printf("cburn daemon desktop notification: %v", err)
+				}
+			}
+		}
+	}()
+}
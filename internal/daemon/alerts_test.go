@@ -0,0 +1,110 @@
+package daemon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/claudeai"
+	"github.com/theirongolddev/cburn/internal/config"
+	"github.com/theirongolddev/cburn/internal/model"
+	"github.com/theirongolddev/cburn/internal/notify"
+)
+
+func TestAlerterBudgetCrossings(t *testing.T) {
+	a := newAlerter(Config{Webhooks: []config.Webhook{{URL: "http://x"}}, MonthlyBudgetUSD: 100})
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	if n := a.budget(60, now); n != nil {
+		t.Fatalf("first observation should seed silently, got %+v", n)
+	}
+	if n := a.budget(70, now); n != nil {
+		t.Fatalf("no new step crossed, got %+v", n)
+	}
+	n := a.budget(85, now)
+	if n == nil || n.Values["month_cost_usd"] != 85 {
+		t.Fatalf("expected 80%% crossing, got %+v", n)
+	}
+	if n := a.budget(90, now); n != nil {
+		t.Fatalf("80%% already reported, got %+v", n)
+	}
+
+	// New month: spend resets and is re-seeded.
+	next := time.Date(2026, 4, 1, 0, 5, 0, 0, time.UTC)
+	if n := a.budget(1, next); n != nil {
+		t.Fatalf("new month should seed silently, got %+v", n)
+	}
+	if n := a.budget(55, next); n == nil {
+		t.Fatal("expected 50% crossing in the new month")
+	}
+}
+
+func TestAlerterRateLimitEdges(t *testing.T) {
+	a := newAlerter(Config{Webhooks: []config.Webhook{{URL: "http://x"}}, RateLimitThreshold: 0.8})
+	now := time.Now()
+	usage := func(pct float64) *claudeai.ParsedUsage {
+		return &claudeai.ParsedUsage{FiveHour: &claudeai.ParsedWindow{Pct: pct}}
+	}
+
+	if ns := a.rateLimits(usage(0.9), now); len(ns) != 0 {
+		t.Fatalf("first check should seed silently, got %d", len(ns))
+	}
+	if ns := a.rateLimits(usage(0.5), now); len(ns) != 0 {
+		t.Fatalf("below threshold, got %d", len(ns))
+	}
+	if ns := a.rateLimits(usage(0.85), now); len(ns) != 1 {
+		t.Fatalf("rising edge should fire once, got %d", len(ns))
+	}
+	if ns := a.rateLimits(usage(0.95), now); len(ns) != 0 {
+		t.Fatalf("still above, should not repeat, got %d", len(ns))
+	}
+}
+
+func TestAlerterRateLimitReset(t *testing.T) {
+	a := newAlerter(Config{Desktop: true})
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	exhausted := &claudeai.ParsedUsage{FiveHour: &claudeai.ParsedWindow{Pct: 1, ResetsAt: now.Add(time.Hour)}}
+
+	a.rateLimits(exhausted, now)
+	a.lastRLCheck = now // no session key needed: resets come from the tracker
+	if ns := a.checkRateLimits("", "", now.Add(30*time.Minute)); len(ns) != 0 {
+		t.Fatalf("before reset, got %+v", ns)
+	}
+	ns := a.checkRateLimits("", "", now.Add(time.Hour))
+	if len(ns) != 1 || ns[0].Kind != notify.KindRLReset {
+		t.Fatalf("at reset, got %+v", ns)
+	}
+	if ns := a.checkRateLimits("", "", now.Add(2*time.Hour)); len(ns) != 0 {
+		t.Fatalf("reset repeated: %+v", ns)
+	}
+}
+
+func TestAlerterRunaways(t *testing.T) {
+	a := newAlerter(Config{Desktop: true, RunawayAlerts: true})
+	now := time.Now()
+	loop := model.SessionStats{SessionID: "loop", Project: "app", UserMessages: 1, APICalls: 40, RepeatedCalls: 15}
+	calm := model.SessionStats{SessionID: "calm", Project: "app", UserMessages: 10, APICalls: 40}
+
+	if ns := a.runaways([]model.SessionStats{loop, calm}, now); len(ns) != 0 {
+		t.Fatalf("first poll should seed silently, got %+v", ns)
+	}
+	calm.RepeatedCalls = 10
+	ns := a.runaways([]model.SessionStats{loop, calm}, now)
+	if len(ns) != 1 || ns[0].Kind != notify.KindRunaway {
+		t.Fatalf("new runaway = %+v, want one runaway notification", ns)
+	}
+	if ns := a.runaways([]model.SessionStats{loop, calm}, now); len(ns) != 0 {
+		t.Fatalf("runaway repeated: %+v", ns)
+	}
+
+	off := newAlerter(Config{Desktop: true})
+	off.runaways(nil, now)
+	if ns := off.runaways([]model.SessionStats{loop}, now); len(ns) != 0 {
+		t.Fatalf("runaway alerts off, got %+v", ns)
+	}
+}
+
+func TestNewAlerterDisabledWithoutWebhooks(t *testing.T) {
+	if a := newAlerter(Config{MonthlyBudgetUSD: 10}); a != nil {
+		t.Fatal("expected nil alerter without webhooks")
+	}
+}
@@ -0,0 +1,36 @@
+package daemon
+
+import (
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/model"
+	"github.com/theirongolddev/cburn/internal/pipeline"
+)
+
+// AnomalyEvent describes the day behind an anomaly event: spend that reached
+// pipeline.AnomalyFactor times the median of the 7 days before.
+type AnomalyEvent struct {
+	Date      string  `json:"date"` // local, YYYY-MM-DD
+	CostUSD   float64 `json:"cost_usd"`
+	MedianUSD float64 `json:"median_usd"`
+	Ratio     float64 `json:"ratio"`
+}
+
+// anomalyWatch raises today's cost anomaly at most once per day.
+type anomalyWatch struct {
+	day string // date of the last anomaly raised
+}
+
+// observe returns today's anomaly the first time a poll sees it.
+func (w *anomalyWatch) observe(sessions []model.SessionStats, now time.Time) *AnomalyEvent {
+	a, ok := pipeline.TodayAnomaly(sessions, now)
+	if !ok {
+		return nil
+	}
+	day := a.Date.Format("2006-01-02")
+	if day == w.day {
+		return nil
+	}
+	w.day = day
+	return &AnomalyEvent{Date: day, CostUSD: a.Cost, MedianUSD: a.Median, Ratio: a.Ratio()}
+}
@@ -0,0 +1,33 @@
+package daemon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+func TestAnomalyWatchOncePerDay(t *testing.T) {
+	now := time.Date(2026, 3, 20, 15, 0, 0, 0, time.Local)
+	day := func(back int, cost float64) model.SessionStats {
+		at := now.AddDate(0, 0, -back).Add(-time.Hour)
+		return model.SessionStats{StartTime: at, EndTime: at, EstimatedCost: cost}
+	}
+	var sessions []model.SessionStats
+	for back := 1; back <= 7; back++ {
+		sessions = append(sessions, day(back, 2))
+	}
+
+	var w anomalyWatch
+	if an := w.observe(append(sessions, day(0, 3)), now); an != nil {
+		t.Fatalf("normal day raised %+v", an)
+	}
+	sessions = append(sessions, day(0, 10))
+	an := w.observe(sessions, now)
+	if an == nil || an.Date != "2026-03-20" || an.Ratio != 5 {
+		t.Fatalf("anomaly = %+v, want 5x on 2026-03-20", an)
+	}
+	if an := w.observe(sessions, now.Add(time.Minute)); an != nil {
+		t.Fatalf("anomaly repeated the same day: %+v", an)
+	}
+}
@@ -0,0 +1,42 @@
+package daemon
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/calendar"
+)
+
+// handleCalendar serves the heavy-usage sessions of the last poll as an
+// iCalendar feed a calendar app can subscribe to. ?days= widens or narrows
+// the window (default: the daemon's --days) and ?min_cost= overrides the
+// configured threshold a session's cost must exceed.
+func (s *Service) handleCalendar(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	sessions, days, minCost := s.lastSessions, s.cfg.Days, s.cfg.CalendarMinCostUSD
+	s.mu.RUnlock()
+
+	q := r.URL.Query()
+	if raw := q.Get("days"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			http.Error(w, "days must be a positive whole number", http.StatusBadRequest)
+			return
+		}
+		days = n
+	}
+	if raw := q.Get("min_cost"); raw != "" {
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil || v < 0 {
+			http.Error(w, "min_cost must be a non-negative number", http.StatusBadRequest)
+			return
+		}
+		minCost = v
+	}
+
+	now := time.Now()
+	blocks := calendar.Blocks(sessions, minCost, now.AddDate(0, 0, -days), now)
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	_ = calendar.Write(w, blocks, now)
+}
@@ -0,0 +1,42 @@
+package daemon
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+func TestCalendarFeed(t *testing.T) {
+	now := time.Now()
+	s := New(Config{DataDir: ".", Days: 7, CalendarMinCostUSD: 5})
+	s.lastSessions = []model.SessionStats{
+		{SessionID: "heavy", Project: "app", StartTime: now.Add(-2 * time.Hour), EndTime: now.Add(-time.Hour), EstimatedCost: 9},
+		{SessionID: "light", Project: "app", StartTime: now.Add(-2 * time.Hour), EndTime: now.Add(-time.Hour), EstimatedCost: 2},
+		{SessionID: "old", Project: "app", StartTime: now.AddDate(0, 0, -20), EndTime: now.AddDate(0, 0, -20), EstimatedCost: 40},
+	}
+	get := func(url string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		s.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+		return rec
+	}
+
+	rec := get("/v1/calendar.ics")
+	if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/calendar") {
+		t.Fatalf("status %d, content type %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	if body := rec.Body.String(); !strings.Contains(body, "UID:heavy@cburn") || strings.Contains(body, "UID:light@") || strings.Contains(body, "UID:old@") {
+		t.Errorf("default feed should hold only heavy:\n%s", body)
+	}
+
+	body := get("/v1/calendar.ics?days=30&min_cost=1").Body.String()
+	if strings.Count(body, "BEGIN:VEVENT") != 3 {
+		t.Errorf("days=30&min_cost=1 should hold all three sessions:\n%s", body)
+	}
+	if rec := get("/v1/calendar.ics?days=0"); rec.Code != http.StatusBadRequest {
+		t.Errorf("days=0: status %d, want 400", rec.Code)
+	}
+}
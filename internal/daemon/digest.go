@@ -0,0 +1,80 @@
+package daemon
+
+import (
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/claudeai"
+	"github.com/theirongolddev/cburn/internal/digest"
+	"github.com/theirongolddev/cburn/internal/model"
+	"github.com/theirongolddev/cburn/internal/pipeline"
+)
+
+// digestRetryEvery spaces out attempts after a digest email fails, so a
+// wrong SMTP password doesn't cost a login attempt on every poll.
+const digestRetryEvery = time.Hour
+
+// digestMailer sends the previous day's digest once a day, at or after the
+// configured hour. The last day mailed is kept in a stamp file so a daemon
+// restart doesn't send it again.
+type digestMailer struct {
+	stampPath   string
+	lastAttempt time.Time
+}
+
+func newDigestMailer() *digestMailer {
+	return &digestMailer{stampPath: filepath.Join(pipeline.CacheDir(), "digest-sent")}
+}
+
+// due returns the day whose digest should go out now, if it hasn't yet.
+func (m *digestMailer) due(hour int, now time.Time) (time.Time, bool) {
+	if now.Hour() < hour || now.Sub(m.lastAttempt) < digestRetryEvery {
+		return time.Time{}, false
+	}
+	day := time.Date(now.Year(), now.Month(), now.Day()-1, 0, 0, 0, 0, now.Location())
+	data, _ := os.ReadFile(m.stampPath)
+	if strings.TrimSpace(string(data)) == day.Format("2006-01-02") {
+		return time.Time{}, false
+	}
+	return day, true
+}
+
+// markSent records that day's digest went out.
+func (m *digestMailer) markSent(day time.Time) error {
+	if err := os.MkdirAll(filepath.Dir(m.stampPath), 0o750); err != nil {
+		return err
+	}
+	return os.WriteFile(m.stampPath, []byte(day.Format("2006-01-02")+"\n"), 0o600)
+}
+
+// maybeSendDigest mails the digest when one is due. Rate limits are fetched
+// and the mail is sent in the background so polling never waits on them.
+func (s *Service) maybeSendDigest(sessions []model.SessionStats, now time.Time) {
+	day, ok := s.digest.due(s.cfg.DigestHour, now)
+	if !ok {
+		return
+	}
+	s.digest.lastAttempt = now
+	d := digest.Build(sessions, day, now)
+	mail, sessionKey, orgID := *s.cfg.Digest, s.cfg.SessionKey, s.cfg.OrgID
+
+	go func() {
+		if client := claudeai.NewClient(sessionKey); client != nil {
+			ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+			data := client.WithCache(pipeline.CacheDir()).FetchAll(ctx, orgID)
+			cancel()
+			d.SetRateLimits(data.Usage, data.Error)
+		}
+		if err := mail.Send(d); err != nil {
+			log.Printf("cburn daemon digest email: %v", err)
+			return
+		}
+		if err := s.digest.markSent(day); err != nil {
+			log.Printf("cburn daemon digest stamp: %v", err)
+		}
+	}()
+}
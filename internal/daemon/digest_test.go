@@ -0,0 +1,41 @@
+package daemon
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDigestMailerDue(t *testing.T) {
+	m := &digestMailer{stampPath: filepath.Join(t.TempDir(), "digest-sent")}
+	morning := time.Date(2026, 3, 20, 7, 59, 0, 0, time.Local)
+	if _, ok := m.due(8, morning); ok {
+		t.Fatal("digest due before its hour")
+	}
+
+	day, ok := m.due(8, morning.Add(time.Minute))
+	if !ok || !day.Equal(time.Date(2026, 3, 19, 0, 0, 0, 0, time.Local)) {
+		t.Fatalf("due = %v, %v; want the day before", day, ok)
+	}
+
+	// A failed send waits an hour before trying again.
+	m.lastAttempt = morning.Add(time.Minute)
+	if _, ok := m.due(8, morning.Add(30*time.Minute)); ok {
+		t.Error("retried a failed digest within the hour")
+	}
+	if _, ok := m.due(8, morning.Add(61*time.Minute)); !ok {
+		t.Error("failed digest never retried")
+	}
+
+	// Once sent, not again that day, even after a restart.
+	if err := m.markSent(day); err != nil {
+		t.Fatal(err)
+	}
+	restarted := &digestMailer{stampPath: m.stampPath}
+	if _, ok := restarted.due(8, morning.Add(3*time.Hour)); ok {
+		t.Error("digest sent twice for the same day")
+	}
+	if _, ok := restarted.due(8, morning.AddDate(0, 0, 1).Add(time.Hour)); !ok {
+		t.Error("next day's digest not due")
+	}
+}
@@ -0,0 +1,261 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/model"
+	"github.com/theirongolddev/cburn/internal/pipeline"
+)
+
+// grafanaPrefix is where the Grafana JSON datasource is served: a data
+// source URL of http://127.0.0.1:8787/v1/grafana.
+const grafanaPrefix = "/v1/grafana/"
+
+// grafanaMaxDays bounds a query's range, since answers hold a point per
+// day: an accidental range from the epoch would build tens of thousands.
+const grafanaMaxDays = 3660
+
+// grafanaMetrics are the targets /search offers. daily_* are one series of
+// per-day totals; model_* are one series per model.
+var grafanaMetrics = []string{
+	"daily_cost", "daily_tokens", "daily_sessions", "daily_prompts", "daily_api_calls",
+	"model_cost", "model_tokens", "model_api_calls",
+}
+
+// GrafanaQuery is the body Grafana posts to /v1/grafana/query.
+type GrafanaQuery struct {
+	Range   GrafanaRange    `json:"range"`
+	Targets []GrafanaTarget `json:"targets"`
+}
+
+// GrafanaRange is a query's time range.
+type GrafanaRange struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// GrafanaTarget is one metric requested by a panel. Type is "timeserie"
+// (the default) or "table".
+type GrafanaTarget struct {
+	Target string `json:"target"`
+	RefID  string `json:"refId,omitempty"`
+	Type   string `json:"type,omitempty"`
+}
+
+// GrafanaSeries is a time series answer: [value, unix milliseconds] pairs,
+// one per local day, oldest first.
+type GrafanaSeries struct {
+	Target     string       `json:"target"`
+	Datapoints [][2]float64 `json:"datapoints"`
+}
+
+// GrafanaTable is a table answer: per day for daily_* targets, per model
+// over the whole range for model_* targets.
+type GrafanaTable struct {
+	Type    string          `json:"type"` // always "table"
+	Columns []GrafanaColumn `json:"columns"`
+	Rows    [][]any         `json:"rows"`
+}
+
+// GrafanaColumn is a GrafanaTable column.
+type GrafanaColumn struct {
+	Text string `json:"text"`
+	Type string `json:"type"` // time, string, or number
+}
+
+// handleGrafana serves the Grafana JSON (SimpleJSON) datasource contract
+// over the sessions from the last poll: GET / for "Save & test", /search
+// for the metric names, and /query for their values.
+func (s *Service) handleGrafana(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	sessions := s.lastSessions
+	s.mu.RUnlock()
+
+	switch strings.TrimPrefix(r.URL.Path, grafanaPrefix) {
+	case "":
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = w.Write([]byte("ok\n"))
+	case "search":
+		// The body is {"target": "..."}; it filters the names.
+		var req struct {
+			Target string `json:"target"`
+		}
+		if r.Method == http.MethodPost {
+			_ = json.NewDecoder(io.LimitReader(r.Body, 64<<10)).Decode(&req)
+		}
+		names := []string{}
+		for _, m := range grafanaMetrics {
+			if strings.Contains(m, req.Target) {
+				names = append(names, m)
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(names)
+	case "query":
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var q GrafanaQuery
+		if err := json.NewDecoder(io.LimitReader(r.Body, 1<<20)).Decode(&q); err != nil {
+			http.Error(w, "bad request: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		out, err := grafanaAnswer(sessions, q)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(out)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// grafanaAnswer answers every target of a query, in order.
+func grafanaAnswer(sessions []model.SessionStats, q GrafanaQuery) ([]any, error) {
+	from, to := q.Range.From, q.Range.To
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if !from.Before(to) {
+		return nil, fmt.Errorf("range.from must be before range.to")
+	}
+	if to.Sub(from) > grafanaMaxDays*24*time.Hour {
+		return nil, fmt.Errorf("range is longer than %d days", grafanaMaxDays)
+	}
+
+	out := []any{}
+	for _, t := range q.Targets {
+		if !slices.Contains(grafanaMetrics, t.Target) {
+			return nil, fmt.Errorf("unknown target %q (have %s)", t.Target, strings.Join(grafanaMetrics, ", "))
+		}
+		group, metric, _ := strings.Cut(t.Target, "_")
+		table := t.Type == "table"
+		switch {
+		case group == "daily" && table:
+			out = append(out, dailyTable(sessions, from, to, metric))
+		case group == "daily":
+			out = append(out, dailySeries(sessions, from, to, t.Target, metric))
+		case table:
+			out = append(out, modelTable(sessions, from, to, metric))
+		default:
+			for _, series := range modelSeries(sessions, from, to, metric) {
+				out = append(out, series)
+			}
+		}
+	}
+	return out, nil
+}
+
+func dailySeries(sessions []model.SessionStats, from, to time.Time, target, metric string) GrafanaSeries {
+	days := pipeline.AggregateDays(sessions, from, to)
+	series := GrafanaSeries{Target: target, Datapoints: make([][2]float64, 0, len(days))}
+	for i := len(days) - 1; i >= 0; i-- { // AggregateDays is newest first
+		series.Datapoints = append(series.Datapoints, [2]float64{dailyValue(days[i], metric), float64(days[i].Date.UnixMilli())})
+	}
+	return series
+}
+
+func dailyTable(sessions []model.SessionStats, from, to time.Time, metric string) GrafanaTable {
+	days := pipeline.AggregateDays(sessions, from, to)
+	table := GrafanaTable{
+		Type:    "table",
+		Columns: []GrafanaColumn{{"Time", "time"}, {metric, "number"}},
+		Rows:    make([][]any, 0, len(days)),
+	}
+	for i := len(days) - 1; i >= 0; i-- {
+		table.Rows = append(table.Rows, []any{days[i].Date.UnixMilli(), dailyValue(days[i], metric)})
+	}
+	return table
+}
+
+func dailyValue(d model.DailyStats, metric string) float64 {
+	switch metric {
+	case "cost":
+		return d.EstimatedCost
+	case "tokens":
+		return float64(d.InputTokens + d.OutputTokens + d.CacheCreation5m + d.CacheCreation1h)
+	case "sessions":
+		return float64(d.Sessions)
+	case "prompts":
+		return float64(d.Prompts)
+	}
+	return float64(d.APICalls)
+}
+
+// modelSeries returns a series per model, named after it, with a point for
+// every day AggregateDays returns so stacked panels line up.
+func modelSeries(sessions []model.SessionStats, from, to time.Time, metric string) []GrafanaSeries {
+	var days []time.Time
+	f := from.Local()
+	for d := time.Date(f.Year(), f.Month(), f.Day(), 0, 0, 0, 0, time.Local); !d.After(to); d = d.AddDate(0, 0, 1) {
+		days = append(days, d)
+	}
+	index := make(map[string]int, len(days))
+	for i, d := range days {
+		index[d.Format("2006-01-02")] = i
+	}
+
+	values := make(map[string][]float64)
+	for _, s := range pipeline.FilterByTime(sessions, from, to) {
+		i, ok := index[s.StartTime.Local().Format("2006-01-02")]
+		if !ok {
+			continue
+		}
+		for name, mu := range s.Models {
+			if values[name] == nil {
+				values[name] = make([]float64, len(days))
+			}
+			values[name][i] += modelValue(model.ModelStats{
+				APICalls: mu.APICalls, InputTokens: mu.InputTokens, OutputTokens: mu.OutputTokens,
+				CacheCreation5m: mu.CacheCreation5mTokens, CacheCreation1h: mu.CacheCreation1hTokens,
+				EstimatedCost: mu.EstimatedCost,
+			}, metric)
+		}
+	}
+
+	out := make([]GrafanaSeries, 0, len(values))
+	for _, ms := range pipeline.AggregateModels(sessions, from, to) { // by cost, highest first
+		vals := values[ms.Model]
+		if vals == nil {
+			continue
+		}
+		series := GrafanaSeries{Target: ms.Model, Datapoints: make([][2]float64, len(days))}
+		for i, d := range days {
+			series.Datapoints[i] = [2]float64{vals[i], float64(d.UnixMilli())}
+		}
+		out = append(out, series)
+	}
+	return out
+}
+
+func modelTable(sessions []model.SessionStats, from, to time.Time, metric string) GrafanaTable {
+	models := pipeline.AggregateModels(sessions, from, to)
+	table := GrafanaTable{
+		Type:    "table",
+		Columns: []GrafanaColumn{{"Model", "string"}, {metric, "number"}},
+		Rows:    make([][]any, 0, len(models)),
+	}
+	for _, ms := range models {
+		table.Rows = append(table.Rows, []any{ms.Model, modelValue(ms, metric)})
+	}
+	return table
+}
+
+func modelValue(ms model.ModelStats, metric string) float64 {
+	switch metric {
+	case "cost":
+		return ms.EstimatedCost
+	case "tokens":
+		return float64(ms.InputTokens + ms.OutputTokens + ms.CacheCreation5m + ms.CacheCreation1h)
+	}
+	return float64(ms.APICalls)
+}
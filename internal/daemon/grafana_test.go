@@ -0,0 +1,96 @@
+package daemon
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+func TestGrafanaDatasource(t *testing.T) {
+	day := time.Date(2025, 6, 2, 12, 0, 0, 0, time.Local)
+	usage := func(cost float64) *model.ModelUsage {
+		return &model.ModelUsage{APICalls: 1, InputTokens: 100, EstimatedCost: cost}
+	}
+	s := New(Config{DataDir: "."})
+	s.lastSessions = []model.SessionStats{
+		{SessionID: "a", StartTime: day, EstimatedCost: 3, APICalls: 2, UserMessages: 4,
+			Models: map[string]*model.ModelUsage{"claude-opus-4-6": usage(2), "claude-haiku-4-5": usage(1)}},
+		{SessionID: "b", StartTime: day.AddDate(0, 0, 1), EstimatedCost: 5, APICalls: 1, UserMessages: 1,
+			Models: map[string]*model.ModelUsage{"claude-opus-4-6": usage(5)}},
+	}
+	h := s.Handler()
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return rec
+	}
+
+	if rec := do(http.MethodGet, "/v1/grafana/", ""); rec.Code != http.StatusOK {
+		t.Fatalf("connection test: status %d", rec.Code)
+	}
+
+	var names []string
+	if err := json.NewDecoder(do(http.MethodPost, "/v1/grafana/search", `{"target":"model"}`).Body).Decode(&names); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(names, ",") != "model_cost,model_tokens,model_api_calls" {
+		t.Errorf("search = %v", names)
+	}
+
+	// Three local days: June 1 (empty), 2, and 3
+	from, to := time.Date(2025, 6, 1, 0, 0, 0, 0, time.Local), time.Date(2025, 6, 4, 0, 0, 0, 0, time.Local)
+	body := `{"range":{"from":"` + from.UTC().Format(time.RFC3339) + `","to":"` + to.UTC().Format(time.RFC3339) + `"},` +
+		`"targets":[{"target":"daily_cost","refId":"A"},{"target":"model_cost","refId":"B"},{"target":"model_api_calls","type":"table"}]}`
+	rec := do(http.MethodPost, "/v1/grafana/query", body)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("query: status %d: %s", rec.Code, rec.Body)
+	}
+	var out []struct {
+		Target     string       `json:"target"`
+		Datapoints [][2]float64 `json:"datapoints"`
+		Type       string       `json:"type"`
+		Rows       [][]any      `json:"rows"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+	if len(out) != 4 {
+		t.Fatalf("answers = %d, want daily_cost, two model series, and a table", len(out))
+	}
+	values := func(dp [][2]float64) []float64 {
+		var v []float64
+		for _, p := range dp {
+			v = append(v, p[0])
+		}
+		return v
+	}
+	if got := values(out[0].Datapoints); out[0].Target != "daily_cost" || !slices.Equal(got, []float64{0, 3, 5, 0}) {
+		t.Errorf("daily_cost = %s %v, want [0 3 5 0] through June 4's midnight", out[0].Target, got)
+	}
+	if ms := int64(out[0].Datapoints[1][1]); ms != time.Date(2025, 6, 2, 0, 0, 0, 0, time.Local).UnixMilli() {
+		t.Errorf("second point at %d, want June 2's local midnight", ms)
+	}
+	if got := values(out[1].Datapoints); out[1].Target != "claude-opus-4-6" || !slices.Equal(got, []float64{0, 2, 5, 0}) {
+		t.Errorf("first model series = %s %v, want claude-opus-4-6 [0 2 5 0]", out[1].Target, got)
+	}
+	if got := values(out[2].Datapoints); out[2].Target != "claude-haiku-4-5" || !slices.Equal(got, []float64{0, 1, 0, 0}) {
+		t.Errorf("second model series = %s %v, want claude-haiku-4-5 [0 1 0 0]", out[2].Target, got)
+	}
+	if out[3].Type != "table" || len(out[3].Rows) != 2 || out[3].Rows[0][0] != "claude-opus-4-6" || out[3].Rows[0][1] != 2.0 {
+		t.Errorf("model_api_calls table = %+v", out[3])
+	}
+
+	if rec := do(http.MethodPost, "/v1/grafana/query", `{"targets":[{"target":"bogus"}]}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("unknown target: status %d, want 400", rec.Code)
+	}
+	if rec := do(http.MethodPost, "/v1/grafana/query", `{"range":{"from":"1970-01-01T00:00:00Z"},"targets":[{"target":"model_cost"}]}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("range from the epoch: status %d, want 400", rec.Code)
+	}
+}
@@ -0,0 +1,76 @@
+package daemon
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// Names of the service `cburn daemon install` sets up.
+const (
+	SystemdUnitName = "cburn.service"
+	LaunchdLabel    = "dev.cburn.daemon"
+)
+
+// SystemdUnit renders a systemd user unit that runs exe with args in the
+// foreground and restarts it if it dies. Output goes to the journal.
+func SystemdUnit(exe string, args []string) string {
+	words := make([]string, 0, len(args)+1)
+	for _, w := range append([]string{exe}, args...) {
+		words = append(words, systemdQuote(w))
+	}
+
+	var b strings.Builder
+	b.WriteString("[Unit]\n")
+	b.WriteString("Description=cburn usage daemon\n")
+	b.WriteString("After=network-online.target\n")
+	b.WriteString("\n[Service]\n")
+	fmt.Fprintf(&b, "ExecStart=%s\n", strings.Join(words, " "))
+	b.WriteString("Restart=on-failure\n")
+	b.WriteString("RestartSec=10\n")
+	b.WriteString("\n[Install]\n")
+	b.WriteString("WantedBy=default.target\n")
+	return b.String()
+}
+
+// systemdQuote quotes a word of an ExecStart line. '%' starts a specifier
+// in unit files, so it is doubled.
+func systemdQuote(s string) string {
+	s = strings.ReplaceAll(s, "%", "%%")
+	if s != "" && !strings.ContainsAny(s, " \t\"'\\;$") {
+		return s
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `$$`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// LaunchdPlist renders a launchd agent that starts exe with args at login,
+// keeps it running, and sends its output to logFile.
+func LaunchdPlist(exe string, args []string, logFile string) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">` + "\n")
+	b.WriteString(`<plist version="1.0">` + "\n<dict>\n")
+	plistString(&b, "Label", LaunchdLabel)
+	b.WriteString("\t<key>ProgramArguments</key>\n\t<array>\n")
+	for _, w := range append([]string{exe}, args...) {
+		fmt.Fprintf(&b, "\t\t<string>%s</string>\n", xmlEscape(w))
+	}
+	b.WriteString("\t</array>\n")
+	b.WriteString("\t<key>RunAtLoad</key>\n\t<true/>\n")
+	b.WriteString("\t<key>KeepAlive</key>\n\t<dict>\n\t\t<key>SuccessfulExit</key>\n\t\t<false/>\n\t</dict>\n")
+	plistString(&b, "StandardOutPath", logFile)
+	plistString(&b, "StandardErrorPath", logFile)
+	b.WriteString("</dict>\n</plist>\n")
+	return b.String()
+}
+
+func plistString(b *strings.Builder, key, value string) {
+	fmt.Fprintf(b, "\t<key>%s</key>\n\t<string>%s</string>\n", key, xmlEscape(value))
+}
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
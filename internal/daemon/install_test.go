@@ -0,0 +1,27 @@
+package daemon
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSystemdUnitQuotesExecStart(t *testing.T) {
+	unit := SystemdUnit("/opt/my tools/cburn", []string{"daemon", "--addr=127.0.0.1:9000", "--project=100%"})
+	want := `ExecStart="/opt/my tools/cburn" daemon --addr=127.0.0.1:9000 --project=100%%` + "\n"
+	if !strings.Contains(unit, want) {
+		t.Fatalf("unit missing %q:\n%s", want, unit)
+	}
+}
+
+func TestLaunchdPlistEscapes(t *testing.T) {
+	plist := LaunchdPlist("/usr/local/bin/cburn", []string{"daemon", "--project=a&b"}, "/tmp/cburnd.log")
+	for _, want := range []string{
+		"<string>" + LaunchdLabel + "</string>",
+		"<string>--project=a&amp;b</string>",
+		"<key>StandardErrorPath</key>\n\t<string>/tmp/cburnd.log</string>",
+	} {
+		if !strings.Contains(plist, want) {
+			t.Errorf("plist missing %q:\n%s", want, plist)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package daemon
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// APIVersion is the version of the /v1 HTTP API that OpenAPISpec describes.
+// Additive changes bump the minor version; /v1 never breaks.
+const APIVersion = "1.5.0"
+
+// eventTypes are the values of Event.Type.
+var eventTypes = []string{"snapshot", "usage_delta", "session_started", "session_ended", "anomaly"}
+
+// OpenAPISpec returns the OpenAPI 3.0 description of the daemon's HTTP API,
+// served at /v1/openapi.json. Schemas are generated from the Go types the
+// handlers encode, so the description can't drift from the responses.
+func OpenAPISpec() map[string]any {
+	schemas := map[string]any{}
+	for _, v := range []any{
+		Status{}, Snapshot{}, Delta{}, Event{}, SessionEvent{}, AnomalyEvent{},
+		GrafanaQuery{}, GrafanaRange{}, GrafanaTarget{}, GrafanaSeries{}, GrafanaTable{}, GrafanaColumn{},
+	} {
+		t := reflect.TypeOf(v)
+		schemas[t.Name()] = schemaFor(t, true)
+	}
+	schemas["Event"].(map[string]any)["properties"].(map[string]any)["type"] = map[string]any{
+		"type": "string",
+		"enum": eventTypes,
+	}
+
+	jsonBody := func(desc string, schema map[string]any) map[string]any {
+		return map[string]any{"200": map[string]any{
+			"description": desc,
+			"content":     map[string]any{"application/json": map[string]any{"schema": schema}},
+		}}
+	}
+	ref := func(name string) map[string]any {
+		return map[string]any{"$ref": "#/components/schemas/" + name}
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":       "cburn daemon API",
+			"version":     APIVersion,
+			"description": "Local usage snapshots and events from `cburn daemon`.",
+		},
+		"servers": []any{map[string]any{"url": "http://127.0.0.1:8787"}},
+		"paths": map[string]any{
+			"/healthz": map[string]any{"get": map[string]any{
+				"summary": "Liveness probe",
+				"responses": map[string]any{"200": map[string]any{
+					"description": "The daemon is up",
+					"content":     map[string]any{"text/plain": map[string]any{"schema": map[string]any{"type": "string"}}},
+				}},
+			}},
+			"/v1/status": map[string]any{"get": map[string]any{
+				"summary":   "Current usage snapshot and daemon state",
+				"responses": jsonBody("Daemon status", ref("Status")),
+			}},
+			"/v1/events": map[string]any{"get": map[string]any{
+				"summary":   "Buffered recent events, oldest first",
+				"responses": jsonBody("Recent events", map[string]any{"type": "array", "items": ref("Event")}),
+			}},
+			"/v1/stream": map[string]any{"get": map[string]any{
+				"summary": "Server-Sent Events stream of events",
+				"description": "Each SSE message has `id:` (the event ID), `event:` (its type), and `data:` " +
+					"(the Event as JSON). Comments keep idle connections open. A client resuming from an " +
+					"event still buffered receives the events after it; otherwise it receives one snapshot.",
+				"parameters": []any{
+					map[string]any{"name": "Last-Event-ID", "in": "header", "schema": map[string]any{"type": "integer", "format": "int64"}},
+					map[string]any{"name": "last_event_id", "in": "query", "schema": map[string]any{"type": "integer", "format": "int64"}},
+				},
+				"responses": map[string]any{"200": map[string]any{
+					"description": "Event stream",
+					"content":     map[string]any{"text/event-stream": map[string]any{"schema": map[string]any{"type": "string"}}},
+				}},
+			}},
+			"/v1/slack/command": map[string]any{"post": map[string]any{
+				"summary": "Slack slash command: usage summary",
+				"description": "Answers a Slack slash command (`/cburn today`, `yesterday`, `week`, `month`, or `<n>d`). " +
+					"Requests must carry a valid `X-Slack-Signature` for the configured signing secret; " +
+					"without one configured the endpoint returns 404.",
+				"requestBody": map[string]any{"content": map[string]any{"application/x-www-form-urlencoded": map[string]any{
+					"schema": map[string]any{"type": "object", "properties": map[string]any{"text": map[string]any{"type": "string"}}},
+				}}},
+				"responses": jsonBody("Slack message", map[string]any{"type": "object", "properties": map[string]any{
+					"response_type": map[string]any{"type": "string", "enum": []string{"in_channel", "ephemeral"}},
+					"text":          map[string]any{"type": "string"},
+				}}),
+			}},
+			"/v1/grafana/": map[string]any{"get": map[string]any{
+				"summary": "Grafana JSON datasource: connection test",
+				"description": "Point a Grafana JSON (SimpleJSON) data source at `/v1/grafana`. " +
+					"Values come from the sessions of the last poll, with the daemon's filters.",
+				"responses": map[string]any{"200": map[string]any{
+					"description": "The datasource is up",
+					"content":     map[string]any{"text/plain": map[string]any{"schema": map[string]any{"type": "string"}}},
+				}},
+			}},
+			"/v1/grafana/search": map[string]any{"post": map[string]any{
+				"summary": "Grafana JSON datasource: metric names containing `target`",
+				"requestBody": map[string]any{"content": map[string]any{"application/json": map[string]any{
+					"schema": map[string]any{"type": "object", "properties": map[string]any{"target": map[string]any{"type": "string"}}},
+				}}},
+				"responses": jsonBody("Metric names", map[string]any{
+					"type": "array", "items": map[string]any{"type": "string", "enum": grafanaMetrics},
+				}),
+			}},
+			"/v1/grafana/query": map[string]any{"post": map[string]any{
+				"summary": "Grafana JSON datasource: values for the requested targets",
+				"description": "`daily_*` targets answer with one series of per-day totals; `model_*` targets with a " +
+					"series per model. Targets of type `table` answer with a table per day or per model instead. " +
+					"Ranges over ten years are refused with 400.",
+				"requestBody": map[string]any{"content": map[string]any{"application/json": map[string]any{
+					"schema": ref("GrafanaQuery"),
+				}}},
+				"responses": jsonBody("Series and tables, in target order", map[string]any{
+					"type": "array", "items": map[string]any{"oneOf": []any{ref("GrafanaSeries"), ref("GrafanaTable")}},
+				}),
+			}},
+			"/v1/calendar.ics": map[string]any{"get": map[string]any{
+				"summary": "iCalendar feed of heavy-usage sessions",
+				"description": "One event per session, subagents included, that cost more than `min_cost` " +
+					"(default `[calendar] min_cost_usd`), from its first to last activity. Event UIDs are " +
+					"stable, so subscribed calendars update events in place.",
+				"parameters": []any{
+					map[string]any{"name": "days", "in": "query", "schema": map[string]any{"type": "integer", "minimum": 1}},
+					map[string]any{"name": "min_cost", "in": "query", "schema": map[string]any{"type": "number", "minimum": 0}},
+				},
+				"responses": map[string]any{"200": map[string]any{
+					"description": "iCalendar (RFC 5545) document",
+					"content":     map[string]any{"text/calendar": map[string]any{"schema": map[string]any{"type": "string"}}},
+				}},
+			}},
+			"/v1/openapi.json": map[string]any{"get": map[string]any{
+				"summary":   "This document",
+				"responses": jsonBody("OpenAPI description", map[string]any{"type": "object"}),
+			}},
+		},
+		"components": map[string]any{"schemas": schemas},
+	}
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemaFor maps a Go type to a JSON schema, following encoding/json's
+// field naming. Nested API types become references, unless top is set.
+func schemaFor(t reflect.Type, top bool) map[string]any {
+	switch {
+	case t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case t.Kind() == reflect.Struct && !top && t.PkgPath() == reflect.TypeOf(Status{}).PkgPath():
+		return map[string]any{"$ref": "#/components/schemas/" + t.Name()}
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		props := map[string]any{}
+		var required []string
+		for i := range t.NumField() {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			props[name] = schemaFor(f.Type, false)
+			if !strings.Contains(opts, "omitempty") {
+				required = append(required, name)
+			}
+		}
+		s := map[string]any{"type": "object", "properties": props}
+		if len(required) > 0 {
+			s["required"] = required
+		}
+		return s
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": schemaFor(t.Elem(), false)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": schemaFor(t.Elem(), false)}
+	case reflect.Pointer:
+		return schemaFor(t.Elem(), false)
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int64, reflect.Uint64:
+		return map[string]any{"type": "integer", "format": "int64"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	}
+	return map[string]any{}
+}
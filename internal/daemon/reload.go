@@ -0,0 +1,72 @@
+package daemon
+
+import "slices"
+
+// Reload swaps in cfg while the daemon keeps running, so SSE subscribers
+// stay connected: the polling interval, filters, data directory,
+// notification settings, and sinks all take effect at once, with a poll
+// right away. The listen address, event buffer, and heartbeat are fixed at
+// start and kept. Sinks in the replaced config are closed. Reload may be
+// called from any goroutine; of several calls before Run gets to them, the
+// last wins.
+func (s *Service) Reload(cfg Config) {
+	s.mu.Lock()
+	if s.pending != nil {
+		CloseSinks(s.pending.Sinks)
+	}
+	s.pending = &cfg
+	s.mu.Unlock()
+
+	select {
+	case s.reload <- struct{}{}:
+	default:
+	}
+}
+
+// applyReload installs the config passed to Reload, if any, and reports
+// whether it did. It runs on Run's goroutine, which owns polling, alerts,
+// and sinks.
+func (s *Service) applyReload() bool {
+	s.mu.Lock()
+	if s.pending == nil {
+		s.mu.Unlock()
+		return false
+	}
+	cfg := *s.pending
+	s.pending = nil
+
+	prev := s.cfg
+	cfg.Addr, cfg.EventsBuffer, cfg.Heartbeat = prev.Addr, prev.EventsBuffer, prev.Heartbeat
+	cfg.Interval = normalizeInterval(cfg.Interval)
+	s.cfg = cfg
+
+	// Different filters make a different total, not a usage change: start
+	// over with a snapshot, and relearn which sessions are running.
+	if cfg.DataDir != prev.DataDir || cfg.Days != prev.Days ||
+		cfg.ProjectFilter != prev.ProjectFilter || cfg.ModelFilter != prev.ModelFilter ||
+		cfg.IncludeSubagents != prev.IncludeSubagents || !slices.Equal(cfg.ExtraRoots, prev.ExtraRoots) ||
+		!slices.Equal(cfg.ScanExclude, prev.ScanExclude) {
+		s.hasSnapshot = false
+		s.sessions = newSessionTracker()
+	}
+	s.mu.Unlock()
+
+	alerts := newAlerter(cfg)
+	alerts.carryOver(s.alerts)
+	s.alerts = alerts
+	CloseSinks(prev.Sinks)
+	return true
+}
+
+// carryOver keeps what a previous alerter already notified about, so a
+// reload doesn't repeat budget or rate-limit notifications. Budget progress
+// only carries over while the budget and billing day are unchanged.
+func (a *alerter) carryOver(prev *alerter) {
+	if a == nil || prev == nil {
+		return
+	}
+	if a.budgetUSD == prev.budgetUSD && a.billingDay == prev.billingDay {
+		a.budgetMonth, a.budgetLevel = prev.budgetMonth, prev.budgetLevel
+	}
+	a.rlSeeded, a.rlAbove, a.rlResets, a.lastRLCheck = prev.rlSeeded, prev.rlAbove, prev.rlResets, prev.lastRLCheck
+}
@@ -0,0 +1,38 @@
+package daemon
+
+import (
+	"testing"
+	"time"
+)
+
+type closeCounter struct{ closed int }
+
+func (c *closeCounter) Write(Event) error { return nil }
+func (c *closeCounter) Close() error      { c.closed++; return nil }
+
+func TestReload(t *testing.T) {
+	oldSink, newSink := &closeCounter{}, &closeCounter{}
+	s := New(Config{Addr: "127.0.0.1:9999", Interval: time.Minute, Days: 30, Sinks: []Sink{oldSink}})
+	s.hasSnapshot = true
+
+	if s.applyReload() {
+		t.Fatal("applyReload applied a config without a Reload")
+	}
+	s.Reload(Config{Addr: "0.0.0.0:1", Interval: time.Second, Days: 7, ProjectFilter: "app", Sinks: []Sink{newSink}})
+	if !s.applyReload() {
+		t.Fatal("applyReload ignored the pending config")
+	}
+
+	if s.cfg.Addr != "127.0.0.1:9999" {
+		t.Errorf("Addr = %q, want the address the daemon started with", s.cfg.Addr)
+	}
+	if s.cfg.Interval != 10*time.Second || s.cfg.Days != 7 || s.cfg.ProjectFilter != "app" {
+		t.Errorf("reloaded config = %+v", s.cfg)
+	}
+	if s.hasSnapshot {
+		t.Error("changed filters should start over with a snapshot")
+	}
+	if oldSink.closed != 1 || newSink.closed != 0 {
+		t.Errorf("sinks closed old=%d new=%d, want 1 and 0", oldSink.closed, newSink.closed)
+	}
+}
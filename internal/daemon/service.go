@@ -8,17 +8,25 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/theirongolddev/cburn/internal/config"
+	"github.com/theirongolddev/cburn/internal/digest"
 	"github.com/theirongolddev/cburn/internal/model"
+	"github.com/theirongolddev/cburn/internal/notify"
 	"github.com/theirongolddev/cburn/internal/pipeline"
+	"github.com/theirongolddev/cburn/internal/receipts"
 	"github.com/theirongolddev/cburn/internal/store"
 )
 
 // Config controls the daemon runtime behavior.
 type Config struct {
 	DataDir          string
+	ExtraRoots       []config.ScanRoot // scanned alongside DataDir
+	ScanExclude      []string          // glob patterns skipped in every root
 	Days             int
 	ProjectFilter    string
 	ModelFilter      string
@@ -27,6 +35,33 @@ type Config struct {
 	Interval         time.Duration
 	Addr             string
 	EventsBuffer     int
+	WriteReceipts    bool          // append cost receipts to project dirs for finished sessions
+	Heartbeat        time.Duration // SSE keep-alive comment interval
+
+	// Webhook notifications; all optional.
+	Webhooks           []config.Webhook
+	Desktop            bool    // also show notifications on the desktop
+	UsageDeltaUSD      float64 // per-poll cost that triggers a usage notification
+	RunawayAlerts      bool    // notify when a session starts to look like a runaway agent
+	MonthlyBudgetUSD   float64 // budget for 50/80/100% crossing notifications
+	BillingDay         int     // day of the month the budget period starts (see config.BudgetConfig)
+	SessionKey         string  // claude.ai session key for rate-limit warnings
+	OrgID              string  // preferred claude.ai organization; "" means the first
+	RateLimitThreshold float64 // window utilization (0-1) that triggers a warning
+
+	// Signing secret of the Slack app whose slash command posts to
+	// /v1/slack/command; "" leaves the endpoint off.
+	SlackSigningSecret string
+
+	// Cost at which a session gets an event in /v1/calendar.ics.
+	CalendarMinCostUSD float64
+
+	// Daily digest email; nil disables it.
+	Digest     *digest.SMTP
+	DigestHour int // local hour the previous day's digest goes out
+
+	// Sinks get a copy of every published event; Run closes them.
+	Sinks []Sink
 }
 
 // Snapshot is a compact usage state for status/event payloads.
@@ -60,13 +95,17 @@ func (d Delta) isZero() bool {
 		d.EstimatedCostUSD == 0
 }
 
-// Event is emitted whenever usage snapshot updates.
+// Event is emitted whenever usage snapshot updates, when a session starts
+// or ends (Type session_started or session_ended, with Session set), and
+// when today's spend turns anomalous (Type anomaly, with Anomaly set).
 type Event struct {
-	ID        int64     `json:"id"`
-	Type      string    `json:"type"`
-	Timestamp time.Time `json:"timestamp"`
-	Snapshot  Snapshot  `json:"snapshot"`
-	Delta     Delta     `json:"delta"`
+	ID        int64         `json:"id"`
+	Type      string        `json:"type"`
+	Timestamp time.Time     `json:"timestamp"`
+	Snapshot  Snapshot      `json:"snapshot"`
+	Delta     Delta         `json:"delta"`
+	Session   *SessionEvent `json:"session,omitempty"`
+	Anomaly   *AnomalyEvent `json:"anomaly,omitempty"`
 }
 
 // Status is served at /v1/status.
@@ -99,40 +138,80 @@ type Service struct {
 	nextEventID int64
 	events      []Event
 
+	lastSessions []model.SessionStats // as of the last poll, for /v1/slack/command
+
 	nextSubID int
 	subs      map[int]chan Event
+
+	alerts    *alerter // nil when no webhooks are configured
+	sessions  *sessionTracker
+	anomalies anomalyWatch
+	digest    *digestMailer
+
+	// pending is the config passed to Reload, applied by Run on its next
+	// pass; reload wakes Run up for it.
+	pending *Config
+	reload  chan struct{}
 }
 
 // New returns a new daemon service with the provided config.
 func New(cfg Config) *Service {
-	if cfg.Interval < 2*time.Second {
-		cfg.Interval = 10 * time.Second
-	}
+	cfg.Interval = normalizeInterval(cfg.Interval)
 	if cfg.EventsBuffer < 1 {
 		cfg.EventsBuffer = 200
 	}
 	if cfg.Addr == "" {
 		cfg.Addr = "127.0.0.1:8787"
 	}
+	if cfg.Heartbeat <= 0 {
+		cfg.Heartbeat = 15 * time.Second
+	}
 
+	startedAt := time.Now()
 	return &Service{
 		cfg:       cfg,
-		startedAt: time.Now(),
-		subs:      make(map[int]chan Event),
+		startedAt: startedAt,
+		// Seed event IDs from the start time so they keep increasing across
+		// daemon restarts; a Last-Event-ID from a previous run then falls
+		// outside the buffer and triggers a snapshot resync instead of a
+		// bogus replay.
+		nextEventID: startedAt.UnixMilli() * 1000,
+		subs:        make(map[int]chan Event),
+		alerts:      newAlerter(cfg),
+		sessions:    newSessionTracker(),
+		digest:      newDigestMailer(),
+		reload:      make(chan struct{}, 1),
 	}
 }
 
-// Run starts HTTP endpoints and polling until ctx is canceled.
-func (s *Service) Run(ctx context.Context) error {
+func normalizeInterval(d time.Duration) time.Duration {
+	if d < 2*time.Second {
+		return 10 * time.Second
+	}
+	return d
+}
+
+// Handler returns the HTTP API, as served by Run.
+func (s *Service) Handler() http.Handler {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/healthz", s.handleHealth)
 	mux.HandleFunc("/v1/status", s.handleStatus)
 	mux.HandleFunc("/v1/events", s.handleEvents)
 	mux.HandleFunc("/v1/stream", s.handleStream)
+	mux.HandleFunc("/v1/openapi.json", handleOpenAPI)
+	mux.HandleFunc("/v1/slack/command", s.handleSlackCommand)
+	mux.HandleFunc(grafanaPrefix, s.handleGrafana)
+	mux.HandleFunc("/v1/calendar.ics", s.handleCalendar)
+	return mux
+}
+
+// Run starts HTTP endpoints and polling until ctx is canceled.
+func (s *Service) Run(ctx context.Context) error {
+	defer func() { CloseSinks(s.cfg.Sinks) }()
 
 	server := &http.Server{
 		Addr:              s.cfg.Addr,
-		Handler:           mux,
+		Handler:           s.Handler(),
 		ReadHeaderTimeout: 5 * time.Second,
 	}
 
@@ -157,6 +236,11 @@ func (s *Service) Run(ctx context.Context) error {
 			return server.Shutdown(shutdownCtx)
 		case <-ticker.C:
 			s.pollOnce()
+		case <-s.reload:
+			if s.applyReload() {
+				ticker.Reset(s.cfg.Interval)
+				s.pollOnce()
+			}
 		case err := <-errCh:
 			return fmt.Errorf("daemon http server: %w", err)
 		}
@@ -179,6 +263,12 @@ func (s *Service) pollOnce() {
 	now := time.Now()
 	since := now.AddDate(0, 0, -s.cfg.Days)
 
+	if s.cfg.WriteReceipts {
+		if _, err := receipts.WriteCompleted(sessions, now); err != nil {
+			log.Printf("cburn daemon receipts error: %v", err)
+		}
+	}
+
 	filtered := sessions
 	if s.cfg.ProjectFilter != "" {
 		filtered = pipeline.FilterByProject(filtered, s.cfg.ProjectFilter)
@@ -201,6 +291,7 @@ func (s *Service) pollOnce() {
 
 	s.hasSnapshot = true
 	s.snapshot = snap
+	s.lastSessions = filtered
 	s.lastPollAt = now
 	s.pollCount++
 	s.lastError = ""
@@ -229,28 +320,91 @@ func (s *Service) pollOnce() {
 			publish = true
 		}
 	}
+
+	var sessionEvents []Event
+	for _, c := range s.sessions.observe(filtered, now) {
+		s.nextEventID++
+		sessionEvents = append(sessionEvents, Event{
+			ID:        s.nextEventID,
+			Type:      c.typ,
+			Timestamp: now,
+			Snapshot:  snap,
+			Session:   newSessionEvent(c.session),
+		})
+	}
+	var anomalyEvent *Event
+	if an := s.anomalies.observe(filtered, now); an != nil {
+		s.nextEventID++
+		anomalyEvent = &Event{
+			ID:        s.nextEventID,
+			Type:      "anomaly",
+			Timestamp: now,
+			Snapshot:  snap,
+			Anomaly:   an,
+		}
+	}
 	s.mu.Unlock()
 
 	if publish {
 		s.publishEvent(ev)
 	}
+	for _, se := range sessionEvents {
+		s.publishEvent(se)
+	}
+	if anomalyEvent != nil {
+		s.publishEvent(*anomalyEvent)
+	}
+
+	if s.alerts != nil {
+		var an *AnomalyEvent
+		if anomalyEvent != nil {
+			an = anomalyEvent.Anomaly
+		}
+		s.fireAlerts(filtered, ev, publish && ev.Type == "usage_delta", an, now)
+	}
+	if s.cfg.Digest != nil {
+		s.maybeSendDigest(filtered, now)
+	}
 
 	_ = start
 }
 
+// fireAlerts evaluates webhook conditions after a successful poll.
+func (s *Service) fireAlerts(sessions []model.SessionStats, ev Event, hasDelta bool, an *AnomalyEvent, now time.Time) {
+	var ns []notify.Notification
+	if hasDelta {
+		if n := s.alerts.usage(ev.Delta, ev.Snapshot, now); n != nil {
+			ns = append(ns, *n)
+		}
+	}
+	if an != nil {
+		ns = append(ns, anomalyNotification(an, now))
+	}
+	ns = append(ns, s.alerts.runaways(sessions, now)...)
+
+	periodStart, _ := pipeline.BillingPeriod(s.cfg.BillingDay, now)
+	periodCost := pipeline.Aggregate(sessions, periodStart, now).EstimatedCost
+	if n := s.alerts.budget(periodCost, now); n != nil {
+		ns = append(ns, *n)
+	}
+
+	ns = append(ns, s.alerts.checkRateLimits(s.cfg.SessionKey, s.cfg.OrgID, now)...)
+	s.alerts.dispatch(ns)
+}
+
 func (s *Service) loadSessions() ([]model.SessionStats, error) {
 	if s.cfg.UseCache {
 		cache, err := store.Open(pipeline.CachePath())
 		if err == nil {
 			defer func() { _ = cache.Close() }()
-			cr, loadErr := pipeline.LoadWithCache(s.cfg.DataDir, s.cfg.IncludeSubagents, cache, nil)
+			cr, loadErr := pipeline.LoadWithCache(pipeline.ScanRoots(s.cfg.DataDir, s.cfg.ExtraRoots, s.cfg.ScanExclude), s.cfg.IncludeSubagents, cache, nil)
 			if loadErr == nil {
-				return cr.Sessions, nil
+				return pipeline.WithoutExcluded(cr.Sessions), nil
 			}
 		}
 	}
 
-	result, err := pipeline.Load(s.cfg.DataDir, s.cfg.IncludeSubagents, nil)
+	result, err := pipeline.Load(pipeline.ScanRoots(s.cfg.DataDir, s.cfg.ExtraRoots, s.cfg.ScanExclude), s.cfg.IncludeSubagents, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -296,6 +450,12 @@ func (s *Service) publishEvent(ev Event) {
 		}
 	}
 	s.mu.Unlock()
+
+	for _, sink := range s.cfg.Sinks {
+		if err := sink.Write(ev); err != nil {
+			log.Printf("cburn daemon sink error: %v", err)
+		}
+	}
 }
 
 func (s *Service) snapshotStatus() Status {
@@ -338,6 +498,11 @@ func (s *Service) handleEvents(w http.ResponseWriter, _ *http.Request) {
 	_ = json.NewEncoder(w).Encode(events)
 }
 
+func handleOpenAPI(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(OpenAPISpec())
+}
+
 func (s *Service) handleStream(w http.ResponseWriter, r *http.Request) {
 	flusher, ok := w.(http.Flusher)
 	if !ok {
@@ -348,47 +513,100 @@ func (s *Service) handleStream(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "text/event-stream")
 	w.Header().Set("Cache-Control", "no-cache")
 	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no") // disable nginx response buffering
+
+	lastID := parseLastEventID(r)
 
 	ch := make(chan Event, 16)
-	id := s.addSubscriber(ch)
+	id, backlog := s.subscribe(ch, lastID)
 	defer s.removeSubscriber(id)
 
-	// Send current snapshot immediately.
-	current := Event{
-		Type:      "snapshot",
-		Timestamp: time.Now(),
-		Snapshot:  s.snapshotStatus().Summary,
+	_, _ = fmt.Fprintf(w, "retry: %d\n\n", (3 * time.Second).Milliseconds())
+	for _, ev := range backlog {
+		writeSSE(w, ev)
+		lastID = ev.ID
 	}
-	writeSSE(w, current)
 	flusher.Flush()
 
+	s.mu.RLock()
+	every := s.cfg.Heartbeat
+	s.mu.RUnlock()
+	heartbeat := time.NewTicker(every)
+	defer heartbeat.Stop()
+
 	for {
 		select {
 		case <-r.Context().Done():
 			return
 		case ev := <-ch:
+			if ev.ID <= lastID {
+				continue // already sent during replay
+			}
 			writeSSE(w, ev)
+			lastID = ev.ID
+			flusher.Flush()
+		case <-heartbeat.C:
+			_, _ = fmt.Fprint(w, ": keep-alive\n\n")
 			flusher.Flush()
 		}
 	}
 }
 
-func writeSSE(w http.ResponseWriter, ev Event) {
-	data, err := json.Marshal(ev)
-	if err != nil {
-		return
+// parseLastEventID reads the resume point from the Last-Event-ID header, or
+// the last_event_id query parameter for clients that can't set headers.
+func parseLastEventID(r *http.Request) int64 {
+	raw := r.Header.Get("Last-Event-ID")
+	if raw == "" {
+		raw = r.URL.Query().Get("last_event_id")
 	}
-	_, _ = fmt.Fprintf(w, "event: %s\n", ev.Type)
-	_, _ = fmt.Fprintf(w, "data: %s\n\n", data)
+	id, err := strconv.ParseInt(strings.TrimSpace(raw), 10, 64)
+	if err != nil || id < 0 {
+		return 0
+	}
+	return id
 }
 
-func (s *Service) addSubscriber(ch chan Event) int {
+// subscribe registers ch and returns the events the client should receive
+// first. When lastID is still covered by the buffer, that is every buffered
+// event after it; otherwise (new client, or too far behind) it is a single
+// snapshot of the current state carrying the latest event ID.
+// Registration and the backlog read share one lock so no event is missed.
+func (s *Service) subscribe(ch chan Event, lastID int64) (int, []Event) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+
 	s.nextSubID++
 	id := s.nextSubID
 	s.subs[id] = ch
-	return id
+
+	if lastID > 0 && len(s.events) > 0 && s.events[0].ID <= lastID+1 && lastID <= s.nextEventID {
+		var backlog []Event
+		for _, ev := range s.events {
+			if ev.ID > lastID {
+				backlog = append(backlog, ev)
+			}
+		}
+		return id, backlog
+	}
+
+	return id, []Event{{
+		ID:        s.nextEventID,
+		Type:      "snapshot",
+		Timestamp: time.Now(),
+		Snapshot:  s.snapshot,
+	}}
+}
+
+func writeSSE(w http.ResponseWriter, ev Event) {
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return
+	}
+	if ev.ID > 0 {
+		_, _ = fmt.Fprintf(w, "id: %d\n", ev.ID)
+	}
+	_, _ = fmt.Fprintf(w, "event: %s\n", ev.Type)
+	_, _ = fmt.Fprintf(w, "data: %s\n\n", data)
 }
 
 func (s *Service) removeSubscriber(id int) {
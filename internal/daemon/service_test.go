@@ -64,3 +64,30 @@ func TestPublishEventRingBuffer(t *testing.T) {
 		t.Fatalf("events ring contains IDs [%d, %d], want [2, 3]", s.events[0].ID, s.events[1].ID)
 	}
 }
+
+func TestSubscribeReplaysFromLastEventID(t *testing.T) {
+	s := New(Config{DataDir: ".", EventsBuffer: 3})
+	base := s.nextEventID
+	for i := int64(1); i <= 4; i++ {
+		s.nextEventID = base + i
+		s.publishEvent(Event{ID: base + i, Type: "usage_delta"})
+	}
+	// Buffer now holds base+2..base+4.
+
+	_, backlog := s.subscribe(make(chan Event, 1), base+2)
+	if len(backlog) != 2 || backlog[0].ID != base+3 || backlog[1].ID != base+4 {
+		t.Fatalf("resume backlog = %+v, want IDs base+3, base+4", backlog)
+	}
+
+	// Too far behind: the buffer no longer covers base+1, so resync.
+	_, backlog = s.subscribe(make(chan Event, 1), base)
+	if len(backlog) != 1 || backlog[0].Type != "snapshot" || backlog[0].ID != base+4 {
+		t.Fatalf("stale resume backlog = %+v, want single snapshot at latest ID", backlog)
+	}
+
+	// New client: snapshot only.
+	_, backlog = s.subscribe(make(chan Event, 1), 0)
+	if len(backlog) != 1 || backlog[0].Type != "snapshot" {
+		t.Fatalf("new client backlog = %+v, want snapshot", backlog)
+	}
+}
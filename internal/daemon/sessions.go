@@ -0,0 +1,126 @@
+package daemon
+
+import (
+	"slices"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/model"
+	"github.com/theirongolddev/cburn/internal/receipts"
+)
+
+// SessionEvent describes the session behind a session_started or
+// session_ended event.
+type SessionEvent struct {
+	SessionID    string    `json:"session_id"`
+	Project      string    `json:"project"`
+	Branch       string    `json:"branch,omitempty"`
+	Models       []string  `json:"models"`
+	StartTime    time.Time `json:"start_time"`
+	LastActivity time.Time `json:"last_activity"`
+	DurationSecs int64     `json:"duration_secs"`
+	Prompts      int       `json:"prompts"`
+	APICalls     int       `json:"api_calls"`
+	CostUSD      float64   `json:"cost_usd"`
+}
+
+// sessionIdleAfter is how long a session goes without activity before it
+// counts as ended; the same bar receipts use for a finished session.
+const sessionIdleAfter = receipts.CompletedAfter
+
+// sessionTracker turns successive polls into session start and end events.
+// A session starts when its ID first appears or its activity resumes, and
+// ends once it has been idle for sessionIdleAfter or disappears.
+type sessionTracker struct {
+	seeded bool
+	known  map[string]struct{}
+	active map[string]model.SessionStats
+}
+
+func newSessionTracker() *sessionTracker {
+	return &sessionTracker{
+		known:  make(map[string]struct{}),
+		active: make(map[string]model.SessionStats),
+	}
+}
+
+// sessionChange is one start or end found by observe.
+type sessionChange struct {
+	typ     string // "session_started" or "session_ended"
+	session model.SessionStats
+}
+
+// observe records a poll's sessions and returns what started and ended
+// since the last one. The first poll only learns the current state: the
+// daemon didn't see those sessions start, and a restart must not repeat them.
+func (t *sessionTracker) observe(sessions []model.SessionStats, now time.Time) []sessionChange {
+	cutoff := now.Add(-sessionIdleAfter)
+	present := make(map[string]struct{}, len(sessions))
+	var changes []sessionChange
+
+	for _, s := range sessions {
+		if s.IsSubagent {
+			continue
+		}
+		present[s.SessionID] = struct{}{}
+		busy := s.EndTime.After(cutoff)
+		_, known := t.known[s.SessionID]
+		_, wasActive := t.active[s.SessionID]
+		t.known[s.SessionID] = struct{}{}
+
+		if !t.seeded {
+			if busy {
+				t.active[s.SessionID] = s
+			}
+			continue
+		}
+
+		if !wasActive && (busy || !known) {
+			changes = append(changes, sessionChange{"session_started", s})
+		}
+		if busy {
+			t.active[s.SessionID] = s
+			continue
+		}
+		if wasActive || !known {
+			// Idle already, or it began and finished between polls
+			changes = append(changes, sessionChange{"session_ended", s})
+		}
+		delete(t.active, s.SessionID)
+	}
+
+	// Sessions that vanished (deleted, hidden, filtered out) end as last seen
+	var gone []string
+	for id := range t.active {
+		if _, ok := present[id]; !ok {
+			gone = append(gone, id)
+		}
+	}
+	slices.Sort(gone)
+	for _, id := range gone {
+		changes = append(changes, sessionChange{"session_ended", t.active[id]})
+		delete(t.active, id)
+	}
+
+	t.seeded = true
+	return changes
+}
+
+func newSessionEvent(s model.SessionStats) *SessionEvent {
+	models := make([]string, 0, len(s.Models))
+	for name := range s.Models {
+		models = append(models, name)
+	}
+	slices.Sort(models)
+	return &SessionEvent{
+		SessionID:    s.SessionID,
+		Project:      s.Project,
+		Branch:       s.GitBranch,
+		Models:       models,
+		StartTime:    s.StartTime,
+		LastActivity: s.EndTime,
+		DurationSecs: s.DurationSecs,
+		Prompts:      s.UserMessages,
+		APICalls:     s.APICalls,
+		CostUSD:      s.EstimatedCost,
+	}
+}
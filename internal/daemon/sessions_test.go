@@ -0,0 +1,55 @@
+package daemon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+func TestSessionTrackerObserve(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	sess := func(id string, lastActive time.Duration) model.SessionStats {
+		return model.SessionStats{SessionID: id, Project: "app", StartTime: now.Add(-2 * time.Hour), EndTime: now.Add(-lastActive)}
+	}
+	types := func(cs []sessionChange) []string {
+		var out []string
+		for _, c := range cs {
+			out = append(out, c.typ+":"+c.session.SessionID)
+		}
+		return out
+	}
+	check := func(name string, got []sessionChange, want ...string) {
+		t.Helper()
+		g := types(got)
+		if len(g) != len(want) {
+			t.Fatalf("%s: changes = %v, want %v", name, g, want)
+		}
+		for i := range want {
+			if g[i] != want[i] {
+				t.Fatalf("%s: changes = %v, want %v", name, g, want)
+			}
+		}
+	}
+
+	tr := newSessionTracker()
+	// The first poll only learns what is already running
+	check("seed", tr.observe([]model.SessionStats{sess("a", time.Minute), sess("old", 5*time.Hour)}, now))
+
+	now = now.Add(time.Minute)
+	check("new session", tr.observe([]model.SessionStats{
+		sess("a", 0), sess("old", 5*time.Hour), sess("b", 0),
+		{SessionID: "b-sub", IsSubagent: true, EndTime: now},
+	}, now), "session_started:b")
+
+	now = now.Add(time.Hour)
+	check("idle", tr.observe([]model.SessionStats{
+		sess("a", time.Hour), sess("old", 6*time.Hour), sess("b", time.Minute),
+		sess("quick", time.Hour),
+	}, now), "session_ended:a", "session_started:quick", "session_ended:quick")
+
+	now = now.Add(time.Minute)
+	check("resumed and removed", tr.observe([]model.SessionStats{
+		sess("a", 0), sess("old", 6*time.Hour), sess("quick", time.Hour),
+	}, now), "session_started:a", "session_ended:b")
+}
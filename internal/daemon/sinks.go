@@ -0,0 +1,210 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/config"
+
+	_ "modernc.org/sqlite" // register sqlite driver
+)
+
+// Sink receives every event the daemon publishes, in order. Write is only
+// called from the polling goroutine.
+type Sink interface {
+	Write(Event) error
+	Close() error
+}
+
+// OpenSinks opens the sinks configured under [[daemon.sinks]]. On error,
+// any already opened are closed.
+func OpenSinks(cfgs []config.Sink) ([]Sink, error) {
+	var sinks []Sink
+	for i, c := range cfgs {
+		s, err := openSink(c)
+		if err != nil {
+			CloseSinks(sinks)
+			return nil, fmt.Errorf("daemon sink %d (%s): %w", i+1, c.Type, err)
+		}
+		if len(c.Events) > 0 {
+			s = &eventFilter{Sink: s, types: c.Events}
+		}
+		sinks = append(sinks, s)
+	}
+	return sinks, nil
+}
+
+// CloseSinks closes every sink, ignoring errors.
+func CloseSinks(sinks []Sink) {
+	for _, s := range sinks {
+		_ = s.Close()
+	}
+}
+
+func openSink(c config.Sink) (Sink, error) {
+	switch c.Type {
+	case "stdout":
+		return &jsonLines{w: os.Stdout}, nil
+	case "file":
+		return openFileSink(c.ExpandedPath())
+	case "webhook":
+		if c.URL == "" {
+			return nil, errors.New("url is required")
+		}
+		return &webhookSink{url: c.URL, client: &http.Client{Timeout: 10 * time.Second}}, nil
+	case "sqlite":
+		return openSQLiteSink(c.ExpandedPath())
+	case "":
+		return nil, errors.New("type is required (stdout, file, webhook, or sqlite)")
+	}
+	return nil, fmt.Errorf("unknown type %q (want stdout, file, webhook, or sqlite)", c.Type)
+}
+
+// eventFilter passes on only the listed event types.
+type eventFilter struct {
+	Sink
+	types []string
+}
+
+func (f *eventFilter) Write(ev Event) error {
+	if !slices.Contains(f.types, ev.Type) {
+		return nil
+	}
+	return f.Sink.Write(ev)
+}
+
+// jsonLines writes each event as one line of JSON.
+type jsonLines struct {
+	w io.Writer
+	f *os.File // set when w is a file this sink owns
+}
+
+func openFileSink(path string) (Sink, error) {
+	if path == "" {
+		return nil, errors.New("path is required")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, err
+	}
+	//nolint:gosec // sink path is configured by the local user
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &jsonLines{w: f, f: f}, nil
+}
+
+func (j *jsonLines) Write(ev Event) error {
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	if _, err := j.w.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	if j.f != nil {
+		// An audit log should survive a crash right after the write
+		return j.f.Sync()
+	}
+	return nil
+}
+
+func (j *jsonLines) Close() error {
+	if j.f != nil {
+		return j.f.Close()
+	}
+	return nil
+}
+
+// webhookSink POSTs each event as JSON.
+type webhookSink struct {
+	url    string
+	client *http.Client
+}
+
+func (w *webhookSink) Write(ev Event) error {
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, w.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("POST %s: HTTP %d", w.url, resp.StatusCode)
+	}
+	return nil
+}
+
+func (w *webhookSink) Close() error { return nil }
+
+// sqliteSink appends events to an events table.
+type sqliteSink struct {
+	db     *sql.DB
+	insert *sql.Stmt
+}
+
+func openSQLiteSink(path string) (Sink, error) {
+	if path == "" {
+		return nil, errors.New("path is required")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, err
+	}
+	db, err := sql.Open("sqlite", path+"?_pragma=journal_mode(wal)")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS events (
+		id         INTEGER PRIMARY KEY,
+		type       TEXT NOT NULL,
+		timestamp  TEXT NOT NULL,
+		session_id TEXT NOT NULL DEFAULT '',
+		data       TEXT NOT NULL
+	)`); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	insert, err := db.Prepare(`INSERT OR IGNORE INTO events (id, type, timestamp, session_id, data)
+		VALUES (?, ?, ?, ?, ?)`)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return &sqliteSink{db: db, insert: insert}, nil
+}
+
+func (s *sqliteSink) Write(ev Event) error {
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	var sessionID string
+	if ev.Session != nil {
+		sessionID = ev.Session.SessionID
+	}
+	_, err = s.insert.Exec(ev.ID, ev.Type, ev.Timestamp.UTC().Format(time.RFC3339Nano), sessionID, string(data))
+	return err
+}
+
+func (s *sqliteSink) Close() error {
+	_ = s.insert.Close()
+	return s.db.Close()
+}
@@ -0,0 +1,68 @@
+package daemon
+
+import (
+	"bufio"
+	"database/sql"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/config"
+)
+
+func TestSinks(t *testing.T) {
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "audit", "events.jsonl")
+	dbPath := filepath.Join(dir, "events.db")
+	sinks, err := OpenSinks([]config.Sink{
+		{Type: "file", Path: logPath},
+		{Type: "sqlite", Path: dbPath, Events: []string{"session_ended"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	svc := New(Config{Sinks: sinks})
+	at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	svc.publishEvent(Event{ID: 1, Type: "usage_delta", Timestamp: at})
+	svc.publishEvent(Event{ID: 2, Type: "session_ended", Timestamp: at, Session: &SessionEvent{SessionID: "s1"}})
+	CloseSinks(sinks)
+
+	f, err := os.Open(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+	var ids []int64
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var ev Event
+		if err := json.Unmarshal(sc.Bytes(), &ev); err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, ev.ID)
+	}
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+		t.Fatalf("file sink IDs = %v, want [1 2]", ids)
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = db.Close() }()
+	var n int
+	var sessionID string
+	if err := db.QueryRow("SELECT COUNT(*), MAX(session_id) FROM events").Scan(&n, &sessionID); err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 || sessionID != "s1" {
+		t.Fatalf("sqlite sink has %d events (session %q), want only the session_ended one", n, sessionID)
+	}
+
+	if _, err := OpenSinks([]config.Sink{{Type: "carrier-pigeon"}}); err == nil {
+		t.Fatal("OpenSinks accepted an unknown type")
+	}
+}
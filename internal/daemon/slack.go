@@ -0,0 +1,126 @@
+package daemon
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/cli"
+	"github.com/theirongolddev/cburn/internal/model"
+	"github.com/theirongolddev/cburn/internal/pipeline"
+)
+
+// slackMaxSkew is how far a Slack request's timestamp may be from now;
+// older requests are refused as possible replays.
+const slackMaxSkew = 5 * time.Minute
+
+// slackTopProjects is how many projects a slash-command reply lists.
+const slackTopProjects = 3
+
+// slackResponse is the reply to a Slack slash command.
+type slackResponse struct {
+	ResponseType string `json:"response_type"` // in_channel or ephemeral
+	Text         string `json:"text"`
+}
+
+// handleSlackCommand answers Slack slash commands such as `/cburn today`
+// with a usage summary from the last poll. It is only served when a Slack
+// signing secret is configured, and every request must carry Slack's
+// signature.
+func (s *Service) handleSlackCommand(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	secret, sessions := s.cfg.SlackSigningSecret, s.lastSessions
+	s.mu.RUnlock()
+	if secret == "" {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, 64<<10))
+	if err != nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	now := time.Now()
+	if !verifySlackSignature(secret, r.Header, body, now) {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(slackReply(sessions, form.Get("text"), now))
+}
+
+// verifySlackSignature checks the X-Slack-Signature of a request body as
+// Slack documents it: an HMAC-SHA256 of "v0:<timestamp>:<body>" keyed with
+// the app's signing secret.
+func verifySlackSignature(secret string, h http.Header, body []byte, now time.Time) bool {
+	ts, err := strconv.ParseInt(h.Get("X-Slack-Request-Timestamp"), 10, 64)
+	if err != nil {
+		return false
+	}
+	if skew := now.Sub(time.Unix(ts, 0)); skew > slackMaxSkew || skew < -slackMaxSkew {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "v0:%d:", ts)
+	mac.Write(body)
+	want := "v0=" + hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(want), []byte(h.Get("X-Slack-Signature")))
+}
+
+// slackReply builds the reply to the command text: today (the default),
+// yesterday, week, month, or a number of days like 3d.
+func slackReply(sessions []model.SessionStats, text string, now time.Time) slackResponse {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	var (
+		since, until = today, now
+		label        string
+	)
+	switch arg := strings.ToLower(strings.TrimSpace(text)); arg {
+	case "", "today":
+		label = "today"
+	case "yesterday":
+		since, until, label = today.AddDate(0, 0, -1), today, "yesterday"
+	case "week":
+		since, label = today.AddDate(0, 0, -6), "the last 7 days"
+	case "month":
+		since, label = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()), "this month"
+	default:
+		n, err := strconv.Atoi(strings.TrimSuffix(arg, "d"))
+		if err != nil || n < 1 {
+			return slackResponse{ResponseType: "ephemeral", Text: "Usage: `/cburn [today|yesterday|week|month|<n>d]`"}
+		}
+		since, label = today.AddDate(0, 0, 1-n), fmt.Sprintf("the last %d days", n)
+	}
+
+	stats := pipeline.Aggregate(sessions, since, until)
+	var b strings.Builder
+	fmt.Fprintf(&b, "*Claude usage %s*\n", label)
+	fmt.Fprintf(&b, "$%.2f · %d sessions · %d prompts · %s tokens",
+		stats.EstimatedCost, stats.TotalSessions, stats.TotalPrompts, cli.FormatTokens(stats.TotalBilledTokens))
+	if projects := pipeline.AggregateProjects(sessions, since, until); len(projects) > 0 {
+		parts := make([]string, 0, slackTopProjects)
+		for _, p := range projects[:min(len(projects), slackTopProjects)] {
+			parts = append(parts, fmt.Sprintf("%s $%.2f", p.Project, p.EstimatedCost))
+		}
+		fmt.Fprintf(&b, "\nTop projects: %s", strings.Join(parts, " · "))
+	}
+	return slackResponse{ResponseType: "in_channel", Text: b.String()}
+}
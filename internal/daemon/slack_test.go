@@ -0,0 +1,70 @@
+package daemon
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+func signedSlackRequest(secret, body string, at time.Time) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/v1/slack/command", strings.NewReader(body))
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "v0:%d:%s", at.Unix(), body)
+	req.Header.Set("X-Slack-Request-Timestamp", strconv.FormatInt(at.Unix(), 10))
+	req.Header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+	return req
+}
+
+func TestSlackCommand(t *testing.T) {
+	now := time.Now()
+	s := New(Config{DataDir: ".", SlackSigningSecret: "shh"})
+	s.lastSessions = []model.SessionStats{
+		{Project: "app", StartTime: now, EndTime: now, EstimatedCost: 4.5, UserMessages: 3},
+		{Project: "api", StartTime: now, EndTime: now, EstimatedCost: 1.25, UserMessages: 1},
+	}
+	h := s.Handler()
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, signedSlackRequest("shh", "command=%2Fcburn&text=today", now))
+	var reply slackResponse
+	if err := json.NewDecoder(rec.Body).Decode(&reply); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("status %d, err %v", rec.Code, err)
+	}
+	if reply.ResponseType != "in_channel" || !strings.Contains(reply.Text, "$5.75 · 2 sessions · 4 prompts") ||
+		!strings.Contains(reply.Text, "Top projects: app $4.50 · api $1.25") {
+		t.Errorf("reply = %+v", reply)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, signedSlackRequest("shh", "text=lots", now))
+	if _ = json.NewDecoder(rec.Body).Decode(&reply); reply.ResponseType != "ephemeral" {
+		t.Errorf("unknown period should get private usage help, got %+v", reply)
+	}
+
+	for name, req := range map[string]*http.Request{
+		"wrong secret": signedSlackRequest("guess", "text=today", now),
+		"replayed":     signedSlackRequest("shh", "text=today", now.Add(-10*time.Minute)),
+	} {
+		rec = httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("%s: status %d, want 401", name, rec.Code)
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	New(Config{DataDir: "."}).Handler().ServeHTTP(rec, signedSlackRequest("", "text=today", now))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("without a signing secret: status %d, want 404", rec.Code)
+	}
+}
@@ -0,0 +1,114 @@
+// Package digest builds the daily usage digest: one day's cost, its most
+// expensive sessions, and claude.ai rate-limit status, rendered as an HTML
+// email. `cburn report --daily` writes it to a file; the daemon can mail it
+// every morning (see SMTP).
+package digest
+
+import (
+	"cmp"
+	"fmt"
+	"html/template"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/claudeai"
+	"github.com/theirongolddev/cburn/internal/cli"
+	"github.com/theirongolddev/cburn/internal/model"
+	"github.com/theirongolddev/cburn/internal/pipeline"
+)
+
+// TopSessions is how many of the day's most expensive sessions a digest lists.
+const TopSessions = 5
+
+// Digest is one day of usage, ready to render.
+type Digest struct {
+	Day      time.Time // local midnight of the day covered
+	Cost     float64
+	Sessions int
+	Prompts  int
+	APICalls int
+	Tokens   int64
+	WeekAvg  float64 // average daily cost over the 7 days before Day
+
+	Anomaly *pipeline.CostAnomaly // set when Day's spend spiked
+	Top     []model.SessionStats  // the most expensive sessions that started on Day
+
+	// RateLimits are the claude.ai windows as of the digest, when a session
+	// key is configured; RateLimitError says why they are missing otherwise.
+	RateLimits     []claudeai.NamedWindow
+	RateLimitError string
+	GeneratedAt    time.Time
+}
+
+// Build summarizes the day starting at day (local midnight) from sessions,
+// which should reach back at least a week before it for the comparison.
+func Build(sessions []model.SessionStats, day, now time.Time) Digest {
+	end := day.AddDate(0, 0, 1)
+	stats := pipeline.Aggregate(sessions, day, end)
+	week := pipeline.Aggregate(sessions, day.AddDate(0, 0, -7), day)
+
+	d := Digest{
+		Day:         day,
+		Cost:        stats.EstimatedCost,
+		Sessions:    stats.TotalSessions,
+		Prompts:     stats.TotalPrompts,
+		APICalls:    stats.TotalAPICalls,
+		Tokens:      stats.TotalBilledTokens,
+		WeekAvg:     week.EstimatedCost / 7,
+		GeneratedAt: now,
+	}
+	for _, a := range pipeline.FindCostAnomalies(sessions, day, end.Add(-time.Nanosecond)) {
+		if a.Date.Equal(day) {
+			d.Anomaly = &a
+		}
+	}
+
+	top := slices.Clone(pipeline.FilterByTime(sessions, day, end))
+	slices.SortFunc(top, func(a, b model.SessionStats) int {
+		return cmp.Compare(b.EstimatedCost, a.EstimatedCost)
+	})
+	d.Top = top[:min(len(top), TopSessions)]
+	return d
+}
+
+// SetRateLimits records the claude.ai usage windows, or why they couldn't be
+// fetched.
+func (d *Digest) SetRateLimits(usage *claudeai.ParsedUsage, err error) {
+	d.RateLimits = usage.Windows()
+	if err != nil && len(d.RateLimits) == 0 {
+		d.RateLimitError = err.Error()
+	}
+}
+
+// Subject is the digest's email subject line.
+func (d Digest) Subject() string {
+	return fmt.Sprintf("cburn: %s of Claude usage on %s", cli.FormatCostFixed(d.Cost, 2), d.Day.Format("Mon Jan 2"))
+}
+
+var page = template.Must(template.New("digest").Funcs(template.FuncMap{
+	"cost":     func(v float64) string { return cli.FormatCostFixed(v, 2) },
+	"tokens":   cli.FormatTokens,
+	"duration": cli.FormatDuration,
+	"pct":      func(v float64) string { return fmt.Sprintf("%.0f%%", v*100) },
+	"hot":      func(v float64) bool { return v >= 0.8 },
+	"shortID": func(id string) string {
+		if len(id) > 8 {
+			return id[:8]
+		}
+		return id
+	},
+	"clock": func(t time.Time) string { return t.Local().Format("15:04") },
+	"resets": func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return "resets " + t.Local().Format("Mon 15:04")
+	},
+}).Parse(pageHTML))
+
+// WriteHTML renders d as a self-contained HTML page, with inline styles so
+// mail clients show it as intended.
+func WriteHTML(w io.Writer, d Digest) error {
+	return page.Execute(w, d)
+}
@@ -0,0 +1,87 @@
+package digest
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/claudeai"
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+func TestBuild(t *testing.T) {
+	day := time.Date(2026, 3, 19, 0, 0, 0, 0, time.Local)
+	session := func(id string, back int, cost float64) model.SessionStats {
+		at := day.AddDate(0, 0, -back).Add(10 * time.Hour)
+		return model.SessionStats{SessionID: id, Project: "app", StartTime: at, EndTime: at, EstimatedCost: cost, UserMessages: 1}
+	}
+	var sessions []model.SessionStats
+	for back := 1; back <= 7; back++ {
+		sessions = append(sessions, session("old", back, 2))
+	}
+	for i := range 7 {
+		sessions = append(sessions, session(string(rune('a'+i)), 0, float64(i+1)))
+	}
+	sessions = append(sessions, session("next", -1, 50)) // the day after: not counted
+
+	d := Build(sessions, day, day.AddDate(0, 0, 1).Add(8*time.Hour))
+	if d.Cost != 28 || d.Sessions != 7 || d.WeekAvg != 2 {
+		t.Errorf("cost %v over %d sessions, week avg %v; want 28, 7, 2", d.Cost, d.Sessions, d.WeekAvg)
+	}
+	if d.Anomaly == nil || d.Anomaly.Median != 2 {
+		t.Errorf("anomaly = %+v, want 28 against a median of 2", d.Anomaly)
+	}
+	if len(d.Top) != TopSessions || d.Top[0].SessionID != "g" || d.Top[4].SessionID != "c" {
+		t.Errorf("top sessions = %v", d.Top)
+	}
+}
+
+func TestWriteHTML(t *testing.T) {
+	d := Digest{
+		Day:  time.Date(2026, 3, 19, 0, 0, 0, 0, time.Local),
+		Cost: 12.5,
+		Top:  []model.SessionStats{{SessionID: "abcdef0123456789", Project: "<script>", EstimatedCost: 9}},
+	}
+	d.SetRateLimits(&claudeai.ParsedUsage{FiveHour: &claudeai.ParsedWindow{Pct: 0.9}}, nil)
+
+	var b bytes.Buffer
+	if err := WriteHTML(&b, d); err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+	for _, want := range []string{"Thursday, March 19", "$12.50", "abcdef01", "&lt;script&gt;", "5-hour", "90%"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("digest is missing %q", want)
+		}
+	}
+	if strings.Contains(out, "Cost anomaly") {
+		t.Error("anomaly shown without one")
+	}
+
+	d.RateLimits = nil
+	d.SetRateLimits(nil, errors.New("session expired"))
+	b.Reset()
+	_ = WriteHTML(&b, d)
+	if !strings.Contains(b.String(), "Unavailable: session expired") {
+		t.Error("rate-limit error not shown")
+	}
+}
+
+func TestMessage(t *testing.T) {
+	s := SMTP{To: "me@example.com"}
+	msg, err := s.message("cburn: $1.00 of Claude usage", []byte("<p>"+strings.Repeat("x", 200)+"</p>"), time.Date(2026, 3, 20, 8, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	head, body, _ := strings.Cut(string(msg), "\r\n\r\n")
+	if !strings.Contains(head, "From: me@example.com\r\n") || !strings.Contains(head, "Content-Type: text/html; charset=utf-8") {
+		t.Errorf("headers = %q", head)
+	}
+	for _, line := range strings.Split(body, "\r\n") {
+		if len(line) > 76 {
+			t.Fatalf("body line of %d bytes: quoted-printable should wrap it", len(line))
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package digest
+
+import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// SMTP is the mail server and addresses a digest is sent with.
+type SMTP struct {
+	Host     string
+	Port     int // 465 for implicit TLS; anything else upgrades with STARTTLS when offered
+	Username string
+	Password string
+	From     string
+	To       string
+}
+
+// Send mails d as an HTML email.
+func (s SMTP) Send(d Digest) error {
+	if s.Host == "" || s.To == "" {
+		return errors.New("digest email needs an SMTP host and a recipient")
+	}
+	var body bytes.Buffer
+	if err := WriteHTML(&body, d); err != nil {
+		return err
+	}
+	msg, err := s.message(d.Subject(), body.Bytes(), d.GeneratedAt)
+	if err != nil {
+		return err
+	}
+
+	port := s.Port
+	if port == 0 {
+		port = 587
+	}
+	addr := net.JoinHostPort(s.Host, strconv.Itoa(port))
+	var auth smtp.Auth
+	if s.Username != "" {
+		auth = smtp.PlainAuth("", s.Username, s.Password, s.Host)
+	}
+	if port != 465 {
+		return smtp.SendMail(addr, auth, s.from(), []string{s.To}, msg)
+	}
+
+	conn, err := tls.Dial("tcp", addr, &tls.Config{ServerName: s.Host, MinVersion: tls.VersionTLS12})
+	if err != nil {
+		return err
+	}
+	c, err := smtp.NewClient(conn, s.Host)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	defer func() { _ = c.Close() }()
+	if auth != nil {
+		if err := c.Auth(auth); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(s.from()); err != nil {
+		return err
+	}
+	if err := c.Rcpt(s.To); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// from is the sender address, the recipient's own unless set.
+func (s SMTP) from() string {
+	if s.From != "" {
+		return s.From
+	}
+	return s.To
+}
+
+// message builds the RFC 5322 message: headers, then the quoted-printable
+// HTML body, which keeps lines under the SMTP length limit.
+func (s SMTP) message(subject string, html []byte, at time.Time) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", s.from())
+	fmt.Fprintf(&b, "To: %s\r\n", s.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", at.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/html; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+	qp := quotedprintable.NewWriter(&b)
+	if _, err := qp.Write(html); err != nil {
+		return nil, err
+	}
+	if err := qp.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
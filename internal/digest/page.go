@@ -0,0 +1,72 @@
+package digest
+
+// pageHTML is the digest template. Mail clients drop <style> blocks and
+// external CSS, so every style is inline.
+const pageHTML = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Subject}}</title>
+</head>
+<body style="margin:0;padding:24px;background:#f6f5f2;font-family:-apple-system,Segoe UI,Helvetica,Arial,sans-serif;color:#1c1b1a">
+<div style="max-width:640px;margin:0 auto;background:#ffffff;border:1px solid #e6e4d9;border-radius:8px;padding:24px">
+<h1 style="margin:0 0 4px;font-size:20px">Claude usage, {{.Day.Format "Monday, January 2"}}</h1>
+<p style="margin:0 0 20px;color:#6f6e69;font-size:13px">cburn daily digest</p>
+
+<p style="margin:0;font-size:32px;font-weight:bold">{{cost .Cost}}</p>
+<p style="margin:4px 0 0;color:#6f6e69;font-size:14px">
+{{.Sessions}} sessions &middot; {{.Prompts}} prompts &middot; {{.APICalls}} API calls &middot; {{tokens .Tokens}} tokens<br>
+7-day average: {{cost .WeekAvg}}/day
+</p>
+{{with .Anomaly}}
+<p style="margin:16px 0 0;padding:10px 12px;background:#fdf0e3;border-left:4px solid #da702c;font-size:14px">
+<strong>Cost anomaly:</strong> {{printf "%.1f" .Ratio}}x the median of the 7 days before ({{cost .Median}}/day). Worth checking for a runaway agent loop.
+</p>
+{{end}}
+
+<h2 style="margin:28px 0 8px;font-size:15px">Top sessions</h2>
+{{if .Top}}
+<table style="width:100%;border-collapse:collapse;font-size:13px">
+<tr style="color:#6f6e69;text-align:left">
+<th style="padding:4px 8px 4px 0;font-weight:normal">Started</th>
+<th style="padding:4px 8px;font-weight:normal">Project</th>
+<th style="padding:4px 8px;font-weight:normal;text-align:right">Duration</th>
+<th style="padding:4px 8px;font-weight:normal;text-align:right">Prompts</th>
+<th style="padding:4px 0 4px 8px;font-weight:normal;text-align:right">Cost</th>
+</tr>
+{{range .Top}}
+<tr style="border-top:1px solid #e6e4d9">
+<td style="padding:6px 8px 6px 0">{{clock .StartTime}}</td>
+<td style="padding:6px 8px">{{.Project}} <span style="color:#878580">{{shortID .SessionID}}</span></td>
+<td style="padding:6px 8px;text-align:right">{{duration .DurationSecs}}</td>
+<td style="padding:6px 8px;text-align:right">{{.UserMessages}}</td>
+<td style="padding:6px 0 6px 8px;text-align:right;font-weight:bold">{{cost .EstimatedCost}}</td>
+</tr>
+{{end}}
+</table>
+{{else}}
+<p style="margin:0;color:#6f6e69;font-size:13px">No sessions.</p>
+{{end}}
+
+{{if or .RateLimits .RateLimitError}}
+<h2 style="margin:28px 0 8px;font-size:15px">Rate limits</h2>
+{{if .RateLimits}}
+<table style="width:100%;border-collapse:collapse;font-size:13px">
+{{range .RateLimits}}
+<tr>
+<td style="padding:4px 8px 4px 0">{{.Label}}</td>
+<td style="padding:4px 8px;text-align:right;font-weight:bold;color:{{if hot .Window.Pct}}#d14d41{{else}}#1c1b1a{{end}}">{{pct .Window.Pct}}</td>
+<td style="padding:4px 0 4px 8px;color:#6f6e69">{{resets .Window.ResetsAt}}</td>
+</tr>
+{{end}}
+</table>
+{{else}}
+<p style="margin:0;color:#6f6e69;font-size:13px">Unavailable: {{.RateLimitError}}</p>
+{{end}}
+{{end}}
+
+<p style="margin:28px 0 0;color:#878580;font-size:12px">Estimated at API list prices. Generated {{.GeneratedAt.Format "2006-01-02 15:04 MST"}}.</p>
+</div>
+</body>
+</html>
+`
@@ -0,0 +1,330 @@
+// Package fixtures synthesizes Claude Code session files for tests and bug
+// reports. The output has the layout and line mix Claude Code writes, with
+// made-up prompts, so it can be shared without exposing real work.
+package fixtures
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Cache patterns for Options.Cache.
+const (
+	CacheMixed = "mixed" // mostly warm reads with the odd full rewrite
+	CacheWarm  = "warm"  // each call reads the prior context and writes the new turn
+	CacheCold  = "cold"  // each call rewrites the whole context
+	CacheNone  = "none"  // no prompt caching
+)
+
+// DefaultModels are the models sessions pick from when Options.Models is empty.
+var DefaultModels = []string{"claude-sonnet-4-6", "claude-opus-4-6", "claude-haiku-4-5"}
+
+// Options controls what Generate writes. Zero fields take the defaults
+// noted on each.
+type Options struct {
+	Sessions  int       // top-level sessions (default 20)
+	Projects  int       // distinct project directories (default 3)
+	Days      int       // sessions start within this many days before Now (default 14)
+	MaxTurns  int       // user prompts per session, 1..MaxTurns (default 8)
+	Models    []string  // model IDs to pick from (default DefaultModels)
+	Cache     string    // CacheMixed (default), CacheWarm, CacheCold, or CacheNone
+	Subagents float64   // fraction of sessions that spawn a subagent (default 0.25; negative for none)
+	Seed      uint64    // same seed, same files (default 1)
+	Now       time.Time // end of the time range (default time.Now)
+}
+
+// Result counts what Generate wrote.
+type Result struct {
+	Dir       string
+	Files     int
+	Sessions  int
+	Subagents int
+	APICalls  int
+	Lines     int
+}
+
+func (o *Options) defaults() error {
+	if o.Sessions == 0 {
+		o.Sessions = 20
+	}
+	if o.Projects == 0 {
+		o.Projects = 3
+	}
+	if o.Days == 0 {
+		o.Days = 14
+	}
+	if o.MaxTurns == 0 {
+		o.MaxTurns = 8
+	}
+	if len(o.Models) == 0 {
+		o.Models = DefaultModels
+	}
+	if o.Cache == "" {
+		o.Cache = CacheMixed
+	}
+	if o.Subagents == 0 {
+		o.Subagents = 0.25
+	}
+	if o.Seed == 0 {
+		o.Seed = 1
+	}
+	if o.Now.IsZero() {
+		o.Now = time.Now()
+	}
+	switch {
+	case o.Sessions < 0 || o.Projects < 0 || o.Days < 0 || o.MaxTurns < 0:
+		return errors.New("counts must be positive")
+	case o.Cache != CacheMixed && o.Cache != CacheWarm && o.Cache != CacheCold && o.Cache != CacheNone:
+		return fmt.Errorf("unknown cache pattern %q (want mixed, warm, cold, or none)", o.Cache)
+	}
+	return nil
+}
+
+// projectNames are the made-up repositories sessions are spread across.
+var projectNames = []string{"webapp", "api-server", "cli-tool", "infra", "data-pipeline", "mobile", "docs-site", "ml-experiments"}
+
+var prompts = []string{
+	"fix the failing test in the auth package",
+	"add pagination to the list endpoint",
+	"why is this query slow?",
+	"refactor the config loader",
+	"write a migration for the new column",
+	"explain what this function does",
+	"update the README for the new flag",
+	"add retries to the HTTP client",
+}
+
+// Generate writes synthetic sessions under dir in the ~/.claude layout
+// (projects/<encoded-path>/<session>.jsonl, subagents beside them), so
+// dir works as a --data-dir.
+func Generate(dir string, opts Options) (Result, error) {
+	if err := opts.defaults(); err != nil {
+		return Result{}, err
+	}
+	g := &generator{
+		opts: opts,
+		rng:  rand.New(rand.NewPCG(opts.Seed, opts.Seed^0x9e3779b97f4a7c15)),
+		res:  Result{Dir: dir},
+	}
+	for i := 0; i < opts.Sessions; i++ {
+		if err := g.session(dir, i); err != nil {
+			return g.res, err
+		}
+	}
+	return g.res, nil
+}
+
+type generator struct {
+	opts  Options
+	rng   *rand.Rand
+	res   Result
+	msgID int
+}
+
+// entry is one JSONL line; only the fields cburn reads, plus prompt text.
+type entry struct {
+	Type       string   `json:"type"`
+	Subtype    string   `json:"subtype,omitempty"`
+	Timestamp  string   `json:"timestamp"`
+	SessionID  string   `json:"sessionId"`
+	Cwd        string   `json:"cwd,omitempty"`
+	GitBranch  string   `json:"gitBranch,omitempty"`
+	Version    string   `json:"version,omitempty"`
+	Message    *message `json:"message,omitempty"`
+	DurationMs int64    `json:"durationMs,omitempty"`
+}
+
+type message struct {
+	ID      string `json:"id,omitempty"`
+	Role    string `json:"role"`
+	Model   string `json:"model,omitempty"`
+	Content any    `json:"content"`
+	Usage   *usage `json:"usage,omitempty"`
+}
+
+type usage struct {
+	InputTokens              int64          `json:"input_tokens"`
+	OutputTokens             int64          `json:"output_tokens"`
+	CacheCreationInputTokens int64          `json:"cache_creation_input_tokens"`
+	CacheReadInputTokens     int64          `json:"cache_read_input_tokens"`
+	CacheCreation            *cacheCreation `json:"cache_creation,omitempty"`
+	ServiceTier              string         `json:"service_tier"`
+}
+
+type cacheCreation struct {
+	Ephemeral5mInputTokens int64 `json:"ephemeral_5m_input_tokens"`
+	Ephemeral1hInputTokens int64 `json:"ephemeral_1h_input_tokens"`
+}
+
+func (g *generator) session(dir string, n int) error {
+	project := fmt.Sprintf("project-%d", n%g.opts.Projects)
+	if g.opts.Projects <= len(projectNames) {
+		project = projectNames[n%g.opts.Projects]
+	}
+	cwd := "/home/dev/" + project
+	projDir := filepath.Join(dir, "projects", strings.ReplaceAll(cwd, "/", "-"))
+	id := g.uuid()
+
+	span := time.Duration(g.opts.Days) * 24 * time.Hour
+	start := g.opts.Now.Add(-span).Add(time.Duration(g.rng.Int64N(int64(span) + 1))).Truncate(time.Second)
+	s := sessionState{
+		id:      id,
+		cwd:     cwd,
+		branch:  []string{"main", "main", "feature/search", "fix/flaky-test"}[g.rng.IntN(4)],
+		model:   g.opts.Models[g.rng.IntN(len(g.opts.Models))],
+		longTTL: g.rng.Float64() < 0.1,
+		t:       start,
+	}
+	lines := g.turns(&s, 1+g.rng.IntN(g.opts.MaxTurns))
+	if err := g.write(filepath.Join(projDir, id+".jsonl"), lines); err != nil {
+		return err
+	}
+	g.res.Sessions++
+
+	if g.opts.Subagents > 0 && g.rng.Float64() < g.opts.Subagents {
+		sub := s
+		sub.model = g.opts.Models[len(g.opts.Models)-1] // the last model listed, haiku by default
+		sub.t = start.Add(30 * time.Second)
+		sub.context = 0
+		lines := g.turns(&sub, 1+g.rng.IntN(3))
+		path := filepath.Join(projDir, id, "subagents", "agent-"+g.uuid()[:8]+".jsonl")
+		if err := g.write(path, lines); err != nil {
+			return err
+		}
+		g.res.Subagents++
+	}
+	return nil
+}
+
+type sessionState struct {
+	id, cwd, branch, model string
+	longTTL                bool // writes to the 1h cache instead of 5m
+	context                int64
+	t                      time.Time
+}
+
+// turns builds prompts, their API calls, and turn timings, with the odd
+// long pause and compaction.
+func (g *generator) turns(s *sessionState, n int) []entry {
+	var lines []entry
+	for turn := 0; turn < n; turn++ {
+		if turn > 0 {
+			pause := time.Duration(20+g.rng.IntN(160)) * time.Second
+			if g.rng.Float64() < 0.15 {
+				pause = time.Duration(10+g.rng.IntN(40)) * time.Minute // stepped away
+			}
+			s.t = s.t.Add(pause)
+		}
+		turnStart := s.t
+		lines = append(lines, entry{
+			Type: "user", Timestamp: stamp(s.t), SessionID: s.id, Cwd: s.cwd, GitBranch: s.branch, Version: "2.1.0",
+			Message: &message{Role: "user", Content: prompts[g.rng.IntN(len(prompts))]},
+		})
+		for call := 1 + g.rng.IntN(4); call > 0; call-- {
+			s.t = s.t.Add(time.Duration(3+g.rng.IntN(25)) * time.Second)
+			lines = append(lines, entry{
+				Type: "assistant", Timestamp: stamp(s.t), SessionID: s.id, Cwd: s.cwd, GitBranch: s.branch,
+				Message: &message{
+					ID: g.nextMsgID(), Role: "assistant", Model: s.model,
+					Content: []map[string]string{{"type": "text", "text": "Done."}},
+					Usage:   g.usage(s),
+				},
+			})
+			g.res.APICalls++
+		}
+		lines = append(lines, entry{
+			Type: "system", Subtype: "turn_duration", Timestamp: stamp(s.t), SessionID: s.id,
+			DurationMs: s.t.Sub(turnStart).Milliseconds(),
+		})
+		if s.context > 150_000 {
+			lines = append(lines, entry{Type: "system", Subtype: "compact_boundary", Timestamp: stamp(s.t), SessionID: s.id})
+			s.context = 20_000
+		}
+	}
+	return lines
+}
+
+// usage returns one call's token counts under the cache pattern, growing
+// the session's context by the new turn.
+func (g *generator) usage(s *sessionState) *usage {
+	if s.context == 0 {
+		s.context = 12_000 + g.rng.Int64N(8_000) // system prompt and tools
+	}
+	added := 500 + g.rng.Int64N(6_000)
+	u := &usage{OutputTokens: 50 + g.rng.Int64N(2_000), ServiceTier: "standard"}
+
+	pattern := g.opts.Cache
+	if pattern == CacheMixed {
+		pattern = CacheWarm
+		if g.rng.Float64() < 0.15 {
+			pattern = CacheCold
+		}
+	}
+	switch pattern {
+	case CacheNone:
+		u.InputTokens = s.context + added
+	case CacheCold:
+		u.InputTokens = 1 + g.rng.Int64N(10)
+		u.CacheCreationInputTokens = s.context + added
+	default:
+		u.InputTokens = 1 + g.rng.Int64N(10)
+		u.CacheReadInputTokens = s.context
+		u.CacheCreationInputTokens = added
+	}
+	if u.CacheCreationInputTokens > 0 {
+		cc := &cacheCreation{Ephemeral5mInputTokens: u.CacheCreationInputTokens}
+		if s.longTTL {
+			cc = &cacheCreation{Ephemeral1hInputTokens: u.CacheCreationInputTokens}
+		}
+		u.CacheCreation = cc
+	}
+	s.context += added + u.OutputTokens
+	return u
+}
+
+func (g *generator) write(path string, lines []entry) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for _, e := range lines {
+		if err := enc.Encode(e); err != nil {
+			_ = f.Close()
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	g.res.Files++
+	g.res.Lines += len(lines)
+	return f.Close()
+}
+
+func (g *generator) uuid() string {
+	return fmt.Sprintf("%08x-%04x-4%03x-%04x-%012x",
+		g.rng.Uint32(), g.rng.Uint32()&0xffff, g.rng.Uint32()&0xfff,
+		0x8000|g.rng.Uint32()&0x3fff, g.rng.Uint64()&0xffffffffffff)
+}
+
+func (g *generator) nextMsgID() string {
+	g.msgID++
+	return fmt.Sprintf("msg_fx%018d", g.msgID)
+}
+
+func stamp(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05.000Z")
+}
@@ -0,0 +1,74 @@
+package fixtures
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/pipeline"
+	"github.com/theirongolddev/cburn/internal/source"
+)
+
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	res, err := Generate(dir, Options{Sessions: 12, Projects: 2, Subagents: 0.5, Seed: 7, Now: now})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Sessions != 12 || res.Files != res.Sessions+res.Subagents {
+		t.Fatalf("result = %+v", res)
+	}
+
+	loaded, err := pipeline.Load([]source.Root{{Path: dir}}, true, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Sessions) != res.Files || loaded.ProjectCount != 2 || loaded.ParseErrors != 0 {
+		t.Fatalf("loaded %d sessions in %d projects (%d parse errors), want %d in 2",
+			len(loaded.Sessions), loaded.ProjectCount, loaded.ParseErrors, res.Files)
+	}
+	calls, subs := 0, 0
+	for _, s := range loaded.Sessions {
+		calls += s.APICalls
+		if s.IsSubagent {
+			subs++
+		}
+		if s.StartTime.Before(now.AddDate(0, 0, -14)) || s.EndTime.IsZero() {
+			t.Errorf("session %s spans %v..%v", s.SessionID, s.StartTime, s.EndTime)
+		}
+		if s.CacheReadTokens == 0 || s.EstimatedCost <= 0 {
+			t.Errorf("session %s: cache reads %d, cost %v", s.SessionID, s.CacheReadTokens, s.EstimatedCost)
+		}
+	}
+	if calls != res.APICalls || subs != res.Subagents {
+		t.Errorf("parsed %d calls, %d subagents; generated %d, %d", calls, subs, res.APICalls, res.Subagents)
+	}
+}
+
+func TestGenerate_Deterministic(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	a, b := t.TempDir(), t.TempDir()
+	for _, dir := range []string{a, b} {
+		if _, err := Generate(dir, Options{Sessions: 3, Cache: CacheCold, Now: now}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files, _ := filepath.Glob(filepath.Join(a, "projects", "*", "*.jsonl"))
+	if len(files) == 0 {
+		t.Fatal("no files generated")
+	}
+	for _, f := range files {
+		rel, _ := filepath.Rel(a, f)
+		x, _ := os.ReadFile(f)
+		y, err := os.ReadFile(filepath.Join(b, rel))
+		if err != nil || string(x) != string(y) {
+			t.Errorf("%s differs between runs with the same seed", rel)
+		}
+	}
+
+	if _, err := Generate(t.TempDir(), Options{Cache: "lukewarm"}); err == nil {
+		t.Error("unknown cache pattern accepted")
+	}
+}
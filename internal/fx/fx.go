@@ -0,0 +1,116 @@
+// Package fx looks up USD exchange rates so costs can be shown in other
+// currencies.
+package fx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ratesURL serves the latest rates against USD; a variable so tests can
+// point it at a local server.
+var ratesURL = "https://open.er-api.com/v6/latest/USD"
+
+const (
+	cacheFile = "fx.json"
+	// cacheFreshFor is how long cached rates are used without asking again;
+	// the source itself only updates daily.
+	cacheFreshFor = 24 * time.Hour
+)
+
+// cacheEntry is the on-disk form of the last successful lookup.
+type cacheEntry struct {
+	Rates     map[string]float64 `json:"rates"`
+	FetchedAt time.Time          `json:"fetched_at"`
+}
+
+// Rate returns how many units of the currency code one USD buys. Rates are
+// cached in dir for a day; when a fresh lookup fails, a cached rate of any
+// age is used instead.
+func Rate(ctx context.Context, code, dir string) (float64, error) {
+	code = strings.ToUpper(code)
+	if code == "" || code == "USD" {
+		return 1, nil
+	}
+
+	cached := loadCache(dir)
+	if cached != nil && time.Since(cached.FetchedAt) < cacheFreshFor {
+		if r, ok := cached.Rates[code]; ok {
+			return r, nil
+		}
+	}
+
+	rates, err := fetch(ctx)
+	if err != nil {
+		if cached != nil {
+			if r, ok := cached.Rates[code]; ok {
+				return r, nil
+			}
+		}
+		return 0, fmt.Errorf("exchange rate lookup: %w", err)
+	}
+	saveCache(dir, cacheEntry{Rates: rates, FetchedAt: time.Now()})
+
+	r, ok := rates[code]
+	if !ok || r <= 0 {
+		return 0, fmt.Errorf("no exchange rate for %s", code)
+	}
+	return r, nil
+}
+
+func fetch(ctx context.Context) (map[string]float64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ratesURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Result string             `json:"result"`
+		Rates  map[string]float64 `json:"rates"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+	if body.Result != "success" || len(body.Rates) == 0 {
+		return nil, errors.New("no rates in response")
+	}
+	return body.Rates, nil
+}
+
+func loadCache(dir string) *cacheEntry {
+	data, err := os.ReadFile(filepath.Join(dir, cacheFile)) //nolint:gosec // path under the cache dir
+	if err != nil {
+		return nil
+	}
+	var e cacheEntry
+	if json.Unmarshal(data, &e) != nil {
+		return nil
+	}
+	return &e
+}
+
+func saveCache(dir string, e cacheEntry) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	if os.MkdirAll(dir, 0o750) != nil {
+		return
+	}
+	_ = os.WriteFile(filepath.Join(dir, cacheFile), data, 0o600)
+}
@@ -0,0 +1,39 @@
+package fx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRate_CachesAndFallsBack(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(`{"result":"success","rates":{"USD":1,"EUR":0.9}}`))
+	}))
+	defer srv.Close()
+	old := ratesURL
+	defer func() { ratesURL = old }()
+	ratesURL = srv.URL
+
+	dir := t.TempDir()
+	ctx := context.Background()
+	if r, err := Rate(ctx, "eur", dir); err != nil || r != 0.9 {
+		t.Fatalf("Rate = %v, %v; want 0.9", r, err)
+	}
+	if _, err := Rate(ctx, "EUR", dir); err != nil || calls != 1 {
+		t.Errorf("second lookup: err %v, %d fetches; want the cached rate", err, calls)
+	}
+	if _, err := Rate(ctx, "XYZ", dir); err == nil {
+		t.Error("unknown currency returned a rate")
+	}
+
+	// Server down: the stale cache still answers
+	srv.Close()
+	saveCache(dir, cacheEntry{Rates: map[string]float64{"EUR": 0.8}})
+	if r, err := Rate(ctx, "EUR", dir); err != nil || r != 0.8 {
+		t.Errorf("offline Rate = %v, %v; want the cached 0.8", r, err)
+	}
+}
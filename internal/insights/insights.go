@@ -0,0 +1,171 @@
+// Package insights turns per-project cache usage into concrete suggestions
+// for reducing cost.
+package insights
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/config"
+	"github.com/theirongolddev/cburn/internal/model"
+	"github.com/theirongolddev/cburn/internal/pipeline"
+)
+
+// Insight kinds.
+const (
+	KindShortTTLExpiry = "cache_expiry"    // 5m writes rarely read back
+	KindUnusedLongTTL  = "unused_1h_cache" // paying the 1h premium for little reuse
+	KindUncachedInput  = "uncached_input"  // most input never hits the cache
+)
+
+// Thresholds below which a project is too small to say anything useful about.
+const (
+	minSessions    = 3
+	minTokens      = 1_000_000
+	lowReuse       = 2.0 // cached tokens read back fewer than this many times
+	uncachedShare  = 0.5 // fraction of input sent without caching
+	maxRecommended = 10
+)
+
+// ProjectCache is one project's cache write/read profile.
+type ProjectCache struct {
+	Project  string
+	Sessions int
+
+	InputTokens   int64 // uncached input
+	Write5mTokens int64
+	Write1hTokens int64
+	ReadTokens    int64
+
+	Write5mPremiumUSD float64 // 5m write cost above plain input
+	Write1hPremiumUSD float64 // 1h write cost above a 5m write
+	UncachedSavingUSD float64 // saving if uncached input had been cache reads
+}
+
+// Reuse returns how many times each cache-written token was read back.
+func (p ProjectCache) Reuse() float64 {
+	w := p.Write5mTokens + p.Write1hTokens
+	if w == 0 {
+		return 0
+	}
+	return float64(p.ReadTokens) / float64(w)
+}
+
+// HitRate returns the share of input tokens served from cache.
+func (p ProjectCache) HitRate() float64 {
+	total := p.InputTokens + p.Write5mTokens + p.Write1hTokens + p.ReadTokens
+	if total == 0 {
+		return 0
+	}
+	return float64(p.ReadTokens) / float64(total)
+}
+
+// Insight is one suggestion, ranked by its estimated cost impact.
+type Insight struct {
+	Project   string
+	Kind      string
+	Title     string
+	Detail    string
+	ImpactUSD float64
+}
+
+// ProjectCacheStats builds per-project cache profiles for sessions in the
+// time range, sorted by cache writes (largest first). Pricing is resolved at
+// each session's start time.
+func ProjectCacheStats(sessions []model.SessionStats, since, until time.Time) []ProjectCache {
+	byProject := make(map[string]*ProjectCache)
+	for _, s := range pipeline.FilterByTime(sessions, since, until) {
+		pc, ok := byProject[s.Project]
+		if !ok {
+			pc = &ProjectCache{Project: s.Project}
+			byProject[s.Project] = pc
+		}
+		if !s.IsSubagent {
+			pc.Sessions++
+		}
+		for name, mu := range s.Models {
+			pc.InputTokens += mu.InputTokens
+			pc.Write5mTokens += mu.CacheCreation5mTokens
+			pc.Write1hTokens += mu.CacheCreation1hTokens
+			pc.ReadTokens += mu.CacheReadTokens
+
+			p, ok := config.LookupPricingAt(name, s.StartTime)
+			if !ok {
+				continue
+			}
+			pc.Write5mPremiumUSD += float64(mu.CacheCreation5mTokens) * (p.CacheWrite5mPerMTok - p.InputPerMTok) / 1_000_000
+			pc.Write1hPremiumUSD += float64(mu.CacheCreation1hTokens) * (p.CacheWrite1hPerMTok - p.CacheWrite5mPerMTok) / 1_000_000
+			pc.UncachedSavingUSD += float64(mu.InputTokens) * (p.InputPerMTok - p.CacheReadPerMTok) / 1_000_000
+		}
+	}
+
+	out := make([]ProjectCache, 0, len(byProject))
+	for _, pc := range byProject {
+		out = append(out, *pc)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		wi := out[i].Write5mTokens + out[i].Write1hTokens
+		wj := out[j].Write5mTokens + out[j].Write1hTokens
+		if wi != wj {
+			return wi > wj
+		}
+		return out[i].Project < out[j].Project
+	})
+	return out
+}
+
+// Recommend returns suggestions for projects with wasteful cache patterns,
+// highest estimated impact first.
+func Recommend(stats []ProjectCache) []Insight {
+	var out []Insight
+	for _, p := range stats {
+		if p.Sessions < minSessions {
+			continue
+		}
+		reuse := p.Reuse()
+
+		if p.Write5mTokens >= minTokens && reuse < lowReuse && p.Write5mTokens >= p.Write1hTokens {
+			out = append(out, Insight{
+				Project: p.Project,
+				Kind:    KindShortTTLExpiry,
+				Title:   fmt.Sprintf("%s: high 5m cache writes but low reads", p.Project),
+				Detail: fmt.Sprintf("Cached tokens are read back only %.1fx on average. Your prompts are likely "+
+					"more than 5 minutes apart, so the cache expires before it's reused. Batch related prompts, "+
+					"or use the 1h cache for long think-time sessions.", reuse),
+				ImpactUSD: p.Write5mPremiumUSD,
+			})
+		}
+
+		if p.Write1hTokens >= minTokens && reuse < lowReuse {
+			out = append(out, Insight{
+				Project: p.Project,
+				Kind:    KindUnusedLongTTL,
+				Title:   fmt.Sprintf("%s: paying for 1h cache that is rarely reused", p.Project),
+				Detail: fmt.Sprintf("1h cache writes cost more than 5m writes, but cached tokens are read back "+
+					"only %.1fx. Unless gaps between prompts often exceed 5 minutes, the 5m cache is cheaper.", reuse),
+				ImpactUSD: p.Write1hPremiumUSD,
+			})
+		}
+
+		totalInput := p.InputTokens + p.Write5mTokens + p.Write1hTokens + p.ReadTokens
+		if p.InputTokens >= minTokens && totalInput > 0 &&
+			float64(p.InputTokens)/float64(totalInput) >= uncachedShare {
+			out = append(out, Insight{
+				Project: p.Project,
+				Kind:    KindUncachedInput,
+				Title:   fmt.Sprintf("%s: most input is sent uncached", p.Project),
+				Detail: fmt.Sprintf("%.0f%% of input tokens never hit the cache. Keep stable content (system "+
+					"prompt, CLAUDE.md, tool definitions) at the start of the context and avoid changing it "+
+					"mid-session.", float64(p.InputTokens)/float64(totalInput)*100),
+				ImpactUSD: p.UncachedSavingUSD,
+			})
+		}
+	}
+
+	sort.SliceStable(out, func(i, j int) bool { return out[i].ImpactUSD > out[j].ImpactUSD })
+	if len(out) > maxRecommended {
+		out = out[:maxRecommended]
+	}
+	return out
+}
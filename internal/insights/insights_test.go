@@ -0,0 +1,57 @@
+package insights
+
+import (
+	"testing"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+func session(project string, at time.Time, mu model.ModelUsage) model.SessionStats {
+	return model.SessionStats{
+		Project:   project,
+		StartTime: at,
+		Models:    map[string]*model.ModelUsage{"claude-sonnet-4-6": &mu},
+	}
+}
+
+func TestRecommend(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	var sessions []model.SessionStats
+	for i := 0; i < 3; i++ {
+		at := now.Add(-time.Duration(i+1) * time.Hour)
+		// Writes a lot of 5m cache and barely reads it.
+		sessions = append(sessions, session("sparse", at, model.ModelUsage{CacheCreation5mTokens: 1_000_000, CacheReadTokens: 500_000}))
+		// Healthy reuse.
+		sessions = append(sessions, session("tight", at, model.ModelUsage{CacheCreation5mTokens: 1_000_000, CacheReadTokens: 20_000_000}))
+		// Mostly uncached input.
+		sessions = append(sessions, session("nocache", at, model.ModelUsage{InputTokens: 2_000_000, CacheReadTokens: 100_000}))
+	}
+
+	stats := ProjectCacheStats(sessions, now.AddDate(0, 0, -1), now)
+	if len(stats) != 3 {
+		t.Fatalf("got %d projects, want 3", len(stats))
+	}
+
+	got := Recommend(stats)
+	kinds := make(map[string]string)
+	for _, in := range got {
+		kinds[in.Project] = in.Kind
+		if in.ImpactUSD <= 0 {
+			t.Errorf("%s: impact = %v, want > 0", in.Project, in.ImpactUSD)
+		}
+	}
+	if len(got) != 2 || kinds["sparse"] != KindShortTTLExpiry || kinds["nocache"] != KindUncachedInput {
+		t.Errorf("unexpected insights: %+v", got)
+	}
+}
+
+func TestRecommendSkipsSmallProjects(t *testing.T) {
+	now := time.Now()
+	stats := ProjectCacheStats([]model.SessionStats{
+		session("tiny", now, model.ModelUsage{CacheCreation5mTokens: 5_000_000}),
+	}, now.Add(-time.Hour), now.Add(time.Hour))
+	if got := Recommend(stats); len(got) != 0 {
+		t.Errorf("expected no insights for a single-session project, got %+v", got)
+	}
+}
@@ -0,0 +1,111 @@
+package insights
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/claudeai"
+	"github.com/theirongolddev/cburn/internal/model"
+	"github.com/theirongolddev/cburn/internal/pipeline"
+)
+
+// Scheduling looks at this many recent days of activity, and needs at least
+// minScheduleSessions of them to describe a habit.
+const (
+	scheduleDays        = 28
+	minScheduleSessions = 10
+	lowHeadroom         = 0.2 // below this, suggest waiting for the weekly reset
+)
+
+// dayParts are the blocks of the day usage is described in.
+var dayParts = []struct {
+	name       string
+	start, end int // local hours, [start, end)
+}{
+	{"nights", 0, 6},
+	{"mornings", 6, 12},
+	{"afternoons", 12, 18},
+	{"evenings", 18, 24},
+}
+
+// ScheduleAdvice suggests when to run heavy agentic work, from the weekly
+// rate-limit window and the times of day you usually use Claude.
+type ScheduleAdvice struct {
+	Quiet      string  // day part with the least of your usage, e.g. "mornings"
+	QuietShare float64 // its share of your tokens, 0.0-1.0
+
+	// Weekly window, when claude.ai data is available
+	HaveWeekly bool
+	Headroom   float64   // share of the weekly window left, 0.0-1.0
+	ResetsAt   time.Time // zero if unknown
+}
+
+// Schedule returns advice from the last four weeks of sessions and the
+// weekly window (nil when unknown), or nil when there is too little history.
+func Schedule(sessions []model.SessionStats, weekly *claudeai.ParsedWindow, now time.Time) *ScheduleAdvice {
+	hours := pipeline.AggregateHourly(sessions, now.AddDate(0, 0, -scheduleDays), now)
+
+	var total int64
+	var count int
+	for _, h := range hours {
+		total += h.Tokens
+		count += h.Sessions
+	}
+	if count < minScheduleSessions || total == 0 {
+		return nil
+	}
+
+	adv := &ScheduleAdvice{QuietShare: 2}
+	for _, p := range dayParts {
+		var tokens int64
+		for _, h := range hours[p.start:p.end] {
+			tokens += h.Tokens
+		}
+		if share := float64(tokens) / float64(total); share < adv.QuietShare {
+			adv.Quiet, adv.QuietShare = p.name, share
+		}
+	}
+
+	adv.SetWeekly(weekly, now)
+	return adv
+}
+
+// SetWeekly updates the advice for the current weekly window, or clears it
+// when weekly is nil.
+func (s *ScheduleAdvice) SetWeekly(weekly *claudeai.ParsedWindow, now time.Time) {
+	s.HaveWeekly, s.Headroom, s.ResetsAt = false, 0, time.Time{}
+	if weekly == nil {
+		return
+	}
+	s.HaveWeekly = true
+	s.Headroom = max(1-weekly.Pct, 0)
+	if weekly.ResetsAt.After(now) {
+		s.ResetsAt = weekly.ResetsAt
+	}
+}
+
+// WaitForReset reports whether the weekly window is too full for a heavy run.
+func (s ScheduleAdvice) WaitForReset() bool {
+	return s.HaveWeekly && s.Headroom < lowHeadroom
+}
+
+// Summary describes the advice in a sentence or two, e.g. "Weekly window
+// resets Tue 03:00 with 40% headroom left. You're quietest in the mornings
+// (9% of your usage): a good time for heavy agentic runs."
+func (s ScheduleAdvice) Summary() string {
+	usage := fmt.Sprintf("%s (%.0f%% of your usage)", s.Quiet, s.QuietShare*100)
+	if !s.HaveWeekly {
+		return "You're quietest in the " + usage + ": schedule heavy agentic runs then, away from interactive work."
+	}
+
+	window := "Weekly window has"
+	if !s.ResetsAt.IsZero() {
+		window = "Weekly window resets " + s.ResetsAt.Local().Format("Mon 15:04") + " with"
+	}
+	if s.WaitForReset() {
+		return fmt.Sprintf("%s only %.0f%% headroom left: hold heavy agentic runs until after the reset, ideally in the %s.",
+			window, s.Headroom*100, usage)
+	}
+	return fmt.Sprintf("%s %.0f%% headroom left. You're quietest in the %s: a good time for heavy agentic runs.",
+		window, s.Headroom*100, usage)
+}
@@ -0,0 +1,48 @@
+package insights
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/claudeai"
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+func TestSchedule(t *testing.T) {
+	now := time.Date(2026, 3, 20, 12, 0, 0, 0, time.Local)
+	var sessions []model.SessionStats
+	for d := 1; d <= 12; d++ {
+		day := now.AddDate(0, 0, -d)
+		at := func(h int) time.Time { return time.Date(day.Year(), day.Month(), day.Day(), h, 0, 0, 0, time.Local) }
+		sessions = append(sessions,
+			model.SessionStats{StartTime: at(14), InputTokens: 700},
+			model.SessionStats{StartTime: at(20), InputTokens: 150},
+			model.SessionStats{StartTime: at(9), InputTokens: 50},
+			model.SessionStats{StartTime: at(2), InputTokens: 100},
+		)
+	}
+
+	if Schedule(sessions[:8], nil, now) != nil {
+		t.Error("advice from 8 sessions, want nil below the minimum")
+	}
+
+	adv := Schedule(sessions, nil, now)
+	if adv == nil || adv.Quiet != "mornings" || adv.QuietShare != 0.05 {
+		t.Fatalf("advice = %+v, want quiet mornings at 5%%", adv)
+	}
+	if !strings.Contains(adv.Summary(), "quietest in the mornings (5% of your usage)") {
+		t.Errorf("summary = %q", adv.Summary())
+	}
+
+	reset := now.Add(39 * time.Hour)
+	adv = Schedule(sessions, &claudeai.ParsedWindow{Pct: 0.6, ResetsAt: reset}, now)
+	if adv.WaitForReset() || !strings.Contains(adv.Summary(), "with 40% headroom left") {
+		t.Errorf("60%% used: summary = %q", adv.Summary())
+	}
+
+	adv = Schedule(sessions, &claudeai.ParsedWindow{Pct: 0.9, ResetsAt: reset}, now)
+	if !adv.WaitForReset() || !strings.Contains(adv.Summary(), "until after the reset") {
+		t.Errorf("90%% used: summary = %q", adv.Summary())
+	}
+}
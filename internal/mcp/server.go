@@ -0,0 +1,188 @@
+// Package mcp implements a minimal Model Context Protocol server over stdio.
+//
+// Only the tool surface is supported: initialize, ping, tools/list, and
+// tools/call. Messages are newline-delimited JSON-RPC 2.0, as used by the
+// MCP stdio transport.
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// ProtocolVersion is the MCP revision this server implements.
+const ProtocolVersion = "2024-11-05"
+
+// JSON-RPC error codes.
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// Tool is one callable tool. Handler receives the raw arguments object and
+// returns a JSON-serializable result.
+type Tool struct {
+	Name        string
+	Description string
+	InputSchema map[string]any
+	Handler     func(ctx context.Context, args json.RawMessage) (any, error)
+}
+
+// Server dispatches MCP requests to registered tools.
+type Server struct {
+	Name    string
+	Version string
+	tools   []Tool
+}
+
+// NewServer returns a server advertising the given name and version.
+func NewServer(name, version string) *Server {
+	return &Server{Name: name, Version: version}
+}
+
+// AddTool registers a tool.
+func (s *Server) AddTool(t Tool) {
+	if t.InputSchema == nil {
+		t.InputSchema = map[string]any{"type": "object", "properties": map[string]any{}}
+	}
+	s.tools = append(s.tools, t)
+}
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type textContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// Serve reads requests from r and writes responses to w until r is exhausted
+// or ctx is canceled.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	var mu sync.Mutex
+	enc := json.NewEncoder(w)
+	write := func(resp response) {
+		mu.Lock()
+		defer mu.Unlock()
+		_ = enc.Encode(resp)
+	}
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for sc.Scan() {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		line := sc.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var req request
+		if err := json.Unmarshal(line, &req); err != nil {
+			write(response{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &rpcError{codeParseError, "parse error"}})
+			continue
+		}
+		// Notifications (no id) never get a response.
+		if len(req.ID) == 0 {
+			continue
+		}
+
+		result, rerr := s.handle(ctx, req)
+		resp := response{JSONRPC: "2.0", ID: req.ID, Result: result, Error: rerr}
+		write(resp)
+	}
+	return sc.Err()
+}
+
+func (s *Server) handle(ctx context.Context, req request) (any, *rpcError) {
+	if req.JSONRPC != "2.0" {
+		return nil, &rpcError{codeInvalidRequest, "jsonrpc must be \"2.0\""}
+	}
+
+	switch req.Method {
+	case "initialize":
+		return map[string]any{
+			"protocolVersion": ProtocolVersion,
+			"capabilities":    map[string]any{"tools": map[string]any{}},
+			"serverInfo":      map[string]any{"name": s.Name, "version": s.Version},
+		}, nil
+
+	case "ping":
+		return map[string]any{}, nil
+
+	case "tools/list":
+		tools := make([]map[string]any, 0, len(s.tools))
+		for _, t := range s.tools {
+			tools = append(tools, map[string]any{
+				"name":        t.Name,
+				"description": t.Description,
+				"inputSchema": t.InputSchema,
+			})
+		}
+		return map[string]any{"tools": tools}, nil
+
+	case "tools/call":
+		var p struct {
+			Name      string          `json:"name"`
+			Arguments json.RawMessage `json:"arguments"`
+		}
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			return nil, &rpcError{codeInvalidParams, "invalid tools/call params"}
+		}
+		for _, t := range s.tools {
+			if t.Name == p.Name {
+				return callTool(ctx, t, p.Arguments), nil
+			}
+		}
+		return nil, &rpcError{codeInvalidParams, fmt.Sprintf("unknown tool %q", p.Name)}
+
+	default:
+		return nil, &rpcError{codeMethodNotFound, fmt.Sprintf("method %q not found", req.Method)}
+	}
+}
+
+// callTool runs a tool and wraps its result as MCP text content. Tool
+// failures are reported in-band with isError so the model can see them.
+func callTool(ctx context.Context, t Tool, args json.RawMessage) map[string]any {
+	if len(args) == 0 {
+		args = json.RawMessage("{}")
+	}
+	out, err := t.Handler(ctx, args)
+	if err != nil {
+		return map[string]any{
+			"content": []textContent{{Type: "text", Text: err.Error()}},
+			"isError": true,
+		}
+	}
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return map[string]any{
+			"content": []textContent{{Type: "text", Text: err.Error()}},
+			"isError": true,
+		}
+	}
+	return map[string]any{"content": []textContent{{Type: "text", Text: string(data)}}}
+}
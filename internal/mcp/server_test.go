@@ -0,0 +1,72 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestServe_ToolsListAndCall(t *testing.T) {
+	s := NewServer("cburn", "test")
+	s.AddTool(Tool{
+		Name: "echo",
+		Handler: func(_ context.Context, args json.RawMessage) (any, error) {
+			var a struct{ Msg string }
+			_ = json.Unmarshal(args, &a)
+			return map[string]string{"msg": a.Msg}, nil
+		},
+	})
+	s.AddTool(Tool{
+		Name: "fail",
+		Handler: func(context.Context, json.RawMessage) (any, error) {
+			return nil, errors.New("boom")
+		},
+	})
+
+	in := strings.Join([]string{
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"echo","arguments":{"Msg":"hi"}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"fail"}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"nope"}`,
+	}, "\n")
+
+	var out bytes.Buffer
+	if err := s.Serve(context.Background(), strings.NewReader(in), &out); err != nil {
+		t.Fatal(err)
+	}
+
+	var resps []map[string]any
+	dec := json.NewDecoder(&out)
+	for dec.More() {
+		var r map[string]any
+		if err := dec.Decode(&r); err != nil {
+			t.Fatal(err)
+		}
+		resps = append(resps, r)
+	}
+	if len(resps) != 5 {
+		t.Fatalf("got %d responses, want 5 (notification must not be answered)", len(resps))
+	}
+
+	tools := resps[1]["result"].(map[string]any)["tools"].([]any)
+	if len(tools) != 2 {
+		t.Errorf("tools/list returned %d tools", len(tools))
+	}
+
+	content := resps[2]["result"].(map[string]any)["content"].([]any)[0].(map[string]any)
+	if !strings.Contains(content["text"].(string), `"msg": "hi"`) {
+		t.Errorf("echo result = %v", content["text"])
+	}
+
+	if resps[3]["result"].(map[string]any)["isError"] != true {
+		t.Errorf("failing tool should set isError: %v", resps[3])
+	}
+	if resps[4]["error"] == nil {
+		t.Errorf("unknown method should return an error: %v", resps[4])
+	}
+}
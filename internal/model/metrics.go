@@ -27,6 +27,11 @@ type SummaryStats struct {
 	SessionsPerDay float64
 	PromptsPerDay  float64
 	MinutesPerDay  float64
+
+	// Time at the keyboard (gap-aware wall clock) and Claude's working time,
+	// over top-level sessions; subagents run inside their parent's time.
+	ActiveSecs int64
+	ClaudeSecs int64
 }
 
 // DailyStats holds metrics for a single calendar day.
@@ -43,6 +48,9 @@ type DailyStats struct {
 	CacheReadTokens int64
 	EstimatedCost   float64
 	ActualCost      *float64
+	ModelTokens     map[string]int64 // billed tokens (excluding cache reads) by model; nil on days without usage
+	ActiveSecs      int64            // gap-aware time in top-level sessions
+	ClaudeSecs      int64            // Claude's summed turn durations
 }
 
 // ModelStats holds aggregated metrics for a single model.
@@ -56,12 +64,24 @@ type ModelStats struct { //nolint:revive // renaming would break many call sites
 	CacheReadTokens int64
 	EstimatedCost   float64
 	SharePercent    float64
-	TrendDirection  int // -1, 0, +1 vs previous period
+	CacheReadShare  float64 // cache reads / all input (uncached + cache writes + cache reads), 0-1
+	TrendDirection  int     // -1, 0, +1 vs previous period
+}
+
+// ModelLatency holds turn duration statistics for a single model.
+type ModelLatency struct {
+	Model string
+	Turns int
+	Mean  time.Duration
+	P50   time.Duration
+	P90   time.Duration
+	P99   time.Duration
 }
 
 // ProjectStats holds aggregated metrics for a single project.
 type ProjectStats struct {
 	Project        string
+	Tag            string
 	Sessions       int
 	Prompts        int
 	TotalTokens    int64
@@ -69,6 +89,92 @@ type ProjectStats struct {
 	TrendDirection int
 }
 
+// TagStats holds aggregated metrics for a single cost allocation tag.
+type TagStats struct {
+	Tag           string
+	Projects      int
+	Sessions      int
+	Prompts       int
+	TotalTokens   int64
+	EstimatedCost float64
+	SharePercent  float64
+}
+
+// OriginStats holds aggregated metrics for interactive or automated sessions.
+type OriginStats struct {
+	Origin        string // OriginInteractive or OriginAutomation
+	Projects      int
+	Sessions      int
+	Prompts       int
+	TotalTokens   int64
+	EstimatedCost float64
+	SharePercent  float64
+}
+
+// Session origins, as reported in OriginStats.
+const (
+	OriginInteractive = "Interactive"
+	OriginAutomation  = "Automation"
+)
+
+// MetricCard is what a custom metric (see pipeline.RegisterMetric) reports
+// for a period: a titled list of labeled values, shown as a dashboard card
+// and in `cburn summary`.
+type MetricCard struct {
+	Title string
+	Rows  []MetricRow
+}
+
+// MetricRow is one labeled value on a MetricCard, formatted by the metric.
+type MetricRow struct {
+	Label string
+	Value string
+}
+
+// SubagentStats holds aggregated metrics for one subagent type across
+// sessions.
+type SubagentStats struct {
+	Type          string // e.g. "acompact"; "task" for plain Task agents
+	Runs          int    // subagent sessions
+	Parents       int    // distinct sessions that spawned them
+	APICalls      int
+	TotalTokens   int64
+	EstimatedCost float64
+	SharePercent  float64 // of all subagent cost
+}
+
+// TierStats holds aggregated metrics for a single API service tier.
+type TierStats struct {
+	Tier          string
+	APICalls      int
+	EstimatedCost float64
+	ListCost      float64 // the same calls at standard-tier prices
+	SharePercent  float64
+}
+
+// ModelCoverage describes one normalized model seen in the data and whether
+// cburn knows how to price it.
+type ModelCoverage struct {
+	Model         string   // normalized name, as used for pricing
+	RawNames      []string // model IDs as reported by the API
+	Priced        bool
+	FirstSeen     time.Time
+	LastSeen      time.Time
+	Sessions      int
+	APICalls      int
+	EstimatedCost float64
+}
+
+// BranchStats holds aggregated metrics for a single repo + git branch.
+type BranchStats struct {
+	Repo          string
+	Branch        string
+	Sessions      int
+	Prompts       int
+	TotalTokens   int64
+	EstimatedCost float64
+}
+
 // HourlyStats holds prompt/session counts for one hour of the day.
 type HourlyStats struct {
 	Hour     int
@@ -26,20 +26,52 @@ type ModelUsage struct { //nolint:revive // renaming would break many call sites
 	CacheCreation1hTokens int64
 	CacheReadTokens       int64
 	EstimatedCost         float64
+	RawNames              []string // distinct model IDs as reported, before normalization
+
+	// TurnDurationsMs holds the duration of each turn this model answered.
+	TurnDurationsMs []int64
+}
+
+// TierUsage tracks API calls and cost for one service tier within a session.
+type TierUsage struct {
+	APICalls      int
+	EstimatedCost float64
 }
 
+// ContextCurvePoints bounds the length of SessionStats.ContextCurve.
+const ContextCurvePoints = 48
+
+// MinIdleGapSecs is the shortest pause recorded in SessionStats.IdleGaps.
+const MinIdleGapSecs = 60
+
 // SessionStats holds aggregated metrics for a single session file.
 type SessionStats struct {
 	SessionID     string
 	Project       string
 	ProjectPath   string
+	Repo          string // git repository name resolved from ProjectPath
+	GitBranch     string // git branch recorded by Claude Code, if any
+	Entrypoint    string // how Claude Code was started: "cli", or "sdk-..." for headless runs
+	Tag           string // cost allocation tag from [projects] rules (not cached)
+	Automation    string // why the session counts as automated, "" if interactive (not cached)
+	Source        string // import label for sessions from another machine; "" for local
 	FilePath      string
+	FileModTime   time.Time // session file mtime when last scanned
 	IsSubagent    bool
 	ParentSession string
 	StartTime     time.Time
 	EndTime       time.Time
 	DurationSecs  int64
 
+	// RawDurationSecs is the duration before gap-aware mode excluded idle
+	// gaps from DurationSecs (see pipeline.ApplyGapAware); 0 when the mode
+	// is off.
+	RawDurationSecs int64
+
+	// FirstMessageID is the first API message ID in the file. Files with the
+	// same SessionID and FirstMessageID are copies of one session.
+	FirstMessageID string
+
 	UserMessages int
 	APICalls     int
 
@@ -50,7 +82,38 @@ type SessionStats struct {
 	CacheReadTokens       int64
 
 	Models map[string]*ModelUsage
+	Tiers  map[string]*TierUsage // by service tier ("standard", "batch", ...)
 
 	EstimatedCost float64
 	CacheHitRate  float64
+
+	// Context size is a call's whole prompt: input, cache read, and cache
+	// write tokens. ContextCurve follows it over the session in call order,
+	// reduced to at most ContextCurvePoints values by keeping each span's peak.
+	PeakContext  int64
+	ContextCurve []int64
+
+	// Compactions counts the times Claude Code compacted the conversation.
+	Compactions int
+
+	// RepeatedCalls is the longest run of back-to-back API calls with the
+	// same input and output token counts, under a minute apart (0 if none):
+	// the signature of an agent stuck repeating one step.
+	RepeatedCalls int
+
+	// ClaudeSecs sums the turn durations Claude Code logged, the time Claude
+	// spent working (0 when the log has none). IdleGaps lists, in seconds,
+	// every pause of at least MinIdleGapSecs between consecutive entries, so
+	// active time can be estimated for any idle threshold.
+	ClaudeSecs int64
+	IdleGaps   []int64
+
+	// Derived after load by pipeline.ScoreEfficiency (not cached).
+	CacheSavings    float64 // USD saved by cache reads versus uncached input
+	EfficiencyScore int     // 1-100 against the project's other sessions; 0 if unscored
+
+	// Set from the cache's session annotations, kept apart from the session row.
+	Note     string   // free-form user note
+	UserTags []string // user tags, e.g. "billable"
+	Excluded bool     // hidden by the user from every total
 }
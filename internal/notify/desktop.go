@@ -0,0 +1,38 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// ErrDesktopUnsupported indicates no desktop notifier is available here.
+var ErrDesktopUnsupported = errors.New("desktop notifications are not supported on this platform")
+
+// Desktop shows n as a desktop notification: notify-send on Linux and other
+// freedesktop systems, osascript on macOS.
+func Desktop(ctx context.Context, n Notification) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		script := "display notification " + appleScriptString(n.Text) + " with title " + appleScriptString(n.Title)
+		cmd = exec.CommandContext(ctx, "osascript", "-e", script) //nolint:gosec // fixed binary; text is quoted above
+	case "windows":
+		return ErrDesktopUnsupported
+	default:
+		if _, err := exec.LookPath("notify-send"); err != nil {
+			return ErrDesktopUnsupported
+		}
+		cmd = exec.CommandContext(ctx, "notify-send", "--app-name=cburn", n.Title, n.Text) //nolint:gosec // fixed binary; args are not parsed by a shell
+	}
+	return cmd.Run()
+}
+
+// appleScriptString quotes s as an AppleScript string literal.
+func appleScriptString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
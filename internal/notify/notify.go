@@ -0,0 +1,193 @@
+// Package notify delivers usage notifications to Slack, Discord, or generic
+// JSON webhooks.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/config"
+)
+
+// Event kinds a webhook can subscribe to.
+const (
+	KindUsage     = "usage"
+	KindBudget    = "budget"
+	KindRateLimit = "rate_limit"
+	KindRLReset   = "rate_limit_reset"
+	KindAnomaly   = "anomaly"
+	KindRunaway   = "runaway"
+	KindTest      = "test"
+)
+
+// Payload formats.
+const (
+	FormatJSON    = "json"
+	FormatSlack   = "slack"
+	FormatDiscord = "discord"
+)
+
+// Notification is one message sent to webhooks. The generic JSON format
+// posts it as-is.
+type Notification struct {
+	Kind   string             `json:"kind"`
+	Title  string             `json:"title"`
+	Text   string             `json:"text"`
+	At     time.Time          `json:"at"`
+	Values map[string]float64 `json:"values,omitempty"`
+}
+
+// Sender posts notifications with retry.
+type Sender struct {
+	HTTP     *http.Client
+	Attempts int           // total tries per webhook
+	Backoff  time.Duration // doubled after each failed try
+}
+
+// NewSender returns a Sender with sensible defaults: 3 attempts, 1s backoff.
+func NewSender() *Sender {
+	return &Sender{
+		HTTP:     &http.Client{Timeout: 10 * time.Second},
+		Attempts: 3,
+		Backoff:  time.Second,
+	}
+}
+
+// Wants reports whether wh subscribes to kind. Test notifications always go through.
+func Wants(wh config.Webhook, kind string) bool {
+	if kind == KindTest || len(wh.Events) == 0 {
+		return true
+	}
+	for _, e := range wh.Events {
+		if strings.EqualFold(e, kind) {
+			return true
+		}
+	}
+	return false
+}
+
+// FormatFor returns the payload format for wh, detecting Slack and Discord
+// webhook URLs when no format is configured.
+func FormatFor(wh config.Webhook) string {
+	if wh.Format != "" {
+		return strings.ToLower(wh.Format)
+	}
+	u, err := url.Parse(wh.URL)
+	if err != nil {
+		return FormatJSON
+	}
+	host := strings.ToLower(u.Hostname())
+	switch {
+	case host == "hooks.slack.com":
+		return FormatSlack
+	case strings.HasSuffix(host, "discord.com") && strings.Contains(u.Path, "/api/webhooks/"):
+		return FormatDiscord
+	}
+	return FormatJSON
+}
+
+// Body encodes n in the given payload format.
+func Body(format string, n Notification) ([]byte, error) {
+	switch format {
+	case FormatSlack:
+		return json.Marshal(map[string]string{"text": "*" + n.Title + "*\n" + n.Text})
+	case FormatDiscord:
+		return json.Marshal(map[string]string{"content": "**" + n.Title + "**\n" + n.Text})
+	case FormatJSON:
+		return json.Marshal(n)
+	default:
+		return nil, fmt.Errorf("unknown webhook format %q", format)
+	}
+}
+
+// Send posts n to wh, retrying network errors, 429s, and 5xx responses.
+func (s *Sender) Send(ctx context.Context, wh config.Webhook, n Notification) error {
+	body, err := Body(FormatFor(wh), n)
+	if err != nil {
+		return err
+	}
+
+	attempts := s.Attempts
+	if attempts < 1 {
+		attempts = 1
+	}
+	backoff := s.Backoff
+
+	var lastErr error
+	for i := 0; i < attempts; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		retry, err := s.post(ctx, wh.URL, body)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if !retry {
+			break
+		}
+	}
+	return fmt.Errorf("webhook %s: %w", redactURL(wh.URL), lastErr)
+}
+
+// SendAll posts n to every webhook subscribed to its kind and returns the
+// errors encountered.
+func (s *Sender) SendAll(ctx context.Context, hooks []config.Webhook, n Notification) []error {
+	var errs []error
+	for _, wh := range hooks {
+		if wh.URL == "" || !Wants(wh, n.Kind) {
+			continue
+		}
+		if err := s.Send(ctx, wh, n); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+func (s *Sender) post(ctx context.Context, target string, body []byte) (retry bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "cburn")
+
+	resp, err := s.HTTP.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, fmt.Errorf("HTTP %d", resp.StatusCode)
+	default:
+		return false, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+}
+
+// redactURL keeps only scheme and host: webhook paths usually embed secrets.
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return "(invalid url)"
+	}
+	return u.Scheme + "://" + u.Host + "/…"
+}
@@ -0,0 +1,80 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/config"
+)
+
+func TestFormatFor(t *testing.T) {
+	tests := []struct {
+		wh   config.Webhook
+		want string
+	}{
+		{config.Webhook{URL: "https://hooks.slack.com/services/T/B/X"}, FormatSlack},
+		{config.Webhook{URL: "https://discord.com/api/webhooks/1/abc"}, FormatDiscord},
+		{config.Webhook{URL: "https://example.com/hook"}, FormatJSON},
+		{config.Webhook{URL: "https://example.com/hook", Format: "Slack"}, FormatSlack},
+	}
+	for _, tt := range tests {
+		if got := FormatFor(tt.wh); got != tt.want {
+			t.Errorf("FormatFor(%q) = %q, want %q", tt.wh.URL, got, tt.want)
+		}
+	}
+}
+
+func TestWants(t *testing.T) {
+	wh := config.Webhook{Events: []string{"budget"}}
+	if !Wants(wh, KindBudget) || Wants(wh, KindUsage) || !Wants(wh, KindTest) {
+		t.Error("event filtering mismatch")
+	}
+	if !Wants(config.Webhook{}, KindRateLimit) {
+		t.Error("empty events should match everything")
+	}
+}
+
+func TestSend_RetriesServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	var got Notification
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	s := &Sender{HTTP: srv.Client(), Attempts: 3, Backoff: time.Millisecond}
+	n := Notification{Kind: KindUsage, Title: "t", Text: "x"}
+	if err := s.Send(context.Background(), config.Webhook{URL: srv.URL}, n); err != nil {
+		t.Fatal(err)
+	}
+	if calls.Load() != 3 || got.Kind != KindUsage {
+		t.Errorf("calls = %d, payload = %+v", calls.Load(), got)
+	}
+}
+
+func TestSend_NoRetryOnClientError(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	s := &Sender{HTTP: srv.Client(), Attempts: 3, Backoff: time.Millisecond}
+	if err := s.Send(context.Background(), config.Webhook{URL: srv.URL}, Notification{}); err == nil {
+		t.Fatal("expected error for 404")
+	}
+	if calls.Load() != 1 {
+		t.Errorf("calls = %d, want 1", calls.Load())
+	}
+}
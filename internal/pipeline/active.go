@@ -0,0 +1,94 @@
+package pipeline
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+// DefaultIdleGap is the pause between log entries after which the time in
+// between is treated as away from the keyboard rather than active.
+const DefaultIdleGap = 5 * time.Minute
+
+var (
+	idleGap  atomic.Int64 // nanoseconds; 0 means DefaultIdleGap
+	gapAware atomic.Bool
+)
+
+// SetIdleGap sets the pause treated as idle by the active-time metrics and
+// gap-aware durations. d <= 0 restores DefaultIdleGap.
+func SetIdleGap(d time.Duration) {
+	idleGap.Store(int64(max(d, 0)))
+}
+
+// IdleGap returns the configured idle threshold.
+func IdleGap() time.Duration {
+	if d := time.Duration(idleGap.Load()); d > 0 {
+		return d
+	}
+	return DefaultIdleGap
+}
+
+// SetGapAware turns gap-aware durations on or off for subsequent loads.
+func SetGapAware(on bool) { gapAware.Store(on) }
+
+// GapAware reports whether loads correct durations for idle gaps.
+func GapAware() bool { return gapAware.Load() }
+
+// ApplyGapAware shortens each session's DurationSecs to its active time
+// when that is smaller, keeping the original in RawDurationSecs. Sessions
+// timed by Claude Code's own turn durations are already free of idle time
+// and stay as they are. It does nothing unless gap-aware mode is on.
+func ApplyGapAware(sessions []model.SessionStats) {
+	if !GapAware() {
+		return
+	}
+	idle := IdleGap()
+	for i := range sessions {
+		correctDuration(&sessions[i], idle)
+	}
+}
+
+// gapAdjusted is ApplyGapAware for a single session.
+func gapAdjusted(s model.SessionStats) model.SessionStats {
+	if GapAware() {
+		correctDuration(&s, IdleGap())
+	}
+	return s
+}
+
+func correctDuration(s *model.SessionStats, idle time.Duration) {
+	if s.RawDurationSecs != 0 {
+		return // already corrected
+	}
+	s.RawDurationSecs = s.DurationSecs
+	if active := ActiveSecs(*s, idle); active > 0 && active < s.DurationSecs {
+		s.DurationSecs = active
+	}
+}
+
+// ActiveSecs estimates the time spent actively in s: its wall-clock span
+// minus every pause between entries longer than idle.
+func ActiveSecs(s model.SessionStats, idle time.Duration) int64 {
+	if s.StartTime.IsZero() || s.EndTime.IsZero() {
+		return 0
+	}
+	secs := int64(s.EndTime.Sub(s.StartTime).Seconds())
+	limit := int64(idle.Seconds())
+	for _, gap := range s.IdleGaps {
+		if gap > limit {
+			secs -= gap
+		}
+	}
+	return max(secs, 0)
+}
+
+// MinutesPerDollar returns active minutes per dollar spent, or 0 when
+// nothing was spent.
+func MinutesPerDollar(activeSecs int64, cost float64) float64 {
+	if cost <= 0 {
+		return 0
+	}
+	return float64(activeSecs) / 60 / cost
+}
@@ -0,0 +1,61 @@
+package pipeline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+func TestActiveSecs(t *testing.T) {
+	start := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	s := model.SessionStats{
+		StartTime: start,
+		EndTime:   start.Add(2 * time.Hour),
+		IdleGaps:  []int64{90, 240, 1800, 3000}, // 1.5m, 4m, 30m, 50m
+	}
+
+	if got := ActiveSecs(s, DefaultIdleGap); got != 7200-1800-3000 {
+		t.Errorf("ActiveSecs(5m) = %d, want %d", got, 7200-1800-3000)
+	}
+	if got := ActiveSecs(s, time.Hour); got != 7200 {
+		t.Errorf("ActiveSecs(1h) = %d, want 7200", got)
+	}
+	if got := ActiveSecs(model.SessionStats{}, DefaultIdleGap); got != 0 {
+		t.Errorf("ActiveSecs(no times) = %d, want 0", got)
+	}
+	if got := MinutesPerDollar(2400, 4); got != 10 {
+		t.Errorf("MinutesPerDollar = %v, want 10", got)
+	}
+}
+
+func TestApplyGapAware(t *testing.T) {
+	start := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	wall := model.SessionStats{
+		StartTime:    start,
+		EndTime:      start.Add(2 * time.Hour),
+		DurationSecs: 7200,
+		IdleGaps:     []int64{1800, 600},
+	}
+	timed := wall
+	timed.DurationSecs = 900 // from turn durations, already below the active span
+
+	sessions := []model.SessionStats{wall, timed}
+	ApplyGapAware(sessions)
+	if sessions[0].DurationSecs != 7200 || sessions[0].RawDurationSecs != 0 {
+		t.Fatalf("mode off changed durations: %+v", sessions[0])
+	}
+
+	SetGapAware(true)
+	SetIdleGap(15 * time.Minute)
+	defer func() { SetGapAware(false); SetIdleGap(0) }()
+
+	ApplyGapAware(sessions)
+	ApplyGapAware(sessions) // idempotent
+	if got := sessions[0]; got.DurationSecs != 7200-1800 || got.RawDurationSecs != 7200 {
+		t.Errorf("wall-clock session = %d (raw %d), want %d (raw 7200)", got.DurationSecs, got.RawDurationSecs, 7200-1800)
+	}
+	if got := sessions[1]; got.DurationSecs != 900 || got.RawDurationSecs != 900 {
+		t.Errorf("turn-timed session = %d (raw %d), want 900 (raw 900)", got.DurationSecs, got.RawDurationSecs)
+	}
+}
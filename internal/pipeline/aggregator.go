@@ -2,6 +2,8 @@
 package pipeline
 
 import (
+	"maps"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -13,31 +15,56 @@ import (
 // Aggregate computes summary statistics from a slice of session stats,
 // filtered to sessions within the given time range.
 func Aggregate(sessions []model.SessionStats, since, until time.Time) model.SummaryStats {
-	filtered := FilterByTime(sessions, since, until)
+	acc := newSummaryAcc()
+	for _, s := range FilterByTime(sessions, since, until) {
+		acc.add(s)
+	}
+	return acc.summary()
+}
 
-	var stats model.SummaryStats
-	activeDays := make(map[string]struct{})
+// summaryAcc accumulates model.SummaryStats one session at a time.
+type summaryAcc struct {
+	stats      model.SummaryStats
+	activeDays map[string]struct{}
+}
 
-	for _, s := range filtered {
-		stats.TotalSessions++
-		stats.TotalPrompts += s.UserMessages
-		stats.TotalAPICalls += s.APICalls
-		stats.TotalDurationSecs += s.DurationSecs
+func newSummaryAcc() *summaryAcc {
+	return &summaryAcc{activeDays: make(map[string]struct{})}
+}
 
-		stats.InputTokens += s.InputTokens
-		stats.OutputTokens += s.OutputTokens
-		stats.CacheCreation5mTokens += s.CacheCreation5mTokens
-		stats.CacheCreation1hTokens += s.CacheCreation1hTokens
-		stats.CacheReadTokens += s.CacheReadTokens
-		stats.EstimatedCost += s.EstimatedCost
+func (a *summaryAcc) add(s model.SessionStats) {
+	stats := &a.stats
+	stats.TotalSessions++
+	stats.TotalPrompts += s.UserMessages
+	stats.TotalAPICalls += s.APICalls
+	stats.TotalDurationSecs += s.DurationSecs
+	if !s.IsSubagent {
+		stats.ActiveSecs += ActiveSecs(s, IdleGap())
+		stats.ClaudeSecs += s.ClaudeSecs
+	}
 
-		if !s.StartTime.IsZero() {
-			day := s.StartTime.Local().Format("2006-01-02")
-			activeDays[day] = struct{}{}
-		}
+	stats.InputTokens += s.InputTokens
+	stats.OutputTokens += s.OutputTokens
+	stats.CacheCreation5mTokens += s.CacheCreation5mTokens
+	stats.CacheCreation1hTokens += s.CacheCreation1hTokens
+	stats.CacheReadTokens += s.CacheReadTokens
+	stats.EstimatedCost += s.EstimatedCost
+
+	if !s.StartTime.IsZero() {
+		day := s.StartTime.Local().Format("2006-01-02")
+		a.activeDays[day] = struct{}{}
 	}
 
-	stats.ActiveDays = len(activeDays)
+	// Cache savings (sum across all models found in sessions)
+	for modelName, mu := range s.Models {
+		stats.CacheSavings += config.CalculateCacheSavingsAt(modelName, s.StartTime, mu.CacheReadTokens)
+	}
+}
+
+// summary derives the totals and rates from what has been added so far.
+func (a *summaryAcc) summary() model.SummaryStats {
+	stats := a.stats
+	stats.ActiveDays = len(a.activeDays)
 	stats.TotalBilledTokens = stats.InputTokens + stats.OutputTokens +
 		stats.CacheCreation5mTokens + stats.CacheCreation1hTokens
 
@@ -48,13 +75,6 @@ func Aggregate(sessions []model.SessionStats, since, until time.Time) model.Summ
 		stats.CacheHitRate = float64(stats.CacheReadTokens) / float64(totalCacheInput)
 	}
 
-	// Cache savings (sum across all models found in sessions)
-	for _, s := range filtered {
-		for modelName, mu := range s.Models {
-			stats.CacheSavings += config.CalculateCacheSavingsAt(modelName, s.StartTime, mu.CacheReadTokens)
-		}
-	}
-
 	// Per-active-day rates
 	if stats.ActiveDays > 0 {
 		days := float64(stats.ActiveDays)
@@ -70,54 +90,86 @@ func Aggregate(sessions []model.SessionStats, since, until time.Time) model.Summ
 
 // AggregateDays computes per-day statistics from sessions.
 func AggregateDays(sessions []model.SessionStats, since, until time.Time) []model.DailyStats {
-	filtered := FilterByTime(sessions, since, until)
+	acc := newDayAcc()
+	for _, s := range FilterByTime(sessions, since, until) {
+		acc.add(s)
+	}
+	return acc.days(since, until)
+}
 
-	dayMap := make(map[string]*model.DailyStats)
+// dayAcc accumulates per-day statistics one session at a time.
+type dayAcc struct {
+	dayMap map[string]*model.DailyStats
+}
 
-	for _, s := range filtered {
-		if s.StartTime.IsZero() {
-			continue
+func newDayAcc() *dayAcc {
+	return &dayAcc{dayMap: make(map[string]*model.DailyStats)}
+}
+
+func (a *dayAcc) add(s model.SessionStats) {
+	if s.StartTime.IsZero() {
+		return
+	}
+	dayKey := s.StartTime.Local().Format("2006-01-02")
+	ds, ok := a.dayMap[dayKey]
+	if !ok {
+		t, _ := time.ParseInLocation("2006-01-02", dayKey, time.Local)
+		ds = &model.DailyStats{Date: t}
+		a.dayMap[dayKey] = ds
+	}
+
+	ds.Sessions++
+	ds.Prompts += s.UserMessages
+	ds.APICalls += s.APICalls
+	ds.DurationSecs += s.DurationSecs
+	if !s.IsSubagent {
+		ds.ActiveSecs += ActiveSecs(s, IdleGap())
+		ds.ClaudeSecs += s.ClaudeSecs
+	}
+	ds.InputTokens += s.InputTokens
+	ds.OutputTokens += s.OutputTokens
+	ds.CacheCreation5m += s.CacheCreation5mTokens
+	ds.CacheCreation1h += s.CacheCreation1hTokens
+	ds.CacheReadTokens += s.CacheReadTokens
+	ds.EstimatedCost += s.EstimatedCost
+
+	for name, mu := range s.Models {
+		if ds.ModelTokens == nil {
+			ds.ModelTokens = make(map[string]int64)
 		}
-		dayKey := s.StartTime.Local().Format("2006-01-02")
-		ds, ok := dayMap[dayKey]
-		if !ok {
-			t, _ := time.ParseInLocation("2006-01-02", dayKey, time.Local)
-			ds = &model.DailyStats{Date: t}
-			dayMap[dayKey] = ds
-		}
-
-		ds.Sessions++
-		ds.Prompts += s.UserMessages
-		ds.APICalls += s.APICalls
-		ds.DurationSecs += s.DurationSecs
-		ds.InputTokens += s.InputTokens
-		ds.OutputTokens += s.OutputTokens
-		ds.CacheCreation5m += s.CacheCreation5mTokens
-		ds.CacheCreation1h += s.CacheCreation1hTokens
-		ds.CacheReadTokens += s.CacheReadTokens
-		ds.EstimatedCost += s.EstimatedCost
-	}
-
-	// Fill in every day in the range so the chart shows gaps as zeros
-	day := since.Local().Truncate(24 * time.Hour)
-	end := until.Local().Truncate(24 * time.Hour)
+		ds.ModelTokens[name] += mu.InputTokens + mu.OutputTokens +
+			mu.CacheCreation5mTokens + mu.CacheCreation1hTokens
+	}
+}
+
+// days returns a copy of the accumulated days, most recent first, with every
+// day in [since, until] present so charts show gaps as zeros.
+func (a *dayAcc) days(since, until time.Time) []model.DailyStats {
+	days := make([]model.DailyStats, 0, len(a.dayMap))
+	seen := make(map[string]struct{}, len(a.dayMap))
+	for key, ds := range a.dayMap {
+		d := *ds
+		if ds.ModelTokens != nil {
+			d.ModelTokens = maps.Clone(ds.ModelTokens)
+		}
+		days = append(days, d)
+		seen[key] = struct{}{}
+	}
+
+	// Fill in every day in the range
+	// (midnight in time.Local, not UTC, so non-UTC zones key the right days)
+	day := localMidnight(since)
+	end := localMidnight(until)
 	for !day.After(end) {
-		dayKey := day.Format("2006-01-02")
-		if _, ok := dayMap[dayKey]; !ok {
-			dayMap[dayKey] = &model.DailyStats{Date: day}
+		if _, ok := seen[day.Format("2006-01-02")]; !ok {
+			days = append(days, model.DailyStats{Date: day})
 		}
 		day = day.AddDate(0, 0, 1)
 	}
 
-	// Convert to sorted slice (most recent first)
-	days := make([]model.DailyStats, 0, len(dayMap))
-	for _, ds := range dayMap {
-		days = append(days, *ds)
-	}
 	sort.Slice(days, func(i, j int) bool {
 		return days[i].Date.After(days[j].Date)
 	})
-
 	return days
 }
 
@@ -152,6 +204,9 @@ func AggregateModels(sessions []model.SessionStats, since, until time.Time) []mo
 		if totalCalls > 0 {
 			ms.SharePercent = float64(ms.APICalls) / float64(totalCalls) * 100
 		}
+		if totalInput := ms.InputTokens + ms.CacheCreation5m + ms.CacheCreation1h + ms.CacheReadTokens; totalInput > 0 {
+			ms.CacheReadShare = float64(ms.CacheReadTokens) / float64(totalInput)
+		}
 		models = append(models, *ms)
 	}
 	sort.Slice(models, func(i, j int) bool {
@@ -170,7 +225,7 @@ func AggregateProjects(sessions []model.SessionStats, since, until time.Time) []
 	for _, s := range filtered {
 		ps, ok := projMap[s.Project]
 		if !ok {
-			ps = &model.ProjectStats{Project: s.Project}
+			ps = &model.ProjectStats{Project: s.Project, Tag: s.Tag}
 			projMap[s.Project] = ps
 		}
 		ps.Sessions++
@@ -192,6 +247,196 @@ func AggregateProjects(sessions []model.SessionStats, since, until time.Time) []
 	return projects
 }
 
+// ApplyTags sets each session's cost allocation tag from the [projects] rules.
+// Sessions are modified in place.
+func ApplyTags(sessions []model.SessionStats, pc config.ProjectsConfig) {
+	for i := range sessions {
+		sessions[i].Tag = pc.TagFor(sessions[i].Project, sessions[i].ProjectPath)
+	}
+}
+
+// AggregateTags computes per-tag statistics from sessions.
+// Untagged sessions are grouped under an empty Tag.
+func AggregateTags(sessions []model.SessionStats, since, until time.Time) []model.TagStats {
+	filtered := FilterByTime(sessions, since, until)
+
+	tagMap := make(map[string]*model.TagStats)
+	tagProjects := make(map[string]map[string]struct{})
+	var totalCost float64
+
+	for _, s := range filtered {
+		ts, ok := tagMap[s.Tag]
+		if !ok {
+			ts = &model.TagStats{Tag: s.Tag}
+			tagMap[s.Tag] = ts
+			tagProjects[s.Tag] = make(map[string]struct{})
+		}
+		tagProjects[s.Tag][s.Project] = struct{}{}
+		ts.Sessions++
+		ts.Prompts += s.UserMessages
+		ts.TotalTokens += s.InputTokens + s.OutputTokens +
+			s.CacheCreation5mTokens + s.CacheCreation1hTokens
+		ts.EstimatedCost += s.EstimatedCost
+		totalCost += s.EstimatedCost
+	}
+
+	tags := make([]model.TagStats, 0, len(tagMap))
+	for tag, ts := range tagMap {
+		ts.Projects = len(tagProjects[tag])
+		if totalCost > 0 {
+			ts.SharePercent = ts.EstimatedCost / totalCost * 100
+		}
+		tags = append(tags, *ts)
+	}
+	// Sort by cost descending
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].EstimatedCost > tags[j].EstimatedCost
+	})
+
+	return tags
+}
+
+// AggregateModelCoverage lists every normalized model in the sessions with
+// the raw IDs that mapped to it and whether it has known pricing. Unpriced
+// models sort first, then the most recently seen.
+func AggregateModelCoverage(sessions []model.SessionStats, since, until time.Time) []model.ModelCoverage {
+	filtered := FilterByTime(sessions, since, until)
+
+	covMap := make(map[string]*model.ModelCoverage)
+	for _, s := range filtered {
+		for modelName, mu := range s.Models {
+			mc, ok := covMap[modelName]
+			if !ok {
+				_, priced := config.LookupPricing(modelName)
+				mc = &model.ModelCoverage{Model: modelName, Priced: priced}
+				covMap[modelName] = mc
+			}
+			for _, raw := range mu.RawNames {
+				if !slices.Contains(mc.RawNames, raw) {
+					mc.RawNames = append(mc.RawNames, raw)
+				}
+			}
+			if !s.StartTime.IsZero() && (mc.FirstSeen.IsZero() || s.StartTime.Before(mc.FirstSeen)) {
+				mc.FirstSeen = s.StartTime
+			}
+			last := s.EndTime
+			if last.IsZero() {
+				last = s.StartTime
+			}
+			if last.After(mc.LastSeen) {
+				mc.LastSeen = last
+			}
+			mc.Sessions++
+			mc.APICalls += mu.APICalls
+			mc.EstimatedCost += mu.EstimatedCost
+		}
+	}
+
+	coverage := make([]model.ModelCoverage, 0, len(covMap))
+	for _, mc := range covMap {
+		if len(mc.RawNames) == 0 {
+			mc.RawNames = []string{mc.Model}
+		}
+		sort.Strings(mc.RawNames)
+		coverage = append(coverage, *mc)
+	}
+	sort.Slice(coverage, func(i, j int) bool {
+		if coverage[i].Priced != coverage[j].Priced {
+			return !coverage[i].Priced
+		}
+		if !coverage[i].LastSeen.Equal(coverage[j].LastSeen) {
+			return coverage[i].LastSeen.After(coverage[j].LastSeen)
+		}
+		return coverage[i].Model < coverage[j].Model
+	})
+	return coverage
+}
+
+// AggregateTiers computes per-service-tier statistics from sessions, most
+// expensive first. Sessions without a tier breakdown (e.g. imported from an
+// older cburn) count as standard tier.
+func AggregateTiers(sessions []model.SessionStats, since, until time.Time) []model.TierStats {
+	filtered := FilterByTime(sessions, since, until)
+
+	tierMap := make(map[string]*model.TierStats)
+	add := func(tier string, calls int, cost float64) {
+		ts, ok := tierMap[tier]
+		if !ok {
+			ts = &model.TierStats{Tier: tier}
+			tierMap[tier] = ts
+		}
+		ts.APICalls += calls
+		ts.EstimatedCost += cost
+		ts.ListCost += cost / config.TierMultiplier(tier)
+	}
+
+	var totalCost float64
+	for _, s := range filtered {
+		totalCost += s.EstimatedCost
+		if len(s.Tiers) == 0 {
+			add(config.TierStandard, s.APICalls, s.EstimatedCost)
+			continue
+		}
+		for tier, tu := range s.Tiers {
+			add(tier, tu.APICalls, tu.EstimatedCost)
+		}
+	}
+
+	tiers := make([]model.TierStats, 0, len(tierMap))
+	for _, ts := range tierMap {
+		if totalCost > 0 {
+			ts.SharePercent = ts.EstimatedCost / totalCost * 100
+		}
+		tiers = append(tiers, *ts)
+	}
+	sort.Slice(tiers, func(i, j int) bool {
+		if tiers[i].EstimatedCost != tiers[j].EstimatedCost {
+			return tiers[i].EstimatedCost > tiers[j].EstimatedCost
+		}
+		return tiers[i].Tier < tiers[j].Tier
+	})
+	return tiers
+}
+
+// AggregateBranches computes per-branch statistics from sessions.
+// Sessions without a recorded branch are grouped under an empty Branch;
+// Repo falls back to the project name when the cwd isn't a git repository.
+func AggregateBranches(sessions []model.SessionStats, since, until time.Time) []model.BranchStats {
+	filtered := FilterByTime(sessions, since, until)
+
+	type key struct{ repo, branch string }
+	branchMap := make(map[key]*model.BranchStats)
+
+	for _, s := range filtered {
+		repo := s.Repo
+		if repo == "" {
+			repo = s.Project
+		}
+		k := key{repo, s.GitBranch}
+		bs, ok := branchMap[k]
+		if !ok {
+			bs = &model.BranchStats{Repo: repo, Branch: s.GitBranch}
+			branchMap[k] = bs
+		}
+		bs.Sessions++
+		bs.Prompts += s.UserMessages
+		bs.TotalTokens += s.InputTokens + s.OutputTokens +
+			s.CacheCreation5mTokens + s.CacheCreation1hTokens
+		bs.EstimatedCost += s.EstimatedCost
+	}
+
+	// Sort by cost descending
+	branches := make([]model.BranchStats, 0, len(branchMap))
+	for _, bs := range branchMap {
+		branches = append(branches, *bs)
+	}
+	sort.Slice(branches, func(i, j int) bool {
+		return branches[i].EstimatedCost > branches[j].EstimatedCost
+	})
+
+	return branches
+}
+
 // AggregateHourly computes prompt counts by hour of day.
 func AggregateHourly(sessions []model.SessionStats, since, until time.Time) []model.HourlyStats {
 	filtered := FilterByTime(sessions, since, until)
@@ -223,20 +468,24 @@ func FilterByTime(sessions []model.SessionStats, since, until time.Time) []model
 
 	var result []model.SessionStats
 	for _, s := range sessions {
-		if s.StartTime.IsZero() {
-			continue
-		}
-		if !since.IsZero() && s.StartTime.Before(since) {
-			continue
-		}
-		if !until.IsZero() && !s.StartTime.Before(until) {
-			continue
+		if inRange(s, since, until) {
+			result = append(result, s)
 		}
-		result = append(result, s)
 	}
 	return result
 }
 
+// inRange reports whether s started in [since, until); a zero bound is open.
+func inRange(s model.SessionStats, since, until time.Time) bool {
+	if s.StartTime.IsZero() {
+		return false
+	}
+	if !since.IsZero() && s.StartTime.Before(since) {
+		return false
+	}
+	return until.IsZero() || s.StartTime.Before(until)
+}
+
 // FilterByProject returns sessions matching the project substring.
 func FilterByProject(sessions []model.SessionStats, project string) []model.SessionStats {
 	if project == "" {
@@ -268,6 +517,41 @@ func FilterByModel(sessions []model.SessionStats, modelFilter string) []model.Se
 	return result
 }
 
+// WithoutExcluded returns the sessions the user has not hidden from totals.
+func WithoutExcluded(sessions []model.SessionStats) []model.SessionStats {
+	var result []model.SessionStats
+	for _, s := range sessions {
+		if !s.Excluded {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// ActiveWindow is how recently a session file must have been written for the
+// session to count as live.
+const ActiveWindow = 5 * time.Minute
+
+// IsActive reports whether a local session's file was modified within
+// ActiveWindow of now. Imported sessions are never active.
+func IsActive(s model.SessionStats, now time.Time) bool {
+	if s.Source != "" || s.FileModTime.IsZero() {
+		return false
+	}
+	return now.Sub(s.FileModTime) < ActiveWindow
+}
+
+// CountActive returns the number of top-level sessions that are live.
+func CountActive(sessions []model.SessionStats, now time.Time) int {
+	n := 0
+	for _, s := range sessions {
+		if !s.IsSubagent && IsActive(s, now) {
+			n++
+		}
+	}
+	return n
+}
+
 func containsIgnoreCase(s, substr string) bool {
 	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
 }
@@ -330,3 +614,9 @@ func AggregateLastHour(sessions []model.SessionStats) []model.MinuteStats {
 	}
 	return buckets
 }
+
+// localMidnight returns the start of t's day in time.Local.
+func localMidnight(t time.Time) time.Time {
+	t = t.Local()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
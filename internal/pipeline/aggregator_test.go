@@ -0,0 +1,64 @@
+package pipeline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+func TestAggregateModelCoverage(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 6, d, 12, 0, 0, 0, time.UTC) }
+	sessions := []model.SessionStats{
+		{StartTime: day(1), EndTime: day(1), Models: map[string]*model.ModelUsage{
+			"claude-sonnet-4-6": {APICalls: 2, EstimatedCost: 1, RawNames: []string{"claude-sonnet-4-6"}},
+		}},
+		{StartTime: day(3), EndTime: day(4), Models: map[string]*model.ModelUsage{
+			"claude-sonnet-4-6":         {APICalls: 1, EstimatedCost: 0.5, RawNames: []string{"claude-sonnet-4-6-20260101"}},
+			"claude-mystery-9-20990101": {APICalls: 4},
+		}},
+	}
+
+	got := AggregateModelCoverage(sessions, time.Time{}, time.Time{})
+	if len(got) != 2 {
+		t.Fatalf("got %d models, want 2: %+v", len(got), got)
+	}
+	if got[0].Model != "claude-mystery-9-20990101" || got[0].Priced {
+		t.Errorf("first = %s (priced %v), want the unpriced model first", got[0].Model, got[0].Priced)
+	}
+	if len(got[0].RawNames) != 1 || got[0].RawNames[0] != got[0].Model {
+		t.Errorf("raw names without cached IDs = %v, want the model name", got[0].RawNames)
+	}
+
+	sonnet := got[1]
+	if !sonnet.Priced || sonnet.Sessions != 2 || sonnet.APICalls != 3 || sonnet.EstimatedCost != 1.5 {
+		t.Errorf("sonnet = %+v, want priced, 2 sessions, 3 calls, $1.50", sonnet)
+	}
+	if len(sonnet.RawNames) != 2 {
+		t.Errorf("sonnet raw names = %v, want both IDs", sonnet.RawNames)
+	}
+	if !sonnet.FirstSeen.Equal(day(1)) || !sonnet.LastSeen.Equal(day(4)) {
+		t.Errorf("seen %v..%v, want %v..%v", sonnet.FirstSeen, sonnet.LastSeen, day(1), day(4))
+	}
+}
+
+func TestAggregateDays_FillsLocalMidnights(t *testing.T) {
+	saved := time.Local
+	time.Local = time.FixedZone("UTC-5", -5*3600)
+	t.Cleanup(func() { time.Local = saved })
+
+	since := time.Date(2025, 6, 1, 3, 0, 0, 0, time.UTC) // May 31, 22:00 local
+	until := time.Date(2025, 6, 2, 3, 0, 0, 0, time.UTC) // June 1, 22:00 local
+	days := AggregateDays(nil, since, until)
+	if len(days) != 2 {
+		t.Fatalf("got %d days, want 2", len(days))
+	}
+	for _, d := range days {
+		if h, m, _ := d.Date.Clock(); h != 0 || m != 0 || d.Date.Location() != time.Local {
+			t.Errorf("day %v is not a local midnight", d.Date)
+		}
+	}
+	if got := days[1].Date.Format("2006-01-02"); got != "2025-05-31" {
+		t.Errorf("first day = %s, want 2025-05-31", got)
+	}
+}
@@ -0,0 +1,69 @@
+package pipeline
+
+import (
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+const (
+	// AnomalyFactor is how many times the trailing median a day's spend
+	// must reach to count as an anomaly.
+	AnomalyFactor = 3.0
+	// anomalyBaselineDays is how many days before a day its median covers.
+	anomalyBaselineDays = 7
+	// anomalyMinCost keeps light days from tripping the detector: $2 against
+	// a $0.50 median is not a runaway agent loop.
+	anomalyMinCost = 5.0
+)
+
+// CostAnomaly is a day whose spend spiked against the days before it.
+type CostAnomaly struct {
+	Date   time.Time // local midnight
+	Cost   float64
+	Median float64 // median daily cost over the anomalyBaselineDays before Date
+}
+
+// Ratio returns Cost as a multiple of Median.
+func (c CostAnomaly) Ratio() float64 {
+	if c.Median <= 0 {
+		return 0
+	}
+	return c.Cost / c.Median
+}
+
+// FindCostAnomalies returns the days from since through until whose cost is
+// at least AnomalyFactor times the median of the 7 days before, most recent
+// first. Days without a baseline (a median of 0) never count.
+func FindCostAnomalies(sessions []model.SessionStats, since, until time.Time) []CostAnomaly {
+	from := localMidnight(since)
+	days := AggregateDays(sessions, from.AddDate(0, 0, -anomalyBaselineDays), until)
+
+	// days is most recent first, so each day's baseline is the slice after it.
+	costs := make([]float64, len(days))
+	for i, d := range days {
+		costs[i] = d.EstimatedCost
+	}
+	var out []CostAnomaly
+	for i, d := range days {
+		if d.Date.Before(from) || i+anomalyBaselineDays >= len(days) {
+			continue
+		}
+		base := median(costs[i+1 : i+1+anomalyBaselineDays])
+		if base <= 0 || d.EstimatedCost < anomalyMinCost || d.EstimatedCost < AnomalyFactor*base {
+			continue
+		}
+		out = append(out, CostAnomaly{Date: d.Date, Cost: d.EstimatedCost, Median: base})
+	}
+	return out
+}
+
+// TodayAnomaly reports whether today's spend so far is already an anomaly.
+func TodayAnomaly(sessions []model.SessionStats, now time.Time) (CostAnomaly, bool) {
+	for _, a := range FindCostAnomalies(sessions, now, now) {
+		if a.Date.Equal(localMidnight(now)) {
+			return a, true
+		}
+	}
+	return CostAnomaly{}, false
+}
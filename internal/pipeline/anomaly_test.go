@@ -0,0 +1,40 @@
+package pipeline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+func TestFindCostAnomalies(t *testing.T) {
+	now := time.Date(2026, 3, 20, 15, 0, 0, 0, time.Local)
+	day := func(back int, cost float64) model.SessionStats {
+		at := now.AddDate(0, 0, -back).Add(-time.Hour)
+		return model.SessionStats{StartTime: at, EndTime: at, EstimatedCost: cost}
+	}
+	var sessions []model.SessionStats
+	for back := 1; back <= 10; back++ {
+		sessions = append(sessions, day(back, 4))
+	}
+	sessions = append(sessions, day(0, 15), day(5, 9)) // today: 15 vs a median of 4
+
+	got := FindCostAnomalies(sessions, now.AddDate(0, 0, -2), now)
+	if len(got) != 1 {
+		t.Fatalf("anomalies = %+v, want today only", got)
+	}
+	if a := got[0]; !a.Date.Equal(localMidnight(now)) || a.Cost != 15 || a.Median != 4 || a.Ratio() != 3.75 {
+		t.Errorf("anomaly = %+v (ratio %v)", a, a.Ratio())
+	}
+	if _, ok := TodayAnomaly(sessions, now); !ok {
+		t.Error("TodayAnomaly missed today")
+	}
+
+	// Below anomalyMinCost, or without history to compare to, nothing fires.
+	if got := FindCostAnomalies([]model.SessionStats{day(0, 4), day(1, 1)}, now, now); len(got) != 0 {
+		t.Errorf("small spend flagged: %+v", got)
+	}
+	if _, ok := TodayAnomaly([]model.SessionStats{day(0, 100)}, now); ok {
+		t.Error("anomaly without a baseline")
+	}
+}
@@ -0,0 +1,105 @@
+package pipeline
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/config"
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+// ciPathMarkers are working directory fragments that CI runners check code
+// out under: GitHub Actions (hosted and in containers), GitLab, Jenkins,
+// Buildkite, and CircleCI.
+var ciPathMarkers = []string{
+	"/home/runner/work/",
+	"/github/workspace",
+	"/__w/",
+	"/builds/",
+	"/jenkins/workspace/",
+	"/var/lib/buildkite-agent/builds/",
+	"/home/circleci/",
+}
+
+// AutomationReason returns why a session looks like an automated run, or ""
+// if it looks interactive: it was started through the SDK or `claude -p`
+// (an "sdk-" entrypoint), it ran in a CI checkout, its project matches a
+// [projects] automation pattern, or it made API calls with no user entries.
+func AutomationReason(s model.SessionStats, pc config.ProjectsConfig) string {
+	switch {
+	case strings.HasPrefix(s.Entrypoint, "sdk"):
+		return "headless (" + s.Entrypoint + ")"
+	case pc.IsAutomation(s.Project, s.ProjectPath):
+		return "automation rule"
+	}
+	for _, m := range ciPathMarkers {
+		if strings.Contains(s.ProjectPath, m) {
+			return "CI checkout"
+		}
+	}
+	if !s.IsSubagent && s.UserMessages == 0 && s.APICalls > 0 {
+		return "no user prompts"
+	}
+	return ""
+}
+
+// ApplyAutomation sets each session's Automation reason. Subagents of an
+// automated session are automated too. Sessions are modified in place.
+func ApplyAutomation(sessions []model.SessionStats, pc config.ProjectsConfig) {
+	parents := make(map[string]string)
+	for i := range sessions {
+		s := &sessions[i]
+		s.Automation = AutomationReason(*s, pc)
+		if s.Automation != "" && !s.IsSubagent {
+			parents[s.SessionID] = s.Automation
+		}
+	}
+	for i := range sessions {
+		s := &sessions[i]
+		if s.IsSubagent && s.Automation == "" {
+			s.Automation = parents[s.ParentSession]
+		}
+	}
+}
+
+// AggregateOrigins splits sessions into interactive and automated spend,
+// most expensive first. Origins with no sessions are left out.
+func AggregateOrigins(sessions []model.SessionStats, since, until time.Time) []model.OriginStats {
+	byOrigin := make(map[string]*model.OriginStats)
+	projects := make(map[string]map[string]struct{})
+	var totalCost float64
+
+	for _, s := range FilterByTime(sessions, since, until) {
+		origin := model.OriginInteractive
+		if s.Automation != "" {
+			origin = model.OriginAutomation
+		}
+		st, ok := byOrigin[origin]
+		if !ok {
+			st = &model.OriginStats{Origin: origin}
+			byOrigin[origin] = st
+			projects[origin] = make(map[string]struct{})
+		}
+		projects[origin][s.Project] = struct{}{}
+		st.Sessions++
+		st.Prompts += s.UserMessages
+		st.TotalTokens += s.InputTokens + s.OutputTokens +
+			s.CacheCreation5mTokens + s.CacheCreation1hTokens
+		st.EstimatedCost += s.EstimatedCost
+		totalCost += s.EstimatedCost
+	}
+
+	origins := make([]model.OriginStats, 0, len(byOrigin))
+	for origin, st := range byOrigin {
+		st.Projects = len(projects[origin])
+		if totalCost > 0 {
+			st.SharePercent = st.EstimatedCost / totalCost * 100
+		}
+		origins = append(origins, *st)
+	}
+	sort.Slice(origins, func(i, j int) bool {
+		return origins[i].EstimatedCost > origins[j].EstimatedCost
+	})
+	return origins
+}
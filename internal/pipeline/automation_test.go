@@ -0,0 +1,59 @@
+package pipeline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/config"
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+func TestAutomationReason(t *testing.T) {
+	pc := config.ProjectsConfig{Automation: []string{"/srv/bots"}}
+	tests := []struct {
+		name string
+		s    model.SessionStats
+		want string
+	}{
+		{"interactive", model.SessionStats{Entrypoint: "cli", ProjectPath: "/home/me/api", UserMessages: 4, APICalls: 10}, ""},
+		{"sdk", model.SessionStats{Entrypoint: "sdk-cli", UserMessages: 1, APICalls: 3}, "headless (sdk-cli)"},
+		{"rule", model.SessionStats{Project: "triage", ProjectPath: "/srv/bots/triage", UserMessages: 2, APICalls: 3}, "automation rule"},
+		{"ci", model.SessionStats{ProjectPath: "/home/runner/work/api/api", UserMessages: 2, APICalls: 3}, "CI checkout"},
+		{"no prompts", model.SessionStats{ProjectPath: "/home/me/api", APICalls: 3}, "no user prompts"},
+		{"subagent", model.SessionStats{ProjectPath: "/home/me/api", IsSubagent: true, APICalls: 3}, ""},
+	}
+	for _, tt := range tests {
+		if got := AutomationReason(tt.s, pc); got != tt.want {
+			t.Errorf("%s: AutomationReason = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestApplyAutomationAndAggregateOrigins(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	start := now.Add(-time.Hour)
+	sessions := []model.SessionStats{
+		{SessionID: "ci", Project: "api", Entrypoint: "sdk-cli", StartTime: start, UserMessages: 1, APICalls: 5, EstimatedCost: 3},
+		{SessionID: "ci/agent-a1b2", ParentSession: "ci", IsSubagent: true, Project: "api", StartTime: start, APICalls: 2, EstimatedCost: 1},
+		{SessionID: "me", Project: "api", Entrypoint: "cli", StartTime: start, UserMessages: 6, APICalls: 20, EstimatedCost: 12},
+		{SessionID: "me/agent-c3d4", ParentSession: "me", IsSubagent: true, Project: "api", StartTime: start, APICalls: 2, EstimatedCost: 4},
+	}
+	ApplyAutomation(sessions, config.ProjectsConfig{})
+	if sessions[1].Automation == "" {
+		t.Error("subagent of a headless session should count as automated")
+	}
+	if sessions[3].Automation != "" {
+		t.Errorf("subagent of an interactive session: Automation = %q", sessions[3].Automation)
+	}
+
+	got := AggregateOrigins(sessions, now.AddDate(0, 0, -1), now)
+	if len(got) != 2 {
+		t.Fatalf("origins = %+v, want 2", got)
+	}
+	if got[0].Origin != model.OriginInteractive || got[0].EstimatedCost != 16 || got[0].SharePercent != 80 {
+		t.Errorf("first = %+v, want Interactive $16 (80%%)", got[0])
+	}
+	if got[1].Origin != model.OriginAutomation || got[1].Sessions != 2 || got[1].EstimatedCost != 4 {
+		t.Errorf("second = %+v, want Automation, 2 sessions, $4", got[1])
+	}
+}
@@ -1,9 +1,12 @@
 package pipeline
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/theirongolddev/cburn/internal/source"
 	"github.com/theirongolddev/cburn/internal/store"
@@ -15,7 +18,7 @@ func BenchmarkLoad(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		result, err := Load(claudeDir, true, nil)
+		result, err := Load([]source.Root{{Path: claudeDir}}, true, nil)
 		if err != nil {
 			b.Fatal(err)
 		}
@@ -23,6 +26,20 @@ func BenchmarkLoad(b *testing.B) {
 	}
 }
 
+func BenchmarkLoadStream(b *testing.B) {
+	homeDir, _ := os.UserHomeDir()
+	claudeDir := filepath.Join(homeDir, ".claude")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		agg := NewStreamAggregator(time.Time{}, time.Time{})
+		if _, err := LoadStream([]source.Root{{Path: claudeDir}}, true, nil, agg.Add); err != nil {
+			b.Fatal(err)
+		}
+		_ = agg.Summary()
+	}
+}
+
 func BenchmarkParseFile(b *testing.B) {
 	homeDir, _ := os.UserHomeDir()
 	claudeDir := filepath.Join(homeDir, ".claude")
@@ -83,10 +100,78 @@ func BenchmarkLoadWithCache(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		cr, err := LoadWithCache(claudeDir, true, cache, nil)
+		cr, err := LoadWithCache([]source.Root{{Path: claudeDir}}, true, cache, nil)
 		if err != nil {
 			b.Fatal(err)
 		}
 		_ = cr
 	}
 }
+
+// writeBenchCorpus lays out files session files under a temp Claude dir,
+// every tenth one long, and returns its root.
+func writeBenchCorpus(b *testing.B, files int) source.Root {
+	b.Helper()
+	claude := b.TempDir()
+	dir := filepath.Join(claude, "projects", "-home-me-app")
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		b.Fatal(err)
+	}
+	start := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+	for f := 0; f < files; f++ {
+		turns := 20
+		if f%10 == 0 {
+			turns = 1_000
+		}
+		var sb strings.Builder
+		for i := 0; i < turns; i++ {
+			ts := start.Add(time.Duration(f)*time.Hour + time.Duration(i)*time.Second).Format(time.RFC3339)
+			sb.WriteString(`{"type":"user","timestamp":"` + ts + `","cwd":"/home/me/app"}` + "\n")
+			fmt.Fprintf(&sb, `{"type":"assistant","timestamp":"%s","message":{"id":"msg_%d_%d","model":"claude-sonnet-4-6","usage":{"input_tokens":12,"output_tokens":340,"cache_read_input_tokens":24000}}}`+"\n", ts, f, i)
+		}
+		if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("s%04d.jsonl", f)), []byte(sb.String()), 0o600); err != nil {
+			b.Fatal(err)
+		}
+	}
+	return source.Root{Path: claude}
+}
+
+// BenchmarkLoadWithCache_Fixture times a load of a synthetic corpus into an
+// empty cache (every file parsed and saved) and a reload with nothing changed.
+func BenchmarkLoadWithCache_Fixture(b *testing.B) {
+	roots := []source.Root{writeBenchCorpus(b, 200)}
+
+	b.Run("cold", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			cache, err := store.Open(filepath.Join(b.TempDir(), "cache.db"))
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.StartTimer()
+			if _, err := LoadWithCache(roots, true, cache, nil); err != nil {
+				b.Fatal(err)
+			}
+			b.StopTimer()
+			_ = cache.Close()
+			b.StartTimer()
+		}
+	})
+
+	b.Run("warm", func(b *testing.B) {
+		cache, err := store.Open(filepath.Join(b.TempDir(), "cache.db"))
+		if err != nil {
+			b.Fatal(err)
+		}
+		defer func() { _ = cache.Close() }()
+		if _, err := LoadWithCache(roots, true, cache, nil); err != nil {
+			b.Fatal(err)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, err := LoadWithCache(roots, true, cache, nil); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
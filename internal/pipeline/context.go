@@ -0,0 +1,74 @@
+package pipeline
+
+import "github.com/theirongolddev/cburn/internal/model"
+
+// Claude Code runs a conversation in a 200K-token context window (1M for
+// long-context models) and compacts it automatically once a prompt fills
+// most of the window, which rewrites the cache from scratch.
+const (
+	standardContextWindow = 200_000
+	longContextWindow     = 1_000_000
+	autoCompactShare      = 0.8
+)
+
+// ContextWindow returns the context window s ran in, judged by its largest
+// prompt: one beyond the standard window implies a long-context model.
+func ContextWindow(s model.SessionStats) int64 {
+	if s.PeakContext > standardContextWindow {
+		return longContextWindow
+	}
+	return standardContextWindow
+}
+
+// ContextShare returns s's largest prompt as a fraction of its window.
+func ContextShare(s model.SessionStats) float64 {
+	return float64(s.PeakContext) / float64(ContextWindow(s))
+}
+
+// NearAutoCompact reports whether s's context grew to the size at which
+// Claude Code compacts automatically.
+func NearAutoCompact(s model.SessionStats) bool {
+	return ContextShare(s) >= autoCompactShare
+}
+
+// CompactionStats summarizes how often sessions were compacted and what
+// compacted sessions cost next to the rest.
+type CompactionStats struct {
+	Sessions    int // sessions considered
+	Compacted   int // sessions compacted at least once
+	Compactions int // compactions across all sessions
+
+	CompactedCost float64 // mean cost of a compacted session
+	OtherCost     float64 // mean cost of any other session
+}
+
+// Rate returns the share of sessions compacted at least once.
+func (c CompactionStats) Rate() float64 {
+	if c.Sessions == 0 {
+		return 0
+	}
+	return float64(c.Compacted) / float64(c.Sessions)
+}
+
+// Compactions tallies compactions across sessions.
+func Compactions(sessions []model.SessionStats) CompactionStats {
+	var c CompactionStats
+	var compactedCost, otherCost float64
+	for _, s := range sessions {
+		c.Sessions++
+		c.Compactions += s.Compactions
+		if s.Compactions > 0 {
+			c.Compacted++
+			compactedCost += s.EstimatedCost
+		} else {
+			otherCost += s.EstimatedCost
+		}
+	}
+	if c.Compacted > 0 {
+		c.CompactedCost = compactedCost / float64(c.Compacted)
+	}
+	if other := c.Sessions - c.Compacted; other > 0 {
+		c.OtherCost = otherCost / float64(other)
+	}
+	return c
+}
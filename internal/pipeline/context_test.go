@@ -0,0 +1,47 @@
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+func TestNearAutoCompact(t *testing.T) {
+	for _, tc := range []struct {
+		peak   int64
+		window int64
+		near   bool
+	}{
+		{0, 200_000, false},
+		{120_000, 200_000, false},
+		{165_000, 200_000, true},
+		{300_000, 1_000_000, false},
+		{850_000, 1_000_000, true},
+	} {
+		s := model.SessionStats{PeakContext: tc.peak}
+		if got := ContextWindow(s); got != tc.window {
+			t.Errorf("ContextWindow(%d) = %d, want %d", tc.peak, got, tc.window)
+		}
+		if got := NearAutoCompact(s); got != tc.near {
+			t.Errorf("NearAutoCompact(%d) = %v, want %v", tc.peak, got, tc.near)
+		}
+	}
+}
+
+func TestCompactions(t *testing.T) {
+	c := Compactions([]model.SessionStats{
+		{Compactions: 2, EstimatedCost: 12},
+		{Compactions: 1, EstimatedCost: 8},
+		{EstimatedCost: 2},
+		{EstimatedCost: 4},
+	})
+	if c.Sessions != 4 || c.Compacted != 2 || c.Compactions != 3 {
+		t.Errorf("counts = %+v", c)
+	}
+	if c.Rate() != 0.5 {
+		t.Errorf("Rate = %v, want 0.5", c.Rate())
+	}
+	if c.CompactedCost != 10 || c.OtherCost != 3 {
+		t.Errorf("mean costs = %v / %v, want 10 / 3", c.CompactedCost, c.OtherCost)
+	}
+}
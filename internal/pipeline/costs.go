@@ -56,6 +56,17 @@ func AggregateCostBreakdown(
 			cache1hCost := float64(usage.CacheCreation1hTokens) * pricing.CacheWrite1hPerMTok / 1_000_000
 			cacheReadCost := float64(usage.CacheReadTokens) * pricing.CacheReadPerMTok / 1_000_000
 
+			// Scale list prices to the billed cost so discounted service
+			// tiers (batch) are reflected in every component.
+			if list := inputCost + outputCost + cache5mCost + cache1hCost + cacheReadCost; list > 0 && usage.EstimatedCost > 0 {
+				scale := usage.EstimatedCost / list
+				inputCost *= scale
+				outputCost *= scale
+				cache5mCost *= scale
+				cache1hCost *= scale
+				cacheReadCost *= scale
+			}
+
 			totals.InputCost += inputCost
 			totals.OutputCost += outputCost
 			totals.Cache5mCost += cache5mCost
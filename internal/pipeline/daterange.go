@@ -0,0 +1,117 @@
+package pipeline
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+// DateLayout is the format accepted for --from / --to dates.
+const DateLayout = "2006-01-02"
+
+// ParseDateRange turns optional from/to dates (DateLayout, in time.Local)
+// into a [since, until) range for FilterByTime. The to date is inclusive.
+// Without from, the range covers the days before until; without to, it runs
+// to now. With neither, it is the last days days.
+func ParseDateRange(from, to string, days int, now time.Time) (since, until time.Time, err error) {
+	until = now
+	if to != "" {
+		t, err := time.ParseInLocation(DateLayout, to, time.Local)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid end date %q (want YYYY-MM-DD)", to)
+		}
+		until = t.AddDate(0, 0, 1)
+	}
+	since = until.AddDate(0, 0, -days)
+	if from != "" {
+		t, err := time.ParseInLocation(DateLayout, from, time.Local)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid start date %q (want YYYY-MM-DD)", from)
+		}
+		since = t
+	}
+	if !since.Before(until) {
+		return time.Time{}, time.Time{}, fmt.Errorf("date range is empty: %s is not before %s",
+			since.Format(DateLayout), until.Format(DateLayout))
+	}
+	return since, until, nil
+}
+
+// DateRangeLabel describes a custom [since, until) range as inclusive dates,
+// e.g. "2025-11-01 – 2025-11-30", or "2025-11-01 – now" when it runs to now.
+func DateRangeLabel(since, until, now time.Time) string {
+	end := "now"
+	if until.Before(now) {
+		end = until.Add(-time.Nanosecond).Local().Format(DateLayout)
+	}
+	return since.Local().Format(DateLayout) + " – " + end
+}
+
+// BillingPeriod returns the billing period containing now: from the most
+// recent anchorDay of the month (local midnight) to the next one. anchorDay
+// is clamped to 1-28 so every month has it; 0 means the 1st.
+func BillingPeriod(anchorDay int, now time.Time) (start, end time.Time) {
+	anchorDay = max(1, min(anchorDay, 28))
+	now = now.Local()
+	start = time.Date(now.Year(), now.Month(), anchorDay, 0, 0, 0, 0, time.Local)
+	if now.Before(start) {
+		start = start.AddDate(0, -1, 0)
+	}
+	return start, start.AddDate(0, 1, 0)
+}
+
+// BurnWindow is how far back the current burn rate looks.
+const BurnWindow = 7 * 24 * time.Hour
+
+// BudgetProgress is spend in the current billing period against a budget.
+type BudgetProgress struct {
+	Start, End time.Time // the billing period
+	Spent      float64
+	Budget     float64 // 0 when no budget is set
+	// Projected extrapolates Spent at the period's average rate to End; it
+	// stays 0 until a full day has elapsed, when one busy hour would skew it.
+	Projected float64
+	// BurnPerDay is the spend per day over the last BurnWindow of the
+	// period, or the whole period when it is younger: the current pace.
+	// Like Projected, it stays 0 for the first day.
+	BurnPerDay float64
+	// Elapsed is the fraction of the period that has passed.
+	Elapsed float64
+}
+
+// Pct returns Spent as a fraction of Budget, or 0 without a budget.
+func (b BudgetProgress) Pct() float64 {
+	if b.Budget <= 0 {
+		return 0
+	}
+	return b.Spent / b.Budget
+}
+
+// RunwayDays returns how many days the rest of the budget lasts at
+// BurnPerDay: 0 once it is spent, and ok false without a budget or a burn
+// rate to extrapolate.
+func (b BudgetProgress) RunwayDays() (days float64, ok bool) {
+	if b.Budget <= 0 || b.BurnPerDay <= 0 {
+		return 0, false
+	}
+	return max(b.Budget-b.Spent, 0) / b.BurnPerDay, true
+}
+
+// AggregateBudget computes billing-period-to-date spend for sessions.
+func AggregateBudget(sessions []model.SessionStats, budget float64, anchorDay int, now time.Time) BudgetProgress {
+	start, end := BillingPeriod(anchorDay, now)
+	b := BudgetProgress{
+		Start:   start,
+		End:     end,
+		Spent:   Aggregate(sessions, start, now).EstimatedCost,
+		Budget:  budget,
+		Elapsed: min(float64(now.Sub(start))/float64(end.Sub(start)), 1),
+	}
+	if elapsed := now.Sub(start); elapsed >= 24*time.Hour {
+		b.Projected = b.Spent * float64(end.Sub(start)) / float64(elapsed)
+		window := min(elapsed, BurnWindow)
+		b.BurnPerDay = Aggregate(sessions, now.Add(-window), now).EstimatedCost / window.Hours() * 24
+	}
+	return b
+}
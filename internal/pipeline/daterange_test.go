@@ -0,0 +1,94 @@
+package pipeline
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+func TestParseDateRange(t *testing.T) {
+	now := time.Date(2025, 12, 10, 15, 0, 0, 0, time.Local)
+	day := func(m time.Month, d int) time.Time { return time.Date(2025, m, d, 0, 0, 0, 0, time.Local) }
+
+	tests := []struct {
+		from, to     string
+		since, until time.Time
+	}{
+		{"2025-11-01", "2025-11-30", day(11, 1), day(12, 1)},
+		{"2025-11-01", "", day(11, 1), now},
+		{"", "2025-11-30", day(11, 24), day(12, 1)},
+	}
+	for _, tt := range tests {
+		since, until, err := ParseDateRange(tt.from, tt.to, 7, now)
+		if err != nil {
+			t.Errorf("%q..%q: %v", tt.from, tt.to, err)
+			continue
+		}
+		if !since.Equal(tt.since) || !until.Equal(tt.until) {
+			t.Errorf("%q..%q = %v..%v, want %v..%v", tt.from, tt.to, since, until, tt.since, tt.until)
+		}
+	}
+
+	for _, bad := range [][2]string{{"11/01/2025", ""}, {"2025-12-01", "2025-11-30"}} {
+		if _, _, err := ParseDateRange(bad[0], bad[1], 7, now); err == nil {
+			t.Errorf("%q..%q: expected error", bad[0], bad[1])
+		}
+	}
+
+	if got := DateRangeLabel(day(11, 1), day(12, 1), now); got != "2025-11-01 – 2025-11-30" {
+		t.Errorf("label = %q", got)
+	}
+}
+
+func TestBillingPeriod(t *testing.T) {
+	at := func(m time.Month, d, h int) time.Time { return time.Date(2025, m, d, h, 0, 0, 0, time.Local) }
+
+	tests := []struct {
+		anchor     int
+		now        time.Time
+		start, end time.Time
+	}{
+		{0, at(11, 20, 9), at(11, 1, 0), at(12, 1, 0)},
+		{15, at(11, 20, 9), at(11, 15, 0), at(12, 15, 0)},
+		{15, at(11, 3, 9), at(10, 15, 0), at(11, 15, 0)},
+		{31, at(2, 28, 9), at(2, 28, 0), at(3, 28, 0)}, // clamped to 28
+	}
+	for _, tt := range tests {
+		start, end := BillingPeriod(tt.anchor, tt.now)
+		if !start.Equal(tt.start) || !end.Equal(tt.end) {
+			t.Errorf("BillingPeriod(%d, %v) = %v..%v, want %v..%v", tt.anchor, tt.now, start, end, tt.start, tt.end)
+		}
+	}
+}
+
+func TestAggregateBudget_BurnAndRunway(t *testing.T) {
+	now := time.Date(2025, 6, 21, 0, 0, 0, 0, time.Local) // 20 days into June
+	day := func(d int, cost float64) model.SessionStats {
+		at := time.Date(2025, 6, d, 12, 0, 0, 0, time.Local)
+		return model.SessionStats{StartTime: at, EndTime: at, EstimatedCost: cost}
+	}
+	sessions := []model.SessionStats{day(2, 100), day(5, 60)}
+	for d := 14; d <= 20; d++ {
+		sessions = append(sessions, day(d, 20)) // $20/day this last week
+	}
+
+	b := AggregateBudget(sessions, 500, 1, now)
+	if b.Spent != 300 || b.Projected != 450 {
+		t.Errorf("spent %v, projected %v; want 300, 450", b.Spent, b.Projected)
+	}
+	if math.Abs(b.BurnPerDay-20) > 1e-9 {
+		t.Errorf("burn = %v/day, want 20", b.BurnPerDay)
+	}
+	if days, ok := b.RunwayDays(); !ok || math.Abs(days-10) > 1e-9 {
+		t.Errorf("runway = %v (ok %v), want 10 days", days, ok)
+	}
+	if math.Abs(b.Elapsed-20.0/30) > 1e-9 {
+		t.Errorf("elapsed = %v, want 2/3", b.Elapsed)
+	}
+
+	if _, ok := AggregateBudget(sessions, 0, 1, now).RunwayDays(); ok {
+		t.Error("runway without a budget")
+	}
+}
@@ -0,0 +1,74 @@
+package pipeline
+
+import (
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+// Duplicate is a copy of a session that was skipped because another file
+// holds the same session, e.g. when ~/.claude is synced between machines.
+type Duplicate struct {
+	SessionID string
+	Path      string // the skipped copy
+	KeptPath  string // the copy that was counted
+}
+
+// dupKey identifies one session across copies: its ID plus the ID of its
+// first API message, so two files only match when they start the same way.
+func dupKey(s model.SessionStats) string {
+	return s.SessionID + "\x00" + s.FirstMessageID
+}
+
+// moreComplete reports whether a is a fuller copy of a session than b: more
+// API calls, then more prompts, then a later end. Copies that tie keep
+// their order.
+func moreComplete(a, b model.SessionStats) bool {
+	if a.APICalls != b.APICalls {
+		return a.APICalls > b.APICalls
+	}
+	if a.UserMessages != b.UserMessages {
+		return a.UserMessages > b.UserMessages
+	}
+	return a.EndTime.After(b.EndTime)
+}
+
+// DedupeSessions keeps the most complete copy of each session and reports
+// the rest. Imported sessions are left alone; ImportSource already replaces
+// them per source. Order is otherwise preserved.
+func DedupeSessions(sessions []model.SessionStats) ([]model.SessionStats, []Duplicate) {
+	best := make(map[string]int) // key -> index of the copy kept so far
+	for i, s := range sessions {
+		if s.Source != "" {
+			continue
+		}
+		k := dupKey(s)
+		if j, ok := best[k]; !ok || moreComplete(s, sessions[j]) {
+			best[k] = i
+		}
+	}
+	if len(best) == countLocal(sessions) {
+		return sessions, nil
+	}
+
+	kept := make([]model.SessionStats, 0, len(best))
+	var dups []Duplicate
+	for i, s := range sessions {
+		if s.Source == "" {
+			if j := best[dupKey(s)]; j != i {
+				dups = append(dups, Duplicate{SessionID: s.SessionID, Path: s.FilePath, KeptPath: sessions[j].FilePath})
+				continue
+			}
+		}
+		kept = append(kept, s)
+	}
+	return kept, dups
+}
+
+func countLocal(sessions []model.SessionStats) int {
+	n := 0
+	for _, s := range sessions {
+		if s.Source == "" {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,191 @@
+package pipeline
+
+import (
+	"sort"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/config"
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+// Outlier thresholds for FindInefficientSessions.
+const (
+	// efficiencyMinCalls skips sessions too short for their ratios to mean anything.
+	efficiencyMinCalls = 5
+	// efficiencyMinBaseline is the number of eligible sessions needed before
+	// a baseline is trusted.
+	efficiencyMinBaseline = 5
+	// tokensPerPromptFactor flags sessions using this many times the median
+	// billed tokens per prompt.
+	tokensPerPromptFactor = 3.0
+	// cacheHitDropPP flags sessions whose cache hit rate is this far below
+	// the median (in fraction points, 0.25 = 25pp).
+	cacheHitDropPP = 0.25
+)
+
+// EfficiencyBaseline is the typical per-session efficiency in a period.
+type EfficiencyBaseline struct {
+	Sessions        int // eligible sessions the medians are computed from
+	TokensPerPrompt float64
+	CacheHitRate    float64
+}
+
+// SessionEfficiency is a session flagged as an outlier against the baseline.
+type SessionEfficiency struct {
+	Session         model.SessionStats
+	TokensPerPrompt float64
+	HighTokens      bool // tokens per prompt far above the baseline
+	LowCache        bool // cache hit rate far below the baseline
+}
+
+// billedTokens returns a session's billed tokens (everything except cache reads).
+func billedTokens(s model.SessionStats) int64 {
+	return s.InputTokens + s.OutputTokens + s.CacheCreation5mTokens + s.CacheCreation1hTokens
+}
+
+// FindInefficientSessions compares each session's billed tokens per prompt and
+// cache hit rate to the median of sessions in the same period, and returns
+// the baseline plus the outliers sorted by cost (most expensive first).
+// Subagent sessions and sessions with fewer than efficiencyMinCalls API calls
+// are ignored. No outliers are reported until the baseline has enough sessions.
+func FindInefficientSessions(sessions []model.SessionStats, since, until time.Time) (EfficiencyBaseline, []SessionEfficiency) {
+	var eligible []model.SessionStats
+	for _, s := range FilterByTime(sessions, since, until) {
+		if s.IsSubagent || s.UserMessages == 0 || s.APICalls < efficiencyMinCalls {
+			continue
+		}
+		eligible = append(eligible, s)
+	}
+
+	tpp := make([]float64, len(eligible))
+	hit := make([]float64, len(eligible))
+	for i, s := range eligible {
+		tpp[i] = float64(billedTokens(s)) / float64(s.UserMessages)
+		hit[i] = s.CacheHitRate
+	}
+
+	base := EfficiencyBaseline{Sessions: len(eligible)}
+	if len(eligible) < efficiencyMinBaseline {
+		return base, nil
+	}
+	base.TokensPerPrompt = median(tpp)
+	base.CacheHitRate = median(hit)
+
+	var out []SessionEfficiency
+	for i, s := range eligible {
+		e := SessionEfficiency{
+			Session:         s,
+			TokensPerPrompt: tpp[i],
+			HighTokens:      base.TokensPerPrompt > 0 && tpp[i] >= base.TokensPerPrompt*tokensPerPromptFactor,
+			LowCache:        hit[i] <= base.CacheHitRate-cacheHitDropPP,
+		}
+		if e.HighTokens || e.LowCache {
+			out = append(out, e)
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Session.EstimatedCost > out[j].Session.EstimatedCost
+	})
+	return base, out
+}
+
+// Efficiency score weights; they sum to 100.
+const (
+	scoreWeightCache  = 40.0
+	scoreWeightTokens = 40.0
+	scoreWeightOutput = 20.0
+)
+
+// ScoreEfficiency fills each session's CacheSavings and EfficiencyScore.
+//
+// The score combines three parts: cache hit rate, billed tokens per prompt
+// against the project average (fewer is better), and output tokens per input
+// token against the project average (more is better). A session at its
+// project's average on both ratios with a 100% hit rate scores 70; halving
+// tokens per prompt or doubling the output ratio maxes that part out.
+// Subagents and sessions with fewer than efficiencyMinCalls API calls are
+// left unscored.
+func ScoreEfficiency(sessions []model.SessionStats) {
+	type projectAvg struct {
+		n        int
+		tpp, out float64
+	}
+	avgs := make(map[string]*projectAvg)
+	for i := range sessions {
+		s := &sessions[i]
+		s.CacheSavings = 0
+		for name, mu := range s.Models {
+			s.CacheSavings += config.CalculateCacheSavingsAt(name, s.StartTime, mu.CacheReadTokens)
+		}
+		s.EfficiencyScore = 0
+		if !scorable(*s) {
+			continue
+		}
+		pa, ok := avgs[s.Project]
+		if !ok {
+			pa = &projectAvg{}
+			avgs[s.Project] = pa
+		}
+		pa.n++
+		pa.tpp += tokensPerPrompt(*s)
+		pa.out += outputRatio(*s)
+	}
+	for _, pa := range avgs {
+		pa.tpp /= float64(pa.n)
+		pa.out /= float64(pa.n)
+	}
+
+	for i := range sessions {
+		s := &sessions[i]
+		if !scorable(*s) {
+			continue
+		}
+		pa := avgs[s.Project]
+		score := scoreWeightCache * clamp01(s.CacheHitRate)
+		if tpp := tokensPerPrompt(*s); tpp > 0 {
+			score += scoreWeightTokens * clamp01(pa.tpp/tpp/2)
+		} else {
+			score += scoreWeightTokens
+		}
+		if pa.out > 0 {
+			score += scoreWeightOutput * clamp01(outputRatio(*s)/pa.out/2)
+		}
+		s.EfficiencyScore = max(1, int(score+0.5))
+	}
+}
+
+func scorable(s model.SessionStats) bool {
+	return !s.IsSubagent && s.UserMessages > 0 && s.APICalls >= efficiencyMinCalls
+}
+
+func tokensPerPrompt(s model.SessionStats) float64 {
+	return float64(billedTokens(s)) / float64(s.UserMessages)
+}
+
+// outputRatio returns output tokens per token of context sent, cached or not.
+func outputRatio(s model.SessionStats) float64 {
+	in := s.InputTokens + s.CacheCreation5mTokens + s.CacheCreation1hTokens + s.CacheReadTokens
+	if in == 0 {
+		return 0
+	}
+	return float64(s.OutputTokens) / float64(in)
+}
+
+func clamp01(v float64) float64 {
+	return min(1, max(0, v))
+}
+
+// median returns the median of vals without modifying it.
+func median(vals []float64) float64 {
+	if len(vals) == 0 {
+		return 0
+	}
+	vals = append([]float64(nil), vals...)
+	sort.Float64s(vals)
+	mid := len(vals) / 2
+	if len(vals)%2 == 1 {
+		return vals[mid]
+	}
+	return (vals[mid-1] + vals[mid]) / 2
+}
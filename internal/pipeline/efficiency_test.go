@@ -0,0 +1,96 @@
+package pipeline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+func TestFindInefficientSessions(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	mk := func(id string, prompts int, input int64, hit float64, cost float64) model.SessionStats {
+		return model.SessionStats{
+			SessionID:     id,
+			StartTime:     now.Add(-time.Hour),
+			UserMessages:  prompts,
+			APICalls:      10,
+			InputTokens:   input,
+			CacheHitRate:  hit,
+			EstimatedCost: cost,
+		}
+	}
+
+	sessions := []model.SessionStats{
+		mk("a", 10, 10_000, 0.9, 1),
+		mk("b", 10, 12_000, 0.85, 1),
+		mk("c", 10, 11_000, 0.9, 1),
+		mk("d", 10, 9_000, 0.88, 1),
+		mk("greedy", 10, 100_000, 0.9, 5),
+		mk("nocache", 10, 10_000, 0.3, 2),
+		{SessionID: "sub", IsSubagent: true, StartTime: now.Add(-time.Hour), UserMessages: 1, APICalls: 10, InputTokens: 1_000_000},
+	}
+
+	base, out := FindInefficientSessions(sessions, now.AddDate(0, 0, -1), now)
+	if base.Sessions != 6 {
+		t.Fatalf("baseline sessions = %d, want 6", base.Sessions)
+	}
+	if base.TokensPerPrompt != 1050 {
+		t.Errorf("median tokens/prompt = %v, want 1050", base.TokensPerPrompt)
+	}
+	if len(out) != 2 {
+		t.Fatalf("got %d outliers, want 2: %+v", len(out), out)
+	}
+	if out[0].Session.SessionID != "greedy" || !out[0].HighTokens || out[0].LowCache {
+		t.Errorf("first outlier = %+v, want greedy (high tokens)", out[0])
+	}
+	if out[1].Session.SessionID != "nocache" || !out[1].LowCache || out[1].HighTokens {
+		t.Errorf("second outlier = %+v, want nocache (low cache)", out[1])
+	}
+}
+
+func TestFindInefficientSessionsNeedsBaseline(t *testing.T) {
+	now := time.Now()
+	sessions := []model.SessionStats{
+		{SessionID: "a", StartTime: now, UserMessages: 1, APICalls: 10, InputTokens: 1},
+		{SessionID: "b", StartTime: now, UserMessages: 1, APICalls: 10, InputTokens: 1_000_000},
+	}
+	if _, out := FindInefficientSessions(sessions, now.Add(-time.Hour), now.Add(time.Hour)); out != nil {
+		t.Errorf("expected no outliers without a baseline, got %+v", out)
+	}
+}
+
+func TestScoreEfficiency(t *testing.T) {
+	mk := func(id string, prompts int, input, output int64, hit float64) model.SessionStats {
+		return model.SessionStats{
+			SessionID:    id,
+			Project:      "p",
+			UserMessages: prompts,
+			APICalls:     10,
+			InputTokens:  input,
+			OutputTokens: output,
+			CacheHitRate: hit,
+		}
+	}
+	sessions := []model.SessionStats{
+		mk("lean", 10, 5_000, 1_000, 1),
+		mk("heavy", 10, 50_000, 1_000, 0.2),
+		mk("short", 1, 100, 10, 1),
+		{SessionID: "sub", Project: "p", IsSubagent: true, UserMessages: 5, APICalls: 10, InputTokens: 1_000},
+	}
+	sessions[2].APICalls = 2
+
+	ScoreEfficiency(sessions)
+
+	lean, heavy := sessions[0].EfficiencyScore, sessions[1].EfficiencyScore
+	if lean <= heavy {
+		t.Errorf("lean score %d should beat heavy score %d", lean, heavy)
+	}
+	if lean < 1 || lean > 100 || heavy < 1 || heavy > 100 {
+		t.Errorf("scores out of range: lean %d, heavy %d", lean, heavy)
+	}
+	if sessions[2].EfficiencyScore != 0 || sessions[3].EfficiencyScore != 0 {
+		t.Errorf("short/subagent sessions should be unscored, got %d and %d",
+			sessions[2].EfficiencyScore, sessions[3].EfficiencyScore)
+	}
+}
@@ -0,0 +1,64 @@
+package pipeline
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/theirongolddev/cburn/internal/config"
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+// ApplyProjectIdentity renames sessions so a project that was renamed or
+// moved counts as one. With MergeByRepo, sessions in the same repository at
+// the same place within it take the project name of the latest of them;
+// then the [[projects.aliases]] rules apply, and win. Sessions are modified
+// in place, and applying it again changes nothing.
+func ApplyProjectIdentity(sessions []model.SessionStats, pc config.ProjectsConfig) {
+	if pc.MergeByRepo {
+		type latest struct {
+			project string
+			s       *model.SessionStats
+		}
+		names := make(map[string]latest)
+		for i := range sessions {
+			s := &sessions[i]
+			key := repoKey(s.Repo, s.ProjectPath)
+			if key == "" {
+				continue
+			}
+			if l, ok := names[key]; !ok || s.StartTime.After(l.s.StartTime) {
+				names[key] = latest{s.Project, s}
+			}
+		}
+		for i := range sessions {
+			if l, ok := names[repoKey(sessions[i].Repo, sessions[i].ProjectPath)]; ok {
+				sessions[i].Project = l.project
+			}
+		}
+	}
+	if len(pc.Aliases) == 0 {
+		return
+	}
+	for i := range sessions {
+		if name := pc.AliasFor(sessions[i].Project, sessions[i].ProjectPath); name != "" {
+			sessions[i].Project = name
+		}
+	}
+}
+
+// repoKey identifies where in repository repo the directory path is, e.g.
+// "api/services/auth" for /old/home/api/services/auth, so the same spot
+// matches after the repository moves. It is "" when either is unknown or
+// path is not inside repo.
+func repoKey(repo, path string) string {
+	if repo == "" || path == "" {
+		return ""
+	}
+	parts := strings.Split(filepath.ToSlash(filepath.Clean(path)), "/")
+	for i := len(parts) - 1; i >= 0; i-- {
+		if parts[i] == repo {
+			return strings.Join(parts[i:], "/")
+		}
+	}
+	return ""
+}
@@ -0,0 +1,41 @@
+package pipeline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/config"
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+func TestApplyProjectIdentity(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 6, d, 10, 0, 0, 0, time.UTC) }
+	sessions := []model.SessionStats{
+		{Project: "old-api", Repo: "api", ProjectPath: "/home/me/old/api", StartTime: day(1)},
+		{Project: "api", Repo: "api", ProjectPath: "/home/me/work/api", StartTime: day(5)},
+		{Project: "old-api-auth", Repo: "api", ProjectPath: "/home/me/old/api/services/auth", StartTime: day(2)},
+		{Project: "auth", Repo: "api", ProjectPath: "/home/me/work/api/services/auth", StartTime: day(6)},
+		{Project: "scratch", ProjectPath: "/tmp/scratch", StartTime: day(3)},
+		{Project: "legacy-web", ProjectPath: "/srv/legacy-web", StartTime: day(4)},
+	}
+	pc := config.ProjectsConfig{
+		MergeByRepo: true,
+		Aliases:     []config.ProjectAlias{{Match: "/srv/legacy-*", Project: "web"}},
+	}
+
+	want := []string{"api", "api", "auth", "auth", "scratch", "web"}
+	for round := 0; round < 2; round++ {
+		ApplyProjectIdentity(sessions, pc)
+		for i, s := range sessions {
+			if s.Project != want[i] {
+				t.Errorf("round %d: session %d project = %q, want %q", round, i, s.Project, want[i])
+			}
+		}
+	}
+
+	apart := []model.SessionStats{{Project: "old-api", Repo: "api", ProjectPath: "/home/me/old/api"}}
+	ApplyProjectIdentity(apart, config.ProjectsConfig{})
+	if apart[0].Project != "old-api" {
+		t.Errorf("merged with MergeByRepo off: %q", apart[0].Project)
+	}
+}
@@ -0,0 +1,255 @@
+package pipeline
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/theirongolddev/cburn/internal/model"
+	"github.com/theirongolddev/cburn/internal/source"
+	"github.com/theirongolddev/cburn/internal/store"
+)
+
+// LocalSource is the --source value that selects sessions parsed from this
+// machine's own data directory.
+const LocalSource = "local"
+
+// ImportSource parses another machine's Claude data directory and stores its
+// sessions in the cache under label (see StoreSource).
+// Returns the number of sessions imported.
+func ImportSource(cache *store.Cache, label, claudeDir string, progressFn ProgressFunc) (int, error) {
+	if err := ValidateSourceLabel(label); err != nil {
+		return 0, err
+	}
+
+	result, err := Load([]source.Root{{Path: claudeDir}}, true, progressFn)
+	if err != nil {
+		return 0, fmt.Errorf("parsing %s: %w", claudeDir, err)
+	}
+	if err := StoreSource(cache, label, result.Sessions); err != nil {
+		return 0, err
+	}
+	return len(result.Sessions), nil
+}
+
+// StoreSource stores sessions from another machine in the cache under label,
+// replacing any previous import with the same label. Session IDs are
+// namespaced by label so they can't collide with local sessions.
+func StoreSource(cache *store.Cache, label string, sessions []model.SessionStats) error {
+	if err := ValidateSourceLabel(label); err != nil {
+		return err
+	}
+
+	labeled := make([]model.SessionStats, len(sessions))
+	for i, s := range sessions {
+		s.Source = label
+		s.SessionID = label + "/" + s.SessionID
+		if s.ParentSession != "" {
+			s.ParentSession = label + "/" + s.ParentSession
+		}
+		labeled[i] = s
+	}
+
+	if err := cache.ReplaceSource(label, labeled); err != nil {
+		return fmt.Errorf("storing import: %w", err)
+	}
+	for _, s := range labeled {
+		if s.Note != "" || len(s.UserTags) > 0 {
+			if err := cache.SetAnnotation(s.SessionID, store.Annotation{Note: s.Note, Tags: s.UserTags}); err != nil {
+				return fmt.Errorf("storing import notes: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateSourceLabel rejects labels that would be ambiguous in filters.
+func ValidateSourceLabel(label string) error {
+	switch {
+	case label == "":
+		return fmt.Errorf("source label must not be empty")
+	case strings.EqualFold(label, LocalSource):
+		return fmt.Errorf("source label %q is reserved for this machine's sessions", label)
+	case strings.ContainsAny(label, "/\\"):
+		return fmt.Errorf("source label %q must not contain slashes", label)
+	}
+	return nil
+}
+
+// FilterBySource returns sessions from the given source label.
+// "local" selects sessions parsed from this machine's data directory.
+func FilterBySource(sessions []model.SessionStats, src string) []model.SessionStats {
+	if src == "" {
+		return sessions
+	}
+	want := src
+	if strings.EqualFold(src, LocalSource) {
+		want = ""
+	}
+	var result []model.SessionStats
+	for _, s := range sessions {
+		if strings.EqualFold(s.Source, want) {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// FindClaudeDir locates a Claude data directory under root: root itself when
+// it contains projects/, root's parent when root is the projects/ directory,
+// or the first nested directory containing projects/ (as found in archives).
+func FindClaudeDir(root string) (string, error) {
+	if isDir(filepath.Join(root, "projects")) {
+		return root, nil
+	}
+	if filepath.Base(filepath.Clean(root)) == "projects" && isDir(root) {
+		return filepath.Dir(filepath.Clean(root)), nil
+	}
+
+	var found string
+	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return nil //nolint:nilerr // intentionally skip unreadable entries
+		}
+		if found != "" {
+			return filepath.SkipAll
+		}
+		if d.IsDir() && d.Name() == "projects" {
+			found = filepath.Dir(path)
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if found == "" {
+		return "", fmt.Errorf("no projects/ directory found under %s", root)
+	}
+	return found, nil
+}
+
+// ExtractArchive unpacks a .tar.gz, .tgz, or .zip archive into dest.
+// Entries that would escape dest are rejected.
+func ExtractArchive(archive, dest string) error {
+	lower := strings.ToLower(archive)
+	switch {
+	case strings.HasSuffix(lower, ".zip"):
+		return extractZip(archive, dest)
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return extractTarGz(archive, dest)
+	default:
+		return fmt.Errorf("unsupported archive format: %s (want .tar.gz, .tgz, or .zip)", filepath.Base(archive))
+	}
+}
+
+// IsArchive reports whether path has a supported archive extension.
+func IsArchive(path string) bool {
+	lower := strings.ToLower(path)
+	return strings.HasSuffix(lower, ".zip") || strings.HasSuffix(lower, ".tar.gz") || strings.HasSuffix(lower, ".tgz")
+}
+
+func extractTarGz(archive, dest string) error {
+	f, err := os.Open(archive) //nolint:gosec // user-supplied import path
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("reading gzip: %w", err)
+	}
+	defer func() { _ = gz.Close() }()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("reading tar: %w", err)
+		}
+		target, err := archiveTarget(dest, hdr.Name)
+		if err != nil {
+			return err
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o750); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := writeArchiveFile(target, tr); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func extractZip(archive, dest string) error {
+	zr, err := zip.OpenReader(archive)
+	if err != nil {
+		return fmt.Errorf("reading zip: %w", err)
+	}
+	defer func() { _ = zr.Close() }()
+
+	for _, zf := range zr.File {
+		target, err := archiveTarget(dest, zf.Name)
+		if err != nil {
+			return err
+		}
+		if zf.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, 0o750); err != nil {
+				return err
+			}
+			continue
+		}
+		rc, err := zf.Open()
+		if err != nil {
+			return err
+		}
+		err = writeArchiveFile(target, rc)
+		_ = rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// archiveTarget resolves an archive entry name inside dest.
+func archiveTarget(dest, name string) (string, error) {
+	target := filepath.Join(dest, name) //nolint:gosec // checked against dest below
+	rel, err := filepath.Rel(dest, target)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("archive entry %q escapes destination", name)
+	}
+	return target, nil
+}
+
+func writeArchiveFile(target string, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o750); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600) //nolint:gosec // path validated by archiveTarget
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, r); err != nil { //nolint:gosec // local user-supplied archive
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
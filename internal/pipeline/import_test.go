@@ -0,0 +1,51 @@
+package pipeline
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+func TestFilterBySource(t *testing.T) {
+	sessions := []model.SessionStats{
+		{SessionID: "a"},
+		{SessionID: "alice/b", Source: "alice"},
+		{SessionID: "bob/c", Source: "bob"},
+	}
+
+	if got := FilterBySource(sessions, ""); len(got) != 3 {
+		t.Errorf("empty filter: got %d sessions, want 3", len(got))
+	}
+	if got := FilterBySource(sessions, "local"); len(got) != 1 || got[0].SessionID != "a" {
+		t.Errorf("local filter: got %+v", got)
+	}
+	if got := FilterBySource(sessions, "Alice"); len(got) != 1 || got[0].SessionID != "alice/b" {
+		t.Errorf("alice filter: got %+v", got)
+	}
+}
+
+func TestValidateSourceLabel(t *testing.T) {
+	for _, bad := range []string{"", "local", "LOCAL", "a/b"} {
+		if err := ValidateSourceLabel(bad); err == nil {
+			t.Errorf("ValidateSourceLabel(%q) = nil, want error", bad)
+		}
+	}
+	if err := ValidateSourceLabel("alice-laptop"); err != nil {
+		t.Errorf("ValidateSourceLabel(alice-laptop) = %v", err)
+	}
+}
+
+func TestArchiveTargetRejectsEscape(t *testing.T) {
+	dest := t.TempDir()
+	if _, err := archiveTarget(dest, "../evil"); err == nil {
+		t.Error("expected error for entry escaping destination")
+	}
+	got, err := archiveTarget(dest, ".claude/projects/x.jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(dest, ".claude/projects/x.jsonl"); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
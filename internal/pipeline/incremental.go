@@ -1,13 +1,17 @@
 package pipeline
 
 import (
+	"errors"
 	"fmt"
+	"hash/fnv"
+	"io/fs"
 	"os"
 	"path/filepath"
-	"runtime"
+	"slices"
 	"sync"
-	"sync/atomic"
+	"time"
 
+	"github.com/theirongolddev/cburn/internal/model"
 	"github.com/theirongolddev/cburn/internal/source"
 	"github.com/theirongolddev/cburn/internal/store"
 )
@@ -15,151 +19,391 @@ import (
 // CachedLoadResult extends LoadResult with cache metadata.
 type CachedLoadResult struct {
 	LoadResult
-	CacheHits int
-	Reparsed  int
+	CacheHits   int
+	Reparsed    int
+	Quarantined int // files skipped because they are quarantined
+}
+
+// LoadHooks lets a caller see sessions before LoadWithCacheHooks returns,
+// e.g. to render a partial dashboard. Either hook may be nil.
+type LoadHooks struct {
+	// Stale receives the sessions the cache held after the previous load,
+	// before any file is discovered, so a slow scan (a network mount, say)
+	// has last-known numbers to show. Files may since have changed or
+	// disappeared; Cached and the returned result supersede them.
+	Stale func([]model.SessionStats)
+	// Cached receives a copy of the sessions served from the cache, before
+	// any file is reparsed.
+	Cached func([]model.SessionStats)
+	// Parsed receives each reparsed session as soon as its file is parsed.
+	// It is called from the parse workers.
+	Parsed SessionSink
 }
 
 // LoadWithCache discovers, diffs against cache, parses only changed files,
-// and returns the combined result set.
-func LoadWithCache(claudeDir string, includeSubagents bool, cache *store.Cache, progressFn ProgressFunc) (*CachedLoadResult, error) {
-	// Discover files
-	files, err := source.ScanDir(claudeDir)
+// and returns the combined result set. Each load's duration is recorded in
+// the cache so cburn can report its own overhead.
+func LoadWithCache(roots []source.Root, includeSubagents bool, cache *store.Cache, progressFn ProgressFunc) (*CachedLoadResult, error) {
+	return LoadWithCacheHooks(roots, includeSubagents, cache, progressFn, LoadHooks{})
+}
+
+// LoadWithCacheHooks is LoadWithCache, reporting sessions through hooks as
+// they become available. The returned result still holds every session.
+func LoadWithCacheHooks(roots []source.Root, includeSubagents bool, cache *store.Cache, progressFn ProgressFunc, hooks LoadHooks) (*CachedLoadResult, error) {
+	start := time.Now()
+
+	// Read the cache before discovery, which can take far longer.
+	cached, err := cache.LoadAllSessions()
 	if err != nil {
-		return nil, fmt.Errorf("scanning %s: %w", claudeDir, err)
+		return nil, fmt.Errorf("loading cached sessions: %w", err)
 	}
-
-	if len(files) == 0 {
-		return &CachedLoadResult{}, nil
+	marks, err := loadMarks(cache)
+	if err != nil {
+		return nil, err
+	}
+	if hooks.Stale != nil {
+		hooks.Stale(staleSessions(cached, marks, includeSubagents))
 	}
 
-	// Filter subagents if requested
-	var toProcess []source.DiscoveredFile
-	if includeSubagents {
-		toProcess = files
-	} else {
-		for _, f := range files {
-			if !f.IsSubagent {
-				toProcess = append(toProcess, f)
-			}
-		}
+	diff, err := diffWithCache(roots, includeSubagents, cache)
+	if err != nil {
+		return nil, err
 	}
+	toReparse := diff.toReparse
 
 	result := &CachedLoadResult{
 		LoadResult: LoadResult{
-			TotalFiles:   len(toProcess),
-			ProjectCount: source.CountProjects(files),
+			TotalFiles:   diff.toProcess,
+			ProjectCount: source.CountProjects(diff.files),
 		},
+		CacheHits:   len(diff.unchanged),
+		Reparsed:    len(toReparse),
+		Quarantined: diff.quarantined,
+	}
+
+	// Keep cached sessions: local ones from unchanged files, plus every
+	// session imported from another machine (see ImportSource).
+	for _, s := range cached {
+		if diff.includes(s, includeSubagents) {
+			marks.apply(&s)
+			result.Sessions = append(result.Sessions, s)
+			if s.Source == "" {
+				result.ParsedFiles++
+			}
+		}
+	}
+
+	if hooks.Cached != nil {
+		sessions := slices.Clone(result.Sessions)
+		ApplyGapAware(sessions)
+		hooks.Cached(sessions)
+	}
+
+	// Parse changed files
+	var held []store.SessionWrite
+	if len(toReparse) > 0 {
+		// A session already loaded, from the cache or another file, may
+		// have a less complete copy in the cache; copies are held back
+		// until DedupeSessions has picked the one to keep.
+		var mu sync.Mutex
+		seen := make(map[string]struct{}, len(result.Sessions))
+		for _, s := range result.Sessions {
+			seen[dupKey(s)] = struct{}{}
+		}
+
+		results := make([]source.ParseResult, len(toReparse))
+		tracker := newProgressTracker(progressFn, toReparse, result.CacheHits, result.TotalFiles)
+		saver := newBatchSaver(cache)
+		parseAll(toReparse, tracker, func(idx int, pr source.ParseResult) {
+			results[idx] = pr
+			if pr.Err != nil || (pr.Stats.APICalls == 0 && pr.Stats.UserMessages == 0) {
+				return
+			}
+			marks.apply(&results[idx].Stats)
+			info, statErr := os.Stat(toReparse[idx].Path)
+
+			mu.Lock()
+			_, dup := seen[dupKey(pr.Stats)]
+			seen[dupKey(pr.Stats)] = struct{}{}
+			if dup && statErr == nil {
+				held = append(held, store.SessionWrite{Session: pr.Stats, MtimeNs: info.ModTime().UnixNano(), SizeBytes: info.Size()})
+			}
+			mu.Unlock()
+			if dup {
+				return
+			}
+
+			if hooks.Parsed != nil {
+				hooks.Parsed(gapAdjusted(results[idx].Stats))
+			}
+			if statErr == nil {
+				saver.add(store.SessionWrite{Session: pr.Stats, MtimeNs: info.ModTime().UnixNano(), SizeBytes: info.Size()})
+			}
+		})
+		saver.close()
+
+		// Collect results, remembering files that had problems
+		var clean []string
+		for i, pr := range results {
+			path := toReparse[i].Path
+			if pr.Err != nil {
+				result.FileErrors++
+				_ = cache.RecordParseIssue(store.ParseIssue{FilePath: path, Err: pr.Err.Error(), SeenAt: start})
+				continue
+			}
+			result.ParsedFiles++
+			result.ParseErrors += pr.ParseErrors
+			result.SkewedTimestamps += pr.SkewedTimestamps
+			if pr.ParseErrors > 0 || pr.SkewedTimestamps > 0 {
+				_ = cache.RecordParseIssue(store.ParseIssue{
+					FilePath:         path,
+					ParseErrors:      pr.ParseErrors,
+					SkewedTimestamps: pr.SkewedTimestamps,
+					SeenAt:           start,
+				})
+			} else {
+				clean = append(clean, path)
+			}
+
+			if pr.Stats.APICalls > 0 || pr.Stats.UserMessages > 0 {
+				result.Sessions = append(result.Sessions, pr.Stats)
+			}
+		}
+		_ = cache.ClearParseIssues(clean)
+	}
+
+	result.Sessions, result.Duplicates = DedupeSessions(result.Sessions)
+	recordDuplicates(cache, diff, held, result.Duplicates, start)
+
+	refreshSummaryCache(cache, diff.fingerprint, result.Sessions)
+	_ = cache.RecordScan(start, time.Since(start), result.Reparsed)
+	ApplyGapAware(result.Sessions) // after the summary cache, which keeps raw durations
+	return result, nil
+}
+
+// staleSessions is a copy of the cached sessions as LoadHooks.Stale sees
+// them: marked, deduplicated, without subagents unless requested, and
+// gap-adjusted.
+func staleSessions(cached []model.SessionStats, marks sessionMarks, includeSubagents bool) []model.SessionStats {
+	sessions := make([]model.SessionStats, 0, len(cached))
+	for _, s := range cached {
+		if s.IsSubagent && !includeSubagents {
+			continue
+		}
+		marks.apply(&s)
+		sessions = append(sessions, s)
+	}
+	sessions, _ = DedupeSessions(sessions)
+	ApplyGapAware(sessions)
+	return sessions
+}
+
+// cacheDiff splits the files a cached load covers by whether the cache is
+// current for them.
+type cacheDiff struct {
+	files       []source.DiscoveredFile // everything discovered
+	toProcess   int                     // files not skipped as quarantined or subagents
+	quarantined int
+	unchanged   map[string]struct{} // paths whose cached sessions are current
+	toReparse   []source.DiscoveredFile
+	// staleDups are duplicate records of files that no longer exist.
+	staleDups []string
+	// fingerprint identifies the path, mtime, and size of every processed
+	// file, so a summary built from them can tell when any has changed,
+	// disappeared, or been quarantined.
+	fingerprint string
+}
+
+func diffWithCache(roots []source.Root, includeSubagents bool, cache *store.Cache) (*cacheDiff, error) {
+	files, err := source.ScanRoots(roots)
+	if err != nil {
+		return nil, err
 	}
 
-	if len(toProcess) == 0 {
-		return result, nil
+	quarantined, err := cache.QuarantinedFiles()
+	if err != nil {
+		return nil, fmt.Errorf("reading quarantine list: %w", err)
 	}
 
-	// Get tracked files from cache
 	tracked, err := cache.GetTrackedFiles()
 	if err != nil {
 		return nil, fmt.Errorf("reading cache: %w", err)
 	}
 
-	// Diff: partition into changed and unchanged
-	var toReparse []source.DiscoveredFile
-	var unchanged []string // file paths that haven't changed
+	dups, err := cache.DuplicateFiles()
+	if err != nil {
+		return nil, fmt.Errorf("reading duplicate files: %w", err)
+	}
+
+	d := &cacheDiff{files: files, unchanged: make(map[string]struct{})}
+	processed := make(map[string]source.DiscoveredFile)
+	h := fnv.New64a()
+	if includeSubagents {
+		h.Write([]byte("+subagents\n"))
+	}
+	for _, f := range files {
+		// Skip quarantined files, and subagents unless requested
+		if _, ok := quarantined[f.Path]; ok {
+			d.quarantined++
+			continue
+		}
+		if !includeSubagents && f.IsSubagent {
+			continue
+		}
+		d.toProcess++
 
-	for _, f := range toProcess {
 		info, err := os.Stat(f.Path)
 		if err != nil {
 			continue
 		}
+		fmt.Fprintf(h, "%s\x00%d\x00%d\n", f.Path, info.ModTime().UnixNano(), info.Size())
+
+		processed[f.Path] = f
 
 		cached, ok := tracked[f.Path]
 		if ok && cached.MtimeNs == info.ModTime().UnixNano() && cached.SizeBytes == info.Size() {
-			unchanged = append(unchanged, f.Path)
+			d.unchanged[f.Path] = struct{}{}
 		} else {
-			toReparse = append(toReparse, f)
+			d.toReparse = append(d.toReparse, f)
 		}
 	}
 
-	result.CacheHits = len(unchanged)
-	result.Reparsed = len(toReparse)
-
-	// Load cached sessions
-	if len(unchanged) > 0 {
-		cached, err := cache.LoadAllSessions()
-		if err != nil {
-			return nil, fmt.Errorf("loading cached sessions: %w", err)
-		}
-
-		// Filter to only sessions from unchanged files
-		unchangedSet := make(map[string]struct{}, len(unchanged))
-		for _, p := range unchanged {
-			unchangedSet[p] = struct{}{}
-		}
-		for _, s := range cached {
-			if _, ok := unchangedSet[s.FilePath]; ok {
-				result.Sessions = append(result.Sessions, s)
-				result.ParsedFiles++
+	// A skipped copy of a session stands in for the kept one once that
+	// changes or goes away, so it is parsed again alongside it.
+	for _, dup := range dups {
+		f, ok := processed[dup.FilePath]
+		if !ok {
+			if _, err := os.Stat(dup.FilePath); errors.Is(err, fs.ErrNotExist) {
+				d.staleDups = append(d.staleDups, dup.FilePath)
 			}
+			continue
+		}
+		_, unchanged := d.unchanged[dup.FilePath]
+		_, keptUnchanged := d.unchanged[dup.KeptPath]
+		if unchanged && !keptUnchanged {
+			delete(d.unchanged, dup.FilePath)
+			d.toReparse = append(d.toReparse, f)
 		}
 	}
+	d.fingerprint = fmt.Sprintf("%016x", h.Sum64())
+	return d, nil
+}
 
-	// Parse changed files
-	if len(toReparse) > 0 {
-		numWorkers := runtime.GOMAXPROCS(0)
-		if numWorkers < 1 {
-			numWorkers = 4
+// includes reports whether a cached session belongs in the load: local
+// sessions from unchanged files, and imported ones.
+func (d *cacheDiff) includes(s model.SessionStats, includeSubagents bool) bool {
+	if s.Source != "" {
+		return includeSubagents || !s.IsSubagent
+	}
+	_, ok := d.unchanged[s.FilePath]
+	return ok
+}
+
+// recordDuplicates brings the cache in line with a load's dedupe: held
+// copies that won are saved over the copy cached for their session, and
+// every skipped copy is recorded so doctor can report it. Like session
+// saves, errors are dropped; the next load simply redoes the work.
+func recordDuplicates(cache *store.Cache, diff *cacheDiff, held []store.SessionWrite, dups []Duplicate, at time.Time) {
+	skipped := make(map[string]struct{}, len(dups))
+	for _, d := range dups {
+		skipped[d.Path] = struct{}{}
+	}
+	var winners []store.SessionWrite
+	for _, w := range held {
+		if _, ok := skipped[w.Session.FilePath]; !ok {
+			winners = append(winners, w)
 		}
-		if numWorkers > len(toReparse) {
-			numWorkers = len(toReparse)
+	}
+	_ = cache.SaveSessions(winners)
+
+	cleared := slices.Clone(diff.staleDups)
+	for _, f := range diff.toReparse {
+		cleared = append(cleared, f.Path)
+	}
+	var files []store.DuplicateFile
+	for _, d := range dups {
+		info, err := os.Stat(d.Path)
+		if err != nil {
+			continue
 		}
+		files = append(files, store.DuplicateFile{
+			FilePath:  d.Path,
+			SessionID: d.SessionID,
+			KeptPath:  d.KeptPath,
+			SeenAt:    at,
+			MtimeNs:   info.ModTime().UnixNano(),
+			SizeBytes: info.Size(),
+		})
+	}
+	_ = cache.RecordDuplicates(cleared, files)
+}
 
-		work := make(chan int, len(toReparse))
-		results := make([]source.ParseResult, len(toReparse))
-		var wg sync.WaitGroup
-		var processed atomic.Int64
+// sessionMarks are what the user recorded about sessions in the cache:
+// notes and tags, and which sessions are hidden from totals.
+type sessionMarks struct {
+	notes    map[string]store.Annotation
+	excluded map[string]struct{}
+}
 
-		for i := range toReparse {
-			work <- i
-		}
-		close(work)
-
-		wg.Add(numWorkers)
-		for w := 0; w < numWorkers; w++ {
-			go func() {
-				defer wg.Done()
-				for idx := range work {
-					results[idx] = source.ParseFile(toReparse[idx])
-					n := processed.Add(1)
-					if progressFn != nil {
-						progressFn(int(n)+result.CacheHits, result.TotalFiles)
-					}
-				}
-			}()
-		}
+func loadMarks(cache *store.Cache) (sessionMarks, error) {
+	var m sessionMarks
+	var err error
+	if m.notes, err = cache.Annotations(); err != nil {
+		return m, fmt.Errorf("loading session annotations: %w", err)
+	}
+	if m.excluded, err = cache.ExcludedSessions(); err != nil {
+		return m, fmt.Errorf("loading excluded sessions: %w", err)
+	}
+	return m, nil
+}
 
-		wg.Wait()
+// apply copies the marks for s onto it.
+func (m sessionMarks) apply(s *model.SessionStats) {
+	if n, ok := m.notes[s.SessionID]; ok {
+		s.Note, s.UserTags = n.Note, n.Tags
+	}
+	_, s.Excluded = m.excluded[s.SessionID]
+}
 
-		// Collect and cache results
-		for i, pr := range results {
-			if pr.Err != nil {
-				result.FileErrors++
-				continue
-			}
-			result.ParsedFiles++
-			result.ParseErrors += pr.ParseErrors
+// saveBatchSize is how many sessions a batchSaver writes per transaction.
+const saveBatchSize = 500
 
-			if pr.Stats.APICalls > 0 || pr.Stats.UserMessages > 0 {
-				result.Sessions = append(result.Sessions, pr.Stats)
+// batchSaver writes parsed sessions to the cache on its own goroutine, so
+// SQLite writes overlap parsing, committing saveBatchSize sessions at a time.
+// Like the per-session saves it replaces, write errors are dropped: a session
+// that isn't cached is simply reparsed next time.
+type batchSaver struct {
+	ch   chan store.SessionWrite
+	done chan struct{}
+}
 
-				// Save to cache
-				info, err := os.Stat(toReparse[i].Path)
-				if err == nil {
-					_ = cache.SaveSession(pr.Stats, info.ModTime().UnixNano(), info.Size())
-				}
+func newBatchSaver(cache *store.Cache) *batchSaver {
+	b := &batchSaver{
+		ch:   make(chan store.SessionWrite, saveBatchSize),
+		done: make(chan struct{}),
+	}
+	go func() {
+		defer close(b.done)
+		batch := make([]store.SessionWrite, 0, saveBatchSize)
+		for w := range b.ch {
+			batch = append(batch, w)
+			if len(batch) == saveBatchSize {
+				_ = cache.SaveSessions(batch)
+				batch = batch[:0]
 			}
 		}
-	}
+		_ = cache.SaveSessions(batch)
+	}()
+	return b
+}
 
-	return result, nil
+// add queues a session; it is safe for concurrent use.
+func (b *batchSaver) add(w store.SessionWrite) { b.ch <- w }
+
+// close flushes queued sessions and waits for the last write.
+func (b *batchSaver) close() {
+	close(b.ch)
+	<-b.done
 }
 
 // CacheDir returns the platform-appropriate cache directory.
@@ -174,5 +418,7 @@ func CacheDir() string {
 // CachePath returns the full path to the cache database.
 func CachePath() string {
 	// v2 includes historical pricing-aware cost calculations.
-	return filepath.Join(CacheDir(), "metrics_v2.db")
+	// v3 adds per-session repo and git branch.
+	// v4 adds the import source label.
+	return filepath.Join(CacheDir(), "metrics_v4.db")
 }
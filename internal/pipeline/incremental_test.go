@@ -0,0 +1,244 @@
+package pipeline
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/model"
+	"github.com/theirongolddev/cburn/internal/source"
+	"github.com/theirongolddev/cburn/internal/store"
+)
+
+func TestLoadWithCache_ParseIssuesAndQuarantine(t *testing.T) {
+	claude := t.TempDir()
+	dir := filepath.Join(claude, "projects", "-home-me-app")
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "s1.jsonl")
+	data := `{"type":"assistant","timestamp":"2025-06-01T10:00:00Z","message":{"id":"m1","model":"claude-sonnet-4-6","usage":{"input_tokens":5,"output_tokens":2}}}` + "\n" +
+		`{"type":"assistant","message":{` + "\n"
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cache, err := store.Open(filepath.Join(t.TempDir(), "cache.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = cache.Close() }()
+	roots := []source.Root{{Path: claude}}
+
+	if _, err := LoadWithCache(roots, true, cache, nil); err != nil {
+		t.Fatal(err)
+	}
+	issues, err := cache.ParseIssues()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 1 || issues[0].FilePath != path || issues[0].ParseErrors != 1 {
+		t.Fatalf("issues = %+v, want one bad line in %s", issues, path)
+	}
+
+	if err := cache.Quarantine(path, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	r, err := LoadWithCache(roots, true, cache, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Sessions) != 0 || r.Quarantined != 1 {
+		t.Fatalf("quarantined load: %d sessions, %d quarantined", len(r.Sessions), r.Quarantined)
+	}
+
+	if ok, err := cache.Release(path); err != nil || !ok {
+		t.Fatalf("Release = %v, %v", ok, err)
+	}
+	r, err = LoadWithCache(roots, true, cache, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Sessions) != 1 || r.Reparsed != 1 {
+		t.Fatalf("released load: %d sessions, %d reparsed", len(r.Sessions), r.Reparsed)
+	}
+}
+
+func TestLoadWithCacheHooks(t *testing.T) {
+	claude := t.TempDir()
+	dir := filepath.Join(claude, "projects", "-home-me-app")
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	line := `{"type":"assistant","timestamp":"2025-06-01T10:00:00Z","message":{"id":"m1","model":"claude-sonnet-4-6","usage":{"input_tokens":5,"output_tokens":2}}}` + "\n"
+	for _, name := range []string{"s1.jsonl", "s2.jsonl"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(line), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cache, err := store.Open(filepath.Join(t.TempDir(), "cache.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = cache.Close() }()
+	roots := []source.Root{{Path: claude}}
+	if _, err := LoadWithCache(roots, true, cache, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// Notes survive both serving from the cache and reparsing.
+	for _, id := range []string{"s1", "s2"} {
+		if err := cache.SetAnnotation(id, store.Annotation{Note: "note " + id, Tags: []string{"billable"}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Change one file so the second load serves one session from the cache
+	// and reparses the other.
+	if err := os.WriteFile(filepath.Join(dir, "s2.jsonl"), []byte(line+line), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var stale []model.SessionStats
+	var cached, parsed int
+	var mu sync.Mutex
+	r, err := LoadWithCacheHooks(roots, true, cache, nil, LoadHooks{
+		Stale: func(s []model.SessionStats) { stale = s },
+		Cached: func(s []model.SessionStats) {
+			if stale == nil {
+				t.Error("Cached hook ran before Stale")
+			}
+			cached = len(s)
+		},
+		Parsed: func(model.SessionStats) {
+			mu.Lock()
+			parsed++
+			mu.Unlock()
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Both sessions as of the first load, with their notes.
+	if len(stale) != 2 || stale[0].Note == "" {
+		t.Errorf("stale = %+v, want both cached sessions with notes", stale)
+	}
+	if cached != 1 || parsed != 1 || len(r.Sessions) != 2 {
+		t.Fatalf("cached %d, parsed %d, result %d sessions; want 1, 1, 2", cached, parsed, len(r.Sessions))
+	}
+	for _, s := range r.Sessions {
+		if s.Note != "note "+s.SessionID || len(s.UserTags) != 1 {
+			t.Errorf("%s: note %q, tags %v", s.SessionID, s.Note, s.UserTags)
+		}
+	}
+}
+
+func TestLoadWithCache_Duplicates(t *testing.T) {
+	claude := t.TempDir()
+	line := func(id, ts string) string {
+		return `{"type":"assistant","timestamp":"` + ts + `","message":{"id":"` + id +
+			`","model":"claude-sonnet-4-6","usage":{"input_tokens":5,"output_tokens":2}}}` + "\n"
+	}
+	// The same session synced from two machines; the second copy went on longer.
+	short := filepath.Join(claude, "projects", "-home-me-app", "s1.jsonl")
+	long := filepath.Join(claude, "projects", "-Users-me-app", "s1.jsonl")
+	for path, data := range map[string]string{
+		short: line("m1", "2025-06-01T10:00:00Z"),
+		long:  line("m1", "2025-06-01T10:00:00Z") + line("m2", "2025-06-01T10:05:00Z"),
+	} {
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cache, err := store.Open(filepath.Join(t.TempDir(), "cache.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = cache.Close() }()
+	roots := []source.Root{{Path: claude}}
+
+	check := func(name, wantPath string, wantCalls int) *CachedLoadResult {
+		t.Helper()
+		r, err := LoadWithCache(roots, true, cache, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(r.Sessions) != 1 || r.Sessions[0].FilePath != wantPath || r.Sessions[0].APICalls != wantCalls {
+			t.Fatalf("%s load: %+v, want one session from %s with %d calls", name, r.Sessions, wantPath, wantCalls)
+		}
+		return r
+	}
+
+	r := check("cold", long, 2)
+	if len(r.Duplicates) != 1 || r.Duplicates[0].Path != short || r.Duplicates[0].KeptPath != long {
+		t.Fatalf("duplicates = %+v, want %s kept over %s", r.Duplicates, long, short)
+	}
+	if r = check("warm", long, 2); r.Reparsed != 0 {
+		t.Fatalf("warm load reparsed %d files", r.Reparsed)
+	}
+	dups, err := cache.DuplicateFiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dups) != 1 || dups[0].FilePath != short || dups[0].KeptPath != long {
+		t.Fatalf("DuplicateFiles = %+v", dups)
+	}
+
+	// With the kept copy gone, the other one counts again.
+	if err := os.Remove(long); err != nil {
+		t.Fatal(err)
+	}
+	check("after removal", short, 1)
+	if dups, _ := cache.DuplicateFiles(); len(dups) != 0 {
+		t.Fatalf("DuplicateFiles after removal = %+v", dups)
+	}
+}
+
+func TestLoadWithCache_BatchedSaves(t *testing.T) {
+	claude := t.TempDir()
+	dir := filepath.Join(claude, "projects", "-home-me-app")
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	// More than one full batch, plus a partial one flushed on close.
+	n := 2*saveBatchSize + 3
+	for i := range n {
+		line := fmt.Sprintf(`{"type":"assistant","timestamp":"2025-06-01T10:00:00Z","message":{"id":"m%d","model":"claude-sonnet-4-6","usage":{"input_tokens":5,"output_tokens":2}}}`+"\n", i)
+		if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("s%d.jsonl", i)), []byte(line), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cache, err := store.Open(filepath.Join(t.TempDir(), "cache.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = cache.Close() }()
+	roots := []source.Root{{Path: claude}}
+
+	r, err := LoadWithCache(roots, true, cache, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Sessions) != n || r.Reparsed != n {
+		t.Fatalf("cold load: %d sessions, %d reparsed, want %d", len(r.Sessions), r.Reparsed, n)
+	}
+	if got, err := cache.SessionCount(); err != nil || got != n {
+		t.Fatalf("cached sessions = %d, %v, want %d", got, err, n)
+	}
+
+	r, err = LoadWithCache(roots, true, cache, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Sessions) != n || r.Reparsed != 0 || r.CacheHits != n {
+		t.Fatalf("warm load: %d sessions, %d reparsed, %d cache hits, want all %d from the cache", len(r.Sessions), r.Reparsed, r.CacheHits, n)
+	}
+}
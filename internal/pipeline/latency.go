@@ -0,0 +1,56 @@
+package pipeline
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+// AggregateLatency computes per-model turn duration statistics from the
+// sessions in [since, until), busiest model first. Models without any
+// recorded turn are left out.
+func AggregateLatency(sessions []model.SessionStats, since, until time.Time) []model.ModelLatency {
+	byModel := make(map[string][]int64)
+	for _, s := range FilterByTime(sessions, since, until) {
+		for name, mu := range s.Models {
+			if len(mu.TurnDurationsMs) > 0 {
+				byModel[name] = append(byModel[name], mu.TurnDurationsMs...)
+			}
+		}
+	}
+
+	out := make([]model.ModelLatency, 0, len(byModel))
+	for name, ms := range byModel {
+		slices.Sort(ms)
+		var sum int64
+		for _, v := range ms {
+			sum += v
+		}
+		out = append(out, model.ModelLatency{
+			Model: name,
+			Turns: len(ms),
+			Mean:  time.Duration(sum/int64(len(ms))) * time.Millisecond,
+			P50:   percentileMs(ms, 0.50),
+			P90:   percentileMs(ms, 0.90),
+			P99:   percentileMs(ms, 0.99),
+		})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Turns != out[j].Turns {
+			return out[i].Turns > out[j].Turns
+		}
+		return out[i].Model < out[j].Model
+	})
+	return out
+}
+
+// percentileMs returns the nearest-rank p-th percentile of sorted
+// millisecond values.
+func percentileMs(sorted []int64, p float64) time.Duration {
+	idx := int(math.Ceil(float64(len(sorted))*p)) - 1
+	idx = min(max(idx, 0), len(sorted)-1)
+	return time.Duration(sorted[idx]) * time.Millisecond
+}
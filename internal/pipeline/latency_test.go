@@ -0,0 +1,37 @@
+package pipeline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+func TestAggregateLatency(t *testing.T) {
+	start := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+	sessions := []model.SessionStats{
+		{StartTime: start, Models: map[string]*model.ModelUsage{
+			"claude-opus-4-6":   {TurnDurationsMs: []int64{10_000, 30_000}},
+			"claude-sonnet-4-6": {TurnDurationsMs: []int64{1_000, 2_000, 3_000}},
+		}},
+		{StartTime: start.Add(time.Hour), Models: map[string]*model.ModelUsage{
+			"claude-sonnet-4-6": {TurnDurationsMs: []int64{4_000, 100_000}},
+			"claude-haiku-4-5":  {},
+		}},
+	}
+
+	got := AggregateLatency(sessions, time.Time{}, time.Time{})
+	if len(got) != 2 {
+		t.Fatalf("got %d models, want 2 (haiku has no turns)", len(got))
+	}
+	sonnet, opus := got[0], got[1]
+	if sonnet.Model != "claude-sonnet-4-6" || sonnet.Turns != 5 {
+		t.Errorf("first = %s with %d turns, want sonnet with 5", sonnet.Model, sonnet.Turns)
+	}
+	if sonnet.P50 != 3*time.Second || sonnet.P90 != 100*time.Second || sonnet.Mean != 22*time.Second {
+		t.Errorf("sonnet p50/p90/mean = %v/%v/%v", sonnet.P50, sonnet.P90, sonnet.Mean)
+	}
+	if opus.Mean != 20*time.Second || opus.P50 != 10*time.Second {
+		t.Errorf("opus mean/p50 = %v/%v", opus.Mean, opus.P50)
+	}
+}
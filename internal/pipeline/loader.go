@@ -1,36 +1,197 @@
 package pipeline
 
 import (
-	"fmt"
+	"os"
+	"path/filepath"
 	"runtime"
+	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
+	"github.com/theirongolddev/cburn/internal/config"
 	"github.com/theirongolddev/cburn/internal/model"
 	"github.com/theirongolddev/cburn/internal/source"
 )
 
 // LoadResult holds the output of the full data loading pipeline.
 type LoadResult struct {
-	Sessions     []model.SessionStats
-	TotalFiles   int
-	ParsedFiles  int
-	ParseErrors  int
-	FileErrors   int
-	ProjectCount int
+	Sessions    []model.SessionStats
+	TotalFiles  int
+	ParsedFiles int
+	ParseErrors int
+	FileErrors  int
+	// SkewedTimestamps counts timestamps dropped as implausible (see
+	// source.ParseResult); only files parsed by this load are counted.
+	SkewedTimestamps int
+	ProjectCount     int
+	// Duplicates are copies of sessions that were skipped in favor of a
+	// more complete copy (see DedupeSessions).
+	Duplicates []Duplicate
+}
+
+// Progress is a snapshot of loading progress.
+// Files counts every file accounted for (including cache hits); Bytes and
+// TotalBytes only cover files that actually need parsing.
+type Progress struct {
+	Files      int
+	TotalFiles int
+	Bytes      int64
+	TotalBytes int64
+	Elapsed    time.Duration
+}
+
+// Fraction returns completion in the 0-1 range, weighted by bytes when known
+// so that one huge session file doesn't make progress appear stuck.
+func (p Progress) Fraction() float64 {
+	if p.TotalFiles > 0 && p.Files >= p.TotalFiles {
+		return 1
+	}
+	var f float64
+	switch {
+	case p.TotalBytes > 0:
+		f = float64(p.Bytes) / float64(p.TotalBytes)
+	case p.TotalFiles > 0:
+		f = float64(p.Files) / float64(p.TotalFiles)
+	}
+	if f > 1 {
+		f = 1
+	}
+	return f
+}
+
+// ETA estimates the remaining load time from the observed byte throughput.
+// Returns 0 when there isn't enough data yet to make an estimate.
+func (p Progress) ETA() time.Duration {
+	if p.Bytes <= 0 || p.TotalBytes <= p.Bytes || p.Elapsed <= 0 {
+		return 0
+	}
+	rate := float64(p.Bytes) / p.Elapsed.Seconds()
+	return time.Duration(float64(p.TotalBytes-p.Bytes) / rate * float64(time.Second))
 }
 
 // ProgressFunc is called during loading to report progress.
-// current is the number of files processed so far, total is the total count.
-type ProgressFunc func(current, total int)
+// It may be called concurrently from multiple worker goroutines.
+type ProgressFunc func(Progress)
+
+// progressTracker accumulates file and byte counts from parse workers.
+type progressTracker struct {
+	fn         ProgressFunc
+	start      time.Time
+	baseFiles  int // files already accounted for before parsing (cache hits)
+	totalFiles int
+	totalBytes int64
+	files      atomic.Int64
+	bytes      atomic.Int64
+}
+
+func newProgressTracker(fn ProgressFunc, toParse []source.DiscoveredFile, baseFiles, totalFiles int) *progressTracker {
+	t := &progressTracker{
+		fn:         fn,
+		start:      time.Now(),
+		baseFiles:  baseFiles,
+		totalFiles: totalFiles,
+	}
+	for _, f := range toParse {
+		t.totalBytes += f.Size
+	}
+	return t
+}
+
+// addBytes is passed to the parser as its byte-progress callback.
+func (t *progressTracker) addBytes(n int64) {
+	t.bytes.Add(n)
+	t.report()
+}
+
+// fileDone records a completed file.
+func (t *progressTracker) fileDone() {
+	t.files.Add(1)
+	t.report()
+}
+
+func (t *progressTracker) report() {
+	if t.fn == nil {
+		return
+	}
+	t.fn(Progress{
+		Files:      t.baseFiles + int(t.files.Load()),
+		TotalFiles: t.totalFiles,
+		Bytes:      t.bytes.Load(),
+		TotalBytes: t.totalBytes,
+		Elapsed:    time.Since(t.start),
+	})
+}
+
+// parseFile parses one file, feeding byte progress to the tracker when enabled.
+func (t *progressTracker) parseFile(df source.DiscoveredFile) source.ParseResult {
+	var pr source.ParseResult
+	if t.fn == nil {
+		pr = source.ParseFile(df)
+	} else {
+		pr = source.ParseFileWithProgress(df, t.addBytes)
+	}
+	t.fileDone()
+	return pr
+}
+
+// parseAll parses files on a bounded worker pool, calling handle with each
+// file's index and result as soon as it is parsed. handle runs on the worker
+// goroutines and must be safe for concurrent use.
+func parseAll(files []source.DiscoveredFile, tracker *progressTracker, handle func(int, source.ParseResult)) {
+	numWorkers := runtime.GOMAXPROCS(0)
+	if numWorkers < 1 {
+		numWorkers = 4
+	}
+	if numWorkers > len(files) {
+		numWorkers = len(files)
+	}
+
+	work := make(chan int, len(files))
+	for i := range files {
+		work <- i
+	}
+	close(work)
+
+	var wg sync.WaitGroup
+	wg.Add(numWorkers)
+	for w := 0; w < numWorkers; w++ {
+		go func() {
+			defer wg.Done()
+			for idx := range work {
+				handle(idx, tracker.parseFile(files[idx]))
+			}
+		}()
+	}
+	wg.Wait()
+}
 
-// Load discovers and parses all session files from the Claude data directory.
+// ScanRoots returns the roots to scan: the Claude data directory followed by
+// any extra roots from config, with a leading ~ expanded. Every root skips
+// the exclude patterns.
+func ScanRoots(claudeDir string, extra []config.ScanRoot, exclude []string) []source.Root {
+	roots := []source.Root{{Path: claudeDir, Profile: source.ProfileClaudeCode, Exclude: exclude}}
+	home, _ := os.UserHomeDir()
+	for _, r := range extra {
+		path := r.Path
+		if home != "" && (path == "~" || strings.HasPrefix(path, "~/")) {
+			path = filepath.Join(home, path[1:])
+		}
+		if path == "" {
+			continue
+		}
+		roots = append(roots, source.Root{Path: path, Profile: r.Profile, Project: r.Project, Exclude: exclude})
+	}
+	return roots
+}
+
+// Load discovers and parses all session files under the given roots.
 // It uses a bounded worker pool for parallel parsing.
-func Load(claudeDir string, includeSubagents bool, progressFn ProgressFunc) (*LoadResult, error) {
+func Load(roots []source.Root, includeSubagents bool, progressFn ProgressFunc) (*LoadResult, error) {
 	// Discover files
-	files, err := source.ScanDir(claudeDir)
+	files, err := source.ScanRoots(roots)
 	if err != nil {
-		return nil, fmt.Errorf("scanning %s: %w", claudeDir, err)
+		return nil, err
 	}
 
 	if len(files) == 0 {
@@ -58,42 +219,11 @@ func Load(claudeDir string, includeSubagents bool, progressFn ProgressFunc) (*Lo
 		return result, nil
 	}
 
-	// Parallel parsing with bounded worker pool
-	numWorkers := runtime.GOMAXPROCS(0)
-	if numWorkers < 1 {
-		numWorkers = 4
-	}
-	if numWorkers > len(toProcess) {
-		numWorkers = len(toProcess)
-	}
-
-	work := make(chan int, len(toProcess))
 	results := make([]source.ParseResult, len(toProcess))
-	var wg sync.WaitGroup
-	var processed atomic.Int64
-
-	// Feed work
-	for i := range toProcess {
-		work <- i
-	}
-	close(work)
-
-	// Spawn workers
-	wg.Add(numWorkers)
-	for w := 0; w < numWorkers; w++ {
-		go func() {
-			defer wg.Done()
-			for idx := range work {
-				results[idx] = source.ParseFile(toProcess[idx])
-				n := processed.Add(1)
-				if progressFn != nil {
-					progressFn(int(n), len(toProcess))
-				}
-			}
-		}()
-	}
-
-	wg.Wait()
+	tracker := newProgressTracker(progressFn, toProcess, 0, len(toProcess))
+	parseAll(toProcess, tracker, func(idx int, pr source.ParseResult) {
+		results[idx] = pr
+	})
 
 	// Collect results
 	for _, pr := range results {
@@ -103,10 +233,13 @@ func Load(claudeDir string, includeSubagents bool, progressFn ProgressFunc) (*Lo
 		}
 		result.ParsedFiles++
 		result.ParseErrors += pr.ParseErrors
+		result.SkewedTimestamps += pr.SkewedTimestamps
 		if pr.Stats.APICalls > 0 || pr.Stats.UserMessages > 0 {
 			result.Sessions = append(result.Sessions, pr.Stats)
 		}
 	}
+	result.Sessions, result.Duplicates = DedupeSessions(result.Sessions)
+	ApplyGapAware(result.Sessions)
 
 	return result, nil
 }
@@ -0,0 +1,64 @@
+package pipeline
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+// Metric is a custom KPI computed over the sessions of a period. A fresh
+// Metric is made for every computation, so it can keep its running totals
+// in its own fields.
+type Metric interface {
+	// Add is called once for each session in the period, with tags,
+	// automation, and notes applied and hidden sessions left out.
+	Add(s model.SessionStats)
+	// Card reports the metric once every session was added.
+	Card() model.MetricCard
+}
+
+var metrics []func() Metric
+
+// RegisterMetric adds a custom metric. newMetric is called for every
+// computation; register from an init function or before cmd.Execute, as
+// metrics are not safe to register while cburn runs.
+func RegisterMetric(newMetric func() Metric) {
+	metrics = append(metrics, newMetric)
+}
+
+// HasMetrics reports whether any custom metric is registered.
+func HasMetrics() bool {
+	return len(metrics) > 0
+}
+
+// ComputeMetrics runs every registered metric over the sessions in
+// [since, until), in registration order. A metric that panics reports the
+// panic on its card instead of taking cburn down.
+func ComputeMetrics(sessions []model.SessionStats, since, until time.Time) []model.MetricCard {
+	if len(metrics) == 0 {
+		return nil
+	}
+	inPeriod := WithoutExcluded(FilterByTime(sessions, since, until))
+	cards := make([]model.MetricCard, 0, len(metrics))
+	for i, newMetric := range metrics {
+		cards = append(cards, computeMetric(i, newMetric, inPeriod))
+	}
+	return cards
+}
+
+func computeMetric(i int, newMetric func() Metric, sessions []model.SessionStats) (card model.MetricCard) {
+	defer func() {
+		if r := recover(); r != nil {
+			card = model.MetricCard{
+				Title: fmt.Sprintf("Metric %d", i+1),
+				Rows:  []model.MetricRow{{Label: "failed", Value: fmt.Sprint(r)}},
+			}
+		}
+	}()
+	m := newMetric()
+	for _, s := range sessions {
+		m.Add(s)
+	}
+	return m.Card()
+}
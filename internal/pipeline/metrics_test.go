@@ -0,0 +1,61 @@
+package pipeline
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+type opusCost struct{ opus, total float64 }
+
+func (m *opusCost) Add(s model.SessionStats) {
+	m.total += s.EstimatedCost
+	if mu := s.Models["claude-opus-4-6"]; mu != nil {
+		m.opus += mu.EstimatedCost
+	}
+}
+
+func (m *opusCost) Card() model.MetricCard {
+	return model.MetricCard{Title: "Opus", Rows: []model.MetricRow{{Label: "share", Value: fmt.Sprintf("%.0f%%", m.opus/m.total*100)}}}
+}
+
+type broken struct{}
+
+func (broken) Add(model.SessionStats) { panic("boom") }
+func (broken) Card() model.MetricCard { return model.MetricCard{} }
+
+func TestComputeMetrics(t *testing.T) {
+	saved := metrics
+	t.Cleanup(func() { metrics = saved })
+	metrics = nil
+	if HasMetrics() || ComputeMetrics(nil, time.Time{}, time.Time{}) != nil {
+		t.Fatal("no metrics registered, want none computed")
+	}
+
+	RegisterMetric(func() Metric { return &opusCost{} })
+	RegisterMetric(func() Metric { return broken{} })
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	sessions := []model.SessionStats{
+		{StartTime: now.Add(-time.Hour), EstimatedCost: 4, Models: map[string]*model.ModelUsage{"claude-opus-4-6": {EstimatedCost: 3}}},
+		{StartTime: now.Add(-time.Hour), EstimatedCost: 4},
+		{StartTime: now.Add(-time.Hour), EstimatedCost: 100, Excluded: true},
+		{StartTime: now.AddDate(0, 0, -3), EstimatedCost: 100},
+	}
+
+	// Each computation starts from a fresh metric.
+	for range 2 {
+		cards := ComputeMetrics(sessions, now.AddDate(0, 0, -1), now)
+		if len(cards) != 2 {
+			t.Fatalf("cards = %+v, want 2", cards)
+		}
+		if got := cards[0].Rows[0].Value; got != "38%" {
+			t.Errorf("opus share = %s, want 38%% (hidden and out-of-period sessions left out)", got)
+		}
+		if got := cards[1].Rows[0]; got.Label != "failed" || got.Value != "boom" {
+			t.Errorf("panicking metric row = %+v, want its panic", got)
+		}
+	}
+}
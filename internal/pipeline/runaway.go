@@ -0,0 +1,34 @@
+package pipeline
+
+import (
+	"fmt"
+
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+const (
+	// runawayCallsPerPrompt flags sessions making this many API calls per
+	// prompt; interactive work rarely passes a few dozen.
+	runawayCallsPerPrompt = 150
+	// runawayRepeats flags sessions that made this many identical calls in a
+	// row (see model.SessionStats.RepeatedCalls).
+	runawayRepeats = 10
+)
+
+// RunawayReason returns why s looks like a runaway agent, or "" when it
+// doesn't: an abnormally high ratio of API calls to prompts, or a run of
+// identical back-to-back calls.
+func RunawayReason(s model.SessionStats) string {
+	if s.RepeatedCalls >= runawayRepeats {
+		return fmt.Sprintf("%d identical calls in a row", s.RepeatedCalls)
+	}
+	if s.APICalls >= runawayCallsPerPrompt && s.APICalls >= runawayCallsPerPrompt*max(s.UserMessages, 1) {
+		return fmt.Sprintf("%d API calls for %d prompts", s.APICalls, s.UserMessages)
+	}
+	return ""
+}
+
+// IsRunaway reports whether s looks like a runaway agent.
+func IsRunaway(s model.SessionStats) bool {
+	return RunawayReason(s) != ""
+}
@@ -0,0 +1,26 @@
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+func TestRunawayReason(t *testing.T) {
+	tests := []struct {
+		name string
+		s    model.SessionStats
+		want bool
+	}{
+		{"interactive", model.SessionStats{UserMessages: 20, APICalls: 400}, false},
+		{"calls per prompt", model.SessionStats{UserMessages: 2, APICalls: 300}, true},
+		{"headless", model.SessionStats{APICalls: 150}, true},
+		{"few calls", model.SessionStats{APICalls: 40}, false},
+		{"repeats", model.SessionStats{UserMessages: 5, APICalls: 30, RepeatedCalls: 12}, true},
+	}
+	for _, tt := range tests {
+		if got := IsRunaway(tt.s); got != tt.want {
+			t.Errorf("%s: IsRunaway = %v (%q), want %v", tt.name, got, RunawayReason(tt.s), tt.want)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package pipeline
+
+import (
+	"sync"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/model"
+	"github.com/theirongolddev/cburn/internal/source"
+)
+
+// SessionSink receives sessions as LoadStream parses them. It is called from
+// the parse workers, so it must be safe for concurrent use.
+type SessionSink func(model.SessionStats)
+
+// LoadStream is Load without the session slice: each session goes to sink
+// as soon as its file is parsed, so callers that only need totals never hold
+// every session in memory. The returned LoadResult has no Sessions. Sessions
+// are not held back, so of several copies of one session the first parsed
+// is kept, rather than the most complete.
+func LoadStream(roots []source.Root, includeSubagents bool, progressFn ProgressFunc, sink SessionSink) (*LoadResult, error) {
+	files, err := source.ScanRoots(roots)
+	if err != nil {
+		return nil, err
+	}
+
+	var toProcess []source.DiscoveredFile
+	for _, f := range files {
+		if includeSubagents || !f.IsSubagent {
+			toProcess = append(toProcess, f)
+		}
+	}
+
+	result := &LoadResult{
+		TotalFiles:   len(toProcess),
+		ProjectCount: source.CountProjects(files),
+	}
+	if len(toProcess) == 0 {
+		return result, nil
+	}
+
+	var mu sync.Mutex
+	kept := make(map[string]string) // dupKey -> path of the copy sent to sink
+	tracker := newProgressTracker(progressFn, toProcess, 0, len(toProcess))
+	parseAll(toProcess, tracker, func(_ int, pr source.ParseResult) {
+		mu.Lock()
+		if pr.Err != nil {
+			result.FileErrors++
+			mu.Unlock()
+			return
+		}
+		result.ParsedFiles++
+		result.ParseErrors += pr.ParseErrors
+		result.SkewedTimestamps += pr.SkewedTimestamps
+		if pr.Stats.APICalls == 0 && pr.Stats.UserMessages == 0 {
+			mu.Unlock()
+			return
+		}
+		k := dupKey(pr.Stats)
+		if keptPath, ok := kept[k]; ok {
+			result.Duplicates = append(result.Duplicates, Duplicate{
+				SessionID: pr.Stats.SessionID, Path: pr.Stats.FilePath, KeptPath: keptPath,
+			})
+			mu.Unlock()
+			return
+		}
+		kept[k] = pr.Stats.FilePath
+		mu.Unlock()
+
+		sink(gapAdjusted(pr.Stats))
+	})
+
+	return result, nil
+}
+
+// StreamAggregator keeps running summary and daily totals for sessions in
+// [since, until) as they arrive. Add may be called from many goroutines
+// while others read snapshots, so it can back a partial view during a load.
+type StreamAggregator struct {
+	since, until time.Time
+
+	mu      sync.Mutex
+	summary *summaryAcc
+	days    *dayAcc
+}
+
+// NewStreamAggregator returns an empty aggregator for [since, until).
+func NewStreamAggregator(since, until time.Time) *StreamAggregator {
+	return &StreamAggregator{
+		since:   since,
+		until:   until,
+		summary: newSummaryAcc(),
+		days:    newDayAcc(),
+	}
+}
+
+// Add folds one session into the totals; sessions outside the range are
+// ignored. Its method value is a SessionSink.
+func (a *StreamAggregator) Add(s model.SessionStats) {
+	if (!a.since.IsZero() || !a.until.IsZero()) && !inRange(s, a.since, a.until) {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.summary.add(s)
+	a.days.add(s)
+}
+
+// Summary returns the totals so far, as Aggregate would for the same sessions.
+func (a *StreamAggregator) Summary() model.SummaryStats {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.summary.summary()
+}
+
+// Days returns the per-day totals so far, as AggregateDays would.
+func (a *StreamAggregator) Days() []model.DailyStats {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.days.days(a.since, a.until)
+}
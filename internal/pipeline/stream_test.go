@@ -0,0 +1,66 @@
+package pipeline
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+func TestStreamAggregatorMatchesAggregate(t *testing.T) {
+	since := time.Date(2025, 6, 1, 0, 0, 0, 0, time.Local)
+	until := since.AddDate(0, 0, 7)
+
+	var sessions []model.SessionStats
+	for i := range 200 {
+		sessions = append(sessions, model.SessionStats{
+			StartTime:     since.Add(time.Duration(i) * 57 * time.Minute), // some fall past until
+			UserMessages:  i % 5,
+			APICalls:      i%7 + 1,
+			InputTokens:   int64(i * 100),
+			OutputTokens:  int64(i * 10),
+			EstimatedCost: float64(i) / 100,
+			Models: map[string]*model.ModelUsage{
+				"claude-sonnet-4-6": {InputTokens: int64(i * 100), OutputTokens: int64(i * 10)},
+			},
+		})
+	}
+
+	agg := NewStreamAggregator(since, until)
+	var wg sync.WaitGroup
+	for w := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := w; i < len(sessions); i += 4 {
+				agg.Add(sessions[i])
+			}
+		}()
+	}
+	wg.Wait()
+
+	want := Aggregate(sessions, since, until)
+	got := agg.Summary()
+	// Float sums depend on order; compare those loosely.
+	if d := got.EstimatedCost - want.EstimatedCost; d > 1e-9 || d < -1e-9 {
+		t.Errorf("cost = %v, want %v", got.EstimatedCost, want.EstimatedCost)
+	}
+	got.EstimatedCost, got.CostPerDay = want.EstimatedCost, want.CostPerDay
+	if got != want {
+		t.Errorf("summary = %+v\nwant %+v", got, want)
+	}
+
+	gotDays, wantDays := agg.Days(), AggregateDays(sessions, since, until)
+	if len(gotDays) != len(wantDays) {
+		t.Fatalf("%d days, want %d", len(gotDays), len(wantDays))
+	}
+	for i := range wantDays {
+		g, w := gotDays[i], wantDays[i]
+		g.EstimatedCost = w.EstimatedCost
+		if !reflect.DeepEqual(g, w) {
+			t.Errorf("day %d = %+v, want %+v", i, g, w)
+		}
+	}
+}
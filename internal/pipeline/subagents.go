@@ -0,0 +1,65 @@
+package pipeline
+
+import (
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+// SubagentType returns the agent type encoded in a subagent session ID:
+// "uuid/agent-acompact-7b10e8" is "acompact". IDs with nothing but the
+// agent's own ID after "agent-" belong to plain Task agents, "task".
+func SubagentType(sessionID string) string {
+	name := strings.TrimPrefix(path.Base(sessionID), "agent-")
+	if i := strings.LastIndex(name, "-"); i > 0 {
+		return name[:i]
+	}
+	return "task"
+}
+
+// AggregateSubagents computes per-type statistics for the subagent sessions
+// among sessions, most expensive first.
+func AggregateSubagents(sessions []model.SessionStats, since, until time.Time) []model.SubagentStats {
+	byType := make(map[string]*model.SubagentStats)
+	parents := make(map[string]map[string]struct{})
+	var totalCost float64
+
+	for _, s := range FilterByTime(sessions, since, until) {
+		if !s.IsSubagent {
+			continue
+		}
+		typ := SubagentType(s.SessionID)
+		st, ok := byType[typ]
+		if !ok {
+			st = &model.SubagentStats{Type: typ}
+			byType[typ] = st
+			parents[typ] = make(map[string]struct{})
+		}
+		parents[typ][s.ParentSession] = struct{}{}
+		st.Runs++
+		st.APICalls += s.APICalls
+		st.TotalTokens += s.InputTokens + s.OutputTokens +
+			s.CacheCreation5mTokens + s.CacheCreation1hTokens
+		st.EstimatedCost += s.EstimatedCost
+		totalCost += s.EstimatedCost
+	}
+
+	out := make([]model.SubagentStats, 0, len(byType))
+	for typ, st := range byType {
+		st.Parents = len(parents[typ])
+		if totalCost > 0 {
+			st.SharePercent = st.EstimatedCost / totalCost * 100
+		}
+		out = append(out, *st)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].EstimatedCost != out[j].EstimatedCost {
+			return out[i].EstimatedCost > out[j].EstimatedCost
+		}
+		return out[i].Type < out[j].Type
+	})
+	return out
+}
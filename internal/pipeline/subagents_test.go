@@ -0,0 +1,49 @@
+package pipeline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+func TestSubagentType(t *testing.T) {
+	for id, want := range map[string]string{
+		"p1/agent-acompact-7b10e8":     "acompact",
+		"p1/agent-a3f9c2d1":            "task",
+		"p1/agent-code-reviewer-1a2b3": "code-reviewer",
+		"agent-acompact-7b10e8":        "acompact",
+	} {
+		if got := SubagentType(id); got != want {
+			t.Errorf("SubagentType(%q) = %q, want %q", id, got, want)
+		}
+	}
+}
+
+func TestAggregateSubagents(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	sub := func(parent, id string, cost float64) model.SessionStats {
+		return model.SessionStats{
+			SessionID: parent + "/" + id, ParentSession: parent, IsSubagent: true,
+			StartTime: now.Add(-time.Hour), APICalls: 2, InputTokens: 100, EstimatedCost: cost,
+		}
+	}
+	sessions := []model.SessionStats{
+		{SessionID: "p1", StartTime: now.Add(-time.Hour), EstimatedCost: 10},
+		sub("p1", "agent-acompact-aa11", 1),
+		sub("p2", "agent-acompact-bb22", 2),
+		sub("p1", "agent-a1b2c3", 1),
+	}
+
+	got := AggregateSubagents(sessions, now.AddDate(0, 0, -1), now)
+	if len(got) != 2 {
+		t.Fatalf("types = %+v, want acompact and task", got)
+	}
+	ac := got[0]
+	if ac.Type != "acompact" || ac.Runs != 2 || ac.Parents != 2 || ac.EstimatedCost != 3 || ac.TotalTokens != 200 || ac.SharePercent != 75 {
+		t.Errorf("acompact = %+v", ac)
+	}
+	if got[1].Type != "task" || got[1].Runs != 1 {
+		t.Errorf("task = %+v", got[1])
+	}
+}
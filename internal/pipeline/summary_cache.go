@@ -0,0 +1,214 @@
+package pipeline
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/config"
+	"github.com/theirongolddev/cburn/internal/model"
+	"github.com/theirongolddev/cburn/internal/source"
+	"github.com/theirongolddev/cburn/internal/store"
+)
+
+// summaryZone names the zone day buckets are cut in. time.Local is often
+// just "Local", so the current offset is included to notice a changed
+// system zone.
+func summaryZone() string {
+	return time.Local.String() + " " + time.Now().Format("-0700")
+}
+
+// refreshSummaryCache rebuilds the summary_cache table from sessions unless
+// it was already built from the same files in the same zone. Failures are
+// ignored: SummaryFromCache then falls back to a full load.
+func refreshSummaryCache(cache *store.Cache, fingerprint string, sessions []model.SessionStats) {
+	zone := summaryZone()
+	if fp, z, err := cache.SummaryMeta(); err == nil && fp == fingerprint && z == zone {
+		return
+	}
+	_ = cache.ReplaceSummary(fingerprint, zone, buildSummaryRows(sessions))
+}
+
+// buildSummaryRows totals sessions per local day, project, source, and model.
+func buildSummaryRows(sessions []model.SessionStats) []store.SummaryRow {
+	type key struct{ day, project, source, model string }
+	rows := make(map[key]*store.SummaryRow)
+	row := func(k key) *store.SummaryRow {
+		r, ok := rows[k]
+		if !ok {
+			r = &store.SummaryRow{Day: k.day, Project: k.project, Source: k.source, Model: k.model}
+			rows[k] = r
+		}
+		return r
+	}
+
+	for _, s := range sessions {
+		if s.StartTime.IsZero() || s.Excluded {
+			continue
+		}
+		day := s.StartTime.Local().Format(DateLayout)
+
+		var savings float64
+		for name, mu := range s.Models {
+			ms := config.CalculateCacheSavingsAt(name, s.StartTime, mu.CacheReadTokens)
+			savings += ms
+
+			r := row(key{day, s.Project, s.Source, name})
+			r.Sessions++
+			r.APICalls += mu.APICalls
+			r.InputTokens += mu.InputTokens
+			r.OutputTokens += mu.OutputTokens
+			r.CacheCreation5mTokens += mu.CacheCreation5mTokens
+			r.CacheCreation1hTokens += mu.CacheCreation1hTokens
+			r.CacheReadTokens += mu.CacheReadTokens
+			r.EstimatedCost += mu.EstimatedCost
+			r.CacheSavings += ms
+		}
+
+		r := row(key{day, s.Project, s.Source, ""})
+		r.Sessions++
+		r.Prompts += s.UserMessages
+		r.APICalls += s.APICalls
+		r.DurationSecs += s.DurationSecs
+		if !s.IsSubagent {
+			r.ActiveSecs += ActiveSecs(s, DefaultIdleGap)
+			r.ClaudeSecs += s.ClaudeSecs
+		}
+		r.InputTokens += s.InputTokens
+		r.OutputTokens += s.OutputTokens
+		r.CacheCreation5mTokens += s.CacheCreation5mTokens
+		r.CacheCreation1hTokens += s.CacheCreation1hTokens
+		r.CacheReadTokens += s.CacheReadTokens
+		r.EstimatedCost += s.EstimatedCost
+		r.CacheSavings += savings
+	}
+
+	out := make([]store.SummaryRow, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, *r)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Day < out[j].Day })
+	return out
+}
+
+// SummaryQuery is one Aggregate call for SummaryFromCache to answer.
+type SummaryQuery struct {
+	Since, Until time.Time
+	Source       string // as for FilterBySource
+	Project      string // as for FilterByProject
+}
+
+func (q SummaryQuery) matches(project, src string) bool {
+	if q.Source != "" {
+		want := q.Source
+		if strings.EqualFold(want, LocalSource) {
+			want = ""
+		}
+		if !strings.EqualFold(src, want) {
+			return false
+		}
+	}
+	return q.Project == "" || containsIgnoreCase(project, q.Project)
+}
+
+// SummaryFromCache answers each query as Aggregate would over a
+// LoadWithCache result, but from the summary_cache table: whole days come
+// from its rows and only sessions in the partial days at either end are read.
+// ok is false when any file changed since the table was built, or it was
+// built for another zone; the caller should then do a full load, which
+// rebuilds it.
+func SummaryFromCache(roots []source.Root, includeSubagents bool, cache *store.Cache, queries ...SummaryQuery) (stats []model.SummaryStats, ok bool, err error) {
+	diff, err := diffWithCache(roots, includeSubagents, cache)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(diff.toReparse) > 0 {
+		return nil, false, nil
+	}
+	fp, zone, err := cache.SummaryMeta()
+	if err != nil {
+		return nil, false, fmt.Errorf("reading summary cache: %w", err)
+	}
+	if fp != diff.fingerprint || zone != summaryZone() {
+		return nil, false, nil
+	}
+
+	marks, err := loadMarks(cache)
+	if err != nil {
+		return nil, false, err
+	}
+	for _, q := range queries {
+		s, err := summaryFromCache(cache, diff, marks, includeSubagents, q)
+		if err != nil {
+			return nil, false, err
+		}
+		stats = append(stats, s)
+	}
+	return stats, true, nil
+}
+
+func summaryFromCache(cache *store.Cache, diff *cacheDiff, marks sessionMarks, includeSubagents bool, q SummaryQuery) (model.SummaryStats, error) {
+	acc := newSummaryAcc()
+
+	// Whole local days inside the range, and the partial ones around them
+	firstDay := localMidnight(q.Since)
+	if firstDay.Before(q.Since) {
+		firstDay = firstDay.AddDate(0, 0, 1)
+	}
+	endDay := localMidnight(q.Until)
+	edges := [][2]time.Time{{q.Since, q.Until}}
+	if firstDay.Before(endDay) {
+		edges = [][2]time.Time{{q.Since, firstDay}, {endDay, q.Until}}
+
+		rows, err := cache.SummaryRows(firstDay.Format(DateLayout), endDay.Format(DateLayout))
+		if err != nil {
+			return model.SummaryStats{}, fmt.Errorf("reading summary cache: %w", err)
+		}
+		for _, r := range rows {
+			if r.Model == "" && q.matches(r.Project, r.Source) {
+				acc.addRow(r)
+			}
+		}
+	}
+
+	for _, e := range edges {
+		if !e[0].Before(e[1]) {
+			continue
+		}
+		sessions, err := cache.LoadSessionsBetween(e[0], e[1])
+		if err != nil {
+			return model.SummaryStats{}, fmt.Errorf("loading cached sessions: %w", err)
+		}
+		for _, s := range sessions {
+			marks.apply(&s)
+			if diff.includes(s, includeSubagents) && !s.Excluded && q.matches(s.Project, s.Source) {
+				acc.add(s)
+			}
+		}
+	}
+	return acc.summary(), nil
+}
+
+// addRow folds a whole-session summary_cache row into the totals.
+func (a *summaryAcc) addRow(r store.SummaryRow) {
+	stats := &a.stats
+	stats.TotalSessions += r.Sessions
+	stats.TotalPrompts += r.Prompts
+	stats.TotalAPICalls += r.APICalls
+	stats.TotalDurationSecs += r.DurationSecs
+	stats.ActiveSecs += r.ActiveSecs
+	stats.ClaudeSecs += r.ClaudeSecs
+
+	stats.InputTokens += r.InputTokens
+	stats.OutputTokens += r.OutputTokens
+	stats.CacheCreation5mTokens += r.CacheCreation5mTokens
+	stats.CacheCreation1hTokens += r.CacheCreation1hTokens
+	stats.CacheReadTokens += r.CacheReadTokens
+	stats.EstimatedCost += r.EstimatedCost
+	stats.CacheSavings += r.CacheSavings
+
+	if r.Sessions > 0 {
+		a.activeDays[r.Day] = struct{}{}
+	}
+}
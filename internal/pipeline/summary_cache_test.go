@@ -0,0 +1,120 @@
+package pipeline
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/model"
+	"github.com/theirongolddev/cburn/internal/source"
+	"github.com/theirongolddev/cburn/internal/store"
+)
+
+func TestSummaryFromCacheMatchesAggregate(t *testing.T) {
+	claude := t.TempDir()
+	base := time.Date(2025, 6, 1, 0, 0, 0, 0, time.Local)
+	for i := 0; i < 12; i++ {
+		project := []string{"-home-me-app", "-home-me-site"}[i%2]
+		dir := filepath.Join(claude, "projects", project)
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			t.Fatal(err)
+		}
+		start := base.Add(time.Duration(i*9) * time.Hour)
+		ts := start.UTC().Format(time.RFC3339)
+		later := start.Add(3 * time.Minute).UTC().Format(time.RFC3339)
+		data := `{"type":"user","timestamp":"` + ts + `"}` + "\n" +
+			`{"type":"user","timestamp":"` + later + `"}` + "\n" +
+			fmt.Sprintf(`{"type":"assistant","timestamp":"%s","message":{"id":"m%d","model":"claude-sonnet-4-6","usage":{"input_tokens":%d,"output_tokens":2,"cache_read_input_tokens":100}}}`, ts, i, 10+i) + "\n"
+		if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("s%02d.jsonl", i)), []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cache, err := store.Open(filepath.Join(t.TempDir(), "cache.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = cache.Close() }()
+	roots := []source.Root{{Path: claude}}
+
+	r, err := LoadWithCache(roots, true, cache, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Partial days at both ends, and a range inside one day
+	queries := []SummaryQuery{
+		{Since: base.Add(5 * time.Hour), Until: base.Add(80 * time.Hour)},
+		{Since: base.Add(5 * time.Hour), Until: base.Add(80 * time.Hour), Project: "site"},
+		{Since: base.Add(25 * time.Hour), Until: base.Add(40 * time.Hour)},
+	}
+	got, ok, err := SummaryFromCache(roots, true, cache, queries...)
+	if err != nil || !ok {
+		t.Fatalf("SummaryFromCache = ok %v, err %v", ok, err)
+	}
+	for i, q := range queries {
+		want := Aggregate(FilterByProject(r.Sessions, q.Project), q.Since, q.Until)
+		if !sameSummary(got[i], want) {
+			t.Errorf("query %d:\n got %+v\nwant %+v", i, got[i], want)
+		}
+	}
+
+	// A changed file makes the table stale until the next full load.
+	path := filepath.Join(claude, "projects", "-home-me-app", "s00.jsonl")
+	if err := os.WriteFile(path, []byte(`{"type":"user","timestamp":"2025-06-01T10:00:00Z"}`+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, _ := SummaryFromCache(roots, true, cache, queries...); ok {
+		t.Error("answered from a stale summary cache")
+	}
+	if _, err := LoadWithCache(roots, true, cache, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, _ := SummaryFromCache(roots, true, cache, queries...); !ok {
+		t.Error("summary cache not rebuilt by the full load")
+	}
+
+	// Hiding sessions (one in a whole day, one in an edge day) drops them
+	// from both paths.
+	for _, id := range []string{"s03", "s01"} {
+		if err := cache.SetExcluded(id, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, ok, _ := SummaryFromCache(roots, true, cache, queries...); ok {
+		t.Error("answered from a summary cache built before sessions were hidden")
+	}
+	if r, err = LoadWithCache(roots, true, cache, nil); err != nil {
+		t.Fatal(err)
+	}
+	visible := WithoutExcluded(r.Sessions)
+	if len(visible) != len(r.Sessions)-2 {
+		t.Fatalf("%d of %d sessions visible, want 2 hidden", len(visible), len(r.Sessions))
+	}
+	got, ok, err = SummaryFromCache(roots, true, cache, queries...)
+	if err != nil || !ok {
+		t.Fatalf("SummaryFromCache = ok %v, err %v", ok, err)
+	}
+	for i, q := range queries {
+		want := Aggregate(FilterByProject(visible, q.Project), q.Since, q.Until)
+		if !sameSummary(got[i], want) {
+			t.Errorf("query %d with hidden sessions:\n got %+v\nwant %+v", i, got[i], want)
+		}
+	}
+}
+
+// sameSummary compares summaries, allowing for float sums taken in another
+// order.
+func sameSummary(a, b model.SummaryStats) bool {
+	round := func(s *model.SummaryStats) {
+		for _, f := range []*float64{&s.EstimatedCost, &s.CacheSavings, &s.CacheHitRate, &s.CostPerDay} {
+			*f = math.Round(*f*1e12) / 1e12
+		}
+	}
+	round(&a)
+	round(&b)
+	return a == b
+}
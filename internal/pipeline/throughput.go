@@ -0,0 +1,10 @@
+package pipeline
+
+// OutputPerMinute returns output tokens per active minute, where active
+// time is the summed turn duration; 0 when no time was recorded.
+func OutputPerMinute(outputTokens, durationSecs int64) float64 {
+	if durationSecs <= 0 {
+		return 0
+	}
+	return float64(outputTokens) / (float64(durationSecs) / 60)
+}
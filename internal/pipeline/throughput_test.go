@@ -0,0 +1,12 @@
+package pipeline
+
+import "testing"
+
+func TestOutputPerMinute(t *testing.T) {
+	if got := OutputPerMinute(3000, 120); got != 1500 {
+		t.Errorf("OutputPerMinute(3000, 120s) = %v, want 1500", got)
+	}
+	if got := OutputPerMinute(3000, 0); got != 0 {
+		t.Errorf("no active time: %v, want 0", got)
+	}
+}
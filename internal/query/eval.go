@@ -0,0 +1,351 @@
+package query
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/model"
+	"github.com/theirongolddev/cburn/internal/pipeline"
+)
+
+// Result is a query's output: a row per group, with the group's keys
+// followed by one value per aggregate.
+type Result struct {
+	Columns []string // group fields, then aggregates like "sum(cost)"
+	Rows    []Row
+}
+
+// Row is one group of a Result.
+type Row struct {
+	Keys   []string
+	Values []float64
+}
+
+// Window narrows [since, until) to the query's days=, since=, and until=
+// conditions; days counts back from now.
+func (q *Query) Window(since, until, now time.Time) (time.Time, time.Time) {
+	if q.Days > 0 {
+		since, until = now.AddDate(0, 0, -q.Days), now
+	}
+	if !q.Since.IsZero() {
+		since = q.Since
+	}
+	if !q.Until.IsZero() {
+		until = q.Until
+	}
+	return since, until
+}
+
+// record is one session's usage of one model, the unit a query aggregates:
+// model metrics (cost, tokens, ...) are the model's share of the session,
+// and session metrics (prompts, duration) belong to the whole session.
+type record struct {
+	s     *model.SessionStats
+	model string
+	mu    *model.ModelUsage // nil for a session without API calls
+}
+
+// group holds, for each session in a group, its value for each aggregate:
+// model metrics summed over the session's records in the group, session
+// metrics taken once.
+type group struct {
+	keys     []string
+	sessions map[string][]float64
+}
+
+// Run evaluates the query over the sessions in [since, until). Hidden
+// sessions are left out. Aggregates are over sessions, so a session that
+// used several models counts once: count() counts distinct sessions, avg()
+// is a per-session average, and min() and max() compare sessions' totals
+// within the group rather than single models' shares.
+func (q *Query) Run(sessions []model.SessionStats, since, until time.Time) Result {
+	res := Result{Columns: append([]string{}, q.By...)}
+	for _, a := range q.Aggs {
+		res.Columns = append(res.Columns, a.String())
+	}
+
+	groups := make(map[string]*group)
+	var order []*group
+	inPeriod := pipeline.WithoutExcluded(pipeline.FilterByTime(sessions, since, until))
+	for i := range inPeriod {
+		s := &inPeriod[i]
+		if !q.matchesSession(s) {
+			continue
+		}
+		for _, r := range records(s) {
+			if !q.matchesModel(r.model) {
+				continue
+			}
+			keys := make([]string, len(q.By))
+			for k, field := range q.By {
+				keys[k] = groupKey(r, field)
+			}
+			id := strings.Join(keys, "\x00")
+			g, ok := groups[id]
+			if !ok {
+				g = &group{keys: keys, sessions: make(map[string][]float64)}
+				groups[id] = g
+				order = append(order, g)
+			}
+			vals, ok := g.sessions[s.SessionID]
+			if !ok {
+				vals = make([]float64, len(q.Aggs))
+				g.sessions[s.SessionID] = vals
+			}
+			for a, agg := range q.Aggs {
+				if agg.Metric == "" {
+					continue
+				}
+				if v, perSession := metricValue(r, agg.Metric); perSession {
+					vals[a] = v
+				} else {
+					vals[a] += v
+				}
+			}
+		}
+	}
+
+	// Without grouping there is always exactly one row, even if empty.
+	if len(q.By) == 0 && len(order) == 0 {
+		order = append(order, &group{sessions: map[string][]float64{}})
+	}
+
+	for _, g := range order {
+		row := Row{Keys: g.keys, Values: make([]float64, len(q.Aggs))}
+		n := float64(len(g.sessions))
+		for a, agg := range q.Aggs {
+			sum, lo, hi := 0.0, math.Inf(1), math.Inf(-1)
+			for _, vals := range g.sessions {
+				sum += vals[a]
+				lo, hi = min(lo, vals[a]), max(hi, vals[a])
+			}
+			switch agg.Func {
+			case "count":
+				row.Values[a] = n
+			case "sum":
+				row.Values[a] = sum
+			case "avg":
+				if n > 0 {
+					row.Values[a] = sum / n
+				}
+			case "min":
+				if n > 0 {
+					row.Values[a] = lo
+				}
+			case "max":
+				if n > 0 {
+					row.Values[a] = hi
+				}
+			}
+		}
+		res.Rows = append(res.Rows, row)
+	}
+
+	q.sortRows(res.Rows)
+	if q.Limit > 0 && len(res.Rows) > q.Limit {
+		res.Rows = res.Rows[:q.Limit]
+	}
+	return res
+}
+
+// sortRows orders groups by time when the first grouping is a time field,
+// and otherwise by the first aggregate, largest first.
+func (q *Query) sortRows(rows []Row) {
+	byTime := len(q.By) > 0 && contains([]string{"day", "week", "month", "hour"}, q.By[0])
+	sort.SliceStable(rows, func(i, j int) bool {
+		if !byTime && len(q.Aggs) > 0 && rows[i].Values[0] != rows[j].Values[0] {
+			return rows[i].Values[0] > rows[j].Values[0]
+		}
+		for k := range rows[i].Keys {
+			if rows[i].Keys[k] != rows[j].Keys[k] {
+				return rows[i].Keys[k] < rows[j].Keys[k]
+			}
+		}
+		return false
+	})
+}
+
+func records(s *model.SessionStats) []record {
+	if len(s.Models) == 0 {
+		return []record{{s: s}}
+	}
+	names := make([]string, 0, len(s.Models))
+	for name := range s.Models {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	recs := make([]record, len(names))
+	for i, name := range names {
+		recs[i] = record{s: s, model: name, mu: s.Models[name]}
+	}
+	return recs
+}
+
+// metricValue returns a record's value for a metric, and whether the
+// metric belongs to the whole session rather than the model.
+func metricValue(r record, metric string) (float64, bool) {
+	switch metric {
+	case "prompts":
+		return float64(r.s.UserMessages), true
+	case "duration":
+		return float64(r.s.DurationSecs), true
+	}
+	mu := r.mu
+	if mu == nil {
+		return 0, false
+	}
+	switch metric {
+	case "cost":
+		return mu.EstimatedCost, false
+	case "tokens":
+		return float64(mu.InputTokens + mu.OutputTokens + mu.CacheCreation5mTokens + mu.CacheCreation1hTokens), false
+	case "input":
+		return float64(mu.InputTokens), false
+	case "output":
+		return float64(mu.OutputTokens), false
+	case "cache_read":
+		return float64(mu.CacheReadTokens), false
+	case "cache_write":
+		return float64(mu.CacheCreation5mTokens + mu.CacheCreation1hTokens), false
+	case "calls":
+		return float64(mu.APICalls), false
+	}
+	return 0, false
+}
+
+func groupKey(r record, field string) string {
+	s := r.s
+	start := s.StartTime.In(time.Local)
+	switch field {
+	case "project":
+		return s.Project
+	case "model":
+		return r.model
+	case "day":
+		return start.Format("2006-01-02")
+	case "week":
+		monday := start.AddDate(0, 0, -((int(start.Weekday()) + 6) % 7))
+		return monday.Format("2006-01-02")
+	case "month":
+		return start.Format("2006-01")
+	case "hour":
+		return start.Format("15")
+	case "branch":
+		return s.GitBranch
+	case "repo":
+		return s.Repo
+	case "tag":
+		return s.Tag
+	case "origin":
+		return origin(s)
+	case "source":
+		if s.Source == "" {
+			return "local"
+		}
+		return s.Source
+	case "session":
+		return s.SessionID
+	}
+	return ""
+}
+
+func origin(s *model.SessionStats) string {
+	if s.Automation != "" {
+		return model.OriginAutomation
+	}
+	return model.OriginInteractive
+}
+
+// matchesSession applies every condition except those on the model.
+func (q *Query) matchesSession(s *model.SessionStats) bool {
+	for _, c := range q.Where {
+		var ok bool
+		switch c.Field {
+		case "model":
+			continue
+		case "cost":
+			ok = c.compare(s.EstimatedCost)
+		case "tokens":
+			ok = c.compare(float64(s.InputTokens + s.OutputTokens + s.CacheCreation5mTokens + s.CacheCreation1hTokens))
+		case "calls":
+			ok = c.compare(float64(s.APICalls))
+		case "prompts":
+			ok = c.compare(float64(s.UserMessages))
+		case "duration":
+			ok = c.compare(float64(s.DurationSecs) / 60)
+		case "tag":
+			// The [projects] tag or any user tag
+			ok = c.matchAny(append([]string{s.Tag}, s.UserTags...))
+		case "project":
+			ok = c.matchText(s.Project)
+		case "branch":
+			ok = c.matchText(s.GitBranch)
+		case "repo":
+			ok = c.matchText(s.Repo)
+		case "origin":
+			ok = c.matchText(origin(s))
+		case "source":
+			ok = c.matchText(groupKey(record{s: s}, "source"))
+		case "session":
+			ok = c.matchText(s.SessionID)
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesModel applies the conditions on the model to one model's usage.
+func (q *Query) matchesModel(name string) bool {
+	for _, c := range q.Where {
+		if c.Field == "model" && !c.matchText(name) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchText compares text case-insensitively; ~ is a regular expression
+// search.
+func (c Cond) matchText(text string) bool {
+	switch c.Op {
+	case "~":
+		return c.re.MatchString(text)
+	case "!~":
+		return !c.re.MatchString(text)
+	case "!=":
+		return !strings.EqualFold(text, c.Value)
+	}
+	return strings.EqualFold(text, c.Value)
+}
+
+// matchAny is matchText over several values: a positive condition needs
+// one of them to match, a negative one needs all of them to.
+func (c Cond) matchAny(texts []string) bool {
+	negative := c.Op == "!=" || c.Op == "!~"
+	for _, t := range texts {
+		if c.matchText(t) != negative {
+			return !negative
+		}
+	}
+	return negative
+}
+
+func (c Cond) compare(v float64) bool {
+	switch c.Op {
+	case "!=":
+		return v != c.num
+	case ">":
+		return v > c.num
+	case ">=":
+		return v >= c.num
+	case "<":
+		return v < c.num
+	case "<=":
+		return v <= c.num
+	}
+	return v == c.num
+}
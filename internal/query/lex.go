@@ -0,0 +1,81 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+)
+
+type tokKind int
+
+const (
+	tokEOF tokKind = iota
+	tokWord
+	tokString
+	tokOp
+	tokComma
+	tokLParen
+	tokRParen
+)
+
+type token struct {
+	kind tokKind
+	text string
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of query"
+	case tokString:
+		return fmt.Sprintf("%q", t.text)
+	}
+	return "'" + t.text + "'"
+}
+
+// lex splits a query into tokens. Words run until whitespace or
+// punctuation, so dates, numbers like 1.5M, and paths need no quotes;
+// anything else goes in single or double quotes.
+func lex(src string) ([]token, error) {
+	var toks []token
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == ',':
+			toks = append(toks, token{tokComma, ","})
+			i++
+		case c == '(':
+			toks = append(toks, token{tokLParen, "("})
+			i++
+		case c == ')':
+			toks = append(toks, token{tokRParen, ")"})
+			i++
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(src[i+1:], c)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string starting at %q", src[i:])
+			}
+			toks = append(toks, token{tokString, src[i+1 : i+1+end]})
+			i += end + 2
+		case strings.IndexByte("=!~<>", c) >= 0:
+			op := string(c)
+			if i+1 < len(src) && (src[i+1] == '=' && c != '=' && c != '~' || (c == '!' && src[i+1] == '~')) {
+				op += string(src[i+1])
+			}
+			if op == "!" {
+				return nil, fmt.Errorf("'!' must be followed by '=' or '~'")
+			}
+			toks = append(toks, token{tokOp, op})
+			i += len(op)
+		default:
+			j := i
+			for j < len(src) && !strings.ContainsRune(" \t\n\r,()\"'=!~<>", rune(src[j])) {
+				j++
+			}
+			toks = append(toks, token{tokWord, src[i:j]})
+			i = j
+		}
+	}
+	return toks, nil
+}
@@ -0,0 +1,303 @@
+// Package query implements the expression language of `cburn query`: one
+// or more aggregates over sessions, optionally grouped, filtered, and
+// limited, e.g.
+//
+//	sum(cost), count() by project where days=7 and model~"opus" limit 5
+package query
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Aggregate functions.
+var funcs = map[string]bool{"sum": true, "avg": true, "min": true, "max": true, "count": true}
+
+// Metrics split between those a model contributes to within a session and
+// those that belong to the whole session.
+var (
+	modelMetrics   = []string{"cost", "tokens", "input", "output", "cache_read", "cache_write", "calls"}
+	sessionMetrics = []string{"prompts", "duration"}
+)
+
+// Fields a query can group by, and the string fields it can filter on.
+var (
+	groupFields  = []string{"project", "model", "day", "week", "month", "hour", "branch", "repo", "tag", "origin", "source", "session"}
+	stringFields = []string{"project", "model", "branch", "repo", "tag", "origin", "source", "session"}
+	// numberFields filter on a session's totals; duration is in minutes.
+	numberFields = []string{"cost", "tokens", "calls", "prompts", "duration"}
+)
+
+// Query is a parsed query.
+type Query struct {
+	Aggs  []Agg
+	By    []string
+	Where []Cond
+	Limit int // 0 for no limit
+
+	// The time window from `days=`, `since=`, and `until=` conditions;
+	// zero values leave the caller's window in place.
+	Days         int
+	Since, Until time.Time
+}
+
+// Agg is one aggregate column, e.g. sum(cost). Metric is "" for count().
+type Agg struct {
+	Func   string
+	Metric string
+}
+
+func (a Agg) String() string {
+	return a.Func + "(" + a.Metric + ")"
+}
+
+// Cond is one filter condition, e.g. model~"opus" or cost>5.
+type Cond struct {
+	Field string
+	Op    string // = != ~ !~ > >= < <=
+	Value string
+
+	re  *regexp.Regexp // for ~ and !~
+	num float64        // for number fields
+}
+
+// Parse parses a query.
+func Parse(src string) (*Query, error) {
+	toks, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{toks: toks}
+	q, err := p.query()
+	if err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+type parser struct {
+	toks []token
+	pos  int
+}
+
+func (p *parser) peek() token {
+	if p.pos < len(p.toks) {
+		return p.toks[p.pos]
+	}
+	return token{kind: tokEOF}
+}
+
+func (p *parser) next() token {
+	t := p.peek()
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+// keyword consumes the next token if it is the keyword kw.
+func (p *parser) keyword(kw string) bool {
+	if t := p.peek(); t.kind == tokWord && strings.EqualFold(t.text, kw) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(kind tokKind, what string) (token, error) {
+	t := p.next()
+	if t.kind != kind {
+		return t, fmt.Errorf("expected %s, found %s", what, t)
+	}
+	return t, nil
+}
+
+func (p *parser) query() (*Query, error) {
+	q := &Query{}
+	for {
+		a, err := p.agg()
+		if err != nil {
+			return nil, err
+		}
+		q.Aggs = append(q.Aggs, a)
+		if p.peek().kind != tokComma {
+			break
+		}
+		p.next()
+	}
+
+	if p.keyword("by") {
+		for {
+			t, err := p.expect(tokWord, "a field to group by")
+			if err != nil {
+				return nil, err
+			}
+			field := strings.ToLower(t.text)
+			if !contains(groupFields, field) {
+				return nil, fmt.Errorf("cannot group by %q (have %s)", t.text, strings.Join(groupFields, ", "))
+			}
+			q.By = append(q.By, field)
+			if p.peek().kind != tokComma {
+				break
+			}
+			p.next()
+		}
+	}
+
+	if p.keyword("where") {
+		for {
+			if err := p.cond(q); err != nil {
+				return nil, err
+			}
+			if !p.keyword("and") {
+				break
+			}
+		}
+	}
+
+	if p.keyword("limit") {
+		t, err := p.expect(tokWord, "a number after limit")
+		if err != nil {
+			return nil, err
+		}
+		n, err := strconv.Atoi(t.text)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("limit must be a positive whole number, not %q", t.text)
+		}
+		q.Limit = n
+	}
+
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %s", t)
+	}
+	return q, nil
+}
+
+func (p *parser) agg() (Agg, error) {
+	t, err := p.expect(tokWord, "an aggregate like sum(cost) or count()")
+	if err != nil {
+		return Agg{}, err
+	}
+	a := Agg{Func: strings.ToLower(t.text)}
+	if !funcs[a.Func] {
+		return Agg{}, fmt.Errorf("unknown function %q (have sum, avg, min, max, count)", t.text)
+	}
+	if _, err := p.expect(tokLParen, "'(' after "+a.Func); err != nil {
+		return Agg{}, err
+	}
+	if m := p.peek(); m.kind == tokWord {
+		p.next()
+		a.Metric = strings.ToLower(m.text)
+	}
+	if _, err := p.expect(tokRParen, "')'"); err != nil {
+		return Agg{}, err
+	}
+
+	switch {
+	case a.Func == "count" && a.Metric != "":
+		return Agg{}, errors.New("count() counts sessions and takes no metric")
+	case a.Func != "count" && a.Metric == "":
+		return Agg{}, fmt.Errorf("%s() needs a metric (%s)", a.Func, strings.Join(allMetrics(), ", "))
+	case a.Metric != "" && !contains(allMetrics(), a.Metric):
+		return Agg{}, fmt.Errorf("unknown metric %q (have %s)", a.Metric, strings.Join(allMetrics(), ", "))
+	}
+	return a, nil
+}
+
+func (p *parser) cond(q *Query) error {
+	ft, err := p.expect(tokWord, "a field to filter on")
+	if err != nil {
+		return err
+	}
+	ot, err := p.expect(tokOp, "an operator after "+ft.text)
+	if err != nil {
+		return err
+	}
+	vt := p.next()
+	if vt.kind != tokWord && vt.kind != tokString {
+		return fmt.Errorf("expected a value after %s%s, found %s", ft.text, ot.text, vt)
+	}
+	c := Cond{Field: strings.ToLower(ft.text), Op: ot.text, Value: vt.text}
+
+	switch {
+	case c.Field == "days":
+		n, err := strconv.Atoi(c.Value)
+		if c.Op != "=" || err != nil || n < 1 {
+			return fmt.Errorf("days takes '=' and a positive whole number, as in days=7")
+		}
+		q.Days = n
+		return nil
+	case c.Field == "since" || c.Field == "until":
+		d, err := time.ParseInLocation("2006-01-02", c.Value, time.Local)
+		if c.Op != "=" || err != nil {
+			return fmt.Errorf("%s takes '=' and a date, as in %s=2025-12-01", c.Field, c.Field)
+		}
+		if c.Field == "since" {
+			q.Since = d
+		} else {
+			q.Until = d.AddDate(0, 0, 1) // through the end of that day
+		}
+		return nil
+	case contains(numberFields, c.Field):
+		if c.Op == "~" || c.Op == "!~" {
+			return fmt.Errorf("%s is a number; use = != > >= < <=", c.Field)
+		}
+		if c.num, err = parseNumber(c.Value); err != nil {
+			return fmt.Errorf("%s%s%s: %q is not a number", c.Field, c.Op, c.Value, c.Value)
+		}
+	case contains(stringFields, c.Field):
+		switch c.Op {
+		case "=", "!=":
+		case "~", "!~":
+			if c.re, err = regexp.Compile("(?i)" + c.Value); err != nil {
+				return fmt.Errorf("%s%s: %w", c.Field, c.Op, err)
+			}
+		default:
+			return fmt.Errorf("%s is text; use = != ~ !~", c.Field)
+		}
+	default:
+		return fmt.Errorf("cannot filter on %q (have days, since, until, %s, %s)",
+			ft.text, strings.Join(stringFields, ", "), strings.Join(numberFields, ", "))
+	}
+	q.Where = append(q.Where, c)
+	return nil
+}
+
+// parseNumber reads a number with an optional K, M, or B suffix, and an
+// optional leading $ for costs.
+func parseNumber(s string) (float64, error) {
+	s = strings.TrimPrefix(s, "$")
+	mult := 1.0
+	if s != "" {
+		switch s[len(s)-1] {
+		case 'k', 'K':
+			mult = 1e3
+		case 'm', 'M':
+			mult = 1e6
+		case 'b', 'B':
+			mult = 1e9
+		}
+	}
+	if mult != 1 {
+		s = s[:len(s)-1]
+	}
+	n, err := strconv.ParseFloat(s, 64)
+	return n * mult, err
+}
+
+func allMetrics() []string {
+	return append(append([]string{}, modelMetrics...), sessionMetrics...)
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
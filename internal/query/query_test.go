@@ -0,0 +1,131 @@
+package query
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+func TestParse(t *testing.T) {
+	q, err := Parse(`sum(cost), count() by project, model where days=7 and model~"opus" and cost>=1.5 limit 5`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(q.Aggs) != 2 || q.Aggs[0].String() != "sum(cost)" || q.Aggs[1].String() != "count()" {
+		t.Errorf("aggs = %v", q.Aggs)
+	}
+	if !slices.Equal(q.By, []string{"project", "model"}) || q.Days != 7 || q.Limit != 5 {
+		t.Errorf("by = %v, days = %d, limit = %d", q.By, q.Days, q.Limit)
+	}
+	if len(q.Where) != 2 || q.Where[0].Op != "~" || q.Where[1].Op != ">=" || q.Where[1].num != 1.5 {
+		t.Errorf("where = %+v", q.Where)
+	}
+
+	q, err = Parse(`SUM(tokens) BY day WHERE since=2025-12-01 AND until=2025-12-07 AND tokens>1M AND tag!='billable'`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.Since.Format("2006-01-02") != "2025-12-01" || q.Until.Format("2006-01-02") != "2025-12-08" {
+		t.Errorf("window = %v..%v, want through the end of Dec 7", q.Since, q.Until)
+	}
+	if q.Where[0].num != 1e6 || q.Where[1].Value != "billable" {
+		t.Errorf("where = %+v", q.Where)
+	}
+
+	for _, bad := range []string{
+		"",
+		"cost",
+		"sum()",
+		"count(cost)",
+		"median(cost)",
+		"sum(dollars)",
+		"sum(cost) by color",
+		"sum(cost) where project>api",
+		"sum(cost) where cost~5",
+		"sum(cost) where days=week",
+		"sum(cost) where since=yesterday",
+		`sum(cost) where project="api`,
+		"sum(cost) limit 0",
+		"sum(cost) by project extra",
+		"sum(cost) where model~(",
+	} {
+		if _, err := Parse(bad); err == nil {
+			t.Errorf("Parse(%q): expected an error", bad)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.Local)
+	mu := func(cost float64, calls int) *model.ModelUsage {
+		return &model.ModelUsage{EstimatedCost: cost, APICalls: calls, InputTokens: 100}
+	}
+	sessions := []model.SessionStats{
+		{SessionID: "a", Project: "api", StartTime: now.Add(-time.Hour), UserMessages: 4, EstimatedCost: 5,
+			Models: map[string]*model.ModelUsage{"claude-opus-4-6": mu(4, 2), "claude-haiku-4-5": mu(1, 3)}},
+		{SessionID: "b", Project: "api", StartTime: now.Add(-25 * time.Hour), UserMessages: 2, EstimatedCost: 2,
+			Models: map[string]*model.ModelUsage{"claude-opus-4-6": mu(2, 1)}},
+		{SessionID: "c", Project: "web", StartTime: now.Add(-2 * time.Hour), UserMessages: 1, EstimatedCost: 3, Automation: "CI checkout",
+			Models: map[string]*model.ModelUsage{"claude-sonnet-4-6": mu(3, 5)}},
+		{SessionID: "d", Project: "web", StartTime: now.Add(-time.Hour), EstimatedCost: 50, Excluded: true,
+			Models: map[string]*model.ModelUsage{"claude-opus-4-6": mu(50, 9)}},
+		{SessionID: "old", Project: "api", StartTime: now.AddDate(0, 0, -20), EstimatedCost: 9,
+			Models: map[string]*model.ModelUsage{"claude-opus-4-6": mu(9, 1)}},
+	}
+
+	run := func(src string) Result {
+		t.Helper()
+		q, err := Parse(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		since, until := q.Window(now.AddDate(0, 0, -30), now, now)
+		return q.Run(sessions, since, until)
+	}
+	rows := func(r Result) string {
+		var parts []string
+		for _, row := range r.Rows {
+			var vals []string
+			for _, v := range row.Values {
+				vals = append(vals, strconv.FormatFloat(v, 'f', -1, 64))
+			}
+			parts = append(parts, strings.Join(append(row.Keys, vals...), " "))
+		}
+		return strings.Join(parts, "; ")
+	}
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		// Hidden and out-of-window sessions are left out.
+		{`sum(cost), count()`, "19 4"},
+		{`sum(cost) by project where days=7`, "api 7; web 3"},
+		{`sum(cost), count() by project where days=7 and model~"opus"`, "api 6 2"},
+		// A session's prompts count once however many models it used.
+		{`sum(prompts), avg(prompts) by project where days=7`, "api 6 3; web 1 1"},
+		{`sum(calls) by model where days=7`, "claude-sonnet-4-6 5; claude-haiku-4-5 3; claude-opus-4-6 3"},
+		{`sum(cost) by origin where days=7`, "Interactive 7; Automation 3"},
+		// min and max compare whole sessions, not one model's share of one.
+		{`max(cost), min(cost) by project where project=api`, "api 9 2"},
+		{`min(cost), max(cost) where session=a`, "5 5"},
+		{`min(cost) by model where days=7`, "claude-sonnet-4-6 3; claude-opus-4-6 2; claude-haiku-4-5 1"},
+		{`sum(cost) by day where days=7`, "2026-03-09 2; 2026-03-10 8"},
+		{`count() where cost>2.5 and project!=web`, "2"},
+		{`sum(cost) by project limit 1`, "api 16"},
+		{`sum(cost) where project=nothing`, "0"},
+	}
+	for _, tt := range tests {
+		if got := rows(run(tt.query)); got != tt.want {
+			t.Errorf("%s\n  got  %s\n  want %s", tt.query, got, tt.want)
+		}
+	}
+
+	if cols := run(`sum(cost), count() by project`).Columns; !slices.Equal(cols, []string{"project", "sum(cost)", "count()"}) {
+		t.Errorf("columns = %v", cols)
+	}
+}
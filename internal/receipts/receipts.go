@@ -0,0 +1,266 @@
+// Package receipts writes per-session cost receipts into project directories.
+//
+// When enabled (config `[receipts] enabled = true`), each finished session gets
+// one JSON line appended to <project>/.cburn/receipts.jsonl. The .cburn
+// directory carries its own .gitignore so receipts are never committed by
+// accident. Teammates' receipt files dropped into the same directory
+// (e.g. receipts-alice.jsonl) are merged by Load.
+package receipts
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+const (
+	// DirName is the per-project directory holding receipts.
+	DirName = ".cburn"
+	// FileName is the receipt log written by this machine.
+	FileName = "receipts.jsonl"
+
+	// CompletedAfter is how long a session must be idle before it is
+	// considered finished and receives a receipt.
+	CompletedAfter = 30 * time.Minute
+)
+
+// Receipt summarizes one finished session, including its subagents.
+type Receipt struct {
+	SessionID        string    `json:"session_id"`
+	Project          string    `json:"project"`
+	Branch           string    `json:"branch,omitempty"`
+	Author           string    `json:"author"`
+	StartTime        time.Time `json:"start_time"`
+	EndTime          time.Time `json:"end_time"`
+	DurationSecs     int64     `json:"duration_secs"`
+	Prompts          int       `json:"prompts"`
+	APICalls         int       `json:"api_calls"`
+	Subagents        int       `json:"subagents,omitempty"`
+	InputTokens      int64     `json:"input_tokens"`
+	OutputTokens     int64     `json:"output_tokens"`
+	CacheWriteTokens int64     `json:"cache_write_tokens"`
+	CacheReadTokens  int64     `json:"cache_read_tokens"`
+	CostUSD          float64   `json:"cost_usd"`
+	Models           []string  `json:"models"`
+}
+
+// Author returns the identity stamped on receipts written by this machine.
+func Author() string {
+	name := "unknown"
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		name = u.Username
+	}
+	if host, err := os.Hostname(); err == nil && host != "" {
+		name += "@" + host
+	}
+	return name
+}
+
+// WriteCompleted appends receipts for finished top-level sessions that don't
+// have one yet. Subagent usage is folded into its parent's receipt. Sessions
+// whose project directory no longer exists, or that were imported from
+// another machine, are skipped.
+// Returns the number of receipts written.
+func WriteCompleted(sessions []model.SessionStats, now time.Time) (int, error) {
+	author := Author()
+
+	children := make(map[string][]model.SessionStats)
+	for _, s := range sessions {
+		if s.IsSubagent && s.ParentSession != "" {
+			children[s.ParentSession] = append(children[s.ParentSession], s)
+		}
+	}
+
+	byDir := make(map[string][]Receipt)
+	for _, s := range sessions {
+		if s.IsSubagent || s.Source != "" || s.ProjectPath == "" || s.EndTime.IsZero() {
+			continue
+		}
+		if now.Sub(s.EndTime) < CompletedAfter {
+			continue
+		}
+		r := newReceipt(s, children[s.SessionID], author)
+		if r.APICalls == 0 {
+			continue
+		}
+		byDir[s.ProjectPath] = append(byDir[s.ProjectPath], r)
+	}
+
+	written := 0
+	var errs []error
+	for dir, pending := range byDir {
+		n, err := appendNew(dir, pending)
+		written += n
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return written, errors.Join(errs...)
+}
+
+func newReceipt(s model.SessionStats, subagents []model.SessionStats, author string) Receipt {
+	r := Receipt{
+		SessionID:    s.SessionID,
+		Project:      s.Project,
+		Branch:       s.GitBranch,
+		Author:       author,
+		StartTime:    s.StartTime.UTC(),
+		EndTime:      s.EndTime.UTC(),
+		DurationSecs: s.DurationSecs,
+		Prompts:      s.UserMessages,
+		Subagents:    len(subagents),
+	}
+
+	models := make(map[string]struct{})
+	for _, part := range append([]model.SessionStats{s}, subagents...) {
+		r.APICalls += part.APICalls
+		r.InputTokens += part.InputTokens
+		r.OutputTokens += part.OutputTokens
+		r.CacheWriteTokens += part.CacheCreation5mTokens + part.CacheCreation1hTokens
+		r.CacheReadTokens += part.CacheReadTokens
+		r.CostUSD += part.EstimatedCost
+		for m := range part.Models {
+			models[m] = struct{}{}
+		}
+		if part.EndTime.After(r.EndTime) {
+			r.EndTime = part.EndTime.UTC()
+		}
+	}
+	for m := range models {
+		r.Models = append(r.Models, m)
+	}
+	sort.Strings(r.Models)
+	return r
+}
+
+// appendNew writes receipts not already present in dir's receipt file.
+func appendNew(projectDir string, pending []Receipt) (int, error) {
+	if info, err := os.Stat(projectDir); err != nil || !info.IsDir() {
+		return 0, nil
+	}
+
+	dir := filepath.Join(projectDir, DirName)
+	path := filepath.Join(dir, FileName)
+
+	existing, err := readFile(path)
+	if err != nil {
+		return 0, err
+	}
+	seen := make(map[string]struct{}, len(existing))
+	for _, r := range existing {
+		seen[r.SessionID] = struct{}{}
+	}
+
+	var lines []byte
+	n := 0
+	for _, r := range pending {
+		if _, ok := seen[r.SessionID]; ok {
+			continue
+		}
+		b, err := json.Marshal(r)
+		if err != nil {
+			return 0, err
+		}
+		lines = append(lines, b...)
+		lines = append(lines, '\n')
+		n++
+	}
+	if n == 0 {
+		return 0, nil
+	}
+
+	if err := ensureDir(dir); err != nil {
+		return 0, err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644) //nolint:gosec // receipts are meant to be readable by teammates
+	if err != nil {
+		return 0, fmt.Errorf("opening receipts: %w", err)
+	}
+	if _, err := f.Write(lines); err != nil {
+		_ = f.Close()
+		return 0, fmt.Errorf("writing receipts: %w", err)
+	}
+	return n, f.Close()
+}
+
+// ensureDir creates the .cburn directory with a .gitignore that ignores itself.
+func ensureDir(dir string) error {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("creating %s: %w", dir, err)
+	}
+	ignore := filepath.Join(dir, ".gitignore")
+	if _, err := os.Stat(ignore); errors.Is(err, os.ErrNotExist) {
+		if err := os.WriteFile(ignore, []byte("*\n"), 0o644); err != nil { //nolint:gosec // plain .gitignore
+			return fmt.Errorf("writing %s: %w", ignore, err)
+		}
+	}
+	return nil
+}
+
+// Load reads and merges every receipts*.jsonl file in projectDir/.cburn,
+// deduplicating by session ID. Results are sorted by start time.
+func Load(projectDir string) ([]Receipt, error) {
+	paths, err := filepath.Glob(filepath.Join(projectDir, DirName, "receipts*.jsonl"))
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{})
+	var all []Receipt
+	for _, p := range paths {
+		rs, err := readFile(p)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range rs {
+			if _, ok := seen[r.SessionID]; ok {
+				continue
+			}
+			seen[r.SessionID] = struct{}{}
+			all = append(all, r)
+		}
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].StartTime.Before(all[j].StartTime)
+	})
+	return all, nil
+}
+
+// readFile parses a receipt log, skipping malformed lines. A missing file yields none.
+func readFile(path string) ([]Receipt, error) {
+	f, err := os.Open(path) //nolint:gosec // path is built from a project directory
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("opening receipts: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var out []Receipt
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var r Receipt
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			continue
+		}
+		out = append(out, r)
+	}
+	if err := scanner.Err(); err != nil {
+		return out, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return out, nil
+}
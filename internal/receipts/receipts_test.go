@@ -0,0 +1,51 @@
+package receipts
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+func TestWriteCompleted(t *testing.T) {
+	proj := t.TempDir()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	sessions := []model.SessionStats{
+		{SessionID: "done", Project: "p", ProjectPath: proj, EndTime: now.Add(-time.Hour), APICalls: 2, EstimatedCost: 1.5},
+		{SessionID: "sub", Project: "p", ProjectPath: proj, IsSubagent: true, ParentSession: "done", EndTime: now.Add(-time.Hour), APICalls: 1, EstimatedCost: 0.5},
+		{SessionID: "live", Project: "p", ProjectPath: proj, EndTime: now.Add(-time.Minute), APICalls: 3, EstimatedCost: 9},
+		{SessionID: "gone", Project: "q", ProjectPath: filepath.Join(proj, "missing"), EndTime: now.Add(-time.Hour), APICalls: 1},
+	}
+
+	n, err := WriteCompleted(sessions, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Fatalf("wrote %d receipts, want 1", n)
+	}
+
+	// Second run must not duplicate.
+	if n, _ := WriteCompleted(sessions, now); n != 0 {
+		t.Errorf("second run wrote %d receipts, want 0", n)
+	}
+
+	if _, err := os.Stat(filepath.Join(proj, DirName, ".gitignore")); err != nil {
+		t.Errorf("missing .gitignore: %v", err)
+	}
+
+	got, err := Load(proj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("loaded %d receipts, want 1", len(got))
+	}
+	r := got[0]
+	if r.SessionID != "done" || r.APICalls != 3 || r.Subagents != 1 || r.CostUSD != 2.0 {
+		t.Errorf("receipt = %+v, want parent+subagent totals", r)
+	}
+}
@@ -0,0 +1,192 @@
+// Package redact swaps identifying names (projects, repositories, branches,
+// session IDs, organizations) for stable pseudonyms, so a dashboard can be
+// screenshotted or screen-shared without leaking client names.
+package redact
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"sync/atomic"
+
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+var enabled atomic.Bool
+
+// SetEnabled turns privacy mode on or off.
+func SetEnabled(on bool) { enabled.Store(on) }
+
+// Enabled reports whether privacy mode is on.
+func Enabled() bool { return enabled.Load() }
+
+// Name returns a stable pseudonym for s such as "project-3f9a2c", the same
+// for the same input every run. Empty names stay empty.
+func Name(kind, s string) string {
+	if s == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(kind + "\x00" + s))
+	return kind + "-" + hex.EncodeToString(sum[:3])
+}
+
+// ID returns id, or a pseudonymous ID of the same short form in privacy mode.
+func ID(id string) string {
+	if !Enabled() || id == "" {
+		return id
+	}
+	sum := sha256.Sum256([]byte(id))
+	return hex.EncodeToString(sum[:4])
+}
+
+// Org returns an organization name, masked in privacy mode.
+func Org(name string) string {
+	if !Enabled() {
+		return name
+	}
+	return Name("org", name)
+}
+
+// Project returns a project name, masked in privacy mode.
+func Project(name string) string {
+	if !Enabled() {
+		return name
+	}
+	return Name("project", name)
+}
+
+// User returns a teammate's email or API key name, masked in privacy mode.
+func User(name string) string {
+	if !Enabled() {
+		return name
+	}
+	return Name("user", name)
+}
+
+// Path returns a file path, reduced to a masked project and file name in
+// privacy mode.
+func Path(p string) string {
+	if !Enabled() || p == "" {
+		return p
+	}
+	return path.Join(Name("project", path.Base(path.Dir(p))), ID(path.Base(p)))
+}
+
+// Sessions returns sessions with project, repository, branch, tag, and
+// file path replaced by pseudonyms when privacy mode is on, else sessions
+// unchanged. Session IDs are kept, since notes and exclusions are filed
+// under them; display them through ID.
+func Sessions(sessions []model.SessionStats) []model.SessionStats {
+	if !Enabled() {
+		return sessions
+	}
+	out := make([]model.SessionStats, len(sessions))
+	for i, s := range sessions {
+		s.Project = Name("project", s.Project)
+		s.ProjectPath = Name("path", s.ProjectPath)
+		s.Repo = Name("repo", s.Repo)
+		s.GitBranch = Name("branch", s.GitBranch)
+		s.Tag = Name("tag", s.Tag)
+		s.FilePath = Path(s.FilePath)
+		out[i] = s
+	}
+	return out
+}
+
+// Line returns the shape of a JSONL line with its content removed: object
+// keys, numbers, punctuation, and true/false/null are kept, while every
+// other letter, including all of each string value, becomes "x". Broken
+// lines stay broken in the same place, so the shape shows what went wrong
+// without the prompt or code it held. The result is cut at limit bytes.
+func Line(line []byte, limit int) string {
+	out := make([]byte, 0, min(len(line), limit))
+	for i := 0; i < len(line) && len(out) < limit; {
+		c := line[i]
+		switch {
+		case c == '"':
+			end := stringEnd(line, i+1)
+			key := end < len(line) && nextNonSpace(line, end+1) == ':' && plainKey(line[i+1:end])
+			out = append(out, '"')
+			for j := i + 1; j < end && len(out) < limit; j++ {
+				if key {
+					out = append(out, line[j])
+				} else if line[j] != '\\' {
+					out = append(out, 'x')
+				}
+			}
+			if end < len(line) {
+				out = append(out, '"')
+			}
+			i = end + 1
+		case isLetter(c):
+			j := i
+			for j < len(line) && isLetter(line[j]) {
+				j++
+			}
+			switch w := string(line[i:j]); w {
+			case "true", "false", "null":
+				out = append(out, w...)
+			default:
+				out = append(out, bytes.Repeat([]byte("x"), j-i)...)
+			}
+			i = j
+		case c < 0x80:
+			out = append(out, c)
+			i++
+		default: // non-ASCII outside a string
+			out = append(out, 'x')
+			i++
+		}
+	}
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	s := string(out)
+	if len(line) > limit {
+		s += fmt.Sprintf("…(%d bytes)", len(line))
+	}
+	return s
+}
+
+// stringEnd returns the index of the quote closing the string starting at
+// i, or len(b) if it never closes.
+func stringEnd(b []byte, i int) int {
+	for ; i < len(b); i++ {
+		switch b[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return len(b)
+}
+
+func nextNonSpace(b []byte, i int) byte {
+	for ; i < len(b); i++ {
+		if b[i] != ' ' && b[i] != '\t' {
+			return b[i]
+		}
+	}
+	return 0
+}
+
+// plainKey reports whether a key looks like a field name rather than
+// content used as a map key.
+func plainKey(k []byte) bool {
+	if len(k) == 0 || len(k) > 40 {
+		return false
+	}
+	for _, c := range k {
+		if !isLetter(c) && (c < '0' || c > '9') && c != '_' && c != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
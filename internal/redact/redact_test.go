@@ -0,0 +1,62 @@
+package redact
+
+import (
+	"testing"
+
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+func TestSessionsMasksNames(t *testing.T) {
+	in := []model.SessionStats{
+		{SessionID: "abcdef123456", Project: "acme-portal", Repo: "acme/portal", GitBranch: "main"},
+		{SessionID: "fedcba654321", Project: "acme-portal"},
+	}
+
+	SetEnabled(false)
+	if got := Sessions(in); got[0].Project != "acme-portal" {
+		t.Fatalf("disabled: project = %q, want unchanged", got[0].Project)
+	}
+	if got := ID("abcdef123456"); got != "abcdef123456" {
+		t.Fatalf("disabled: ID = %q, want unchanged", got)
+	}
+
+	SetEnabled(true)
+	defer SetEnabled(false)
+	got := Sessions(in)
+	if got[0].Project == "acme-portal" || got[0].Repo == "acme/portal" {
+		t.Fatalf("enabled: names leaked: %+v", got[0])
+	}
+	if got[0].Project != got[1].Project {
+		t.Errorf("same project masked differently: %q vs %q", got[0].Project, got[1].Project)
+	}
+	if got[0].SessionID != in[0].SessionID {
+		t.Errorf("SessionID changed to %q; annotations key on it", got[0].SessionID)
+	}
+	if in[0].Project != "acme-portal" {
+		t.Errorf("input modified: %q", in[0].Project)
+	}
+	if id := ID("abcdef123456"); id == "abcdef123456" || len(id) != 8 {
+		t.Errorf("ID = %q, want an 8-char pseudonym", id)
+	}
+	if Org("Acme Corp") == "Acme Corp" {
+		t.Error("org name not masked")
+	}
+}
+
+func TestLine(t *testing.T) {
+	for _, tc := range []struct {
+		in, want string
+	}{
+		{`{"type":"assistant","n":12,"ok":true,"text":"secret plan"}`, `{"type":"xxxxxxxxx","n":12,"ok":true,"text":"xxxxxxxxxxx"}`},
+		{`{"type":"assistant","message":{"content":"rm -rf /ho`, `{"type":"xxxxxxxxx","message":{"content":"xxxxxxxxxx`},
+		{`{"a":"x\"y"} trailing garbage`, `{"a":"xxx"} xxxxxxxx xxxxxxx`},
+		{`{"my secret key":1}`, `{"xxxxxxxxxxxxx":1}`},
+	} {
+		if got := Line([]byte(tc.in), 200); got != tc.want {
+			t.Errorf("Line(%s)\n got %s\nwant %s", tc.in, got, tc.want)
+		}
+	}
+	if got := Line([]byte(`{"text":"abcdefghij"}`), 8); got != `{"text":…(21 bytes)` {
+		t.Errorf("cut line = %s", got)
+	}
+}
@@ -0,0 +1,169 @@
+// Package secrets keeps API keys in the operating system's credential
+// store: the macOS Keychain through security(1), the freedesktop Secret
+// Service through secret-tool(1), and the Windows Credential Manager
+// through PowerShell.
+package secrets
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// service is the name every cburn secret is filed under.
+const service = "cburn"
+
+var (
+	// ErrNotFound indicates no secret is stored under the name.
+	ErrNotFound = errors.New("secret not found in keychain")
+	// ErrUnsupported indicates no usable credential store on this system.
+	ErrUnsupported = errors.New("no supported keychain on this system")
+)
+
+// runCmd runs a command with stdin and returns its stdout. Replaced in tests.
+var runCmd = func(stdin, name string, args ...string) ([]byte, error) {
+	cmd := exec.Command(name, args...) //nolint:gosec // fixed binaries; secrets go through stdin
+	cmd.Stdin = strings.NewReader(stdin)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil && stderr.Len() > 0 {
+		err = fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return out, err
+}
+
+// lookPath reports whether a binary is on PATH. Replaced in tests.
+var lookPath = func(name string) bool {
+	_, err := exec.LookPath(name)
+	return err == nil
+}
+
+// Available returns nil when this system has a credential store cburn can
+// use, else ErrUnsupported.
+func Available() error {
+	if !lookPath(tool()) {
+		return ErrUnsupported
+	}
+	return nil
+}
+
+// tool is the binary that talks to this system's credential store.
+func tool() string {
+	switch runtime.GOOS {
+	case "darwin":
+		return "security"
+	case "windows":
+		return "powershell"
+	default:
+		return "secret-tool"
+	}
+}
+
+// Get returns the secret stored under name.
+func Get(name string) (string, error) {
+	if err := Available(); err != nil {
+		return "", err
+	}
+	var out []byte
+	var err error
+	switch runtime.GOOS {
+	case "darwin":
+		out, err = runCmd("", "security", "find-generic-password", "-s", service, "-a", name, "-w")
+		var exit *exec.ExitError
+		if errors.As(err, &exit) && exit.ExitCode() == 44 {
+			return "", ErrNotFound
+		}
+	case "windows":
+		out, err = runCmd("", "powershell", "-NoProfile", "-NonInteractive", "-Command", vaultScript(name,
+			`try { $c = $v.Retrieve($s, $n) } catch { exit 2 }; $c.RetrievePassword(); [Console]::Out.Write($c.Password)`))
+		var exit *exec.ExitError
+		if errors.As(err, &exit) && exit.ExitCode() == 2 {
+			return "", ErrNotFound
+		}
+	default:
+		// secret-tool exits 1 with no output both for a missing item and
+		// for some failures; treat empty output as missing.
+		out, err = runCmd("", "secret-tool", "lookup", "service", service, "account", name)
+		if err != nil && len(out) == 0 {
+			var exit *exec.ExitError
+			if errors.As(err, &exit) && exit.ExitCode() == 1 {
+				return "", ErrNotFound
+			}
+		}
+	}
+	if err != nil {
+		return "", fmt.Errorf("reading %s from keychain: %w", name, err)
+	}
+	value := strings.TrimRight(string(out), "\r\n")
+	if value == "" {
+		return "", ErrNotFound
+	}
+	return value, nil
+}
+
+// Set stores value under name, replacing any previous value. The value is
+// passed on stdin so it never shows up in a process listing.
+func Set(name, value string) error {
+	if err := Available(); err != nil {
+		return err
+	}
+	if strings.ContainsAny(value, "\"\r\n") {
+		return fmt.Errorf("storing %s in keychain: value contains quotes or line breaks", name)
+	}
+	var err error
+	switch runtime.GOOS {
+	case "darwin":
+		_, err = runCmd(fmt.Sprintf("add-generic-password -U -s %s -a %s -w \"%s\"\n", service, name, value),
+			"security", "-i")
+	case "windows":
+		_, err = runCmd(value, "powershell", "-NoProfile", "-NonInteractive", "-Command", vaultScript(name,
+			`$p = [Console]::In.ReadToEnd(); try { $v.Remove($v.Retrieve($s, $n)) } catch {}; `+
+				`$v.Add((New-Object Windows.Security.Credentials.PasswordCredential($s, $n, $p)))`))
+	default:
+		_, err = runCmd(value, "secret-tool", "store", "--label", service+" "+name, "service", service, "account", name)
+	}
+	if err != nil {
+		return fmt.Errorf("storing %s in keychain: %w", name, err)
+	}
+	return nil
+}
+
+// Delete removes the secret stored under name. A missing secret is not an
+// error.
+func Delete(name string) error {
+	if err := Available(); err != nil {
+		return err
+	}
+	var err error
+	switch runtime.GOOS {
+	case "darwin":
+		_, err = runCmd("", "security", "delete-generic-password", "-s", service, "-a", name)
+		var exit *exec.ExitError
+		if errors.As(err, &exit) && exit.ExitCode() == 44 {
+			err = nil
+		}
+	case "windows":
+		_, err = runCmd("", "powershell", "-NoProfile", "-NonInteractive", "-Command", vaultScript(name,
+			`try { $v.Remove($v.Retrieve($s, $n)) } catch {}`))
+	default:
+		_, err = runCmd("", "secret-tool", "clear", "service", service, "account", name)
+	}
+	if err != nil {
+		return fmt.Errorf("removing %s from keychain: %w", name, err)
+	}
+	return nil
+}
+
+// vaultScript prefixes body with a PowerShell preamble binding $v to the
+// Windows PasswordVault and $s/$n to the service and secret name. Names
+// are cburn constants, never user input.
+func vaultScript(name, body string) string {
+	return `$ErrorActionPreference = 'Stop'; ` +
+		`[void][Windows.Security.Credentials.PasswordVault, Windows.Security.Credentials, ContentType = WindowsRuntime]; ` +
+		`$v = New-Object Windows.Security.Credentials.PasswordVault; ` +
+		fmt.Sprintf(`$s = '%s'; $n = '%s'; `, service, name) + body
+}
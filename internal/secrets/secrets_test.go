@@ -0,0 +1,85 @@
+package secrets
+
+import (
+	"errors"
+	"os/exec"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// exitErr returns a real *exec.ExitError with the given code.
+func exitErr(t *testing.T, code string) error {
+	t.Helper()
+	err := exec.Command("sh", "-c", "exit "+code).Run()
+	if err == nil {
+		t.Fatal("expected exit error")
+	}
+	return err
+}
+
+func TestSecretToolBackend(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("exercises the secret-tool backend")
+	}
+	store := map[string]string{}
+	var stdinSeen []string
+	defer func(r func(string, string, ...string) ([]byte, error), l func(string) bool) {
+		runCmd, lookPath = r, l
+	}(runCmd, lookPath)
+	lookPath = func(string) bool { return true }
+	runCmd = func(stdin, name string, args ...string) ([]byte, error) {
+		if name != "secret-tool" {
+			t.Fatalf("ran %s", name)
+		}
+		for _, a := range args {
+			if strings.HasPrefix(a, "sk-") {
+				t.Errorf("secret passed as an argument: %v", args)
+			}
+		}
+		account := args[len(args)-1]
+		switch args[0] {
+		case "store":
+			stdinSeen = append(stdinSeen, stdin)
+			store[account] = stdin
+		case "lookup":
+			if v, ok := store[account]; ok {
+				return []byte(v + "\n"), nil
+			}
+			return nil, exitErr(t, "1")
+		case "clear":
+			delete(store, account)
+		}
+		return nil, nil
+	}
+
+	if _, err := Get("admin_api.api_key"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get before Set: %v, want ErrNotFound", err)
+	}
+	if err := Set("admin_api.api_key", "sk-ant-admin01-xyz"); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := Get("admin_api.api_key"); err != nil || got != "sk-ant-admin01-xyz" {
+		t.Errorf("Get = %q, %v", got, err)
+	}
+	if len(stdinSeen) != 1 || stdinSeen[0] != "sk-ant-admin01-xyz" {
+		t.Errorf("stdin = %q", stdinSeen)
+	}
+	if err := Delete("admin_api.api_key"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Get("admin_api.api_key"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get after Delete: %v, want ErrNotFound", err)
+	}
+}
+
+func TestUnavailable(t *testing.T) {
+	defer func(l func(string) bool) { lookPath = l }(lookPath)
+	lookPath = func(string) bool { return false }
+	if _, err := Get("x"); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("Get = %v, want ErrUnsupported", err)
+	}
+	if err := Set("x", "y"); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("Set = %v, want ErrUnsupported", err)
+	}
+}
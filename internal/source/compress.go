@@ -0,0 +1,78 @@
+package source
+
+import (
+	"compress/gzip"
+	"io"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Session file extensions. Archived sessions may be compressed; they are
+// decompressed transparently while parsing.
+const (
+	extJSONL = ".jsonl"
+	extGzip  = ".jsonl.gz"
+	extZstd  = ".jsonl.zst"
+)
+
+// trimSessionExt returns name without its session file extension, and false
+// if name is not a session file.
+func trimSessionExt(name string) (string, bool) {
+	for _, ext := range []string{extJSONL, extGzip, extZstd} {
+		if strings.HasSuffix(name, ext) {
+			return strings.TrimSuffix(name, ext), true
+		}
+	}
+	return "", false
+}
+
+// IsCompressed reports whether path is a compressed session archive.
+func IsCompressed(path string) bool {
+	return strings.HasSuffix(path, extGzip) || strings.HasSuffix(path, extZstd)
+}
+
+// dedupeArchives drops compressed files that share a session ID with another
+// file, preferring the plain .jsonl: a session that was archived while its
+// original still exists must only be counted once.
+func dedupeArchives(files []DiscoveredFile) []DiscoveredFile {
+	plain := make(map[string]bool)
+	for _, f := range files {
+		if !IsCompressed(f.Path) {
+			plain[f.ProjectDir+"\x00"+f.SessionID] = true
+		}
+	}
+	seen := make(map[string]bool)
+	out := files[:0]
+	for _, f := range files {
+		key := f.ProjectDir + "\x00" + f.SessionID
+		if IsCompressed(f.Path) {
+			if plain[key] || seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+		out = append(out, f)
+	}
+	return out
+}
+
+// decompress wraps r according to path's extension. The returned release
+// func frees decoder resources; it does not close r.
+func decompress(path string, r io.Reader) (io.Reader, func(), error) {
+	switch {
+	case strings.HasSuffix(path, extGzip):
+		zr, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, nil, err
+		}
+		return zr, func() { _ = zr.Close() }, nil
+	case strings.HasSuffix(path, extZstd):
+		zr, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, nil, err
+		}
+		return zr, zr.Close, nil
+	}
+	return r, func() {}, nil
+}
@@ -0,0 +1,134 @@
+package source
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Built-in discovery profiles.
+const (
+	// ProfileClaudeCode is the ~/.claude layout: projects/<encoded-path>/<session>.jsonl,
+	// with subagents under <session>/subagents/.
+	ProfileClaudeCode = "claude-code"
+	// ProfileJSONL accepts any *.jsonl (or .jsonl.gz/.jsonl.zst) file under
+	// the root, e.g. Claude Desktop exports or custom SDK logging.
+	ProfileJSONL = "jsonl"
+)
+
+// Root is one directory to scan for session files.
+type Root struct {
+	Path    string
+	Profile string // discovery profile; "" means ProfileClaudeCode
+	Project string // fixed project name for every file; "" uses the profile's naming
+
+	// Exclude lists glob patterns for files and directories to skip, tested
+	// against each entry's name and its path relative to the directory the
+	// profile walks. Profiles registered with RegisterProfile may ignore it.
+	Exclude []string
+}
+
+// Profile discovers the session files under a root.
+type Profile func(root Root) ([]DiscoveredFile, error)
+
+var profiles = map[string]Profile{
+	ProfileClaudeCode: scanClaudeCode,
+	ProfileJSONL:      scanJSONL,
+}
+
+// RegisterProfile adds or replaces a discovery profile.
+func RegisterProfile(name string, p Profile) {
+	profiles[name] = p
+}
+
+// Profiles returns the names of all registered discovery profiles.
+func Profiles() []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ScanRoots discovers session files under every root. A file reachable from
+// more than one root is returned once, from the first root that found it.
+func ScanRoots(roots []Root) ([]DiscoveredFile, error) {
+	var files []DiscoveredFile
+	seen := make(map[string]struct{})
+	for _, r := range roots {
+		name := r.Profile
+		if name == "" {
+			name = ProfileClaudeCode
+		}
+		scan, ok := profiles[name]
+		if !ok {
+			return nil, fmt.Errorf("scan root %s: unknown profile %q (have %s)", r.Path, name, strings.Join(Profiles(), ", "))
+		}
+		found, err := scan(r)
+		if err != nil {
+			return nil, fmt.Errorf("scanning %s: %w", r.Path, err)
+		}
+		for _, f := range found {
+			if _, dup := seen[f.Path]; dup {
+				continue
+			}
+			seen[f.Path] = struct{}{}
+			if r.Project != "" {
+				f.Project = r.Project
+			}
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
+
+// scanJSONL walks root for *.jsonl files in any layout. A file's project is
+// the first directory below the root, or the root's own name for files
+// directly inside it; the session ID is its path relative to the root.
+func scanJSONL(r Root) ([]DiscoveredFile, error) {
+	info, err := os.Stat(r.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, nil
+	}
+
+	files, err := walkRoot(r.Path, r.Exclude, func(path, rel string, d fs.DirEntry) (DiscoveredFile, bool) {
+		if _, ok := trimSessionExt(d.Name()); !ok {
+			return DiscoveredFile{}, false
+		}
+
+		parts := strings.Split(rel, string(filepath.Separator))
+		projectDir := filepath.Base(r.Path)
+		if len(parts) > 1 {
+			projectDir = parts[0]
+		}
+
+		df := DiscoveredFile{
+			Path:       path,
+			Project:    projectDir,
+			ProjectDir: projectDir,
+			SessionID:  sessionPath(filepath.ToSlash(rel)),
+		}
+		if info, err := d.Info(); err == nil {
+			df.Size = info.Size()
+			df.ModTime = info.ModTime()
+		}
+		return df, true
+	})
+	return dedupeArchives(files), err
+}
+
+// sessionPath strips the session extension from a relative path.
+func sessionPath(rel string) string {
+	base, _ := trimSessionExt(rel)
+	return base
+}
@@ -0,0 +1,156 @@
+package source
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+func touch(t *testing.T, path string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("{}\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestScanRoots(t *testing.T) {
+	claude := t.TempDir()
+	touch(t, filepath.Join(claude, "projects", "-home-me-projects-app", "s1.jsonl"))
+
+	exports := filepath.Join(t.TempDir(), "exports")
+	touch(t, filepath.Join(exports, "loose.jsonl"))
+	touch(t, filepath.Join(exports, "notes", "day1", "chat.jsonl"))
+	touch(t, filepath.Join(exports, "notes", "readme.txt"))
+
+	files, err := ScanRoots([]Root{
+		{Path: claude},
+		{Path: exports, Profile: ProfileJSONL},
+		{Path: claude, Project: "dup"}, // already seen; must not be returned twice
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(map[string]DiscoveredFile)
+	for _, f := range files {
+		got[f.SessionID] = f
+	}
+	if len(files) != 3 {
+		t.Fatalf("got %d files, want 3: %+v", len(files), files)
+	}
+	if f := got["s1"]; f.Project != "app" {
+		t.Errorf("claude-code session project = %q, want app", f.Project)
+	}
+	if f := got["loose"]; f.Project != "exports" {
+		t.Errorf("top-level export project = %q, want exports", f.Project)
+	}
+	if f := got["notes/day1/chat"]; f.Project != "notes" {
+		t.Errorf("nested export project = %q, want notes", f.Project)
+	}
+
+	files, err = ScanRoots([]Root{{Path: exports, Profile: ProfileJSONL, Project: "desktop"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range files {
+		if f.Project != "desktop" {
+			t.Errorf("%s project = %q, want fixed desktop", f.Path, f.Project)
+		}
+	}
+
+	if _, err := ScanRoots([]Root{{Path: exports, Profile: "nope"}}); err == nil {
+		t.Error("unknown profile should fail")
+	}
+}
+
+func TestScanRoots_Exclude(t *testing.T) {
+	claude := t.TempDir()
+	projects := filepath.Join(claude, "projects")
+	for _, dir := range []string{"-home-me-projects-a", "-home-me-projects-b", "-mnt-share-huge"} {
+		touch(t, filepath.Join(projects, dir, "s-"+dir[len(dir)-1:]+".jsonl"))
+	}
+	touch(t, filepath.Join(projects, "-home-me-projects-a", "s-a", "subagents", "agent-1.jsonl"))
+	touch(t, filepath.Join(projects, "-home-me-projects-b", "scratch", "x.jsonl"))
+
+	exports := t.TempDir()
+	touch(t, filepath.Join(exports, "keep", "c.jsonl"))
+	touch(t, filepath.Join(exports, "node_modules", "pkg", "d.jsonl"))
+
+	exclude := []string{"-mnt-*", "*/scratch", "node_modules"}
+	files, err := ScanRoots([]Root{
+		{Path: claude, Exclude: exclude},
+		{Path: exports, Profile: ProfileJSONL, Exclude: exclude},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, f := range files {
+		ids = append(ids, f.SessionID)
+	}
+	want := []string{"s-a/agent-1", "s-a", "s-b", "keep/c"}
+	if !slices.Equal(ids, want) {
+		t.Errorf("sessions = %v, want %v (in walk order)", ids, want)
+	}
+}
+
+func TestScanDirAndParseCompressed(t *testing.T) {
+	const line = `{"type":"assistant","timestamp":"2025-06-01T10:00:00Z","message":{"id":"m1","model":"claude-sonnet-4-6","usage":{"input_tokens":5,"output_tokens":2}}}` + "\n"
+	proj := filepath.Join(t.TempDir(), "projects", "-home-me-projects-app")
+	if err := os.MkdirAll(proj, 0o750); err != nil {
+		t.Fatal(err)
+	}
+
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	_, _ = zw.Write([]byte(line))
+	_ = zw.Close()
+
+	zst, err := zstd.NewWriter(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zstData := zst.EncodeAll([]byte(line), nil)
+	_ = zst.Close()
+
+	for name, data := range map[string][]byte{
+		"old.jsonl.gz":   gz.Bytes(),
+		"old2.jsonl.zst": zstData,
+		"live.jsonl":     []byte(line),
+		"live.jsonl.gz":  gz.Bytes(), // archived copy of a live session: ignored
+	} {
+		if err := os.WriteFile(filepath.Join(proj, name), data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, err := ScanDir(filepath.Dir(filepath.Dir(proj)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := make(map[string]string)
+	for _, f := range files {
+		ids[f.SessionID] = filepath.Base(f.Path)
+	}
+	if len(files) != 3 || ids["old"] != "old.jsonl.gz" || ids["old2"] != "old2.jsonl.zst" || ids["live"] != "live.jsonl" {
+		t.Fatalf("discovered %v, want old (gz), old2 (zst), and plain live", ids)
+	}
+
+	for _, f := range files {
+		r := ParseFile(f)
+		if r.Err != nil {
+			t.Fatalf("%s: %v", f.Path, r.Err)
+		}
+		if r.Stats.APICalls != 1 || r.Stats.InputTokens != 5 {
+			t.Errorf("%s: calls=%d input=%d, want 1 and 5", f.Path, r.Stats.APICalls, r.Stats.InputTokens)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package source
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// repoCache memoizes cwd -> repo name lookups; many sessions share a cwd.
+var repoCache sync.Map
+
+// ResolveRepo returns the name of the git repository containing dir, found by
+// walking up to the nearest directory with a .git entry (directory, or file
+// for worktrees and submodules). Returns "" if dir is empty or not in a repo.
+func ResolveRepo(dir string) string {
+	if dir == "" {
+		return ""
+	}
+	if v, ok := repoCache.Load(dir); ok {
+		return v.(string)
+	}
+
+	repo := ""
+	for d := filepath.Clean(dir); ; {
+		if _, err := os.Stat(filepath.Join(d, ".git")); err == nil {
+			repo = filepath.Base(d)
+			break
+		}
+		parent := filepath.Dir(d)
+		if parent == d {
+			break
+		}
+		d = parent
+	}
+
+	repoCache.Store(dir, repo)
+	return repo
+}
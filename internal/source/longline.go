@@ -0,0 +1,186 @@
+package source
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+)
+
+// DefaultMaxLineBytes is the per-line memory bound used by ParseFile. Lines
+// longer than this (multi-megabyte tool results, pasted images) are parsed
+// from their head and tail only.
+const DefaultMaxLineBytes = 16 << 20
+
+// readBufSize is the bufio.Reader size; lines longer than this are assembled
+// from several reads.
+const readBufSize = 64 * 1024
+
+// lineReader reads newline-delimited lines of any length. With a non-zero
+// max, a line longer than max keeps only its first max/2 bytes and its last
+// max/2 bytes, so memory stays bounded no matter how long the line is.
+type lineReader struct {
+	br   *bufio.Reader
+	max  int
+	line []byte
+	tail tailRing
+	over bool // current line exceeded max
+}
+
+func newLineReader(r io.Reader, maxLine int) *lineReader {
+	return &lineReader{br: bufio.NewReaderSize(r, readBufSize), max: maxLine}
+}
+
+// next returns the next line without its line ending. The slice is only
+// valid until the following call. truncated reports that the middle of the
+// line was dropped. The final line need not end in a newline; io.EOF is
+// returned once no data remains.
+func (lr *lineReader) next() (line []byte, truncated bool, err error) {
+	lr.line = lr.line[:0]
+	lr.over = false
+	lr.tail.reset()
+
+	read := 0
+	for {
+		chunk, err := lr.br.ReadSlice('\n')
+		read += len(chunk)
+		lr.add(chunk)
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		if err == io.EOF {
+			if read == 0 {
+				return nil, false, io.EOF
+			}
+			break
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		break
+	}
+
+	if lr.over {
+		lr.line = lr.tail.appendTo(lr.line)
+	}
+	lr.line = bytes.TrimSuffix(lr.line, []byte("\n"))
+	lr.line = bytes.TrimSuffix(lr.line, []byte("\r"))
+	return lr.line, lr.over, nil
+}
+
+func (lr *lineReader) add(p []byte) {
+	if !lr.over {
+		if lr.max <= 0 || len(lr.line)+len(p) <= lr.max {
+			lr.line = append(lr.line, p...)
+			return
+		}
+		// Too long: keep the head and roll everything after it through the tail.
+		lr.over = true
+		half := lr.max / 2
+		lr.tail.init(lr.max - half)
+		if len(lr.line) < half {
+			n := half - len(lr.line)
+			lr.line = append(lr.line, p[:n]...)
+			p = p[n:]
+		}
+		lr.tail.write(lr.line[half:])
+		lr.line = lr.line[:half]
+	}
+	lr.tail.write(p)
+}
+
+// tailRing keeps the last len(buf) bytes written to it.
+type tailRing struct {
+	buf  []byte
+	pos  int
+	full bool
+}
+
+func (r *tailRing) init(size int) {
+	if cap(r.buf) < size {
+		r.buf = make([]byte, size)
+	}
+	r.buf = r.buf[:size]
+	r.reset()
+}
+
+func (r *tailRing) reset() {
+	r.pos = 0
+	r.full = false
+}
+
+func (r *tailRing) write(p []byte) {
+	n := len(r.buf)
+	if n == 0 {
+		return
+	}
+	if len(p) >= n {
+		copy(r.buf, p[len(p)-n:])
+		r.pos = 0
+		r.full = true
+		return
+	}
+	k := copy(r.buf[r.pos:], p)
+	copy(r.buf, p[k:])
+	if r.pos+len(p) >= n {
+		r.full = true
+	}
+	r.pos = (r.pos + len(p)) % n
+}
+
+// appendTo appends the ring's contents, oldest first, to dst.
+func (r *tailRing) appendTo(dst []byte) []byte {
+	if !r.full {
+		return append(dst, r.buf[:r.pos]...)
+	}
+	dst = append(dst, r.buf[r.pos:]...)
+	return append(dst, r.buf[:r.pos]...)
+}
+
+var (
+	patUsage = []byte(`"usage":`)
+	patID    = []byte(`"id":"`)
+	patModel = []byte(`"model":"`)
+)
+
+// truncatedEntryType finds the entry type of a line whose middle was dropped.
+// The top-level "type" key usually follows the (large) message body, so
+// brace tracking can't be trusted; the last type pattern naming a routed
+// entry kind wins instead.
+func truncatedEntryType(line []byte) string {
+	best, bestIdx := "", -1
+	for _, typ := range []string{"assistant", "user", "system"} {
+		for _, pat := range []string{`"type":"` + typ + `"`, `"type": "` + typ + `"`} {
+			if idx := bytes.LastIndex(line, []byte(pat)); idx > bestIdx {
+				best, bestIdx = typ, idx
+			}
+		}
+	}
+	return best
+}
+
+// salvageAssistant rebuilds the fields ParseFile needs from a truncated
+// assistant line. The message ID and model precede the content in the head;
+// usage and the timestamp follow it in the tail.
+func salvageAssistant(line []byte) (RawEntry, bool) {
+	idx := bytes.LastIndex(line, patUsage)
+	if idx < 0 {
+		return RawEntry{}, false
+	}
+	var usage RawUsage
+	if err := json.NewDecoder(bytes.NewReader(line[idx+len(patUsage):])).Decode(&usage); err != nil {
+		return RawEntry{}, false
+	}
+	msg := &RawMessage{
+		ID:    extractStringBytes(line, 256, patID),
+		Model: extractStringBytes(line, 256, patModel),
+		Usage: &usage,
+	}
+	return RawEntry{
+		Type:      "assistant",
+		Timestamp: extractStringBytes(line, 40, patTimestamp1, patTimestamp2),
+		Cwd:       extractCwdBytes(line),
+		GitBranch: extractGitBranchBytes(line),
+		Message:   msg,
+	}, true
+}
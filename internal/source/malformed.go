@@ -0,0 +1,50 @@
+package source
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"slices"
+)
+
+// BadLine is a line ParseFile counted as a parse error.
+type BadLine struct {
+	Num  int // 1-based
+	Line []byte
+}
+
+// MalformedLines returns up to limit lines of path that ParseFile skips as
+// parse errors: assistant entries that are not valid JSON. Lines are read
+// whole, unlike ParseFile, so samples show the full damage.
+func MalformedLines(path string, limit int) ([]BadLine, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	r, release, err := decompress(path, f)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	var bad []BadLine
+	lr := newLineReader(r, 0)
+	for n := 1; len(bad) < limit; n++ {
+		line, _, err := lr.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return bad, err
+		}
+		if extractTopLevelType(line) != "assistant" {
+			continue
+		}
+		var entry RawEntry
+		if json.Unmarshal(line, &entry) != nil {
+			bad = append(bad, BadLine{Num: n, Line: slices.Clone(line)})
+		}
+	}
+	return bad, nil
+}
@@ -2,10 +2,12 @@
 package source
 
 import (
-	"bufio"
 	"bytes"
 	"encoding/json"
+	"io"
 	"os"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/theirongolddev/cburn/internal/config"
@@ -20,13 +22,38 @@ var (
 	patTimestamp2   = []byte(`"timestamp": "`)
 	patCwd1         = []byte(`"cwd":"`)
 	patCwd2         = []byte(`"cwd": "`)
+	patGitBranch1   = []byte(`"gitBranch":"`)
+	patGitBranch2   = []byte(`"gitBranch": "`)
+	patEntrypoint1  = []byte(`"entrypoint":"`)
+	patEntrypoint2  = []byte(`"entrypoint": "`)
+
+	// Claude Code marks each compaction with a system entry of this subtype.
+	patCompactBoundary = []byte(`"compact_boundary"`)
 )
 
 // ParseResult holds the output of parsing a single JSONL file.
 type ParseResult struct {
 	Stats       model.SessionStats
 	ParseErrors int
-	Err         error
+	// SkewedTimestamps counts timestamps ignored by saneTimestamp.
+	SkewedTimestamps int
+	Err              error
+}
+
+// Timestamps outside [minSaneTime, file mtime + maxClockSkew] are treated as
+// missing: a corrupted or badly skewed clock would otherwise put a session
+// in the far past or future and distort daily charts.
+var minSaneTime = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+const maxClockSkew = 24 * time.Hour
+
+// saneTimestamp reports whether ts is plausible for a file last written at
+// mtime (now when unknown).
+func saneTimestamp(ts, mtime time.Time) bool {
+	if mtime.IsZero() {
+		mtime = time.Now()
+	}
+	return !ts.Before(minSaneTime) && !ts.After(mtime.Add(maxClockSkew))
 }
 
 // ParseFile reads a JSONL session file and produces deduplicated session statistics.
@@ -34,34 +61,91 @@ type ParseResult struct {
 //
 // Entry routing by top-level "type" field:
 //   - "user"      → byte-level extraction (timestamp, cwd, count)
-//   - "system"    → byte-level extraction (timestamp, cwd, durationMs)
+//   - "system"    → byte-level extraction (timestamp, cwd, durationMs, compactions)
 //   - "assistant" → full JSON parse (token usage, model, costs)
 //   - everything else → skip
+//
+// Lines of any length are accepted; see ParseOptions.MaxLineBytes for how
+// very long lines are bounded.
 func ParseFile(df DiscoveredFile) ParseResult {
+	return ParseFileWithOptions(df, ParseOptions{MaxLineBytes: DefaultMaxLineBytes})
+}
+
+// ParseFileWithProgress is ParseFile with byte-level progress reporting.
+// onRead receives the number of bytes consumed since the previous call,
+// batched so that very large files report steadily without flooding the caller.
+func ParseFileWithProgress(df DiscoveredFile, onRead func(n int64)) ParseResult {
+	return ParseFileWithOptions(df, ParseOptions{OnRead: onRead, MaxLineBytes: DefaultMaxLineBytes})
+}
+
+// ParseOptions tunes ParseFileWithOptions.
+type ParseOptions struct {
+	// OnRead, if set, receives byte progress as in ParseFileWithProgress.
+	OnRead func(n int64)
+
+	// MaxLineBytes bounds the memory held for a single line. A longer line
+	// keeps only its first and last MaxLineBytes/2 bytes, which is where an
+	// entry's type, IDs, usage, and timestamp live; the message content in
+	// between is dropped unparsed. 0 buffers every line whole.
+	MaxLineBytes int
+}
+
+// ParseFileWithOptions is ParseFile with explicit options.
+func ParseFileWithOptions(df DiscoveredFile, opts ParseOptions) ParseResult {
 	f, err := os.Open(df.Path)
 	if err != nil {
 		return ParseResult{Err: err}
 	}
 	defer func() { _ = f.Close() }()
 
+	var r io.Reader = f
+	if onRead := opts.OnRead; onRead != nil {
+		cr := &countingReader{r: f, onRead: onRead}
+		defer cr.flush()
+		r = cr
+	}
+	// Progress counts bytes on disk, so decompress after counting.
+	r, release, err := decompress(df.Path, r)
+	if err != nil {
+		return ParseResult{Err: err}
+	}
+	defer release()
+
 	calls := make(map[string]*model.APICall)
 
 	var (
 		userMessages  int
+		compactions   int
 		parseErrors   int
+		skewed        int
 		totalDuration int64
 		minTime       time.Time
 		maxTime       time.Time
 		cwd           string
+		gitBranch     string // last non-empty branch seen (branches can change mid-session)
+		entrypoint    string
+		firstMsgID    string
+		lastModel     string // model of the latest assistant entry, which a turn duration belongs to
+		turns         []turnDuration
+		times         []time.Time // every sane entry timestamp, for idle gaps
 	)
 
-	scanner := bufio.NewScanner(f)
-	scanner.Buffer(make([]byte, 0, 256*1024), 2*1024*1024)
-
-	for scanner.Scan() {
-		line := scanner.Bytes()
+	lr := newLineReader(r, opts.MaxLineBytes)
+	for {
+		line, truncated, err := lr.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return ParseResult{Err: err}
+		}
 
-		entryType := extractTopLevelType(line)
+		var entryType string
+		if truncated {
+			entryType = truncatedEntryType(line)
+		} else {
+			entryType = extractTopLevelType(line)
+		}
 		if entryType == "" {
 			continue
 		}
@@ -70,49 +154,102 @@ func ParseFile(df DiscoveredFile) ParseResult {
 		case "user":
 			userMessages++
 			if ts, ok := extractTimestampBytes(line); ok {
-				updateTimeRange(&minTime, &maxTime, ts)
+				if saneTimestamp(ts, df.ModTime) {
+					updateTimeRange(&minTime, &maxTime, ts)
+					times = append(times, ts)
+				} else {
+					skewed++
+				}
 			}
 			if cwd == "" {
 				if c := extractCwdBytes(line); c != "" {
 					cwd = c
 				}
 			}
+			if b := extractGitBranchBytes(line); b != "" {
+				gitBranch = b
+			}
+			if entrypoint == "" {
+				entrypoint = extractEntrypointBytes(line)
+			}
 
 		case "system":
 			if ts, ok := extractTimestampBytes(line); ok {
-				updateTimeRange(&minTime, &maxTime, ts)
+				if saneTimestamp(ts, df.ModTime) {
+					updateTimeRange(&minTime, &maxTime, ts)
+					times = append(times, ts)
+				} else {
+					skewed++
+				}
 			}
 			if cwd == "" {
 				if c := extractCwdBytes(line); c != "" {
 					cwd = c
 				}
 			}
+			if b := extractGitBranchBytes(line); b != "" {
+				gitBranch = b
+			}
+			if entrypoint == "" {
+				entrypoint = extractEntrypointBytes(line)
+			}
 			if bytes.Contains(line, patTurnDuration) {
 				if ms, ok := extractDurationMs(line); ok {
 					totalDuration += ms
+					if lastModel != "" {
+						turns = append(turns, turnDuration{lastModel, ms})
+					}
 				}
 			}
+			if bytes.Contains(line, patCompactBoundary) {
+				compactions++
+			}
 
 		case "assistant":
 			var entry RawEntry
-			if err := json.Unmarshal(line, &entry); err != nil {
+			if truncated {
+				var ok bool
+				if entry, ok = salvageAssistant(line); !ok {
+					parseErrors++
+					continue
+				}
+			} else if err := json.Unmarshal(line, &entry); err != nil {
 				parseErrors++
 				continue
 			}
 
+			var ts time.Time
 			if entry.Timestamp != "" {
-				ts, err := time.Parse(time.RFC3339Nano, entry.Timestamp)
-				if err == nil {
-					updateTimeRange(&minTime, &maxTime, ts)
+				if t, err := time.Parse(time.RFC3339Nano, entry.Timestamp); err == nil {
+					if saneTimestamp(t, df.ModTime) {
+						ts = t
+						updateTimeRange(&minTime, &maxTime, ts)
+						times = append(times, ts)
+					} else {
+						skewed++
+					}
 				}
 			}
 			if cwd == "" && entry.Cwd != "" {
 				cwd = entry.Cwd
 			}
+			if entry.GitBranch != "" {
+				gitBranch = entry.GitBranch
+			}
+			if entry.Message != nil && entry.Message.Model != "" {
+				lastModel = entry.Message.Model
+			}
+			var ms int64
 			if entry.DurationMs > 0 {
-				totalDuration += entry.DurationMs
+				ms = entry.DurationMs
 			} else if entry.Data != nil && entry.Data.DurationMs > 0 {
-				totalDuration += entry.Data.DurationMs
+				ms = entry.Data.DurationMs
+			}
+			if ms > 0 {
+				totalDuration += ms
+				if lastModel != "" {
+					turns = append(turns, turnDuration{lastModel, ms})
+				}
 			}
 
 			if entry.Message == nil || entry.Message.ID == "" {
@@ -123,6 +260,9 @@ func ParseFile(df DiscoveredFile) ParseResult {
 				continue
 			}
 
+			if firstMsgID == "" {
+				firstMsgID = msg.ID
+			}
 			u := msg.Usage
 			var cache5m, cache1h int64
 			if u.CacheCreation != nil {
@@ -132,8 +272,6 @@ func ParseFile(df DiscoveredFile) ParseResult {
 				cache5m = u.CacheCreationInputTokens
 			}
 
-			ts, _ := time.Parse(time.RFC3339Nano, entry.Timestamp)
-
 			calls[msg.ID] = &model.APICall{
 				MessageID:             msg.ID,
 				Model:                 msg.Model,
@@ -148,24 +286,30 @@ func ParseFile(df DiscoveredFile) ParseResult {
 		}
 	}
 
-	if err := scanner.Err(); err != nil {
-		return ParseResult{Err: err}
-	}
-
 	stats := model.SessionStats{
 		SessionID:     df.SessionID,
 		Project:       df.Project,
 		ProjectPath:   cwd,
+		Repo:          ResolveRepo(cwd),
+		GitBranch:     gitBranch,
+		Entrypoint:    entrypoint,
 		FilePath:      df.Path,
+		FileModTime:   df.ModTime,
 		IsSubagent:    df.IsSubagent,
 		ParentSession: df.ParentSession,
 		StartTime:     minTime,
 		EndTime:       maxTime,
 		UserMessages:  userMessages,
-		APICalls:      len(calls),
-		Models:        make(map[string]*model.ModelUsage),
+		Compactions:   compactions,
+
+		FirstMessageID: firstMsgID,
+		APICalls:       len(calls),
+		Models:         make(map[string]*model.ModelUsage),
+		Tiers:          make(map[string]*model.TierUsage),
 	}
 
+	stats.ClaudeSecs = totalDuration / 1000
+	stats.IdleGaps = idleGaps(times)
 	if totalDuration > 0 {
 		stats.DurationSecs = totalDuration / 1000
 	} else if !minTime.IsZero() && !maxTime.IsZero() {
@@ -173,9 +317,13 @@ func ParseFile(df DiscoveredFile) ParseResult {
 	}
 
 	for _, call := range calls {
+		if call.Timestamp.IsZero() {
+			call.Timestamp = maxTime // priced as of the session's last sane timestamp
+		}
 		call.EstimatedCost = config.CalculateCostAt(
 			call.Model,
 			call.Timestamp,
+			call.ServiceTier,
 			call.InputTokens,
 			call.OutputTokens,
 			call.CacheCreation5mTokens,
@@ -190,6 +338,15 @@ func ParseFile(df DiscoveredFile) ParseResult {
 		stats.CacheReadTokens += call.CacheReadTokens
 		stats.EstimatedCost += call.EstimatedCost
 
+		tier := config.NormalizeTier(call.ServiceTier)
+		tu, ok := stats.Tiers[tier]
+		if !ok {
+			tu = &model.TierUsage{}
+			stats.Tiers[tier] = tu
+		}
+		tu.APICalls++
+		tu.EstimatedCost += call.EstimatedCost
+
 		normalized := config.NormalizeModelName(call.Model)
 		mu, ok := stats.Models[normalized]
 		if !ok {
@@ -203,8 +360,21 @@ func ParseFile(df DiscoveredFile) ParseResult {
 		mu.CacheCreation1hTokens += call.CacheCreation1hTokens
 		mu.CacheReadTokens += call.CacheReadTokens
 		mu.EstimatedCost += call.EstimatedCost
+		if call.Model != "" && !slices.Contains(mu.RawNames, call.Model) {
+			mu.RawNames = append(mu.RawNames, call.Model)
+		}
+	}
+
+	for _, td := range turns {
+		if mu, ok := stats.Models[config.NormalizeModelName(td.model)]; ok {
+			mu.TurnDurationsMs = append(mu.TurnDurationsMs, td.ms)
+		}
 	}
 
+	ordered := orderCalls(calls)
+	stats.ContextCurve, stats.PeakContext = contextCurve(ordered)
+	stats.RepeatedCalls = repeatedCalls(ordered)
+
 	totalCacheInput := stats.CacheReadTokens + stats.CacheCreation5mTokens +
 		stats.CacheCreation1hTokens + stats.InputTokens
 	if totalCacheInput > 0 {
@@ -212,8 +382,109 @@ func ParseFile(df DiscoveredFile) ParseResult {
 	}
 
 	return ParseResult{
-		Stats:       stats,
-		ParseErrors: parseErrors,
+		Stats:            stats,
+		ParseErrors:      parseErrors,
+		SkewedTimestamps: skewed,
+	}
+}
+
+// turnDuration is one turn's wall time and the model that answered it.
+type turnDuration struct {
+	model string
+	ms    int64
+}
+
+// orderCalls returns calls in the order they were made.
+func orderCalls(calls map[string]*model.APICall) []*model.APICall {
+	ordered := make([]*model.APICall, 0, len(calls))
+	for _, c := range calls {
+		ordered = append(ordered, c)
+	}
+	slices.SortFunc(ordered, func(a, b *model.APICall) int {
+		if c := a.Timestamp.Compare(b.Timestamp); c != 0 {
+			return c
+		}
+		return strings.Compare(a.MessageID, b.MessageID)
+	})
+	return ordered
+}
+
+// contextCurve returns each call's prompt size in call order, reduced to
+// model.ContextCurvePoints spans, and the largest prompt.
+func contextCurve(ordered []*model.APICall) ([]int64, int64) {
+	if len(ordered) == 0 {
+		return nil, 0
+	}
+	sizes := make([]int64, len(ordered))
+	var peak int64
+	for i, c := range ordered {
+		sizes[i] = c.InputTokens + c.CacheReadTokens + c.CacheCreation5mTokens + c.CacheCreation1hTokens
+		peak = max(peak, sizes[i])
+	}
+	return downsamplePeaks(sizes, model.ContextCurvePoints), peak
+}
+
+// repeatWithin is the longest pause between two calls that still continues
+// a run of identical calls.
+const repeatWithin = time.Minute
+
+// repeatedCalls returns the length of the longest run of consecutive calls
+// with the same new input and output token counts, each within repeatWithin
+// of the last: an agent retrying the same step. Below two it returns 0.
+func repeatedCalls(ordered []*model.APICall) int {
+	longest, run := 0, 1
+	for i := 1; i < len(ordered); i++ {
+		prev, c := ordered[i-1], ordered[i]
+		if c.InputTokens == prev.InputTokens && c.OutputTokens == prev.OutputTokens &&
+			c.Timestamp.Sub(prev.Timestamp) <= repeatWithin {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 1
+		}
+	}
+	return longest
+}
+
+// downsamplePeaks splits values into n near-equal spans and keeps the
+// largest value of each, so short spikes survive the reduction.
+func downsamplePeaks(values []int64, n int) []int64 {
+	if len(values) <= n {
+		return values
+	}
+	out := make([]int64, n)
+	for i := range n {
+		span := values[i*len(values)/n : (i+1)*len(values)/n]
+		out[i] = slices.Max(span)
+	}
+	return out
+}
+
+// progressChunk is the minimum number of bytes accumulated before a
+// countingReader reports to its callback.
+const progressChunk = 1 << 20
+
+// countingReader wraps a reader and reports bytes read in progressChunk batches.
+type countingReader struct {
+	r       io.Reader
+	onRead  func(n int64)
+	pending int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.pending += int64(n)
+	if c.pending >= progressChunk {
+		c.flush()
+	}
+	return n, err
+}
+
+// flush reports any bytes not yet passed to the callback.
+func (c *countingReader) flush() {
+	if c.pending > 0 {
+		c.onRead(c.pending)
+		c.pending = 0
 	}
 }
 
@@ -323,14 +594,30 @@ func extractTimestampBytes(line []byte) (time.Time, bool) {
 
 // extractCwdBytes extracts the cwd field via byte scanning.
 func extractCwdBytes(line []byte) string {
-	for _, pat := range [][]byte{patCwd1, patCwd2} {
+	return extractStringBytes(line, 1024, patCwd1, patCwd2)
+}
+
+// extractGitBranchBytes extracts the gitBranch field via byte scanning.
+func extractGitBranchBytes(line []byte) string {
+	return extractStringBytes(line, 256, patGitBranch1, patGitBranch2)
+}
+
+// extractEntrypointBytes extracts the entrypoint field via byte scanning.
+func extractEntrypointBytes(line []byte) string {
+	return extractStringBytes(line, 64, patEntrypoint1, patEntrypoint2)
+}
+
+// extractStringBytes returns the string value following the first matching
+// key pattern, or "" if none matches or the value exceeds maxLen bytes.
+func extractStringBytes(line []byte, maxLen int, pats ...[]byte) string {
+	for _, pat := range pats {
 		idx := bytes.Index(line, pat)
 		if idx < 0 {
 			continue
 		}
 		start := idx + len(pat)
 		end := bytes.IndexByte(line[start:], '"')
-		if end < 0 || end > 1024 {
+		if end < 0 || end > maxLen {
 			continue
 		}
 		return string(line[start : start+end])
@@ -362,6 +649,19 @@ func extractDurationMs(line []byte) (int64, bool) {
 	return n, true
 }
 
+// idleGaps returns the pauses of at least model.MinIdleGapSecs between
+// consecutive timestamps, in seconds.
+func idleGaps(times []time.Time) []int64 {
+	slices.SortFunc(times, func(a, b time.Time) int { return a.Compare(b) })
+	var gaps []int64
+	for i := 1; i < len(times); i++ {
+		if gap := int64(times[i].Sub(times[i-1]).Seconds()); gap >= model.MinIdleGapSecs {
+			gaps = append(gaps, gap)
+		}
+	}
+	return gaps
+}
+
 func updateTimeRange(minTime, maxTime *time.Time, ts time.Time) {
 	if minTime.IsZero() || ts.Before(*minTime) {
 		*minTime = ts
@@ -0,0 +1,122 @@
+package source
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// BenchmarkParseFile_LongLines parses a session dominated by 8MB assistant
+// lines, comparing whole-line buffering with the bounded default. Allocations
+// per op show the memory bound at work.
+func BenchmarkParseFile_LongLines(b *testing.B) {
+	lines := make([]string, 0, 8)
+	for i := 0; i < 8; i++ {
+		lines = append(lines, longAssistantLine("m"+strings.Repeat("0", i), 8<<20))
+	}
+	path := filepath.Join(b.TempDir(), "big.jsonl")
+	data := strings.Join(lines, "\n") + "\n"
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		b.Fatal(err)
+	}
+	df := DiscoveredFile{Path: path, SessionID: "big", Project: "bench"}
+
+	for _, bc := range []struct {
+		name    string
+		maxLine int
+	}{
+		{"unbounded", 0},
+		{"bounded-1MB", 1 << 20},
+		{"bounded-default", DefaultMaxLineBytes},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(data)))
+			for i := 0; i < b.N; i++ {
+				if r := ParseFileWithOptions(df, ParseOptions{MaxLineBytes: bc.maxLine}); r.Err != nil || r.Stats.APICalls != 8 {
+					b.Fatalf("err=%v calls=%d", r.Err, r.Stats.APICalls)
+				}
+			}
+		})
+	}
+}
+
+// benchSessionLines builds a session of n lines in roughly the mix Claude
+// Code writes: mostly progress entries, then assistant and user turns, with
+// the odd system line. Assistant content is padded to about 2KB per line.
+func benchSessionLines(n int) []string {
+	start := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+	pad := strings.Repeat("lorem ipsum ", 170)
+	lines := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		ts := start.Add(time.Duration(i) * time.Second).Format(time.RFC3339)
+		switch i % 10 {
+		case 0, 5:
+			lines = append(lines, `{"type":"user","timestamp":"`+ts+`","cwd":"/home/me/app","gitBranch":"main","message":{"role":"user","content":"fix the failing test"}}`)
+		case 1, 4, 7:
+			lines = append(lines, fmt.Sprintf(`{"type":"assistant","timestamp":"%s","message":{"id":"msg_%d","model":"claude-sonnet-4-6","type":"message","content":[{"type":"text","text":"%s"}],"usage":{"input_tokens":12,"output_tokens":340,"cache_creation_input_tokens":800,"cache_read_input_tokens":24000}}}`, ts, i, pad))
+		case 9:
+			lines = append(lines, `{"type":"system","timestamp":"`+ts+`","subtype":"turn_duration","durationMs":4200}`)
+		default:
+			lines = append(lines, `{"type":"progress","timestamp":"`+ts+`","data":{"type":"hook_progress","hookName":"PostToolUse"}}`)
+		}
+	}
+	return lines
+}
+
+// BenchmarkParseFile_Sizes parses synthetic sessions from a quick one-off
+// question up to a day-long agent run.
+func BenchmarkParseFile_Sizes(b *testing.B) {
+	for _, bc := range []struct {
+		name  string
+		lines int
+	}{
+		{"small", 40},
+		{"medium", 2_000},
+		{"huge", 50_000},
+	} {
+		data := strings.Join(benchSessionLines(bc.lines), "\n") + "\n"
+		path := filepath.Join(b.TempDir(), bc.name+".jsonl")
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			b.Fatal(err)
+		}
+		df := DiscoveredFile{Path: path, SessionID: bc.name, Project: "bench"}
+
+		b.Run(bc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(data)))
+			for i := 0; i < b.N; i++ {
+				if r := ParseFile(df); r.Err != nil || r.Stats.APICalls == 0 {
+					b.Fatalf("err=%v calls=%d", r.Err, r.Stats.APICalls)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkExtractTopLevelType covers the type sniffing every line goes
+// through, including a line whose top-level type follows a large nested body.
+func BenchmarkExtractTopLevelType(b *testing.B) {
+	for _, bc := range []struct {
+		name string
+		line string
+		want string
+	}{
+		{"progress", `{"type":"progress","timestamp":"2025-06-01T10:00:00Z","data":{"type":"hook_progress"}}`, ""},
+		{"user", `{"type":"user","timestamp":"2025-06-01T10:00:00Z","message":{"content":"hi"}}`, "user"},
+		{"type-last-64KB", longAssistantLine("m1", 64<<10), "assistant"},
+	} {
+		line := []byte(bc.line)
+		b.Run(bc.name, func(b *testing.B) {
+			b.SetBytes(int64(len(line)))
+			for i := 0; i < b.N; i++ {
+				if got := extractTopLevelType(line); got != bc.want {
+					b.Fatalf("type = %q, want %q", got, bc.want)
+				}
+			}
+		})
+	}
+}
@@ -3,6 +3,8 @@ package source
 import (
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -89,6 +91,30 @@ func TestParseFile_TimeRange(t *testing.T) {
 	}
 }
 
+func TestParseFile_SkewedTimestamps(t *testing.T) {
+	df := writeSession(t,
+		`{"type":"user","timestamp":"1970-01-01T00:00:00Z"}`,
+		`{"type":"user","timestamp":"2025-06-01T10:00:00Z"}`,
+		`{"type":"assistant","timestamp":"2099-01-01T00:00:00Z","message":{"id":"m1","model":"claude-sonnet-4-6","usage":{"input_tokens":5,"output_tokens":2}}}`,
+		`{"type":"system","timestamp":"2025-06-01T11:00:00Z"}`,
+	)
+	df.ModTime = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	result := ParseFile(df)
+	if result.SkewedTimestamps != 2 {
+		t.Errorf("SkewedTimestamps = %d, want 2", result.SkewedTimestamps)
+	}
+	wantStart := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+	wantEnd := time.Date(2025, 6, 1, 11, 0, 0, 0, time.UTC)
+	if !result.Stats.StartTime.Equal(wantStart) || !result.Stats.EndTime.Equal(wantEnd) {
+		t.Errorf("range = %v..%v, want %v..%v", result.Stats.StartTime, result.Stats.EndTime, wantStart, wantEnd)
+	}
+	// The call itself is still counted.
+	if result.Stats.APICalls != 1 {
+		t.Errorf("APICalls = %d, want 1", result.Stats.APICalls)
+	}
+}
+
 func TestParseFile_SystemDuration(t *testing.T) {
 	df := writeSession(t,
 		`{"type":"system","subtype":"turn_duration","timestamp":"2025-06-01T10:00:00Z","durationMs":5000}`,
@@ -131,6 +157,14 @@ func TestParseFile_MalformedLines(t *testing.T) {
 	if result.Stats.UserMessages != 1 {
 		t.Errorf("UserMessages = %d, want 1", result.Stats.UserMessages)
 	}
+
+	bad, err := MalformedLines(df.Path, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bad) != result.ParseErrors || len(bad) != 1 || bad[0].Num != 3 {
+		t.Errorf("MalformedLines = %+v, ParseErrors = %d; want line 3 only", bad, result.ParseErrors)
+	}
 }
 
 func TestParseFile_CacheTokens(t *testing.T) {
@@ -155,6 +189,260 @@ func TestParseFile_CacheTokens(t *testing.T) {
 	}
 }
 
+func TestParseFile_GitBranch(t *testing.T) {
+	df := writeSession(t,
+		`{"type":"user","timestamp":"2025-06-01T10:00:00Z","gitBranch":"main"}`,
+		`{"type":"assistant","timestamp":"2025-06-01T10:00:01Z","gitBranch":"feature/x","message":{"id":"msg1","model":"claude-sonnet-4-6","usage":{"input_tokens":1,"output_tokens":1}}}`,
+		`{"type":"user","timestamp":"2025-06-01T10:01:00Z"}`,
+	)
+
+	result := ParseFile(df)
+	if result.Err != nil {
+		t.Fatalf("unexpected error: %v", result.Err)
+	}
+	if result.Stats.GitBranch != "feature/x" {
+		t.Errorf("GitBranch = %q, want feature/x (last seen)", result.Stats.GitBranch)
+	}
+}
+
+func TestParseFile_Entrypoint(t *testing.T) {
+	df := writeSession(t,
+		`{"type":"user","timestamp":"2025-06-01T10:00:00Z","entrypoint":"sdk-cli"}`,
+		`{"type":"assistant","timestamp":"2025-06-01T10:00:01Z","entrypoint":"cli","message":{"id":"msg1","model":"claude-sonnet-4-6","usage":{"input_tokens":1,"output_tokens":1}}}`,
+	)
+
+	result := ParseFile(df)
+	if result.Err != nil {
+		t.Fatalf("unexpected error: %v", result.Err)
+	}
+	if result.Stats.Entrypoint != "sdk-cli" {
+		t.Errorf("Entrypoint = %q, want sdk-cli", result.Stats.Entrypoint)
+	}
+}
+
+func TestParseFile_ContextCurve(t *testing.T) {
+	// Out of order in the file; the curve follows timestamps.
+	df := writeSession(t,
+		`{"type":"assistant","timestamp":"2025-06-01T10:02:00Z","message":{"id":"m3","model":"claude-sonnet-4-6","usage":{"input_tokens":5,"cache_read_input_tokens":1000,"cache_creation_input_tokens":500,"output_tokens":10}}}`,
+		`{"type":"assistant","timestamp":"2025-06-01T10:00:00Z","message":{"id":"m1","model":"claude-sonnet-4-6","usage":{"input_tokens":10,"cache_creation_input_tokens":200,"output_tokens":10}}}`,
+		`{"type":"assistant","timestamp":"2025-06-01T10:01:00Z","message":{"id":"m2","model":"claude-sonnet-4-6","usage":{"input_tokens":5,"cache_read_input_tokens":200,"cache_creation_input_tokens":300,"output_tokens":10}}}`,
+	)
+
+	result := ParseFile(df)
+	if result.Err != nil {
+		t.Fatalf("unexpected error: %v", result.Err)
+	}
+	want := []int64{210, 505, 1505}
+	if got := result.Stats.ContextCurve; !slices.Equal(got, want) {
+		t.Errorf("ContextCurve = %v, want %v", got, want)
+	}
+	if result.Stats.PeakContext != 1505 {
+		t.Errorf("PeakContext = %d, want 1505", result.Stats.PeakContext)
+	}
+}
+
+func TestParseFile_Compactions(t *testing.T) {
+	df := writeSession(t,
+		`{"type":"user","timestamp":"2025-06-01T10:00:00Z"}`,
+		`{"type":"system","subtype":"compact_boundary","timestamp":"2025-06-01T10:30:00Z","compactMetadata":{"trigger":"auto","preTokens":162000}}`,
+		`{"type":"system","subtype":"turn_duration","durationMs":1000,"timestamp":"2025-06-01T10:31:00Z"}`,
+		`{"type":"system","subtype":"compact_boundary","timestamp":"2025-06-01T11:00:00Z","compactMetadata":{"trigger":"manual"}}`,
+	)
+
+	result := ParseFile(df)
+	if result.Err != nil {
+		t.Fatalf("unexpected error: %v", result.Err)
+	}
+	if result.Stats.Compactions != 2 {
+		t.Errorf("Compactions = %d, want 2", result.Stats.Compactions)
+	}
+}
+
+func TestParseFile_RepeatedCalls(t *testing.T) {
+	call := func(id, ts string, in, out int) string {
+		return `{"type":"assistant","timestamp":"2025-06-01T` + ts + `Z","message":{"id":"` + id +
+			`","model":"claude-sonnet-4-6","usage":{"input_tokens":` + strconv.Itoa(in) + `,"output_tokens":` + strconv.Itoa(out) + `}}}`
+	}
+	df := writeSession(t,
+		call("m1", "10:00:00", 10, 50),
+		call("m2", "10:00:20", 10, 50),
+		call("m3", "10:00:40", 10, 50),
+		call("m4", "10:05:00", 10, 50), // too long after m3: a new run
+		call("m5", "10:05:10", 10, 50),
+		call("m6", "10:05:20", 12, 50),
+	)
+
+	result := ParseFile(df)
+	if result.Err != nil {
+		t.Fatalf("unexpected error: %v", result.Err)
+	}
+	if result.Stats.RepeatedCalls != 3 {
+		t.Errorf("RepeatedCalls = %d, want 3", result.Stats.RepeatedCalls)
+	}
+}
+
+func TestParseFile_TurnDurationsByModel(t *testing.T) {
+	df := writeSession(t,
+		`{"type":"assistant","timestamp":"2025-06-01T10:00:00Z","message":{"id":"m1","model":"claude-opus-4-6","usage":{"input_tokens":10,"output_tokens":10}}}`,
+		`{"type":"system","subtype":"turn_duration","durationMs":12000,"timestamp":"2025-06-01T10:00:12Z"}`,
+		`{"type":"assistant","timestamp":"2025-06-01T10:01:00Z","message":{"id":"m2","model":"claude-sonnet-4-6","usage":{"input_tokens":10,"output_tokens":10}}}`,
+		`{"type":"system","subtype":"turn_duration","durationMs":3000,"timestamp":"2025-06-01T10:01:03Z"}`,
+		`{"type":"system","subtype":"turn_duration","durationMs":4000,"timestamp":"2025-06-01T10:02:04Z"}`,
+	)
+
+	result := ParseFile(df)
+	if result.Err != nil {
+		t.Fatalf("unexpected error: %v", result.Err)
+	}
+	if got := result.Stats.Models["claude-opus-4-6"].TurnDurationsMs; !slices.Equal(got, []int64{12000}) {
+		t.Errorf("opus turns = %v", got)
+	}
+	if got := result.Stats.Models["claude-sonnet-4-6"].TurnDurationsMs; !slices.Equal(got, []int64{3000, 4000}) {
+		t.Errorf("sonnet turns = %v", got)
+	}
+}
+
+func TestParseFile_IdleGapsAndClaudeTime(t *testing.T) {
+	df := writeSession(t,
+		`{"type":"user","timestamp":"2025-06-01T10:00:00Z"}`,
+		`{"type":"system","subtype":"turn_duration","durationMs":30000,"timestamp":"2025-06-01T10:00:30Z"}`,
+		`{"type":"user","timestamp":"2025-06-01T10:45:30Z"}`,
+		`{"type":"system","subtype":"turn_duration","durationMs":90000,"timestamp":"2025-06-01T10:47:00Z"}`,
+	)
+
+	result := ParseFile(df)
+	if result.Err != nil {
+		t.Fatalf("unexpected error: %v", result.Err)
+	}
+	if got := result.Stats.IdleGaps; !slices.Equal(got, []int64{2700, 90}) {
+		t.Errorf("IdleGaps = %v, want [2700 90]", got)
+	}
+	if result.Stats.ClaudeSecs != 120 {
+		t.Errorf("ClaudeSecs = %d, want 120", result.Stats.ClaudeSecs)
+	}
+}
+
+func TestDownsamplePeaks(t *testing.T) {
+	got := downsamplePeaks([]int64{1, 9, 2, 3, 8, 4, 5, 6}, 4)
+	if want := []int64{9, 3, 8, 6}; !slices.Equal(got, want) {
+		t.Errorf("downsamplePeaks = %v, want %v", got, want)
+	}
+}
+
+func TestResolveRepo(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "myrepo")
+	sub := filepath.Join(root, "pkg", "inner")
+	if err := os.MkdirAll(filepath.Join(root, ".git"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(sub, 0o750); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := ResolveRepo(sub); got != "myrepo" {
+		t.Errorf("ResolveRepo(sub) = %q, want myrepo", got)
+	}
+	if got := ResolveRepo(""); got != "" {
+		t.Errorf("ResolveRepo(\"\") = %q, want empty", got)
+	}
+}
+
+func TestParseFileWithProgress_ReportsAllBytes(t *testing.T) {
+	df := writeSession(t,
+		`{"type":"user","timestamp":"2025-06-01T10:00:00Z"}`,
+		`{"type":"user","timestamp":"2025-06-01T10:05:00Z"}`,
+	)
+	info, err := os.Stat(df.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var total int64
+	result := ParseFileWithProgress(df, func(n int64) { total += n })
+	if result.Err != nil {
+		t.Fatalf("unexpected error: %v", result.Err)
+	}
+	if total != info.Size() {
+		t.Errorf("reported bytes = %d, want %d", total, info.Size())
+	}
+}
+
+// longAssistantLine returns a Claude Code-shaped assistant entry whose text
+// content is contentBytes long, with the top-level type after the message.
+func longAssistantLine(id string, contentBytes int) string {
+	return `{"cwd":"/tmp/big","message":{"model":"claude-sonnet-4-6","id":"` + id + `","type":"message","content":[{"type":"text","text":"` +
+		strings.Repeat("x", contentBytes) +
+		`"}],"usage":{"input_tokens":7,"output_tokens":3,"cache_read_input_tokens":11}},"type":"assistant","timestamp":"2025-06-01T10:00:00Z"}`
+}
+
+func TestParseFile_LongLines(t *testing.T) {
+	df := writeSession(t,
+		`{"type":"user","timestamp":"2025-06-01T09:59:00Z"}`,
+		longAssistantLine("big1", 3<<20), // beyond the old 2MB scanner limit
+		`{"type":"user","timestamp":"2025-06-01T10:01:00Z","message":{"content":"`+strings.Repeat("y", 3<<20)+`"}}`,
+	)
+
+	for _, tc := range []struct {
+		name    string
+		maxLine int
+	}{
+		{"unbounded", 0},
+		{"bounded", 64 * 1024},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			result := ParseFileWithOptions(df, ParseOptions{MaxLineBytes: tc.maxLine})
+			if result.Err != nil {
+				t.Fatalf("unexpected error: %v", result.Err)
+			}
+			st := result.Stats
+			if result.ParseErrors != 0 {
+				t.Errorf("ParseErrors = %d, want 0", result.ParseErrors)
+			}
+			if st.UserMessages != 2 || st.APICalls != 1 {
+				t.Errorf("UserMessages, APICalls = %d, %d, want 2, 1", st.UserMessages, st.APICalls)
+			}
+			if st.InputTokens != 7 || st.OutputTokens != 3 || st.CacheReadTokens != 11 {
+				t.Errorf("tokens = %d/%d/%d, want 7/3/11", st.InputTokens, st.OutputTokens, st.CacheReadTokens)
+			}
+			if _, ok := st.Models["claude-sonnet-4-6"]; !ok {
+				t.Errorf("models = %v, want claude-sonnet-4-6", st.Models)
+			}
+			if st.ProjectPath != "/tmp/big" {
+				t.Errorf("ProjectPath = %q, want /tmp/big", st.ProjectPath)
+			}
+			if want := time.Date(2025, 6, 1, 10, 1, 0, 0, time.UTC); !st.EndTime.Equal(want) {
+				t.Errorf("EndTime = %v, want %v", st.EndTime, want)
+			}
+		})
+	}
+}
+
+func TestLineReader_BoundedKeepsHeadAndTail(t *testing.T) {
+	long := "HEAD" + strings.Repeat("m", 1000) + "TAIL"
+	input := "short\n" + long + "\r\nlast"
+	lr := newLineReader(strings.NewReader(input), 64)
+
+	var got []string
+	var truncs []bool
+	for {
+		line, truncated, err := lr.next()
+		if err != nil {
+			break
+		}
+		got = append(got, string(line))
+		truncs = append(truncs, truncated)
+	}
+	if len(got) != 3 {
+		t.Fatalf("got %d lines, want 3: %q", len(got), got)
+	}
+	if got[0] != "short" || got[2] != "last" || truncs[0] || truncs[2] {
+		t.Errorf("short lines = %q, %q (truncated %v, %v)", got[0], got[2], truncs[0], truncs[2])
+	}
+	if !truncs[1] || len(got[1]) > 64 || !strings.HasPrefix(got[1], "HEAD") || !strings.HasSuffix(got[1], "TAIL") {
+		t.Errorf("long line = %q (truncated %v), want at most 64 bytes of head+tail", got[1], truncs[1])
+	}
+}
+
 func TestExtractTopLevelType(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -208,3 +496,31 @@ func FuzzExtractTopLevelType(f *testing.F) {
 		}
 	})
 }
+
+func TestReadPrompts(t *testing.T) {
+	df := writeSession(t,
+		`{"type":"user","timestamp":"2025-06-01T10:00:00Z","message":{"role":"user","content":"fix the   flaky\ntest in parser_test.go"}}`,
+		`{"type":"assistant","timestamp":"2025-06-01T10:00:05Z","message":{"id":"m1","role":"assistant","model":"claude-sonnet-4-6","usage":{"input_tokens":1,"output_tokens":1}}}`,
+		`{"type":"user","timestamp":"2025-06-01T10:00:06Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"t1","content":"ok"}]}}`,
+		`{"type":"user","timestamp":"2025-06-01T10:01:00Z","isMeta":true,"message":{"role":"user","content":"Caveat: local commands"}}`,
+		`{"type":"user","timestamp":"2025-06-01T10:02:00Z","message":{"role":"user","content":"<command-message>review</command-message>\n<command-name>/review</command-name>\n<command-args>main</command-args>"}}`,
+		`{"type":"user","timestamp":"2025-06-01T10:02:01Z","message":{"role":"user","content":"<local-command-stdout>done</local-command-stdout>"}}`,
+		`{"type":"user","timestamp":"2025-06-01T10:03:00Z","message":{"role":"user","content":[{"type":"text","text":"now make it much faster please"}]}}`,
+	)
+
+	prompts, err := ReadPrompts(df.Path, 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, p := range prompts {
+		got = append(got, p.Text)
+	}
+	want := []string{"fix the fla…", "/review main", "now make it…"}
+	if !slices.Equal(got, want) {
+		t.Errorf("prompts = %q, want %q", got, want)
+	}
+	if len(prompts) > 0 && !prompts[0].Time.Equal(time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("first prompt time = %v", prompts[0].Time)
+	}
+}
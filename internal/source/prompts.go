@@ -0,0 +1,123 @@
+package source
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Prompt is one user prompt of a session, as shown in a transcript preview.
+type Prompt struct {
+	Time time.Time
+	Text string
+}
+
+// rawPromptEntry is the part of a user entry a prompt is read from.
+type rawPromptEntry struct {
+	Type      string `json:"type"`
+	Timestamp string `json:"timestamp"`
+	IsMeta    bool   `json:"isMeta"`
+	Message   *struct {
+		Content json.RawMessage `json:"content"`
+	} `json:"message"`
+}
+
+// ReadPrompts returns the text of each prompt the user typed in the session
+// file at path, whitespace-collapsed and cut to maxChars characters. Tool
+// results, which Claude Code also records as user entries, are skipped, as
+// are meta entries and local command output. Prompt text is never cached;
+// the file is read on every call.
+func ReadPrompts(path string, maxChars int) ([]Prompt, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	r, release, err := decompress(path, f)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	var prompts []Prompt
+	lr := newLineReader(r, DefaultMaxLineBytes)
+	for {
+		line, truncated, err := lr.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if truncated || extractTopLevelType(line) != "user" {
+			continue
+		}
+		var e rawPromptEntry
+		if json.Unmarshal(line, &e) != nil || e.IsMeta || e.Message == nil {
+			continue
+		}
+		text := promptText(e.Message.Content)
+		if text == "" {
+			continue
+		}
+		p := Prompt{Text: clipText(text, maxChars)}
+		if ts, err := time.Parse(time.RFC3339Nano, e.Timestamp); err == nil {
+			p.Time = ts
+		}
+		prompts = append(prompts, p)
+	}
+	return prompts, nil
+}
+
+// promptText extracts what the user typed from a message's content: a plain
+// string, or the text blocks of a content array. A slash command is shown as
+// typed ("/review main"); command output yields "".
+func promptText(content json.RawMessage) string {
+	var text string
+	if err := json.Unmarshal(content, &text); err != nil {
+		var blocks []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		}
+		if json.Unmarshal(content, &blocks) != nil {
+			return ""
+		}
+		var parts []string
+		for _, b := range blocks {
+			if b.Type == "text" {
+				parts = append(parts, b.Text)
+			}
+		}
+		text = strings.Join(parts, " ")
+	}
+
+	if strings.HasPrefix(strings.TrimSpace(text), "<local-command-") {
+		return ""
+	}
+	if name, ok := tagValue(text, "command-name"); ok {
+		args, _ := tagValue(text, "command-args")
+		text = name + " " + args
+	}
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// tagValue returns the text between <tag> and </tag> in s.
+func tagValue(s, tag string) (string, bool) {
+	_, rest, ok := strings.Cut(s, "<"+tag+">")
+	if !ok {
+		return "", false
+	}
+	val, _, ok := strings.Cut(rest, "</"+tag+">")
+	return val, ok
+}
+
+// clipText cuts s to at most n characters, marking the cut with an ellipsis.
+func clipText(s string, n int) string {
+	if n <= 0 || utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n-1]) + "…"
+}
@@ -1,15 +1,23 @@
 package source
 
 import (
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
 )
 
-// ScanDir walks the Claude projects directory and discovers all JSONL session files.
-// It returns discovered files categorized as main sessions or subagent sessions.
+// ScanDir walks the Claude projects directory and discovers all JSONL session files,
+// including .jsonl.gz and .jsonl.zst archives. It returns discovered files
+// categorized as main sessions or subagent sessions.
 func ScanDir(claudeDir string) ([]DiscoveredFile, error) {
-	projectsDir := filepath.Join(claudeDir, "projects")
+	return scanClaudeCode(Root{Path: claudeDir})
+}
+
+// scanClaudeCode is the ProfileClaudeCode discovery: ScanDir, skipping
+// anything matching the root's Exclude patterns (relative to projects/).
+func scanClaudeCode(r Root) ([]DiscoveredFile, error) {
+	projectsDir := filepath.Join(r.Path, "projects")
 
 	info, err := os.Stat(projectsDir)
 	if err != nil {
@@ -22,29 +30,16 @@ func ScanDir(claudeDir string) ([]DiscoveredFile, error) {
 		return nil, nil
 	}
 
-	var files []DiscoveredFile
-
-	err = filepath.WalkDir(projectsDir, func(path string, d os.DirEntry, err error) error {
-		if err != nil {
-			return nil //nolint:nilerr // intentionally skip unreadable entries
-		}
-		if d.IsDir() {
-			return nil
-		}
-		if filepath.Ext(path) != ".jsonl" {
-			return nil
-		}
-
+	files, err := walkRoot(projectsDir, r.Exclude, func(path, rel string, d fs.DirEntry) (DiscoveredFile, bool) {
 		// Skip sessions-index.json and other non-session files
-		name := d.Name()
-		if !strings.HasSuffix(name, ".jsonl") {
-			return nil
+		base, ok := trimSessionExt(d.Name())
+		if !ok {
+			return DiscoveredFile{}, false
 		}
 
-		rel, _ := filepath.Rel(projectsDir, path)
 		parts := strings.Split(rel, string(filepath.Separator))
 		if len(parts) < 2 {
-			return nil
+			return DiscoveredFile{}, false
 		}
 
 		projectDir := parts[0]
@@ -55,6 +50,10 @@ func ScanDir(claudeDir string) ([]DiscoveredFile, error) {
 			Project:    project,
 			ProjectDir: projectDir,
 		}
+		if info, err := d.Info(); err == nil {
+			df.Size = info.Size()
+			df.ModTime = info.ModTime()
+		}
 
 		// Determine if this is a subagent file
 		// Pattern: <project>/<session-uuid>/subagents/agent-<id>.jsonl
@@ -62,17 +61,15 @@ func ScanDir(claudeDir string) ([]DiscoveredFile, error) {
 			df.IsSubagent = true
 			df.ParentSession = parts[1]
 			// Use parent+agent to avoid collisions across sessions
-			df.SessionID = parts[1] + "/" + strings.TrimSuffix(name, ".jsonl")
+			df.SessionID = parts[1] + "/" + base
 		} else {
-			// Main session: <project>/<session-uuid>.jsonl
-			df.SessionID = strings.TrimSuffix(name, ".jsonl")
+			// Main session: <project>/<session-uuid>.jsonl (or an archive of one)
+			df.SessionID = base
 		}
-
-		files = append(files, df)
-		return nil
+		return df, true
 	})
 
-	return files, err
+	return dedupeArchives(files), err
 }
 
 // decodeProjectName extracts a human-readable project name from the encoded directory name.
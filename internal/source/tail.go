@@ -0,0 +1,229 @@
+package source
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/config"
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+// Tailer follows a single session file and yields API calls as they are appended.
+//
+// Claude Code writes several entries per message ID while a response streams,
+// so a call is held back until a different message appears or a poll finds no
+// new data; only the final usage for each message is emitted.
+type Tailer struct {
+	path    string
+	offset  int64
+	partial []byte // trailing bytes of an incomplete line
+	pending *model.APICall
+	emitted map[string]struct{}
+}
+
+// NewTailer starts following path. With fromEnd, existing content is skipped.
+func NewTailer(path string, fromEnd bool) (*Tailer, error) {
+	t := &Tailer{path: path, emitted: make(map[string]struct{})}
+	if fromEnd {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		t.offset = info.Size()
+	}
+	return t, nil
+}
+
+// Path returns the file being followed.
+func (t *Tailer) Path() string {
+	return t.path
+}
+
+// Poll reads anything appended since the last poll and returns completed calls.
+func (t *Tailer) Poll() ([]model.APICall, error) {
+	f, err := os.Open(t.path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() < t.offset {
+		// File was truncated or replaced; start over.
+		t.offset = 0
+		t.partial = nil
+	}
+
+	var out []model.APICall
+	if info.Size() == t.offset {
+		// Idle: the pending call can't receive further updates worth waiting for.
+		return t.flush(out), nil
+	}
+
+	if _, err := f.Seek(t.offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(io.LimitReader(f, info.Size()-t.offset))
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", t.path, err)
+	}
+	t.offset += int64(len(data))
+
+	data = append(t.partial, data...)
+	lastNL := bytes.LastIndexByte(data, '\n')
+	if lastNL < 0 {
+		t.partial = data
+		return nil, nil
+	}
+	t.partial = append([]byte(nil), data[lastNL+1:]...)
+
+	for _, line := range bytes.Split(data[:lastNL], []byte{'\n'}) {
+		call, ok := parseCallLine(line)
+		if !ok {
+			continue
+		}
+		if t.pending != nil && t.pending.MessageID != call.MessageID {
+			out = t.flush(out)
+		}
+		t.pending = &call
+	}
+	return out, nil
+}
+
+// flush appends the pending call to out unless it was already emitted.
+func (t *Tailer) flush(out []model.APICall) []model.APICall {
+	if t.pending == nil {
+		return out
+	}
+	call := *t.pending
+	t.pending = nil
+	if _, dup := t.emitted[call.MessageID]; dup {
+		return out
+	}
+	t.emitted[call.MessageID] = struct{}{}
+	return append(out, call)
+}
+
+// parseCallLine extracts a priced API call from an assistant entry.
+func parseCallLine(line []byte) (model.APICall, bool) {
+	if extractTopLevelType(line) != "assistant" {
+		return model.APICall{}, false
+	}
+	var entry RawEntry
+	if err := json.Unmarshal(line, &entry); err != nil {
+		return model.APICall{}, false
+	}
+	if entry.Message == nil || entry.Message.ID == "" || entry.Message.Usage == nil {
+		return model.APICall{}, false
+	}
+
+	u := entry.Message.Usage
+	var cache5m, cache1h int64
+	if u.CacheCreation != nil {
+		cache5m = u.CacheCreation.Ephemeral5mInputTokens
+		cache1h = u.CacheCreation.Ephemeral1hInputTokens
+	} else if u.CacheCreationInputTokens > 0 {
+		cache5m = u.CacheCreationInputTokens
+	}
+	ts, _ := time.Parse(time.RFC3339Nano, entry.Timestamp)
+
+	call := model.APICall{
+		MessageID:             entry.Message.ID,
+		Model:                 entry.Message.Model,
+		Timestamp:             ts,
+		InputTokens:           u.InputTokens,
+		OutputTokens:          u.OutputTokens,
+		CacheCreation5mTokens: cache5m,
+		CacheCreation1hTokens: cache1h,
+		CacheReadTokens:       u.CacheReadInputTokens,
+		ServiceTier:           u.ServiceTier,
+	}
+	call.EstimatedCost = config.CalculateCostAt(call.Model, ts, call.ServiceTier,
+		call.InputTokens, call.OutputTokens,
+		call.CacheCreation5mTokens, call.CacheCreation1hTokens, call.CacheReadTokens)
+	return call, true
+}
+
+// Follower tails whichever session file was most recently modified,
+// switching files when a newer session becomes active.
+type Follower struct {
+	claudeDir        string
+	includeSubagents bool
+	rescanEvery      time.Duration
+
+	lastScan time.Time
+	file     DiscoveredFile
+	tailer   *Tailer
+}
+
+// FollowUpdate is the result of one Follower poll.
+type FollowUpdate struct {
+	File     DiscoveredFile // session currently followed (zero if none found)
+	Switched bool           // File changed since the previous poll
+	Calls    []model.APICall
+}
+
+// NewFollower returns a Follower that rescans for the newest session every rescanEvery.
+func NewFollower(claudeDir string, includeSubagents bool, rescanEvery time.Duration) *Follower {
+	return &Follower{
+		claudeDir:        claudeDir,
+		includeSubagents: includeSubagents,
+		rescanEvery:      rescanEvery,
+	}
+}
+
+// Poll rescans for the newest session when due, then returns new calls.
+// Calls already in a file when it is first followed are skipped.
+func (f *Follower) Poll() (FollowUpdate, error) {
+	var upd FollowUpdate
+
+	if f.tailer == nil || time.Since(f.lastScan) >= f.rescanEvery {
+		f.lastScan = time.Now()
+		newest, ok, err := NewestSession(f.claudeDir, f.includeSubagents)
+		if err != nil {
+			return upd, err
+		}
+		if ok && newest.Path != f.file.Path {
+			t, err := NewTailer(newest.Path, true)
+			if err != nil {
+				return upd, err
+			}
+			f.file, f.tailer = newest, t
+			upd.Switched = true
+		}
+	}
+
+	upd.File = f.file
+	if f.tailer == nil {
+		return upd, nil
+	}
+	calls, err := f.tailer.Poll()
+	upd.Calls = calls
+	return upd, err
+}
+
+// NewestSession returns the most recently modified session file.
+func NewestSession(claudeDir string, includeSubagents bool) (DiscoveredFile, bool, error) {
+	files, err := ScanDir(claudeDir)
+	if err != nil {
+		return DiscoveredFile{}, false, err
+	}
+	var newest DiscoveredFile
+	found := false
+	for _, df := range files {
+		if (df.IsSubagent && !includeSubagents) || IsCompressed(df.Path) {
+			continue
+		}
+		if !found || df.ModTime.After(newest.ModTime) {
+			newest, found = df, true
+		}
+	}
+	return newest, found, nil
+}
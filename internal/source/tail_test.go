@@ -0,0 +1,59 @@
+package source
+
+import (
+	"os"
+	"testing"
+)
+
+func appendLines(t *testing.T, path string, lines ...string) {
+	t.Helper()
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+	for _, l := range lines {
+		if _, err := f.WriteString(l + "\n"); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestTailer_EmitsFinalUsagePerMessage(t *testing.T) {
+	df := writeSession(t,
+		`{"type":"assistant","timestamp":"2025-06-01T10:00:00Z","message":{"id":"old","model":"claude-sonnet-4-6","usage":{"input_tokens":1,"output_tokens":1}}}`,
+	)
+	tl, err := NewTailer(df.Path, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	appendLines(t, df.Path,
+		`{"type":"assistant","timestamp":"2025-06-01T10:01:00Z","message":{"id":"m1","model":"claude-sonnet-4-6","usage":{"input_tokens":10,"output_tokens":1}}}`,
+		`{"type":"assistant","timestamp":"2025-06-01T10:01:01Z","message":{"id":"m1","model":"claude-sonnet-4-6","usage":{"input_tokens":10,"output_tokens":50}}}`,
+	)
+	calls, err := tl.Poll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 0 {
+		t.Fatalf("got %d calls while m1 may still stream, want 0", len(calls))
+	}
+
+	appendLines(t, df.Path,
+		`{"type":"assistant","timestamp":"2025-06-01T10:02:00Z","message":{"id":"m2","model":"claude-sonnet-4-6","usage":{"input_tokens":5,"output_tokens":5}}}`,
+	)
+	calls, _ = tl.Poll()
+	if len(calls) != 1 || calls[0].MessageID != "m1" || calls[0].OutputTokens != 50 {
+		t.Fatalf("got %+v, want final m1 usage", calls)
+	}
+
+	// Idle poll flushes the last pending message.
+	calls, _ = tl.Poll()
+	if len(calls) != 1 || calls[0].MessageID != "m2" {
+		t.Fatalf("idle poll got %+v, want m2", calls)
+	}
+	if calls[0].EstimatedCost <= 0 {
+		t.Errorf("EstimatedCost = %v, want > 0", calls[0].EstimatedCost)
+	}
+}
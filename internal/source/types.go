@@ -1,5 +1,7 @@
 package source
 
+import "time"
+
 // RawEntry represents a single line in a Claude Code JSONL session file.
 type RawEntry struct {
 	Type      string      `json:"type"`
@@ -7,6 +9,7 @@ type RawEntry struct {
 	Timestamp string      `json:"timestamp,omitempty"`
 	SessionID string      `json:"sessionId,omitempty"`
 	Cwd       string      `json:"cwd,omitempty"`
+	GitBranch string      `json:"gitBranch,omitempty"`
 	Version   string      `json:"version,omitempty"`
 	Message   *RawMessage `json:"message,omitempty"`
 
@@ -54,5 +57,7 @@ type DiscoveredFile struct {
 	ProjectDir    string // raw directory name
 	SessionID     string // extracted from filename
 	IsSubagent    bool
-	ParentSession string // for subagents: parent session UUID
+	ParentSession string    // for subagents: parent session UUID
+	Size          int64     // file size in bytes at scan time
+	ModTime       time.Time // file modification time at scan time
 }
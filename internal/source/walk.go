@@ -0,0 +1,99 @@
+package source
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// walkParallelism bounds how many top-level directories of a root are
+// walked at once. Walking is I/O bound, and on network mounts most of the
+// time goes to round trips, so this is independent of the CPU count.
+const walkParallelism = 8
+
+// walkRoot calls visit for every file under root whose path isn't
+// excluded, with its path relative to root. Each top-level directory
+// (a project, in the Claude Code layout) is walked in its own goroutine;
+// results come back in the same lexical order a single filepath.WalkDir
+// would give. Unreadable entries are skipped.
+//
+// An exclude pattern is a glob (filepath.Match syntax) tested against an
+// entry's name and its slash-separated path relative to root; an excluded
+// directory is not descended into.
+func walkRoot(root string, exclude []string, visit func(path, rel string, d fs.DirEntry) (DiscoveredFile, bool)) ([]DiscoveredFile, error) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([][]DiscoveredFile, len(entries))
+	sem := make(chan struct{}, walkParallelism)
+	var wg sync.WaitGroup
+	for i, e := range entries {
+		if excluded(exclude, e.Name(), e.Name()) {
+			continue
+		}
+		path := filepath.Join(root, e.Name())
+		if !e.IsDir() {
+			if df, ok := visit(path, e.Name(), e); ok {
+				results[i] = []DiscoveredFile{df}
+			}
+			continue
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+			results[i] = walkDir(root, path, exclude, visit)
+		}()
+	}
+	wg.Wait()
+
+	var files []DiscoveredFile
+	for _, r := range results {
+		files = append(files, r...)
+	}
+	return files, nil
+}
+
+// walkDir walks one directory below root for walkRoot.
+func walkDir(root, dir string, exclude []string, visit func(path, rel string, d fs.DirEntry) (DiscoveredFile, bool)) []DiscoveredFile {
+	var files []DiscoveredFile
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil //nolint:nilerr // intentionally skip unreadable entries
+		}
+		rel, _ := filepath.Rel(root, path)
+		if path != dir && excluded(exclude, d.Name(), filepath.ToSlash(rel)) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if df, ok := visit(path, rel, d); ok {
+			files = append(files, df)
+		}
+		return nil
+	})
+	return files
+}
+
+// excluded reports whether an entry matches one of the exclude patterns by
+// name or by relative path.
+func excluded(patterns []string, name, rel string) bool {
+	for _, p := range patterns {
+		p = strings.TrimSuffix(p, "/")
+		if ok, _ := filepath.Match(p, name); ok {
+			return true
+		}
+		if ok, _ := filepath.Match(p, rel); ok {
+			return true
+		}
+	}
+	return false
+}
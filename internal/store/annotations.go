@@ -0,0 +1,95 @@
+package store
+
+import (
+	"strings"
+	"time"
+)
+
+// Annotation is the note and tags a user attached to a session.
+type Annotation struct {
+	Note string
+	Tags []string
+}
+
+// IsZero reports whether a has neither a note nor tags.
+func (a Annotation) IsZero() bool {
+	return a.Note == "" && len(a.Tags) == 0
+}
+
+// Annotations returns every session's annotation, keyed by session ID.
+func (c *Cache) Annotations() (map[string]Annotation, error) {
+	rows, err := c.db.Query("SELECT session_id, note, tags FROM session_annotations")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	out := make(map[string]Annotation)
+	for rows.Next() {
+		var id, note, tags string
+		if err := rows.Scan(&id, &note, &tags); err != nil {
+			return nil, err
+		}
+		a := Annotation{Note: note}
+		if tags != "" {
+			a.Tags = strings.Split(tags, ",")
+		}
+		out[id] = a
+	}
+	return out, rows.Err()
+}
+
+// SetAnnotation stores a for a session, or removes it when a is empty.
+func (c *Cache) SetAnnotation(sessionID string, a Annotation) error {
+	if a.IsZero() {
+		_, err := c.db.Exec("DELETE FROM session_annotations WHERE session_id = ?", sessionID)
+		return err
+	}
+	_, err := c.db.Exec(`INSERT OR REPLACE INTO session_annotations (session_id, note, tags, updated_at)
+		VALUES (?, ?, ?, ?)`,
+		sessionID, a.Note, strings.Join(a.Tags, ","), time.Now().UTC().Format(time.RFC3339))
+	return err
+}
+
+// ExcludedSessions returns the IDs of sessions hidden from totals.
+func (c *Cache) ExcludedSessions() (map[string]struct{}, error) {
+	rows, err := c.db.Query("SELECT session_id FROM excluded_sessions")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	out := make(map[string]struct{})
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		out[id] = struct{}{}
+	}
+	return out, rows.Err()
+}
+
+// SetExcluded hides a session from totals, or shows it again. The summary
+// cache is invalidated, since its rows no longer match.
+func (c *Cache) SetExcluded(sessionID string, excluded bool) error {
+	tx, err := c.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if excluded {
+		_, err = tx.Exec("INSERT OR IGNORE INTO excluded_sessions (session_id, excluded_at) VALUES (?, ?)",
+			sessionID, time.Now().UTC().Format(time.RFC3339))
+	} else {
+		_, err = tx.Exec("DELETE FROM excluded_sessions WHERE session_id = ?", sessionID)
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM summary_meta"); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/theirongolddev/cburn/internal/model"
@@ -25,19 +27,41 @@ func Open(dbPath string) (*Cache, error) {
 		return nil, fmt.Errorf("creating cache dir: %w", err)
 	}
 
-	db, err := sql.Open("sqlite", dbPath+"?_pragma=journal_mode(wal)&_pragma=synchronous(normal)&_pragma=foreign_keys(on)")
+	db, err := openDB(dbPath)
 	if err != nil {
-		return nil, fmt.Errorf("opening cache db: %w", err)
+		return nil, err
 	}
-
-	if _, err := db.Exec(schemaSQL); err != nil {
+	if merr := migrate(db); merr != nil {
 		_ = db.Close()
-		return nil, fmt.Errorf("creating schema: %w", err)
+		if !rebuildable(merr) {
+			return nil, merr
+		}
+		// A cache a migration failed on is set aside and rebuilt from the
+		// session files. One from a newer cburn is left alone; callers fall
+		// back to loading without the cache.
+		if err := setAside(dbPath); err != nil {
+			return nil, fmt.Errorf("%w; setting the cache aside: %v", merr, err)
+		}
+		if db, err = openDB(dbPath); err != nil {
+			return nil, err
+		}
+		if err := migrate(db); err != nil {
+			_ = db.Close()
+			return nil, err
+		}
 	}
 
 	return &Cache{db: db}, nil
 }
 
+func openDB(dbPath string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", dbPath+"?_pragma=journal_mode(wal)&_pragma=synchronous(normal)&_pragma=foreign_keys(on)")
+	if err != nil {
+		return nil, fmt.Errorf("opening cache db: %w", err)
+	}
+	return db, nil
+}
+
 // Close closes the cache database.
 func (c *Cache) Close() error {
 	return c.db.Close()
@@ -71,12 +95,178 @@ func (c *Cache) GetTrackedFiles() (map[string]FileInfo, error) {
 
 // SaveSession stores a parsed session and its file tracking info.
 func (c *Cache) SaveSession(s model.SessionStats, mtimeNs, sizeBytes int64) error {
+	return c.SaveSessions([]SessionWrite{{Session: s, MtimeNs: mtimeNs, SizeBytes: sizeBytes}})
+}
+
+// SessionWrite is a parsed session and the state of the file it came from.
+type SessionWrite struct {
+	Session   model.SessionStats
+	MtimeNs   int64
+	SizeBytes int64
+}
+
+// SaveSessions writes a batch of sessions and their file tracker entries in
+// one transaction, reusing prepared statements across rows. A first full
+// parse saves thousands of sessions; one transaction each is far slower.
+func (c *Cache) SaveSessions(batch []SessionWrite) error {
+	if len(batch) == 0 {
+		return nil
+	}
 	tx, err := c.db.Begin()
 	if err != nil {
 		return err
 	}
 	defer func() { _ = tx.Rollback() }()
 
+	w, err := newSessionWriter(tx)
+	if err != nil {
+		return err
+	}
+	defer w.close()
+
+	track, err := tx.Prepare(`INSERT OR REPLACE INTO file_tracker (file_path, mtime_ns, size_bytes)
+		VALUES (?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = track.Close() }()
+
+	for _, sw := range batch {
+		if err := w.save(sw.Session, sw.MtimeNs, sw.SizeBytes); err != nil {
+			return err
+		}
+		if _, err := track.Exec(sw.Session.FilePath, sw.MtimeNs, sw.SizeBytes); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ReplaceSource atomically replaces all sessions imported under a source label.
+// Imported sessions are not tied to local files, so no file tracking is recorded.
+func (c *Cache) ReplaceSource(source string, sessions []model.SessionStats) error {
+	if source == "" {
+		return fmt.Errorf("source label must not be empty")
+	}
+
+	tx, err := c.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.Exec("DELETE FROM sessions WHERE source = ?", source); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM summary_meta"); err != nil {
+		return err
+	}
+	w, err := newSessionWriter(tx)
+	if err != nil {
+		return err
+	}
+	defer w.close()
+	for _, s := range sessions {
+		s.Source = source
+		if err := w.save(s, 0, 0); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// SourceInfo summarizes the sessions imported under one source label.
+type SourceInfo struct {
+	Source        string
+	Sessions      int
+	EstimatedCost float64
+	LastActivity  time.Time
+}
+
+// ListSources returns all imported source labels with session counts.
+func (c *Cache) ListSources() ([]SourceInfo, error) {
+	rows, err := c.db.Query(`SELECT source, COUNT(*), COALESCE(SUM(estimated_cost), 0), COALESCE(MAX(end_time), '')
+		FROM sessions WHERE source != '' GROUP BY source ORDER BY source`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var out []SourceInfo
+	for rows.Next() {
+		var si SourceInfo
+		var last string
+		if err := rows.Scan(&si.Source, &si.Sessions, &si.EstimatedCost, &last); err != nil {
+			return nil, err
+		}
+		si.LastActivity, _ = time.Parse(time.RFC3339, last)
+		out = append(out, si)
+	}
+	return out, rows.Err()
+}
+
+// DeleteSource removes every session imported under a source label.
+func (c *Cache) DeleteSource(source string) (int64, error) {
+	res, err := c.db.Exec("DELETE FROM sessions WHERE source = ? AND source != ''", source)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := c.db.Exec("DELETE FROM summary_meta"); err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// sessionWriter writes session rows and their model and tier breakdowns
+// within one transaction through prepared statements.
+type sessionWriter struct {
+	session, delModels, model, delTiers, tier *sql.Stmt
+}
+
+func newSessionWriter(tx *sql.Tx) (*sessionWriter, error) {
+	w := &sessionWriter{}
+	for _, st := range []struct {
+		dst   **sql.Stmt
+		query string
+	}{
+		{&w.session, `INSERT OR REPLACE INTO sessions
+			(session_id, project, project_path, repo, git_branch, source, file_path, is_subagent, parent_session,
+			 start_time, end_time, duration_secs, user_messages, api_calls,
+			 input_tokens, output_tokens, cache_creation_5m, cache_creation_1h,
+			 cache_read_tokens, estimated_cost, cache_hit_rate, file_mtime_ns, file_size, parsed_at, first_message_id,
+			 peak_context, context_curve, compactions, claude_secs, idle_gaps, repeated_calls, entrypoint)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`},
+		{&w.delModels, "DELETE FROM session_models WHERE session_id = ?"},
+		{&w.model, `INSERT INTO session_models
+			(session_id, model, api_calls, input_tokens, output_tokens,
+			 cache_creation_5m, cache_creation_1h, cache_read_tokens, estimated_cost, raw_names, turn_ms)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`},
+		{&w.delTiers, "DELETE FROM session_tiers WHERE session_id = ?"},
+		{&w.tier, `INSERT INTO session_tiers (session_id, tier, api_calls, estimated_cost)
+			VALUES (?, ?, ?, ?)`},
+	} {
+		stmt, err := tx.Prepare(st.query)
+		if err != nil {
+			w.close()
+			return nil, err
+		}
+		*st.dst = stmt
+	}
+	return w, nil
+}
+
+func (w *sessionWriter) close() {
+	for _, stmt := range []*sql.Stmt{w.session, w.delModels, w.model, w.delTiers, w.tier} {
+		if stmt != nil {
+			_ = stmt.Close()
+		}
+	}
+}
+
+// save writes a session row and replaces its model and tier breakdowns.
+func (w *sessionWriter) save(s model.SessionStats, mtimeNs, sizeBytes int64) error {
 	now := time.Now().UTC().Format(time.RFC3339)
 	startTime := ""
 	if !s.StartTime.IsZero() {
@@ -92,59 +282,89 @@ func (c *Cache) SaveSession(s model.SessionStats, mtimeNs, sizeBytes int64) erro
 		isSubagent = 1
 	}
 
-	_, err = tx.Exec(`INSERT OR REPLACE INTO sessions
-		(session_id, project, project_path, file_path, is_subagent, parent_session,
-		 start_time, end_time, duration_secs, user_messages, api_calls,
-		 input_tokens, output_tokens, cache_creation_5m, cache_creation_1h,
-		 cache_read_tokens, estimated_cost, cache_hit_rate, file_mtime_ns, file_size, parsed_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
-		s.SessionID, s.Project, s.ProjectPath, s.FilePath, isSubagent, s.ParentSession,
+	_, err := w.session.Exec(
+		s.SessionID, s.Project, s.ProjectPath, s.Repo, s.GitBranch, s.Source, s.FilePath, isSubagent, s.ParentSession,
 		startTime, endTime, s.DurationSecs, s.UserMessages, s.APICalls,
 		s.InputTokens, s.OutputTokens, s.CacheCreation5mTokens, s.CacheCreation1hTokens,
-		s.CacheReadTokens, s.EstimatedCost, s.CacheHitRate, mtimeNs, sizeBytes, now,
+		s.CacheReadTokens, s.EstimatedCost, s.CacheHitRate, mtimeNs, sizeBytes, now, s.FirstMessageID,
+		s.PeakContext, joinInts(s.ContextCurve), s.Compactions, s.ClaudeSecs, joinInts(s.IdleGaps), s.RepeatedCalls, s.Entrypoint,
 	)
 	if err != nil {
 		return err
 	}
 
-	// Delete old model entries for this session
-	_, err = tx.Exec("DELETE FROM session_models WHERE session_id = ?", s.SessionID)
-	if err != nil {
+	// Replace model entries for this session
+	if _, err := w.delModels.Exec(s.SessionID); err != nil {
 		return err
 	}
-
-	// Insert model entries
 	for modelName, mu := range s.Models {
-		_, err = tx.Exec(`INSERT INTO session_models
-			(session_id, model, api_calls, input_tokens, output_tokens,
-			 cache_creation_5m, cache_creation_1h, cache_read_tokens, estimated_cost)
-			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		_, err = w.model.Exec(
 			s.SessionID, modelName, mu.APICalls, mu.InputTokens, mu.OutputTokens,
 			mu.CacheCreation5mTokens, mu.CacheCreation1hTokens, mu.CacheReadTokens, mu.EstimatedCost,
+			strings.Join(mu.RawNames, ","), joinInts(mu.TurnDurationsMs),
 		)
 		if err != nil {
 			return err
 		}
 	}
 
-	// Update file tracker
-	_, err = tx.Exec(`INSERT OR REPLACE INTO file_tracker (file_path, mtime_ns, size_bytes)
-		VALUES (?, ?, ?)`, s.FilePath, mtimeNs, sizeBytes)
-	if err != nil {
+	if _, err := w.delTiers.Exec(s.SessionID); err != nil {
 		return err
 	}
+	for tier, tu := range s.Tiers {
+		if _, err := w.tier.Exec(s.SessionID, tier, tu.APICalls, tu.EstimatedCost); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// joinInts stores a list of integers, such as a context curve, as
+// comma-separated values.
+func joinInts(values []int64) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = strconv.FormatInt(v, 10)
+	}
+	return strings.Join(parts, ",")
+}
 
-	return tx.Commit()
+// splitInts reverses joinInts, skipping malformed values.
+func splitInts(s string) []int64 {
+	if s == "" {
+		return nil
+	}
+	parts := strings.Split(s, ",")
+	values := make([]int64, 0, len(parts))
+	for _, p := range parts {
+		if v, err := strconv.ParseInt(p, 10, 64); err == nil {
+			values = append(values, v)
+		}
+	}
+	return values
 }
 
 // LoadAllSessions reads all cached sessions from the database.
 func (c *Cache) LoadAllSessions() ([]model.SessionStats, error) {
+	return c.loadSessions("1 = 1")
+}
+
+// LoadSessionsBetween reads the cached sessions that started in [since, until).
+func (c *Cache) LoadSessionsBetween(since, until time.Time) ([]model.SessionStats, error) {
+	return c.loadSessions("start_time >= ? AND start_time < ? AND start_time != ''",
+		since.UTC().Format(time.RFC3339), until.UTC().Format(time.RFC3339))
+}
+
+// loadSessions reads the sessions matching where, with their model and tier
+// breakdowns. where is a condition on the sessions table.
+func (c *Cache) loadSessions(where string, args ...any) ([]model.SessionStats, error) {
 	rows, err := c.db.Query(`SELECT
-		session_id, project, project_path, file_path, is_subagent, parent_session,
+		session_id, project, project_path, repo, git_branch, source, file_path, is_subagent, parent_session,
 		start_time, end_time, duration_secs, user_messages, api_calls,
 		input_tokens, output_tokens, cache_creation_5m, cache_creation_1h,
-		cache_read_tokens, estimated_cost, cache_hit_rate
-		FROM sessions`)
+		cache_read_tokens, estimated_cost, cache_hit_rate, file_mtime_ns, first_message_id,
+		peak_context, context_curve, compactions, claude_secs, idle_gaps, repeated_calls, entrypoint
+		FROM sessions WHERE `+where, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -153,14 +373,17 @@ func (c *Cache) LoadAllSessions() ([]model.SessionStats, error) {
 	var sessions []model.SessionStats
 	for rows.Next() {
 		var s model.SessionStats
-		var startStr, endStr, parentSession, projectPath sql.NullString
+		var startStr, endStr, parentSession, projectPath, repo, gitBranch sql.NullString
 		var isSubagent int
+		var mtimeNs int64
+		var curve, gaps string
 
 		err := rows.Scan(
-			&s.SessionID, &s.Project, &projectPath, &s.FilePath, &isSubagent, &parentSession,
+			&s.SessionID, &s.Project, &projectPath, &repo, &gitBranch, &s.Source, &s.FilePath, &isSubagent, &parentSession,
 			&startStr, &endStr, &s.DurationSecs, &s.UserMessages, &s.APICalls,
 			&s.InputTokens, &s.OutputTokens, &s.CacheCreation5mTokens, &s.CacheCreation1hTokens,
-			&s.CacheReadTokens, &s.EstimatedCost, &s.CacheHitRate,
+			&s.CacheReadTokens, &s.EstimatedCost, &s.CacheHitRate, &mtimeNs, &s.FirstMessageID,
+			&s.PeakContext, &curve, &s.Compactions, &s.ClaudeSecs, &gaps, &s.RepeatedCalls, &s.Entrypoint,
 		)
 		if err != nil {
 			return nil, err
@@ -173,6 +396,13 @@ func (c *Cache) LoadAllSessions() ([]model.SessionStats, error) {
 		if projectPath.Valid {
 			s.ProjectPath = projectPath.String
 		}
+		s.Repo = repo.String
+		s.GitBranch = gitBranch.String
+		s.ContextCurve = splitInts(curve)
+		s.IdleGaps = splitInts(gaps)
+		if mtimeNs > 0 {
+			s.FileModTime = time.Unix(0, mtimeNs)
+		}
 		if startStr.Valid && startStr.String != "" {
 			s.StartTime, _ = time.Parse(time.RFC3339, startStr.String)
 		}
@@ -191,8 +421,8 @@ func (c *Cache) LoadAllSessions() ([]model.SessionStats, error) {
 	// Batch-load model data
 	modelRows, err := c.db.Query(`SELECT
 		session_id, model, api_calls, input_tokens, output_tokens,
-		cache_creation_5m, cache_creation_1h, cache_read_tokens, estimated_cost
-		FROM session_models`)
+		cache_creation_5m, cache_creation_1h, cache_read_tokens, estimated_cost, raw_names, turn_ms
+		FROM session_models WHERE session_id IN (SELECT session_id FROM sessions WHERE `+where+`)`, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -205,19 +435,47 @@ func (c *Cache) LoadAllSessions() ([]model.SessionStats, error) {
 	}
 
 	for modelRows.Next() {
-		var sid, modelName string
+		var sid, modelName, rawNames, turnMs string
 		var mu model.ModelUsage
 		err := modelRows.Scan(&sid, &modelName, &mu.APICalls, &mu.InputTokens, &mu.OutputTokens,
-			&mu.CacheCreation5mTokens, &mu.CacheCreation1hTokens, &mu.CacheReadTokens, &mu.EstimatedCost)
+			&mu.CacheCreation5mTokens, &mu.CacheCreation1hTokens, &mu.CacheReadTokens, &mu.EstimatedCost, &rawNames, &turnMs)
 		if err != nil {
 			return nil, err
 		}
+		if rawNames != "" {
+			mu.RawNames = strings.Split(rawNames, ",")
+		}
+		mu.TurnDurationsMs = splitInts(turnMs)
 		if idx, ok := sessionIdx[sid]; ok {
 			sessions[idx].Models[modelName] = &mu
 		}
 	}
+	if err := modelRows.Err(); err != nil {
+		return nil, err
+	}
 
-	return sessions, modelRows.Err()
+	tierRows, err := c.db.Query(`SELECT session_id, tier, api_calls, estimated_cost FROM session_tiers
+		WHERE session_id IN (SELECT session_id FROM sessions WHERE `+where+`)`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tierRows.Close() }()
+
+	for tierRows.Next() {
+		var sid, tier string
+		var tu model.TierUsage
+		if err := tierRows.Scan(&sid, &tier, &tu.APICalls, &tu.EstimatedCost); err != nil {
+			return nil, err
+		}
+		if idx, ok := sessionIdx[sid]; ok {
+			if sessions[idx].Tiers == nil {
+				sessions[idx].Tiers = make(map[string]*model.TierUsage)
+			}
+			sessions[idx].Tiers[tier] = &tu
+		}
+	}
+
+	return sessions, tierRows.Err()
 }
 
 // DeleteSession removes a session and its associated data.
@@ -238,3 +496,187 @@ func (c *Cache) SessionCount() (int, error) {
 	err := c.db.QueryRow("SELECT COUNT(*) FROM sessions").Scan(&count)
 	return count, err
 }
+
+// ScanDay is cburn's own scan/parse overhead for one local calendar day.
+type ScanDay struct {
+	Day         string // "2006-01-02"
+	Scans       int
+	FilesParsed int
+	Duration    time.Duration
+}
+
+// scanLogRetentionDays bounds how much scan history is kept.
+const scanLogRetentionDays = 90
+
+// RecordScan adds one cache-assisted load to the day's overhead totals.
+func (c *Cache) RecordScan(at time.Time, d time.Duration, filesParsed int) error {
+	day := at.Local().Format("2006-01-02")
+	_, err := c.db.Exec(`
+		INSERT INTO scan_log (day, scans, files_parsed, duration_ms) VALUES (?, 1, ?, ?)
+		ON CONFLICT(day) DO UPDATE SET
+			scans = scans + 1,
+			files_parsed = files_parsed + excluded.files_parsed,
+			duration_ms = duration_ms + excluded.duration_ms`,
+		day, filesParsed, d.Milliseconds())
+	if err != nil {
+		return err
+	}
+	cutoff := at.Local().AddDate(0, 0, -scanLogRetentionDays).Format("2006-01-02")
+	_, err = c.db.Exec("DELETE FROM scan_log WHERE day < ?", cutoff)
+	return err
+}
+
+// ScanHistory returns per-day overhead for days on or after since, newest first.
+func (c *Cache) ScanHistory(since time.Time) ([]ScanDay, error) {
+	rows, err := c.db.Query(
+		"SELECT day, scans, files_parsed, duration_ms FROM scan_log WHERE day >= ? ORDER BY day DESC",
+		since.Local().Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var out []ScanDay
+	for rows.Next() {
+		var d ScanDay
+		var ms int64
+		if err := rows.Scan(&d.Day, &d.Scans, &d.FilesParsed, &ms); err != nil {
+			return nil, err
+		}
+		d.Duration = time.Duration(ms) * time.Millisecond
+		out = append(out, d)
+	}
+	return out, rows.Err()
+}
+
+// DiskUsage returns the on-disk size of the database at dbPath, including
+// its WAL and shared-memory files.
+func DiskUsage(dbPath string) int64 {
+	var total int64
+	for _, p := range []string{dbPath, dbPath + "-wal", dbPath + "-shm"} {
+		if info, err := os.Stat(p); err == nil {
+			total += info.Size()
+		}
+	}
+	return total
+}
+
+// ParseIssue is a session file that failed to read, had unparseable lines,
+// or had implausible timestamps on its last parse. Quarantined files are
+// skipped by cached loads.
+type ParseIssue struct {
+	FilePath         string
+	ParseErrors      int    // malformed lines skipped
+	SkewedTimestamps int    // timestamps dropped as implausible
+	Err              string // read error; empty if the file was read
+	SeenAt           time.Time
+	Quarantined      bool
+}
+
+// RecordParseIssue records the outcome of a problematic parse, keeping the
+// file's quarantine state.
+func (c *Cache) RecordParseIssue(pi ParseIssue) error {
+	_, err := c.db.Exec(`
+		INSERT INTO parse_issues (file_path, parse_errors, skewed_timestamps, error, seen_at) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(file_path) DO UPDATE SET
+			parse_errors = excluded.parse_errors,
+			skewed_timestamps = excluded.skewed_timestamps,
+			error = excluded.error,
+			seen_at = excluded.seen_at`,
+		pi.FilePath, pi.ParseErrors, pi.SkewedTimestamps, pi.Err, pi.SeenAt.UTC().Format(time.RFC3339))
+	return err
+}
+
+// ClearParseIssues forgets the parse issues of files that parsed cleanly,
+// in one transaction.
+func (c *Cache) ClearParseIssues(filePaths []string) error {
+	if len(filePaths) == 0 {
+		return nil
+	}
+	tx, err := c.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	stmt, err := tx.Prepare("DELETE FROM parse_issues WHERE file_path = ? AND quarantined = 0")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = stmt.Close() }()
+	for _, p := range filePaths {
+		if _, err := stmt.Exec(p); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// ParseIssues returns every recorded issue, quarantined files first, then
+// by number of bad lines.
+func (c *Cache) ParseIssues() ([]ParseIssue, error) {
+	rows, err := c.db.Query(`
+		SELECT file_path, parse_errors, skewed_timestamps, error, seen_at, quarantined FROM parse_issues
+		ORDER BY quarantined DESC, parse_errors DESC, file_path`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var out []ParseIssue
+	for rows.Next() {
+		var pi ParseIssue
+		var seen string
+		if err := rows.Scan(&pi.FilePath, &pi.ParseErrors, &pi.SkewedTimestamps, &pi.Err, &seen, &pi.Quarantined); err != nil {
+			return nil, err
+		}
+		pi.SeenAt, _ = time.Parse(time.RFC3339, seen)
+		out = append(out, pi)
+	}
+	return out, rows.Err()
+}
+
+// QuarantinedFiles returns the paths of quarantined files.
+func (c *Cache) QuarantinedFiles() (map[string]struct{}, error) {
+	rows, err := c.db.Query("SELECT file_path FROM parse_issues WHERE quarantined = 1")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	out := make(map[string]struct{})
+	for rows.Next() {
+		var p string
+		if err := rows.Scan(&p); err != nil {
+			return nil, err
+		}
+		out[p] = struct{}{}
+	}
+	return out, rows.Err()
+}
+
+// Quarantine stops cached loads from reading filePath until it is released.
+func (c *Cache) Quarantine(filePath string, at time.Time) error {
+	_, err := c.db.Exec(`
+		INSERT INTO parse_issues (file_path, parse_errors, seen_at, quarantined) VALUES (?, 0, ?, 1)
+		ON CONFLICT(file_path) DO UPDATE SET quarantined = 1`,
+		filePath, at.UTC().Format(time.RFC3339))
+	return err
+}
+
+// Release lifts a quarantine so the file is parsed again on the next load.
+// It reports whether filePath was quarantined.
+func (c *Cache) Release(filePath string) (bool, error) {
+	res, err := c.db.Exec("DELETE FROM parse_issues WHERE file_path = ? AND quarantined = 1", filePath)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if n > 0 {
+		err = c.DeleteFileTracker(filePath)
+	}
+	return n > 0, err
+}
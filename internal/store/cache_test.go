@@ -0,0 +1,103 @@
+package store
+
+import (
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+func TestSaveSessions(t *testing.T) {
+	c, err := Open(filepath.Join(t.TempDir(), "cache.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = c.Close() }()
+
+	start := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+	batch := []SessionWrite{
+		{Session: model.SessionStats{
+			SessionID: "s1", Project: "app", FilePath: "/data/s1.jsonl",
+			StartTime: start, EndTime: start.Add(time.Hour), APICalls: 3, EstimatedCost: 5.1,
+			Models: map[string]*model.ModelUsage{
+				"claude-opus-4-6":  {APICalls: 2, InputTokens: 100, OutputTokens: 50, EstimatedCost: 5},
+				"claude-haiku-4-5": {APICalls: 1, InputTokens: 10, CacheReadTokens: 7, EstimatedCost: 0.1},
+			},
+			Tiers: map[string]*model.TierUsage{
+				"standard": {APICalls: 2, EstimatedCost: 5},
+				"batch":    {APICalls: 1, EstimatedCost: 0.1},
+			},
+		}, MtimeNs: 11, SizeBytes: 110},
+		{Session: model.SessionStats{
+			SessionID: "s2", Project: "api", FilePath: "/data/s2.jsonl",
+			StartTime: start.Add(2 * time.Hour), EndTime: start.Add(3 * time.Hour), APICalls: 1, EstimatedCost: 2,
+			Models: map[string]*model.ModelUsage{"claude-sonnet-4-6": {APICalls: 1, OutputTokens: 20, EstimatedCost: 2}},
+			Tiers:  map[string]*model.TierUsage{"standard": {APICalls: 1, EstimatedCost: 2}},
+		}, MtimeNs: 22, SizeBytes: 220},
+	}
+	if err := c.SaveSessions(batch); err != nil {
+		t.Fatal(err)
+	}
+
+	tracked, err := c.GetTrackedFiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tracked) != 2 || tracked["/data/s1.jsonl"] != (FileInfo{11, 110}) || tracked["/data/s2.jsonl"] != (FileInfo{22, 220}) {
+		t.Errorf("tracked files = %+v", tracked)
+	}
+
+	sessions, err := c.LoadAllSessions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("sessions = %d, want 2", len(sessions))
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].SessionID < sessions[j].SessionID })
+	s1, s2 := sessions[0], sessions[1]
+	if s1.Project != "app" || !s1.StartTime.Equal(start) || s1.APICalls != 3 || s1.EstimatedCost != 5.1 {
+		t.Errorf("s1 = %+v", s1)
+	}
+	if len(s1.Models) != 2 {
+		t.Fatalf("s1 models = %v, want 2", s1.Models)
+	}
+	if mu := s1.Models["claude-opus-4-6"]; mu == nil || mu.APICalls != 2 || mu.InputTokens != 100 || mu.OutputTokens != 50 || mu.EstimatedCost != 5 {
+		t.Errorf("s1 opus = %+v", mu)
+	}
+	if mu := s1.Models["claude-haiku-4-5"]; mu == nil || mu.CacheReadTokens != 7 || mu.EstimatedCost != 0.1 {
+		t.Errorf("s1 haiku = %+v", mu)
+	}
+	if tu := s1.Tiers["batch"]; len(s1.Tiers) != 2 || tu == nil || tu.APICalls != 1 || tu.EstimatedCost != 0.1 {
+		t.Errorf("s1 tiers = %v", s1.Tiers)
+	}
+	if mu := s2.Models["claude-sonnet-4-6"]; len(s2.Models) != 1 || mu == nil || mu.OutputTokens != 20 {
+		t.Errorf("s2 models = %v", s2.Models)
+	}
+	if len(s2.Tiers) != 1 || s2.Tiers["standard"] == nil {
+		t.Errorf("s2 tiers = %v", s2.Tiers)
+	}
+
+	// Saving a session again replaces its models and tiers rather than
+	// adding to them.
+	s := batch[0].Session
+	s.Models = map[string]*model.ModelUsage{"claude-opus-4-6": {APICalls: 1, EstimatedCost: 1}}
+	s.Tiers = map[string]*model.TierUsage{"standard": {APICalls: 1, EstimatedCost: 1}}
+	if err := c.SaveSessions([]SessionWrite{{Session: s, MtimeNs: 33, SizeBytes: 330}}); err != nil {
+		t.Fatal(err)
+	}
+	sessions, err = c.LoadAllSessions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, got := range sessions {
+		if got.SessionID == "s1" && (len(got.Models) != 1 || len(got.Tiers) != 1) {
+			t.Errorf("resaved s1: models %v, tiers %v", got.Models, got.Tiers)
+		}
+	}
+	if tracked, _ := c.GetTrackedFiles(); tracked["/data/s1.jsonl"] != (FileInfo{33, 330}) {
+		t.Errorf("resaved s1 tracked as %+v", tracked["/data/s1.jsonl"])
+	}
+}
@@ -0,0 +1,83 @@
+package store
+
+import "time"
+
+// DuplicateFile is a session file skipped as a copy of the session held by
+// KeptPath. MtimeNs and SizeBytes are the file's state when it was skipped.
+type DuplicateFile struct {
+	FilePath  string
+	SessionID string
+	KeptPath  string
+	SeenAt    time.Time
+	MtimeNs   int64
+	SizeBytes int64
+}
+
+// DuplicateFiles returns every recorded duplicate, grouped by kept file.
+func (c *Cache) DuplicateFiles() ([]DuplicateFile, error) {
+	rows, err := c.db.Query(`SELECT file_path, session_id, kept_path, seen_at
+		FROM duplicate_files ORDER BY kept_path, file_path`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var out []DuplicateFile
+	for rows.Next() {
+		var d DuplicateFile
+		var seenAt string
+		if err := rows.Scan(&d.FilePath, &d.SessionID, &d.KeptPath, &seenAt); err != nil {
+			return nil, err
+		}
+		d.SeenAt, _ = time.Parse(time.RFC3339, seenAt)
+		out = append(out, d)
+	}
+	return out, rows.Err()
+}
+
+// RecordDuplicates forgets the duplicate records of the cleared files, then
+// stores dups, in one transaction. Each duplicate's file is tracked so it is
+// not reparsed until it, or the file it duplicates, changes.
+func (c *Cache) RecordDuplicates(cleared []string, dups []DuplicateFile) error {
+	if len(cleared) == 0 && len(dups) == 0 {
+		return nil
+	}
+	tx, err := c.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	del, err := tx.Prepare("DELETE FROM duplicate_files WHERE file_path = ?")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = del.Close() }()
+	for _, p := range cleared {
+		if _, err := del.Exec(p); err != nil {
+			return err
+		}
+	}
+
+	ins, err := tx.Prepare(`INSERT OR REPLACE INTO duplicate_files (file_path, session_id, kept_path, seen_at)
+		VALUES (?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = ins.Close() }()
+	track, err := tx.Prepare(`INSERT OR REPLACE INTO file_tracker (file_path, mtime_ns, size_bytes)
+		VALUES (?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = track.Close() }()
+	for _, d := range dups {
+		if _, err := ins.Exec(d.FilePath, d.SessionID, d.KeptPath, d.SeenAt.UTC().Format(time.RFC3339)); err != nil {
+			return err
+		}
+		if _, err := track.Exec(d.FilePath, d.MtimeNs, d.SizeBytes); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
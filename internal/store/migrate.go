@@ -0,0 +1,254 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// A migration upgrades the cache schema by one version: the migration at
+// index i takes a cache from version i to i+1. schemaSQL always creates the
+// latest schema and fills in missing tables, so a migration only has to add
+// columns to tables that already exist. Append new migrations at the end
+// and never reorder them.
+//
+// Caches written before schema_version existed are treated as version 0.
+// They may be at any point in this history, so each step checks for the
+// columns it adds and does nothing when they are present.
+type migration struct {
+	name    string
+	table   string   // a table added by this step, created by schemaSQL
+	columns []column // columns added to existing tables
+
+	// reparse forgets tracked files so sessions cached without the new
+	// data are parsed again; resummarize drops the pre-aggregated summary.
+	reparse, resummarize bool
+}
+
+// column is a column added by a migration, with its SQL definition.
+type column struct {
+	table, name, def string
+}
+
+var migrations = []migration{
+	{ // Sessions cached before then were priced without tier multipliers.
+		name:    "per-tier costs",
+		table:   "session_tiers",
+		reparse: true,
+	},
+	{
+		name:    "raw model IDs",
+		columns: []column{{"session_models", "raw_names", "TEXT NOT NULL DEFAULT ''"}},
+		reparse: true,
+	},
+	{ // Sessions parsed before implausible timestamps were dropped may sit
+		// on the wrong day.
+		name:    "skewed timestamp counts",
+		columns: []column{{"parse_issues", "skewed_timestamps", "INTEGER NOT NULL DEFAULT 0"}},
+		reparse: true,
+	},
+	{ // Tells copies of one session apart.
+		name:    "first message IDs",
+		columns: []column{{"sessions", "first_message_id", "TEXT NOT NULL DEFAULT ''"}},
+		reparse: true,
+	},
+	{
+		name: "context sizes",
+		columns: []column{
+			{"sessions", "peak_context", "INTEGER NOT NULL DEFAULT 0"},
+			{"sessions", "context_curve", "TEXT NOT NULL DEFAULT ''"},
+		},
+		reparse: true,
+	},
+	{
+		name:    "compaction counts",
+		columns: []column{{"sessions", "compactions", "INTEGER NOT NULL DEFAULT 0"}},
+		reparse: true,
+	},
+	{
+		name:    "turn durations per model",
+		columns: []column{{"session_models", "turn_ms", "TEXT NOT NULL DEFAULT ''"}},
+		reparse: true,
+	},
+	{
+		name: "Claude time and idle gaps",
+		columns: []column{
+			{"sessions", "claude_secs", "INTEGER NOT NULL DEFAULT 0"},
+			{"sessions", "idle_gaps", "TEXT NOT NULL DEFAULT ''"},
+		},
+		reparse: true,
+	},
+	{
+		name: "active time in the summary cache",
+		columns: []column{
+			{"summary_cache", "active_secs", "INTEGER NOT NULL DEFAULT 0"},
+			{"summary_cache", "claude_secs", "INTEGER NOT NULL DEFAULT 0"},
+		},
+		resummarize: true,
+	},
+	{
+		name:    "repeated call runs",
+		columns: []column{{"sessions", "repeated_calls", "INTEGER NOT NULL DEFAULT 0"}},
+		reparse: true,
+	},
+	{
+		name:    "session entrypoint",
+		columns: []column{{"sessions", "entrypoint", "TEXT NOT NULL DEFAULT ''"}},
+		reparse: true,
+	},
+	{ // Adds nothing itself; schemaSQL recreates the views on any upgrade.
+		name: "daily_costs and model_costs views",
+	},
+}
+
+// SchemaVersion is the cache schema version this build writes.
+var SchemaVersion = len(migrations)
+
+// errNewerSchema is returned by migrate for a cache written by a newer
+// cburn, whose schema this build can't know.
+type errNewerSchema struct{ version int }
+
+func (e errNewerSchema) Error() string {
+	return fmt.Sprintf("cache schema version %d is newer than this cburn's %d", e.version, SchemaVersion)
+}
+
+// migrationError is returned by migrate when a step fails.
+type migrationError struct {
+	step string
+	err  error
+}
+
+func (e *migrationError) Error() string {
+	return fmt.Sprintf("migrating cache (%s): %v", e.step, e.err)
+}
+func (e *migrationError) Unwrap() error { return e.err }
+
+// rebuildable reports whether err means a migration failed, so the cache
+// can only be set aside and rebuilt. A cache from a newer cburn is not
+// rebuildable: the newer binary still uses it, and an older one resetting
+// it would have the two wipe each other's cache in turn.
+func rebuildable(err error) bool {
+	var failed *migrationError
+	return errors.As(err, &failed)
+}
+
+// migrate brings db to the latest schema: a new cache gets schemaSQL, an
+// older one the migrations after its version, in one transaction.
+func migrate(db *sql.DB) error {
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_version (version INTEGER NOT NULL)`); err != nil {
+		return fmt.Errorf("creating schema_version: %w", err)
+	}
+	version := 0
+	if err := db.QueryRow(`SELECT version FROM schema_version`).Scan(&version); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("reading schema version: %w", err)
+	}
+	if version > SchemaVersion {
+		return errNewerSchema{version}
+	}
+	if version == SchemaVersion {
+		return nil
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	fresh, err := missingTable(tx, "sessions")
+	if err != nil {
+		return err
+	}
+	var reparse, resummarize bool
+	if !fresh {
+		for _, m := range migrations[version:] {
+			changed, err := m.apply(tx)
+			if err != nil {
+				return &migrationError{m.name, err}
+			}
+			reparse = reparse || changed && m.reparse
+			resummarize = resummarize || changed && m.resummarize
+		}
+	}
+
+	if _, err := tx.Exec(schemaSQL); err != nil {
+		return fmt.Errorf("creating schema: %w", err)
+	}
+	if reparse {
+		if _, err := tx.Exec(`DELETE FROM file_tracker`); err != nil {
+			return fmt.Errorf("resetting file tracker: %w", err)
+		}
+	}
+	if resummarize {
+		if _, err := tx.Exec(`DELETE FROM summary_meta`); err != nil {
+			return fmt.Errorf("resetting summary cache: %w", err)
+		}
+	}
+	if _, err := tx.Exec(`DELETE FROM schema_version`); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`INSERT INTO schema_version (version) VALUES (?)`, SchemaVersion); err != nil {
+		return fmt.Errorf("recording schema version: %w", err)
+	}
+	return tx.Commit()
+}
+
+// apply adds the step's columns, reporting whether the cache lacked any of
+// them or its table. A missing table counts as changed but is left for
+// schemaSQL to create whole.
+func (m migration) apply(tx *sql.Tx) (changed bool, err error) {
+	if m.table != "" {
+		if changed, err = missingTable(tx, m.table); err != nil {
+			return false, err
+		}
+	}
+	for _, c := range m.columns {
+		if noTable, err := missingTable(tx, c.table); err != nil {
+			return false, err
+		} else if noTable {
+			changed = true
+			continue
+		}
+		var n int
+		if err := tx.QueryRow(`SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?`, c.table, c.name).Scan(&n); err != nil {
+			return false, err
+		}
+		if n > 0 {
+			continue
+		}
+		if _, err := tx.Exec(fmt.Sprintf(`ALTER TABLE %s ADD COLUMN %s %s`, c.table, c.name, c.def)); err != nil {
+			return false, err
+		}
+		changed = true
+	}
+	return changed, nil
+}
+
+func missingTable(tx *sql.Tx, name string) (bool, error) {
+	var n int
+	err := tx.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?`, name).Scan(&n)
+	return n == 0, err
+}
+
+// setAside moves a cache that can't be migrated, with its WAL files, to
+// the same names plus ".bak", so Open can start a new one without losing
+// imported sources and notes for good. An earlier backup is never
+// overwritten; later ones get ".bak.1", ".bak.2", and so on.
+func setAside(dbPath string) error {
+	bak := dbPath + ".bak"
+	for i := 1; exists(bak); i++ {
+		bak = fmt.Sprintf("%s.bak.%d", dbPath, i)
+	}
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if err := os.Rename(dbPath+suffix, bak+suffix); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return os.Rename(dbPath, bak)
+}
+
+func exists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
@@ -0,0 +1,182 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+// seedCache opens a new cache at path holding one session, its tracked
+// file, and a built summary.
+func seedCache(t *testing.T, path string) *Cache {
+	t.Helper()
+	c, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := model.SessionStats{
+		SessionID: "s1",
+		Project:   "app",
+		FilePath:  "/data/s1.jsonl",
+		StartTime: time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2025, 6, 1, 11, 0, 0, 0, time.UTC),
+	}
+	if err := c.SaveSession(s, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.ReplaceSummary("fp", "UTC", []SummaryRow{{Day: "2025-06-01", Project: "app", Sessions: 1}}); err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func schemaVersionOf(t *testing.T, c *Cache) int {
+	t.Helper()
+	var v int
+	if err := c.db.QueryRow(`SELECT version FROM schema_version`).Scan(&v); err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestMigrate_Fresh(t *testing.T) {
+	c := seedCache(t, filepath.Join(t.TempDir(), "cache.db"))
+	defer func() { _ = c.Close() }()
+	if v := schemaVersionOf(t, c); v != SchemaVersion {
+		t.Errorf("version = %d, want %d", v, SchemaVersion)
+	}
+}
+
+// TestMigrate_Steps rolls a current cache back to just before each step,
+// by dropping what the step adds, and checks reopening redoes it.
+func TestMigrate_Steps(t *testing.T) {
+	for i, m := range migrations {
+		t.Run(m.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "cache.db")
+			c := seedCache(t, path)
+			var stmts []string
+			if m.table != "" {
+				stmts = append(stmts, "DROP TABLE "+m.table)
+			}
+			for _, col := range m.columns {
+				stmts = append(stmts, "ALTER TABLE "+col.table+" DROP COLUMN "+col.name)
+			}
+			for _, stmt := range stmts {
+				if _, err := c.db.Exec(stmt); err != nil {
+					t.Fatalf("%s: %v", stmt, err)
+				}
+			}
+			if _, err := c.db.Exec(`UPDATE schema_version SET version = ?`, i); err != nil {
+				t.Fatal(err)
+			}
+			_ = c.Close()
+
+			c, err := Open(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer func() { _ = c.Close() }()
+
+			if v := schemaVersionOf(t, c); v != SchemaVersion {
+				t.Errorf("version = %d, want %d", v, SchemaVersion)
+			}
+			tx, err := c.db.Begin()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if changed, err := m.apply(tx); err != nil || changed {
+				t.Errorf("step still pending after migrating: changed=%v err=%v", changed, err)
+			}
+			_ = tx.Rollback()
+
+			tracked, _ := c.GetTrackedFiles()
+			if m.reparse != (len(tracked) == 0) {
+				t.Errorf("tracked files = %d, reparse = %v", len(tracked), m.reparse)
+			}
+			fp, _, _ := c.SummaryMeta()
+			if m.resummarize != (fp == "") {
+				t.Errorf("summary fingerprint = %q, resummarize = %v", fp, m.resummarize)
+			}
+			if n, _ := c.SessionCount(); n != 1 {
+				t.Errorf("sessions = %d, want 1 kept", n)
+			}
+		})
+	}
+}
+
+func TestMigrate_UnversionedCurrentCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.db")
+	c := seedCache(t, path)
+	if _, err := c.db.Exec(`DROP TABLE schema_version`); err != nil {
+		t.Fatal(err)
+	}
+	_ = c.Close()
+
+	c, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = c.Close() }()
+	if tracked, _ := c.GetTrackedFiles(); len(tracked) != 1 {
+		t.Errorf("up-to-date cache was reset: %d tracked files", len(tracked))
+	}
+	if v := schemaVersionOf(t, c); v != SchemaVersion {
+		t.Errorf("version = %d, want %d", v, SchemaVersion)
+	}
+}
+
+func TestMigrate_NewerSchemaIsKept(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.db")
+	c := seedCache(t, path)
+	if _, err := c.db.Exec(`UPDATE schema_version SET version = ?`, SchemaVersion+1); err != nil {
+		t.Fatal(err)
+	}
+	_ = c.Close()
+
+	if c, err := Open(path); err == nil {
+		_ = c.Close()
+		t.Fatal("Open succeeded on a newer cache")
+	}
+	if _, err := os.Stat(path + ".bak"); !os.IsNotExist(err) {
+		t.Errorf("newer cache was set aside: %v", err)
+	}
+
+	// The newer cburn still finds its sessions.
+	SchemaVersion++
+	defer func() { SchemaVersion-- }()
+	c, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = c.Close() }()
+	if n, _ := c.SessionCount(); n != 1 {
+		t.Errorf("sessions = %d, want 1 kept", n)
+	}
+}
+
+func TestSetAside_KeepsEarlierBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.db")
+	for _, name := range []string{path + ".bak", path, path + "-wal"} {
+		if err := os.WriteFile(name, []byte(filepath.Base(name)), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := setAside(path); err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range map[string]string{
+		path + ".bak":       "cache.db.bak",
+		path + ".bak.1":     "cache.db",
+		path + ".bak.1-wal": "cache.db-wal",
+	} {
+		if got, err := os.ReadFile(name); err != nil || string(got) != want {
+			t.Errorf("%s = %q, %v; want %q", filepath.Base(name), got, err, want)
+		}
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("cache still in place: %v", err)
+	}
+}
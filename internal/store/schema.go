@@ -5,6 +5,10 @@ CREATE TABLE IF NOT EXISTS sessions (
     session_id           TEXT PRIMARY KEY,
     project              TEXT NOT NULL,
     project_path         TEXT,
+    repo                 TEXT,
+    git_branch           TEXT,
+    entrypoint           TEXT NOT NULL DEFAULT '',
+    source               TEXT NOT NULL DEFAULT '',
     file_path            TEXT NOT NULL,
     is_subagent          INTEGER NOT NULL DEFAULT 0,
     parent_session       TEXT,
@@ -22,7 +26,14 @@ CREATE TABLE IF NOT EXISTS sessions (
     cache_hit_rate       REAL,
     file_mtime_ns        INTEGER NOT NULL,
     file_size            INTEGER NOT NULL,
-    parsed_at            TEXT NOT NULL
+    parsed_at            TEXT NOT NULL,
+    first_message_id     TEXT NOT NULL DEFAULT '',
+    peak_context         INTEGER NOT NULL DEFAULT 0,
+    context_curve        TEXT NOT NULL DEFAULT '',
+    compactions          INTEGER NOT NULL DEFAULT 0,
+    claude_secs          INTEGER NOT NULL DEFAULT 0,
+    idle_gaps            TEXT NOT NULL DEFAULT '',
+    repeated_calls       INTEGER NOT NULL DEFAULT 0
 );
 
 CREATE TABLE IF NOT EXISTS session_models (
@@ -35,15 +46,134 @@ CREATE TABLE IF NOT EXISTS session_models (
     cache_creation_1h    INTEGER,
     cache_read_tokens    INTEGER,
     estimated_cost       REAL,
+    raw_names            TEXT NOT NULL DEFAULT '',
+    turn_ms              TEXT NOT NULL DEFAULT '',
     PRIMARY KEY (session_id, model)
 );
 
+CREATE TABLE IF NOT EXISTS session_tiers (
+    session_id           TEXT NOT NULL REFERENCES sessions(session_id) ON DELETE CASCADE,
+    tier                 TEXT NOT NULL,
+    api_calls            INTEGER,
+    estimated_cost       REAL,
+    PRIMARY KEY (session_id, tier)
+);
+
 CREATE TABLE IF NOT EXISTS file_tracker (
     file_path            TEXT PRIMARY KEY,
     mtime_ns             INTEGER NOT NULL,
     size_bytes           INTEGER NOT NULL
 );
 
+CREATE TABLE IF NOT EXISTS scan_log (
+    day                  TEXT PRIMARY KEY,
+    scans                INTEGER NOT NULL,
+    files_parsed         INTEGER NOT NULL,
+    duration_ms          INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS parse_issues (
+    file_path            TEXT PRIMARY KEY,
+    parse_errors         INTEGER NOT NULL,
+    skewed_timestamps    INTEGER NOT NULL DEFAULT 0,
+    error                TEXT NOT NULL DEFAULT '',
+    seen_at              TEXT NOT NULL,
+    quarantined          INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS summary_cache (
+    day                  TEXT NOT NULL,
+    project              TEXT NOT NULL,
+    source               TEXT NOT NULL,
+    model                TEXT NOT NULL,
+    sessions             INTEGER NOT NULL,
+    prompts              INTEGER NOT NULL,
+    api_calls            INTEGER NOT NULL,
+    duration_secs        INTEGER NOT NULL,
+    input_tokens         INTEGER NOT NULL,
+    output_tokens        INTEGER NOT NULL,
+    cache_creation_5m    INTEGER NOT NULL,
+    cache_creation_1h    INTEGER NOT NULL,
+    cache_read_tokens    INTEGER NOT NULL,
+    estimated_cost       REAL NOT NULL,
+    cache_savings        REAL NOT NULL,
+    active_secs          INTEGER NOT NULL DEFAULT 0,
+    claude_secs          INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (day, project, source, model)
+);
+
+CREATE TABLE IF NOT EXISTS summary_meta (
+    id                   INTEGER PRIMARY KEY CHECK (id = 1),
+    fingerprint          TEXT NOT NULL,
+    zone                 TEXT NOT NULL,
+    built_at             TEXT NOT NULL
+);
+
+-- User notes and tags. Kept apart from sessions so reparsing a file, which
+-- rewrites its session row, leaves them alone.
+CREATE TABLE IF NOT EXISTS session_annotations (
+    session_id           TEXT PRIMARY KEY,
+    note                 TEXT NOT NULL DEFAULT '',
+    tags                 TEXT NOT NULL DEFAULT '',
+    updated_at           TEXT NOT NULL
+);
+
+-- Sessions the user hid from every total.
+CREATE TABLE IF NOT EXISTS excluded_sessions (
+    session_id           TEXT PRIMARY KEY,
+    excluded_at          TEXT NOT NULL
+);
+
+-- Files skipped as copies of a session held by another file (kept_path),
+-- e.g. when ~/.claude is synced between machines.
+CREATE TABLE IF NOT EXISTS duplicate_files (
+    file_path            TEXT PRIMARY KEY,
+    session_id           TEXT NOT NULL,
+    kept_path            TEXT NOT NULL,
+    seen_at              TEXT NOT NULL
+);
+
 CREATE INDEX IF NOT EXISTS idx_sessions_start ON sessions(start_time);
 CREATE INDEX IF NOT EXISTS idx_sessions_project ON sessions(project);
+CREATE INDEX IF NOT EXISTS idx_sessions_source ON sessions(source);
+
+-- Views for reading the cache with sqlite3, DuckDB, or cburn sql. Their
+-- columns are kept stable across schema versions: new columns may be
+-- added, existing ones are not renamed or dropped. Hidden sessions are
+-- left out and subagents count as sessions of their own, as in cburn
+-- daily. Days are UTC, so every reader of the file sees the same rows
+-- whatever its zone; callers bucketing by another zone group sessions by
+-- a shifted start_time instead.
+DROP VIEW IF EXISTS daily_costs;
+CREATE VIEW daily_costs AS
+SELECT date(start_time)                             AS day_utc,
+       COUNT(*)                                     AS sessions,
+       SUM(user_messages)                           AS prompts,
+       SUM(api_calls)                               AS api_calls,
+       SUM(input_tokens)                            AS input_tokens,
+       SUM(output_tokens)                           AS output_tokens,
+       SUM(cache_creation_5m + cache_creation_1h)   AS cache_write_tokens,
+       SUM(cache_read_tokens)                       AS cache_read_tokens,
+       SUM(estimated_cost)                          AS estimated_cost
+FROM sessions
+WHERE COALESCE(start_time, '') != ''
+  AND session_id NOT IN (SELECT session_id FROM excluded_sessions)
+GROUP BY day_utc;
+
+DROP VIEW IF EXISTS model_costs;
+CREATE VIEW model_costs AS
+SELECT date(s.start_time)                               AS day_utc,
+       m.model                                          AS model,
+       COUNT(*)                                         AS sessions,
+       SUM(m.api_calls)                                 AS api_calls,
+       SUM(m.input_tokens)                              AS input_tokens,
+       SUM(m.output_tokens)                             AS output_tokens,
+       SUM(m.cache_creation_5m + m.cache_creation_1h)   AS cache_write_tokens,
+       SUM(m.cache_read_tokens)                         AS cache_read_tokens,
+       SUM(m.estimated_cost)                            AS estimated_cost
+FROM session_models m
+JOIN sessions s ON s.session_id = m.session_id
+WHERE COALESCE(s.start_time, '') != ''
+  AND s.session_id NOT IN (SELECT session_id FROM excluded_sessions)
+GROUP BY day_utc, m.model;
 `
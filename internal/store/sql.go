@@ -0,0 +1,50 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+)
+
+// QueryResult is the output of Query: column names and one value per
+// column for each row. Values are int64, float64, string, []byte, or nil.
+type QueryResult struct {
+	Columns []string
+	Rows    [][]any
+}
+
+// Query runs an analyst's SQL against the cache at dbPath, which Open must
+// have created. The database is opened read-only with query_only set, so a
+// statement that would write fails instead of touching the cache.
+func Query(dbPath, query string) (*QueryResult, error) {
+	u := url.URL{Scheme: "file", Path: dbPath, RawQuery: "mode=ro&_pragma=query_only(1)"}
+	db, err := sql.Open("sqlite", u.String())
+	if err != nil {
+		return nil, fmt.Errorf("opening cache db: %w", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	res := &QueryResult{Columns: cols}
+	for rows.Next() {
+		vals := make([]any, len(cols))
+		ptrs := make([]any, len(cols))
+		for i := range vals {
+			ptrs[i] = &vals[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, err
+		}
+		res.Rows = append(res.Rows, vals)
+	}
+	return res, rows.Err()
+}
@@ -0,0 +1,105 @@
+package store
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+func TestQuery_Views(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.db")
+	c, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Evening in New York is the next day in UTC.
+	day := time.Date(2025, 6, 1, 21, 0, 0, 0, time.FixedZone("EDT", -4*3600))
+	session := func(id string, start time.Time, models map[string]float64) SessionWrite {
+		s := model.SessionStats{SessionID: id, Project: "app", FilePath: "/data/" + id + ".jsonl",
+			StartTime: start, EndTime: start.Add(time.Hour), UserMessages: 2, Models: map[string]*model.ModelUsage{}}
+		for name, cost := range models {
+			s.Models[name] = &model.ModelUsage{APICalls: 1, InputTokens: 10, EstimatedCost: cost}
+			s.APICalls++
+			s.InputTokens += 10
+			s.EstimatedCost += cost
+		}
+		return SessionWrite{Session: s, MtimeNs: 1, SizeBytes: 1}
+	}
+	if err := c.SaveSessions([]SessionWrite{
+		session("a", day, map[string]float64{"claude-opus-4-6": 3, "claude-haiku-4-5": 0.5}),
+		session("b", day.Add(time.Hour), map[string]float64{"claude-opus-4-6": 1}),
+		session("c", day.AddDate(0, 0, 1), map[string]float64{"claude-sonnet-4-6": 2}),
+		session("hidden", day, map[string]float64{"claude-opus-4-6": 100}),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SetExcluded("hidden", true); err != nil {
+		t.Fatal(err)
+	}
+	_ = c.Close()
+
+	rows := func(query string) string {
+		t.Helper()
+		res, err := Query(path, query)
+		if err != nil {
+			t.Fatalf("%s: %v", query, err)
+		}
+		return fmt.Sprint(res.Rows)
+	}
+	if got, want := rows(`SELECT day_utc, sessions, prompts, api_calls, input_tokens, estimated_cost FROM daily_costs ORDER BY day_utc`),
+		"[[2025-06-02 2 4 3 30 4.5] [2025-06-03 1 2 1 10 2]]"; got != want {
+		t.Errorf("daily_costs = %s, want %s", got, want)
+	}
+	if got, want := rows(`SELECT model, SUM(sessions), SUM(estimated_cost) FROM model_costs GROUP BY model ORDER BY model`),
+		"[[claude-haiku-4-5 1 0.5] [claude-opus-4-6 2 4] [claude-sonnet-4-6 1 2]]"; got != want {
+		t.Errorf("model_costs = %s, want %s", got, want)
+	}
+
+	for _, stmt := range []string{
+		`DELETE FROM sessions`,
+		`UPDATE sessions SET estimated_cost = 0`,
+		`CREATE TABLE scratch (x)`,
+		`DROP VIEW daily_costs`,
+	} {
+		if _, err := Query(path, stmt); err == nil {
+			t.Errorf("%s: expected the read-only cache to refuse it", stmt)
+		}
+	}
+	if got := rows(`SELECT COUNT(*) FROM sessions`); got != "[[4]]" {
+		t.Errorf("sessions after refused writes = %s, want [[4]]", got)
+	}
+}
+
+// TestMigrate_RecreatesViews checks a cache from before the views, or with
+// a stale definition, gets the current ones on upgrade.
+func TestMigrate_RecreatesViews(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.db")
+	c := seedCache(t, path)
+	for _, stmt := range []string{
+		`DROP VIEW daily_costs`,
+		`DROP VIEW model_costs`,
+		`CREATE VIEW model_costs AS SELECT 1 AS stale`,
+	} {
+		if _, err := c.db.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+	if _, err := c.db.Exec(`UPDATE schema_version SET version = ?`, SchemaVersion-1); err != nil {
+		t.Fatal(err)
+	}
+	_ = c.Close()
+
+	c, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = c.Close()
+	for _, view := range []string{"daily_costs", "model_costs"} {
+		if _, err := Query(path, "SELECT day_utc, estimated_cost FROM "+view); err != nil {
+			t.Errorf("%s: %v", view, err)
+		}
+	}
+}
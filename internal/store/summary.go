@@ -0,0 +1,108 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// SummaryRow is one day's totals for a project, source, and model in the
+// summary_cache table. Rows with an empty Model hold whole-session totals;
+// the others hold each model's share, with Sessions counting the sessions
+// that used it.
+type SummaryRow struct {
+	Day     string // local date, YYYY-MM-DD, in the zone the table was built for
+	Project string
+	Source  string
+	Model   string
+
+	Sessions     int
+	Prompts      int
+	APICalls     int
+	DurationSecs int64
+	ActiveSecs   int64 // top-level sessions only, as in model.SummaryStats
+	ClaudeSecs   int64
+
+	InputTokens           int64
+	OutputTokens          int64
+	CacheCreation5mTokens int64
+	CacheCreation1hTokens int64
+	CacheReadTokens       int64
+	EstimatedCost         float64
+	CacheSavings          float64
+}
+
+// SummaryMeta returns the fingerprint of the file set and the time zone the
+// summary_cache table was built for, or empty strings if it was never built
+// or has been invalidated since.
+func (c *Cache) SummaryMeta() (fingerprint, zone string, err error) {
+	err = c.db.QueryRow("SELECT fingerprint, zone FROM summary_meta WHERE id = 1").Scan(&fingerprint, &zone)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", "", nil
+	}
+	return fingerprint, zone, err
+}
+
+// ReplaceSummary swaps the whole summary_cache table for rows in one
+// transaction and records what it was built from.
+func (c *Cache) ReplaceSummary(fingerprint, zone string, rows []SummaryRow) error {
+	tx, err := c.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.Exec("DELETE FROM summary_cache"); err != nil {
+		return err
+	}
+	stmt, err := tx.Prepare(`INSERT INTO summary_cache
+		(day, project, source, model, sessions, prompts, api_calls, duration_secs,
+		 input_tokens, output_tokens, cache_creation_5m, cache_creation_1h, cache_read_tokens,
+		 estimated_cost, cache_savings, active_secs, claude_secs)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = stmt.Close() }()
+
+	for _, r := range rows {
+		_, err := stmt.Exec(r.Day, r.Project, r.Source, r.Model, r.Sessions, r.Prompts, r.APICalls, r.DurationSecs,
+			r.InputTokens, r.OutputTokens, r.CacheCreation5mTokens, r.CacheCreation1hTokens, r.CacheReadTokens,
+			r.EstimatedCost, r.CacheSavings, r.ActiveSecs, r.ClaudeSecs)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(`INSERT OR REPLACE INTO summary_meta (id, fingerprint, zone, built_at) VALUES (1, ?, ?, ?)`,
+		fingerprint, zone, time.Now().UTC().Format(time.RFC3339))
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// SummaryRows returns the summary_cache rows for days in [fromDay, toDay).
+func (c *Cache) SummaryRows(fromDay, toDay string) ([]SummaryRow, error) {
+	rows, err := c.db.Query(`SELECT day, project, source, model, sessions, prompts, api_calls, duration_secs,
+		input_tokens, output_tokens, cache_creation_5m, cache_creation_1h, cache_read_tokens,
+		estimated_cost, cache_savings, active_secs, claude_secs
+		FROM summary_cache WHERE day >= ? AND day < ?`, fromDay, toDay)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var out []SummaryRow
+	for rows.Next() {
+		var r SummaryRow
+		err := rows.Scan(&r.Day, &r.Project, &r.Source, &r.Model, &r.Sessions, &r.Prompts, &r.APICalls, &r.DurationSecs,
+			&r.InputTokens, &r.OutputTokens, &r.CacheCreation5mTokens, &r.CacheCreation1hTokens, &r.CacheReadTokens,
+			&r.EstimatedCost, &r.CacheSavings, &r.ActiveSecs, &r.ClaudeSecs)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, r)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,157 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/theirongolddev/cburn/internal/model"
+	"github.com/theirongolddev/cburn/internal/store"
+	"github.com/theirongolddev/cburn/internal/tui/components"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// parseAnnotation reads the note editor's text: words starting with '#' are
+// tags, the rest is the note.
+func parseAnnotation(s string) store.Annotation {
+	var a store.Annotation
+	var words []string
+	for _, w := range strings.Fields(s) {
+		if len(w) > 1 && w[0] == '#' {
+			// Tags are stored comma-separated
+			if tag := strings.ReplaceAll(w[1:], ",", ""); tag != "" && !slices.Contains(a.Tags, tag) {
+				a.Tags = append(a.Tags, tag)
+			}
+			continue
+		}
+		words = append(words, w)
+	}
+	a.Note = strings.Join(words, " ")
+	return a
+}
+
+// formatAnnotation is the inverse of parseAnnotation, for editing.
+func formatAnnotation(note string, tags []string) string {
+	parts := []string{note}
+	for _, tag := range tags {
+		parts = append(parts, "#"+tag)
+	}
+	return strings.TrimSpace(strings.Join(parts, " "))
+}
+
+// startNoteEdit opens the note editor for the selected session.
+func (a App) startNoteEdit() (tea.Model, tea.Cmd) {
+	sessions := a.getSearchFilteredSessions()
+	if a.sessState.cursor >= len(sessions) {
+		return a, nil
+	}
+	sel := sessions[a.sessState.cursor]
+
+	ti := textinput.New()
+	ti.Prompt = ""
+	ti.Placeholder = "the big refactor #billable #client-x"
+	ti.CharLimit = 200
+	ti.Width = 72
+	ti.SetValue(formatAnnotation(sel.Note, sel.UserTags))
+	ti.Focus()
+
+	a.sessState.annotating = true
+	a.sessState.noteSession = sel.SessionID
+	a.sessState.noteInput = ti
+	return a, ti.Cursor.BlinkCmd()
+}
+
+// updateSessionNote handles keys while the note editor is open.
+func (a App) updateSessionNote(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		a.sessState.annotating = false
+		if a.sessState.tagSessions != nil {
+			a.saveBulkTags()
+			return a, nil
+		}
+		ann := parseAnnotation(a.sessState.noteInput.Value())
+		if err := saveAnnotation(a.sessState.noteSession, ann); err != nil {
+			a.toast(components.ToastError, "Saving note failed: "+err.Error())
+			return a, nil
+		}
+		setAnnotation(a.sessions, a.sessState.noteSession, ann)
+		a.recompute()
+		if ann.IsZero() {
+			a.toast(components.ToastInfo, "Note cleared")
+		} else {
+			a.toast(components.ToastInfo, "Note saved")
+		}
+		return a, nil
+	case "esc":
+		a.sessState.annotating = false
+		a.sessState.tagSessions = nil
+		return a, nil
+	}
+
+	var cmd tea.Cmd
+	a.sessState.noteInput, cmd = a.sessState.noteInput.Update(msg)
+	return a, cmd
+}
+
+// saveAnnotation writes a session's note and tags to the cache.
+func saveAnnotation(sessionID string, ann store.Annotation) error {
+	cache, err := storeOpen()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = cache.Close() }()
+	return cache.SetAnnotation(sessionID, ann)
+}
+
+// setAnnotation updates the loaded copy of a session so the change shows
+// without a reload.
+func setAnnotation(sessions []model.SessionStats, sessionID string, ann store.Annotation) {
+	for i := range sessions {
+		if sessions[i].SessionID == sessionID {
+			sessions[i].Note, sessions[i].UserTags = ann.Note, ann.Tags
+		}
+	}
+}
+
+// toggleExcluded hides the selected session, with its subagents, from every
+// total, or restores it.
+func (a App) toggleExcluded() (tea.Model, tea.Cmd) {
+	sessions := a.getSearchFilteredSessions()
+	if a.sessState.cursor >= len(sessions) {
+		return a, nil
+	}
+	sel := sessions[a.sessState.cursor]
+	ids := []string{sel.SessionID}
+	for _, sub := range a.subagentMap[sel.SessionID] {
+		ids = append(ids, sub.SessionID)
+	}
+	exclude := !sel.Excluded
+
+	cache, err := storeOpen()
+	if err != nil {
+		a.toast(components.ToastError, "Hiding session failed: "+err.Error())
+		return a, nil
+	}
+	defer func() { _ = cache.Close() }()
+	for _, id := range ids {
+		if err := cache.SetExcluded(id, exclude); err != nil {
+			a.toast(components.ToastError, "Hiding session failed: "+err.Error())
+			return a, nil
+		}
+	}
+
+	for i := range a.sessions {
+		if slices.Contains(ids, a.sessions[i].SessionID) {
+			a.sessions[i].Excluded = exclude
+		}
+	}
+	a.recompute()
+	if exclude {
+		a.toast(components.ToastInfo, "Session hidden from totals (H lists hidden sessions)")
+	} else {
+		a.toast(components.ToastInfo, "Session restored to totals")
+	}
+	return a, nil
+}
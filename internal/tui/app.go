@@ -5,36 +5,62 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/theirongolddev/cburn/internal/adminapi"
 	"github.com/theirongolddev/cburn/internal/claudeai"
 	"github.com/theirongolddev/cburn/internal/cli"
 	"github.com/theirongolddev/cburn/internal/config"
+	"github.com/theirongolddev/cburn/internal/insights"
 	"github.com/theirongolddev/cburn/internal/model"
 	"github.com/theirongolddev/cburn/internal/pipeline"
+	"github.com/theirongolddev/cburn/internal/redact"
+	"github.com/theirongolddev/cburn/internal/source"
 	"github.com/theirongolddev/cburn/internal/store"
 	"github.com/theirongolddev/cburn/internal/tui/components"
 	"github.com/theirongolddev/cburn/internal/tui/theme"
+	"github.com/theirongolddev/cburn/internal/usagelog"
 
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 // DataLoadedMsg is sent when the data pipeline finishes.
 type DataLoadedMsg struct {
 	Sessions []model.SessionStats
 	LoadTime time.Duration
+	Overhead *scanOverhead
 }
 
+// PartialDataMsg carries sessions that are ready before the initial load
+// finishes: first those served from the cache, then reparsed ones in
+// batches. DataLoadedMsg still delivers the complete set.
+type PartialDataMsg struct {
+	Sessions []model.SessionStats
+}
+
+// StaleDataMsg carries the sessions cached by the previous run, sent before
+// discovery starts so a slow scan doesn't leave the dashboard empty. The
+// first PartialDataMsg or DataLoadedMsg replaces them.
+type StaleDataMsg struct {
+	Sessions []model.SessionStats
+}
+
+// partialFlushEvery throttles PartialDataMsg batches during the initial load.
+const partialFlushEvery = 250 * time.Millisecond
+
 // ProgressMsg reports file parsing progress.
 type ProgressMsg struct {
-	Current int
-	Total   int
+	Progress pipeline.Progress
 }
 
 // SubDataMsg is sent when the claude.ai subscription data fetch completes.
@@ -42,18 +68,33 @@ type SubDataMsg struct {
 	Data *claudeai.SubscriptionData
 }
 
+// TailMsg carries new API calls from the live tail follower.
+type TailMsg struct {
+	Update source.FollowUpdate
+	Err    error
+}
+
+// maxTailCalls bounds the live tail history kept in memory.
+const maxTailCalls = 50
+
 // RefreshDataMsg is sent when a background data refresh completes.
+// Err is set, and Sessions nil, when the sessions could not be loaded.
 type RefreshDataMsg struct {
 	Sessions []model.SessionStats
 	LoadTime time.Duration
+	Overhead *scanOverhead
+	Err      error
 }
 
 // App is the root Bubble Tea model.
 type App struct {
 	// Data
-	sessions []model.SessionStats
-	loaded   bool
-	loadTime time.Duration
+	sessions  []model.SessionStats
+	loaded    bool
+	streaming bool // the dashboard shows partial data while the initial load runs
+	stale     bool // the sessions shown are the previous run's, until the scan reports
+	loadTime  time.Duration
+	overhead  *scanOverhead // cburn's own scan cost and cache size; nil without a cache
 
 	// Auto-refresh state
 	autoRefresh     bool
@@ -61,20 +102,92 @@ type App struct {
 	lastRefresh     time.Time
 	refreshing      bool
 
+	// Consecutive failed refreshes and the latest error; while failing,
+	// auto-refresh retries on refreshRetryDelay instead of the usual interval.
+	refreshFailures int
+	refreshErr      error
+
+	// Adaptive refresh: nextRefresh moves between refreshMin and refreshMax
+	// based on session activity; refreshInterval is used when disabled.
+	adaptiveRefresh bool
+	refreshMin      time.Duration
+	refreshMax      time.Duration
+	nextRefresh     time.Duration
+
+	// Opt-in local usage log of tab switches
+	usageLog bool
+
+	// Live tail of the newest session (Overview pane)
+	follower    *source.Follower
+	tailPolling bool
+	tailTicks   int
+	tailFile    source.DiscoveredFile
+	tailCalls   []model.APICall // most recent last, capped at maxTailCalls
+
+	// Cost allocation rules from [projects] config
+	projectRules config.ProjectsConfig
+
 	// Subscription data from claude.ai
 	subData     *claudeai.SubscriptionData
 	subFetching bool
 	subTicks    int // counts ticks for periodic refresh
 
+	// Org-wide per-user usage from the Admin API (Team tab)
+	teamEnabled   bool // an Admin API key is configured
+	teamFetching  bool
+	teamUsers     []adminapi.UserUsage
+	teamPeriod    string
+	teamErr       error
+	teamFetchedAt time.Time
+
+	// Exhausted rate-limit windows, and the most recent one to reset
+	rlResets    *claudeai.ResetTracker
+	lastRLReset *claudeai.WindowReset
+
+	// Transient notifications over the content area, oldest first
+	toasts        []components.Toast
+	manualRefresh bool   // the running refresh was requested with "r"
+	lastSubErr    string // last claude.ai problem toasted, so repeats stay quiet
+	budgetMonth   string // start date of the billing period budgetLevel belongs to
+	budgetLevel   int    // number of budgetToastSteps already toasted this period
+
+	// Today's spend spike against the week before; nil on a normal day
+	anomaly *pipeline.CostAnomaly
+
+	// Utilization (0-1) at which the subscription card suggests waiting for a reset
+	hintThreshold float64
+
+	// Subscription the projected cost is compared against, if configured
+	planName string
+	planUSD  float64
+
 	// Pre-computed for current filter
 	filtered   []model.SessionStats
 	stats      model.SummaryStats
 	prevStats  model.SummaryStats // previous period for comparison
 	dailyStats []model.DailyStats
+	prevDaily  []model.DailyStats // previous period, for the cost trend overlay
 	models     []model.ModelStats
+	latency    []model.ModelLatency // only models with recorded turn durations
 	projects   []model.ProjectStats
+	tags       []model.TagStats    // nil when no [projects] rules are configured
+	origins    []model.OriginStats // nil when no session in the period is automated
+	metrics    []model.MetricCard  // custom metrics registered through pkg/plugin
+	subagents  []model.SubagentStats
 	costByType pipeline.TokenTypeCosts
 	modelCosts []pipeline.ModelCostBreakdown
+	tiers      []model.TierStats
+
+	// Sessions that are outliers against the period's efficiency baseline
+	effBaseline pipeline.EfficiencyBaseline
+	inefficient []pipeline.SessionEfficiency
+
+	// Per-project cache profiles and the suggestions derived from them
+	cacheProfiles []insights.ProjectCache
+	insights      []insights.Insight
+
+	// When to schedule heavy runs; nil with too little history
+	schedule *insights.ScheduleAdvice
 
 	// Live activity charts (today + last hour)
 	todayHourly []model.HourlyStats
@@ -83,6 +196,9 @@ type App struct {
 	// Subagent grouping: parent session ID -> subagent sessions
 	subagentMap map[string][]model.SessionStats
 
+	// Sessions in the period hidden from totals
+	hiddenCount int
+
 	// UI state
 	width     int
 	height    int
@@ -90,27 +206,42 @@ type App struct {
 	showHelp  bool
 
 	// Filter state
-	days        int
-	project     string
-	modelFilter string
+	days         int
+	project      string
+	modelFilter  string
+	sourceFilter string
+
+	// Custom date range; rangeSince is zero for the rolling days window
+	rangeSince   time.Time
+	rangeUntil   time.Time
+	rangeEditing bool
+	rangeInput   textinput.Model
+
+	// Saved view last applied, and the picker to switch views
+	viewName   string
+	viewPicker viewPicker
 
 	// Per-tab state
+	overview  overviewState
 	sessState sessionsState
+	breakdown breakdownState
 	settings  settingsState
 
+	costsScroll int // Costs tab page scroll offset
+
 	// First-run setup (huh form)
 	setupForm *huh.Form
 	setupVals setupValues
 	needSetup bool
 
 	// Loading — channel-based progress subscription
-	spinner     spinner.Model
-	progress    int
-	progressMax int
-	loadSub     chan tea.Msg // progress + completion messages from loader goroutine
+	spinner  spinner.Model
+	progress pipeline.Progress
+	loadSub  chan tea.Msg // progress + completion messages from loader goroutine
 
 	// Data dir for pipeline
 	claudeDir        string
+	scanRoots        []source.Root
 	includeSubagents bool
 }
 
@@ -141,7 +272,7 @@ func loadConfigOrDefault() config.Config {
 }
 
 // NewApp creates a new TUI app model.
-func NewApp(claudeDir string, days int, project, modelFilter string, includeSubagents bool) App {
+func NewApp(claudeDir string, days int, project, modelFilter, sourceFilter string, includeSubagents bool) App {
 	needSetup := !config.Exists()
 
 	sp := spinner.New()
@@ -154,16 +285,33 @@ func NewApp(claudeDir string, days int, project, modelFilter string, includeSuba
 	if refreshInterval < 10*time.Second {
 		refreshInterval = 30 * time.Second // minimum 10s, default 30s
 	}
+	refreshMin, refreshMax := refreshBounds(cfg.TUI)
+
+	planName, planUSD, _ := cfg.Budget.Subscription()
 
 	return App{
 		claudeDir:        claudeDir,
+		scanRoots:        pipeline.ScanRoots(claudeDir, cfg.General.ScanRoots, cfg.General.ScanExclude),
 		days:             days,
 		needSetup:        needSetup,
 		project:          project,
 		modelFilter:      modelFilter,
+		sourceFilter:     sourceFilter,
 		includeSubagents: includeSubagents,
 		autoRefresh:      cfg.TUI.AutoRefresh,
 		refreshInterval:  refreshInterval,
+		adaptiveRefresh:  cfg.TUI.AdaptiveRefresh,
+		refreshMin:       refreshMin,
+		refreshMax:       refreshMax,
+		nextRefresh:      refreshMin,
+		usageLog:         cfg.Analytics.Enabled,
+		projectRules:     cfg.Projects,
+		hintThreshold:    cfg.RateLimits.HintThreshold(),
+		planName:         planName,
+		planUSD:          planUSD,
+		teamEnabled:      config.GetAdminAPIKey(cfg) != "",
+		rlResets:         claudeai.NewResetTracker(),
+		follower:         source.NewFollower(claudeDir, includeSubagents, 5*time.Second),
 		spinner:          sp,
 		loadSub:          make(chan tea.Msg, 1),
 	}
@@ -173,7 +321,7 @@ func NewApp(claudeDir string, days int, project, modelFilter string, includeSuba
 func (a App) Init() tea.Cmd {
 	cmds := []tea.Cmd{
 		tea.EnableMouseCellMotion, // Enable mouse support
-		loadDataCmd(a.claudeDir, a.includeSubagents, a.loadSub),
+		loadDataCmd(a.scanRoots, a.includeSubagents, a.loadSub),
 		a.spinner.Tick,
 		tickCmd(),
 	}
@@ -181,41 +329,80 @@ func (a App) Init() tea.Cmd {
 	// Start subscription data fetch if session key is configured
 	cfg := loadConfigOrDefault()
 	if sessionKey := config.GetSessionKey(cfg); sessionKey != "" {
-		cmds = append(cmds, fetchSubDataCmd(sessionKey))
+		cmds = append(cmds, fetchSubDataCmd(sessionKey, cfg.ClaudeAI.OrgID))
 	}
 
 	return tea.Batch(cmds...)
 }
 
 func (a *App) recompute() {
-	now := time.Now()
-	since := now.AddDate(0, 0, -a.days)
+	since, until := a.period(time.Now())
+
+	pipeline.ApplyProjectIdentity(a.sessions, a.projectRules)
+	pipeline.ApplyTags(a.sessions, a.projectRules)
+	pipeline.ApplyAutomation(a.sessions, a.projectRules)
+	pipeline.ScoreEfficiency(a.sessions)
 
 	filtered := a.sessions
+	if a.sourceFilter != "" {
+		filtered = pipeline.FilterBySource(filtered, a.sourceFilter)
+	}
 	if a.project != "" {
 		filtered = pipeline.FilterByProject(filtered, a.project)
 	}
 	if a.modelFilter != "" {
 		filtered = pipeline.FilterByModel(filtered, a.modelFilter)
 	}
-
-	timeFiltered := pipeline.FilterByTime(filtered, since, now)
-	a.stats = pipeline.Aggregate(filtered, since, now)
-	a.dailyStats = pipeline.AggregateDays(filtered, since, now)
-	a.models = pipeline.AggregateModels(filtered, since, now)
-	a.projects = pipeline.AggregateProjects(filtered, since, now)
-	a.costByType, a.modelCosts = pipeline.AggregateCostBreakdown(filtered, since, now)
+	filtered = redact.Sessions(filtered)
+
+	// Hidden sessions count toward nothing, but can still be listed
+	withHidden := pipeline.FilterByTime(filtered, since, until)
+	filtered = pipeline.WithoutExcluded(filtered)
+
+	timeFiltered := pipeline.FilterByTime(filtered, since, until)
+	a.hiddenCount = len(withHidden) - len(timeFiltered)
+	a.stats = pipeline.Aggregate(filtered, since, until)
+	a.dailyStats = pipeline.AggregateDays(filtered, since, until)
+	if a.overview.chartCursor >= len(a.dailyStats) {
+		a.overview.chartCursor = len(a.dailyStats) - 1
+	}
+	a.models = pipeline.AggregateModels(filtered, since, until)
+	a.latency = pipeline.AggregateLatency(filtered, since, until)
+	a.projects = pipeline.AggregateProjects(filtered, since, until)
+	if a.breakdown.sortBy != projSortCost {
+		sortProjects(a.projects, a.breakdown.sortBy)
+	}
+	a.tags = nil
+	if len(a.projectRules.Rules) > 0 {
+		a.tags = pipeline.AggregateTags(filtered, since, until)
+	}
+	a.origins = pipeline.AggregateOrigins(filtered, since, until)
+	if !slices.ContainsFunc(a.origins, func(o model.OriginStats) bool { return o.Origin == model.OriginAutomation }) {
+		a.origins = nil
+	}
+	a.subagents = pipeline.AggregateSubagents(filtered, since, until)
+	a.metrics = pipeline.ComputeMetrics(filtered, since, until)
+	a.costByType, a.modelCosts = pipeline.AggregateCostBreakdown(filtered, since, until)
+	a.tiers = pipeline.AggregateTiers(filtered, since, until)
+	a.effBaseline, a.inefficient = pipeline.FindInefficientSessions(filtered, since, until)
+	a.cacheProfiles = insights.ProjectCacheStats(filtered, since, until)
+	a.insights = insights.Recommend(a.cacheProfiles)
+	a.schedule = insights.Schedule(filtered, a.weeklyWindow(), time.Now())
 
 	// Live activity charts
 	a.todayHourly = pipeline.AggregateTodayHourly(filtered)
 	a.lastHour = pipeline.AggregateLastHour(filtered)
 
 	// Previous period for comparison (same duration, immediately before)
-	prevSince := since.AddDate(0, 0, -a.days)
+	prevSince := since.Add(-until.Sub(since))
 	a.prevStats = pipeline.Aggregate(filtered, prevSince, since)
+	a.prevDaily = pipeline.AggregateDays(filtered, prevSince, since)
 
 	// Group subagents under their parent sessions for the sessions tab.
 	// Other tabs (overview, costs, breakdown) still use full aggregations above.
+	if a.sessState.showHidden {
+		timeFiltered = withHidden
+	}
 	a.filtered, a.subagentMap = groupSubagents(timeFiltered)
 
 	// Filter out empty sessions (0 API calls — user started Claude but did nothing)
@@ -241,10 +428,44 @@ func (a *App) recompute() {
 		a.sessState.cursor = 0
 	}
 	a.sessState.detailScroll = 0
+
+	// Clamp breakdown project cursor
+	if a.breakdown.cursor >= len(a.projects) {
+		a.breakdown.cursor = len(a.projects) - 1
+	}
+	if a.breakdown.cursor < 0 {
+		a.breakdown.cursor = 0
+		a.breakdown.detail = false
+	}
 }
 
 // Update implements tea.Model.
 func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	prevTab := a.activeTab
+	m, cmd := a.update(msg)
+	na, ok := m.(App)
+	if !ok || na.activeTab == prevTab {
+		return m, cmd
+	}
+	if na.usageLog {
+		cmd = tea.Batch(cmd, recordTabCmd(components.Tabs[na.activeTab].Name))
+	}
+	// The team report is fetched the first time the Team tab is opened
+	if na.activeTab == 5 && na.teamFetchedAt.IsZero() && !na.teamFetching {
+		cmd = tea.Batch(cmd, na.fetchTeamCmd())
+	}
+	return na, cmd
+}
+
+// recordTabCmd appends a tab switch to the local usage log.
+func recordTabCmd(name string) tea.Cmd {
+	return func() tea.Msg {
+		_ = usagelog.Record(usagelog.KindTab, name)
+		return nil
+	}
+}
+
+func (a App) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 
 	case tea.WindowSizeMsg:
@@ -261,38 +482,7 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return a, nil
 		}
 
-		switch msg.Button {
-		case tea.MouseButtonWheelUp:
-			// Scroll up in sessions tab
-			if a.activeTab == 2 && !a.sessState.searching {
-				if a.sessState.cursor > 0 {
-					a.sessState.cursor--
-					a.sessState.detailScroll = 0
-				}
-			}
-			return a, nil
-
-		case tea.MouseButtonWheelDown:
-			// Scroll down in sessions tab
-			if a.activeTab == 2 && !a.sessState.searching {
-				searchFiltered := a.getSearchFilteredSessions()
-				if a.sessState.cursor < len(searchFiltered)-1 {
-					a.sessState.cursor++
-					a.sessState.detailScroll = 0
-				}
-			}
-			return a, nil
-
-		case tea.MouseButtonLeft:
-			// Check if click is in tab bar area (first 2 lines)
-			if msg.Y <= 1 {
-				if tab := a.tabAtX(msg.X); tab >= 0 && tab < len(components.Tabs) {
-					a.activeTab = tab
-				}
-			}
-			return a, nil
-		}
-		return a, nil
+		return a.updateMouse(msg)
 
 	case tea.KeyMsg:
 		key := msg.String()
@@ -312,14 +502,32 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		// Settings tab has its own keybindings (text input)
-		if a.activeTab == 4 && a.settings.editing {
+		if a.activeTab == 6 && a.settings.editing {
 			return a.updateSettingsInput(msg)
 		}
 
+		// Theme gallery intercepts all keys when open
+		if a.activeTab == 6 && a.settings.gallery {
+			return a.updateThemeGallery(key)
+		}
+
 		// Sessions search mode intercepts all keys when active
 		if a.activeTab == 2 && a.sessState.searching {
 			return a.updateSessionsSearch(msg)
 		}
+		if a.activeTab == 2 && a.sessState.annotating {
+			return a.updateSessionNote(msg)
+		}
+
+		// Date-range input intercepts all keys when open
+		if a.rangeEditing {
+			return a.updateRangeInput(msg)
+		}
+
+		// View picker intercepts all keys when open
+		if a.viewPicker.open {
+			return a.updateViewPicker(msg)
+		}
 
 		// Help toggle
 		if key == "?" {
@@ -338,13 +546,58 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			compactSessions := a.isCompactLayout()
 			searchFiltered := a.getSearchFilteredSessions()
 
+			if a.sessState.transcriptAsk {
+				return a.confirmTranscript(key)
+			}
+			if a.sessState.yankPending {
+				a.sessState.yankPending = false
+				return a.copySession(key), nil
+			}
+			if a.sessState.visual {
+				if m, cmd, ok := a.updateVisual(key); ok {
+					return m, cmd
+				}
+			}
+
 			switch key {
+			case "v":
+				if len(searchFiltered) > 0 {
+					a.sessState.visual = true
+					a.sessState.anchor = a.sessState.cursor
+				}
+				return a, nil
 			case "/":
 				// Start search mode
 				a.sessState.searching = true
 				a.sessState.searchInput = newSearchInput()
 				a.sessState.searchInput.Focus()
 				return a, a.sessState.searchInput.Cursor.BlinkCmd()
+			case "n":
+				return a.startNoteEdit()
+			case "o", "O":
+				// In the full detail pane; elsewhere o still goes to Overview.
+				if compactSessions || a.sessState.viewMode == sessViewDetail {
+					return a.openSessionFile(key == "O")
+				}
+			case "p":
+				return a.toggleTranscript()
+			case "y":
+				if len(searchFiltered) > 0 {
+					a.sessState.yankPending = true
+					a.toast(components.ToastInfo, "Copy: [i] session ID  [p] file path  [s] cost summary")
+				}
+				return a, nil
+			case "X":
+				return a.toggleExcluded()
+			case "H":
+				a.sessState.showHidden = !a.sessState.showHidden
+				a.recompute()
+				if a.sessState.showHidden {
+					a.toast(components.ToastInfo, "Listing hidden sessions too")
+				} else {
+					a.toast(components.ToastInfo, "Hidden sessions unlisted")
+				}
+				return a, nil
 			case "q":
 				if !compactSessions && a.sessState.viewMode == sessViewDetail {
 					a.sessState.viewMode = sessViewSplit
@@ -363,6 +616,7 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Clear search if active, otherwise exit detail view
 				if a.sessState.searchQuery != "" {
 					a.sessState.searchQuery = ""
+					a.viewName = ""
 					a.sessState.cursor = 0
 					a.sessState.offset = 0
 					return a, nil
@@ -426,8 +680,73 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		// Overview and Costs scroll as a page on short terminals
+		if a.pageScroll() != nil {
+			halfPage := max((a.height-scrollOverhead)/2, minHalfPageScroll)
+			switch key {
+			case "j", "down", "J":
+				a.scrollPage(1)
+				return a, nil
+			case "k", "up", "K":
+				a.scrollPage(-1)
+				return a, nil
+			case "ctrl+d":
+				a.scrollPage(halfPage)
+				return a, nil
+			case "ctrl+u":
+				a.scrollPage(-halfPage)
+				return a, nil
+			}
+		}
+
+		// Overview tab: daily chart cursor
+		if a.activeTab == 0 {
+			switch key {
+			case "h":
+				a.overview.moveChartCursor(-1, len(a.dailyStats))
+				return a, nil
+			case "l":
+				a.overview.moveChartCursor(1, len(a.dailyStats))
+				return a, nil
+			case "m":
+				a.overview.chartTotals = !a.overview.chartTotals
+				return a, nil
+			case "esc":
+				if a.overview.chartActive {
+					a.overview.chartActive = false
+					return a, nil
+				}
+			}
+		}
+
+		// Breakdown tab: project picker and detail view
+		if a.activeTab == 3 {
+			switch key {
+			case "j", "down":
+				if a.breakdown.cursor < len(a.projects)-1 {
+					a.breakdown.cursor++
+				}
+				return a, nil
+			case "k", "up":
+				if a.breakdown.cursor > 0 {
+					a.breakdown.cursor--
+				}
+				return a, nil
+			case "enter":
+				if len(a.projects) > 0 {
+					a.breakdown.detail = true
+				}
+				return a, nil
+			case "esc", "q":
+				if a.breakdown.detail {
+					a.breakdown.detail = false
+					return a, nil
+				}
+			}
+		}
+
 		// Settings tab navigation (non-editing mode)
-		if a.activeTab == 4 {
+		if a.activeTab == 6 {
 			switch key {
 			case "j", "down":
 				if a.settings.cursor < settingsFieldCount-1 {
@@ -441,6 +760,8 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return a, nil
 			case "enter":
 				return a.settingsStartEdit()
+			case "p":
+				return a.openThemeGallery()
 			}
 		}
 
@@ -449,10 +770,25 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return a, tea.Quit
 		}
 
+		// Date range
+		if key == "D" {
+			return a.startRangeEdit()
+		}
+
+		// Saved views
+		if key == "v" {
+			return a.openViewPicker()
+		}
+
 		// Manual refresh
-		if key == "r" && !a.refreshing {
+		if key == "r" && !a.refreshing && !a.streaming {
 			a.refreshing = true
-			return a, refreshDataCmd(a.claudeDir, a.includeSubagents)
+			a.manualRefresh = true
+			cmd := refreshDataCmd(a.scanRoots, a.includeSubagents)
+			if a.activeTab == 5 && !a.teamFetching {
+				cmd = tea.Batch(cmd, a.fetchTeamCmd())
+			}
+			return a, cmd
 		}
 
 		// Toggle auto-refresh
@@ -461,7 +797,29 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Persist to config (best-effort, ignore errors)
 			cfg := loadConfigOrDefault()
 			cfg.TUI.AutoRefresh = a.autoRefresh
-			_ = config.Save(cfg)
+			if err := config.Save(cfg); err != nil {
+				a.toast(components.ToastError, "Saving auto-refresh setting failed: "+err.Error())
+			} else if a.autoRefresh {
+				a.toast(components.ToastInfo, "Auto-refresh on")
+			} else {
+				a.toast(components.ToastInfo, "Auto-refresh off")
+			}
+			return a, nil
+		}
+
+		// Toggle privacy mode
+		if key == "P" {
+			redact.SetEnabled(!redact.Enabled())
+			a.recompute()
+			cfg := loadConfigOrDefault()
+			cfg.Appearance.Privacy = redact.Enabled()
+			if err := config.Save(cfg); err != nil {
+				a.toast(components.ToastError, "Saving privacy setting failed: "+err.Error())
+			} else if redact.Enabled() {
+				a.toast(components.ToastInfo, "Privacy mode on: names and IDs masked")
+			} else {
+				a.toast(components.ToastInfo, "Privacy mode off")
+			}
 			return a, nil
 		}
 
@@ -475,8 +833,12 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			a.activeTab = 2
 		case "b":
 			a.activeTab = 3
-		case "x":
+		case "i":
 			a.activeTab = 4
+		case "t":
+			a.activeTab = 5
+		case "x":
+			a.activeTab = 6
 		case "left":
 			a.activeTab = (a.activeTab - 1 + len(components.Tabs)) % len(components.Tabs)
 		case "right":
@@ -484,12 +846,39 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return a, nil
 
+	case StaleDataMsg:
+		a.sessions = msg.Sessions
+		a.loaded = true
+		a.streaming = true
+		a.stale = true
+		a.recompute()
+		return a, waitForLoadMsg(a.loadSub)
+
+	case PartialDataMsg:
+		if a.stale {
+			a.sessions = nil
+			a.stale = false
+		}
+		a.sessions = append(a.sessions, msg.Sessions...)
+		if !a.loaded {
+			a.loaded = true
+			a.streaming = true
+		}
+		a.recompute()
+		return a, waitForLoadMsg(a.loadSub)
+
 	case DataLoadedMsg:
 		a.sessions = msg.Sessions
 		a.loaded = true
+		a.streaming = false
+		a.stale = false
 		a.loadTime = msg.LoadTime
+		a.overhead = msg.Overhead
 		a.lastRefresh = time.Now()
+		a.nextRefresh = nextRefreshInterval(a.refreshMin, a.refreshMin, a.refreshMax, latestActivity(a.sessions), a.lastRefresh)
 		a.recompute()
+		a.checkBudget(time.Now())
+		a.checkAnomaly(time.Now())
 
 		// Activate first-run setup after data loads
 		if a.needSetup {
@@ -503,24 +892,54 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return a, nil
 
 	case ProgressMsg:
-		a.progress = msg.Current
-		a.progressMax = msg.Total
+		a.progress = msg.Progress
 		return a, waitForLoadMsg(a.loadSub)
 
+	case TranscriptMsg:
+		if tr := a.sessState.transcript; tr != nil && tr.sessionID == msg.SessionID {
+			if msg.Err != nil {
+				a.sessState.transcript = nil
+				a.toast(components.ToastError, "Reading prompts failed: "+msg.Err.Error())
+			} else {
+				a.sessState.transcript = &sessionTranscript{sessionID: msg.SessionID, prompts: msg.Prompts}
+			}
+		}
+		return a, nil
+
+	case EditorClosedMsg:
+		if msg.Err != nil {
+			a.toast(components.ToastError, "Editor: "+msg.Err.Error())
+		}
+		return a, nil
+
 	case SubDataMsg:
 		a.subData = msg.Data
 		a.subFetching = false
+		if msg.Data != nil && msg.Data.Usage != nil && !msg.Data.Stale {
+			a.noteRLResets(a.rlResets.Observe(msg.Data.Usage, time.Now()))
+		}
+		a.noteSubProblem(msg.Data)
+		if a.schedule != nil {
+			a.schedule.SetWeekly(a.weeklyWindow(), time.Now())
+		}
 
-		// Cache org ID if we got one (best-effort, ignore errors)
+		// Cache the org ID unless the user's choice still matches an org
+		// (best-effort, ignore errors)
 		if msg.Data != nil && msg.Data.Org.UUID != "" {
 			cfg := loadConfigOrDefault()
-			if cfg.ClaudeAI.OrgID != msg.Data.Org.UUID {
+			if _, chosen := claudeai.SelectOrg(msg.Data.Orgs, cfg.ClaudeAI.OrgID); !chosen {
 				cfg.ClaudeAI.OrgID = msg.Data.Org.UUID
 				_ = config.Save(cfg)
 			}
 		}
 		return a, nil
 
+	case TeamDataMsg:
+		a.teamFetching = false
+		a.teamUsers, a.teamErr = msg.Users, msg.Err
+		a.teamPeriod, a.teamFetchedAt = msg.Period, msg.FetchedAt
+		return a, nil
+
 	case spinner.TickMsg:
 		if !a.loaded {
 			var cmd tea.Cmd
@@ -531,6 +950,8 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tickMsg:
 		a.subTicks++
+		a.noteRLResets(a.rlResets.Due(time.Now()))
+		a.pruneToasts(time.Now())
 
 		cmds := []tea.Cmd{tickCmd()}
 
@@ -540,28 +961,78 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cfg := loadConfigOrDefault()
 			if sessionKey := config.GetSessionKey(cfg); sessionKey != "" {
 				a.subFetching = true
-				cmds = append(cmds, fetchSubDataCmd(sessionKey))
+				cmds = append(cmds, fetchSubDataCmd(sessionKey, cfg.ClaudeAI.OrgID))
 			}
 		}
 
+		// Poll the live tail once per second (4 ticks at 250ms)
+		a.tailTicks++
+		if a.loaded && !a.tailPolling && a.tailTicks >= 4 {
+			a.tailTicks = 0
+			a.tailPolling = true
+			cmds = append(cmds, tailPollCmd(a.follower))
+		}
+
 		// Auto-refresh session data
-		if a.loaded && a.autoRefresh && !a.refreshing {
-			if time.Since(a.lastRefresh) >= a.refreshInterval {
+		if a.loaded && !a.streaming && a.autoRefresh && !a.refreshing {
+			if time.Since(a.lastRefresh) >= a.currentRefreshInterval() {
 				a.refreshing = true
-				cmds = append(cmds, refreshDataCmd(a.claudeDir, a.includeSubagents))
+				cmds = append(cmds, refreshDataCmd(a.scanRoots, a.includeSubagents))
 			}
 		}
 
 		return a, tea.Batch(cmds...)
 
+	case TailMsg:
+		a.tailPolling = false
+		if msg.Err != nil {
+			return a, nil
+		}
+		if msg.Update.Switched {
+			a.tailCalls = nil
+		}
+		a.tailFile = msg.Update.File
+		a.tailCalls = append(a.tailCalls, msg.Update.Calls...)
+		if n := len(a.tailCalls); n > maxTailCalls {
+			a.tailCalls = a.tailCalls[n-maxTailCalls:]
+		}
+		return a, nil
+
 	case RefreshDataMsg:
 		a.refreshing = false
 		a.lastRefresh = time.Now()
+		manual := a.manualRefresh
+		a.manualRefresh = false
+
+		if msg.Err != nil {
+			a.refreshFailures++
+			a.refreshErr = msg.Err
+			// Repeated background failures are already on the status bar.
+			if manual || a.refreshFailures == 1 {
+				a.toast(components.ToastError, "Refresh failed: "+msg.Err.Error())
+			}
+			return a, nil
+		}
+
+		if a.refreshFailures > 0 && !manual {
+			a.toast(components.ToastSuccess, "Refresh recovered")
+		}
+		a.refreshFailures = 0
+		a.refreshErr = nil
 		if msg.Sessions != nil {
 			a.sessions = msg.Sessions
 			a.loadTime = msg.LoadTime
 			a.recompute()
+			a.checkBudget(a.lastRefresh)
+			a.checkAnomaly(a.lastRefresh)
+		}
+		if manual {
+			a.toast(components.ToastSuccess, fmt.Sprintf("Refreshed %d sessions in %.1fs", len(msg.Sessions), msg.LoadTime.Seconds()))
+		}
+		if msg.Overhead != nil {
+			a.overhead = msg.Overhead
 		}
+		a.nextRefresh = nextRefreshInterval(a.nextRefresh, a.refreshMin, a.refreshMax, latestActivity(a.sessions), a.lastRefresh)
 		return a, nil
 	}
 
@@ -573,6 +1044,17 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return a, nil
 }
 
+// currentRefreshInterval returns the auto-refresh delay in effect.
+func (a App) currentRefreshInterval() time.Duration {
+	if a.refreshFailures > 0 {
+		return refreshRetryDelay(a.refreshFailures)
+	}
+	if a.adaptiveRefresh {
+		return a.nextRefresh
+	}
+	return a.refreshInterval
+}
+
 func (a App) updateSetupForm(msg tea.Msg) (tea.Model, tea.Cmd) {
 	form, cmd := a.setupForm.Update(msg)
 	if f, ok := form.(*huh.Form); ok {
@@ -685,7 +1167,7 @@ func (a App) viewLoading() string {
 	b.WriteString(subtitleStyle.Render(" · Claude Usage Metrics"))
 	b.WriteString("\n\n")
 
-	if a.progressMax > 0 {
+	if a.progress.TotalFiles > 0 {
 		barW := 40
 		if barW > w-30 {
 			barW = w - 30
@@ -693,14 +1175,25 @@ func (a App) viewLoading() string {
 		if barW < 20 {
 			barW = 20
 		}
-		pct := float64(a.progress) / float64(a.progressMax)
+		p := a.progress
 		b.WriteString(spinnerStyle.Render(a.spinner.View()))
 		b.WriteString(subtitleStyle.Render(" Parsing sessions\n\n"))
-		b.WriteString(components.ProgressBar(pct, barW))
+		b.WriteString(components.ProgressBar(p.Fraction(), barW))
 		b.WriteString("\n")
-		b.WriteString(countStyle.Render(cli.FormatNumber(int64(a.progress))))
+		b.WriteString(countStyle.Render(cli.FormatNumber(int64(p.Files))))
 		b.WriteString(subtitleStyle.Render(" / "))
-		b.WriteString(countStyle.Render(cli.FormatNumber(int64(a.progressMax))))
+		b.WriteString(countStyle.Render(cli.FormatNumber(int64(p.TotalFiles))))
+		b.WriteString(subtitleStyle.Render(" files"))
+		if p.TotalBytes > 0 {
+			b.WriteString(subtitleStyle.Render("  ·  "))
+			b.WriteString(countStyle.Render(cli.FormatBytes(p.Bytes)))
+			b.WriteString(subtitleStyle.Render(" / "))
+			b.WriteString(countStyle.Render(cli.FormatBytes(p.TotalBytes)))
+		}
+		if eta := p.ETA(); eta > 0 {
+			b.WriteString("\n")
+			b.WriteString(subtitleStyle.Render("ETA " + cli.FormatDuration(int64(eta.Seconds()+1))))
+		}
 	} else {
 		b.WriteString(spinnerStyle.Render(a.spinner.View()))
 		b.WriteString(subtitleStyle.Render(" Discovering sessions..."))
@@ -755,9 +1248,11 @@ func (a App) viewHelp() string {
 	b.WriteString(sectionStyle.Render("Navigation"))
 	b.WriteString("\n")
 	navBindings := []struct{ key, desc string }{
-		{"o c s b x", "Jump to tab"},
+		{"o c s b i t x", "Jump to tab"},
 		{"← →", "Previous / Next tab"},
 		{"j k", "Navigate lists"},
+		{"h l", "Inspect daily chart bars"},
+		{"m", "Daily chart: by model / totals"},
 		{"J K", "Scroll detail pane"},
 		{"^d ^u", "Half-page scroll"},
 	}
@@ -771,11 +1266,20 @@ func (a App) viewHelp() string {
 	b.WriteString(sectionStyle.Render("Actions"))
 	b.WriteString("\n")
 	actionBindings := []struct{ key, desc string }{
-		{"/", "Search sessions"},
+		{"/", "Search sessions (cost>5, model:opus)"},
+		{"n", "Note / #tags on a session"},
+		{"y i/p/s", "Copy session ID / file path / cost summary"},
+		{"o O", "Session detail: open JSONL in $EDITOR / reveal"},
+		{"p", "Session detail: preview prompts (asks first)"},
+		{"X H", "Hide session / list hidden"},
+		{"v", "Sessions: select rows to hide, tag, export, copy"},
+		{"D", "Date range (from..to, or days)"},
+		{"v", "Other tabs: switch / save view"},
 		{"Enter", "Expand / Confirm"},
 		{"Esc", "Back / Cancel"},
 		{"r", "Refresh data"},
 		{"R", "Toggle auto-refresh"},
+		{"P", "Toggle privacy mode (mask names)"},
 		{"?", "Toggle help"},
 		{"q", "Quit"},
 	}
@@ -794,13 +1298,13 @@ func (a App) viewHelp() string {
 		lipgloss.WithWhitespaceBackground(t.Background))
 }
 
-func (a App) viewMain() string {
+// mainLayout renders the header and status bar around the tab content and
+// returns them with the height left for the content between.
+func (a App) mainLayout() (header, statusBar string, contentH int) {
 	t := theme.Active
 	w := a.width
-	cw := a.contentWidth()
 	h := a.height
 
-	// 1. Render header (tab bar + filter pill)
 	filterPillStyle := lipgloss.NewStyle().
 		Foreground(t.TextDim).
 		Background(t.Surface)
@@ -810,8 +1314,14 @@ func (a App) viewMain() string {
 		Background(t.Surface).
 		Bold(true)
 
-	filterStr := filterPillStyle.Render(" ") +
-		filterAccentStyle.Render(fmt.Sprintf("%dd", a.days))
+	filterStr := filterPillStyle.Render(" ")
+	if a.viewName != "" {
+		filterStr += filterAccentStyle.Render("◆ "+a.viewName) + filterPillStyle.Render(" │ ")
+	}
+	filterStr += filterAccentStyle.Render(a.periodLabel())
+	if a.sourceFilter != "" {
+		filterStr += filterPillStyle.Render(" │ ") + filterAccentStyle.Render("@"+a.sourceFilter)
+	}
 	if a.project != "" {
 		filterStr += filterPillStyle.Render(" │ ") + filterAccentStyle.Render(a.project)
 	}
@@ -819,40 +1329,70 @@ func (a App) viewMain() string {
 		filterStr += filterPillStyle.Render(" │ ") + filterAccentStyle.Render(a.modelFilter)
 	}
 	filterStr += filterPillStyle.Render(" ")
+	if a.stale {
+		filterStr += lipgloss.NewStyle().Foreground(t.Surface).Background(t.Orange).Bold(true).Render(" STALE ")
+	}
+	if a.rangeEditing {
+		filterStr = filterPillStyle.Render(" ") + a.rangeInput.View()
+	} else if a.viewPicker.open {
+		filterStr = a.renderViewPicker()
+	}
 
 	// Pad filter line to full width
 	filterRowStyle := lipgloss.NewStyle().
 		Background(t.Surface).
 		Width(w)
 
-	header := components.RenderTabBar(a.activeTab, w) +
+	header = components.RenderTabBar(a.activeTab, w) +
 		filterRowStyle.Render(filterStr)
+	if a.anomaly != nil {
+		header += "\n" + a.renderAnomalyBanner(w)
+	}
 
 	// 2. Render status bar
 	dataAge := fmt.Sprintf("%.1fs", a.loadTime.Seconds())
-	statusBar := components.RenderStatusBar(w, dataAge, a.subData, a.refreshing, a.autoRefresh)
+	if a.stale {
+		dataAge = "last run's cache, scanning for changes"
+	} else if a.streaming {
+		dataAge = fmt.Sprintf("loading %s/%s files",
+			cli.FormatNumber(int64(a.progress.Files)), cli.FormatNumber(int64(a.progress.TotalFiles)))
+	}
+	active := pipeline.CountActive(a.sessions, time.Now())
+	statusBar = components.RenderStatusBar(w, dataAge, a.subData, a.refreshing, a.autoRefresh, a.refreshErr != nil, active)
 
 	// 3. Calculate content zone height
 	headerH := lipgloss.Height(header)
 	statusH := lipgloss.Height(statusBar)
-	contentH := h - headerH - statusH
+	contentH = h - headerH - statusH
 	if contentH < minContentHeight {
 		contentH = minContentHeight
 	}
+	return header, statusBar, contentH
+}
+
+func (a App) viewMain() string {
+	t := theme.Active
+	w := a.width
+	cw := a.contentWidth()
+
+	// 1-3. Header (tab bar + filter pill), status bar, and the content zone between
+	header, statusBar, contentH := a.mainLayout()
 
 	// 4. Render tab content (pass contentH to sessions)
 	var content string
 	switch a.activeTab {
-	case 0:
-		content = a.renderOverviewTab(cw)
-	case 1:
-		content = a.renderCostsTab(cw)
+	case 0, 1:
+		content = applyPageScroll(a.renderPageTab(cw), *a.pageScroll(), contentH)
 	case 2:
 		searchFiltered := a.getSearchFilteredSessions()
 		content = a.renderSessionsContent(searchFiltered, cw, contentH)
 	case 3:
 		content = a.renderBreakdownTab(cw)
 	case 4:
+		content = a.renderInsightsTab(cw)
+	case 5:
+		content = a.renderTeamTab(cw)
+	case 6:
 		content = a.renderSettingsTab(cw)
 	}
 
@@ -861,6 +1401,7 @@ func (a App) viewMain() string {
 
 	// 6. Fill each line to full width with background (fixes gaps between cards)
 	content = fillLinesWithBackground(content, cw, t.Background)
+	content = components.OverlayToasts(content, a.toasts, cw)
 
 	// 7. Place content with background fill (handles centering when w > cw)
 	content = lipgloss.Place(w, contentH, lipgloss.Center, lipgloss.Top, content,
@@ -871,7 +1412,7 @@ func (a App) viewMain() string {
 
 	// 9. Ensure entire terminal is filled with background
 	// This handles any edge cases where the calculated heights don't perfectly match
-	return lipgloss.Place(w, h, lipgloss.Left, lipgloss.Top, output,
+	return lipgloss.Place(w, a.height, lipgloss.Left, lipgloss.Top, output,
 		lipgloss.WithWhitespaceBackground(t.Background))
 }
 
@@ -928,6 +1469,7 @@ func groupSubagents(sessions []model.SessionStats) ([]model.SessionStats, map[st
 			enriched.CacheCreation1hTokens += sub.CacheCreation1hTokens
 			enriched.CacheReadTokens += sub.CacheReadTokens
 			enriched.EstimatedCost += sub.EstimatedCost
+			enriched.RepeatedCalls = max(enriched.RepeatedCalls, sub.RepeatedCalls)
 
 			for modelName, mu := range sub.Models {
 				existing, exists := enriched.Models[modelName]
@@ -942,6 +1484,7 @@ func groupSubagents(sessions []model.SessionStats) ([]model.SessionStats, map[st
 					existing.CacheCreation1hTokens += mu.CacheCreation1hTokens
 					existing.CacheReadTokens += mu.CacheReadTokens
 					existing.EstimatedCost += mu.EstimatedCost
+					existing.TurnDurationsMs = append(slices.Clip(existing.TurnDurationsMs), mu.TurnDurationsMs...)
 				}
 			}
 		}
@@ -968,37 +1511,82 @@ func tickCmd() tea.Cmd {
 }
 
 // loadDataCmd starts the data loading pipeline in a background goroutine.
-// It streams ProgressMsg updates and a final DataLoadedMsg through sub.
-func loadDataCmd(claudeDir string, includeSubagents bool, sub chan tea.Msg) tea.Cmd {
+// It streams StaleDataMsg, ProgressMsg, and PartialDataMsg updates and a
+// final DataLoadedMsg through sub.
+func loadDataCmd(roots []source.Root, includeSubagents bool, sub chan tea.Msg) tea.Cmd {
 	return func() tea.Msg {
 		go func() {
 			start := time.Now()
 
+			// Reparsed sessions collect in pending and go out in batches.
+			// Sends never block the workers: a batch that doesn't fit waits
+			// for the next progress report, and DataLoadedMsg has them all.
+			var mu sync.Mutex
+			var pending []model.SessionStats
+			var lastFlush time.Time
+			flush := func() {
+				mu.Lock()
+				defer mu.Unlock()
+				if len(pending) == 0 || time.Since(lastFlush) < partialFlushEvery {
+					return
+				}
+				select {
+				case sub <- PartialDataMsg{Sessions: pending}:
+					pending = nil
+					lastFlush = time.Now()
+				default:
+				}
+			}
+
 			// Progress callback: non-blocking send so workers aren't stalled.
 			// If the channel is full, we skip this update — the next one catches up.
-			progressFn := func(current, total int) {
+			progressFn := func(p pipeline.Progress) {
+				flush()
 				select {
-				case sub <- ProgressMsg{Current: current, Total: total}:
+				case sub <- ProgressMsg{Progress: p}:
 				default:
 				}
 			}
+			// Once stale sessions are on screen, the cached ones replace
+			// them even when there are none.
+			var staleShown bool
+			hooks := pipeline.LoadHooks{
+				Stale: func(sessions []model.SessionStats) {
+					if len(sessions) > 0 {
+						staleShown = true
+						sub <- StaleDataMsg{Sessions: sessions}
+					}
+				},
+				Cached: func(sessions []model.SessionStats) {
+					if len(sessions) > 0 || staleShown {
+						sub <- PartialDataMsg{Sessions: sessions}
+					}
+				},
+				Parsed: func(s model.SessionStats) {
+					mu.Lock()
+					pending = append(pending, s)
+					mu.Unlock()
+				},
+			}
 
 			// Try cached load
 			cache, err := storeOpen()
 			if err == nil {
-				cr, loadErr := pipeline.LoadWithCache(claudeDir, includeSubagents, cache, progressFn)
+				cr, loadErr := pipeline.LoadWithCacheHooks(roots, includeSubagents, cache, progressFn, hooks)
+				overhead := readScanOverhead(cache, time.Now())
 				_ = cache.Close()
 				if loadErr == nil {
 					sub <- DataLoadedMsg{
 						Sessions: cr.Sessions,
 						LoadTime: time.Since(start),
+						Overhead: overhead,
 					}
 					return
 				}
 			}
 
 			// Fallback: uncached load
-			result, err := pipeline.Load(claudeDir, includeSubagents, progressFn)
+			result, err := pipeline.Load(roots, includeSubagents, progressFn)
 			if err != nil {
 				sub <- DataLoadedMsg{LoadTime: time.Since(start)}
 				return
@@ -1025,27 +1613,75 @@ func storeOpen() (*store.Cache, error) {
 	return store.Open(pipeline.CachePath())
 }
 
+// scanOverhead summarizes what cburn itself costs: time spent scanning and
+// parsing, and the disk used by its cache.
+type scanOverhead struct {
+	Today     store.ScanDay
+	Week      store.ScanDay // totals over the last 7 days
+	DiskBytes int64
+
+	ParseIssues int // files with read errors or malformed lines, including quarantined ones
+	Quarantined int
+}
+
+func readScanOverhead(cache *store.Cache, now time.Time) *scanOverhead {
+	days, err := cache.ScanHistory(now.AddDate(0, 0, -6))
+	if err != nil {
+		return nil
+	}
+	o := &scanOverhead{DiskBytes: store.DiskUsage(pipeline.CachePath())}
+	if issues, err := cache.ParseIssues(); err == nil {
+		o.ParseIssues = len(issues)
+		for _, pi := range issues {
+			if pi.Quarantined {
+				o.Quarantined++
+			}
+		}
+	}
+	today := now.Local().Format("2006-01-02")
+	for _, d := range days {
+		if d.Day == today {
+			o.Today = d
+		}
+		o.Week.Scans += d.Scans
+		o.Week.FilesParsed += d.FilesParsed
+		o.Week.Duration += d.Duration
+	}
+	return o
+}
+
+// tailPollCmd polls the live tail follower in the background.
+// Only one poll runs at a time (guarded by App.tailPolling).
+func tailPollCmd(f *source.Follower) tea.Cmd {
+	return func() tea.Msg {
+		upd, err := f.Poll()
+		return TailMsg{Update: upd, Err: err}
+	}
+}
+
 // refreshDataCmd refreshes session data in the background (no progress UI).
-func refreshDataCmd(claudeDir string, includeSubagents bool) tea.Cmd {
+func refreshDataCmd(roots []source.Root, includeSubagents bool) tea.Cmd {
 	return func() tea.Msg {
 		start := time.Now()
 
 		cache, err := storeOpen()
 		if err == nil {
-			cr, loadErr := pipeline.LoadWithCache(claudeDir, includeSubagents, cache, nil)
+			cr, loadErr := pipeline.LoadWithCache(roots, includeSubagents, cache, nil)
+			overhead := readScanOverhead(cache, time.Now())
 			_ = cache.Close()
 			if loadErr == nil {
 				return RefreshDataMsg{
 					Sessions: cr.Sessions,
 					LoadTime: time.Since(start),
+					Overhead: overhead,
 				}
 			}
 		}
 
 		// Fallback: uncached load
-		result, err := pipeline.Load(claudeDir, includeSubagents, nil)
+		result, err := pipeline.Load(roots, includeSubagents, nil)
 		if err != nil {
-			return RefreshDataMsg{LoadTime: time.Since(start)}
+			return RefreshDataMsg{LoadTime: time.Since(start), Err: fmt.Errorf("loading sessions: %w", err)}
 		}
 		return RefreshDataMsg{
 			Sessions: result.Sessions,
@@ -1139,8 +1775,121 @@ func fillLinesWithBackground(s string, w int, bg lipgloss.Color) string {
 	return result.String()
 }
 
-// fetchSubDataCmd fetches subscription data from claude.ai in a background goroutine.
-func fetchSubDataCmd(sessionKey string) tea.Cmd {
+// noteRLResets remembers the latest rate-limit window reset for display.
+func (a *App) noteRLResets(resets []claudeai.WindowReset) {
+	for i := range resets {
+		a.lastRLReset = &resets[i]
+		a.toast(components.ToastSuccess, resets[i].Text())
+	}
+}
+
+const (
+	toastFor      = 4 * time.Second
+	toastErrorFor = 8 * time.Second // errors stay up long enough to read
+	maxToasts     = 3
+)
+
+// toast shows a transient notification, dropping the oldest past maxToasts.
+func (a *App) toast(level components.ToastLevel, text string) {
+	d := toastFor
+	if level == components.ToastError {
+		d = toastErrorFor
+	}
+	a.toasts = append(a.toasts, components.Toast{Text: text, Level: level, Until: time.Now().Add(d)})
+	if n := len(a.toasts); n > maxToasts {
+		a.toasts = a.toasts[n-maxToasts:]
+	}
+}
+
+// pruneToasts drops toasts whose time is up.
+func (a *App) pruneToasts(now time.Time) {
+	kept := a.toasts[:0]
+	for _, t := range a.toasts {
+		if now.Before(t.Until) {
+			kept = append(kept, t)
+		}
+	}
+	a.toasts = kept
+}
+
+// noteSubProblem toasts a failed or stale claude.ai fetch. The same problem
+// is reported once, not on every periodic refresh.
+func (a *App) noteSubProblem(d *claudeai.SubscriptionData) {
+	problem := ""
+	switch {
+	case d == nil:
+	case d.Error != nil && errors.Is(d.Error, claudeai.ErrUnauthorized):
+		problem = "claude.ai session key expired — run cburn auth import-browser"
+	case d.Error != nil:
+		problem = "claude.ai fetch failed: " + d.Error.Error()
+	case d.Stale:
+		problem = "claude.ai unreachable; showing data from " + d.FetchedAt.Local().Format("3:04 PM")
+	}
+	if problem == a.lastSubErr {
+		return
+	}
+	a.lastSubErr = problem
+	if problem != "" {
+		a.toast(components.ToastError, problem)
+	}
+}
+
+// budgetToastSteps are the fractions of the monthly budget that raise a toast.
+var budgetToastSteps = []float64{0.8, 1.0}
+
+// checkBudget toasts when month-to-date cost reaches a new budget step.
+func (a *App) checkBudget(now time.Time) {
+	cfg := loadConfigOrDefault()
+	if cfg.Budget.MonthlyUSD == nil || *cfg.Budget.MonthlyUSD <= 0 {
+		return
+	}
+	bp := pipeline.AggregateBudget(pipeline.WithoutExcluded(a.sessions), *cfg.Budget.MonthlyUSD, cfg.Budget.BillingDay, now)
+	budget, cost := bp.Budget, bp.Spent
+
+	if month := bp.Start.Format("2006-01-02"); month != a.budgetMonth {
+		a.budgetMonth = month
+		a.budgetLevel = 0
+	}
+	level := 0
+	for _, step := range budgetToastSteps {
+		if cost >= step*budget {
+			level++
+		}
+	}
+	if level <= a.budgetLevel {
+		return
+	}
+	a.budgetLevel = level
+	a.toast(components.ToastWarn, fmt.Sprintf("%.0f%% of monthly budget used: %s of %s",
+		budgetToastSteps[level-1]*100, cli.FormatCostFixed(cost, 2), cli.FormatCostFixed(budget, 2)))
+}
+
+// checkAnomaly updates the banner for today's spend spiking against the
+// week before, as a runaway agent loop would make it.
+func (a *App) checkAnomaly(now time.Time) {
+	a.anomaly = nil
+	if an, ok := pipeline.TodayAnomaly(pipeline.WithoutExcluded(a.sessions), now); ok {
+		a.anomaly = &an
+	}
+}
+
+// renderAnomalyBanner is the full-width warning line under the filter row
+// while a.anomaly is set.
+func (a App) renderAnomalyBanner(w int) string {
+	t := theme.Active
+	text := fmt.Sprintf(" ! Spend today is %.1fx normal: %s against a median of %s/day over the last week. Runaway agent loop?",
+		a.anomaly.Ratio(), cli.FormatCostFixed(a.anomaly.Cost, 2), cli.FormatCostFixed(a.anomaly.Median, 2))
+	return lipgloss.NewStyle().
+		Foreground(t.Background).
+		Background(t.Orange).
+		Bold(true).
+		Width(w).
+		Render(ansi.Truncate(text, w, "…"))
+}
+
+// fetchSubDataCmd fetches subscription data for the preferred organization
+// from claude.ai in a background goroutine.
+func fetchSubDataCmd(sessionKey, orgID string) tea.Cmd {
 	return func() tea.Msg {
 		client := claudeai.NewClient(sessionKey)
 		if client == nil {
@@ -1151,7 +1900,7 @@ func fetchSubDataCmd(sessionKey string) tea.Cmd {
 		}
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
-		return SubDataMsg{Data: client.FetchAll(ctx)}
+		return SubDataMsg{Data: client.WithCache(pipeline.CacheDir()).FetchAll(ctx, orgID)}
 	}
 }
 
@@ -1188,7 +1937,15 @@ func (a App) updateSessionsSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch key {
 	case "enter":
 		// Apply search and exit search mode
-		a.sessState.searchQuery = strings.TrimSpace(a.sessState.searchInput.Value())
+		query := strings.TrimSpace(a.sessState.searchInput.Value())
+		if _, err := parseSessionSearch(query); err != nil {
+			a.toast(components.ToastError, "Search: "+err.Error())
+			return a, nil
+		}
+		if query != a.sessState.searchQuery {
+			a.viewName = ""
+		}
+		a.sessState.searchQuery = query
 		a.sessState.searching = false
 		a.sessState.cursor = 0
 		a.sessState.offset = 0
@@ -1212,5 +1969,7 @@ func (a App) getSearchFilteredSessions() []model.SessionStats {
 	if a.sessState.searchQuery == "" {
 		return a.filtered
 	}
-	return filterSessionsBySearch(a.filtered, a.sessState.searchQuery)
+	// The applied query was checked when it was entered.
+	filtered, _ := filterSessionsBySearch(a.filtered, a.sessState.searchQuery)
+	return filtered
 }
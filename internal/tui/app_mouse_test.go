@@ -1,20 +1,27 @@
 package tui
 
-import "testing"
+import (
+	"fmt"
+	"testing"
+
+	"github.com/theirongolddev/cburn/internal/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
 
 func TestTabAtXMatchesTabWidths(t *testing.T) {
-	for active := 0; active < 5; active++ {
+	for active := 0; active < 7; active++ {
 		a := App{activeTab: active}
 		pos := 0
 
-		for i := 0; i < 5; i++ {
+		for i := 0; i < 7; i++ {
 			w := tabWidthForTest(i, active)
 			x := pos + w/2 // midpoint inside this tab
 			if got := a.tabAtX(x); got != i {
 				t.Fatalf("active=%d x=%d -> tab=%d, want %d", active, x, got, i)
 			}
 			pos += w
-			if i < 4 {
+			if i < 6 {
 				pos++ // separator
 			}
 		}
@@ -27,12 +34,57 @@ func tabWidthForTest(tabIdx, activeIdx int) int {
 		len("Costs"),
 		len("Sessions"),
 		len("Breakdown"),
+		len("Insights"),
+		len("Team"),
 		len("Settings"),
 	}
 
 	w := nameWidths[tabIdx] + 2 // horizontal padding in tab renderer
-	if tabIdx != activeIdx && tabIdx == 4 {
+	if tabIdx != activeIdx && tabIdx == 6 {
 		w += 3 // inactive Settings adds "[x]"
 	}
 	return w
 }
+
+func TestProjectColumnAt(t *testing.T) {
+	cols := projectColumns(80, false)
+	nameW := cols[0].width
+	for x, want := range map[int]int{
+		0:               projSortProject,
+		nameW:           -1, // gap
+		nameW + 1:       projSortSessions,
+		nameW + 8:       projSortPrompts,
+		nameW + 17:      projSortTokens,
+		nameW + 28:      projSortCost,
+		nameW + 28 + 9:  projSortCost,
+		nameW + 28 + 10: -1,
+	} {
+		if got := projectColumnAt(cols, x); got != want {
+			t.Errorf("x=%d: got column %d, want %d", x, got, want)
+		}
+	}
+}
+
+func TestClickSessionsSelectsRow(t *testing.T) {
+	a := App{width: 160, height: 40, loaded: true, activeTab: 2}
+	for i := 0; i < 10; i++ {
+		a.filtered = append(a.filtered, model.SessionStats{SessionID: fmt.Sprintf("s%d", i)})
+	}
+	x0, y0, _ := a.contentOrigin()
+	click := func(a App, row int) App {
+		m, _ := a.updateMouse(tea.MouseMsg{
+			X: x0 + 5, Y: y0 + cardBodyTop + row,
+			Button: tea.MouseButtonLeft, Action: tea.MouseActionPress,
+		})
+		return m.(App)
+	}
+
+	a = click(a, 4)
+	if a.sessState.cursor != 4 || a.sessState.viewMode != sessViewSplit {
+		t.Fatalf("cursor=%d mode=%d, want row 4 selected in the split view", a.sessState.cursor, a.sessState.viewMode)
+	}
+	a = click(a, 4)
+	if a.sessState.viewMode != sessViewDetail {
+		t.Error("clicking the selected row again did not open its detail")
+	}
+}
@@ -0,0 +1,299 @@
+package tui
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/cli"
+	"github.com/theirongolddev/cburn/internal/model"
+	"github.com/theirongolddev/cburn/internal/redact"
+	"github.com/theirongolddev/cburn/internal/store"
+	"github.com/theirongolddev/cburn/internal/tui/components"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// selectionRange returns the first and last list index of the visual
+// selection: every row between where v was pressed and the cursor.
+func (ss sessionsState) selectionRange() (lo, hi int) {
+	return min(ss.anchor, ss.cursor), max(ss.anchor, ss.cursor)
+}
+
+// inSelection reports whether list row i is part of the visual selection.
+func (ss sessionsState) inSelection(i int) bool {
+	if !ss.visual {
+		return false
+	}
+	lo, hi := ss.selectionRange()
+	return i >= lo && i <= hi
+}
+
+// selectedSessions returns the visually selected sessions, or just the one
+// under the cursor outside visual mode.
+func (a App) selectedSessions() []model.SessionStats {
+	sessions := a.getSearchFilteredSessions()
+	if len(sessions) == 0 {
+		return nil
+	}
+	lo, hi := a.sessState.cursor, a.sessState.cursor
+	if a.sessState.visual {
+		lo, hi = a.sessState.selectionRange()
+	}
+	hi = min(hi, len(sessions)-1)
+	if lo > hi {
+		return nil
+	}
+	return sessions[lo : hi+1]
+}
+
+// updateVisual handles the bulk actions of visual-select mode. It reports
+// false for keys it leaves to the normal Sessions bindings, like j/k.
+func (a App) updateVisual(key string) (tea.Model, tea.Cmd, bool) {
+	switch key {
+	case "esc", "v":
+		a.sessState.visual = false
+		return a, nil, true
+	case "X":
+		m, cmd := a.bulkExclude()
+		return m, cmd, true
+	case "n", "#":
+		m, cmd := a.startBulkTag()
+		return m, cmd, true
+	case "e":
+		return a.exportSelection("csv"), nil, true
+	case "E":
+		return a.exportSelection("json"), nil, true
+	case "y":
+		return a.copySelectionSummary(), nil, true
+	}
+	return a, nil, false
+}
+
+// bulkExclude hides the selected sessions, with their subagents, from every
+// total; when all of them are hidden already it restores them instead.
+func (a App) bulkExclude() (tea.Model, tea.Cmd) {
+	sel := a.selectedSessions()
+	if len(sel) == 0 {
+		return a, nil
+	}
+	exclude := slices.ContainsFunc(sel, func(s model.SessionStats) bool { return !s.Excluded })
+	var ids []string
+	for _, s := range sel {
+		ids = append(ids, s.SessionID)
+		for _, sub := range a.subagentMap[s.SessionID] {
+			ids = append(ids, sub.SessionID)
+		}
+	}
+
+	cache, err := storeOpen()
+	if err != nil {
+		a.toast(components.ToastError, "Hiding sessions failed: "+err.Error())
+		return a, nil
+	}
+	defer func() { _ = cache.Close() }()
+	for _, id := range ids {
+		if err := cache.SetExcluded(id, exclude); err != nil {
+			a.toast(components.ToastError, "Hiding sessions failed: "+err.Error())
+			return a, nil
+		}
+	}
+
+	for i := range a.sessions {
+		if slices.Contains(ids, a.sessions[i].SessionID) {
+			a.sessions[i].Excluded = exclude
+		}
+	}
+	a.sessState.visual = false
+	a.recompute()
+	if exclude {
+		a.toast(components.ToastInfo, fmt.Sprintf("%d sessions hidden from totals (H lists hidden sessions)", len(sel)))
+	} else {
+		a.toast(components.ToastInfo, fmt.Sprintf("%d sessions restored to totals", len(sel)))
+	}
+	return a, nil
+}
+
+// startBulkTag opens the note editor to add #tags to every selected session.
+func (a App) startBulkTag() (tea.Model, tea.Cmd) {
+	sel := a.selectedSessions()
+	if len(sel) == 0 {
+		return a, nil
+	}
+	ids := make([]string, len(sel))
+	for i, s := range sel {
+		ids[i] = s.SessionID
+	}
+
+	ti := textinput.New()
+	ti.Prompt = ""
+	ti.Placeholder = "#billable #client-x"
+	ti.CharLimit = 200
+	ti.Width = 72
+	ti.Focus()
+
+	a.sessState.annotating = true
+	a.sessState.tagSessions = ids
+	a.sessState.noteInput = ti
+	return a, ti.Cursor.BlinkCmd()
+}
+
+// saveBulkTags adds the tags typed into the note editor to each session in
+// a.sessState.tagSessions, keeping their notes and existing tags.
+func (a *App) saveBulkTags() {
+	ids := a.sessState.tagSessions
+	a.sessState.tagSessions = nil
+	tags := parseAnnotation(a.sessState.noteInput.Value()).Tags
+	if len(tags) == 0 {
+		a.toast(components.ToastInfo, "No #tags given; nothing changed")
+		return
+	}
+
+	cache, err := storeOpen()
+	if err != nil {
+		a.toast(components.ToastError, "Tagging sessions failed: "+err.Error())
+		return
+	}
+	defer func() { _ = cache.Close() }()
+	for _, id := range ids {
+		i := slices.IndexFunc(a.sessions, func(s model.SessionStats) bool { return s.SessionID == id })
+		if i < 0 {
+			continue
+		}
+		ann := store.Annotation{Note: a.sessions[i].Note, Tags: slices.Clone(a.sessions[i].UserTags)}
+		for _, tag := range tags {
+			if !slices.Contains(ann.Tags, tag) {
+				ann.Tags = append(ann.Tags, tag)
+			}
+		}
+		if err := cache.SetAnnotation(id, ann); err != nil {
+			a.toast(components.ToastError, "Tagging sessions failed: "+err.Error())
+			return
+		}
+		setAnnotation(a.sessions, id, ann)
+	}
+	a.sessState.visual = false
+	a.recompute()
+	a.toast(components.ToastInfo, fmt.Sprintf("Tagged %d sessions #%s", len(ids), strings.Join(tags, " #")))
+}
+
+// sessionExport is one session in an exported selection.
+type sessionExport struct {
+	SessionID    string    `json:"session_id"`
+	Project      string    `json:"project"`
+	Branch       string    `json:"branch,omitempty"`
+	StartTime    time.Time `json:"start_time"`
+	DurationSecs int64     `json:"duration_secs"`
+	Prompts      int       `json:"prompts"`
+	APICalls     int       `json:"api_calls"`
+	Tokens       int64     `json:"tokens"`
+	CostUSD      float64   `json:"cost_usd"`
+	Tags         []string  `json:"tags,omitempty"`
+	Note         string    `json:"note,omitempty"`
+}
+
+func newSessionExport(s model.SessionStats) sessionExport {
+	return sessionExport{
+		SessionID:    redact.ID(s.SessionID),
+		Project:      s.Project,
+		Branch:       s.GitBranch,
+		StartTime:    s.StartTime,
+		DurationSecs: s.DurationSecs,
+		Prompts:      s.UserMessages,
+		APICalls:     s.APICalls,
+		Tokens:       s.InputTokens + s.OutputTokens + s.CacheCreation5mTokens + s.CacheCreation1hTokens,
+		CostUSD:      s.EstimatedCost,
+		Tags:         s.UserTags,
+		Note:         s.Note,
+	}
+}
+
+// exportSelection writes the selected sessions to a csv or json file in the
+// working directory.
+func (a App) exportSelection(format string) App {
+	sel := a.selectedSessions()
+	if len(sel) == 0 {
+		return a
+	}
+	rows := make([]sessionExport, len(sel))
+	for i, s := range sel {
+		rows[i] = newSessionExport(s)
+	}
+	path := fmt.Sprintf("cburn-sessions-%s.%s", time.Now().Format("20060102-150405"), format)
+	if err := writeSessionExport(path, format, rows); err != nil {
+		a.toast(components.ToastError, "Export failed: "+err.Error())
+		return a
+	}
+	a.toast(components.ToastSuccess, fmt.Sprintf("Exported %d sessions to %s", len(rows), path))
+	return a
+}
+
+func writeSessionExport(path, format string, rows []sessionExport) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	if format == "json" {
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(rows)
+	} else {
+		err = writeSessionCSV(f, rows)
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+func writeSessionCSV(f *os.File, rows []sessionExport) error {
+	w := csv.NewWriter(f)
+	_ = w.Write([]string{"session_id", "project", "branch", "start_time", "duration_secs", "prompts", "api_calls", "tokens", "cost_usd", "tags", "note"})
+	for _, r := range rows {
+		_ = w.Write([]string{
+			r.SessionID, r.Project, r.Branch, r.StartTime.Format(time.RFC3339),
+			strconv.FormatInt(r.DurationSecs, 10), strconv.Itoa(r.Prompts), strconv.Itoa(r.APICalls),
+			strconv.FormatInt(r.Tokens, 10), strconv.FormatFloat(r.CostUSD, 'f', 4, 64),
+			strings.Join(r.Tags, " "), r.Note,
+		})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// selectionSummary is the plain-text summary of sessions copied with y.
+func selectionSummary(sel []model.SessionStats) string {
+	var b strings.Builder
+	var total float64
+	for _, s := range sel {
+		total += s.EstimatedCost
+	}
+	fmt.Fprintf(&b, "%d Claude Code sessions, %s\n", len(sel), cli.FormatCostFixed(total, 2))
+	for _, s := range sel {
+		fmt.Fprintf(&b, "- %s %s %s (%s, %d prompts)\n",
+			s.StartTime.Local().Format("2006-01-02 15:04"), s.Project, cli.FormatCostFixed(s.EstimatedCost, 2),
+			cli.FormatDuration(s.DurationSecs), s.UserMessages)
+	}
+	return b.String()
+}
+
+// copySelectionSummary copies a cost summary of the selection to the
+// system clipboard.
+func (a App) copySelectionSummary() App {
+	sel := a.selectedSessions()
+	if len(sel) == 0 {
+		return a
+	}
+	if err := copyText(selectionSummary(sel)); err != nil {
+		a.toast(components.ToastError, "Copy failed: "+err.Error())
+		return a
+	}
+	a.toast(components.ToastSuccess, fmt.Sprintf("Copied a summary of %d sessions", len(sel)))
+	return a
+}
@@ -0,0 +1,72 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+func TestVisualSelection(t *testing.T) {
+	a := App{filtered: []model.SessionStats{{SessionID: "a"}, {SessionID: "b"}, {SessionID: "c"}, {SessionID: "d"}}}
+	a.sessState.cursor = 1
+	if sel := a.selectedSessions(); len(sel) != 1 || sel[0].SessionID != "b" {
+		t.Fatalf("outside visual mode = %v, want the cursor row", sel)
+	}
+
+	a.sessState.visual, a.sessState.anchor, a.sessState.cursor = true, 3, 1 // selected upwards
+	sel := a.selectedSessions()
+	if len(sel) != 3 || sel[0].SessionID != "b" || sel[2].SessionID != "d" {
+		t.Fatalf("selection = %v, want b..d", sel)
+	}
+	if a.sessState.inSelection(0) || !a.sessState.inSelection(2) {
+		t.Error("inSelection disagrees with the range")
+	}
+
+	m, _, ok := a.updateVisual("esc")
+	if a = m.(App); !ok || a.sessState.visual {
+		t.Error("esc should leave visual mode")
+	}
+}
+
+func TestWriteSessionExport(t *testing.T) {
+	rows := []sessionExport{{
+		SessionID: "s1", Project: "app", StartTime: time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC),
+		Prompts: 3, CostUSD: 1.5, Tags: []string{"billable", "x"}, Note: "big, refactor",
+	}}
+	dir := t.TempDir()
+
+	csvPath := filepath.Join(dir, "out.csv")
+	if err := writeSessionExport(csvPath, "csv", rows); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(csvPath)
+	if want := "s1,app,,2026-03-01T09:00:00Z,0,3,0,0,1.5000,billable x,\"big, refactor\"\n"; !strings.HasSuffix(string(data), want) {
+		t.Errorf("csv = %q", data)
+	}
+
+	jsonPath := filepath.Join(dir, "out.json")
+	if err := writeSessionExport(jsonPath, "json", rows); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(jsonPath); !strings.Contains(string(data), `"cost_usd": 1.5`) {
+		t.Errorf("json = %s", data)
+	}
+	if err := writeSessionExport(jsonPath, "json", rows); err == nil {
+		t.Error("export overwrote an existing file")
+	}
+}
+
+func TestSessionSummary(t *testing.T) {
+	s := model.SessionStats{
+		SessionID: "abc123", Project: "app", StartTime: time.Date(2026, 3, 1, 9, 30, 0, 0, time.Local),
+		UserMessages: 4, APICalls: 20, InputTokens: 1500, OutputTokens: 500, EstimatedCost: 2.5,
+	}
+	want := "Claude Code session abc123 (app, 2026-03-01 09:30): $2.50, 4 prompts, 20 API calls, 2.0K tokens"
+	if got := sessionSummary(s); got != want {
+		t.Errorf("sessionSummary = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,76 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/theirongolddev/cburn/internal/cli"
+	"github.com/theirongolddev/cburn/internal/model"
+	"github.com/theirongolddev/cburn/internal/tui/components"
+
+	"github.com/atotto/clipboard"
+	"github.com/aymanbagabas/go-osc52/v2"
+)
+
+// overSSH reports whether cburn runs in an SSH session, where the system
+// clipboard belongs to the remote machine rather than the user's.
+func overSSH() bool {
+	return os.Getenv("SSH_TTY") != "" || os.Getenv("SSH_CONNECTION") != ""
+}
+
+// copyText puts text on the clipboard: the system clipboard locally, or the
+// terminal's through an OSC 52 escape over SSH and wherever no clipboard
+// tool is installed. Terminals may ignore OSC 52, so that path can't
+// confirm the copy worked.
+func copyText(text string) error {
+	if !overSSH() {
+		if err := clipboard.WriteAll(text); err == nil {
+			return nil
+		}
+	}
+	seq := osc52.New(text)
+	if os.Getenv("TMUX") != "" {
+		seq = seq.Tmux()
+	} else if strings.HasPrefix(os.Getenv("TERM"), "screen") {
+		seq = seq.Screen()
+	}
+	// stderr reaches the same terminal without racing the renderer on stdout.
+	_, err := seq.WriteTo(os.Stderr)
+	return err
+}
+
+// sessionSummary is the one-line cost summary copied with y s.
+func sessionSummary(s model.SessionStats) string {
+	return fmt.Sprintf("Claude Code session %s (%s, %s): %s, %d prompts, %d API calls, %s tokens",
+		s.SessionID, s.Project, s.StartTime.Local().Format("2006-01-02 15:04"),
+		cli.FormatCostFixed(s.EstimatedCost, 2), s.UserMessages, s.APICalls,
+		cli.FormatTokens(s.InputTokens+s.OutputTokens+s.CacheCreation5mTokens+s.CacheCreation1hTokens))
+}
+
+// copySession copies the selected session's ID (what "i"), file path ("p"),
+// or cost summary ("s"), after y.
+func (a App) copySession(what string) App {
+	sel := a.selectedSessions()
+	if len(sel) == 0 {
+		return a
+	}
+	s := sel[0]
+	var text, label string
+	switch what {
+	case "i":
+		text, label = s.SessionID, "session ID"
+	case "p":
+		text, label = s.FilePath, "file path"
+	case "s":
+		text, label = sessionSummary(s), "cost summary"
+	default:
+		return a
+	}
+	if err := copyText(text); err != nil {
+		a.toast(components.ToastError, "Copy failed: "+err.Error())
+		return a
+	}
+	a.toast(components.ToastSuccess, "Copied "+label)
+	return a
+}
@@ -49,6 +49,13 @@ func Sparkline(values []float64, color lipgloss.Color) string {
 
 // BarChart renders a visually polished bar chart with gradient-style coloring.
 func BarChart(values []float64, labels []string, color lipgloss.Color, width, height int) string {
+	return BarChartWithCursor(values, labels, color, width, height, -1)
+}
+
+// BarChartWithCursor renders a BarChart with the bar at index cursor drawn
+// in a highlight color. A negative cursor highlights nothing. When bars are
+// down-sampled to fit the width, the nearest sampled bar is highlighted.
+func BarChartWithCursor(values []float64, labels []string, color lipgloss.Color, width, height, cursor int) string {
 	if len(values) == 0 {
 		return ""
 	}
@@ -56,6 +63,79 @@ func BarChart(values []float64, labels []string, color lipgloss.Color, width, he
 		return Sparkline(values, color)
 	}
 
+	t := theme.Active
+	// Gradient coloring based on row height
+	return renderBarChart(values, labels, width, height, cursor, func(_ int, _, rowPct float64) lipgloss.Color {
+		switch {
+		case rowPct > 0.8:
+			return t.AccentBright
+		case rowPct > 0.5:
+			return color
+		default:
+			return t.Accent
+		}
+	})
+}
+
+// StackedBarChart renders bars split into segments: stacks[i][k] is segment k
+// of bar i, drawn bottom-up in colors[k]. Layout, axes, and cursor behave as
+// in BarChartWithCursor.
+func StackedBarChart(stacks [][]float64, colors []lipgloss.Color, labels []string, width, height, cursor int) string {
+	if len(stacks) == 0 || len(colors) == 0 {
+		return ""
+	}
+	totals := make([]float64, len(stacks))
+	for i, segs := range stacks {
+		for _, v := range segs {
+			totals[i] += v
+		}
+	}
+	if width < 15 || height < 3 {
+		return Sparkline(totals, colors[0])
+	}
+
+	return renderBarChart(totals, labels, width, height, cursor, func(bar int, level, _ float64) lipgloss.Color {
+		acc := 0.0
+		last := 0
+		for k, v := range stacks[bar] {
+			if v <= 0 {
+				continue
+			}
+			acc += v
+			last = k
+			if level < acc {
+				return colors[k%len(colors)]
+			}
+		}
+		return colors[last%len(colors)]
+	})
+}
+
+// ChartLegend renders "■ name" entries in their colors on one line.
+func ChartLegend(names []string, colors []lipgloss.Color) string {
+	t := theme.Active
+	spaceStyle := lipgloss.NewStyle().Background(t.Surface)
+	nameStyle := lipgloss.NewStyle().Foreground(t.TextMuted).Background(t.Surface)
+
+	var b strings.Builder
+	for i, name := range names {
+		if i > 0 {
+			b.WriteString(spaceStyle.Render("  "))
+		}
+		b.WriteString(lipgloss.NewStyle().Foreground(colors[i%len(colors)]).Background(t.Surface).Render("■ "))
+		b.WriteString(nameStyle.Render(name))
+	}
+	return b.String()
+}
+
+// cellColorFunc picks the color of one chart cell. bar is the index into the
+// caller's (un-sampled) values, level is the data value at the middle of the
+// filled part of the cell, and rowPct is the row's height in the chart (0-1).
+type cellColorFunc func(bar int, level, rowPct float64) lipgloss.Color
+
+// renderBarChart draws the axes, bars, and labels shared by BarChartWithCursor
+// and StackedBarChart.
+func renderBarChart(values []float64, labels []string, width, height, cursor int, cellColor cellColorFunc) string {
 	t := theme.Active
 
 	// Find max value
@@ -112,6 +192,10 @@ func BarChart(values []float64, labels []string, color lipgloss.Color, width, he
 	}
 
 	n := len(values)
+	srcIdx := make([]int, n) // displayed bar -> index into the caller's values
+	for i := range srcIdx {
+		srcIdx[i] = i
+	}
 
 	// Bar sizing
 	gap := 1
@@ -130,18 +214,24 @@ func BarChart(values []float64, labels []string, color lipgloss.Color, width, he
 			maxN = 2
 		}
 		sampled := make([]float64, maxN)
+		sampledIdx := make([]int, maxN)
 		var sampledLabels []string
 		if len(labels) == n {
 			sampledLabels = make([]string, maxN)
 		}
 		for i := range sampled {
-			srcIdx := i * (n - 1) / (maxN - 1)
-			sampled[i] = values[srcIdx]
+			src := i * (n - 1) / (maxN - 1)
+			sampled[i] = values[src]
+			sampledIdx[i] = src
 			if sampledLabels != nil {
-				sampledLabels[i] = labels[srcIdx]
+				sampledLabels[i] = labels[src]
 			}
 		}
+		if cursor >= 0 {
+			cursor = int(math.Round(float64(cursor) * float64(maxN-1) / float64(n-1)))
+		}
 		values = sampled
+		srcIdx = sampledIdx
 		labels = sampledLabels
 		n = maxN
 		barW = 2
@@ -153,8 +243,8 @@ func BarChart(values []float64, labels []string, color lipgloss.Color, width, he
 
 	blocks := []rune{' ', '▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
 
-	// Multi-color gradient for bars based on height
 	axisStyle := lipgloss.NewStyle().Foreground(t.TextDim).Background(t.Surface)
+	cursorStyle := lipgloss.NewStyle().Foreground(t.Yellow).Background(t.Surface)
 
 	var b strings.Builder
 
@@ -164,18 +254,6 @@ func BarChart(values []float64, labels []string, color lipgloss.Color, width, he
 		rowBottom := ceiling * float64(row-1) / float64(chartH)
 		rowPct := float64(row) / float64(chartH) // How high in the chart (0=bottom, 1=top)
 
-		// Choose bar color based on row height (gradient effect)
-		var barColor lipgloss.Color
-		switch {
-		case rowPct > 0.8:
-			barColor = t.AccentBright
-		case rowPct > 0.5:
-			barColor = color
-		default:
-			barColor = t.Accent
-		}
-		barStyle := lipgloss.NewStyle().Foreground(barColor).Background(t.Surface)
-
 		label := tickLabels[row]
 		b.WriteString(axisStyle.Render(fmt.Sprintf("%*s", yLabelW, label)))
 		b.WriteString(axisStyle.Render("│"))
@@ -184,9 +262,14 @@ func BarChart(values []float64, labels []string, color lipgloss.Color, width, he
 			if i > 0 && gap > 0 {
 				b.WriteString(lipgloss.NewStyle().Background(t.Surface).Render(strings.Repeat(" ", gap)))
 			}
+			style := cursorStyle
+			if i != cursor && v > rowBottom {
+				level := (rowBottom + math.Min(v, rowTop)) / 2
+				style = lipgloss.NewStyle().Foreground(cellColor(srcIdx[i], level, rowPct)).Background(t.Surface)
+			}
 			switch {
 			case v >= rowTop:
-				b.WriteString(barStyle.Render(strings.Repeat("█", barW)))
+				b.WriteString(style.Render(strings.Repeat("█", barW)))
 			case v > rowBottom:
 				frac := (v - rowBottom) / (rowTop - rowBottom)
 				idx := int(frac * 8)
@@ -196,7 +279,7 @@ func BarChart(values []float64, labels []string, color lipgloss.Color, width, he
 				if idx < 1 {
 					idx = 1
 				}
-				b.WriteString(barStyle.Render(strings.Repeat(string(blocks[idx]), barW)))
+				b.WriteString(style.Render(strings.Repeat(string(blocks[idx]), barW)))
 			default:
 				b.WriteString(lipgloss.NewStyle().Background(t.Surface).Render(strings.Repeat(" ", barW)))
 			}
@@ -207,7 +290,15 @@ func BarChart(values []float64, labels []string, color lipgloss.Color, width, he
 	// X-axis line with 0 label
 	b.WriteString(axisStyle.Render(fmt.Sprintf("%*s", yLabelW, "0")))
 	b.WriteString(axisStyle.Render("└"))
-	b.WriteString(axisStyle.Render(strings.Repeat("─", axisLen)))
+	if cursor >= 0 && cursor < n {
+		// Mark the selected bar on the axis so zero-height bars are still visible.
+		pre := cursor * (barW + gap)
+		b.WriteString(axisStyle.Render(strings.Repeat("─", pre)))
+		b.WriteString(cursorStyle.Render(strings.Repeat("▲", barW)))
+		b.WriteString(axisStyle.Render(strings.Repeat("─", axisLen-pre-barW)))
+	} else {
+		b.WriteString(axisStyle.Render(strings.Repeat("─", axisLen)))
+	}
 
 	// X-axis labels
 	if len(labels) == n && n > 0 {
@@ -0,0 +1,50 @@
+package components
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/theirongolddev/cburn/internal/tui/theme"
+)
+
+var sgrRe = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+func TestBarChartWithCursorMarksAxis(t *testing.T) {
+	theme.SetActive("flexoki-dark")
+	vals := []float64{1, 5, 3, 0}
+
+	plain := sgrRe.ReplaceAllString(BarChart(vals, nil, theme.Active.Blue, 40, 6), "")
+	if strings.Contains(plain, "▲") {
+		t.Fatal("BarChart without cursor should not mark the axis")
+	}
+
+	for cursor := range vals {
+		out := sgrRe.ReplaceAllString(BarChartWithCursor(vals, nil, theme.Active.Blue, 40, 6, cursor), "")
+		lines := strings.Split(out, "\n")
+		axis := []rune(lines[len(lines)-1])
+		start := strings.IndexRune(string(axis), '└')
+		if start < 0 {
+			t.Fatalf("no axis line in:\n%s", out)
+		}
+		// Each bar is 6 cells wide (capped) with a 1-cell gap.
+		want := len([]rune(string(axis)[:start])) + 1 + cursor*7
+		if axis[want] != '▲' {
+			t.Errorf("cursor %d: axis %q has no marker at %d", cursor, string(axis), want)
+		}
+	}
+}
+
+func TestStackedBarChartMatchesTotalsLayout(t *testing.T) {
+	theme.SetActive("flexoki-dark")
+	stacks := [][]float64{{1, 2}, {4, 0}, {0, 3}}
+	colors := []lipgloss.Color{theme.Active.Blue, theme.Active.Magenta}
+	labels := []string{"a", "b", "c"}
+
+	stacked := sgrRe.ReplaceAllString(StackedBarChart(stacks, colors, labels, 40, 6, -1), "")
+	plain := sgrRe.ReplaceAllString(BarChart([]float64{3, 4, 3}, labels, theme.Active.Blue, 40, 6), "")
+	if stacked != plain {
+		t.Errorf("stacked layout differs from totals:\n%s\nvs\n%s", stacked, plain)
+	}
+}
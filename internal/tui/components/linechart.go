@@ -0,0 +1,205 @@
+package components
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/theirongolddev/cburn/internal/tui/theme"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// LineSeries is one line in a LineChart.
+type LineSeries struct {
+	Name   string
+	Values []float64
+	Color  lipgloss.Color
+}
+
+// brailleBits maps a dot's (x, y) position within a 2x4 braille cell to its bit.
+var brailleBits = [4][2]rune{
+	{0x01, 0x08},
+	{0x02, 0x10},
+	{0x04, 0x20},
+	{0x40, 0x80},
+}
+
+// LineChart plots one or more series on a shared Y range using braille dots,
+// which gives 2x4 sub-cell resolution and stays readable over long ranges
+// where bars would be a single column wide. Series are drawn in order, so
+// where lines cross the later series' color wins. yFormat formats Y-axis
+// labels; nil uses compact token-style labels.
+func LineChart(series []LineSeries, labels []string, width, height int, yFormat func(float64) string) string {
+	n := 0
+	maxVal := 0.0
+	for _, s := range series {
+		if len(s.Values) > n {
+			n = len(s.Values)
+		}
+		for _, v := range s.Values {
+			if v > maxVal {
+				maxVal = v
+			}
+		}
+	}
+	if n == 0 {
+		return ""
+	}
+	if width < 15 || height < 3 {
+		return Sparkline(series[0].Values, series[0].Color)
+	}
+	if maxVal == 0 {
+		maxVal = 1
+	}
+	zeroLabel := "0"
+	if yFormat == nil {
+		yFormat = formatChartLabel
+	} else {
+		zeroLabel = yFormat(0)
+	}
+
+	t := theme.Active
+
+	tickStep := chartTickStep(maxVal)
+	ceiling := math.Ceil(maxVal/tickStep) * tickStep
+
+	yLabelW := max(len(yFormat(ceiling)), len(yFormat(ceiling/2))) + 1
+	if yLabelW < 4 {
+		yLabelW = 4
+	}
+	plotW := width - yLabelW - 1
+	if plotW < 5 {
+		plotW = 5
+	}
+
+	dotsW, dotsH := plotW*2, height*4
+	cells := make([][]rune, height)
+	owner := make([][]int, height) // series index that last drew in each cell
+	for r := range cells {
+		cells[r] = make([]rune, plotW)
+		owner[r] = make([]int, plotW)
+		for c := range owner[r] {
+			owner[r][c] = -1
+		}
+	}
+
+	set := func(si, x, y int) {
+		if x < 0 || x >= dotsW || y < 0 || y >= dotsH {
+			return
+		}
+		fromTop := dotsH - 1 - y
+		r, c := fromTop/4, x/2
+		cells[r][c] |= brailleBits[fromTop%4][x%2]
+		owner[r][c] = si
+	}
+
+	xFor := func(i int) int {
+		if n == 1 {
+			return dotsW / 2
+		}
+		return i * (dotsW - 1) / (n - 1)
+	}
+	yFor := func(v float64) int {
+		return int(math.Round(v / ceiling * float64(dotsH-1)))
+	}
+
+	for si, s := range series {
+		for i, v := range s.Values {
+			x, y := xFor(i), yFor(v)
+			if i == 0 {
+				set(si, x, y)
+				continue
+			}
+			// Connect to the previous point with a straight line.
+			px, py := xFor(i-1), yFor(s.Values[i-1])
+			steps := max(abs(x-px), abs(y-py))
+			for k := 1; k <= steps; k++ {
+				set(si, px+(x-px)*k/steps, py+(y-py)*k/steps)
+			}
+		}
+	}
+
+	axisStyle := lipgloss.NewStyle().Foreground(t.TextDim).Background(t.Surface)
+	spaceStyle := lipgloss.NewStyle().Background(t.Surface)
+	seriesStyles := make([]lipgloss.Style, len(series))
+	for i, s := range series {
+		seriesStyles[i] = lipgloss.NewStyle().Foreground(s.Color).Background(t.Surface)
+	}
+
+	var b strings.Builder
+	for r := 0; r < height; r++ {
+		label := ""
+		switch r {
+		case 0:
+			label = yFormat(ceiling)
+		case height / 2:
+			label = yFormat(ceiling / 2)
+		}
+		b.WriteString(axisStyle.Render(fmt.Sprintf("%*s", yLabelW, label)))
+		b.WriteString(axisStyle.Render("│"))
+
+		// Render runs of cells sharing a series as one styled string.
+		for c := 0; c < plotW; {
+			si := owner[r][c]
+			end := c
+			var run strings.Builder
+			for end < plotW && owner[r][end] == si {
+				if si < 0 {
+					run.WriteByte(' ')
+				} else {
+					run.WriteRune(0x2800 + cells[r][end])
+				}
+				end++
+			}
+			if si < 0 {
+				b.WriteString(spaceStyle.Render(run.String()))
+			} else {
+				b.WriteString(seriesStyles[si].Render(run.String()))
+			}
+			c = end
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(axisStyle.Render(fmt.Sprintf("%*s", yLabelW, zeroLabel)))
+	b.WriteString(axisStyle.Render("└" + strings.Repeat("─", plotW)))
+
+	if len(labels) == n && n > 0 {
+		buf := []byte(strings.Repeat(" ", plotW))
+		lastEnd := -1
+		place := func(i int) {
+			lbl := labels[i]
+			pos := xFor(i) / 2
+			if pos+len(lbl) > plotW {
+				pos = plotW - len(lbl)
+			}
+			if pos < 0 || pos <= lastEnd {
+				return
+			}
+			copy(buf[pos:], lbl)
+			lastEnd = pos + len(lbl)
+		}
+		step := max(1, n*8/(plotW+1))
+		for i := 0; i < n-1; i += step {
+			// Leave room for the last label.
+			if xFor(i)/2+len(labels[i]) < plotW-len(labels[n-1])-1 {
+				place(i)
+			}
+		}
+		place(n - 1)
+
+		b.WriteString("\n")
+		b.WriteString(spaceStyle.Render(strings.Repeat(" ", yLabelW+1)))
+		b.WriteString(axisStyle.Render(strings.TrimRight(string(buf), " ")))
+	}
+
+	return b.String()
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
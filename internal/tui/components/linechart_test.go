@@ -0,0 +1,28 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/theirongolddev/cburn/internal/tui/theme"
+)
+
+func TestLineChartLayout(t *testing.T) {
+	theme.SetActive("flexoki-dark")
+	series := []LineSeries{
+		{Name: "prev", Values: []float64{1, 1, 1, 1}, Color: theme.Active.TextDim},
+		{Name: "cur", Values: []float64{0, 2, 4, 8}, Color: theme.Active.Green},
+	}
+	out := sgrRe.ReplaceAllString(LineChart(series, []string{"a", "b", "c", "d"}, 40, 5, nil), "")
+	lines := strings.Split(out, "\n")
+	if len(lines) != 5+2 { // rows + axis + labels
+		t.Fatalf("got %d lines, want 7", len(lines))
+	}
+	// The peak (8) sits at the ceiling, so the top row must have dots.
+	if !strings.ContainsFunc(lines[0], func(r rune) bool { return r > 0x2800 && r <= 0x28FF }) {
+		t.Errorf("top row has no peak dot: %q", lines[0])
+	}
+	if !strings.HasPrefix(strings.TrimSpace(lines[len(lines)-1]), "a") {
+		t.Errorf("labels row = %q, want first label", lines[len(lines)-1])
+	}
+}
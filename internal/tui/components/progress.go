@@ -45,6 +45,50 @@ func ProgressBar(pct float64, width int) string {
 	return b.String() + spaceStyle.Render(" ") + pctStyle.Render(fmt.Sprintf("%.0f%%", pct*100))
 }
 
+// BudgetGauge renders budget spent as a bar with a marker at pace, the
+// share of the period elapsed, so spend ahead of the calendar shows at a
+// glance. The bar takes the color of projected, the month-end total as a
+// fraction of the budget: green under 80%, orange under 100%, red over.
+func BudgetGauge(spent, pace, projected float64, width int) string {
+	t := theme.Active
+	width = max(width, 4)
+	filled := max(0, min(int(spent*float64(width)), width))
+	marker := max(0, min(int(pace*float64(width)), width-1))
+
+	color := t.Green
+	switch {
+	case projected >= 1:
+		color = t.Red
+	case projected >= 0.8:
+		color = t.Orange
+	}
+	filledStyle := lipgloss.NewStyle().Foreground(color).Background(t.Surface)
+	emptyStyle := lipgloss.NewStyle().Foreground(t.TextDim).Background(t.Surface)
+	markerStyle := lipgloss.NewStyle().Foreground(t.TextPrimary).Background(t.Surface).Bold(true)
+	pctStyle := lipgloss.NewStyle().Foreground(color).Background(t.Surface).Bold(true)
+	spaceStyle := lipgloss.NewStyle().Background(t.Surface)
+
+	var b strings.Builder
+	for i := 0; i < width; i++ {
+		switch {
+		case i == marker:
+			b.WriteString(markerStyle.Render("│"))
+		case i < filled:
+			b.WriteString(filledStyle.Render("█"))
+		default:
+			b.WriteString(emptyStyle.Render("░"))
+		}
+	}
+	mark := ""
+	switch {
+	case projected >= 1 && theme.Accessible():
+		mark = "!!"
+	case projected >= 0.8 && theme.Accessible():
+		mark = "!"
+	}
+	return b.String() + spaceStyle.Render(" ") + pctStyle.Render(fmt.Sprintf("%.0f%%", spent*100)+mark)
+}
+
 // ColorForPct returns green/yellow/orange/red based on utilization level.
 func ColorForPct(pct float64) string {
 	t := theme.Active
@@ -60,6 +104,21 @@ func ColorForPct(pct float64) string {
 	}
 }
 
+// SeverityMark returns "!" from 70% and "!!" from 90% utilization, the
+// levels ColorForPct turns orange and red, when theme.Accessible; otherwise "".
+func SeverityMark(pct float64) string {
+	if !theme.Accessible() {
+		return ""
+	}
+	switch {
+	case pct >= 0.9:
+		return "!!"
+	case pct >= 0.7:
+		return "!"
+	}
+	return ""
+}
+
 // RateLimitBar renders a labeled progress bar with percentage and countdown.
 func RateLimitBar(label string, pct float64, resetsAt time.Time, labelW, barWidth int) string {
 	t := theme.Active
@@ -83,12 +142,12 @@ func RateLimitBar(label string, pct float64, resetsAt time.Time, labelW, barWidt
 	countdownStyle := lipgloss.NewStyle().Foreground(t.TextDim).Background(t.Surface)
 	spaceStyle := lipgloss.NewStyle().Background(t.Surface)
 
-	pctStr := fmt.Sprintf("%3.0f%%", pct*100)
+	pctStr := fmt.Sprintf("%3.0f%%", pct*100) + SeverityMark(pct)
 	countdown := ""
 	if !resetsAt.IsZero() {
 		dur := time.Until(resetsAt)
 		if dur > 0 {
-			countdown = formatCountdown(dur)
+			countdown = FormatCountdown(dur)
 		} else {
 			countdown = "now"
 		}
@@ -114,7 +173,8 @@ func CompactRateBar(label string, pct float64, width int) string {
 		pct = 1
 	}
 
-	barW := width - lipgloss.Width(label) - 6
+	mark := SeverityMark(pct)
+	barW := width - lipgloss.Width(label) - 6 - len(mark)
 	if barW < 4 {
 		barW = 4
 	}
@@ -134,10 +194,11 @@ func CompactRateBar(label string, pct float64, width int) string {
 		spaceStyle.Render(" ") +
 		bar.ViewAs(pct) +
 		spaceStyle.Render(" ") +
-		pctStyle.Render(fmt.Sprintf("%2.0f%%", pct*100))
+		pctStyle.Render(fmt.Sprintf("%2.0f%%", pct*100)+mark)
 }
 
-func formatCountdown(d time.Duration) string {
+// FormatCountdown renders a duration as "2d 3h", "1h 5m", or "12m".
+func FormatCountdown(d time.Duration) string {
 	h := int(d.Hours())
 	m := int(d.Minutes()) % 60
 	if h >= 24 {
@@ -0,0 +1,35 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/theirongolddev/cburn/internal/tui/theme"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestSeverityMark(t *testing.T) {
+	defer theme.SetActive(theme.Active.Name)
+
+	theme.SetActive(theme.FlexokiDark.Name)
+	if got := SeverityMark(0.95); got != "" {
+		t.Errorf("color theme marked severity: %q", got)
+	}
+
+	theme.SetActive(theme.HighContrast.Name)
+	for pct, want := range map[float64]string{0.5: "", 0.7: "!", 0.89: "!", 0.9: "!!", 1: "!!"} {
+		if got := SeverityMark(pct); got != want {
+			t.Errorf("SeverityMark(%v) = %q, want %q", pct, got, want)
+		}
+	}
+}
+
+func TestBudgetGauge(t *testing.T) {
+	got := ansi.Strip(BudgetGauge(0.5, 0.25, 0.9, 8))
+	if want := "██│█░░░░ 50%"; got != want {
+		t.Errorf("BudgetGauge = %q, want %q", got, want)
+	}
+	if got := ansi.Strip(BudgetGauge(1.4, 1, 1.4, 4)); got != "███│ 140%" {
+		t.Errorf("overspent gauge = %q", got)
+	}
+}
@@ -11,7 +11,9 @@ import (
 )
 
 // RenderStatusBar renders a polished bottom status bar with rate limits and controls.
-func RenderStatusBar(width int, dataAge string, subData *claudeai.SubscriptionData, refreshing, autoRefresh bool) string {
+// activeSessions, when non-zero, is shown as a live-session count;
+// refreshFailed replaces the data age with a warning while refreshes fail.
+func RenderStatusBar(width int, dataAge string, subData *claudeai.SubscriptionData, refreshing, autoRefresh, refreshFailed bool, activeSessions int) string {
 	t := theme.Active
 
 	// Main container
@@ -43,14 +45,26 @@ func RenderStatusBar(width int, dataAge string, subData *claudeai.SubscriptionDa
 	// Build middle section: rate limit indicators
 	middle := renderStatusRateLimits(subData)
 
-	// Build right section: refresh status
+	// Build right section: live sessions and refresh status
 	var right string
+	if activeSessions > 0 {
+		liveStyle := lipgloss.NewStyle().
+			Foreground(t.Green).
+			Background(t.SurfaceHover)
+		right = liveStyle.Render(fmt.Sprintf("● %d active", activeSessions)) + spaceStyle.Render("  ")
+	}
 	if refreshing {
 		spinnerStyle := lipgloss.NewStyle().
 			Foreground(t.AccentBright).
 			Background(t.SurfaceHover).
 			Bold(true)
-		right = spinnerStyle.Render("↻ refreshing")
+		right += spinnerStyle.Render("↻ refreshing")
+	} else if refreshFailed {
+		failStyle := lipgloss.NewStyle().
+			Foreground(t.Red).
+			Background(t.SurfaceHover).
+			Bold(true)
+		right += failStyle.Render("⚠ refresh failed")
 	} else if dataAge != "" {
 		refreshIcon := ""
 		if autoRefresh {
@@ -62,7 +76,7 @@ func RenderStatusBar(width int, dataAge string, subData *claudeai.SubscriptionDa
 		dataStyle := lipgloss.NewStyle().
 			Foreground(t.TextMuted).
 			Background(t.SurfaceHover)
-		right = refreshIcon + dataStyle.Render("Data: "+dataAge)
+		right += refreshIcon + dataStyle.Render("Data: "+dataAge)
 	}
 	right += spaceStyle.Render(" ")
 
@@ -21,6 +21,8 @@ var Tabs = []Tab{
 	{Name: "Costs", Key: 'c', KeyPos: 0},
 	{Name: "Sessions", Key: 's', KeyPos: 0},
 	{Name: "Breakdown", Key: 'b', KeyPos: 0},
+	{Name: "Insights", Key: 'i', KeyPos: 0},
+	{Name: "Team", Key: 't', KeyPos: 0},
 	{Name: "Settings", Key: 'x', KeyPos: -1},
 }
 
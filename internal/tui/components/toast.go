@@ -0,0 +1,77 @@
+package components
+
+import (
+	"strings"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/tui/theme"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// ToastLevel sets a toast's icon and color.
+type ToastLevel int
+
+// Toast levels, from least to most urgent.
+const (
+	ToastInfo ToastLevel = iota
+	ToastSuccess
+	ToastWarn
+	ToastError
+)
+
+// Toast is a transient one-line notification shown over the content area.
+type Toast struct {
+	Text  string
+	Level ToastLevel
+	Until time.Time // dismissed once this passes
+}
+
+// maxToastWidth caps a toast so it never covers most of a narrow terminal.
+const maxToastWidth = 60
+
+// RenderToast renders a single toast line at most width cells wide.
+func RenderToast(toast Toast, width int) string {
+	t := theme.Active
+
+	icon, color := "•", t.Accent
+	switch toast.Level {
+	case ToastSuccess:
+		icon, color = "✓", t.GreenBright
+	case ToastWarn:
+		icon, color = "!", t.Orange
+	case ToastError:
+		icon, color = "✗", t.Red
+	}
+
+	if width > maxToastWidth {
+		width = maxToastWidth
+	}
+	text := ansi.Truncate(toast.Text, width-5, "…")
+
+	iconStyle := lipgloss.NewStyle().Foreground(color).Background(t.SurfaceBright).Bold(true)
+	textStyle := lipgloss.NewStyle().Foreground(t.TextPrimary).Background(t.SurfaceBright)
+	return iconStyle.Render(" "+icon+" ") + textStyle.Render(text+" ")
+}
+
+// OverlayToasts draws toasts over the bottom-right corner of content, which
+// must already be padded to width columns. The newest toast is lowest.
+func OverlayToasts(content string, toasts []Toast, width int) string {
+	if len(toasts) == 0 || width < 20 {
+		return content
+	}
+	lines := strings.Split(content, "\n")
+	// Keep one blank row under the stack so it doesn't touch the status bar.
+	row := len(lines) - 1 - len(toasts)
+	if row < 0 {
+		return content
+	}
+	for _, toast := range toasts {
+		line := RenderToast(toast, width-2)
+		left := width - 1 - lipgloss.Width(line)
+		lines[row] = ansi.Truncate(lines[row], left, "") + line + ansi.TruncateLeft(lines[row], left+lipgloss.Width(line), "")
+		row++
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,32 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestOverlayToasts(t *testing.T) {
+	const width = 40
+	content := strings.TrimSuffix(strings.Repeat(strings.Repeat("x", width)+"\n", 5), "\n")
+
+	got := OverlayToasts(content, []Toast{{Text: "Settings saved", Level: ToastSuccess}}, width)
+	lines := strings.Split(got, "\n")
+	if len(lines) != 5 {
+		t.Fatalf("got %d lines, want 5", len(lines))
+	}
+	for i, line := range lines {
+		if w := lipgloss.Width(line); w != width {
+			t.Errorf("line %d width = %d, want %d", i, w, width)
+		}
+	}
+	toastLine := ansi.Strip(lines[3])
+	if !strings.HasPrefix(toastLine, "xxx") || !strings.Contains(toastLine, "Settings saved") || !strings.HasSuffix(toastLine, "x") {
+		t.Errorf("toast row = %q, want it spliced into the right of the content", toastLine)
+	}
+	if lines[4] != strings.Repeat("x", width) {
+		t.Errorf("bottom row was overwritten: %q", lines[4])
+	}
+}
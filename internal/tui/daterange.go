@@ -0,0 +1,115 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/pipeline"
+	"github.com/theirongolddev/cburn/internal/tui/components"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// WithDateRange fixes the dashboard to [since, until) instead of the last
+// days days, as set by --from / --to. Zero times keep the rolling window.
+func (a App) WithDateRange(since, until time.Time) App {
+	a.rangeSince, a.rangeUntil = since, until
+	return a
+}
+
+// period returns the time window the dashboard aggregates.
+func (a App) period(now time.Time) (since, until time.Time) {
+	if !a.rangeSince.IsZero() {
+		return a.rangeSince, a.rangeUntil
+	}
+	return now.AddDate(0, 0, -a.days), now
+}
+
+// periodLabel is the filter pill text for the current window.
+func (a App) periodLabel() string {
+	if !a.rangeSince.IsZero() {
+		return pipeline.DateRangeLabel(a.rangeSince, a.rangeUntil, time.Now())
+	}
+	return fmt.Sprintf("%dd", a.days)
+}
+
+func newRangeInput(current string) textinput.Model {
+	ti := textinput.New()
+	ti.Prompt = "Range: "
+	ti.Placeholder = "2025-11-01..2025-11-30, 2025-11-01.., 7 for the last 7 days, or billing"
+	ti.CharLimit = 40
+	ti.Width = 72
+	ti.SetValue(current)
+	ti.Focus()
+	return ti
+}
+
+// startRangeEdit opens the date-range input in the filter row.
+func (a App) startRangeEdit() (tea.Model, tea.Cmd) {
+	current := ""
+	if !a.rangeSince.IsZero() {
+		current = a.rangeSince.Local().Format(pipeline.DateLayout) + ".."
+		if a.rangeUntil.Before(time.Now()) {
+			current += a.rangeUntil.Add(-time.Nanosecond).Local().Format(pipeline.DateLayout)
+		}
+	}
+	a.rangeEditing = true
+	a.rangeInput = newRangeInput(current)
+	return a, a.rangeInput.Cursor.BlinkCmd()
+}
+
+// updateRangeInput handles keys while the date-range input is open.
+func (a App) updateRangeInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		a.rangeEditing = false
+		since, until, days, err := parseRangeInput(a.rangeInput.Value(), a.days, loadConfigOrDefault().Budget.BillingDay, time.Now())
+		if err != nil {
+			a.toast(components.ToastError, err.Error())
+			return a, nil
+		}
+		a.rangeSince, a.rangeUntil, a.days = since, until, days
+		a.viewName = ""
+		a.recompute()
+		a.toast(components.ToastInfo, "Showing "+a.periodLabel())
+		return a, nil
+	case "esc":
+		a.rangeEditing = false
+		return a, nil
+	}
+
+	var cmd tea.Cmd
+	a.rangeInput, cmd = a.rangeInput.Update(msg)
+	return a, cmd
+}
+
+// parseRangeInput reads "FROM..TO" (either side optional), a day count such
+// as "7" or "7d", "billing" for the current billing period (anchored on
+// anchorDay), or "" to return to the rolling window of days days.
+// A zero since means the rolling window.
+func parseRangeInput(s string, days, anchorDay int, now time.Time) (since, until time.Time, newDays int, err error) {
+	s = strings.TrimSpace(s)
+	switch strings.ToLower(s) {
+	case "":
+		return time.Time{}, time.Time{}, days, nil
+	case "billing", "b":
+		since, _ = pipeline.BillingPeriod(anchorDay, now)
+		return since, now, days, nil
+	}
+	if n, err := strconv.Atoi(strings.TrimSuffix(s, "d")); err == nil {
+		if n < 1 {
+			return time.Time{}, time.Time{}, days, errors.New("day count must be at least 1")
+		}
+		return time.Time{}, time.Time{}, n, nil
+	}
+	from, to, ok := strings.Cut(s, "..")
+	if !ok {
+		from, to = s, s // a single date
+	}
+	since, until, err = pipeline.ParseDateRange(strings.TrimSpace(from), strings.TrimSpace(to), days, now)
+	return since, until, days, err
+}
@@ -0,0 +1,177 @@
+package tui
+
+import (
+	"github.com/theirongolddev/cburn/internal/tui/components"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// wheelScrollLines is how far one wheel notch scrolls a text pane.
+const wheelScrollLines = 3
+
+// cardBodyTop is the offset from a titled ContentCard's top edge to its
+// first body line: border, title, separator.
+const cardBodyTop = 3
+
+// contentOrigin returns where viewMain draws the tab content: its left
+// column, top row, and height.
+func (a App) contentOrigin() (x, y, h int) {
+	header, _, contentH := a.mainLayout()
+	return (a.width - a.contentWidth()) / 2, lipgloss.Height(header), contentH
+}
+
+// updateMouse routes clicks and wheel scrolls to whatever is under the
+// pointer on the active tab.
+func (a App) updateMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		return a.mouseWheel(msg, -1)
+	case tea.MouseButtonWheelDown:
+		return a.mouseWheel(msg, 1)
+	case tea.MouseButtonLeft:
+		if msg.Action != tea.MouseActionPress {
+			return a, nil
+		}
+		// Check if click is in tab bar area (first 2 lines)
+		if msg.Y <= 1 {
+			if tab := a.tabAtX(msg.X); tab >= 0 && tab < len(components.Tabs) {
+				a.activeTab = tab
+			}
+			return a, nil
+		}
+		x0, y0, _ := a.contentOrigin()
+		switch a.activeTab {
+		case 2:
+			return a.clickSessions(msg.X-x0, msg.Y-y0)
+		case 3:
+			return a.clickBreakdown(msg.X-x0, msg.Y-y0)
+		case 6:
+			return a.clickSettings(msg.Y - y0)
+		}
+	}
+	return a, nil
+}
+
+func (a App) mouseWheel(msg tea.MouseMsg, delta int) (tea.Model, tea.Cmd) {
+	switch a.activeTab {
+	case 0, 1:
+		a.scrollPage(delta * wheelScrollLines)
+	case 2:
+		if a.sessState.searching || a.sessState.annotating {
+			return a, nil
+		}
+		if x0, _, _ := a.contentOrigin(); a.overSessionDetail(msg.X - x0) {
+			a.sessState.detailScroll = max(a.sessState.detailScroll+delta*wheelScrollLines, 0)
+			return a, nil
+		}
+		a.moveSessionCursor(delta)
+	case 3:
+		if !a.breakdown.detail {
+			a.breakdown.cursor = min(max(a.breakdown.cursor+delta, 0), max(len(a.projects)-1, 0))
+		}
+	case 6:
+		if !a.settings.editing && !a.settings.gallery {
+			a.settings.cursor = min(max(a.settings.cursor+delta, 0), settingsFieldCount-1)
+		}
+	}
+	return a, nil
+}
+
+// moveSessionCursor moves the session selection by delta rows.
+func (a *App) moveSessionCursor(delta int) {
+	n := len(a.getSearchFilteredSessions())
+	cursor := min(max(a.sessState.cursor+delta, 0), max(n-1, 0))
+	if cursor != a.sessState.cursor {
+		a.sessState.cursor = cursor
+		a.sessState.detailScroll = 0
+	}
+}
+
+// overSessionDetail reports whether content column x is over the session
+// detail, which fills the tab outside the split view.
+func (a App) overSessionDetail(x int) bool {
+	if a.isCompactLayout() || a.sessState.viewMode == sessViewDetail {
+		return true
+	}
+	leftW, ok := sessSplitLeftWidth(a.contentWidth())
+	return !ok || x >= leftW
+}
+
+// clickSessions selects the clicked row of the split view's list; clicking
+// the selected row again opens its detail.
+func (a App) clickSessions(x, y int) (tea.Model, tea.Cmd) {
+	ss := a.sessState
+	if ss.searching || ss.annotating || a.overSessionDetail(x) {
+		return a, nil
+	}
+	sessions := a.getSearchFilteredSessions()
+	if len(sessions) == 0 {
+		return a, nil
+	}
+	_, _, h := a.contentOrigin()
+	cursor := min(max(ss.cursor, 0), len(sessions)-1)
+	offset, end := ss.listWindow(cursor, len(sessions), h)
+	idx := offset + y - cardBodyTop
+	if y < cardBodyTop || idx >= end {
+		return a, nil
+	}
+	if idx == cursor {
+		a.sessState.viewMode = sessViewDetail
+		return a, nil
+	}
+	a.sessState.cursor = idx
+	a.sessState.detailScroll = 0
+	return a, nil
+}
+
+// clickBreakdown sorts the projects table by a clicked header and selects
+// a clicked row; clicking the selected row again opens the project.
+func (a App) clickBreakdown(x, y int) (tea.Model, tea.Cmd) {
+	if a.breakdown.detail {
+		return a, nil
+	}
+	cw := a.contentWidth()
+	top := lipgloss.Height(a.renderModelsTab(cw))
+	if len(a.latency) > 0 {
+		top += lipgloss.Height(a.renderLatencyCard(cw))
+	}
+	if len(a.tags) > 0 {
+		top += lipgloss.Height(a.renderTagsCard(cw))
+	}
+	if len(a.origins) > 0 {
+		top += lipgloss.Height(a.renderOriginsCard(cw))
+	}
+	if len(a.subagents) > 0 {
+		top += lipgloss.Height(a.renderSubagentsCard(cw))
+	}
+	row := y - top - cardBodyTop
+
+	switch {
+	case row == 0:
+		cols := projectColumns(components.CardInnerWidth(cw), a.isCompactLayout())
+		if by := projectColumnAt(cols, x-2); by >= 0 {
+			a.setProjectSort(by)
+		}
+	case row >= 2 && row-2 < len(a.projects):
+		if row-2 == a.breakdown.cursor {
+			a.breakdown.detail = true
+			return a, nil
+		}
+		a.breakdown.cursor = row - 2
+	}
+	return a, nil
+}
+
+// clickSettings selects and starts editing the clicked field.
+func (a App) clickSettings(y int) (tea.Model, tea.Cmd) {
+	if a.settings.editing || a.settings.gallery {
+		return a, nil
+	}
+	field := y - cardBodyTop
+	if field < 0 || field >= settingsFieldCount {
+		return a, nil
+	}
+	a.settings.cursor = field
+	return a.settingsStartEdit()
+}
@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/theirongolddev/cburn/internal/tui/components"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// EditorClosedMsg reports that the editor opened with o has exited and the
+// TUI is back.
+type EditorClosedMsg struct {
+	Err error
+}
+
+// editorCommand returns the command that opens path in $VISUAL or $EDITOR,
+// which may carry arguments ("code --wait"), or vi without either.
+func editorCommand(path string) *exec.Cmd {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	args := strings.Fields(editor)
+	if len(args) == 0 {
+		args = []string{"vi"}
+	}
+	return exec.Command(args[0], append(args[1:], path)...) //nolint:gosec // the user's own editor
+}
+
+// revealCommand returns the command that shows path in the platform's file
+// manager: selected in Finder or Explorer, or its folder elsewhere.
+func revealCommand(path string) *exec.Cmd {
+	switch runtime.GOOS {
+	case "darwin":
+		return exec.Command("open", "-R", path)
+	case "windows":
+		return exec.Command("explorer", "/select,"+path)
+	default:
+		return exec.Command("xdg-open", filepath.Dir(path))
+	}
+}
+
+// sessionFilePath returns the selected session's file, looked up in the
+// unredacted sessions so privacy mode doesn't mask the real path.
+func (a App) sessionFilePath() string {
+	sel := a.selectedSessions()
+	if len(sel) == 0 {
+		return ""
+	}
+	for _, s := range a.sessions {
+		if s.SessionID == sel[0].SessionID {
+			return s.FilePath
+		}
+	}
+	return ""
+}
+
+// openSessionFile opens the selected session's JSONL in the editor, with
+// the TUI suspended until it exits, or reveals it in the file manager.
+func (a App) openSessionFile(reveal bool) (tea.Model, tea.Cmd) {
+	path := a.sessionFilePath()
+	if path == "" {
+		return a, nil
+	}
+	if _, err := os.Stat(path); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			a.toast(components.ToastError, "Session file is gone: "+filepath.Base(path))
+		} else {
+			a.toast(components.ToastError, "Opening session file failed: "+err.Error())
+		}
+		return a, nil
+	}
+
+	if reveal {
+		if err := revealCommand(path).Start(); err != nil {
+			a.toast(components.ToastError, "Revealing session file failed: "+err.Error())
+		}
+		return a, nil
+	}
+	return a, tea.ExecProcess(editorCommand(path), func(err error) tea.Msg {
+		return EditorClosedMsg{Err: err}
+	})
+}
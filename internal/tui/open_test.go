@@ -0,0 +1,23 @@
+package tui
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestEditorCommand(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "code --wait")
+	if got := editorCommand("/tmp/s.jsonl").Args; !slices.Equal(got, []string{"code", "--wait", "/tmp/s.jsonl"}) {
+		t.Errorf("args = %v", got)
+	}
+	t.Setenv("VISUAL", "nvim")
+	if got := editorCommand("/tmp/s.jsonl").Args; !slices.Equal(got, []string{"nvim", "/tmp/s.jsonl"}) {
+		t.Errorf("VISUAL should win: %v", got)
+	}
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "")
+	if got := editorCommand("/tmp/s.jsonl").Args; got[0] != "vi" {
+		t.Errorf("fallback = %v, want vi", got)
+	}
+}
@@ -0,0 +1,78 @@
+package tui
+
+import (
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/config"
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+// activeWindow is how recently a session must have been written to count as
+// live activity for adaptive refresh.
+const activeWindow = 2 * time.Minute
+
+// Failed refreshes are retried after refreshRetryBase, doubling with each
+// consecutive failure up to refreshRetryMax.
+const (
+	refreshRetryBase = 5 * time.Second
+	refreshRetryMax  = 5 * time.Minute
+)
+
+// refreshBounds returns the adaptive refresh floor and ceiling from config,
+// falling back to 5s / 5m when unset or inverted.
+func refreshBounds(cfg config.TUIConfig) (lo, hi time.Duration) {
+	lo = time.Duration(cfg.RefreshMinSec) * time.Second
+	hi = time.Duration(cfg.RefreshMaxSec) * time.Second
+	if lo < 2*time.Second {
+		lo = 5 * time.Second
+	}
+	if hi < lo {
+		hi = 5 * time.Minute
+		if hi < lo {
+			hi = lo
+		}
+	}
+	return lo, hi
+}
+
+// latestActivity returns the newest session end time, i.e. when Claude last
+// wrote to any session file.
+func latestActivity(sessions []model.SessionStats) time.Time {
+	var latest time.Time
+	for i := range sessions {
+		if sessions[i].EndTime.After(latest) {
+			latest = sessions[i].EndTime
+		}
+	}
+	return latest
+}
+
+// nextRefreshInterval picks the delay before the next auto-refresh.
+// Recent activity snaps to the floor; otherwise the current interval doubles
+// toward the ceiling so an idle TUI backs off to minutes.
+func nextRefreshInterval(current, lo, hi time.Duration, lastActivity, now time.Time) time.Duration {
+	if !lastActivity.IsZero() && now.Sub(lastActivity) < activeWindow {
+		return lo
+	}
+	next := current * 2
+	if next < lo {
+		next = lo
+	}
+	if next > hi {
+		next = hi
+	}
+	return next
+}
+
+// refreshRetryDelay returns how long to wait after the given number of
+// consecutive refresh failures.
+func refreshRetryDelay(failures int) time.Duration {
+	d := refreshRetryBase
+	for i := 1; i < failures && d < refreshRetryMax; i++ {
+		d *= 2
+	}
+	if d > refreshRetryMax {
+		d = refreshRetryMax
+	}
+	return d
+}
@@ -0,0 +1,76 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/config"
+	"github.com/theirongolddev/cburn/internal/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestNextRefreshInterval(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	lo, hi := 5*time.Second, 5*time.Minute
+
+	tests := []struct {
+		name     string
+		current  time.Duration
+		activity time.Time
+		want     time.Duration
+	}{
+		{"active snaps to floor", 2 * time.Minute, now.Add(-30 * time.Second), lo},
+		{"idle doubles", 30 * time.Second, now.Add(-time.Hour), time.Minute},
+		{"idle capped at ceiling", 4 * time.Minute, now.Add(-time.Hour), hi},
+		{"no sessions backs off", 5 * time.Second, time.Time{}, 10 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := nextRefreshInterval(tt.current, lo, hi, tt.activity, now); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRefreshBounds_Defaults(t *testing.T) {
+	lo, hi := refreshBounds(config.TUIConfig{})
+	if lo != 5*time.Second || hi != 5*time.Minute {
+		t.Errorf("got %v/%v, want 5s/5m", lo, hi)
+	}
+	lo, hi = refreshBounds(config.TUIConfig{RefreshMinSec: 10, RefreshMaxSec: 600})
+	if lo != 10*time.Second || hi != 10*time.Minute {
+		t.Errorf("got %v/%v, want 10s/10m", lo, hi)
+	}
+}
+
+func TestRefreshRetryDelay(t *testing.T) {
+	for failures, want := range map[int]time.Duration{
+		1:  5 * time.Second,
+		2:  10 * time.Second,
+		4:  40 * time.Second,
+		10: 5 * time.Minute,
+	} {
+		if got := refreshRetryDelay(failures); got != want {
+			t.Errorf("refreshRetryDelay(%d) = %v, want %v", failures, got, want)
+		}
+	}
+}
+
+func TestStaleDataReplacedByScan(t *testing.T) {
+	a := App{width: 120, height: 30, days: 30, loadSub: make(chan tea.Msg, 1)}
+	m, _ := a.Update(StaleDataMsg{Sessions: []model.SessionStats{{SessionID: "old"}, {SessionID: "gone"}}})
+	a = m.(App)
+	if !a.loaded || !a.stale || len(a.sessions) != 2 {
+		t.Fatalf("after stale: loaded=%v stale=%v sessions=%d", a.loaded, a.stale, len(a.sessions))
+	}
+	if header, _, _ := a.mainLayout(); !strings.Contains(header, "STALE") {
+		t.Error("header should carry a STALE badge")
+	}
+
+	m, _ = a.Update(PartialDataMsg{Sessions: []model.SessionStats{{SessionID: "old"}}})
+	a = m.(App)
+	if a.stale || len(a.sessions) != 1 {
+		t.Errorf("after cached: stale=%v sessions=%d, want fresh and 1", a.stale, len(a.sessions))
+	}
+}
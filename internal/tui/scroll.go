@@ -0,0 +1,62 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/theirongolddev/cburn/internal/tui/theme"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// pageScroll returns the vertical scroll offset of the active tab when it
+// scrolls as a whole page (Overview, Costs), or nil.
+func (a *App) pageScroll() *int {
+	switch a.activeTab {
+	case 0:
+		return &a.overview.scroll
+	case 1:
+		return &a.costsScroll
+	}
+	return nil
+}
+
+// renderPageTab renders the full, unscrolled content of a page-scrolling tab.
+func (a App) renderPageTab(cw int) string {
+	if a.activeTab == 1 {
+		return a.renderCostsTab(cw)
+	}
+	return a.renderOverviewTab(cw)
+}
+
+// scrollPage moves the active tab's page scroll by delta lines, stopping
+// once the last card is in view.
+func (a *App) scrollPage(delta int) {
+	off := a.pageScroll()
+	if off == nil {
+		return
+	}
+	_, _, h := a.contentOrigin()
+	lines := lipgloss.Height(a.renderPageTab(a.contentWidth()))
+	*off = min(max(*off+delta, 0), max(lines-h, 0))
+}
+
+// applyPageScroll shows the h lines of content starting at off, marking
+// the lines hidden above and below.
+func applyPageScroll(content string, off, h int) string {
+	lines := strings.Split(content, "\n")
+	if len(lines) <= h {
+		return content
+	}
+	off = min(max(off, 0), len(lines)-h)
+	visible := lines[off : off+h]
+
+	dimStyle := lipgloss.NewStyle().Foreground(theme.Active.TextDim).Background(theme.Active.Background)
+	if off > 0 {
+		visible[0] = dimStyle.Render(fmt.Sprintf("  ↑ %d more (K / wheel)", off+1))
+	}
+	if below := len(lines) - off - h; below > 0 {
+		visible[h-1] = dimStyle.Render(fmt.Sprintf("  ↓ %d more (J / wheel)", below+1))
+	}
+	return strings.Join(visible, "\n")
+}
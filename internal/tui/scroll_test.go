@@ -0,0 +1,45 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestApplyPageScroll(t *testing.T) {
+	var lines []string
+	for i := range 10 {
+		lines = append(lines, string(rune('a'+i)))
+	}
+	content := strings.Join(lines, "\n")
+
+	if got := applyPageScroll(content, 3, 20); got != content {
+		t.Errorf("content that fits changed: %q", got)
+	}
+
+	got := strings.Split(ansi.Strip(applyPageScroll(content, 3, 4)), "\n")
+	if len(got) != 4 || !strings.Contains(got[0], "4 more") || got[1] != "e" || got[2] != "f" || !strings.Contains(got[3], "4 more") {
+		t.Errorf("window at 3: %q", got)
+	}
+
+	// Offsets past the end stop at the last page
+	got = strings.Split(ansi.Strip(applyPageScroll(content, 50, 4)), "\n")
+	if got[3] != "j" {
+		t.Errorf("last line = %q, want j", got[3])
+	}
+}
+
+func TestScrollPageClamps(t *testing.T) {
+	a := App{width: 120, height: 20, loaded: true}
+	a.scrollPage(-5)
+	if a.overview.scroll != 0 {
+		t.Errorf("scroll = %d after scrolling up from the top", a.overview.scroll)
+	}
+	a.scrollPage(1000)
+	_, _, h := a.contentOrigin()
+	lines := strings.Count(a.renderOverviewTab(a.contentWidth()), "\n") + 1
+	if want := max(lines-h, 0); a.overview.scroll != want {
+		t.Errorf("scroll = %d, want it stopped at %d", a.overview.scroll, want)
+	}
+}
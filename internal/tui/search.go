@@ -0,0 +1,263 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/cli"
+	"github.com/theirongolddev/cburn/internal/model"
+	"github.com/theirongolddev/cburn/internal/pipeline"
+)
+
+// searchHelp lists the Sessions search syntax for the search prompt.
+const searchHelp = "cost>5  tokens>1M  calls>=100  dur>30m  date:2025-12-01  date>2025-12-01  model:opus  project:api  branch:main  tag:billable  note:refactor  origin:auto"
+
+// searchTerm is one whitespace-separated part of a Sessions search. A term
+// without a field matches project, session ID, cost text, note, or tags.
+type searchTerm struct {
+	field string // "" for a plain text term
+	op    string // ":", "=", ">", ">=", "<", "<="
+	text  string // lowercased text for text and string fields
+	num   float64
+	// from and until bound date terms: the day (or from..to days) given.
+	from, until time.Time
+}
+
+var errNotNumber = errors.New("not a number")
+
+// searchFields maps accepted field names to their canonical name.
+var searchFields = map[string]string{
+	"cost":     "cost",
+	"tokens":   "tokens",
+	"tok":      "tokens",
+	"calls":    "calls",
+	"prompts":  "prompts",
+	"dur":      "dur",
+	"duration": "dur",
+	"date":     "date",
+	"day":      "date",
+	"model":    "model",
+	"project":  "project",
+	"branch":   "branch",
+	"id":       "id",
+	"note":     "note",
+	"tag":      "tag",
+	"origin":   "origin",
+}
+
+// parseSessionSearch splits a query into terms that must all match, e.g.
+// "cost>5 model:opus api". Words with an unknown field stay plain text.
+func parseSessionSearch(query string) ([]searchTerm, error) {
+	var terms []searchTerm
+	for _, word := range strings.Fields(query) {
+		t, err := parseSearchTerm(word)
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, t)
+	}
+	return terms, nil
+}
+
+func parseSearchTerm(word string) (searchTerm, error) {
+	plain := searchTerm{text: strings.ToLower(word)}
+	if len(word) > 1 && word[0] == '#' {
+		return searchTerm{field: "tag", op: ":", text: strings.ToLower(word[1:])}, nil
+	}
+
+	i := strings.IndexAny(word, ":=<>")
+	if i <= 0 {
+		return plain, nil
+	}
+	field, ok := searchFields[strings.ToLower(word[:i])]
+	if !ok {
+		return plain, nil
+	}
+	op, value := word[i:i+1], word[i+1:]
+	if (op == ">" || op == "<") && strings.HasPrefix(value, "=") {
+		op, value = op+"=", value[1:]
+	}
+	if value == "" {
+		return searchTerm{}, fmt.Errorf("%s: missing value", word)
+	}
+	t := searchTerm{field: field, op: op}
+
+	var err error
+	switch field {
+	case "cost":
+		if t.num, err = strconv.ParseFloat(strings.TrimPrefix(value, "$"), 64); err != nil {
+			err = errNotNumber
+		}
+	case "tokens", "calls", "prompts":
+		t.num, err = parseSearchCount(value)
+	case "dur":
+		t.num, err = parseSearchMinutes(value)
+	case "date":
+		err = t.parseDates(value)
+	default:
+		if op != ":" && op != "=" {
+			return searchTerm{}, fmt.Errorf("%s: %s only supports ':'", word, field)
+		}
+		t.text = strings.ToLower(strings.TrimPrefix(value, "#"))
+	}
+	if err != nil {
+		return searchTerm{}, fmt.Errorf("%s: %w", word, err)
+	}
+	return t, nil
+}
+
+// parseSearchCount reads a count with an optional K, M, or B suffix.
+func parseSearchCount(s string) (float64, error) {
+	mult := 1.0
+	switch strings.ToUpper(s[len(s)-1:]) {
+	case "K":
+		mult = 1e3
+	case "M":
+		mult = 1e6
+	case "B", "G":
+		mult = 1e9
+	}
+	if mult != 1 {
+		s = s[:len(s)-1]
+	}
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, errNotNumber
+	}
+	return n * mult, nil
+}
+
+// parseSearchMinutes reads a duration like "90m" or "1h30m"; a bare number
+// is minutes.
+func parseSearchMinutes(s string) (float64, error) {
+	if n, err := strconv.ParseFloat(s, 64); err == nil {
+		return n, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, errors.New("want a duration like 30m or 2h")
+	}
+	return d.Minutes(), nil
+}
+
+// parseDates reads a YYYY-MM-DD day, or a FROM..TO range for ':' and '='.
+func (t *searchTerm) parseDates(s string) error {
+	from, to, isRange := strings.Cut(s, "..")
+	if isRange && t.op != ":" && t.op != "=" {
+		return errors.New("ranges only work with ':'")
+	}
+	if !isRange {
+		to = from
+	}
+	var err error
+	t.from, t.until, err = pipeline.ParseDateRange(from, to, 1, time.Now())
+	return err
+}
+
+// matchesSearch reports whether s satisfies every term.
+func matchesSearch(terms []searchTerm, s model.SessionStats) bool {
+	for _, t := range terms {
+		if !t.matches(s) {
+			return false
+		}
+	}
+	return true
+}
+
+func (t searchTerm) matches(s model.SessionStats) bool {
+	switch t.field {
+	case "":
+		return strings.Contains(strings.ToLower(s.Project), t.text) ||
+			strings.Contains(strings.ToLower(s.SessionID), t.text) ||
+			strings.Contains(strings.ToLower(cli.FormatCost(s.EstimatedCost)), t.text) ||
+			strings.Contains(strings.ToLower(s.Note), t.text) ||
+			hasTag(s, t.text, false)
+	case "cost":
+		return t.compare(cli.ConvertCost(s.EstimatedCost)) // in the display currency, like the list
+	case "tokens":
+		return t.compare(float64(s.InputTokens + s.OutputTokens + s.CacheCreation5mTokens + s.CacheCreation1hTokens))
+	case "calls":
+		return t.compare(float64(s.APICalls))
+	case "prompts":
+		return t.compare(float64(s.UserMessages))
+	case "dur":
+		return t.compare(float64(s.DurationSecs) / 60)
+	case "date":
+		return t.matchesDate(s.StartTime)
+	case "model":
+		for name := range s.Models {
+			if strings.Contains(strings.ToLower(name), t.text) {
+				return true
+			}
+		}
+		return false
+	case "project":
+		return strings.Contains(strings.ToLower(s.Project), t.text)
+	case "branch":
+		return strings.Contains(strings.ToLower(s.GitBranch), t.text)
+	case "id":
+		return strings.HasPrefix(strings.ToLower(s.SessionID), t.text)
+	case "note":
+		return strings.Contains(strings.ToLower(s.Note), t.text)
+	case "tag":
+		return hasTag(s, t.text, true)
+	case "origin":
+		return strings.HasPrefix(strings.ToLower(sessionOrigin(s)), t.text)
+	}
+	return false
+}
+
+// sessionOrigin is model.OriginAutomation for automated sessions, else
+// model.OriginInteractive.
+func sessionOrigin(s model.SessionStats) string {
+	if s.Automation != "" {
+		return model.OriginAutomation
+	}
+	return model.OriginInteractive
+}
+
+// hasTag reports whether one of the session's user tags, or its [projects]
+// tag, contains text (or equals it, when exact).
+func hasTag(s model.SessionStats, text string, exact bool) bool {
+	for _, tag := range append([]string{s.Tag}, s.UserTags...) {
+		tag = strings.ToLower(tag)
+		if tag != "" && (tag == text || !exact && strings.Contains(tag, text)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t searchTerm) compare(v float64) bool {
+	switch t.op {
+	case ">":
+		return v > t.num
+	case ">=":
+		return v >= t.num
+	case "<":
+		return v < t.num
+	case "<=":
+		return v <= t.num
+	}
+	return v == t.num
+}
+
+func (t searchTerm) matchesDate(ts time.Time) bool {
+	if ts.IsZero() {
+		return false
+	}
+	switch t.op {
+	case ">":
+		return !ts.Before(t.until)
+	case ">=":
+		return !ts.Before(t.from)
+	case "<":
+		return ts.Before(t.from)
+	case "<=":
+		return ts.Before(t.until)
+	}
+	return !ts.Before(t.from) && ts.Before(t.until)
+}
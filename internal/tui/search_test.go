@@ -0,0 +1,82 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+func TestSessionSearch(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 12, d, 15, 0, 0, 0, time.Local) }
+	sessions := []model.SessionStats{
+		{SessionID: "aaa", Project: "api", StartTime: day(1), EstimatedCost: 7.5, InputTokens: 2_000_000, APICalls: 120, DurationSecs: 3600,
+			Models: map[string]*model.ModelUsage{"claude-opus-4-6": {}}},
+		{SessionID: "bbb", Project: "site", StartTime: day(2), EstimatedCost: 0.5, InputTokens: 40_000, APICalls: 8, DurationSecs: 300, GitBranch: "main", Automation: "CI checkout",
+			Models: map[string]*model.ModelUsage{"claude-sonnet-4-6": {}}},
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"", []string{"aaa", "bbb"}},
+		{"site", []string{"bbb"}},
+		{"cost>5", []string{"aaa"}},
+		{"cost<=$0.50", []string{"bbb"}},
+		{"tokens>1M", []string{"aaa"}},
+		{"calls>=8 dur<10m", []string{"bbb"}},
+		{"date:2025-12-02", []string{"bbb"}},
+		{"date>2025-12-01", []string{"bbb"}},
+		{"date:2025-12-01..2025-12-02", []string{"aaa", "bbb"}},
+		{"model:opus", []string{"aaa"}},
+		{"branch:main api", nil},
+		{"origin:auto", []string{"bbb"}},
+		{"origin:interactive", []string{"aaa"}},
+		{"foo:bar", nil}, // unknown field: plain text
+	}
+	for _, tt := range tests {
+		got, err := filterSessionsBySearch(sessions, tt.query)
+		if err != nil {
+			t.Errorf("%q: %v", tt.query, err)
+			continue
+		}
+		var ids []string
+		for _, s := range got {
+			ids = append(ids, s.SessionID)
+		}
+		if len(ids) != len(tt.want) || (len(ids) > 0 && ids[0] != tt.want[0]) || (len(ids) > 1 && ids[1] != tt.want[1]) {
+			t.Errorf("%q = %v, want %v", tt.query, ids, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"cost>abc", "tokens>", "date:12/01", "model>opus", "date>2025-12-01..2025-12-02"} {
+		if _, err := filterSessionsBySearch(sessions, bad); err == nil {
+			t.Errorf("%q: expected an error", bad)
+		}
+	}
+}
+
+func TestParseAnnotation(t *testing.T) {
+	a := parseAnnotation("  the big #billable refactor #client-x #billable #a,b ")
+	if a.Note != "the big refactor" || strings.Join(a.Tags, " ") != "billable client-x ab" {
+		t.Errorf("parseAnnotation = %+v", a)
+	}
+	if got := formatAnnotation(a.Note, a.Tags); got != "the big refactor #billable #client-x #ab" {
+		t.Errorf("formatAnnotation = %q", got)
+	}
+	if !parseAnnotation("   ").IsZero() {
+		t.Error("blank input should clear the annotation")
+	}
+
+	s := model.SessionStats{SessionID: "x", Note: "big refactor", UserTags: []string{"billable"}}
+	for _, q := range []string{"refactor", "#billable", "tag:billable", "note:big", "bill"} {
+		if got, _ := filterSessionsBySearch([]model.SessionStats{s}, q); len(got) != 1 {
+			t.Errorf("%q should match an annotated session", q)
+		}
+	}
+	if got, _ := filterSessionsBySearch([]model.SessionStats{s}, "tag:bill"); len(got) != 0 {
+		t.Error("tag: should match whole tags")
+	}
+}
@@ -0,0 +1,58 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/theirongolddev/cburn/internal/tui/theme"
+)
+
+func TestSettingsSelect_ThemePreviewAndCancel(t *testing.T) {
+	defer theme.SetActive(theme.Active.Name)
+	theme.SetActive(theme.All[0].Name)
+
+	var a App
+	a.settings.cursor = settingsFieldTheme
+	m, _ := a.settingsStartEdit()
+	a = m.(App)
+	if !a.settingsSelecting() || a.settings.choice != 0 {
+		t.Fatalf("selecting=%v choice=%d, want a select on the active theme", a.settingsSelecting(), a.settings.choice)
+	}
+
+	m, _ = a.updateSettingsSelect("l")
+	a = m.(App)
+	if theme.Active.Name != theme.All[1].Name {
+		t.Errorf("preview = %s, want %s", theme.Active.Name, theme.All[1].Name)
+	}
+	m, _ = a.updateSettingsSelect("h")
+	m, _ = m.(App).updateSettingsSelect("h")
+	a = m.(App)
+	if want := theme.All[len(theme.All)-1].Name; theme.Active.Name != want {
+		t.Errorf("after wrapping back, preview = %s, want %s", theme.Active.Name, want)
+	}
+
+	m, _ = a.updateSettingsSelect("esc")
+	a = m.(App)
+	if a.settings.editing || theme.Active.Name != theme.All[0].Name {
+		t.Errorf("after esc: editing=%v theme=%s, want the original theme restored", a.settings.editing, theme.Active.Name)
+	}
+}
+
+func TestThemeGallery_CyclesWithoutApplying(t *testing.T) {
+	defer theme.SetActive(theme.Active.Name)
+	theme.SetActive(theme.All[0].Name)
+
+	var a App
+	m, _ := a.openThemeGallery()
+	m, _ = m.(App).updateThemeGallery("h")
+	a = m.(App)
+	if want := len(theme.All) - 1; a.settings.galleryCursor != want {
+		t.Errorf("cursor = %d, want %d after wrapping left", a.settings.galleryCursor, want)
+	}
+	if theme.Active.Name != theme.All[0].Name {
+		t.Errorf("browsing changed the active theme to %s", theme.Active.Name)
+	}
+	m, _ = a.updateThemeGallery("esc")
+	if m.(App).settings.gallery {
+		t.Error("gallery still open after esc")
+	}
+}
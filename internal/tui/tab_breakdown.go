@@ -2,15 +2,110 @@ package tui
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/theirongolddev/cburn/internal/cli"
+	"github.com/theirongolddev/cburn/internal/model"
+	"github.com/theirongolddev/cburn/internal/pipeline"
 	"github.com/theirongolddev/cburn/internal/tui/components"
 	"github.com/theirongolddev/cburn/internal/tui/theme"
 
 	"github.com/charmbracelet/lipgloss"
 )
 
+// breakdownState tracks the project picker in the breakdown tab.
+type breakdownState struct {
+	cursor int  // selected row in the projects table
+	detail bool // showing the selected project's detail view
+	sortBy int  // projects table column, one of the projSort* constants
+}
+
+// Projects table sort columns. The zero value keeps the pipeline's
+// highest-cost-first order.
+const (
+	projSortCost = iota
+	projSortProject
+	projSortSessions
+	projSortPrompts
+	projSortTokens
+)
+
+// projColumn is one projects table column: its header, width, and the sort
+// it selects when clicked.
+type projColumn struct {
+	header string
+	width  int
+	sort   int
+}
+
+// projectColumns lays out the projects table for innerW, with the project
+// name taking what the numeric columns leave.
+func projectColumns(innerW int, compact bool) []projColumn {
+	if compact {
+		return []projColumn{
+			{"Project", max(innerW-10-6-2, 12), projSortProject},
+			{"Sess.", 6, projSortSessions},
+			{"Cost", 10, projSortCost},
+		}
+	}
+	return []projColumn{
+		{"Project", max(innerW-(6+8+10+10)-4, 18), projSortProject},
+		{"Sess.", 6, projSortSessions},
+		{"Prompts", 8, projSortPrompts},
+		{"Tokens", 10, projSortTokens},
+		{"Cost", 10, projSortCost},
+	}
+}
+
+// projectColumnAt returns the sort of the column at x within the table, or
+// -1 between columns.
+func projectColumnAt(cols []projColumn, x int) int {
+	pos := 0
+	for _, c := range cols {
+		if x >= pos && x < pos+c.width {
+			return c.sort
+		}
+		pos += c.width + 1
+	}
+	return -1
+}
+
+// sortProjects orders projects by the given column: names A-Z, numbers
+// largest first.
+func sortProjects(projects []model.ProjectStats, by int) {
+	sort.SliceStable(projects, func(i, j int) bool {
+		p, q := projects[i], projects[j]
+		switch by {
+		case projSortProject:
+			return p.Project < q.Project
+		case projSortSessions:
+			return p.Sessions > q.Sessions
+		case projSortPrompts:
+			return p.Prompts > q.Prompts
+		case projSortTokens:
+			return p.TotalTokens > q.TotalTokens
+		}
+		return p.EstimatedCost > q.EstimatedCost
+	})
+}
+
+// setProjectSort re-sorts the projects table, keeping the selected project.
+func (a *App) setProjectSort(by int) {
+	var selected string
+	if a.breakdown.cursor < len(a.projects) {
+		selected = a.projects[a.breakdown.cursor].Project
+	}
+	a.breakdown.sortBy = by
+	sortProjects(a.projects, by)
+	for i, ps := range a.projects {
+		if ps.Project == selected {
+			a.breakdown.cursor = i
+		}
+	}
+}
+
 func (a App) renderModelsTab(cw int) string {
 	t := theme.Active
 	models := a.models
@@ -78,50 +173,99 @@ func (a App) renderModelsTab(cw int) string {
 	return components.ContentCard("Model Usage", tableBody.String(), cw)
 }
 
+// renderLatencyCard compares turn durations across models.
+func (a App) renderLatencyCard(cw int) string {
+	t := theme.Active
+
+	innerW := components.CardInnerWidth(cw)
+	const numW = 9
+	nameW := max(innerW-7-numW*4-5, 10)
+
+	headerStyle := lipgloss.NewStyle().Foreground(t.Accent).Background(t.Surface).Bold(true)
+	rowStyle := lipgloss.NewStyle().Foreground(t.TextPrimary).Background(t.Surface)
+	mutedStyle := lipgloss.NewStyle().Foreground(t.TextMuted).Background(t.Surface)
+	nameStyle := lipgloss.NewStyle().Foreground(t.BlueBright).Background(t.Surface)
+	slowStyle := lipgloss.NewStyle().Foreground(t.Orange).Background(t.Surface)
+
+	var body strings.Builder
+	body.WriteString(headerStyle.Render(fmt.Sprintf("%-*s %7s %*s %*s %*s %*s", nameW, "Model", "Turns",
+		numW, "Mean", numW, "p50", numW, "p90", numW, "p99")))
+	body.WriteString("\n")
+	body.WriteString(mutedStyle.Render(strings.Repeat("─", innerW)))
+	for _, ml := range a.latency {
+		body.WriteString("\n")
+		body.WriteString(nameStyle.Render(fmt.Sprintf("%-*s", nameW, truncStr(shortModel(ml.Model), nameW))))
+		body.WriteString(rowStyle.Render(fmt.Sprintf(" %7s %*s %*s %*s", cli.FormatNumber(int64(ml.Turns)),
+			numW, cli.FormatLatency(ml.Mean), numW, cli.FormatLatency(ml.P50), numW, cli.FormatLatency(ml.P90))))
+		body.WriteString(slowStyle.Render(fmt.Sprintf(" %*s", numW, cli.FormatLatency(ml.P99))))
+	}
+
+	return components.ContentCard("Latency  (turn duration)", body.String(), cw)
+}
+
 func (a App) renderProjectsTab(cw int) string {
 	t := theme.Active
 	projects := a.projects
 
 	innerW := components.CardInnerWidth(cw)
-	fixedCols := 6 + 8 + 10 + 10 // Sess, Prompts, Tokens, Cost
-	gaps := 4
-	nameW := innerW - fixedCols - gaps
-	if nameW < 18 {
-		nameW = 18
-	}
+	cols := projectColumns(innerW, a.isCompactLayout())
+	nameW := cols[0].width
 
 	headerStyle := lipgloss.NewStyle().Foreground(t.Accent).Background(t.Surface).Bold(true)
 	rowStyle := lipgloss.NewStyle().Foreground(t.TextPrimary).Background(t.Surface)
 	mutedStyle := lipgloss.NewStyle().Foreground(t.TextMuted).Background(t.Surface)
 	nameStyle := lipgloss.NewStyle().Foreground(t.Cyan).Background(t.Surface)
 	costStyle := lipgloss.NewStyle().Foreground(t.GreenBright).Background(t.Surface)
+	selectedStyle := lipgloss.NewStyle().Foreground(t.TextPrimary).Background(t.SurfaceBright).Bold(true)
+
+	// Header; the sorted column is marked and any header sorts when clicked
+	var header []string
+	for i, c := range cols {
+		h := c.header
+		if c.sort == a.breakdown.sortBy {
+			h += "▾"
+		}
+		if i == 0 {
+			header = append(header, fmt.Sprintf("%-*s", c.width, h))
+		} else {
+			header = append(header, fmt.Sprintf("%*s", c.width, h))
+		}
+	}
 
 	var tableBody strings.Builder
+	tableBody.WriteString(headerStyle.Render(strings.Join(header, " ")))
+	tableBody.WriteString("\n")
 	if a.isCompactLayout() {
-		costW := 10
-		sessW := 6
-		nameW = innerW - costW - sessW - 2
-		if nameW < 12 {
-			nameW = 12
-		}
-		tableBody.WriteString(headerStyle.Render(fmt.Sprintf("%-*s %6s %10s", nameW, "Project", "Sess.", "Cost")))
-		tableBody.WriteString("\n")
-		tableBody.WriteString(mutedStyle.Render(strings.Repeat("─", nameW+costW+sessW+2)))
+		tableBody.WriteString(mutedStyle.Render(strings.Repeat("─", nameW+10+6+2)))
 		tableBody.WriteString("\n")
 
-		for _, ps := range projects {
+		for i, ps := range projects {
+			if i == a.breakdown.cursor {
+				row := fmt.Sprintf("%-*s %6d %10s", nameW, truncStr(ps.Project, nameW), ps.Sessions, cli.FormatCost(ps.EstimatedCost))
+				tableBody.WriteString(selectedStyle.Render(padRight(row, innerW)))
+				tableBody.WriteString("\n")
+				continue
+			}
 			tableBody.WriteString(nameStyle.Render(fmt.Sprintf("%-*s", nameW, truncStr(ps.Project, nameW))))
 			tableBody.WriteString(rowStyle.Render(fmt.Sprintf(" %6d", ps.Sessions)))
 			tableBody.WriteString(costStyle.Render(fmt.Sprintf(" %10s", cli.FormatCost(ps.EstimatedCost))))
 			tableBody.WriteString("\n")
 		}
 	} else {
-		tableBody.WriteString(headerStyle.Render(fmt.Sprintf("%-*s %6s %8s %10s %10s", nameW, "Project", "Sess.", "Prompts", "Tokens", "Cost")))
-		tableBody.WriteString("\n")
 		tableBody.WriteString(mutedStyle.Render(strings.Repeat("─", innerW)))
 		tableBody.WriteString("\n")
 
-		for _, ps := range projects {
+		for i, ps := range projects {
+			if i == a.breakdown.cursor {
+				row := fmt.Sprintf("%-*s %6d %8s %10s %10s", nameW, truncStr(ps.Project, nameW),
+					ps.Sessions,
+					cli.FormatNumber(int64(ps.Prompts)),
+					cli.FormatTokens(ps.TotalTokens),
+					cli.FormatCost(ps.EstimatedCost))
+				tableBody.WriteString(selectedStyle.Render(padRight(row, innerW)))
+				tableBody.WriteString("\n")
+				continue
+			}
 			tableBody.WriteString(nameStyle.Render(fmt.Sprintf("%-*s", nameW, truncStr(ps.Project, nameW))))
 			tableBody.WriteString(rowStyle.Render(fmt.Sprintf(" %6d %8s %10s",
 				ps.Sessions,
@@ -132,13 +276,270 @@ func (a App) renderProjectsTab(cw int) string {
 		}
 	}
 
+	if len(projects) > 0 {
+		tableBody.WriteString("\n")
+		tableBody.WriteString(mutedStyle.Render("[j/k] select  [Enter] project detail  click a header to sort"))
+	}
+
 	return components.ContentCard("Projects", tableBody.String(), cw)
 }
 
 func (a App) renderBreakdownTab(cw int) string {
+	if a.breakdown.detail && a.breakdown.cursor < len(a.projects) {
+		return a.renderProjectDetail(a.projects[a.breakdown.cursor].Project, cw)
+	}
+
 	var b strings.Builder
 	b.WriteString(a.renderModelsTab(cw))
 	b.WriteString("\n")
+	if len(a.latency) > 0 {
+		b.WriteString(a.renderLatencyCard(cw))
+		b.WriteString("\n")
+	}
+	if len(a.tags) > 0 {
+		b.WriteString(a.renderTagsCard(cw))
+		b.WriteString("\n")
+	}
+	if len(a.origins) > 0 {
+		b.WriteString(a.renderOriginsCard(cw))
+		b.WriteString("\n")
+	}
+	if len(a.subagents) > 0 {
+		b.WriteString(a.renderSubagentsCard(cw))
+		b.WriteString("\n")
+	}
 	b.WriteString(a.renderProjectsTab(cw))
 	return b.String()
 }
+
+// renderTagsCard renders cost grouped by [projects] allocation tag.
+func (a App) renderTagsCard(cw int) string {
+	t := theme.Active
+
+	innerW := components.CardInnerWidth(cw)
+	nameW := innerW - 6 - 6 - 10 - 7 - 4
+	if nameW < 12 {
+		nameW = 12
+	}
+
+	headerStyle := lipgloss.NewStyle().Foreground(t.Accent).Background(t.Surface).Bold(true)
+	rowStyle := lipgloss.NewStyle().Foreground(t.TextPrimary).Background(t.Surface)
+	mutedStyle := lipgloss.NewStyle().Foreground(t.TextMuted).Background(t.Surface)
+	nameStyle := lipgloss.NewStyle().Foreground(t.Magenta).Background(t.Surface)
+	costStyle := lipgloss.NewStyle().Foreground(t.GreenBright).Background(t.Surface)
+	shareStyle := lipgloss.NewStyle().Foreground(t.Cyan).Background(t.Surface)
+
+	var body strings.Builder
+	body.WriteString(headerStyle.Render(fmt.Sprintf("%-*s %6s %6s %10s %6s", nameW, "Tag", "Proj.", "Sess.", "Cost", "Share")))
+	body.WriteString("\n")
+	body.WriteString(mutedStyle.Render(strings.Repeat("─", innerW)))
+	body.WriteString("\n")
+
+	for _, ts := range a.tags {
+		if ts.Tag == "" {
+			body.WriteString(mutedStyle.Render(fmt.Sprintf("%-*s", nameW, "(untagged)")))
+		} else {
+			body.WriteString(nameStyle.Render(fmt.Sprintf("%-*s", nameW, truncStr(ts.Tag, nameW))))
+		}
+		body.WriteString(rowStyle.Render(fmt.Sprintf(" %6d %6d", ts.Projects, ts.Sessions)))
+		body.WriteString(costStyle.Render(fmt.Sprintf(" %10s", cli.FormatCost(ts.EstimatedCost))))
+		body.WriteString(shareStyle.Render(fmt.Sprintf(" %5.1f%%", ts.SharePercent)))
+		body.WriteString("\n")
+	}
+
+	return components.ContentCard("By Tag", body.String(), cw)
+}
+
+// renderOriginsCard splits cost into interactive and automated sessions
+// (headless runs, CI checkouts; see pipeline.AutomationReason).
+func (a App) renderOriginsCard(cw int) string {
+	t := theme.Active
+
+	innerW := components.CardInnerWidth(cw)
+	nameW := innerW - 6 - 8 - 10 - 7 - 4
+	if nameW < 12 {
+		nameW = 12
+	}
+
+	headerStyle := lipgloss.NewStyle().Foreground(t.Accent).Background(t.Surface).Bold(true)
+	rowStyle := lipgloss.NewStyle().Foreground(t.TextPrimary).Background(t.Surface)
+	mutedStyle := lipgloss.NewStyle().Foreground(t.TextMuted).Background(t.Surface)
+	nameStyle := lipgloss.NewStyle().Foreground(t.Magenta).Background(t.Surface)
+	costStyle := lipgloss.NewStyle().Foreground(t.GreenBright).Background(t.Surface)
+	shareStyle := lipgloss.NewStyle().Foreground(t.Cyan).Background(t.Surface)
+
+	var body strings.Builder
+	body.WriteString(headerStyle.Render(fmt.Sprintf("%-*s %6s %8s %10s %6s", nameW, "Origin", "Sess.", "Prompts", "Cost", "Share")))
+	body.WriteString("\n")
+	body.WriteString(mutedStyle.Render(strings.Repeat("─", innerW)))
+	body.WriteString("\n")
+
+	for _, o := range a.origins {
+		body.WriteString(nameStyle.Render(fmt.Sprintf("%-*s", nameW, o.Origin)))
+		body.WriteString(rowStyle.Render(fmt.Sprintf(" %6d %8s", o.Sessions, cli.FormatNumber(int64(o.Prompts)))))
+		body.WriteString(costStyle.Render(fmt.Sprintf(" %10s", cli.FormatCost(o.EstimatedCost))))
+		body.WriteString(shareStyle.Render(fmt.Sprintf(" %5.1f%%", o.SharePercent)))
+		body.WriteString("\n")
+	}
+
+	return components.ContentCard("By Origin", body.String(), cw)
+}
+
+// renderSubagentsCard renders cost grouped by subagent type, e.g. how much
+// auto-compaction costs across sessions.
+func (a App) renderSubagentsCard(cw int) string {
+	t := theme.Active
+
+	innerW := components.CardInnerWidth(cw)
+	nameW := innerW - 6 - 7 - 8 - 10 - 7 - 5
+	if nameW < 12 {
+		nameW = 12
+	}
+
+	headerStyle := lipgloss.NewStyle().Foreground(t.Accent).Background(t.Surface).Bold(true)
+	rowStyle := lipgloss.NewStyle().Foreground(t.TextPrimary).Background(t.Surface)
+	mutedStyle := lipgloss.NewStyle().Foreground(t.TextMuted).Background(t.Surface)
+	nameStyle := lipgloss.NewStyle().Foreground(t.Magenta).Background(t.Surface)
+	tokenStyle := lipgloss.NewStyle().Foreground(t.Blue).Background(t.Surface)
+	costStyle := lipgloss.NewStyle().Foreground(t.GreenBright).Background(t.Surface)
+	shareStyle := lipgloss.NewStyle().Foreground(t.Cyan).Background(t.Surface)
+
+	var body strings.Builder
+	body.WriteString(headerStyle.Render(fmt.Sprintf("%-*s %6s %7s %8s %10s %6s", nameW, "Agent", "Runs", "Parents", "Tokens", "Cost", "Share")))
+	body.WriteString("\n")
+	body.WriteString(mutedStyle.Render(strings.Repeat("─", innerW)))
+	body.WriteString("\n")
+
+	for _, st := range a.subagents {
+		body.WriteString(nameStyle.Render(fmt.Sprintf("%-*s", nameW, truncStr(st.Type, nameW))))
+		body.WriteString(rowStyle.Render(fmt.Sprintf(" %6d %7d", st.Runs, st.Parents)))
+		body.WriteString(tokenStyle.Render(fmt.Sprintf(" %8s", cli.FormatTokens(st.TotalTokens))))
+		body.WriteString(costStyle.Render(fmt.Sprintf(" %10s", cli.FormatCost(st.EstimatedCost))))
+		body.WriteString(shareStyle.Render(fmt.Sprintf(" %5.1f%%", st.SharePercent)))
+		body.WriteString("\n")
+	}
+
+	return components.ContentCard("Subagents", body.String(), cw)
+}
+
+// projectSessions returns the sessions belonging exactly to project,
+// honoring the active source and model filters.
+func (a App) projectSessions(project string) []model.SessionStats {
+	sessions := pipeline.FilterBySource(pipeline.WithoutExcluded(a.sessions), a.sourceFilter)
+	if a.modelFilter != "" {
+		sessions = pipeline.FilterByModel(sessions, a.modelFilter)
+	}
+	var result []model.SessionStats
+	for _, s := range sessions {
+		if s.Project == project {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// renderProjectDetail renders the drill-down view for a single project:
+// summary cards, daily cost chart, model split, and top sessions.
+func (a App) renderProjectDetail(project string, cw int) string {
+	t := theme.Active
+	now := time.Now()
+	since := now.AddDate(0, 0, -a.days)
+
+	sessions := a.projectSessions(project)
+	stats := pipeline.Aggregate(sessions, since, now)
+	days := pipeline.AggregateDays(sessions, since, now)
+	models := pipeline.AggregateModels(sessions, since, now)
+
+	headerStyle := lipgloss.NewStyle().Foreground(t.Accent).Background(t.Surface).Bold(true)
+	rowStyle := lipgloss.NewStyle().Foreground(t.TextPrimary).Background(t.Surface)
+	mutedStyle := lipgloss.NewStyle().Foreground(t.TextMuted).Background(t.Surface)
+	costStyle := lipgloss.NewStyle().Foreground(t.GreenBright).Background(t.Surface)
+
+	var b strings.Builder
+
+	cards := []struct{ Label, Value, Delta string }{
+		{"Cost", cli.FormatCost(stats.EstimatedCost), cli.FormatCost(stats.CostPerDay) + "/day"},
+		{"Sessions", cli.FormatNumber(int64(stats.TotalSessions)), fmt.Sprintf("%.1f/day", stats.SessionsPerDay)},
+		{"Prompts", cli.FormatNumber(int64(stats.TotalPrompts)), fmt.Sprintf("%.1f/day", stats.PromptsPerDay)},
+		{"Cache", cli.FormatPercent(stats.CacheHitRate), "saved " + cli.FormatCost(stats.CacheSavings)},
+	}
+	b.WriteString(components.MetricCardRow(cards, cw))
+	b.WriteString("\n")
+
+	if len(days) > 0 {
+		chartVals := make([]float64, len(days))
+		for i, d := range days {
+			chartVals[len(days)-1-i] = d.EstimatedCost
+		}
+		b.WriteString(components.PanelCard(
+			fmt.Sprintf("%s · Daily Cost (%dd)", project, a.days),
+			components.BarChart(chartVals, chartDateLabels(days), t.GreenBright, components.CardInnerWidth(cw), 10),
+			cw,
+		))
+		b.WriteString("\n")
+	}
+
+	halves := components.LayoutRow(cw, 2)
+	if a.isCompactLayout() {
+		halves = []int{cw, cw}
+	}
+
+	// Model split
+	modelW := components.CardInnerWidth(halves[0])
+	modelNameW := modelW - 8 - 10 - 7
+	if modelNameW < 10 {
+		modelNameW = 10
+	}
+	var modelBody strings.Builder
+	modelBody.WriteString(headerStyle.Render(fmt.Sprintf("%-*s %7s %9s %6s", modelNameW, "Model", "Calls", "Cost", "Share")))
+	modelBody.WriteString("\n")
+	for _, ms := range models {
+		modelBody.WriteString(rowStyle.Render(fmt.Sprintf("%-*s %7s", modelNameW, truncStr(shortModel(ms.Model), modelNameW), cli.FormatNumber(int64(ms.APICalls)))))
+		modelBody.WriteString(costStyle.Render(fmt.Sprintf(" %9s", cli.FormatCost(ms.EstimatedCost))))
+		modelBody.WriteString(mutedStyle.Render(fmt.Sprintf(" %5.1f%%", ms.SharePercent)))
+		modelBody.WriteString("\n")
+	}
+	modelCard := components.ContentCard("Models", modelBody.String(), halves[0])
+
+	// Top sessions by cost
+	top := pipeline.FilterByTime(sessions, since, now)
+	top = append([]model.SessionStats(nil), top...)
+	sort.Slice(top, func(i, j int) bool {
+		return top[i].EstimatedCost > top[j].EstimatedCost
+	})
+	if len(top) > 5 {
+		top = top[:5]
+	}
+	var topBody strings.Builder
+	topBody.WriteString(headerStyle.Render(fmt.Sprintf("%-12s %8s %6s %9s", "Started", "Duration", "Cache", "Cost")))
+	topBody.WriteString("\n")
+	for _, s := range top {
+		topBody.WriteString(rowStyle.Render(fmt.Sprintf("%-12s %8s %6s",
+			s.StartTime.Local().Format("Jan 02 15:04"),
+			cli.FormatDuration(s.DurationSecs),
+			cli.FormatPercent(s.CacheHitRate))))
+		topBody.WriteString(costStyle.Render(fmt.Sprintf(" %9s", cli.FormatCost(s.EstimatedCost))))
+		topBody.WriteString("\n")
+	}
+	topCard := components.ContentCard("Top Sessions", topBody.String(), halves[1])
+
+	if a.isCompactLayout() {
+		b.WriteString(modelCard)
+		b.WriteString("\n")
+		b.WriteString(topCard)
+	} else {
+		b.WriteString(components.CardRow([]string{modelCard, topCard}))
+	}
+	b.WriteString("\n")
+	b.WriteString(mutedStyle.Render("[Esc] back to breakdown  [j/k] previous/next project"))
+
+	return b.String()
+}
+
+// padRight pads s with spaces to width w (by visual width).
+func padRight(s string, w int) string {
+	if gap := w - lipgloss.Width(s); gap > 0 {
+		return s + strings.Repeat(" ", gap)
+	}
+	return s
+}
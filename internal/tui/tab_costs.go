@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
@@ -10,6 +11,8 @@ import (
 	"github.com/theirongolddev/cburn/internal/cli"
 	"github.com/theirongolddev/cburn/internal/config"
 	"github.com/theirongolddev/cburn/internal/model"
+	"github.com/theirongolddev/cburn/internal/pipeline"
+	"github.com/theirongolddev/cburn/internal/redact"
 	"github.com/theirongolddev/cburn/internal/tui/components"
 	"github.com/theirongolddev/cburn/internal/tui/theme"
 
@@ -17,6 +20,9 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
+// rlResetNoticeFor is how long the Subscription card mentions a reset.
+const rlResetNoticeFor = 30 * time.Minute
+
 func (a App) renderCostsTab(cw int) string {
 	t := theme.Active
 	stats := a.stats
@@ -39,9 +45,27 @@ func (a App) renderCostsTab(cw int) string {
 		{"Projected", cli.FormatCost(stats.CostPerDay*30) + "/mo", cli.FormatCost(stats.CostPerDay) + "/day"},
 		{"Cache Rate", cli.FormatPercent(stats.CacheHitRate), ""},
 	}
+	if a.planUSD > 0 {
+		name := a.planName
+		if name == "" {
+			name = "plan"
+		}
+		m := cli.PlanMultiple(stats.CostPerDay, a.planUSD)
+		if m >= 1 {
+			costCards[2].Delta = cli.FormatPlanMultiple(m) + " " + name
+		} else {
+			costCards[2].Delta = cli.FormatPlanMultiple(m) + " of " + name
+		}
+	}
 	b.WriteString(components.MetricCardRow(costCards, cw))
 	b.WriteString("\n")
 
+	// Row 1b: Cost trend with previous-period overlay
+	if card := a.renderCostTrendCard(cw); card != "" {
+		b.WriteString(card)
+		b.WriteString("\n")
+	}
+
 	// Row 2: Cost breakdown table
 	innerW := components.CardInnerWidth(cw)
 	fixedCols := 10 + 10 + 10 + 10
@@ -101,6 +125,18 @@ func (a App) renderCostsTab(cw int) string {
 	b.WriteString(components.ContentCard(title, tableBody.String(), cw))
 	b.WriteString("\n")
 
+	// Row 2a: Service tier split (only when anything ran off the standard tier)
+	if card := a.renderTierCard(cw); card != "" {
+		b.WriteString(card)
+		b.WriteString("\n")
+	}
+
+	// Row 2b: Cache read share per model
+	if card := a.renderCacheShareCard(cw); card != "" {
+		b.WriteString(card)
+		b.WriteString("\n")
+	}
+
 	// Row 3: Budget progress + Top Spend Days
 	halves := components.LayoutRow(cw, 2)
 
@@ -127,7 +163,7 @@ func (a App) renderCostsTab(cw int) string {
 		var body strings.Builder
 		body.WriteString(bar.ViewAs(pct))
 		body.WriteString(spaceStyle.Render(" "))
-		body.WriteString(valueStyle.Render(fmt.Sprintf("%.0f%%", pct*100)))
+		body.WriteString(valueStyle.Render(fmt.Sprintf("%.0f%%", pct*100) + components.SeverityMark(pct)))
 		body.WriteString("\n")
 		body.WriteString(labelStyle.Render("Used"))
 		body.WriteString(spaceStyle.Render("  "))
@@ -139,12 +175,7 @@ func (a App) renderCostsTab(cw int) string {
 
 		progressCard = components.ContentCard("Overage Spend", body.String(), halves[0])
 	} else {
-		ceiling := 200.0
-		pct := stats.EstimatedCost / ceiling
-		progressInnerW := components.CardInnerWidth(halves[0])
-		progressBody := components.ProgressBar(pct, progressInnerW-10) + "\n" +
-			labelStyle.Render("flat-rate plan ceiling")
-		progressCard = components.ContentCard("Budget Progress", progressBody, halves[0])
+		progressCard = a.renderBudgetCard(halves[0])
 	}
 
 	var spendBody strings.Builder
@@ -218,6 +249,147 @@ func (a App) renderCostsTab(cw int) string {
 	return b.String()
 }
 
+// renderCostTrendCard plots daily cost as a line, overlaid with the previous
+// period of the same length so growth is visible at 90 days, where bars get
+// too thin to read.
+func (a App) renderCostTrendCard(cw int) string {
+	t := theme.Active
+	days := a.dailyStats
+	if len(days) < 2 {
+		return ""
+	}
+
+	// Both series oldest-first; the previous period is aligned to the right
+	// so day i of each period lines up.
+	cur := make([]float64, len(days))
+	for i, d := range days {
+		cur[len(days)-1-i] = d.EstimatedCost
+	}
+	prev := make([]float64, len(a.prevDaily))
+	for i, d := range a.prevDaily {
+		prev[len(a.prevDaily)-1-i] = d.EstimatedCost
+	}
+	if len(prev) > len(cur) {
+		prev = prev[len(prev)-len(cur):]
+	}
+
+	series := []components.LineSeries{
+		{Name: fmt.Sprintf("previous %dd", a.days), Values: prev, Color: t.TextDim},
+		{Name: fmt.Sprintf("last %dd", a.days), Values: cur, Color: t.GreenBright},
+	}
+	chartH := 8
+	if a.isCompactLayout() {
+		chartH = 6
+	}
+	innerW := components.CardInnerWidth(cw)
+	chart := components.LineChart(series, chartDateLabels(days), innerW, chartH, func(v float64) string {
+		if v >= 10 || v == 0 {
+			return cli.FormatCostFixed(v, 0)
+		}
+		return cli.FormatCostFixed(v, 2)
+	})
+	legend := components.ChartLegend(
+		[]string{series[1].Name + " " + cli.FormatCost(a.stats.EstimatedCost), series[0].Name + " " + cli.FormatCost(a.prevStats.EstimatedCost)},
+		[]lipgloss.Color{series[1].Color, series[0].Color},
+	)
+
+	return components.ContentCard(fmt.Sprintf("Cost Trend (%dd)", a.days), chart+"\n"+legend, cw)
+}
+
+// renderTierCard splits cost by API service tier, with what discounted
+// tiers saved against standard pricing. Hidden when everything was standard.
+func (a App) renderTierCard(cw int) string {
+	if len(a.tiers) == 0 || (len(a.tiers) == 1 && a.tiers[0].Tier == config.TierStandard) {
+		return ""
+	}
+	t := theme.Active
+
+	innerW := components.CardInnerWidth(cw)
+	nameW := 10
+	callsW, costW, savedW := 10, 10, 14
+	barW := innerW - nameW - callsW - costW - savedW - 3
+	if barW < 6 {
+		barW = 0
+	}
+
+	nameStyle := lipgloss.NewStyle().Foreground(t.BlueBright).Background(t.Surface)
+	valueStyle := lipgloss.NewStyle().Foreground(t.TextPrimary).Background(t.Surface)
+	costStyle := lipgloss.NewStyle().Foreground(t.GreenBright).Background(t.Surface)
+	savedStyle := lipgloss.NewStyle().Foreground(t.Green).Background(t.Surface)
+	spaceStyle := lipgloss.NewStyle().Background(t.Surface)
+
+	var body strings.Builder
+	for i, ts := range a.tiers {
+		body.WriteString(nameStyle.Render(fmt.Sprintf("%-*s", nameW, truncStr(ts.Tier, nameW))))
+		if barW > 0 {
+			bar := components.ProgressBar(ts.SharePercent/100, barW)
+			body.WriteString(bar)
+			if pad := barW - lipgloss.Width(bar); pad > 0 {
+				body.WriteString(spaceStyle.Render(strings.Repeat(" ", pad)))
+			}
+		}
+		body.WriteString(valueStyle.Render(fmt.Sprintf(" %*s", callsW, cli.FormatNumber(int64(ts.APICalls))+" calls")))
+		body.WriteString(costStyle.Render(fmt.Sprintf(" %*s", costW, cli.FormatCost(ts.EstimatedCost))))
+		saved := ""
+		if d := ts.ListCost - ts.EstimatedCost; d > 0.005 {
+			saved = cli.FormatCost(d) + " saved"
+		}
+		body.WriteString(savedStyle.Render(fmt.Sprintf(" %*s", savedW, saved)))
+		if i < len(a.tiers)-1 {
+			body.WriteString("\n")
+		}
+	}
+
+	return components.ContentCard("Cost by Service Tier", body.String(), cw)
+}
+
+// renderCacheShareCard ranks models by the share of their input served from
+// cache reads, showing which models benefit most from prompt caching.
+func (a App) renderCacheShareCard(cw int) string {
+	t := theme.Active
+
+	models := make([]model.ModelStats, 0, len(a.models))
+	for _, ms := range a.models {
+		if ms.InputTokens+ms.CacheCreation5m+ms.CacheCreation1h+ms.CacheReadTokens > 0 {
+			models = append(models, ms)
+		}
+	}
+	if len(models) == 0 {
+		return ""
+	}
+	sort.SliceStable(models, func(i, j int) bool {
+		return models[i].CacheReadShare > models[j].CacheReadShare
+	})
+
+	innerW := components.CardInnerWidth(cw)
+	nameW := 14
+	readW := 12                        // " 123.4M read"
+	barW := innerW - nameW - readW - 6 // bar suffix " 100%"
+	if barW < 10 {
+		barW = 10
+	}
+
+	nameStyle := lipgloss.NewStyle().Foreground(t.BlueBright).Background(t.Surface)
+	readStyle := lipgloss.NewStyle().Foreground(t.TextMuted).Background(t.Surface)
+	spaceStyle := lipgloss.NewStyle().Background(t.Surface)
+
+	var body strings.Builder
+	for i, ms := range models {
+		bar := components.ProgressBar(ms.CacheReadShare, barW)
+		body.WriteString(nameStyle.Render(fmt.Sprintf("%-*s", nameW, truncStr(shortModel(ms.Model), nameW))))
+		body.WriteString(bar)
+		if pad := innerW - nameW - lipgloss.Width(bar) - readW; pad > 0 {
+			body.WriteString(spaceStyle.Render(strings.Repeat(" ", pad)))
+		}
+		body.WriteString(readStyle.Render(fmt.Sprintf("%*s", readW, cli.FormatTokens(ms.CacheReadTokens)+" read")))
+		if i < len(models)-1 {
+			body.WriteString("\n")
+		}
+	}
+
+	return components.ContentCard("Cache Reads by Model  (share of input)", body.String(), cw)
+}
+
 // renderSubscriptionCard renders the rate limit + overage card at the top of the costs tab.
 func (a App) renderSubscriptionCard(cw int) string {
 	t := theme.Active
@@ -247,9 +419,11 @@ func (a App) renderSubscriptionCard(cw int) string {
 	// Error with no usable data
 	if a.subData.Usage == nil && a.subData.Error != nil {
 		warnStyle := lipgloss.NewStyle().Foreground(t.Orange).Background(t.Surface)
-		return components.ContentCard("Subscription",
-			warnStyle.Render(fmt.Sprintf("Error: %s", a.subData.Error)),
-			cw) + "\n"
+		body := warnStyle.Render(fmt.Sprintf("Error: %s", a.subData.Error))
+		if errors.Is(a.subData.Error, claudeai.ErrUnauthorized) {
+			body += "\n" + hintStyle.Render("Refresh the key with `cburn auth import-browser`; it is picked up within 5 minutes.")
+		}
+		return components.ContentCard("Subscription", body, cw) + "\n"
 	}
 
 	// No usage data at all
@@ -306,17 +480,116 @@ func (a App) renderSubscriptionCard(cw int) string {
 			fmt.Sprintf("  $%.2f / $%.2f", ol.UsedCredits, ol.MonthlyCreditLimit)))
 	}
 
+	// Scheduling hint when a window is near its limit
+	if hint := claudeai.SchedulingHint(a.subData.Usage, a.hintThreshold, time.Now()); hint != nil {
+		hintText := fmt.Sprintf("%s at %.0f%%", hint.Label, hint.Pct*100)
+		if wait := hint.Wait(time.Now()); wait > 0 {
+			hintText += " — start heavy runs after reset in " + components.FormatCountdown(wait) +
+				" (" + hint.ResetsAt.Local().Format("Mon 3:04 PM") + ")"
+		}
+		body.WriteString("\n")
+		warnStyle := lipgloss.NewStyle().Foreground(t.Orange).Background(t.Surface)
+		body.WriteString(warnStyle.Render(truncStr(hintText, innerW)))
+	}
+
+	// A recently reset window that had been exhausted
+	if r := a.lastRLReset; r != nil && time.Since(r.ResetAt) < rlResetNoticeFor {
+		body.WriteString("\n")
+		okStyle := lipgloss.NewStyle().Foreground(t.GreenBright).Background(t.Surface)
+		body.WriteString(okStyle.Render(truncStr(r.Label+" window reset at "+r.ResetAt.Local().Format("3:04 PM")+" — heavy usage can resume", innerW)))
+	}
+
 	// Fetch timestamp
 	if !a.subData.FetchedAt.IsZero() {
 		body.WriteString("\n")
 		tsStyle := lipgloss.NewStyle().Foreground(t.TextDim).Background(t.Surface)
-		body.WriteString(tsStyle.Render("Updated " + a.subData.FetchedAt.Format("3:04 PM")))
+		if a.subData.Stale {
+			warnStyle := lipgloss.NewStyle().Foreground(t.Orange).Background(t.Surface)
+			body.WriteString(warnStyle.Render(truncStr("claude.ai unreachable — as of "+a.subData.FetchedAt.Local().Format("Jan 02 3:04 PM"), innerW)))
+		} else {
+			body.WriteString(tsStyle.Render("Updated " + a.subData.FetchedAt.Format("3:04 PM")))
+		}
 	}
 
 	title := "Subscription"
 	if a.subData.Org.Name != "" {
-		title = "Subscription — " + a.subData.Org.Name
+		title = "Subscription — " + redact.Org(a.subData.Org.Name)
 	}
 
 	return components.ContentCard(title, body.String(), cw) + "\n"
 }
+
+// renderBudgetCard shows billing-period-to-date spend against budget.monthly_usd
+// as a burn gauge: the current daily pace, the projected period total, and the
+// runway left before the budget runs out at that pace.
+func (a App) renderBudgetCard(w int) string {
+	t := theme.Active
+	labelStyle := lipgloss.NewStyle().Foreground(t.TextMuted).Background(t.Surface)
+	valueStyle := lipgloss.NewStyle().Foreground(t.TextPrimary).Background(t.Surface)
+	spaceStyle := lipgloss.NewStyle().Background(t.Surface)
+
+	cfg := loadConfigOrDefault()
+	now := time.Now()
+	var budget float64
+	if cfg.Budget.MonthlyUSD != nil {
+		budget = *cfg.Budget.MonthlyUSD
+	}
+	bp := pipeline.AggregateBudget(pipeline.WithoutExcluded(a.sessions), budget, cfg.Budget.BillingDay, now)
+	title := "Billing Period  since " + bp.Start.Format("Jan 2")
+
+	var body strings.Builder
+	if bp.Budget <= 0 {
+		body.WriteString(labelStyle.Render("Spent"))
+		body.WriteString(spaceStyle.Render("  "))
+		body.WriteString(valueStyle.Render(cli.FormatCost(bp.Spent)))
+		body.WriteString("\n")
+		body.WriteString(labelStyle.Render("Set budget.monthly_usd in Settings to track a budget"))
+		return components.ContentCard(title, body.String(), w)
+	}
+
+	body.WriteString(components.BudgetGauge(bp.Pct(), bp.Elapsed, bp.Projected/bp.Budget, components.CardInnerWidth(w)-10))
+	body.WriteString("\n")
+	body.WriteString(labelStyle.Render("Spent"))
+	body.WriteString(spaceStyle.Render("  "))
+	body.WriteString(valueStyle.Render(cli.FormatCost(bp.Spent)))
+	body.WriteString(spaceStyle.Render(" / "))
+	body.WriteString(valueStyle.Render(cli.FormatCost(bp.Budget)))
+	body.WriteString("\n")
+	body.WriteString(labelStyle.Render("Resets"))
+	body.WriteString(spaceStyle.Render(" "))
+	body.WriteString(valueStyle.Render(fmt.Sprintf("%s (%dd)", bp.End.Format("Jan 2"), int(bp.End.Sub(now).Hours()/24)+1)))
+	if bp.Projected > 0 {
+		projStyle := valueStyle
+		if bp.Projected > bp.Budget {
+			projStyle = lipgloss.NewStyle().Foreground(t.Red).Background(t.Surface)
+		} else if bp.Projected >= 0.8*bp.Budget {
+			projStyle = lipgloss.NewStyle().Foreground(t.Orange).Background(t.Surface)
+		}
+		body.WriteString("\n")
+		body.WriteString(labelStyle.Render("Burn"))
+		body.WriteString(spaceStyle.Render("   "))
+		body.WriteString(valueStyle.Render(cli.FormatCost(bp.BurnPerDay) + "/day"))
+		body.WriteString("\n")
+		body.WriteString(labelStyle.Render("Projected"))
+		body.WriteString(spaceStyle.Render(" "))
+		body.WriteString(projStyle.Render(cli.FormatCost(bp.Projected)))
+	}
+	if days, ok := bp.RunwayDays(); ok {
+		runway := "spent"
+		runwayStyle := lipgloss.NewStyle().Foreground(t.Red).Background(t.Surface)
+		switch end := now.Add(time.Duration(days * 24 * float64(time.Hour))); {
+		case days <= 0:
+		case !end.Before(bp.End):
+			runway = "lasts past reset"
+			runwayStyle = lipgloss.NewStyle().Foreground(t.Green).Background(t.Surface)
+		default:
+			runway = fmt.Sprintf("%.0fd (%s)", days, end.Format("Jan 2"))
+			runwayStyle = lipgloss.NewStyle().Foreground(t.Orange).Background(t.Surface)
+		}
+		body.WriteString("\n")
+		body.WriteString(labelStyle.Render("Runway"))
+		body.WriteString(spaceStyle.Render(" "))
+		body.WriteString(runwayStyle.Render(runway))
+	}
+	return components.ContentCard(title, body.String(), w)
+}
@@ -0,0 +1,154 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/theirongolddev/cburn/internal/claudeai"
+	"github.com/theirongolddev/cburn/internal/cli"
+	"github.com/theirongolddev/cburn/internal/pipeline"
+	"github.com/theirongolddev/cburn/internal/tui/components"
+	"github.com/theirongolddev/cburn/internal/tui/theme"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func (a App) renderInsightsTab(cw int) string {
+	var b strings.Builder
+	b.WriteString(a.renderRecommendationsCard(cw))
+	b.WriteString("\n")
+	if a.schedule != nil {
+		b.WriteString(a.renderScheduleCard(cw))
+		b.WriteString("\n")
+	}
+	b.WriteString(a.renderCacheReuseCard(cw))
+	b.WriteString("\n")
+	b.WriteString(a.renderCompactionCard(cw))
+	return b.String()
+}
+
+// renderRecommendationsCard lists cache suggestions from insights.Recommend.
+func (a App) renderRecommendationsCard(cw int) string {
+	t := theme.Active
+	innerW := components.CardInnerWidth(cw)
+
+	titleStyle := lipgloss.NewStyle().Foreground(t.TextPrimary).Background(t.Surface).Bold(true)
+	impactStyle := lipgloss.NewStyle().Foreground(t.Orange).Background(t.Surface)
+	detailStyle := lipgloss.NewStyle().Foreground(t.TextMuted).Background(t.Surface).Width(innerW)
+	hintStyle := lipgloss.NewStyle().Foreground(t.TextDim).Background(t.Surface)
+
+	if len(a.insights) == 0 {
+		return components.ContentCard("Recommendations",
+			hintStyle.Render("No cache problems found in this period."), cw)
+	}
+
+	var body strings.Builder
+	for i, in := range a.insights {
+		impact := "~" + cli.FormatCost(in.ImpactUSD)
+		title := truncStr(in.Title, innerW-len(impact)-1)
+		body.WriteString(titleStyle.Render(title))
+		if pad := innerW - lipgloss.Width(title) - len(impact); pad > 0 {
+			body.WriteString(hintStyle.Render(strings.Repeat(" ", pad)))
+		}
+		body.WriteString(impactStyle.Render(impact))
+		body.WriteString("\n")
+		body.WriteString(detailStyle.Render(in.Detail))
+		if i < len(a.insights)-1 {
+			body.WriteString("\n\n")
+		}
+	}
+
+	return components.ContentCard(fmt.Sprintf("Recommendations (%d)", len(a.insights)), body.String(), cw)
+}
+
+// renderCacheReuseCard shows each project's cache write/read profile.
+func (a App) renderCacheReuseCard(cw int) string {
+	t := theme.Active
+	innerW := components.CardInnerWidth(cw)
+
+	headStyle := lipgloss.NewStyle().Foreground(t.TextDim).Background(t.Surface)
+	nameStyle := lipgloss.NewStyle().Foreground(t.TextPrimary).Background(t.Surface)
+	numStyle := lipgloss.NewStyle().Foreground(t.TextMuted).Background(t.Surface)
+	goodStyle := lipgloss.NewStyle().Foreground(t.GreenBright).Background(t.Surface)
+	badStyle := lipgloss.NewStyle().Foreground(t.Orange).Background(t.Surface)
+
+	// Project | 5m writes | 1h writes | reads | reuse | hit
+	const numW = 10
+	nameW := innerW - numW*5
+	if nameW < 10 {
+		nameW = 10
+	}
+
+	var body strings.Builder
+	body.WriteString(headStyle.Render(fmt.Sprintf("%-*s%*s%*s%*s%*s%*s", nameW, "Project",
+		numW, "5m Write", numW, "1h Write", numW, "Read", numW, "Reuse", numW, "Hit")))
+	body.WriteString("\n")
+
+	limit := 15
+	if len(a.cacheProfiles) < limit {
+		limit = len(a.cacheProfiles)
+	}
+	for i, pc := range a.cacheProfiles[:limit] {
+		reuse := pc.Reuse()
+		reuseStyle := goodStyle
+		if reuse < 2 {
+			reuseStyle = badStyle
+		}
+		body.WriteString(nameStyle.Render(fmt.Sprintf("%-*s", nameW, truncStr(pc.Project, nameW-1))))
+		body.WriteString(numStyle.Render(fmt.Sprintf("%*s%*s%*s", numW, cli.FormatTokens(pc.Write5mTokens),
+			numW, cli.FormatTokens(pc.Write1hTokens), numW, cli.FormatTokens(pc.ReadTokens))))
+		body.WriteString(reuseStyle.Render(fmt.Sprintf("%*s", numW, fmt.Sprintf("%.1fx", reuse))))
+		body.WriteString(numStyle.Render(fmt.Sprintf("%*s", numW, cli.FormatPercent(pc.HitRate()))))
+		if i < limit-1 {
+			body.WriteString("\n")
+		}
+	}
+
+	return components.ContentCard("Cache Reuse by Project  (reads per cached token)", body.String(), cw)
+}
+
+// renderScheduleCard suggests when to run heavy agentic work, from the
+// weekly rate-limit window and the times of day usage is usually light.
+func (a App) renderScheduleCard(cw int) string {
+	t := theme.Active
+	innerW := components.CardInnerWidth(cw)
+	textStyle := lipgloss.NewStyle().Foreground(t.TextPrimary).Background(t.Surface).Width(innerW)
+	if a.schedule.WaitForReset() {
+		textStyle = textStyle.Foreground(t.Orange)
+	}
+	return components.ContentCard("Optimal Window", textStyle.Render(a.schedule.Summary()), cw)
+}
+
+// weeklyWindow returns the claude.ai weekly rate-limit window, or nil.
+func (a App) weeklyWindow() *claudeai.ParsedWindow {
+	if a.subData == nil || a.subData.Usage == nil {
+		return nil
+	}
+	return a.subData.Usage.SevenDay
+}
+
+// renderCompactionCard shows how often sessions were compacted and what
+// compacted sessions cost next to the rest.
+func (a App) renderCompactionCard(cw int) string {
+	t := theme.Active
+	labelStyle := lipgloss.NewStyle().Foreground(t.TextMuted).Background(t.Surface)
+	valueStyle := lipgloss.NewStyle().Foreground(t.TextPrimary).Background(t.Surface)
+	hintStyle := lipgloss.NewStyle().Foreground(t.TextDim).Background(t.Surface)
+
+	c := pipeline.Compactions(a.filtered)
+	if c.Compactions == 0 {
+		return components.ContentCard("Compaction", hintStyle.Render("No sessions were compacted in this period."), cw)
+	}
+
+	var body strings.Builder
+	body.WriteString(labelStyle.Render("Rate      "))
+	body.WriteString(valueStyle.Render(fmt.Sprintf("%s of sessions", cli.FormatPercent(c.Rate()))))
+	body.WriteString(hintStyle.Render(fmt.Sprintf("  (%d of %d, %d compactions)", c.Compacted, c.Sessions, c.Compactions)))
+	body.WriteString("\n")
+	body.WriteString(labelStyle.Render("Avg cost  "))
+	body.WriteString(lipgloss.NewStyle().Foreground(t.Orange).Background(t.Surface).Render(cli.FormatCost(c.CompactedCost)))
+	body.WriteString(hintStyle.Render(" compacted vs "))
+	body.WriteString(valueStyle.Render(cli.FormatCost(c.OtherCost)))
+	body.WriteString(hintStyle.Render(" otherwise"))
+	return components.ContentCard("Compaction", body.String(), cw)
+}
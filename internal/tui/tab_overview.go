@@ -6,13 +6,43 @@ import (
 	"time"
 
 	"github.com/theirongolddev/cburn/internal/cli"
+	"github.com/theirongolddev/cburn/internal/model"
 	"github.com/theirongolddev/cburn/internal/pipeline"
+	"github.com/theirongolddev/cburn/internal/redact"
 	"github.com/theirongolddev/cburn/internal/tui/components"
 	"github.com/theirongolddev/cburn/internal/tui/theme"
 
 	"github.com/charmbracelet/lipgloss"
 )
 
+// overviewState tracks the Overview tab's daily chart cursor and scrolling.
+type overviewState struct {
+	chartActive bool
+	chartCursor int  // bar index, oldest day = 0
+	chartTotals bool // show plain totals instead of stacking by model
+	scroll      int  // page scroll offset, for terminals too short for every card
+}
+
+// moveChartCursor moves the highlighted bar by delta, starting from the most
+// recent day when the cursor is first activated.
+func (o *overviewState) moveChartCursor(delta, n int) {
+	if n == 0 {
+		return
+	}
+	if !o.chartActive {
+		o.chartActive = true
+		o.chartCursor = n - 1
+		return
+	}
+	o.chartCursor += delta
+	if o.chartCursor < 0 {
+		o.chartCursor = 0
+	}
+	if o.chartCursor >= n {
+		o.chartCursor = n - 1
+	}
+}
+
 func (a App) renderOverviewTab(cw int) string {
 	t := theme.Active
 	stats := a.stats
@@ -62,9 +92,24 @@ func (a App) renderOverviewTab(cw int) string {
 			chartVals[len(days)-1-i] = float64(d.InputTokens + d.OutputTokens + d.CacheCreation5m + d.CacheCreation1h)
 		}
 		chartInnerW := components.CardInnerWidth(cw)
+		cursor := -1
+		if a.overview.chartActive {
+			cursor = a.overview.chartCursor
+		}
+		var chart string
+		if names, stacks := a.dailyModelStacks(); !a.overview.chartTotals && len(names) > 1 {
+			colors := stackedModelColors(names)
+			chart = components.StackedBarChart(stacks, colors, chartLabels, chartInnerW, 10, cursor) +
+				"\n" + components.ChartLegend(names, colors)
+		} else {
+			chart = components.BarChartWithCursor(chartVals, chartLabels, t.BlueBright, chartInnerW, 10, cursor)
+		}
+		if cursor >= 0 && cursor < len(days) {
+			chart += "\n" + renderDayTooltip(days[len(days)-1-cursor])
+		}
 		b.WriteString(components.PanelCard(
 			fmt.Sprintf("Daily Token Usage (%dd)", a.days),
-			components.BarChart(chartVals, chartLabels, t.BlueBright, chartInnerW, 10),
+			chart,
 			cw,
 		))
 		b.WriteString("\n")
@@ -123,6 +168,18 @@ func (a App) renderOverviewTab(cw int) string {
 		b.WriteString("\n")
 	}
 
+	// Row 2.75: Live tail of the newest session
+	if a.tailFile.Path != "" {
+		b.WriteString(a.renderTailCard(cw))
+		b.WriteString("\n")
+	}
+
+	// Row 2.9: Custom metrics from pkg/plugin
+	for _, mc := range a.metrics {
+		b.WriteString(renderMetricCard(mc, cw))
+		b.WriteString("\n")
+	}
+
 	// Row 3: Model Split + Activity Patterns
 	halves := components.LayoutRow(cw, 2)
 	innerW := components.CardInnerWidth(halves[0])
@@ -251,9 +308,230 @@ func (a App) renderOverviewTab(cw int) string {
 		b.WriteString(components.CardRow([]string{modelCard, actCard}))
 	}
 
+	// Row 3.5: Output throughput over time
+	if card := a.renderThroughputCard(cw); card != "" {
+		b.WriteString("\n")
+		b.WriteString(card)
+	}
+
+	// Row 3.6: Active time vs Claude time
+	if card := a.renderActiveTimeCard(cw); card != "" {
+		b.WriteString("\n")
+		b.WriteString(card)
+	}
+
+	// Row 4: Efficiency outliers
+	if len(a.inefficient) > 0 {
+		b.WriteString("\n")
+		b.WriteString(a.renderInefficientCard(cw))
+	}
+
 	return b.String()
 }
 
+// renderThroughputCard plots output tokens per active minute by day against
+// the previous period, so a slowdown from throttling or a model change shows.
+func (a App) renderThroughputCard(cw int) string {
+	t := theme.Active
+	days := a.dailyStats
+	if len(days) < 2 || a.stats.TotalDurationSecs == 0 {
+		return ""
+	}
+
+	cur := make([]float64, len(days))
+	for i, d := range days {
+		cur[len(days)-1-i] = pipeline.OutputPerMinute(d.OutputTokens, d.DurationSecs)
+	}
+	prev := make([]float64, len(a.prevDaily))
+	for i, d := range a.prevDaily {
+		prev[len(a.prevDaily)-1-i] = pipeline.OutputPerMinute(d.OutputTokens, d.DurationSecs)
+	}
+	if len(prev) > len(cur) {
+		prev = prev[len(prev)-len(cur):]
+	}
+
+	series := []components.LineSeries{
+		{Name: fmt.Sprintf("previous %dd", a.days), Values: prev, Color: t.TextDim},
+		{Name: fmt.Sprintf("last %dd", a.days), Values: cur, Color: t.Cyan},
+	}
+	chartH := 6
+	if a.isCompactLayout() {
+		chartH = 5
+	}
+	chart := components.LineChart(series, chartDateLabels(days), components.CardInnerWidth(cw), chartH, func(v float64) string {
+		return cli.FormatTokens(int64(v))
+	})
+	rate := func(s model.SummaryStats) string {
+		return cli.FormatTokens(int64(pipeline.OutputPerMinute(s.OutputTokens, s.TotalDurationSecs))) + "/min"
+	}
+	legend := components.ChartLegend(
+		[]string{series[1].Name + " " + rate(a.stats), series[0].Name + " " + rate(a.prevStats)},
+		[]lipgloss.Color{series[1].Color, series[0].Color},
+	)
+
+	return components.ContentCard("Throughput  (output tokens per active minute)", chart+"\n"+legend, cw)
+}
+
+// renderActiveTimeCard plots the minutes spent actively in Claude Code each
+// day next to the minutes Claude spent working, with minutes per dollar.
+func (a App) renderActiveTimeCard(cw int) string {
+	t := theme.Active
+	days := a.dailyStats
+	if len(days) < 2 || a.stats.ActiveSecs == 0 {
+		return ""
+	}
+
+	active := make([]float64, len(days))
+	claude := make([]float64, len(days))
+	for i, d := range days {
+		active[len(days)-1-i] = float64(d.ActiveSecs) / 60
+		claude[len(days)-1-i] = float64(d.ClaudeSecs) / 60
+	}
+
+	series := []components.LineSeries{
+		{Name: "Claude", Values: claude, Color: t.Magenta},
+		{Name: "active", Values: active, Color: t.Cyan},
+	}
+	chartH := 6
+	if a.isCompactLayout() {
+		chartH = 5
+	}
+	chart := components.LineChart(series, chartDateLabels(days), components.CardInnerWidth(cw), chartH, func(v float64) string {
+		return cli.FormatDuration(int64(v * 60))
+	})
+	legend := components.ChartLegend(
+		[]string{"active " + cli.FormatDuration(a.stats.ActiveSecs), "Claude " + cli.FormatDuration(a.stats.ClaudeSecs)},
+		[]lipgloss.Color{series[1].Color, series[0].Color},
+	)
+	if mpd := pipeline.MinutesPerDollar(a.stats.ActiveSecs, a.stats.EstimatedCost); mpd > 0 {
+		mutedStyle := lipgloss.NewStyle().Foreground(t.TextMuted).Background(t.Surface)
+		legend += mutedStyle.Render(fmt.Sprintf("   %.1f active min per $", mpd))
+	}
+
+	return components.ContentCard("Active Time vs Claude Time  (per day)", chart+"\n"+legend, cw)
+}
+
+// renderInefficientCard lists the costliest sessions flagged by
+// pipeline.FindInefficientSessions.
+func (a App) renderInefficientCard(cw int) string {
+	t := theme.Active
+	innerW := components.CardInnerWidth(cw)
+	base := a.effBaseline
+
+	mutedStyle := lipgloss.NewStyle().Foreground(t.TextMuted).Background(t.Surface)
+	textStyle := lipgloss.NewStyle().Foreground(t.TextPrimary).Background(t.Surface)
+	costStyle := lipgloss.NewStyle().Foreground(t.Orange).Background(t.Surface)
+	warnStyle := lipgloss.NewStyle().Foreground(t.Yellow).Background(t.Surface)
+
+	var body strings.Builder
+	body.WriteString(mutedStyle.Render(fmt.Sprintf("Baseline: %s tok/prompt, %s cache hit (median of %d)",
+		cli.FormatTokens(int64(base.TokensPerPrompt)), cli.FormatPercent(base.CacheHitRate), base.Sessions)))
+	body.WriteString("\n")
+
+	limit := 5
+	if len(a.inefficient) < limit {
+		limit = len(a.inefficient)
+	}
+	// date(12) + space + cost(8) + space + why(~16) leaves the rest for the project
+	projW := innerW - 40
+	if projW < 8 {
+		projW = 8
+	}
+	for _, e := range a.inefficient[:limit] {
+		var why []string
+		if e.HighTokens {
+			why = append(why, fmt.Sprintf("%.1fx tokens", e.TokensPerPrompt/base.TokensPerPrompt))
+		}
+		if e.LowCache {
+			why = append(why, "low cache")
+		}
+		body.WriteString(mutedStyle.Render(e.Session.StartTime.Local().Format("Jan 02 15:04")))
+		body.WriteString(mutedStyle.Render(" "))
+		body.WriteString(textStyle.Render(fmt.Sprintf("%-*s", projW, truncStr(e.Session.Project, projW))))
+		body.WriteString(mutedStyle.Render(" "))
+		body.WriteString(costStyle.Render(fmt.Sprintf("%8s", cli.FormatCost(e.Session.EstimatedCost))))
+		body.WriteString(mutedStyle.Render(" "))
+		body.WriteString(warnStyle.Render(strings.Join(why, ", ")))
+		body.WriteString("\n")
+	}
+	if more := len(a.inefficient) - limit; more > 0 {
+		body.WriteString(mutedStyle.Render(fmt.Sprintf("+%d more (cburn analyze)", more)))
+	}
+
+	return components.ContentCard(fmt.Sprintf("Inefficient Sessions (%d)", len(a.inefficient)), body.String(), cw)
+}
+
+// maxStackedModels is how many models get their own segment in the stacked
+// daily chart; the rest are grouped as "other".
+const maxStackedModels = 4
+
+// dailyModelStacks splits each day's tokens by model for the stacked daily
+// chart, oldest day first. Segments follow the Model Split order.
+func (a App) dailyModelStacks() ([]string, [][]float64) {
+	var names []string
+	index := make(map[string]int)
+	for _, ms := range a.models {
+		if len(names) == maxStackedModels {
+			break
+		}
+		index[ms.Model] = len(names)
+		names = append(names, shortModel(ms.Model))
+	}
+	other := len(names)
+	hasOther := false
+
+	n := len(a.dailyStats)
+	stacks := make([][]float64, n)
+	for i, d := range a.dailyStats {
+		segs := make([]float64, other+1)
+		for name, tok := range d.ModelTokens {
+			k, ok := index[name]
+			if !ok {
+				k = other
+				hasOther = hasOther || tok > 0
+			}
+			segs[k] += float64(tok)
+		}
+		stacks[n-1-i] = segs
+	}
+	if hasOther {
+		names = append(names, "other")
+	}
+	return names, stacks
+}
+
+// stackedModelColors returns segment colors for the stacked daily chart;
+// "other" is always dim. Yellow is reserved for the chart cursor.
+func stackedModelColors(names []string) []lipgloss.Color {
+	t := theme.Active
+	palette := []lipgloss.Color{t.BlueBright, t.Magenta, t.Cyan, t.Green}
+	colors := make([]lipgloss.Color, len(names))
+	for i, name := range names {
+		if name == "other" {
+			colors[i] = t.TextDim
+			continue
+		}
+		colors[i] = palette[i%len(palette)]
+	}
+	return colors
+}
+
+// renderDayTooltip describes the day under the chart cursor.
+func renderDayTooltip(d model.DailyStats) string {
+	t := theme.Active
+	markStyle := lipgloss.NewStyle().Foreground(t.Yellow).Background(t.Surface)
+	dateStyle := lipgloss.NewStyle().Foreground(t.TextPrimary).Background(t.Surface).Bold(true)
+	valStyle := lipgloss.NewStyle().Foreground(t.TextMuted).Background(t.Surface)
+	costStyle := lipgloss.NewStyle().Foreground(t.Orange).Background(t.Surface)
+
+	tokens := d.InputTokens + d.OutputTokens + d.CacheCreation5m + d.CacheCreation1h
+	return markStyle.Render("▸ ") +
+		dateStyle.Render(d.Date.Format("Mon Jan 02")) +
+		valStyle.Render(fmt.Sprintf("  %s tokens  ", cli.FormatTokens(tokens))) +
+		costStyle.Render(cli.FormatCost(d.EstimatedCost)) +
+		valStyle.Render(fmt.Sprintf("  %d sessions  %d prompts", d.Sessions, d.Prompts))
+}
+
 // hourLabels24 returns X-axis labels for 24 hourly buckets.
 func hourLabels24() []string {
 	labels := make([]string, 24)
@@ -275,3 +553,79 @@ func hourLabels24() []string {
 func minuteLabels() []string {
 	return []string{"-55", "-50", "-45", "-40", "-35", "-30", "-25", "-20", "-15", "-10", "-5", "now"}
 }
+
+// renderTailCard shows the latest API calls in the most recently active session.
+func (a App) renderTailCard(cw int) string {
+	t := theme.Active
+	innerW := components.CardInnerWidth(cw)
+
+	timeStyle := lipgloss.NewStyle().Foreground(t.TextMuted).Background(t.Surface)
+	modelStyle := lipgloss.NewStyle().Foreground(t.BlueBright).Background(t.Surface)
+	tokenStyle := lipgloss.NewStyle().Foreground(t.Cyan).Background(t.Surface)
+	costStyle := lipgloss.NewStyle().Foreground(t.GreenBright).Background(t.Surface)
+	dimStyle := lipgloss.NewStyle().Foreground(t.TextDim).Background(t.Surface)
+
+	rows := 5
+	if a.isCompactLayout() {
+		rows = 3
+	}
+
+	var total float64
+	for _, c := range a.tailCalls {
+		total += c.EstimatedCost
+	}
+
+	var body strings.Builder
+	if len(a.tailCalls) == 0 {
+		body.WriteString(dimStyle.Render("Waiting for new API calls..."))
+	} else {
+		modelW := innerW - 8 - 1 - 16 - 1 - 9
+		if modelW < 8 {
+			modelW = 8
+		}
+		start := len(a.tailCalls) - rows
+		if start < 0 {
+			start = 0
+		}
+		// Newest first
+		for i := len(a.tailCalls) - 1; i >= start; i-- {
+			c := a.tailCalls[i]
+			body.WriteString(timeStyle.Render(c.Timestamp.Local().Format("15:04:05")))
+			body.WriteString(modelStyle.Render(fmt.Sprintf(" %-*s", modelW, truncStr(shortModel(c.Model), modelW))))
+			body.WriteString(tokenStyle.Render(fmt.Sprintf(" %7s/%-7s", cli.FormatTokens(c.InputTokens+c.CacheReadTokens), cli.FormatTokens(c.OutputTokens))))
+			body.WriteString(costStyle.Render(fmt.Sprintf(" %8s", cli.FormatCost(c.EstimatedCost))))
+			if i > start {
+				body.WriteString("\n")
+			}
+		}
+	}
+
+	title := fmt.Sprintf("Live · %s", redact.Project(a.tailFile.Project))
+	if len(a.tailCalls) > 0 {
+		title += fmt.Sprintf(" (%d calls, %s)", len(a.tailCalls), cli.FormatCost(total))
+	}
+	return components.ContentCard(title, body.String(), cw)
+}
+
+// renderMetricCard renders a custom metric's card as label/value rows.
+func renderMetricCard(mc model.MetricCard, cw int) string {
+	t := theme.Active
+	labelStyle := lipgloss.NewStyle().Foreground(t.TextMuted).Background(t.Surface)
+	valueStyle := lipgloss.NewStyle().Foreground(t.TextPrimary).Background(t.Surface).Bold(true)
+
+	labelW := 0
+	for _, r := range mc.Rows {
+		labelW = max(labelW, lipgloss.Width(r.Label))
+	}
+	labelW = min(labelW, components.CardInnerWidth(cw)/2)
+
+	var body strings.Builder
+	for i, r := range mc.Rows {
+		if i > 0 {
+			body.WriteString("\n")
+		}
+		body.WriteString(labelStyle.Render(fmt.Sprintf("%-*s ", labelW, truncStr(r.Label, labelW))))
+		body.WriteString(valueStyle.Render(r.Value))
+	}
+	return components.ContentCard(mc.Title, body.String(), cw)
+}
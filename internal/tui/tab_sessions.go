@@ -2,12 +2,16 @@ package tui
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/theirongolddev/cburn/internal/cli"
 	"github.com/theirongolddev/cburn/internal/config"
 	"github.com/theirongolddev/cburn/internal/model"
+	"github.com/theirongolddev/cburn/internal/pipeline"
+	"github.com/theirongolddev/cburn/internal/redact"
 	"github.com/theirongolddev/cburn/internal/tui/components"
 	"github.com/theirongolddev/cburn/internal/tui/theme"
 
@@ -39,44 +43,50 @@ type sessionsState struct {
 	searching   bool            // true when search input is active
 	searchInput textinput.Model // the search text input
 	searchQuery string          // the applied search filter
+
+	showHidden bool // list sessions hidden from totals as well
+
+	// Note editor for the selected session
+	annotating  bool
+	noteSession string // ID of the session being annotated
+	noteInput   textinput.Model
+	tagSessions []string // sessions getting bulk #tags instead; nil for a note
+
+	// Visual select (v): the rows from anchor to cursor
+	visual bool
+	anchor int
+
+	yankPending bool // y was pressed; the next key picks what to copy
+
+	// Transcript preview (p) of the selected session's prompts
+	transcript    *sessionTranscript
+	transcriptAsk bool // p was pressed; waiting for y to confirm
+	transcriptOK  bool // confirmed once this run
 }
 
 // newSearchInput creates a configured text input for session search.
 func newSearchInput() textinput.Model {
 	ti := textinput.New()
-	ti.Placeholder = "search by project, cost, tokens..."
+	ti.Placeholder = "project text, cost>5, tokens>1M, model:opus, date:2025-12-01..."
 	ti.CharLimit = 100
-	ti.Width = 40
+	ti.Width = 60
 	return ti
 }
 
-// filterSessionsBySearch returns sessions matching the search query.
-// Matches against project name and formats cost/tokens for numeric searches.
-func filterSessionsBySearch(sessions []model.SessionStats, query string) []model.SessionStats {
-	if query == "" {
-		return sessions
+// filterSessionsBySearch returns sessions matching every term of the search
+// query (see parseSessionSearch), or an error if the query doesn't parse.
+func filterSessionsBySearch(sessions []model.SessionStats, query string) ([]model.SessionStats, error) {
+	terms, err := parseSessionSearch(query)
+	if err != nil || len(terms) == 0 {
+		return sessions, err
 	}
-	query = strings.ToLower(query)
 	var result []model.SessionStats
 	for _, s := range sessions {
-		// Match project name
-		if strings.Contains(strings.ToLower(s.Project), query) {
-			result = append(result, s)
-			continue
-		}
-		// Match session ID prefix
-		if strings.Contains(strings.ToLower(s.SessionID), query) {
+		if matchesSearch(terms, s) {
 			result = append(result, s)
-			continue
-		}
-		// Match cost (e.g., "$0.50" or "0.5")
-		costStr := cli.FormatCost(s.EstimatedCost)
-		if strings.Contains(strings.ToLower(costStr), query) {
-			result = append(result, s)
-			continue
 		}
 	}
-	return result
+	return result, nil
 }
 
 func (a App) renderSessionsContent(filtered []model.SessionStats, cw, h int) string {
@@ -95,16 +105,45 @@ func (a App) renderSessionsContent(filtered []model.SessionStats, cw, h int) str
 		keyStyle := lipgloss.NewStyle().Foreground(t.Accent).Background(t.Surface)
 		b.WriteString(spaceStyle.Render("  ") + hintStyle.Render("[") + keyStyle.Render("Enter") + hintStyle.Render("] apply  [") +
 			keyStyle.Render("Esc") + hintStyle.Render("] cancel"))
+		b.WriteString("\n")
+		b.WriteString(spaceStyle.Render("  ") + hintStyle.Render(searchHelp))
 		b.WriteString("\n\n")
 
 		// Show preview of filtered results
-		previewFiltered := filterSessionsBySearch(a.filtered, ss.searchInput.Value())
 		countStyle := lipgloss.NewStyle().Foreground(t.TextMuted).Background(t.Surface)
-		b.WriteString(countStyle.Render(fmt.Sprintf("  %d sessions match", len(previewFiltered))))
+		previewFiltered, err := filterSessionsBySearch(a.filtered, ss.searchInput.Value())
+		if err != nil {
+			errStyle := lipgloss.NewStyle().Foreground(t.Red).Background(t.Surface)
+			b.WriteString(errStyle.Render("  " + err.Error()))
+		} else {
+			b.WriteString(countStyle.Render(fmt.Sprintf("  %d sessions match", len(previewFiltered))))
+		}
 
 		return b.String()
 	}
 
+	if ss.annotating {
+		var b strings.Builder
+		labelStyle := lipgloss.NewStyle().Foreground(t.Accent).Background(t.Surface).Bold(true)
+		spaceStyle := lipgloss.NewStyle().Background(t.Surface)
+		hintStyle := lipgloss.NewStyle().Foreground(t.TextDim).Background(t.Surface)
+		keyStyle := lipgloss.NewStyle().Foreground(t.Accent).Background(t.Surface)
+		if ss.tagSessions != nil {
+			b.WriteString(labelStyle.Render(fmt.Sprintf("  Tags for %d sessions: ", len(ss.tagSessions))))
+			b.WriteString(ss.noteInput.View())
+			b.WriteString("\n")
+			b.WriteString(spaceStyle.Render("  ") + hintStyle.Render("[") + keyStyle.Render("Enter") + hintStyle.Render("] add  [") +
+				keyStyle.Render("Esc") + hintStyle.Render("] cancel   #word adds a tag to each; notes are kept"))
+			return b.String()
+		}
+		b.WriteString(labelStyle.Render("  Note for " + shortID(ss.noteSession) + ": "))
+		b.WriteString(ss.noteInput.View())
+		b.WriteString("\n")
+		b.WriteString(spaceStyle.Render("  ") + hintStyle.Render("[") + keyStyle.Render("Enter") + hintStyle.Render("] save  [") +
+			keyStyle.Render("Esc") + hintStyle.Render("] cancel   #word adds a tag; save empty to clear"))
+		return b.String()
+	}
+
 	// Build title with search indicator
 	title := fmt.Sprintf("Sessions [%dd]", a.days)
 	if ss.searchQuery != "" {
@@ -151,18 +190,10 @@ func (a App) renderSessionsSplit(sessions []model.SessionStats, cw, h int) strin
 		return ""
 	}
 
-	leftW := cw / 4
-	if leftW < 36 {
-		leftW = 36
-	}
-	minRightW := 50
-	maxLeftW := cw - minRightW
-	if maxLeftW < 20 {
+	leftW, ok := sessSplitLeftWidth(cw)
+	if !ok {
 		return a.renderSessionDetail(sessions, cw, h)
 	}
-	if leftW > maxLeftW {
-		leftW = maxLeftW
-	}
 	rightW := cw - leftW
 
 	// Left pane: condensed session list
@@ -174,23 +205,11 @@ func (a App) renderSessionsSplit(sessions []model.SessionStats, cw, h int) strin
 	costStyle := lipgloss.NewStyle().Foreground(t.Green).Background(t.Surface)
 
 	var leftBody strings.Builder
-	visible := h - sessListOverhead
-	if visible < sessMinVisible {
-		visible = sessMinVisible
-	}
-
-	offset := ss.offset
-	if cursor < offset {
-		offset = cursor
-	}
-	if cursor >= offset+visible {
-		offset = cursor - visible + 1
-	}
+	offset, end := ss.listWindow(cursor, len(sessions), h)
 
-	end := offset + visible
-	if end > len(sessions) {
-		end = len(sessions)
-	}
+	liveStyle := lipgloss.NewStyle().Foreground(t.Green).Background(t.Surface)
+	warnStyle := lipgloss.NewStyle().Foreground(t.Orange).Background(t.Surface).Bold(true)
+	now := time.Now()
 
 	for i := offset; i < end; i++ {
 		s := sessions[i]
@@ -217,9 +236,20 @@ func (a App) renderSessionsSplit(sessions []model.SessionStats, cw, h int) strin
 				selectedCostStyle.Render(costStr) +
 				lipgloss.NewStyle().Background(t.SurfaceBright).Render(strings.Repeat(" ", max(0, leftInner-len(leftPart)-padN-len(costStr)))))
 		} else {
-			// Normal row
+			// Normal row; live sessions get a green dot in the marker column,
+			// likely runaway agents a warning sign
+			prefix := lipgloss.NewStyle().Background(t.Surface).Render("  ")
+			if ss.inSelection(i) {
+				prefix = lipgloss.NewStyle().Foreground(t.AccentBright).Background(t.Surface).Bold(true).Render("▌ ")
+			} else if s.Excluded {
+				prefix = mutedStyle.Render("⊘ ")
+			} else if pipeline.IsRunaway(s) {
+				prefix = warnStyle.Render("⚠ ")
+			} else if pipeline.IsActive(s, now) {
+				prefix = liveStyle.Render("● ")
+			}
 			leftBody.WriteString(
-				lipgloss.NewStyle().Background(t.Surface).Render("  ") +
+				prefix +
 					mutedStyle.Render(fmt.Sprintf("%-13s", startStr)) +
 					lipgloss.NewStyle().Background(t.Surface).Render(" ") +
 					rowStyle.Render(dur) +
@@ -234,6 +264,13 @@ func (a App) renderSessionsSplit(sessions []model.SessionStats, cw, h int) strin
 	if ss.searchQuery != "" {
 		leftTitle = fmt.Sprintf("Search: %q (%d)", ss.searchQuery, len(sessions))
 	}
+	if a.hiddenCount > 0 {
+		leftTitle += fmt.Sprintf(" · %d hidden", a.hiddenCount)
+	}
+	if ss.visual {
+		lo, hi := ss.selectionRange()
+		leftTitle += fmt.Sprintf(" · %d selected", min(hi, len(sessions)-1)-lo+1)
+	}
 	leftCard := components.ContentCard(leftTitle, leftBody.String(), leftW)
 
 	// Right pane: full session detail with scroll support
@@ -249,6 +286,47 @@ func (a App) renderSessionsSplit(sessions []model.SessionStats, cw, h int) strin
 	return components.CardRow([]string{leftCard, rightCard})
 }
 
+// sessSplitLeftWidth returns the width of the list pane in the split view,
+// or false when the content is too narrow to split.
+func sessSplitLeftWidth(cw int) (int, bool) {
+	leftW := cw / 4
+	if leftW < 36 {
+		leftW = 36
+	}
+	minRightW := 50
+	maxLeftW := cw - minRightW
+	if maxLeftW < 20 {
+		return 0, false
+	}
+	if leftW > maxLeftW {
+		leftW = maxLeftW
+	}
+	return leftW, true
+}
+
+// listWindow returns the range of the n sessions the split list shows with
+// the cursor on screen, for a content zone h lines tall.
+func (ss sessionsState) listWindow(cursor, n, h int) (offset, end int) {
+	visible := h - sessListOverhead
+	if visible < sessMinVisible {
+		visible = sessMinVisible
+	}
+
+	offset = ss.offset
+	if cursor < offset {
+		offset = cursor
+	}
+	if cursor >= offset+visible {
+		offset = cursor - visible + 1
+	}
+
+	end = offset + visible
+	if end > n {
+		end = n
+	}
+	return offset, end
+}
+
 func (a App) renderSessionDetail(sessions []model.SessionStats, cw, h int) string {
 	t := theme.Active
 	ss := a.sessState
@@ -305,11 +383,70 @@ func (a App) renderDetailBody(sel model.SessionStats, w int, mutedStyle lipgloss
 		timeStr += " " + sel.StartTime.Local().Format("MST")
 		body.WriteString(labelStyle.Render("Duration: "))
 		body.WriteString(timeStyle.Render(durStr))
+		if sel.RawDurationSecs > sel.DurationSecs {
+			body.WriteString(dimStyle.Render(" of " + cli.FormatDuration(sel.RawDurationSecs) + " wall"))
+		}
 		body.WriteString(dimStyle.Render(" ("))
 		body.WriteString(mutedStyle.Render(timeStr))
 		body.WriteString(dimStyle.Render(")"))
+		if pipeline.IsActive(sel, time.Now()) {
+			body.WriteString(lipgloss.NewStyle().Foreground(t.Green).Background(t.Surface).Bold(true).Render("  ● active"))
+		}
+		body.WriteString("\n")
+		if active := pipeline.ActiveSecs(sel, pipeline.IdleGap()); active > 0 && !sel.IsSubagent {
+			body.WriteString(labelStyle.Render("Active: "))
+			body.WriteString(timeStyle.Render(cli.FormatDuration(active)))
+			if sel.ClaudeSecs > 0 {
+				body.WriteString(dimStyle.Render("  Claude "))
+				body.WriteString(timeStyle.Render(cli.FormatDuration(sel.ClaudeSecs)))
+			}
+			body.WriteString("\n")
+		}
+	}
+
+	if sel.Source != "" {
+		body.WriteString(labelStyle.Render("Source: "))
+		body.WriteString(modelStyle.Render(sel.Source))
+		body.WriteString("\n")
+	}
+
+	if sel.GitBranch != "" {
+		body.WriteString(labelStyle.Render("Branch: "))
+		if sel.Repo != "" {
+			body.WriteString(mutedStyle.Render(sel.Repo + " "))
+		}
+		body.WriteString(modelStyle.Render(sel.GitBranch))
+		body.WriteString("\n")
+	}
+
+	if sel.Automation != "" {
+		body.WriteString(labelStyle.Render("Origin: "))
+		body.WriteString(modelStyle.Render(model.OriginAutomation))
+		body.WriteString(dimStyle.Render("  (" + sel.Automation + ")"))
+		body.WriteString("\n")
+	}
+
+	if sel.Excluded {
+		body.WriteString(lipgloss.NewStyle().Foreground(t.Orange).Background(t.Surface).Bold(true).Render("⊘ Hidden from totals"))
+		body.WriteString(dimStyle.Render("  (X to restore)"))
+		body.WriteString("\n")
+	}
+	if why := pipeline.RunawayReason(sel); why != "" {
+		body.WriteString(lipgloss.NewStyle().Foreground(t.Orange).Background(t.Surface).Bold(true).Render("⚠ Possible runaway agent"))
+		body.WriteString(dimStyle.Render("  (" + why + ")"))
+		body.WriteString("\n")
+	}
+	if sel.Note != "" {
+		body.WriteString(labelStyle.Render("Note: "))
+		body.WriteString(valueStyle.Render(sel.Note))
+		body.WriteString("\n")
+	}
+	if len(sel.UserTags) > 0 {
+		body.WriteString(labelStyle.Render("Tags: "))
+		body.WriteString(accentStyle.Render("#" + strings.Join(sel.UserTags, " #")))
 		body.WriteString("\n")
 	}
+	body.WriteString(a.renderTranscript(sel.SessionID, innerW))
 
 	ratio := 0.0
 	if sel.UserMessages > 0 {
@@ -323,7 +460,57 @@ func (a App) renderDetailBody(sel model.SessionStats, w int, mutedStyle lipgloss
 	body.WriteString(dimStyle.Render("    "))
 	body.WriteString(labelStyle.Render("Ratio: "))
 	body.WriteString(accentStyle.Render(fmt.Sprintf("%.1fx", ratio)))
-	body.WriteString("\n\n")
+	body.WriteString("\n")
+
+	if sel.EfficiencyScore > 0 {
+		scoreColor := t.Red
+		switch {
+		case sel.EfficiencyScore >= 70:
+			scoreColor = t.Green
+		case sel.EfficiencyScore >= 45:
+			scoreColor = t.Yellow
+		}
+		body.WriteString(labelStyle.Render("Efficiency: "))
+		body.WriteString(lipgloss.NewStyle().Foreground(scoreColor).Background(t.Surface).Bold(true).
+			Render(fmt.Sprintf("%d/100", sel.EfficiencyScore)))
+		body.WriteString(dimStyle.Render("  (cache hit " + cli.FormatPercent(sel.CacheHitRate) + ", vs project average)"))
+		body.WriteString("\n")
+	}
+
+	if perMin := pipeline.OutputPerMinute(sel.OutputTokens, sel.DurationSecs); perMin > 0 {
+		body.WriteString(labelStyle.Render("Throughput: "))
+		body.WriteString(tokenStyle.Render(cli.FormatTokens(int64(perMin))))
+		body.WriteString(dimStyle.Render(" output tokens/active min"))
+		body.WriteString("\n")
+	}
+
+	if sel.PeakContext > 0 {
+		body.WriteString(labelStyle.Render("Context: "))
+		body.WriteString(tokenStyle.Render(cli.FormatTokens(sel.PeakContext)))
+		body.WriteString(dimStyle.Render(fmt.Sprintf(" peak of %s (%s)",
+			cli.FormatTokens(pipeline.ContextWindow(sel)), cli.FormatPercent(pipeline.ContextShare(sel)))))
+		if pipeline.NearAutoCompact(sel) {
+			body.WriteString(lipgloss.NewStyle().Foreground(t.Orange).Background(t.Surface).Bold(true).Render("  ⚠ auto-compact range"))
+		}
+		body.WriteString("\n")
+		if len(sel.ContextCurve) > 1 {
+			body.WriteString(dimStyle.Render("  "))
+			body.WriteString(components.Sparkline(peakSample(sel.ContextCurve, innerW-2), t.Cyan))
+			body.WriteString("\n")
+		}
+	}
+	if sel.Compactions > 0 {
+		times := "times"
+		if sel.Compactions == 1 {
+			times = "time"
+		}
+		body.WriteString(labelStyle.Render("Compacted: "))
+		body.WriteString(lipgloss.NewStyle().Foreground(t.Orange).Background(t.Surface).
+			Render(fmt.Sprintf("%d %s", sel.Compactions, times)))
+		body.WriteString(dimStyle.Render("  (each rewrites the prompt cache)"))
+		body.WriteString("\n")
+	}
+	body.WriteString("\n")
 
 	// Token breakdown table with section header
 	sectionStyle := lipgloss.NewStyle().Foreground(t.AccentBright).Background(t.Surface).Bold(true)
@@ -347,7 +534,6 @@ func (a App) renderDetailBody(sel model.SessionStats, w int, mutedStyle lipgloss
 	cache5mCost := 0.0
 	cache1hCost := 0.0
 	cacheReadCost := 0.0
-	savings := 0.0
 
 	for modelName, mu := range sel.Models {
 		p, ok := config.LookupPricingAt(modelName, sel.StartTime)
@@ -357,7 +543,6 @@ func (a App) renderDetailBody(sel model.SessionStats, w int, mutedStyle lipgloss
 			cache5mCost += float64(mu.CacheCreation5mTokens) * p.CacheWrite5mPerMTok / 1e6
 			cache1hCost += float64(mu.CacheCreation1hTokens) * p.CacheWrite1hPerMTok / 1e6
 			cacheReadCost += float64(mu.CacheReadTokens) * p.CacheReadPerMTok / 1e6
-			savings += config.CalculateCacheSavingsAt(modelName, sel.StartTime, mu.CacheReadTokens)
 		}
 	}
 
@@ -399,7 +584,7 @@ func (a App) renderDetailBody(sel model.SessionStats, w int, mutedStyle lipgloss
 	body.WriteString(dimStyle.Render(" "))
 	body.WriteString(dimStyle.Render(fmt.Sprintf("%*s", tokW, "")))
 	body.WriteString(dimStyle.Render(" "))
-	body.WriteString(savingsStyle.Render(fmt.Sprintf("%*s", costW, cli.FormatCost(savings))))
+	body.WriteString(savingsStyle.Render(fmt.Sprintf("%*s", costW, cli.FormatCost(sel.CacheSavings))))
 	body.WriteString("\n")
 
 	// Model breakdown with colored data
@@ -514,14 +699,33 @@ func (a App) renderDetailBody(sel model.SessionStats, w int, mutedStyle lipgloss
 	body.WriteString("\n")
 	hintKeyStyle := lipgloss.NewStyle().Foreground(t.Accent).Background(t.Surface)
 	hintTextStyle := lipgloss.NewStyle().Foreground(t.TextDim).Background(t.Surface)
-	if w < compactWidth {
+	if a.sessState.visual {
+		n := len(a.selectedSessions())
+		body.WriteString(hintKeyStyle.Render(fmt.Sprintf("%d selected  ", n)) +
+			hintTextStyle.Render("[") + hintKeyStyle.Render("X") + hintTextStyle.Render("] hide  [") +
+			hintKeyStyle.Render("n") + hintTextStyle.Render("] tag  [") +
+			hintKeyStyle.Render("e/E") + hintTextStyle.Render("] export CSV/JSON  [") +
+			hintKeyStyle.Render("y") + hintTextStyle.Render("] copy summary  [") +
+			hintKeyStyle.Render("Esc") + hintTextStyle.Render("] done"))
+	} else if w < compactWidth {
 		body.WriteString(hintTextStyle.Render("[") + hintKeyStyle.Render("/") + hintTextStyle.Render("] search  [") +
+			hintKeyStyle.Render("n") + hintTextStyle.Render("] note  [") +
 			hintKeyStyle.Render("j/k") + hintTextStyle.Render("] navigate  [") +
 			hintKeyStyle.Render("J/K") + hintTextStyle.Render("] scroll  [") +
 			hintKeyStyle.Render("q") + hintTextStyle.Render("] quit"))
+	} else if a.sessState.viewMode == sessViewDetail {
+		body.WriteString(hintTextStyle.Render("[") + hintKeyStyle.Render("o") + hintTextStyle.Render("] open in $EDITOR  [") +
+			hintKeyStyle.Render("O") + hintTextStyle.Render("] reveal file  [") +
+			hintKeyStyle.Render("p") + hintTextStyle.Render("] prompts  [") +
+			hintKeyStyle.Render("n") + hintTextStyle.Render("] note  [") +
+			hintKeyStyle.Render("y") + hintTextStyle.Render("] copy  [") +
+			hintKeyStyle.Render("J/K/^d/^u") + hintTextStyle.Render("] scroll  [") +
+			hintKeyStyle.Render("q") + hintTextStyle.Render("] back"))
 	} else {
 		body.WriteString(hintTextStyle.Render("[") + hintKeyStyle.Render("/") + hintTextStyle.Render("] search  [") +
 			hintKeyStyle.Render("Enter") + hintTextStyle.Render("] expand  [") +
+			hintKeyStyle.Render("n") + hintTextStyle.Render("] note  [") +
+			hintKeyStyle.Render("y") + hintTextStyle.Render("] copy  [") +
 			hintKeyStyle.Render("j/k") + hintTextStyle.Render("] navigate  [") +
 			hintKeyStyle.Render("J/K/^d/^u") + hintTextStyle.Render("] scroll  [") +
 			hintKeyStyle.Render("q") + hintTextStyle.Render("] quit"))
@@ -531,6 +735,7 @@ func (a App) renderDetailBody(sel model.SessionStats, w int, mutedStyle lipgloss
 }
 
 func shortID(id string) string {
+	id = redact.ID(id)
 	if len(id) > 8 {
 		return id[:8]
 	}
@@ -593,3 +798,15 @@ func tokenTableLayout(innerW int) (typeW, tokenW, costW, tableW int) {
 	tableW = typeW + tokenW + costW + 2
 	return
 }
+
+// peakSample reduces values to at most n points for a sparkline, keeping
+// each span's largest value.
+func peakSample(values []int64, n int) []float64 {
+	n = max(min(n, len(values)), 1)
+	out := make([]float64, n)
+	for i := range n {
+		span := values[i*len(values)/n : (i+1)*len(values)/n]
+		out[i] = float64(slices.Max(span))
+	}
+	return out
+}
@@ -2,12 +2,16 @@ package tui
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/theirongolddev/cburn/internal/claudeai"
 	"github.com/theirongolddev/cburn/internal/cli"
 	"github.com/theirongolddev/cburn/internal/config"
+	"github.com/theirongolddev/cburn/internal/redact"
+	"github.com/theirongolddev/cburn/internal/store"
 	"github.com/theirongolddev/cburn/internal/tui/components"
 	"github.com/theirongolddev/cburn/internal/tui/theme"
 
@@ -19,6 +23,7 @@ import (
 const (
 	settingsFieldAPIKey = iota
 	settingsFieldSessionKey
+	settingsFieldOrg
 	settingsFieldTheme
 	settingsFieldDays
 	settingsFieldBudget
@@ -34,8 +39,17 @@ type settingsState struct {
 	cursor  int
 	editing bool
 	input   textinput.Model
-	saved   bool  // flash "saved" message briefly
 	saveErr error // non-nil if last save failed
+
+	orgCursor int // highlighted org while picking from a multi-org account
+
+	// Enumerable fields are edited by cycling through their choices
+	choices   []string
+	choice    int
+	prevTheme string // restored when a previewed theme is canceled
+
+	gallery       bool // theme gallery open in place of the form
+	galleryCursor int
 }
 
 func newSettingsInput() textinput.Model {
@@ -48,7 +62,7 @@ func newSettingsInput() textinput.Model {
 func (a App) settingsStartEdit() (tea.Model, tea.Cmd) {
 	cfg := loadConfigOrDefault()
 	a.settings.editing = true
-	a.settings.saved = false
+	a.settings.choices = nil
 
 	ti := newSettingsInput()
 
@@ -69,10 +83,28 @@ func (a App) settingsStartEdit() (tea.Model, tea.Cmd) {
 		if existing != "" {
 			ti.SetValue(existing)
 		}
-	case settingsFieldTheme:
-		ti.Placeholder = "flexoki-dark, catppuccin-mocha, tokyo-night, terminal"
-		ti.SetValue(cfg.Appearance.Theme)
+	case settingsFieldOrg:
+		if orgs := a.knownOrgs(); len(orgs) > 1 {
+			// Pick from the orgs the last fetch returned instead of typing.
+			a.settings.orgCursor = 0
+			for i, o := range orgs {
+				if o.UUID == a.subData.Org.UUID {
+					a.settings.orgCursor = i
+				}
+			}
+			return a, nil
+		}
+		ti.Placeholder = "org UUID or name (empty = first org)"
+		ti.SetValue(cfg.ClaudeAI.OrgID)
 		ti.EchoMode = textinput.EchoNormal
+	case settingsFieldTheme:
+		a.settings.choices = make([]string, len(theme.All))
+		for i, t := range theme.All {
+			a.settings.choices[i] = t.Name
+		}
+		a.settings.prevTheme = theme.Active.Name
+		a.settings.choice = max(slices.Index(a.settings.choices, theme.Active.Name), 0)
+		return a, nil
 	case settingsFieldDays:
 		ti.Placeholder = "30"
 		ti.SetValue(strconv.Itoa(cfg.General.DefaultDays))
@@ -84,9 +116,9 @@ func (a App) settingsStartEdit() (tea.Model, tea.Cmd) {
 		}
 		ti.EchoMode = textinput.EchoNormal
 	case settingsFieldAutoRefresh:
-		ti.Placeholder = "true or false"
-		ti.SetValue(strconv.FormatBool(a.autoRefresh))
-		ti.EchoMode = textinput.EchoNormal
+		a.settings.choices = []string{"true", "false"}
+		a.settings.choice = slices.Index(a.settings.choices, strconv.FormatBool(a.autoRefresh))
+		return a, nil
 	case settingsFieldRefreshInterval:
 		ti.Placeholder = "30 (seconds, minimum 10)"
 		// Use effective value from App state to match display
@@ -106,11 +138,21 @@ func (a App) settingsStartEdit() (tea.Model, tea.Cmd) {
 func (a App) updateSettingsInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	key := msg.String()
 
+	if a.settingsPickingOrg() {
+		return a.updateOrgPicker(key)
+	}
+	if a.settingsSelecting() {
+		return a.updateSettingsSelect(key)
+	}
+
 	switch key {
 	case "enter":
 		a.settingsSave()
 		a.settings.editing = false
-		a.settings.saved = a.settings.saveErr == nil
+		a.noteSettingsSave()
+		if a.settings.cursor == settingsFieldOrg {
+			return a, a.refetchSubData()
+		}
 		return a, nil
 	case "esc":
 		a.settings.editing = false
@@ -125,12 +167,22 @@ func (a App) updateSettingsInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 func (a *App) settingsSave() {
 	cfg := loadConfigOrDefault()
 	val := strings.TrimSpace(a.settings.input.Value())
+	if a.settingsSelecting() {
+		val = a.settings.choices[a.settings.choice]
+	}
 
 	switch a.settings.cursor {
 	case settingsFieldAPIKey:
 		cfg.AdminAPI.APIKey = val
+		a.teamEnabled = config.GetAdminAPIKey(cfg) != ""
+		a.teamFetchedAt = time.Time{} // refetch with the new key
 	case settingsFieldSessionKey:
+		if val != cfg.ClaudeAI.SessionKey {
+			cfg.ClaudeAI.SessionExpires = time.Time{} // hand-entered keys have no known expiry
+		}
 		cfg.ClaudeAI.SessionKey = val
+	case settingsFieldOrg:
+		cfg.ClaudeAI.OrgID = val
 	case settingsFieldTheme:
 		// Validate theme name
 		found := false
@@ -166,15 +218,135 @@ func (a *App) settingsSave() {
 	case settingsFieldRefreshInterval:
 		var interval int
 		if _, err := fmt.Sscanf(val, "%d", &interval); err == nil && interval >= 10 {
+			// A fixed interval only applies with adaptive refresh off.
 			cfg.TUI.RefreshIntervalSec = interval
+			cfg.TUI.AdaptiveRefresh = false
 			a.refreshInterval = time.Duration(interval) * time.Second
+			a.adaptiveRefresh = false
 		}
 	}
 
 	a.settings.saveErr = config.Save(cfg)
 }
 
+// settingsSelecting reports whether the field being edited cycles through
+// fixed choices instead of taking text.
+func (a App) settingsSelecting() bool {
+	return a.settings.editing && len(a.settings.choices) > 0
+}
+
+// updateSettingsSelect cycles an enumerable field with h/l, previewing
+// themes live; Esc puts the previous theme back.
+func (a App) updateSettingsSelect(key string) (tea.Model, tea.Cmd) {
+	n := len(a.settings.choices)
+	switch key {
+	case "h", "left", "k", "up":
+		a.settings.choice = (a.settings.choice + n - 1) % n
+	case "l", "right", "j", "down", "tab", " ":
+		a.settings.choice = (a.settings.choice + 1) % n
+	case "enter":
+		a.settingsSave()
+		a.settings.editing = false
+		a.settings.choices = nil
+		a.noteSettingsSave()
+		return a, nil
+	case "esc":
+		if a.settings.cursor == settingsFieldTheme {
+			theme.SetActive(a.settings.prevTheme)
+		}
+		a.settings.editing = false
+		a.settings.choices = nil
+		return a, nil
+	}
+	if a.settings.cursor == settingsFieldTheme {
+		theme.SetActive(a.settings.choices[a.settings.choice])
+	}
+	return a, nil
+}
+
+// renderSettingsChoices draws the choices of the field being edited inline,
+// highlighting the current one.
+func (a App) renderSettingsChoices() string {
+	t := theme.Active
+	arrowStyle := lipgloss.NewStyle().Foreground(t.TextMuted).Background(t.Surface)
+	optStyle := lipgloss.NewStyle().Foreground(t.TextMuted).Background(t.Surface)
+	curStyle := lipgloss.NewStyle().Foreground(t.TextPrimary).Background(t.SurfaceBright).Bold(true)
+	gap := lipgloss.NewStyle().Background(t.Surface).Render("  ")
+
+	parts := make([]string, len(a.settings.choices))
+	for i, c := range a.settings.choices {
+		if i == a.settings.choice {
+			parts[i] = curStyle.Render(" " + c + " ")
+		} else {
+			parts[i] = optStyle.Render(" " + c + " ")
+		}
+	}
+	return arrowStyle.Render("◂ ") + strings.Join(parts, gap) + arrowStyle.Render(" ▸")
+}
+
+// knownOrgs returns the organizations from the last claude.ai fetch.
+func (a App) knownOrgs() []claudeai.Organization {
+	if a.subData == nil {
+		return nil
+	}
+	return a.subData.Orgs
+}
+
+// settingsPickingOrg reports whether the org field is being edited as a list.
+func (a App) settingsPickingOrg() bool {
+	return a.settings.editing && a.settings.cursor == settingsFieldOrg && len(a.knownOrgs()) > 1
+}
+
+func (a App) updateOrgPicker(key string) (tea.Model, tea.Cmd) {
+	orgs := a.knownOrgs()
+	switch key {
+	case "j", "down":
+		if a.settings.orgCursor < len(orgs)-1 {
+			a.settings.orgCursor++
+		}
+	case "k", "up":
+		if a.settings.orgCursor > 0 {
+			a.settings.orgCursor--
+		}
+	case "enter":
+		cfg := loadConfigOrDefault()
+		cfg.ClaudeAI.OrgID = orgs[a.settings.orgCursor].UUID
+		a.settings.saveErr = config.Save(cfg)
+		a.settings.editing = false
+		a.noteSettingsSave()
+		return a, a.refetchSubData()
+	case "esc":
+		a.settings.editing = false
+	}
+	return a, nil
+}
+
+// noteSettingsSave confirms or reports the last settings save with a toast.
+func (a *App) noteSettingsSave() {
+	if a.settings.saveErr != nil {
+		a.toast(components.ToastError, "Save failed: "+a.settings.saveErr.Error())
+		return
+	}
+	a.toast(components.ToastSuccess, "Settings saved")
+}
+
+// refetchSubData starts a claude.ai fetch so a changed org preference shows
+// up without waiting for the periodic refresh.
+func (a *App) refetchSubData() tea.Cmd {
+	cfg := loadConfigOrDefault()
+	sessionKey := config.GetSessionKey(cfg)
+	if sessionKey == "" || a.subFetching {
+		return nil
+	}
+	a.subFetching = true
+	a.subTicks = 0
+	return fetchSubDataCmd(sessionKey, cfg.ClaudeAI.OrgID)
+}
+
 func (a App) renderSettingsTab(cw int) string {
+	if a.settings.gallery {
+		return a.renderThemeGallery(cw)
+	}
 	t := theme.Active
 	cfg := loadConfigOrDefault()
 
@@ -183,7 +355,6 @@ func (a App) renderSettingsTab(cw int) string {
 	selectedStyle := lipgloss.NewStyle().Foreground(t.TextPrimary).Background(t.SurfaceBright).Bold(true)
 	selectedLabelStyle := lipgloss.NewStyle().Foreground(t.Accent).Background(t.SurfaceBright).Bold(true)
 	accentStyle := lipgloss.NewStyle().Foreground(t.AccentBright).Background(t.Surface)
-	greenStyle := lipgloss.NewStyle().Foreground(t.GreenBright).Background(t.Surface)
 	markerStyle := lipgloss.NewStyle().Foreground(t.AccentBright).Background(t.SurfaceBright)
 
 	type field struct {
@@ -211,6 +382,16 @@ func (a App) renderSettingsTab(cw int) string {
 		}
 	}
 
+	orgDisplay := "(first)"
+	if a.subData != nil && a.subData.Org.UUID != "" {
+		orgDisplay = redact.Org(a.subData.Org.Name)
+		if n := len(a.subData.Orgs); n > 1 {
+			orgDisplay += fmt.Sprintf("  (%d orgs, Enter to switch)", n)
+		}
+	} else if cfg.ClaudeAI.OrgID != "" {
+		orgDisplay = redact.Org(cfg.ClaudeAI.OrgID)
+	}
+
 	// Use live App state for TUI-specific settings (auto-refresh, interval)
 	// to ensure display matches actual behavior after R toggle
 	refreshIntervalSec := int(a.refreshInterval.Seconds())
@@ -221,6 +402,7 @@ func (a App) renderSettingsTab(cw int) string {
 	fields := []field{
 		{"Admin API Key", apiKeyDisplay},
 		{"Session Key", sessionKeyDisplay},
+		{"Organization", orgDisplay},
 		{"Theme", cfg.Appearance.Theme},
 		{"Default Days", strconv.Itoa(cfg.General.DefaultDays)},
 		{"Monthly Budget", func() string {
@@ -230,16 +412,45 @@ func (a App) renderSettingsTab(cw int) string {
 			return "(not set)"
 		}()},
 		{"Auto Refresh", strconv.FormatBool(a.autoRefresh)},
-		{"Refresh Interval", fmt.Sprintf("%ds", refreshIntervalSec)},
+		{"Refresh Interval", func() string {
+			if a.adaptiveRefresh {
+				return fmt.Sprintf("adaptive %s-%s (next %s)",
+					cli.FormatDuration(int64(a.refreshMin.Seconds())),
+					cli.FormatDuration(int64(a.refreshMax.Seconds())),
+					cli.FormatDuration(int64(a.nextRefresh.Seconds())))
+			}
+			return fmt.Sprintf("%ds", refreshIntervalSec)
+		}()},
 	}
 
 	var formBody strings.Builder
 	for i, f := range fields {
-		// Show text input if currently editing this field
+		// Show the org list if picking one
+		if i == settingsFieldOrg && a.settingsPickingOrg() {
+			formBody.WriteString(markerStyle.Render("▸ "))
+			formBody.WriteString(accentStyle.Render(fmt.Sprintf("%-18s ", f.label)))
+			formBody.WriteString("\n")
+			for j, o := range a.knownOrgs() {
+				line := fmt.Sprintf("    %s  %s", redact.Org(o.Name), redact.ID(o.UUID))
+				if j == a.settings.orgCursor {
+					formBody.WriteString(selectedStyle.Render("  ▸ " + line[4:]))
+				} else {
+					formBody.WriteString(valueStyle.Render(line))
+				}
+				formBody.WriteString("\n")
+			}
+			continue
+		}
+
+		// Show the choices or text input if currently editing this field
 		if a.settings.editing && i == a.settings.cursor {
 			formBody.WriteString(markerStyle.Render("▸ "))
 			formBody.WriteString(accentStyle.Render(fmt.Sprintf("%-18s ", f.label)))
-			formBody.WriteString(a.settings.input.View())
+			if a.settingsSelecting() {
+				formBody.WriteString(a.renderSettingsChoices())
+			} else {
+				formBody.WriteString(a.settings.input.View())
+			}
 			formBody.WriteString("\n")
 			continue
 		}
@@ -272,19 +483,36 @@ func (a App) renderSettingsTab(cw int) string {
 		warnStyle := lipgloss.NewStyle().Foreground(t.Orange).Background(t.Surface)
 		formBody.WriteString("\n")
 		formBody.WriteString(warnStyle.Render(fmt.Sprintf("Save failed: %s", a.settings.saveErr)))
-	} else if a.settings.saved {
-		formBody.WriteString("\n")
-		formBody.WriteString(greenStyle.Render("Saved!"))
 	}
 
 	formBody.WriteString("\n")
-	formBody.WriteString(labelStyle.Render("[j/k] navigate  [Enter] edit  [Esc] cancel"))
+	if a.settingsSelecting() {
+		formBody.WriteString(labelStyle.Render("[h/l] choose  [Enter] save  [Esc] cancel"))
+	} else {
+		formBody.WriteString(labelStyle.Render("[j/k] navigate  [Enter] edit  [p] preview themes  [Esc] cancel"))
+	}
 
 	// General info card
 	var infoBody strings.Builder
 	infoBody.WriteString(labelStyle.Render("Data directory:  ") + valueStyle.Render(a.claudeDir) + "\n")
+	for i, r := range a.scanRoots {
+		if i > 0 { // the first root is the data directory
+			infoBody.WriteString(labelStyle.Render("Scan root:       ") + valueStyle.Render(r.Path) + "\n")
+		}
+	}
 	infoBody.WriteString(labelStyle.Render("Sessions loaded: ") + valueStyle.Render(cli.FormatNumber(int64(len(a.sessions)))) + "\n")
 	infoBody.WriteString(labelStyle.Render("Load time:       ") + valueStyle.Render(fmt.Sprintf("%.1fs", a.loadTime.Seconds())) + "\n")
+	if o := a.overhead; o != nil {
+		infoBody.WriteString(labelStyle.Render("Scans today:     ") + valueStyle.Render(formatScanDay(o.Today)) + "\n")
+		infoBody.WriteString(labelStyle.Render("Scans (7d):      ") + valueStyle.Render(formatScanDay(o.Week)) + "\n")
+		infoBody.WriteString(labelStyle.Render("Cache size:      ") + valueStyle.Render(cli.FormatBytes(o.DiskBytes)) + "\n")
+		if o.ParseIssues > 0 {
+			warnStyle := lipgloss.NewStyle().Foreground(t.Orange).Background(t.Surface)
+			infoBody.WriteString(labelStyle.Render("Parse issues:    ") +
+				warnStyle.Render(fmt.Sprintf("%d files (%d quarantined) — see cburn doctor", o.ParseIssues, o.Quarantined)) + "\n")
+		}
+	}
+	infoBody.WriteString(labelStyle.Render("Time zone:       ") + valueStyle.Render(time.Local.String()) + "\n")
 	infoBody.WriteString(labelStyle.Render("Config file:     ") + valueStyle.Render(config.Path()))
 
 	var b strings.Builder
@@ -294,3 +522,13 @@ func (a App) renderSettingsTab(cw int) string {
 
 	return b.String()
 }
+
+// formatScanDay renders scan overhead as "12 scans, 3.4s total (0.28s avg), 40 files parsed".
+func formatScanDay(d store.ScanDay) string {
+	if d.Scans == 0 {
+		return "none"
+	}
+	avg := d.Duration / time.Duration(d.Scans)
+	return fmt.Sprintf("%d scans, %.1fs total (%.2fs avg), %s files parsed",
+		d.Scans, d.Duration.Seconds(), avg.Seconds(), cli.FormatNumber(int64(d.FilesParsed)))
+}
@@ -0,0 +1,114 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/adminapi"
+	"github.com/theirongolddev/cburn/internal/cli"
+	"github.com/theirongolddev/cburn/internal/config"
+	"github.com/theirongolddev/cburn/internal/redact"
+	"github.com/theirongolddev/cburn/internal/tui/components"
+	"github.com/theirongolddev/cburn/internal/tui/theme"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TeamDataMsg is sent when the Admin API team usage fetch completes.
+type TeamDataMsg struct {
+	Users     []adminapi.UserUsage
+	Period    string // label of the range fetched, e.g. "30d"
+	FetchedAt time.Time
+	Err       error
+}
+
+// fetchTeamCmd fetches the organization's per-user Claude Code usage for
+// the TUI's selected range from the Admin API in a background goroutine.
+func (a *App) fetchTeamCmd() tea.Cmd {
+	adminKey := config.GetAdminAPIKey(loadConfigOrDefault())
+	a.teamEnabled = adminKey != ""
+	if !a.teamEnabled {
+		return nil
+	}
+	a.teamFetching = true
+	since, until := a.period(time.Now())
+	period := a.periodLabel()
+	return func() tea.Msg {
+		client := adminapi.NewClient(adminKey)
+		if client == nil {
+			return TeamDataMsg{Period: period, FetchedAt: time.Now(), Err: errors.New("admin API key must start with sk-ant-admin")}
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+		records, err := client.FetchClaudeCodeUsage(ctx, since, until)
+		return TeamDataMsg{Users: adminapi.TeamUsage(records), Period: period, FetchedAt: time.Now(), Err: err}
+	}
+}
+
+func (a App) renderTeamTab(cw int) string {
+	t := theme.Active
+	mutedStyle := lipgloss.NewStyle().Foreground(t.TextMuted).Background(t.Surface)
+
+	switch {
+	case !a.teamEnabled:
+		return components.ContentCard("Team", mutedStyle.Render(
+			"Org-wide usage needs an Admin API key (sk-ant-admin...).\n"+
+				"Add one under Settings, or set ANTHROPIC_ADMIN_KEY."), cw)
+	case a.teamFetching && a.teamFetchedAt.IsZero():
+		return components.ContentCard("Team", mutedStyle.Render("Fetching usage report..."), cw)
+	case a.teamFetchedAt.IsZero():
+		return components.ContentCard("Team", mutedStyle.Render("Press r to fetch the usage report."), cw)
+	case a.teamErr != nil:
+		errStyle := lipgloss.NewStyle().Foreground(t.Red).Background(t.Surface)
+		return components.ContentCard("Team", errStyle.Render("Fetching usage report failed: "+a.teamErr.Error())+
+			"\n"+mutedStyle.Render("Press r to retry."), cw)
+	case len(a.teamUsers) == 0:
+		return components.ContentCard("Team", mutedStyle.Render("No Claude Code usage reported for the organization in this range."), cw)
+	}
+
+	innerW := components.CardInnerWidth(cw)
+	nameW := max(innerW-4-9-8-9-11-10-6, 12)
+
+	headerStyle := lipgloss.NewStyle().Foreground(t.Accent).Background(t.Surface).Bold(true)
+	rowStyle := lipgloss.NewStyle().Foreground(t.TextPrimary).Background(t.Surface)
+	nameStyle := lipgloss.NewStyle().Foreground(t.Cyan).Background(t.Surface)
+	costStyle := lipgloss.NewStyle().Foreground(t.GreenBright).Background(t.Surface)
+
+	var totalCost float64
+	for _, u := range a.teamUsers {
+		totalCost += u.CostUSD
+	}
+
+	var body strings.Builder
+	body.WriteString(headerStyle.Render(fmt.Sprintf("%3s %-*s %8s %7s %8s %10s %9s %5s",
+		"#", nameW, "User", "Sessions", "Days", "Tokens", "Lines", "Cost", "Share")))
+	body.WriteString("\n")
+	body.WriteString(mutedStyle.Render(strings.Repeat("─", innerW)))
+	for i, u := range a.teamUsers {
+		name := redact.User(u.Actor)
+		if u.IsAPIKey {
+			name += " (key)"
+		}
+		share := 0.0
+		if totalCost > 0 {
+			share = u.CostUSD / totalCost * 100
+		}
+		body.WriteString("\n")
+		body.WriteString(mutedStyle.Render(fmt.Sprintf("%3d ", i+1)))
+		body.WriteString(nameStyle.Render(fmt.Sprintf("%-*s", nameW, truncStr(name, nameW))))
+		body.WriteString(rowStyle.Render(fmt.Sprintf(" %8s %7s %8s %10s", cli.FormatNumber(int64(u.Sessions)),
+			cli.FormatNumber(int64(u.ActiveDays)), cli.FormatTokens(u.TotalTokens()),
+			"+"+cli.FormatNumber(int64(u.LinesAdded)))))
+		body.WriteString(costStyle.Render(fmt.Sprintf(" %9s", cli.FormatCost(u.CostUSD))))
+		body.WriteString(rowStyle.Render(fmt.Sprintf(" %4.0f%%", share)))
+	}
+	body.WriteString("\n\n")
+	body.WriteString(mutedStyle.Render(fmt.Sprintf("%d users · %s estimated · fetched %s",
+		len(a.teamUsers), cli.FormatCost(totalCost), a.teamFetchedAt.Format("15:04"))))
+
+	return components.ContentCard("Team  (Admin API, "+a.teamPeriod+")", body.String(), cw)
+}
@@ -141,8 +141,45 @@ var Terminal = Theme{
 	Cyan:          lipgloss.Color("6"),
 }
 
+// HighContrast is white on black with saturated status colors, for low
+// vision. While it is active, severity is also marked with symbols.
+var HighContrast = Theme{
+	Name:          "high-contrast",
+	Background:    lipgloss.Color("#000000"),
+	Surface:       lipgloss.Color("#000000"),
+	SurfaceHover:  lipgloss.Color("#00307A"),
+	SurfaceBright: lipgloss.Color("#00307A"),
+	Border:        lipgloss.Color("#C0C0C0"),
+	BorderBright:  lipgloss.Color("#FFFFFF"),
+	BorderAccent:  lipgloss.Color("#FFFF00"),
+	TextDim:       lipgloss.Color("#C0C0C0"),
+	TextMuted:     lipgloss.Color("#E0E0E0"),
+	TextPrimary:   lipgloss.Color("#FFFFFF"),
+	Accent:        lipgloss.Color("#FFFF00"),
+	AccentBright:  lipgloss.Color("#FFFF80"),
+	AccentDim:     lipgloss.Color("#333300"),
+	Green:         lipgloss.Color("#00FF00"),
+	GreenBright:   lipgloss.Color("#80FF80"),
+	Orange:        lipgloss.Color("#FFA500"),
+	Red:           lipgloss.Color("#FF5050"),
+	Blue:          lipgloss.Color("#40C0FF"),
+	BlueBright:    lipgloss.Color("#A0E0FF"),
+	Yellow:        lipgloss.Color("#FFFF00"),
+	Magenta:       lipgloss.Color("#FF80FF"),
+	Cyan:          lipgloss.Color("#00FFFF"),
+}
+
 // All available themes.
-var All = []Theme{FlexokiDark, CatppuccinMocha, TokyoNight, Terminal}
+var All = []Theme{FlexokiDark, CatppuccinMocha, TokyoNight, Terminal, HighContrast}
+
+// NoColor is set when color output is off (--no-color or NO_COLOR).
+var NoColor bool
+
+// Accessible reports whether status should be marked with symbols rather
+// than told by color alone: with color off or the high-contrast theme.
+func Accessible() bool {
+	return NoColor || Active.Name == HighContrast.Name
+}
 
 // ByName returns a theme by its name, defaulting to FlexokiDark.
 func ByName(name string) Theme {
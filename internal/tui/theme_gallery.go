@@ -0,0 +1,145 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/theirongolddev/cburn/internal/config"
+	"github.com/theirongolddev/cburn/internal/tui/components"
+	"github.com/theirongolddev/cburn/internal/tui/theme"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// galleryCardWidth is the outer width of one theme's mock dashboard.
+const galleryCardWidth = 30
+
+func (a App) openThemeGallery() (tea.Model, tea.Cmd) {
+	a.settings.gallery = true
+	a.settings.galleryCursor = 0
+	for i, t := range theme.All {
+		if t.Name == theme.Active.Name {
+			a.settings.galleryCursor = i
+		}
+	}
+	return a, nil
+}
+
+// updateThemeGallery moves between themes and applies the chosen one.
+// Nothing is saved until Enter.
+func (a App) updateThemeGallery(key string) (tea.Model, tea.Cmd) {
+	n := len(theme.All)
+	switch key {
+	case "h", "left", "k", "up":
+		a.settings.galleryCursor = (a.settings.galleryCursor + n - 1) % n
+	case "l", "right", "j", "down", "tab":
+		a.settings.galleryCursor = (a.settings.galleryCursor + 1) % n
+	case "enter":
+		name := theme.All[a.settings.galleryCursor].Name
+		cfg := loadConfigOrDefault()
+		cfg.Appearance.Theme = name
+		theme.SetActive(name)
+		a.settings.saveErr = config.Save(cfg)
+		a.settings.gallery = false
+		a.noteSettingsSave()
+	case "esc", "p", "q":
+		a.settings.gallery = false
+	}
+	return a, nil
+}
+
+// renderThemeGallery lays out a mock dashboard in every theme, as many
+// per row as fit, with the highlighted one marked.
+func (a App) renderThemeGallery(cw int) string {
+	t := theme.Active
+	perRow := max((components.CardInnerWidth(cw)+2)/(galleryCardWidth+2), 1)
+
+	var rows []string
+	var row []string
+	for i, th := range theme.All {
+		row = append(row, renderThemeMock(th, i == a.settings.galleryCursor))
+		if len(row) == perRow || i == len(theme.All)-1 {
+			rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, joinWithGap(row, t)...))
+			row = nil
+		}
+	}
+
+	hintStyle := lipgloss.NewStyle().Foreground(t.TextMuted).Background(t.Surface)
+	body := strings.Join(rows, "\n") + "\n\n" +
+		hintStyle.Render("[h/l] choose  [Enter] use theme  [Esc] close")
+	return components.ContentCard("Themes", body, cw)
+}
+
+// joinWithGap puts a two-column gap between gallery cards.
+func joinWithGap(cards []string, t theme.Theme) []string {
+	out := make([]string, 0, 2*len(cards))
+	for i, c := range cards {
+		if i > 0 {
+			gap := lipgloss.NewStyle().Background(t.Surface).
+				Render(strings.Repeat("  \n", lipgloss.Height(c)-1) + "  ")
+			out = append(out, gap)
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// renderThemeMock draws a miniature dashboard in th's colors: a tab bar,
+// a metric row, a bar chart, and a status line.
+func renderThemeMock(th theme.Theme, selected bool) string {
+	inner := galleryCardWidth - 4
+	bg := lipgloss.NewStyle().Background(th.Surface)
+	line := func(parts ...string) string {
+		s := strings.Join(parts, "")
+		if pad := inner - lipgloss.Width(s); pad > 0 {
+			s += bg.Render(strings.Repeat(" ", pad))
+		}
+		return s
+	}
+	fg := func(c lipgloss.Color) lipgloss.Style { return bg.Foreground(c) }
+
+	activeTab := lipgloss.NewStyle().Foreground(th.AccentBright).Background(th.SurfaceHover).Bold(true)
+	barChars := []struct {
+		h int
+		c lipgloss.Color
+	}{{2, th.Accent}, {3, th.Accent}, {1, th.Accent}, {4, th.Green}, {3, th.Blue}, {2, th.Orange}, {4, th.Red}, {3, th.Magenta}}
+
+	lines := []string{
+		line(activeTab.Render(" Overview "), fg(th.TextDim).Render(" Costs  Sessions")),
+		line(fg(th.TextMuted).Render("Cost  "), fg(th.TextPrimary).Bold(true).Render("$42.17"),
+			fg(th.TextMuted).Render("  Calls "), fg(th.Cyan).Render("1,204")),
+		line(fg(th.TextMuted).Render("Cache "), fg(th.Green).Render("87%"),
+			fg(th.TextMuted).Render("    Trend "), fg(th.Red).Render("▲12%")),
+	}
+	for level := 4; level >= 1; level-- {
+		var b strings.Builder
+		b.WriteString(bg.Render(" "))
+		for _, bar := range barChars {
+			if bar.h >= level {
+				b.WriteString(fg(bar.c).Render("██"))
+			} else {
+				b.WriteString(bg.Render("  "))
+			}
+			b.WriteString(bg.Render(" "))
+		}
+		lines = append(lines, line(b.String()))
+	}
+	lines = append(lines, line(fg(th.Yellow).Render("● "), fg(th.TextMuted).Render("2 active"),
+		fg(th.TextDim).Render("  auto 30s")))
+
+	border := th.Border
+	title := fg(th.TextMuted).Render(th.Name)
+	if selected {
+		border = th.BorderAccent
+		title = fg(th.AccentBright).Bold(true).Render("▸ " + th.Name)
+	}
+	lines = append([]string{line(title), line()}, lines...)
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(border).
+		BorderBackground(th.Surface).
+		Background(th.Surface).
+		Padding(0, 1).
+		Render(strings.Join(lines, "\n"))
+}
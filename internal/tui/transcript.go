@@ -0,0 +1,132 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/theirongolddev/cburn/internal/redact"
+	"github.com/theirongolddev/cburn/internal/source"
+	"github.com/theirongolddev/cburn/internal/tui/components"
+	"github.com/theirongolddev/cburn/internal/tui/theme"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// promptPreviewChars is how much of each prompt the transcript preview shows.
+const promptPreviewChars = 200
+
+// TranscriptMsg delivers the prompts read for a session's transcript preview.
+type TranscriptMsg struct {
+	SessionID string
+	Prompts   []source.Prompt
+	Err       error
+}
+
+// sessionTranscript is the transcript preview shown in a session's detail.
+type sessionTranscript struct {
+	sessionID string
+	prompts   []source.Prompt
+	loading   bool
+}
+
+// toggleTranscript shows or hides the selected session's prompts. Prompt
+// text can hold anything the user pasted, so it is never shown in privacy
+// mode, and only after a y/n confirmation the first time each run.
+func (a App) toggleTranscript() (tea.Model, tea.Cmd) {
+	sel := a.selectedSessions()
+	if len(sel) == 0 {
+		return a, nil
+	}
+	if tr := a.sessState.transcript; tr != nil && tr.sessionID == sel[0].SessionID {
+		a.sessState.transcript = nil
+		return a, nil
+	}
+	if redact.Enabled() {
+		a.toast(components.ToastWarn, "Prompt previews are off in privacy mode (P to turn it off)")
+		return a, nil
+	}
+	if !a.sessState.transcriptOK {
+		a.sessState.transcriptAsk = true
+		a.toast(components.ToastWarn, "Show this session's prompt text? It may contain secrets. [y] yes  any other key cancels")
+		return a, nil
+	}
+	return a.loadTranscript()
+}
+
+// confirmTranscript answers the confirmation asked by toggleTranscript.
+func (a App) confirmTranscript(key string) (tea.Model, tea.Cmd) {
+	a.sessState.transcriptAsk = false
+	if key != "y" {
+		return a, nil
+	}
+	a.sessState.transcriptOK = true
+	return a.loadTranscript()
+}
+
+// loadTranscript reads the selected session's prompts in the background.
+func (a App) loadTranscript() (tea.Model, tea.Cmd) {
+	sel := a.selectedSessions()
+	path := a.sessionFilePath()
+	if len(sel) == 0 || path == "" {
+		return a, nil
+	}
+	id := sel[0].SessionID
+	a.sessState.transcript = &sessionTranscript{sessionID: id, loading: true}
+	return a, func() tea.Msg {
+		prompts, err := source.ReadPrompts(path, promptPreviewChars)
+		return TranscriptMsg{SessionID: id, Prompts: prompts, Err: err}
+	}
+}
+
+// renderTranscript renders the PROMPTS section of the detail pane for sel,
+// or "" when its transcript isn't open.
+func (a App) renderTranscript(sessionID string, innerW int) string {
+	tr := a.sessState.transcript
+	if tr == nil || tr.sessionID != sessionID || redact.Enabled() {
+		return ""
+	}
+	t := theme.Active
+	sectionStyle := lipgloss.NewStyle().Foreground(t.AccentBright).Background(t.Surface).Bold(true)
+	timeStyle := lipgloss.NewStyle().Foreground(t.Magenta).Background(t.Surface)
+	textStyle := lipgloss.NewStyle().Foreground(t.TextPrimary).Background(t.Surface)
+	dimStyle := lipgloss.NewStyle().Foreground(t.TextDim).Background(t.Surface)
+
+	var b strings.Builder
+	b.WriteString("\n")
+	if tr.loading {
+		b.WriteString(sectionStyle.Render("PROMPTS"))
+		b.WriteString("\n")
+		b.WriteString(dimStyle.Render("Reading session file..."))
+		b.WriteString("\n")
+		return b.String()
+	}
+	b.WriteString(sectionStyle.Render(fmt.Sprintf("PROMPTS (%d)", len(tr.prompts))))
+	b.WriteString(dimStyle.Render("  p to hide"))
+	b.WriteString("\n")
+	if len(tr.prompts) == 0 {
+		b.WriteString(dimStyle.Render("No typed prompts in this session"))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	const stampW = 6 // "15:04 "
+	textW := max(innerW-stampW, 10)
+	for _, p := range tr.prompts {
+		stamp := strings.Repeat(" ", stampW)
+		if !p.Time.IsZero() {
+			stamp = p.Time.Local().Format("15:04") + " "
+		}
+		for i, line := range strings.Split(ansi.Wrap(p.Text, textW, ""), "\n") {
+			if i == 0 {
+				b.WriteString(timeStyle.Render(stamp))
+			} else {
+				b.WriteString(dimStyle.Render(strings.Repeat(" ", stampW)))
+			}
+			b.WriteString(textStyle.Render(line))
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,40 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/theirongolddev/cburn/internal/model"
+)
+
+func TestToggleTranscriptAsksFirst(t *testing.T) {
+	a := App{
+		filtered: []model.SessionStats{{SessionID: "s1"}},
+		sessions: []model.SessionStats{{SessionID: "s1", FilePath: "/tmp/s1.jsonl"}},
+	}
+
+	m, cmd := a.toggleTranscript()
+	a = m.(App)
+	if !a.sessState.transcriptAsk || cmd != nil || a.sessState.transcript != nil {
+		t.Fatal("first p should ask before reading any prompt text")
+	}
+	m, _ = a.confirmTranscript("n")
+	if a = m.(App); a.sessState.transcriptOK || a.sessState.transcript != nil {
+		t.Fatal("declining should show nothing")
+	}
+
+	a.sessState.transcriptAsk = true
+	m, cmd = a.confirmTranscript("y")
+	a = m.(App)
+	if !a.sessState.transcriptOK || cmd == nil || a.sessState.transcript == nil || !a.sessState.transcript.loading {
+		t.Fatal("confirming should start reading the prompts")
+	}
+
+	m, _ = a.toggleTranscript()
+	if a = m.(App); a.sessState.transcript != nil {
+		t.Error("p again should hide the preview")
+	}
+	m, cmd = a.toggleTranscript()
+	if a = m.(App); a.sessState.transcriptAsk || cmd == nil {
+		t.Error("once confirmed, p should not ask again")
+	}
+}
@@ -0,0 +1,170 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/config"
+	"github.com/theirongolddev/cburn/internal/tui/components"
+	"github.com/theirongolddev/cburn/internal/tui/theme"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// viewPicker is the quick switcher over the saved [[views]] in config,
+// shown in the filter row. Entry 0 clears the filters; entry i is views[i-1].
+type viewPicker struct {
+	open   bool
+	views  []config.View
+	cursor int
+
+	// naming is true while typing the name to save the current filters as
+	naming bool
+	input  textinput.Model
+}
+
+// WithView starts the dashboard in the named view. Its days, project, and
+// model already came in through NewApp; this adds its Sessions search.
+func (a App) WithView(v config.View) (App, error) {
+	if _, err := parseSessionSearch(v.Search); err != nil {
+		return a, err
+	}
+	a.viewName = v.Name
+	a.sessState.searchQuery = strings.TrimSpace(v.Search)
+	return a, nil
+}
+
+// openViewPicker shows the saved views, starting on the current one.
+func (a App) openViewPicker() (tea.Model, tea.Cmd) {
+	a.viewPicker = viewPicker{open: true, views: loadConfigOrDefault().Views}
+	for i, v := range a.viewPicker.views {
+		if strings.EqualFold(v.Name, a.viewName) {
+			a.viewPicker.cursor = i + 1
+		}
+	}
+	return a, nil
+}
+
+// updateViewPicker handles keys while the view picker is open.
+func (a App) updateViewPicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	vp := &a.viewPicker
+	if vp.naming {
+		return a.updateViewName(msg)
+	}
+
+	switch msg.String() {
+	case "left", "h", "shift+tab":
+		vp.cursor = (vp.cursor + len(vp.views)) % (len(vp.views) + 1)
+	case "right", "l", "tab":
+		vp.cursor = (vp.cursor + 1) % (len(vp.views) + 1)
+	case "n":
+		vp.naming = true
+		vp.input = textinput.New()
+		vp.input.Prompt = "Save view as: "
+		vp.input.CharLimit = 32
+		vp.input.Width = 32
+		vp.input.SetValue(a.viewName)
+		vp.input.Focus()
+		return a, vp.input.Cursor.BlinkCmd()
+	case "enter":
+		vp.open = false
+		if vp.cursor == 0 {
+			a.applyView(config.View{})
+			a.toast(components.ToastInfo, "Filters cleared")
+		} else {
+			v := vp.views[vp.cursor-1]
+			a.applyView(v)
+			a.toast(components.ToastInfo, "View "+v.Name)
+		}
+	case "esc", "v":
+		vp.open = false
+	}
+	return a, nil
+}
+
+// updateViewName handles keys while naming a new view.
+func (a App) updateViewName(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	vp := &a.viewPicker
+	switch msg.String() {
+	case "enter":
+		name := strings.TrimSpace(vp.input.Value())
+		if name == "" {
+			return a, nil
+		}
+		v := config.View{
+			Name:    name,
+			Days:    a.days,
+			Project: a.project,
+			Model:   a.modelFilter,
+			Search:  a.sessState.searchQuery,
+		}
+		cfg := loadConfigOrDefault()
+		cfg.SaveView(v)
+		vp.open, vp.naming = false, false
+		if err := config.Save(cfg); err != nil {
+			a.toast(components.ToastError, "Saving view failed: "+err.Error())
+			return a, nil
+		}
+		a.viewName = name
+		a.toast(components.ToastInfo, "Saved view "+name)
+		return a, nil
+	case "esc":
+		vp.naming = false
+		return a, nil
+	}
+
+	var cmd tea.Cmd
+	vp.input, cmd = vp.input.Update(msg)
+	return a, cmd
+}
+
+// applyView switches every filter to v's. A view without days keeps the
+// current window; the zero View clears project, model, and search.
+func (a *App) applyView(v config.View) {
+	if _, err := parseSessionSearch(v.Search); err != nil {
+		a.toast(components.ToastError, "View "+v.Name+" search: "+err.Error())
+		v.Search = ""
+	}
+	if v.Days > 0 {
+		a.days = v.Days
+		a.rangeSince, a.rangeUntil = time.Time{}, time.Time{}
+	}
+	a.project, a.modelFilter = v.Project, v.Model
+	a.sessState.searchQuery = strings.TrimSpace(v.Search)
+	a.sessState.cursor, a.sessState.offset = 0, 0
+	a.viewName = v.Name
+	a.recompute()
+}
+
+// renderViewPicker is the filter row while the picker is open.
+func (a App) renderViewPicker() string {
+	t := theme.Active
+	dim := lipgloss.NewStyle().Foreground(t.TextDim).Background(t.Surface)
+	accent := lipgloss.NewStyle().Foreground(t.Accent).Background(t.Surface).Bold(true)
+
+	vp := a.viewPicker
+	if vp.naming {
+		return dim.Render(" ") + vp.input.View()
+	}
+
+	var b strings.Builder
+	b.WriteString(dim.Render(" View: "))
+	names := []string{"all"}
+	for _, v := range vp.views {
+		names = append(names, v.Name)
+	}
+	for i, name := range names {
+		if i > 0 {
+			b.WriteString(dim.Render(" │ "))
+		}
+		if i == vp.cursor {
+			b.WriteString(accent.Render("[" + name + "]"))
+		} else {
+			b.WriteString(dim.Render(name))
+		}
+	}
+	b.WriteString(dim.Render("   ←/→ pick · enter apply · n save current · esc"))
+	return b.String()
+}
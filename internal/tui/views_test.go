@@ -0,0 +1,41 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/theirongolddev/cburn/internal/config"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestViewPicker(t *testing.T) {
+	a := App{days: 30, project: "old"}
+	a.viewPicker = viewPicker{open: true, views: []config.View{
+		{Name: "work", Days: 7, Project: "api", Search: "cost>5"},
+		{Name: "opus", Model: "opus"},
+	}}
+
+	press := func(key tea.KeyMsg) {
+		m, _ := a.updateViewPicker(key)
+		a = m.(App)
+	}
+	press(tea.KeyMsg{Type: tea.KeyLeft}) // wraps from "all" to the last view
+	if a.viewPicker.cursor != 2 {
+		t.Fatalf("cursor = %d, want 2", a.viewPicker.cursor)
+	}
+	press(tea.KeyMsg{Type: tea.KeyLeft})
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if a.viewPicker.open || a.viewName != "work" || a.days != 7 || a.project != "api" ||
+		a.modelFilter != "" || a.sessState.searchQuery != "cost>5" {
+		t.Errorf("after applying work: view=%q days=%d project=%q model=%q search=%q",
+			a.viewName, a.days, a.project, a.modelFilter, a.sessState.searchQuery)
+	}
+
+	a.viewPicker.open, a.viewPicker.cursor = true, 0
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	if a.viewName != "" || a.days != 7 || a.project != "" || a.sessState.searchQuery != "" {
+		t.Errorf("all should clear filters but keep the window: days=%d project=%q search=%q",
+			a.days, a.project, a.sessState.searchQuery)
+	}
+}
@@ -0,0 +1,139 @@
+// Package usagelog records which cburn commands and TUI tabs are used.
+//
+// The log is opt-in (config `[analytics] enabled = true`), stored only on
+// the local machine, and never transmitted anywhere.
+package usagelog
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/config"
+)
+
+// Event kinds.
+const (
+	KindCommand = "command"
+	KindTab     = "tab"
+)
+
+// Event is one recorded use of a command or tab.
+type Event struct {
+	Time time.Time `json:"ts"`
+	Kind string    `json:"kind"`
+	Name string    `json:"name"`
+}
+
+// Count summarizes uses of a single command or tab.
+type Count struct {
+	Kind     string
+	Name     string
+	Uses     int
+	LastUsed time.Time
+}
+
+// Path returns the location of the usage log.
+func Path() string {
+	return filepath.Join(config.Dir(), "usage.jsonl")
+}
+
+// Record appends an event to the usage log.
+func Record(kind, name string) error {
+	if err := os.MkdirAll(config.Dir(), 0o750); err != nil {
+		return fmt.Errorf("creating config dir: %w", err)
+	}
+
+	line, err := json.Marshal(Event{Time: time.Now().UTC(), Kind: kind, Name: name})
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(Path(), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("opening usage log: %w", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("writing usage log: %w", err)
+	}
+	return f.Close()
+}
+
+// Load reads all events from the usage log. A missing log yields no events.
+// Malformed lines are skipped.
+func Load() ([]Event, error) {
+	f, err := os.Open(Path())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("opening usage log: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var events []Event
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var ev Event
+		if err := json.Unmarshal(scanner.Bytes(), &ev); err != nil {
+			continue
+		}
+		events = append(events, ev)
+	}
+	if err := scanner.Err(); err != nil {
+		return events, fmt.Errorf("reading usage log: %w", err)
+	}
+	return events, nil
+}
+
+// Clear deletes the usage log.
+func Clear() error {
+	if err := os.Remove(Path()); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("removing usage log: %w", err)
+	}
+	return nil
+}
+
+// Summarize counts uses per (kind, name), most used first.
+// Events before since are ignored when since is non-zero.
+func Summarize(events []Event, since time.Time) []Count {
+	type key struct{ kind, name string }
+	counts := make(map[key]*Count)
+
+	for _, ev := range events {
+		if !since.IsZero() && ev.Time.Before(since) {
+			continue
+		}
+		k := key{ev.Kind, ev.Name}
+		c, ok := counts[k]
+		if !ok {
+			c = &Count{Kind: ev.Kind, Name: ev.Name}
+			counts[k] = c
+		}
+		c.Uses++
+		if ev.Time.After(c.LastUsed) {
+			c.LastUsed = ev.Time
+		}
+	}
+
+	result := make([]Count, 0, len(counts))
+	for _, c := range counts {
+		result = append(result, *c)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Uses != result[j].Uses {
+			return result[i].Uses > result[j].Uses
+		}
+		if result[i].Kind != result[j].Kind {
+			return result[i].Kind < result[j].Kind
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
@@ -0,0 +1,48 @@
+package usagelog
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecordAndSummarize(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	for _, ev := range []struct{ kind, name string }{
+		{KindCommand, "tui"},
+		{KindTab, "Costs"},
+		{KindTab, "Costs"},
+		{KindCommand, "daily"},
+	} {
+		if err := Record(ev.kind, ev.name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	events, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 4 {
+		t.Fatalf("loaded %d events, want 4", len(events))
+	}
+
+	counts := Summarize(events, time.Time{})
+	if len(counts) != 3 {
+		t.Fatalf("got %d counts, want 3", len(counts))
+	}
+	if counts[0].Kind != KindTab || counts[0].Name != "Costs" || counts[0].Uses != 2 {
+		t.Errorf("top count = %+v, want tab Costs x2", counts[0])
+	}
+
+	if got := Summarize(events, time.Now().Add(time.Hour)); len(got) != 0 {
+		t.Errorf("future cutoff returned %d counts, want 0", len(got))
+	}
+
+	if err := Clear(); err != nil {
+		t.Fatal(err)
+	}
+	if events, _ := Load(); len(events) != 0 {
+		t.Errorf("after Clear, loaded %d events", len(events))
+	}
+}
@@ -1,7 +1,11 @@
 // cburn analyzes Claude Code usage from local JSONL session logs.
 package main
 
-import "github.com/theirongolddev/cburn/cmd"
+import (
+	"github.com/theirongolddev/cburn/cmd"
+
+	_ "time/tzdata" // --tz and general.timezone work without system zoneinfo
+)
 
 func main() {
 	cmd.Execute()
@@ -0,0 +1,185 @@
+// Package client is a Go client for the HTTP API of `cburn daemon`,
+// described by the OpenAPI document at /v1/openapi.json.
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/daemon"
+)
+
+// The API's response types.
+type (
+	// Status is the daemon's state and current usage snapshot.
+	Status = daemon.Status
+	// Snapshot is aggregate usage over the daemon's window.
+	Snapshot = daemon.Snapshot
+	// Delta is the change in usage between two polls.
+	Delta = daemon.Delta
+	// Event is a snapshot, usage change, session start or end, or cost
+	// anomaly pushed by the daemon.
+	Event = daemon.Event
+	// SessionEvent is the session a session_started or session_ended
+	// event is about.
+	SessionEvent = daemon.SessionEvent
+	// AnomalyEvent is the day of spend an anomaly event is about.
+	AnomalyEvent = daemon.AnomalyEvent
+)
+
+// DefaultAddr is where the daemon listens unless told otherwise.
+const DefaultAddr = "127.0.0.1:8787"
+
+// Client talks to one daemon.
+type Client struct {
+	baseURL string
+	http    *http.Client
+}
+
+// New returns a client for the daemon at addr, either host:port or a full
+// http(s) URL. An empty addr means DefaultAddr. httpClient may be nil; it
+// is used for every request except Stream, which must not time out.
+func New(addr string, httpClient *http.Client) *Client {
+	if addr == "" {
+		addr = DefaultAddr
+	}
+	if !strings.Contains(addr, "://") {
+		addr = "http://" + addr
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &Client{baseURL: strings.TrimRight(addr, "/"), http: httpClient}
+}
+
+// HTTPError is returned when the daemon answers with a status other than 200.
+type HTTPError struct {
+	Path       string
+	StatusCode int
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("GET %s: HTTP %d", e.Path, e.StatusCode)
+}
+
+// Health reports whether the daemon is up.
+func (c *Client) Health(ctx context.Context) error {
+	resp, err := c.get(ctx, c.http, "/healthz", nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// Status returns the daemon's state and current snapshot.
+func (c *Client) Status(ctx context.Context) (Status, error) {
+	var st Status
+	err := c.getJSON(ctx, "/v1/status", &st)
+	return st, err
+}
+
+// Events returns the daemon's buffered recent events, oldest first.
+func (c *Client) Events(ctx context.Context) ([]Event, error) {
+	var events []Event
+	err := c.getJSON(ctx, "/v1/events", &events)
+	return events, err
+}
+
+// OpenAPI returns the daemon's OpenAPI description as JSON.
+func (c *Client) OpenAPI(ctx context.Context) ([]byte, error) {
+	resp, err := c.get(ctx, c.http, "/v1/openapi.json", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	return io.ReadAll(resp.Body)
+}
+
+// Stream subscribes to the event stream and calls fn with each event until
+// ctx is canceled, the connection drops, or fn returns an error, which
+// Stream then returns. Passing the ID of the last event seen as lastEventID
+// resumes without gaps while the daemon still buffers it; 0 starts with a
+// snapshot. Stream does not reconnect by itself.
+func (c *Client) Stream(ctx context.Context, lastEventID int64, fn func(Event) error) error {
+	var header http.Header
+	if lastEventID > 0 {
+		header = http.Header{"Last-Event-ID": {strconv.FormatInt(lastEventID, 10)}}
+	}
+	// Same transport, no overall timeout: the response never ends
+	streamer := &http.Client{Transport: c.http.Transport}
+	resp, err := c.get(ctx, streamer, "/v1/stream", header)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	sc := bufio.NewScanner(resp.Body)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	var data strings.Builder
+	for sc.Scan() {
+		line := sc.Text()
+		switch {
+		case line == "":
+			if data.Len() == 0 {
+				continue
+			}
+			var ev Event
+			if err := json.Unmarshal([]byte(data.String()), &ev); err != nil {
+				return fmt.Errorf("decoding event: %w", err)
+			}
+			data.Reset()
+			if err := fn(ev); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+		// id:, event:, retry:, and comments repeat what the data carries
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return sc.Err()
+}
+
+func (c *Client) getJSON(ctx context.Context, path string, v any) error {
+	resp, err := c.get(ctx, c.http, path, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("decoding %s: %w", path, err)
+	}
+	return nil
+}
+
+// get issues a GET and returns the response when it is a 200.
+func (c *Client) get(ctx context.Context, hc *http.Client, path string, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		return nil, &HTTPError{Path: path, StatusCode: resp.StatusCode}
+	}
+	return resp, nil
+}
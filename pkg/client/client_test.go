@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/theirongolddev/cburn/internal/daemon"
+)
+
+func TestClientAgainstDaemon(t *testing.T) {
+	srv := httptest.NewServer(daemon.New(daemon.Config{DataDir: t.TempDir(), Days: 7}).Handler())
+	defer srv.Close()
+	c := New(srv.URL, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := c.Health(ctx); err != nil {
+		t.Fatalf("Health: %v", err)
+	}
+	st, err := c.Status(ctx)
+	if err != nil || st.Days != 7 {
+		t.Fatalf("Status = %+v, %v", st, err)
+	}
+	if events, err := c.Events(ctx); err != nil || len(events) != 0 {
+		t.Fatalf("Events = %v, %v", events, err)
+	}
+
+	spec, err := c.OpenAPI(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Info  struct{ Version string }
+		Paths map[string]any
+	}
+	if err := json.Unmarshal(spec, &doc); err != nil || doc.Info.Version != daemon.APIVersion || doc.Paths["/v1/stream"] == nil {
+		t.Fatalf("OpenAPI = %s, %v", spec, err)
+	}
+
+	// A new subscriber starts with a snapshot
+	errStop := errors.New("stop")
+	var got Event
+	err = c.Stream(ctx, 0, func(ev Event) error {
+		got = ev
+		return errStop
+	})
+	if !errors.Is(err, errStop) || got.Type != "snapshot" {
+		t.Fatalf("Stream = %+v, %v", got, err)
+	}
+
+	var httpErr *HTTPError
+	if err := New(srv.URL+"/nope", nil).Health(ctx); !errors.As(err, &httpErr) || httpErr.StatusCode != 404 {
+		t.Fatalf("Health on a bad base URL = %v", err)
+	}
+}
@@ -0,0 +1,35 @@
+package plugin_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/theirongolddev/cburn/pkg/plugin"
+)
+
+// reviewShare tracks how much of the spend went to sessions on review
+// branches.
+type reviewShare struct{ review, total float64 }
+
+func (m *reviewShare) Add(s plugin.Session) {
+	m.total += s.EstimatedCost
+	if strings.HasPrefix(s.GitBranch, "review/") {
+		m.review += s.EstimatedCost
+	}
+}
+
+func (m *reviewShare) Card() plugin.Card {
+	share := "n/a"
+	if m.total > 0 {
+		share = fmt.Sprintf("%.1f%%", m.review/m.total*100)
+	}
+	return plugin.Card{
+		Title: "Code review",
+		Rows:  []plugin.Row{{Label: "Share of spend", Value: share}},
+	}
+}
+
+func ExampleRegister() {
+	plugin.Register(func() plugin.Metric { return &reviewShare{} })
+	// then cmd.Execute()
+}
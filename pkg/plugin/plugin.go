@@ -0,0 +1,45 @@
+// Package plugin adds custom metrics to a cburn build. A metric sees every
+// session of the selected period and reports a card of labeled values,
+// shown on the dashboard's Overview tab and by `cburn summary`.
+//
+// Metrics are registered at build time, from a main package that wraps
+// cburn's:
+//
+//	package main
+//
+//	import (
+//		"github.com/theirongolddev/cburn/cmd"
+//		"github.com/theirongolddev/cburn/pkg/plugin"
+//	)
+//
+//	func main() {
+//		plugin.Register(func() plugin.Metric { return &reviewShare{} })
+//		cmd.Execute()
+//	}
+package plugin
+
+import (
+	"github.com/theirongolddev/cburn/internal/model"
+	"github.com/theirongolddev/cburn/internal/pipeline"
+)
+
+// The types a metric works with.
+type (
+	// Metric is a custom KPI: Add is called with each session in the
+	// period, then Card reports the result.
+	Metric = pipeline.Metric
+	// Session is one Claude Code session's aggregated usage.
+	Session = model.SessionStats
+	// ModelUsage is a session's usage of one model.
+	ModelUsage = model.ModelUsage
+	// Card is a metric's titled list of results.
+	Card = model.MetricCard
+	// Row is one labeled value on a Card.
+	Row = model.MetricRow
+)
+
+// Register adds a metric. newMetric is called each time the metric is
+// computed, so every computation starts from fresh totals.
+func Register(newMetric func() Metric) {
+	pipeline.RegisterMetric(newMetric)
+}